@@ -1,101 +1,108 @@
 package main
 
 import (
-	"database/sql"
 	"os"
-	"os/signal"
-	"syscall"
 
-	"github.com/75-hard-discord-bot/internal/bot"
+	"github.com/spf13/cobra"
 	"github.com/75-hard-discord-bot/internal/config"
 	"github.com/75-hard-discord-bot/internal/database"
+	"github.com/75-hard-discord-bot/internal/errreport"
 	"github.com/75-hard-discord-bot/internal/logger"
-	"github.com/75-hard-discord-bot/internal/services"
 )
 
-func main() {
-	// Initialize logger
-	logLevel := logger.GetLogLevelFromEnv()
-	devMode := logger.GetDevModeFromEnv()
-	logger.Init(logLevel, devMode)
-
-	// Load configuration
-	logger.Info("Loading configuration...")
-	cfg, err := config.Load()
-	if err != nil {
-		logger.Fatal("Failed to load configuration: %v", err)
-	}
+// cfg is loaded once flags are parsed, before any subcommand runs
+var cfg *config.Config
 
-	// Initialize database connection (optional - app can run without DB)
-	logger.Info("🔌 Initializing database connection...")
-	var db *sql.DB
-	if cfg.Database != nil {
-		dbConfig := &database.Config{
-			Host:     cfg.Database.Host,
-			Port:     cfg.Database.Port,
-			User:     cfg.Database.User,
-			Password: cfg.Database.Password,
-			DBName:   cfg.Database.DBName,
-			SSLMode:  cfg.Database.SSLMode,
-		}
-		db, err = database.Connect(dbConfig)
-		if err != nil {
-			logger.Fatal("❌ Failed to connect to database: %v", err)
-		}
-		logger.Info("✅ Database connected and migrations applied")
-		defer db.Close()
-	} else {
-		logger.Info("⚠️  No database configured - database features will be unavailable")
+func main() {
+	// Local development: fill in unset variables from .env before anything reads them
+	dotEnvCount, dotEnvErr := config.LoadDotEnv(".env")
+
+	var configPath string
+	root := &cobra.Command{
+		Use:   "bot",
+		Short: "75 Half Chub Discord bot",
+		Long:  "Runs the 75 Half Chub Discord bot. With no subcommand, starts the bot (same as serve).",
+		// Flag errors are reported by cobra; runtime errors are logged by each command
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logger.Init(logger.GetLogLevelFromEnv(), logger.GetDevModeFromEnv())
+			fileOptions, err := logger.GetFileOptionsFromEnv()
+			if err != nil {
+				logger.Fatal("Invalid log file settings: %v", err)
+			}
+			if err := logger.EnableFileOutput(fileOptions); err != nil {
+				logger.Fatal("Failed to open log file: %v", err)
+			}
+			if fileOptions.Path != "" {
+				logger.Info("Also logging to %s (rotating at %d MB or %s, keeping %d)", fileOptions.Path,
+					fileOptions.MaxSize/(1024*1024), fileOptions.MaxAge, fileOptions.MaxBackups)
+			}
+
+			if dotEnvErr != nil {
+				logger.Fatal("Failed to load .env: %v", dotEnvErr)
+			}
+			if dotEnvCount > 0 {
+				logger.Info("Loaded %d variables from .env (existing environment variables were kept)", dotEnvCount)
+			}
+
+			logger.Info("Loading configuration...")
+			cfg, err = config.Load(configPath)
+			if err != nil {
+				logger.Fatal("Failed to load configuration: %v", err)
+			}
+			if cfg.File != "" {
+				logger.Info("Loaded config file %s (environment variables take precedence)", cfg.File)
+			}
+
+			if cfg.ErrorReporting != nil {
+				reporter, err := errreport.NewSentryReporter(cfg.ErrorReporting.DSN, cfg.ErrorReporting.Environment)
+				if err != nil {
+					logger.Fatal("Failed to set up error reporting: %v", err)
+				}
+				logger.SetErrorHook(reporter)
+				logger.Info("Reporting errors to Sentry (environment: %s)", cfg.ErrorReporting.Environment)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			runServe(cfg)
+		},
 	}
+	root.PersistentFlags().StringVar(&configPath, "config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file (environment variables take precedence)")
 
-	// Create service registry
-	serviceRegistry := services.NewServiceRegistry()
-
-	// Create and register services
-	userService := services.NewUserService()
-	serviceRegistry.Register(userService)
-
-	checkInService := services.NewCheckInService(userService)
-	serviceRegistry.Register(checkInService)
-
-	exerciseService := services.NewExerciseService(userService)
-	serviceRegistry.Register(exerciseService)
+	root.AddCommand(
+		serveCommand(),
+		migrateCommand(),
+		registerCommandsCommand(),
+		seedCommand(),
+	)
 
-	weighInService := services.NewWeighInService(userService)
-	serviceRegistry.Register(weighInService)
-
-	waterService := services.NewWaterService(userService)
-	serviceRegistry.Register(waterService)
-
-	summaryService := services.NewSummaryService()
-	serviceRegistry.Register(summaryService)
-
-	// Initialize all services
-	if db != nil {
-		logger.Info("Initializing services...")
-		if err := serviceRegistry.InitializeAll(db); err != nil {
-			logger.Fatal("Failed to initialize services: %v", err)
-		}
-		logger.Info("✅ All services initialized")
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
 	}
+}
 
-	// Create and start bot
-	logger.Info("Creating bot instance...")
-	discordBot, err := bot.NewBot(cfg, db, serviceRegistry)
-	if err != nil {
-		logger.Fatal("Failed to create bot: %v", err)
+// serveCommand starts the bot
+func serveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Connect to Discord and run the bot",
+		Run: func(cmd *cobra.Command, args []string) {
+			runServe(cfg)
+		},
 	}
+}
 
-	// Start bot
-	if err := discordBot.Start(); err != nil {
-		logger.Fatal("Failed to start bot: %v", err)
+// databaseConfig converts the loaded database settings for database.Connect
+func databaseConfig(dbCfg *config.DatabaseConfig) *database.Config {
+	return &database.Config{
+		Host:     dbCfg.Host,
+		Port:     dbCfg.Port,
+		User:     dbCfg.User,
+		Password: dbCfg.Password,
+		DBName:   dbCfg.DBName,
+		SSLMode:  dbCfg.SSLMode,
+
+		MigrationsDryRun: dbCfg.MigrationsDryRun,
 	}
-	defer discordBot.Stop()
-
-	// Wait for interrupt signal
-	sc := make(chan os.Signal, 1)
-	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-	<-sc
-
-	logger.Info("\nShutting down...")
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/75-hard-discord-bot/internal/database"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// migrateCommand applies pending migrations without starting the bot
+func migrateCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending database migrations and exit",
+		Run: func(cmd *cobra.Command, args []string) {
+			if cfg.Database == nil {
+				logger.Fatal("No database configured - set DB_HOST and DB_PASSWORD")
+			}
+			if dryRun {
+				cfg.Database.MigrationsDryRun = true
+			}
+
+			// Connect runs migrations (or prints them in dry-run mode)
+			db, err := database.Connect(databaseConfig(cfg.Database))
+			if err != nil {
+				logger.Fatal("❌ Migration failed: %v", err)
+			}
+			db.Close()
+
+			if cfg.Database.MigrationsDryRun {
+				logger.Info("✅ Migration dry run complete - no changes were applied")
+				return
+			}
+			logger.Info("✅ Migrations applied")
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print pending migration statements without applying them (same as MIGRATIONS_DRY_RUN)")
+	return cmd
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/75-hard-discord-bot/internal/bot"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// registerCommandsCommand syncs slash commands without starting the bot
+func registerCommandsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-commands",
+		Short: "Sync slash commands with Discord and exit",
+		Long: "Registers slash commands without connecting to the gateway. Commands go to\n" +
+			"DISCORD_DEV_GUILD_ID in dev mode and are global otherwise, as when the bot starts.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := bot.SyncCommands(cfg); err != nil {
+				logger.Fatal("❌ Failed to register commands: %v", err)
+			}
+		},
+	}
+}
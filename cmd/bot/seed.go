@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/75-hard-discord-bot/internal/database"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/seed"
+)
+
+// seedCommand fills a development database with fake challenge data.
+// Usage: DEV_MODE=dev DB_HOST=localhost DB_PASSWORD=... bot seed [--users 6] [--days 75]
+func seedCommand() *cobra.Command {
+	defaults := seed.DefaultOptions()
+	opts := defaults
+	var clean bool
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Fill a development database with fake challenge data",
+		Run: func(cmd *cobra.Command, args []string) {
+			// Always log seeding progress
+			logger.Init("INFO", logger.IsDevMode())
+
+			// Refuse to touch a database unless explicitly in dev mode
+			if !logger.IsDevMode() {
+				logger.Fatal("Seeding is only allowed with DEV_MODE enabled")
+			}
+			if cfg.Database == nil {
+				logger.Fatal("No database configured - set DB_HOST and DB_PASSWORD")
+			}
+
+			db, err := database.Connect(databaseConfig(cfg.Database))
+			if err != nil {
+				logger.Fatal("❌ Failed to connect to database: %v", err)
+			}
+			defer db.Close()
+
+			if clean {
+				if err := seed.Reset(db); err != nil {
+					logger.Fatal("Failed to remove seeded data: %v", err)
+				}
+				return
+			}
+
+			if err := seed.Run(db, opts); err != nil {
+				logger.Fatal("Seeding failed: %v", err)
+			}
+			logger.Info("✅ Seeding complete - start the bot to refresh the leaderboard rollup")
+		},
+	}
+	cmd.Flags().IntVar(&opts.Users, "users", defaults.Users, "number of fake users to create")
+	cmd.Flags().IntVar(&opts.Days, "days", defaults.Days, "days of history per user")
+	cmd.Flags().Int64Var(&opts.Seed, "seed", defaults.Seed, "random seed for reproducible data")
+	cmd.Flags().BoolVar(&opts.Reset, "reset", defaults.Reset, "remove previously seeded users first")
+	cmd.Flags().BoolVar(&clean, "clean", false, "only remove seeded users, don't create new ones")
+	return cmd
+}
@@ -0,0 +1,416 @@
+package main
+
+import (
+	"database/sql"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/bot"
+	"github.com/75-hard-discord-bot/internal/config"
+	"github.com/75-hard-discord-bot/internal/database"
+	"github.com/75-hard-discord-bot/internal/email"
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/graphql"
+	"github.com/75-hard-discord-bot/internal/grpcapi"
+	"github.com/75-hard-discord-bot/internal/httpserver"
+	"github.com/75-hard-discord-bot/internal/llm"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+	"github.com/75-hard-discord-bot/internal/shutdown"
+	"github.com/75-hard-discord-bot/internal/storage"
+	"github.com/75-hard-discord-bot/internal/tracing"
+	"github.com/75-hard-discord-bot/internal/twilio"
+)
+
+// runServe starts the bot and blocks until SIGINT/SIGTERM, then shuts down gracefully
+func runServe(cfg *config.Config) {
+	if err := services.DisableFeaturesByDefault(cfg.DisabledFeatures); err != nil {
+		logger.Fatal("Invalid FEATURES_DISABLED: %v", err)
+	}
+
+	var traceExporter *tracing.Exporter
+	if cfg.Tracing != nil {
+		traceExporter = tracing.NewExporter(cfg.Tracing.Endpoint, cfg.Tracing.ServiceName, cfg.Tracing.Headers)
+		tracing.SetExporter(traceExporter)
+		traceExporter.Start()
+		logger.Info("Exporting traces to %s as %s", cfg.Tracing.Endpoint, cfg.Tracing.ServiceName)
+	}
+
+	// Initialize database connection (optional - app can run without DB)
+	logger.Info("🔌 Initializing database connection...")
+	var db *sql.DB
+	var dbRouter *database.Router
+	var err error
+	if cfg.Database != nil {
+		db, err = database.Connect(databaseConfig(cfg.Database))
+		if err != nil {
+			logger.Fatal("❌ Failed to connect to database: %v", err)
+		}
+		if cfg.Database.MigrationsDryRun {
+			db.Close()
+			logger.Info("✅ Migration dry run complete - no changes were applied")
+			return
+		}
+		logger.Info("✅ Database connected and migrations applied")
+
+		// Optional read replica for heavy read queries
+		var replica *sql.DB
+		if cfg.Database.ReadDSN != "" {
+			replica, err = database.ConnectReplica(cfg.Database.ReadDSN)
+			if err != nil {
+				logger.Fatal("❌ Failed to connect to read replica: %v", err)
+			}
+			logger.Info("✅ Read replica connected - summary and leaderboard queries will use it")
+		}
+		dbRouter = database.NewRouter(db, replica)
+	} else {
+		logger.Info("⚠️  No database configured - database features will be unavailable")
+	}
+
+	// Create service registry and the event bus services publish to
+	serviceRegistry := services.NewServiceRegistry()
+	eventBus := events.NewBus()
+
+	// Create and register services
+	userService := services.NewUserService(cfg.UserCacheTTL, cfg.Timezone)
+	serviceRegistry.Register(userService)
+
+	checkInService := services.NewCheckInService(userService, eventBus)
+	serviceRegistry.Register(checkInService)
+
+	exerciseService := services.NewExerciseService(userService)
+	serviceRegistry.Register(exerciseService)
+
+	weighInService := services.NewWeighInService(userService, eventBus)
+	serviceRegistry.Register(weighInService)
+
+	waterService := services.NewWaterService(userService, eventBus)
+	serviceRegistry.Register(waterService)
+
+	stepService := services.NewStepService(userService)
+	serviceRegistry.Register(stepService)
+
+	nutritionService := services.NewNutritionService(userService)
+	serviceRegistry.Register(nutritionService)
+
+	healthImportService := services.NewHealthImportService(userService, exerciseService, waterService, weighInService)
+	serviceRegistry.Register(healthImportService)
+
+	historyImportService := services.NewHistoryImportService(userService)
+	serviceRegistry.Register(historyImportService)
+
+	summaryService := services.NewSummaryService(userService)
+	serviceRegistry.Register(summaryService)
+
+	leaderboardService := services.NewLeaderboardService(cfg.LeaderboardRefreshInterval, userService)
+	serviceRegistry.Register(leaderboardService)
+
+	exportService := services.NewExportService()
+	serviceRegistry.Register(exportService)
+
+	journalService := services.NewJournalService()
+	serviceRegistry.Register(journalService)
+
+	editService := services.NewEditService(userService, leaderboardService, cfg.EditWindow)
+	serviceRegistry.Register(editService)
+
+	correctionService := services.NewCorrectionService(userService, leaderboardService, eventBus)
+	serviceRegistry.Register(correctionService)
+
+	mealService := services.NewMealService(userService)
+	serviceRegistry.Register(mealService)
+
+	financeService := services.NewFinanceService(userService)
+	serviceRegistry.Register(financeService)
+
+	bookService := services.NewBookService(userService, eventBus)
+	serviceRegistry.Register(bookService)
+
+	selfImprovementService := services.NewSelfImprovementService(userService)
+	serviceRegistry.Register(selfImprovementService)
+
+	workoutTimerService := services.NewWorkoutTimerService(userService)
+	serviceRegistry.Register(workoutTimerService)
+
+	forumService := services.NewForumService()
+	serviceRegistry.Register(forumService)
+
+	outboxService := services.NewOutboxService()
+	serviceRegistry.Register(outboxService)
+
+	featureFlagService := services.NewFeatureFlagService()
+	serviceRegistry.Register(featureFlagService)
+
+	settingsService := services.NewSettingsService(eventBus, cfg.SettingsPollInterval)
+	serviceRegistry.Register(settingsService)
+
+	templateService := services.NewTemplateService()
+	serviceRegistry.Register(templateService)
+
+	preferencesService := services.NewPreferencesService(userService)
+	serviceRegistry.Register(preferencesService)
+
+	reminderService := services.NewReminderService(userService)
+	serviceRegistry.Register(reminderService)
+
+	complianceService := services.NewComplianceService(userService)
+	serviceRegistry.Register(complianceService)
+
+	webhookKeyService := services.NewWebhookKeyService()
+	serviceRegistry.Register(webhookKeyService)
+
+	webhookService := services.NewWebhookService()
+	serviceRegistry.Register(webhookService)
+
+	connectionService := services.NewConnectionService()
+	serviceRegistry.Register(connectionService)
+
+	var calendarService *services.CalendarService
+	if cfg.PublicURL != "" && cfg.HTTPAddr != "" {
+		calendarService = services.NewCalendarService(userService, cfg.PublicURL)
+		serviceRegistry.Register(calendarService)
+	}
+
+	if cfg.Twilio != nil {
+		smsService := services.NewSMSService(twilio.NewClient(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken, cfg.Twilio.From))
+		serviceRegistry.Register(smsService)
+	}
+
+	if cfg.Email != nil {
+		var sender email.Sender
+		if cfg.Email.SendGridAPIKey != "" {
+			sender = email.NewSendGrid(cfg.Email.SendGridAPIKey, cfg.Email.From)
+		} else {
+			sender = email.NewSMTP(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.SMTPUsername, cfg.Email.SMTPPassword, cfg.Email.From)
+		}
+		serviceRegistry.Register(services.NewEmailReportService(sender, userService, preferencesService, summaryService))
+	}
+
+	if cfg.Coach != nil {
+		client := llm.NewClient(cfg.Coach.Provider, cfg.Coach.BaseURL, cfg.Coach.APIKey, cfg.Coach.Model)
+		serviceRegistry.Register(services.NewCoachService(client, cfg.Coach.DailyLimit, userService, preferencesService, summaryService))
+	}
+
+	var stravaService *services.StravaService
+	if cfg.Strava != nil {
+		stravaService = services.NewStravaService(cfg.Strava.ClientID, cfg.Strava.ClientSecret, cfg.PublicURL, connectionService, exerciseService)
+		serviceRegistry.Register(stravaService)
+	}
+
+	var fitbitService *services.FitbitService
+	if cfg.Fitbit != nil {
+		fitbitService = services.NewFitbitService(cfg.Fitbit.ClientID, cfg.Fitbit.ClientSecret, cfg.PublicURL,
+			cfg.FitbitSyncInterval, connectionService, waterService, stepService, nutritionService)
+		serviceRegistry.Register(fitbitService)
+	}
+
+	var dashboardService *services.DashboardService
+	if cfg.Dashboard != nil {
+		dashboardService = services.NewDashboardService(cfg.Dashboard.ClientID, cfg.Dashboard.ClientSecret, cfg.PublicURL, userService)
+		serviceRegistry.Register(dashboardService)
+	}
+
+	var sheetService *services.SheetService
+	if cfg.GoogleSheets != nil {
+		sheetService = services.NewSheetService(cfg.GoogleSheets.ServiceAccount, cfg.GoogleSheets.SyncInterval, settingsService, cfg.Locale)
+		serviceRegistry.Register(sheetService)
+	}
+
+	var backupService *services.BackupService
+	if cfg.Backup != nil {
+		backupService = services.NewBackupService(cfg.Database, cfg.Backup)
+		serviceRegistry.Register(backupService)
+
+		// Exports too large for Discord and meal photos go to the backup bucket
+		bucket := storage.NewS3Client(storage.S3Config{
+			Endpoint:        cfg.Backup.Endpoint,
+			Region:          cfg.Backup.Region,
+			Bucket:          cfg.Backup.Bucket,
+			AccessKeyID:     cfg.Backup.AccessKeyID,
+			SecretAccessKey: cfg.Backup.SecretAccessKey,
+		})
+		exportService.SetStorage(bucket, cfg.Backup.Prefix)
+		mealService.SetStorage(bucket, cfg.Backup.Prefix)
+	}
+
+	healthMonitor := services.NewHealthMonitor(serviceRegistry, cfg.HealthCheckInterval)
+	serviceRegistry.Register(healthMonitor)
+
+	// Initialize all services
+	if db != nil {
+		logger.Info("Initializing services...")
+		if err := serviceRegistry.InitializeAll(dbRouter.Primary()); err != nil {
+			logger.Fatal("Failed to initialize services: %v", err)
+		}
+		serviceRegistry.SetReadDB(dbRouter.Read())
+		logger.Info("✅ All services initialized")
+	}
+
+	// Shutdown runs in order once in-flight handlers drain:
+	// background jobs, then the Discord session, then the database
+	coordinator := shutdown.New()
+
+	// Create bot (connects in Start) so background jobs can alert admins through it
+	logger.Info("Creating bot instance...")
+	discordBot, err := bot.NewBot(cfg, db, serviceRegistry, coordinator, eventBus)
+	if err != nil {
+		logger.Fatal("Failed to create bot: %v", err)
+	}
+
+	// Start background jobs
+	healthMonitor.SetAlerter(discordBot.AlertAdmins)
+	healthMonitor.Start()
+	coordinator.OnShutdown("health monitor", healthMonitor.Stop)
+	if db != nil {
+		leaderboardService.Start()
+		coordinator.OnShutdown("leaderboard refresh", leaderboardService.Stop)
+		checkInService.Start()
+		coordinator.OnShutdown("check-in batching", checkInService.Stop)
+	}
+	if db != nil && backupService != nil {
+		backupService.SetAlerter(discordBot.AlertAdmins)
+		backupService.Start()
+		coordinator.OnShutdown("scheduled backups", backupService.Stop)
+	}
+	if db != nil && fitbitService != nil {
+		fitbitService.Start()
+		coordinator.OnShutdown("Fitbit sync", fitbitService.Stop)
+	}
+	if db != nil && sheetService != nil {
+		sheetService.Start()
+		coordinator.OnShutdown("Google Sheets sync", sheetService.Stop)
+	}
+
+	// Probes answer before the bot connects so /readyz reports startup
+	var httpServer *httpserver.Server
+	if cfg.HTTPAddr != "" {
+		httpServer = httpserver.New(cfg.HTTPAddr)
+		probes := httpserver.Probes{Connected: discordBot.Connected, ShuttingDown: coordinator.ShuttingDown}
+		if db != nil {
+			probes.Monitor = healthMonitor
+		}
+		probes.Register(httpServer)
+		if cfg.APIToken != "" {
+			httpserver.API{Token: cfg.APIToken, Services: serviceRegistry}.Register(httpServer)
+			httpserver.GraphQL{Token: cfg.APIToken, Schema: graphql.New(cfg.Locale, serviceRegistry)}.Register(httpServer)
+		}
+		if db != nil {
+			// Per-user keys from /shortcut key authenticate these
+			httpserver.Hooks{Services: serviceRegistry}.Register(httpServer)
+		}
+		if db != nil && calendarService != nil {
+			// Secret per-user URLs from /calendar link
+			httpserver.Calendar{Service: calendarService}.Register(httpServer)
+		}
+		if stravaService != nil {
+			httpserver.Strava{Service: stravaService}.Register(httpServer)
+		}
+		if fitbitService != nil {
+			httpserver.Fitbit{Service: fitbitService}.Register(httpServer)
+		}
+		if dashboardService != nil {
+			httpserver.Dashboard{
+				Service:  dashboardService,
+				Services: serviceRegistry,
+				HomeURL:  cfg.DashboardURL,
+				Locale:   cfg.Locale,
+				Secure:   strings.HasPrefix(cfg.PublicURL, "https://"),
+			}.Register(httpServer)
+		}
+		if err := httpServer.Start(); err != nil {
+			logger.Fatal("Failed to start HTTP server: %v", err)
+		}
+		logger.Info("Serving /healthz and /readyz on %s", cfg.HTTPAddr)
+		if cfg.APIToken != "" {
+			logger.Info("Serving the REST API under /api/v1/ and GraphQL at /api/graphql on %s", cfg.HTTPAddr)
+		}
+		if db != nil {
+			logger.Info("Serving the logging webhook under /hooks/v1/ on %s", cfg.HTTPAddr)
+		}
+		if dashboardService != nil {
+			logger.Info("Serving the web dashboard API under %s on %s", services.DashboardPath, cfg.HTTPAddr)
+		}
+		if stravaService != nil {
+			// Strava confirms the subscription by calling us, so the server must be up first
+			go func() {
+				if err := stravaService.EnsureSubscription(); err != nil {
+					logger.Error("Failed to subscribe to Strava events; new activities won't be imported: %v", err)
+					return
+				}
+				logger.Info("Receiving Strava activity events at %s%s", cfg.PublicURL, services.StravaEventsPath)
+			}()
+		}
+	}
+
+	var grpcServer *grpcapi.Server
+	if cfg.GRPC != nil {
+		grpcServer = grpcapi.New(cfg.GRPC.Addr, cfg.GRPC.CertFile, cfg.GRPC.KeyFile, cfg.APIToken, cfg.Locale, serviceRegistry)
+		if err := grpcServer.Start(); err != nil {
+			logger.Fatal("Failed to start gRPC server: %v", err)
+		}
+		logger.Info("Serving the gRPC API on %s", cfg.GRPC.Addr)
+	}
+
+	// Profiling gets its own localhost-only listener, away from the probe port
+	var pprofServer *httpserver.Server
+	if cfg.PprofAddr != "" {
+		pprofServer = httpserver.New(cfg.PprofAddr)
+		httpserver.RegisterPprof(pprofServer)
+		if err := pprofServer.Start(); err != nil {
+			logger.Fatal("Failed to start pprof server: %v", err)
+		}
+		logger.Info("Serving pprof on http://%s/debug/pprof/", cfg.PprofAddr)
+	}
+
+	// Start bot
+	if err := discordBot.Start(); err != nil {
+		logger.Fatal("Failed to start bot: %v", err)
+	}
+	if db != nil {
+		// Started after the bot so changes are applied to a connected session
+		settingsService.Start()
+		coordinator.OnShutdown("settings watcher", settingsService.Stop)
+	}
+	coordinator.OnShutdown("Discord session", func() {
+		if err := discordBot.Stop(); err != nil {
+			logger.Error("Error closing Discord session: %v", err)
+		}
+	})
+	if httpServer != nil {
+		// /readyz has reported unavailable since shutdown started
+		coordinator.OnShutdown("HTTP server", httpServer.Stop)
+	}
+	if grpcServer != nil {
+		coordinator.OnShutdown("gRPC server", grpcServer.Stop)
+	}
+	if pprofServer != nil {
+		coordinator.OnShutdown("pprof server", pprofServer.Stop)
+	}
+	if dbRouter != nil {
+		coordinator.OnShutdown("database", func() {
+			if err := dbRouter.Close(); err != nil {
+				logger.Error("Error closing read replica: %v", err)
+			}
+			if err := db.Close(); err != nil {
+				logger.Error("Error closing database: %v", err)
+			}
+		})
+	}
+	if traceExporter != nil {
+		// Last, so spans from the rest of shutdown are exported too
+		coordinator.OnShutdown("trace exporter", traceExporter.Stop)
+	}
+
+	// Wait for interrupt signal
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	<-sc
+
+	logger.Info("\nShutting down...")
+	coordinator.Shutdown(cfg.ShutdownTimeout)
+	logger.Info("✅ Shutdown complete")
+	logger.Flush(5 * time.Second)
+}
@@ -5,10 +5,13 @@ go 1.21
 require (
 	github.com/bwmarrin/discordgo v0.28.1
 	github.com/lib/pq v1.10.9
+	github.com/spf13/cobra v1.8.0
 )
 
 require (
 	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
 	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b // indirect
 	golang.org/x/sys v0.0.0-20201119102817-f84b799fce68 // indirect
 )
@@ -0,0 +1,329 @@
+// Package applehealth reads workouts, water, and body weight from an Apple Health
+// export: the export.zip (or its export.xml) from the Health app, or the JSON written
+// by the Health Auto Export app.
+package applehealth
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout is how both formats write dates, with the device's UTC offset
+const timeLayout = "2006-01-02 15:04:05 -0700"
+
+// Record types read from export.xml
+const (
+	waterType  = "HKQuantityTypeIdentifierDietaryWater"
+	weightType = "HKQuantityTypeIdentifierBodyMass"
+)
+
+// Workout is one recorded workout
+type Workout struct {
+	Start   time.Time // In the offset it was recorded in, so Start's date is the local day
+	Minutes int
+	Type    string // e.g. "running" or "traditional strength training"
+	Outdoor bool
+}
+
+// Sample is one measurement, converted to US fluid ounces (water) or pounds (weight)
+type Sample struct {
+	Time  time.Time // In the offset it was recorded in
+	Value float64
+}
+
+// Export is everything the bot imports from a health export
+type Export struct {
+	Workouts []Workout
+	Water    []Sample
+	Weight   []Sample
+	// Skipped counts samples in units the bot can't convert
+	Skipped int
+}
+
+// ParseFile reads an export.zip, export.xml, or Health Auto Export JSON file,
+// detecting the format from its content
+func ParseFile(path string) (*Export, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	head, _ := reader.Peek(512)
+	switch trimmed := strings.TrimLeft(string(head), " \t\r\n\ufeff"); {
+	case strings.HasPrefix(trimmed, "PK"):
+		return parseZip(file)
+	case strings.HasPrefix(trimmed, "<"):
+		return parseXML(reader)
+	case strings.HasPrefix(trimmed, "{"):
+		return parseJSON(reader)
+	}
+	return nil, fmt.Errorf("not an Apple Health export.zip, export.xml, or Health Auto Export JSON file")
+}
+
+// parseZip reads export.xml from the Health app's export.zip
+func parseZip(file *os.File) (*Export, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	archive, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export.zip: %w", err)
+	}
+
+	for _, entry := range archive.File {
+		// export_cda.xml holds the same data in another schema
+		if entry.Name != "export.xml" && !strings.HasSuffix(entry.Name, "/export.xml") {
+			continue
+		}
+		content, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", entry.Name, err)
+		}
+		defer content.Close()
+		return parseXML(content)
+	}
+	return nil, fmt.Errorf("export.zip has no export.xml")
+}
+
+// xmlRecord is a <Record> quantity sample
+type xmlRecord struct {
+	Type      string `xml:"type,attr"`
+	Unit      string `xml:"unit,attr"`
+	Value     string `xml:"value,attr"`
+	StartDate string `xml:"startDate,attr"`
+}
+
+// xmlWorkout is a <Workout> and the children that tell indoor from outdoor
+type xmlWorkout struct {
+	ActivityType string `xml:"workoutActivityType,attr"`
+	Duration     string `xml:"duration,attr"`
+	DurationUnit string `xml:"durationUnit,attr"`
+	StartDate    string `xml:"startDate,attr"`
+	Metadata     []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"MetadataEntry"`
+	Routes []struct{} `xml:"WorkoutRoute"`
+}
+
+// parseXML streams export.xml, which can be gigabytes, keeping only what's imported
+func parseXML(r io.Reader) (*Export, error) {
+	export := &Export{}
+	decoder := xml.NewDecoder(r)
+	// export.xml declares a DTD whose entities the decoder doesn't need
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return export, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse export.xml: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Record":
+			var record xmlRecord
+			for _, attr := range start.Attr {
+				switch attr.Name.Local {
+				case "type":
+					record.Type = attr.Value
+				case "unit":
+					record.Unit = attr.Value
+				case "value":
+					record.Value = attr.Value
+				case "startDate":
+					record.StartDate = attr.Value
+				}
+			}
+			if record.Type == waterType || record.Type == weightType {
+				export.addRecord(record)
+			}
+
+		case "Workout":
+			var workout xmlWorkout
+			if err := decoder.DecodeElement(&workout, &start); err != nil {
+				return nil, fmt.Errorf("failed to parse workout: %w", err)
+			}
+			export.addWorkout(workout)
+		}
+	}
+}
+
+// addRecord converts and keeps a water or weight record
+func (e *Export) addRecord(record xmlRecord) {
+	at, err := time.Parse(timeLayout, record.StartDate)
+	value, valueErr := strconv.ParseFloat(record.Value, 64)
+	if err != nil || valueErr != nil {
+		e.Skipped++
+		return
+	}
+
+	if record.Type == waterType {
+		e.addWater(at, value, record.Unit)
+	} else {
+		e.addWeight(at, value, record.Unit)
+	}
+}
+
+// addWorkout converts and keeps an export.xml workout
+func (e *Export) addWorkout(workout xmlWorkout) {
+	start, err := time.Parse(timeLayout, workout.StartDate)
+	duration, durationErr := strconv.ParseFloat(workout.Duration, 64)
+	if err != nil || durationErr != nil {
+		e.Skipped++
+		return
+	}
+	switch workout.DurationUnit {
+	case "min", "":
+	case "s":
+		duration /= 60
+	case "hr":
+		duration *= 60
+	default:
+		e.Skipped++
+		return
+	}
+
+	// Workouts with a GPS route, or marked as not indoor, were outside
+	outdoor := len(workout.Routes) > 0
+	for _, entry := range workout.Metadata {
+		if entry.Key == "HKIndoorWorkout" {
+			outdoor = entry.Value == "0"
+		}
+	}
+
+	e.Workouts = append(e.Workouts, Workout{
+		Start:   start,
+		Minutes: int(duration),
+		Type:    splitWords(strings.TrimPrefix(workout.ActivityType, "HKWorkoutActivityType")),
+		Outdoor: outdoor,
+	})
+}
+
+// addWater keeps a water sample in US fluid ounces
+func (e *Export) addWater(at time.Time, value float64, unit string) {
+	switch unit {
+	case "fl_oz_us":
+	case "mL":
+		value /= 29.5735
+	case "L":
+		value *= 33.814
+	case "cup_us":
+		value *= 8
+	default:
+		e.Skipped++
+		return
+	}
+	e.Water = append(e.Water, Sample{Time: at, Value: value})
+}
+
+// addWeight keeps a weight sample in pounds
+func (e *Export) addWeight(at time.Time, value float64, unit string) {
+	switch unit {
+	case "lb":
+	case "kg":
+		value *= 2.20462
+	case "st":
+		value *= 14
+	default:
+		e.Skipped++
+		return
+	}
+	e.Weight = append(e.Weight, Sample{Time: at, Value: value})
+}
+
+// haeExport is the Health Auto Export JSON layout
+type haeExport struct {
+	Data struct {
+		Metrics []struct {
+			Name  string `json:"name"`
+			Units string `json:"units"`
+			Data  []struct {
+				Date string  `json:"date"`
+				Qty  float64 `json:"qty"`
+			} `json:"data"`
+		} `json:"metrics"`
+		Workouts []struct {
+			Name     string  `json:"name"`
+			Start    string  `json:"start"`
+			Duration float64 `json:"duration"` // Seconds
+			Location string  `json:"location"` // "Indoor" or "Outdoor" (v2)
+			IsIndoor *bool   `json:"isIndoor"` // v1
+		} `json:"workouts"`
+	} `json:"data"`
+}
+
+// parseJSON reads a Health Auto Export JSON file
+func parseJSON(r io.Reader) (*Export, error) {
+	var hae haeExport
+	if err := json.NewDecoder(r).Decode(&hae); err != nil {
+		return nil, fmt.Errorf("failed to parse Health Auto Export JSON: %w", err)
+	}
+
+	export := &Export{}
+	for _, metric := range hae.Data.Metrics {
+		if metric.Name != "dietary_water" && metric.Name != "weight_body_mass" {
+			continue
+		}
+		for _, point := range metric.Data {
+			at, err := time.Parse(timeLayout, point.Date)
+			if err != nil {
+				export.Skipped++
+				continue
+			}
+			if metric.Name == "dietary_water" {
+				export.addWater(at, point.Qty, metric.Units)
+			} else {
+				export.addWeight(at, point.Qty, metric.Units)
+			}
+		}
+	}
+
+	for _, workout := range hae.Data.Workouts {
+		start, err := time.Parse(timeLayout, workout.Start)
+		if err != nil {
+			export.Skipped++
+			continue
+		}
+		outdoor := strings.EqualFold(workout.Location, "Outdoor")
+		if workout.IsIndoor != nil {
+			outdoor = !*workout.IsIndoor
+		}
+		export.Workouts = append(export.Workouts, Workout{
+			Start:   start,
+			Minutes: int(workout.Duration / 60),
+			Type:    strings.ToLower(workout.Name),
+			Outdoor: outdoor,
+		})
+	}
+	return export, nil
+}
+
+// splitWords turns "TraditionalStrengthTraining" into "traditional strength training"
+func splitWords(name string) string {
+	var words strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			words.WriteByte(' ')
+		}
+		words.WriteRune(r)
+	}
+	return strings.ToLower(words.String())
+}
@@ -4,25 +4,56 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/75-hard-discord-bot/internal/config"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/events"
 	"github.com/75-hard-discord-bot/internal/handlers"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/mqtt"
 	"github.com/75-hard-discord-bot/internal/services"
+	"github.com/75-hard-discord-bot/internal/shutdown"
+	"github.com/75-hard-discord-bot/internal/tracing"
 )
 
 // Bot represents the Discord bot instance
 type Bot struct {
 	session  *discordgo.Session
+	rest     discord.Session // REST calls; sandboxed in dev mode when a sandbox channel is set
 	config   *config.Config
 	db       *sql.DB
 	services *services.ServiceRegistry
+	shutdown *shutdown.Coordinator
+	events   *events.Bus
+	stopped  chan struct{}
+	gateway  *gatewayMonitor
+	started  time.Time
+
+	// mqtt publishes challenge events to a broker when MQTT is configured
+	mqtt *mqtt.Publisher
+
+	// rateLimits counts 429s and exhausted buckets from every REST call
+	rateLimits *rateLimitStats
+
+	// checkInMessages remembers the check-in messages posted or found pinned, so
+	// reactions on them are handled without fetching the message
+	checkInMessages *handlers.CheckInMessages
+
+	// liveChannels is config.Channels with guild settings applied; read via channels().
+	// liveLocale and liveTimezone are the home guild's language and time zone for
+	// posts; read via locale() and timezone().
+	channelsMu   sync.RWMutex
+	liveChannels config.ChannelConfig
+	liveLocale   string
+	liveTimezone *time.Location
 }
 
 // NewBot creates a new bot instance
-func NewBot(cfg *config.Config, db *sql.DB, serviceRegistry *services.ServiceRegistry) (*Bot, error) {
+func NewBot(cfg *config.Config, db *sql.DB, serviceRegistry *services.ServiceRegistry, coordinator *shutdown.Coordinator, bus *events.Bus) (*Bot, error) {
 	// Create Discord session
 	session, err := discordgo.New("Bot " + cfg.DiscordBotToken)
 	if err != nil {
@@ -31,12 +62,47 @@ func NewBot(cfg *config.Config, db *sql.DB, serviceRegistry *services.ServiceReg
 
 	// Register intents needed for slash commands and interactions
 	session.Identify.Intents = discordgo.IntentsGuildMessages | discordgo.IntentsGuildMessageReactions | discordgo.IntentsGuilds
+	if cfg.NaturalLogging {
+		// Reading what members write is a privileged intent, enabled in the developer portal
+		session.Identify.Intents |= discordgo.IntentsMessageContent
+	}
+
+	// REST calls made with discordgo.WithContext(ctx) are traced under the span in ctx,
+	// and every response's rate-limit headers are counted
+	rateLimits := &rateLimitStats{}
+	session.Client.Transport = tracing.Transport(newRateLimitTransport(session.Client.Transport, rateLimits))
+
+	// A dev instance with a sandbox channel never posts to the real channels or DMs
+	rest := discord.Wrap(session)
+	if logger.IsDevMode() && cfg.SandboxChannelID != "" {
+		logger.Info("🧪 Sandbox mode: all posts, pins, and DMs go to channel_id=%s", cfg.SandboxChannelID)
+		rest = discord.Sandbox(rest, cfg.SandboxChannelID)
+	}
 
 	bot := &Bot{
 		session:  session,
+		rest:     rest,
 		config:   cfg,
 		db:       db,
 		services: serviceRegistry,
+		shutdown: coordinator,
+		events:   bus,
+		stopped:  make(chan struct{}),
+		gateway:  &gatewayMonitor{},
+		started:  time.Now(),
+
+		rateLimits:      rateLimits,
+		checkInMessages: handlers.NewCheckInMessages(),
+		liveChannels:    cfg.Channels,
+		liveLocale:      cfg.Locale,
+		liveTimezone:    services.LoadTimezone(cfg.Timezone),
+	}
+
+	if cfg.MQTT != nil {
+		bot.mqtt, err = mqtt.NewPublisher(cfg.MQTT.BrokerURL, cfg.MQTT.ClientID, cfg.MQTT.Username, cfg.MQTT.Password)
+		if err != nil {
+			return nil, fmt.Errorf("error creating MQTT publisher: %w", err)
+		}
 	}
 
 	return bot, nil
@@ -45,28 +111,54 @@ func NewBot(cfg *config.Config, db *sql.DB, serviceRegistry *services.ServiceReg
 // Start starts the bot and registers handlers
 func (b *Bot) Start() error {
 	// Create handlers
-	interactionHandler := handlers.NewInteractionHandler(b.services)
-	modalHandler := handlers.NewModalHandler(b.services)
-	reactionHandler := handlers.NewReactionHandler(b.services)
+	limiter := handlers.NewCooldownLimiter(handlers.DefaultCommandCooldowns)
+	deduper := handlers.NewInteractionDeduper(handlers.DefaultRepeatWindow)
+	forum := handlers.NewForumPublisher(b.channels().Forum, b.services, b.guildLocale)
+	interactionHandler := handlers.NewInteractionHandler(b.services, forum)
+	interactionHandler.SetRuntimeStats(b.runtimeStats)
+	modalHandler := handlers.NewModalHandler(b.services, forum)
+	reactionHandler := handlers.NewReactionHandler(b.services, limiter, b.checkInMessages,
+		func() string { return b.channels().CheckIn }, b.guildLocale, b.guildTimezone)
+	b.subscribe(forum)
+	if b.mqtt != nil {
+		b.mqtt.Start()
+	}
+
+	// Route commands, modals, and components through a shared middleware chain
+	router := handlers.NewRouter()
+	authorizer := handlers.NewAuthorizer(b.config.AdminRoleIDs, handlers.AdminRoutes)
+	features := handlers.NewFeatureGate(b.services, handlers.FeatureRoutes)
+	router.Use(handlers.RecoverMiddleware, handlers.DrainMiddleware(b.shutdown), handlers.LoggingMiddleware, handlers.TracingMiddleware, handlers.LocaleMiddleware(b.guildLocale, b.guildTimezone), deduper.Middleware, authorizer.Middleware, features.Middleware, limiter.Middleware)
+	interactionHandler.RegisterRoutes(router)
+	modalHandler.RegisterRoutes(router)
 
 	// Register handlers
-	b.session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type == discordgo.InteractionApplicationCommand {
-			interactionHandler.HandleSlashCommand(s, i)
+	b.session.AddHandler(recoverHandler("interaction", func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		router.Dispatch(b.rest, i)
+	}))
+	b.registerGatewayHandlers()
+
+	b.session.AddHandler(recoverHandler("reaction", func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		// Reactions are dropped once shutdown starts; in-flight check-ins are waited for
+		if !b.shutdown.Acquire() {
+			return
 		}
-	})
+		defer b.shutdown.Release()
 
-	b.session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type == discordgo.InteractionModalSubmit {
-			modalHandler.HandleModalSubmit(s, i)
-		} else if i.Type == discordgo.InteractionMessageComponent {
-			interactionHandler.HandleButtonClick(s, i)
-		}
-	})
+		reactionHandler.HandleMessageReaction(b.rest, r)
+	}))
 
-	b.session.AddHandler(func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
-		reactionHandler.HandleMessageReaction(s, r)
-	})
+	if b.config.NaturalLogging {
+		naturalLogHandler := handlers.NewNaturalLogHandler(b.services, b.guildLocale)
+		b.session.AddHandler(recoverHandler("message", func(s *discordgo.Session, m *discordgo.MessageCreate) {
+			if m.ChannelID != b.channels().CheckIn || !b.shutdown.Acquire() {
+				return
+			}
+			defer b.shutdown.Release()
+
+			naturalLogHandler.HandleMessage(b.rest, m)
+		}))
+	}
 
 	// Open websocket connection
 	logger.Info("Opening Discord websocket connection...")
@@ -75,49 +167,195 @@ func (b *Bot) Start() error {
 		return fmt.Errorf("error opening connection: %w", err)
 	}
 
+	// Guild settings override the environment before anything is posted
+	b.reloadSettings(forum)
+
 	// Register slash commands
-	if err := RegisterCommands(b.session); err != nil {
+	if err := RegisterCommands(b.session, b.commandGuildID()); err != nil {
 		return fmt.Errorf("failed to register commands: %w", err)
 	}
 
+	// Remove renamed/deleted commands left in the scope we're not registering to
+	if b.config.DiscordDevGuildID != "" {
+		otherScope := b.config.DiscordDevGuildID
+		if b.commandGuildID() != "" {
+			otherScope = ""
+		}
+		if err := CleanupStaleCommands(b.session, otherScope); err != nil {
+			logger.Error("Failed to clean up stale commands: %v", err)
+		}
+	}
+
 	logger.Info("75 Half Chub Discord Bot")
 	logger.Info("===================")
 	if b.db != nil {
 		logger.Info("✅ Database connected - check-ins will be recorded")
 		
 		// Query and display active users
-		if err := b.DisplayActiveUsers(b.config.DiscordChannelID); err != nil {
+		if err := b.DisplayActiveUsers(b.channels().Summaries); err != nil {
 			logger.Error("Failed to display active users: %v", err)
 		}
 	} else {
 		logger.Info("⚠️  No database configured - check-ins will not be recorded")
 	}
 	logger.Info("Bot is now running and listening for commands and reactions...")
+	b.startOutboxDispatcher()
 
-	// Send introduction message
-	if err := b.SendIntroduction(b.config.DiscordChannelID); err != nil {
-		return fmt.Errorf("failed to send introduction: %w", err)
+	if forum.Enabled() {
+		logger.Info("🧵 Forum mode enabled - logs are mirrored to per-user posts in channel_id=%s", b.channels().Forum)
+	}
+	b.startWeeklyRecaps()
+	b.startReminders()
+	b.startComplianceChecks()
+	b.startWorkoutTimers()
+	b.startDailyCheckIns()
+	b.startRateLimitReports()
+
+	// Startup posts are best-effort: if the channel is briefly unavailable, keep
+	// serving slash commands and retry in the background instead of exiting
+	if err := b.SendIntroduction(b.channels().CheckIn); err != nil {
+		logger.Warn("⚠️  Failed to send introduction: %v", err)
 	}
 
 	// Send the check-in message (pinned, datestamped)
-	if err := b.SendCheckInMessage(b.config.DiscordChannelID); err != nil {
-		return fmt.Errorf("failed to send check-in message: %w", err)
+	if err := b.SendCheckInMessage(b.channels().CheckIn); err != nil {
+		logger.Error("❌ Failed to send check-in message: %v", err)
+		logger.Error("❌ Check-ins are unavailable until it is posted - retrying in the background")
+		b.retryInBackground("check-in message", func() error {
+			return b.ensureCheckInMessage(b.channels().CheckIn)
+		})
 	}
 
 	return nil
 }
 
+const (
+	// postRetryInitialDelay is the first wait before retrying a failed startup post
+	postRetryInitialDelay = time.Minute
+	// postRetryMaxDelay caps the wait between retries
+	postRetryMaxDelay = 15 * time.Minute
+)
+
+// retryInBackground keeps retrying fn with exponential backoff until it succeeds
+// or the bot stops. Used for posts the bot can't run properly without.
+func (b *Bot) retryInBackground(name string, fn func() error) {
+	go func() {
+		delay := postRetryInitialDelay
+		for attempt := 1; ; attempt++ {
+			select {
+			case <-time.After(delay):
+			case <-b.stopped:
+				return
+			}
+
+			if err := fn(); err != nil {
+				delay *= 2
+				if delay > postRetryMaxDelay {
+					delay = postRetryMaxDelay
+				}
+				logger.Error("❌ Retry %d for %s failed: %v (next attempt in %s)", attempt, name, err, delay)
+				continue
+			}
+
+			logger.Info("✅ %s posted after %d retries", name, attempt)
+			return
+		}
+	}()
+}
+
+// homeGuildID returns the guild the check-in channel belongs to.
+// It uses the environment channel, since guild settings are looked up by this guild.
+func (b *Bot) homeGuildID() string {
+	if channel, err := b.session.State.Channel(b.config.Channels.CheckIn); err == nil {
+		return channel.GuildID
+	}
+	channel, err := b.session.Channel(b.config.Channels.CheckIn)
+	if err != nil {
+		logger.Error("Failed to look up guild for channel_id=%s: %v", b.config.Channels.CheckIn, err)
+		return ""
+	}
+	return channel.GuildID
+}
+
+// featureEnabled checks a feature flag for the bot's home guild (for scheduled jobs)
+func (b *Bot) featureEnabled(feature string) bool {
+	for _, svc := range b.services.GetServices() {
+		if fs, ok := svc.(*services.FeatureFlagService); ok {
+			return fs.IsEnabled(b.homeGuildID(), feature)
+		}
+	}
+	return services.FeatureDefaults[feature]
+}
+
+// userPrivacy returns how a member wants to appear in the bot's public posts
+func (b *Bot) userPrivacy(userID string) string {
+	for _, svc := range b.services.GetServices() {
+		if ps, ok := svc.(*services.PreferencesService); ok {
+			prefs, err := ps.Get(userID)
+			if err != nil {
+				logger.Error("Failed to load preferences for user_id=%s, treating them as hidden: %v", userID, err)
+				return services.PrivacyHidden
+			}
+			return prefs.Privacy
+		}
+	}
+	return services.DefaultPreferences.Privacy
+}
+
+// runtimeStats reports uptime, gateway state, and registered commands for /botstats
+func (b *Bot) runtimeStats() handlers.RuntimeStats {
+	var commands []string
+	for _, cmd := range commandDefinitions() {
+		commands = append(commands, "/"+cmd.Name)
+	}
+	return handlers.RuntimeStats{
+		StartedAt:        b.started,
+		GatewayConnected: b.Connected(),
+		GatewayLatency:   b.session.HeartbeatLatency(),
+		Commands:         commands,
+	}
+}
+
+// commandGuildID returns the guild to scope slash commands to.
+// Dev mode uses the configured test guild so changes show up instantly;
+// production registers globally (which can take up to an hour to propagate).
+func (b *Bot) commandGuildID() string {
+	if logger.IsDevMode() && b.config.DiscordDevGuildID != "" {
+		return b.config.DiscordDevGuildID
+	}
+	return ""
+}
+
 // Stop gracefully shuts down the bot
 func (b *Bot) Stop() error {
 	logger.Info("Shutting down bot...")
-	return b.session.Close()
+	close(b.stopped)
+	err := b.session.Close()
+	if b.mqtt != nil {
+		b.mqtt.Stop() // After the session, so the last events still go out
+	}
+	return err
+}
+
+// AlertAdmins posts an operational alert to the admin channel
+func (b *Bot) AlertAdmins(message string) {
+	err := withRetry("send admin alert", func(opts ...discordgo.RequestOption) error {
+		_, err := b.rest.ChannelMessageSend(b.channels().Admin, "🚨 "+message, opts...)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to send admin alert to channel_id=%s: %v", b.channels().Admin, err)
+	}
 }
 
 // SendIntroduction sends a one-sentence introduction message to the channel
 func (b *Bot) SendIntroduction(channelID string) error {
-	introMessage := "👋 75 Half Chub Bot here! I'll help you track your daily challenge progress."
+	introMessage := i18n.T(b.locale(), "bot.introduction")
 	logger.Info("Sending introduction message to channel_id=%s", channelID)
-	_, err := b.session.ChannelMessageSend(channelID, introMessage)
+	err := withRetry("send introduction", func(opts ...discordgo.RequestOption) error {
+		_, err := b.rest.ChannelMessageSend(channelID, introMessage, opts...)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("error sending introduction: %w", err)
 	}
@@ -149,91 +387,143 @@ func (b *Bot) DisplayActiveUsers(channelID string) error {
 		return fmt.Errorf("failed to get active users: %w", err)
 	}
 
+	// Members who chose to be hidden are left out of the public roster
+	visible := activeUsers[:0]
+	for _, user := range activeUsers {
+		if user.Privacy != services.PrivacyHidden {
+			visible = append(visible, user)
+		}
+	}
+	activeUsers = visible
+
 	if len(activeUsers) == 0 {
 		logger.Info("📊 No active users found")
 		return nil
 	}
 
-	// Load MST location for date formatting
-	mst, err := time.LoadLocation("America/Denver")
-	if err != nil {
-		mst = time.FixedZone("MST", -7*3600)
-	}
-	today := time.Now().In(mst).Format("January 2, 2006")
+	locale := b.locale()
+	today := i18n.FormatDateIn(locale, time.Now(), b.timezone())
 
 	var message strings.Builder
-	message.WriteString(fmt.Sprintf("📊 **Active Challenge Participants** - %s (MST)\n\n", today))
+	message.WriteString(i18n.T(locale, "bot.active_title", today))
 
 	for _, user := range activeUsers {
-		// Dates are already in MST from GetActiveUsers
-		startDateStr := user.StartDate.Format("Jan 2, 2006")
-		endDateStr := user.EndDate.Format("Jan 2, 2006")
-		
-		message.WriteString(fmt.Sprintf("**%s** - Day %d/%d", user.Username, user.CurrentDay, user.TotalDays))
+		// Calendar dates from GetActiveUsers
+		startDateStr := i18n.FormatShortDate(locale, user.StartDate)
+		endDateStr := i18n.FormatShortDate(locale, user.EndDate)
+
+		message.WriteString(i18n.T(locale, "bot.active_user", services.PublicName(locale, user.Privacy, user.UserID, user.Username), user.CurrentDay, user.TotalDays))
 		if user.DaysAdded > 0 {
 			message.WriteString(fmt.Sprintf(" (+%d)", user.DaysAdded))
 		}
-		message.WriteString(fmt.Sprintf("\n  Started: %s | Ends: %s\n\n", startDateStr, endDateStr))
+		message.WriteString(i18n.T(locale, "bot.active_dates", startDateStr, endDateStr))
 	}
 
-	message.WriteString(fmt.Sprintf("_Total active participants: %d_", len(activeUsers)))
+	message.WriteString(i18n.T(locale, "bot.active_total", len(activeUsers)))
 
 	logger.Info("Displaying active users to channel_id=%s", channelID)
-	_, err = b.session.ChannelMessageSend(channelID, message.String())
-	if err != nil {
-		return fmt.Errorf("error sending active users message: %w", err)
+	// Large rosters exceed Discord's message limit, so send in line-aligned chunks
+	for _, chunk := range discord.SplitMessage(message.String(), discord.MaxMessageLength) {
+		err = withRetry("send active users", func(opts ...discordgo.RequestOption) error {
+			_, err := b.rest.ChannelMessageSend(channelID, chunk, opts...)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error sending active users message: %w", err)
+		}
 	}
 
 	logger.Info("✅ Displayed %d active users", len(activeUsers))
 	return nil
 }
 
+// checkInHeader returns the datestamped title of the check-in message for the day of t in loc
+func checkInHeader(locale string, loc *time.Location, t time.Time) string {
+	return i18n.T(locale, "checkin.header", i18n.T(locale, "checkin.title"), i18n.FormatDateIn(locale, t, loc))
+}
+
 // SendCheckInMessage sends the daily check-in message to the channel (pinned, datestamped)
 func (b *Bot) SendCheckInMessage(channelID string) error {
-	// Load MST location for date formatting
-	mst, err := time.LoadLocation("America/Denver")
-	if err != nil {
-		mst = time.FixedZone("MST", -7*3600)
-	}
-	today := time.Now().In(mst)
-	dateStr := today.Format("January 2, 2006")
+	locale := b.locale()
+	header := checkInHeader(locale, b.timezone(), time.Now())
 
 	// Try to find and unpin existing check-in messages
 	b.CleanupOldCheckInMessages(channelID)
 
-	checkInMessage := fmt.Sprintf("📅 **Daily Check-In - %s (MST)**\n\nCheck this message to confirm you completed the challenges today", dateStr)
+	prompt := b.renderTemplate(services.TemplateCheckIn, i18n.T(locale, "checkin.prompt"),
+		map[string]string{"date": b.templateDate()})
+	checkInMessage := fmt.Sprintf("📅 **%s**\n\n%s", header, prompt)
 	logger.DB("Sending check-in message to channel_id=%s", channelID)
-	msg, err := b.session.ChannelMessageSend(channelID, checkInMessage)
+	var msg *discordgo.Message
+	err := withRetry("send check-in message", func(opts ...discordgo.RequestOption) error {
+		var err error
+		msg, err = b.rest.ChannelMessageSend(channelID, checkInMessage, opts...)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("error sending check-in message: %w", err)
 	}
+	b.checkInMessages.Remember(msg.ID, msg.Timestamp)
 
 	// Pin the message
-	err = b.session.ChannelMessagePin(channelID, msg.ID)
+	err = withRetry("pin check-in message", func(opts ...discordgo.RequestOption) error {
+		return b.rest.ChannelMessagePin(channelID, msg.ID, opts...)
+	})
 	if err != nil {
-		logger.Error("⚠️  Warning: Could not pin check-in message: %v", err)
+		logger.Warn("⚠️  Warning: Could not pin check-in message: %v", err)
 		logger.Info("   Message sent but not pinned")
 	}
 
 	// Add a self-reaction so users can easily click it
-	err = b.session.MessageReactionAdd(channelID, msg.ID, "✅")
+	err = withRetry("add check-in reaction", func(opts ...discordgo.RequestOption) error {
+		return b.rest.MessageReactionAdd(channelID, msg.ID, "✅", opts...)
+	})
 	if err != nil {
-		logger.Error("⚠️  Warning: Could not add self-reaction: %v", err)
+		logger.Warn("⚠️  Warning: Could not add self-reaction: %v", err)
 		logger.Info("   Users can still react manually")
 	}
 
+	// Keep the day's chatter in a thread so the check-in message stays easy to find.
+	// Reactions still go on the parent message, so check-ins are unaffected.
+	b.startDiscussionThread(channelID, msg.ID)
+
 	logger.Info("✅ Check-in message sent and pinned to channel %s", channelID)
 	logger.Info("   Message ID: %s", msg.ID)
-	logger.Info("   Date: %s", dateStr)
+	logger.Info("   %s", header)
 	logger.Info("   Bot has added ✅ reaction - users can click it to check in!")
 
 	return nil
 }
 
+// checkInThreadArchiveMinutes auto-archives the discussion thread after a day of inactivity
+const checkInThreadArchiveMinutes = 1440
+
+// startDiscussionThread opens the per-day discussion thread under a check-in message
+func (b *Bot) startDiscussionThread(channelID, messageID string) {
+	locale := b.locale()
+	name := i18n.T(locale, "checkin.thread", i18n.FormatMonthDay(locale, time.Now().In(b.timezone())))
+
+	err := withRetry("start discussion thread", func(opts ...discordgo.RequestOption) error {
+		_, err := b.rest.MessageThreadStart(channelID, messageID, name, checkInThreadArchiveMinutes, opts...)
+		return err
+	})
+	if err != nil {
+		logger.Warn("⚠️  Warning: Could not start discussion thread: %v", err)
+		logger.Info("   Check the bot has the Create Public Threads permission")
+		return
+	}
+	logger.Info("🧵 Started discussion thread: %s", name)
+}
+
 // CleanupOldCheckInMessages finds and unpins old check-in messages
 func (b *Bot) CleanupOldCheckInMessages(channelID string) {
 	// Get pinned messages
-	pins, err := b.session.ChannelMessagesPinned(channelID)
+	var pins []*discordgo.Message
+	err := withRetry("list pinned messages", func(opts ...discordgo.RequestOption) error {
+		var err error
+		pins, err = b.rest.ChannelMessagesPinned(channelID, opts...)
+		return err
+	})
 	if err != nil {
 		logger.Error("Failed to get pinned messages: %v", err)
 		return
@@ -241,9 +531,13 @@ func (b *Bot) CleanupOldCheckInMessages(channelID string) {
 
 	botID := b.session.State.User.ID
 	for _, pin := range pins {
-		// Only unpin messages from the bot that look like check-in messages
-		if pin.Author.ID == botID && strings.Contains(pin.Content, "Daily Check-In") {
-			err := b.session.ChannelMessageUnpin(channelID, pin.ID)
+		// Only unpin messages from the bot that look like check-in messages, in any language
+		if pin.Author.ID == botID && i18n.Contains(pin.Content, "checkin.title") {
+			// Unpinned messages take late check-ins until the end of the next day
+			b.checkInMessages.Remember(pin.ID, pin.Timestamp)
+			err := withRetry("unpin old check-in message", func(opts ...discordgo.RequestOption) error {
+				return b.rest.ChannelMessageUnpin(channelID, pin.ID, opts...)
+			})
 			if err != nil {
 				logger.Error("Failed to unpin old check-in message %s: %v", pin.ID, err)
 			} else {
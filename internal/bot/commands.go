@@ -1,13 +1,20 @@
 package bot
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/config"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
 )
 
-// RegisterCommands registers all slash commands with Discord
-func RegisterCommands(session *discordgo.Session) error {
-	commands := []*discordgo.ApplicationCommand{
+// commandDefinitions returns every slash command the bot provides
+func commandDefinitions() []*discordgo.ApplicationCommand {
+	return localizeCommands([]*discordgo.ApplicationCommand{
 		{
 			Name:        "exercise",
 			Description: "Log your daily exercise (workout + core/mobility)",
@@ -22,6 +29,151 @@ func RegisterCommands(session *discordgo.Session) error {
 					Name:        "detailed",
 					Description: "Log with full details (opens a form)",
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "start",
+					Description: "Time a workout; stop it when you're done to log how long it took",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "upload",
+					Description: "Log a workout from a watch or app file (.gpx or .fit)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "file",
+							Description: "GPX or FIT file exported from your watch, Garmin Connect, Strava, or similar",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "diet",
+			Description: "Log what you eat for your diet",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "meal",
+					Description: "Log a meal, with a photo if you like",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "description",
+							Description: "What you ate, e.g. grilled chicken, rice, and broccoli",
+							Required:    true,
+							MaxLength:   services.MaxMealDescriptionLength,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "photo",
+							Description: "A photo of the meal",
+							Required:    false,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "finances",
+			Description: "Log your spending for the finances feat",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "purchase",
+					Description: "Log a purchase; one that wasn't necessary breaks today's finances feat",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionNumber,
+							Name:        "amount",
+							Description: "How much you spent",
+							Required:    true,
+							MinValue:    &minPurchaseAmount,
+							MaxValue:    services.MaxPurchaseAmount,
+						},
+						purchaseCategoryOption(),
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "necessary",
+							Description: "Whether it was a necessity, like groceries or rent",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "summary",
+					Description: "See what you've spent over the last 7 days",
+				},
+			},
+		},
+		{
+			Name:        "reading",
+			Description: "Track the book you're reading",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "book",
+					Description: "Start a book; any book you were reading is set aside",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "title",
+							Description: "The book's title",
+							Required:    true,
+							MaxLength:   services.MaxBookTitleLength,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "pages",
+							Description: "How many pages the book has",
+							Required:    true,
+							MinValue:    &minBookPages,
+							MaxValue:    services.MaxBookPages,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "pages",
+					Description: "Log pages read today in your current book",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "pages",
+							Description: "How many pages you read",
+							Required:    true,
+							MinValue:    &minBookPages,
+							MaxValue:    services.MaxBookPages,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "selfimprovement",
+			Description: "Time your self-improvement so you don't have to guess how long it took",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "start",
+					Description: "Start the timer",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "activity",
+							Description: "What you're doing, e.g. reading or a course",
+							Required:    false,
+							MaxLength:   services.MaxSessionActivityLength,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "stop",
+					Description: "Stop the timer and log the minutes",
+				},
 			},
 		},
 		{
@@ -36,6 +188,10 @@ func RegisterCommands(session *discordgo.Session) error {
 				},
 			},
 		},
+		{
+			Name:        "leaderboard",
+			Description: "View the challenge leaderboard",
+		},
 		{
 			Name:        "weighin",
 			Description: "Record your daily weigh-in",
@@ -43,7 +199,7 @@ func RegisterCommands(session *discordgo.Session) error {
 				{
 					Type:        discordgo.ApplicationCommandOptionNumber,
 					Name:        "weight",
-					Description: "Your weight in pounds",
+					Description: "Your weight, in your /preferences units (pounds by default)",
 					Required:    true,
 				},
 				{
@@ -62,7 +218,7 @@ func RegisterCommands(session *discordgo.Session) error {
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "date",
-					Description: "Start date (YYYY-MM-DD) - defaults to today (MST)",
+					Description: "Start date (YYYY-MM-DD) - defaults to today in your timezone",
 					Required:    false,
 				},
 			},
@@ -83,8 +239,8 @@ func RegisterCommands(session *discordgo.Session) error {
 					Options: []*discordgo.ApplicationCommandOption{
 						{
 							Type:        discordgo.ApplicationCommandOptionNumber,
-							Name:        "ounces",
-							Description: "Amount of water in ounces to add",
+							Name:        "amount",
+							Description: "Amount of water to add, in your /preferences units (ounces by default)",
 							Required:    true,
 						},
 					},
@@ -96,26 +252,1020 @@ func RegisterCommands(session *discordgo.Session) error {
 					Options: []*discordgo.ApplicationCommandOption{
 						{
 							Type:        discordgo.ApplicationCommandOptionNumber,
-							Name:        "ounces",
-							Description: "Amount of water in ounces to subtract",
+							Name:        "amount",
+							Description: "Amount of water to subtract, in your /preferences units (ounces by default)",
 							Required:    true,
 						},
 					},
 				},
 			},
 		},
-	}
-
-	// Register commands
-	logger.Info("Registering slash commands...")
-	for _, cmd := range commands {
-		_, err := session.ApplicationCommandCreate(session.State.User.ID, "", cmd)
-		if err != nil {
-			logger.Error("Cannot create command '%s': %v", cmd.Name, err)
-			return err
-		}
-		logger.Info("✅ Registered command: /%s", cmd.Name)
-	}
-
-	return nil
+		{
+			Name:        "preferences",
+			Description: "Your personal preferences",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "units",
+					Description: "View or change your weight and water units",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "weight",
+							Description: "Weight unit",
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Pounds (lbs)", Value: services.WeightUnitPounds},
+								{Name: "Kilograms (kg)", Value: services.WeightUnitKilograms},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "volume",
+							Description: "Water unit",
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Ounces (oz)", Value: services.VolumeUnitOunces},
+								{Name: "Liters (L)", Value: services.VolumeUnitLiters},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "view",
+					Description: "Show all of your preferences",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set",
+					Description: "Change one of your preferences",
+					Options: []*discordgo.ApplicationCommandOption{
+						preferenceOption(),
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "value",
+							Description: "New value, such as America/Chicago, kg, off, 07:30, anonymous, or dm",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "reset",
+					Description: "Put one of your preferences back to its default",
+					Options:     []*discordgo.ApplicationCommandOption{preferenceOption()},
+				},
+			},
+		},
+		{
+			Name:        "deletemydata",
+			Description: "Permanently delete all of your challenge data",
+		},
+		{
+			Name:        "exportmydata",
+			Description: "Receive a copy of all your challenge data by DM",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "format",
+					Description: "File format (defaults to JSON)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "JSON", Value: "json"},
+						{Name: "CSV", Value: "csv"},
+					},
+				},
+			},
+		},
+		{
+			Name:        "shortcut",
+			Description: "Log water and workouts from iOS Shortcuts or Tasker",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "key",
+					Description: "Create a new webhook key, replacing any old one",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "revoke",
+					Description: "Delete your webhook key so it stops working",
+				},
+			},
+		},
+		{
+			Name:        "calendar",
+			Description: "Subscribe to your challenge schedule in your calendar app",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "link",
+					Description: "Get your calendar feed URL",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "revoke",
+					Description: "Delete your calendar feed URL so it stops working",
+				},
+			},
+		},
+		{
+			Name:        "sms",
+			Description: "Get check-in reminders by text message",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Add your phone number and get a verification code",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "number",
+							Description: "Your number with its country code, like +15551234567",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "verify",
+					Description: "Confirm your number with the code texted to it",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "code",
+							Description: "The 6-digit code from the text",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Delete your number and stop getting texts",
+				},
+			},
+		},
+		{
+			Name:        "email",
+			Description: "Get a weekly progress report by email",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Add your email address and get a verification code",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "address",
+							Description: "The address to send reports to",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "verify",
+					Description: "Confirm your address with the code emailed to it",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "code",
+							Description: "The 6-digit code from the email",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "send",
+					Description: "Email yourself this week's report now",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Delete your address and stop getting reports",
+				},
+			},
+		},
+		{
+			Name:        "coach",
+			Description: "Get encouragement and suggestions based on your recent stats",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "question",
+					Description: "Something to ask the coach, like how to fit in water on busy days",
+					MaxLength:   300,
+				},
+			},
+		},
+		{
+			Name:        "undo",
+			Description: "Undo your last water, exercise, or check-in log from the past few minutes",
+		},
+		{
+			Name:        "edit",
+			Description: "Correct a recent day's workout, water, or diet",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "day",
+					Description: "Challenge day to correct",
+					Required:    true,
+					MinValue:    &minChallengeDay,
+				},
+			},
+		},
+		{
+			Name:        "adjust",
+			Description: "Add penalty days to a member's challenge or take them off (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Member whose end date to move",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "days",
+					Description: "Days to add, or a negative number to take days off",
+					Required:    true,
+					MinValue:    &minAdjustDays,
+					MaxValue:    maxAdjustDays,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Why, shown in the announcement and kept in the audit log",
+					Required:    true,
+					MaxLength:   200,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "silent",
+					Description: "Don't announce it in the check-in channel (default: false)",
+				},
+			},
+		},
+		{
+			Name:        "setstart",
+			Description: "Correct the date a member's challenge started (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Member whose start date to correct",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "date",
+					Description: "The correct start date, as YYYY-MM-DD",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Why, kept in the audit log",
+					Required:    true,
+					MaxLength:   200,
+				},
+			},
+		},
+		{
+			Name:        "setday",
+			Description: "Correct which challenge day a member is on today (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Member whose day to correct",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "day",
+					Description: "The challenge day today should be",
+					Required:    true,
+					MinValue:    &minChallengeDay,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Why, kept in the audit log",
+					Required:    true,
+					MaxLength:   200,
+				},
+			},
+		},
+		{
+			Name:        "backfill",
+			Description: "Log a feat a member did on a past day (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Member to log it for",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "feat",
+					Description: "Which feat to log",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Check-in (fills in every feat)", Value: "checkin"},
+						{Name: "Exercise", Value: "exercise"},
+						{Name: "Diet", Value: "diet"},
+						{Name: "Water", Value: "water"},
+						{Name: "Reading", Value: "reading"},
+						{Name: "Finances", Value: "finances"},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "date",
+					Description: "The day they did it, as YYYY-MM-DD",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Why, kept in the audit log",
+					Required:    true,
+					MaxLength:   200,
+				},
+			},
+		},
+		{
+			Name:        "connect",
+			Description: "Link a fitness app so your workouts are logged automatically",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "strava",
+					Description: "Link your Strava account",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "fitbit",
+					Description: "Link your Fitbit account",
+				},
+			},
+		},
+		{
+			Name:        "disconnect",
+			Description: "Unlink a fitness app",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "strava",
+					Description: "Unlink your Strava account",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "fitbit",
+					Description: "Unlink your Fitbit account",
+				},
+			},
+		},
+		{
+			Name:        "import",
+			Description: "Backfill your challenge from another app",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "apple-health",
+					Description: "Import workouts, water, and weight from an Apple Health export",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "file",
+							Description: "export.zip from the Health app, or a Health Auto Export JSON file",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "csv",
+					Description: "Import past days from a CSV of dates and the feats done on them",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "file",
+							Description: "CSV with a date or day column, then exercise, diet, water, reading, finances",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "dry-run",
+							Description: "Preview without saving (default: true)",
+							Required:    false,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "backup",
+			Description: "Database backup controls (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "now",
+					Description: "Take a database backup immediately",
+				},
+			},
+		},
+		{
+			Name:        "export",
+			Description: "Export challenge data (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "csv",
+					Description: "Every completion in a date range, as a zip of CSVs",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "from",
+							Description: "First date, as YYYY-MM-DD",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "to",
+							Description: "Last date, as YYYY-MM-DD",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "Only this member (default: everyone)",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "features",
+			Description: "Turn bot features on or off for this server (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "Show which features are on",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "enable",
+					Description: "Turn a feature on",
+					Options:     []*discordgo.ApplicationCommandOption{featureOption()},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "disable",
+					Description: "Turn a feature off",
+					Options:     []*discordgo.ApplicationCommandOption{featureOption()},
+				},
+			},
+		},
+		{
+			Name:        "botstats",
+			Description: "Show uptime, gateway, memory, database, and job stats (admin only)",
+		},
+		{
+			Name:        "settings",
+			Description: "Change bot settings live for this server (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "Show current settings",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set",
+					Description: "Set a channel without restarting the bot",
+					Options: []*discordgo.ApplicationCommandOption{
+						settingOption(),
+						{
+							Type:        discordgo.ApplicationCommandOptionChannel,
+							Name:        "channel",
+							Description: "Channel to use",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "language",
+					Description: "Change the language of the bot's messages",
+					Options:     []*discordgo.ApplicationCommandOption{languageOption()},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "timezone",
+					Description: "Change the time zone dates are shown in",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "timezone",
+							Description: "IANA time zone, e.g. America/Denver or Europe/Berlin",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "reset",
+					Description: "Go back to the environment config",
+					Options:     []*discordgo.ApplicationCommandOption{settingOption()},
+				},
+			},
+		},
+		{
+			Name:        "config",
+			Description: "Customize the bot for this server (admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "template",
+					Description: "Message templates",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show the templates and their variables",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Edit a template's text",
+							Options:     []*discordgo.ApplicationCommandOption{templateOption()},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "reset",
+							Description: "Go back to the built-in message",
+							Options:     []*discordgo.ApplicationCommandOption{templateOption()},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "webhook",
+					Description: "Webhooks that receive challenge events as JSON",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "add",
+							Description: "Register a URL to receive events",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "url",
+									Description: "http(s) URL to POST events to",
+									Required:    true,
+								},
+								webhookEventOption(),
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "format",
+									Description: "Payload shape (default: standard)",
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "standard", Value: services.WebhookFormatStandard},
+										{Name: "flat (Zapier, Make)", Value: services.WebhookFormatFlat},
+									},
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "Show this server's webhooks",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "remove",
+							Description: "Stop sending events to a webhook",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "id",
+									Description: "Webhook ID from /config webhook list",
+									Required:    true,
+									MinValue:    &minWebhookID,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "test",
+							Description: "Send sample events to a webhook now",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "id",
+									Description: "Webhook ID from /config webhook list",
+									Required:    true,
+									MinValue:    &minWebhookID,
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "sheet",
+					Description: "Google Sheet mirroring check-ins, feats, and weigh-ins",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Mirror this server's challenge data into a spreadsheet",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "spreadsheet",
+									Description: "Google Sheets URL or spreadsheet ID",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "status",
+							Description: "Show the spreadsheet and when it last synced",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "sync",
+							Description: "Sync the spreadsheet now",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "clear",
+							Description: "Stop mirroring (the spreadsheet keeps its data)",
+						},
+					},
+				},
+			},
+		},
+	})
+
+}
+
+// discordLocales maps each translated catalog to the Discord client languages that use it
+var discordLocales = map[string][]discordgo.Locale{
+	i18n.Spanish: {discordgo.SpanishES, discordgo.SpanishLATAM},
+}
+
+// localizeCommands fills in description translations from the command.* catalog
+// entries, so Discord shows each user the commands in their client language
+func localizeCommands(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	for _, cmd := range commands {
+		key := "command." + cmd.Name
+		if localizations := descriptionLocalizations(key); localizations != nil {
+			cmd.DescriptionLocalizations = &localizations
+		}
+		localizeOptions(key, cmd.Options)
+	}
+	return commands
+}
+
+// localizeOptions walks subcommands and options, keyed by their path under the command
+func localizeOptions(parent string, options []*discordgo.ApplicationCommandOption) {
+	for _, option := range options {
+		key := parent + "." + option.Name
+		option.DescriptionLocalizations = descriptionLocalizations(key)
+		localizeOptions(key, option.Options)
+	}
+}
+
+// descriptionLocalizations returns the translations of key, or nil when there are none
+func descriptionLocalizations(key string) map[discordgo.Locale]string {
+	localizations := map[discordgo.Locale]string{}
+	for locale, discordLocales := range discordLocales {
+		description, ok := i18n.Lookup(locale, key)
+		if !ok {
+			continue
+		}
+		for _, discordLocale := range discordLocales {
+			localizations[discordLocale] = description
+		}
+	}
+	if len(localizations) == 0 {
+		return nil
+	}
+	return localizations
+}
+
+// settingOption is the setting choice shared by /settings set and reset
+func settingOption() *discordgo.ApplicationCommandOption {
+	names := make([]string, 0, len(services.SettingDescriptions))
+	for name := range services.SettingDescriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Stable order so the command fingerprint doesn't churn
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(names))
+	for _, name := range names {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "setting",
+		Description: "Setting to change",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// preferenceOption is the preference choice shared by /preferences set and reset
+func preferenceOption() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(services.PreferenceDescriptions))
+	for _, name := range services.PreferenceNames() { // Sorted so the command fingerprint doesn't churn
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "preference",
+		Description: "Preference to change",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// templateOption is the template choice shared by /config template set and reset
+func templateOption() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(services.TemplateVariables))
+	for _, name := range services.TemplateNames() { // Sorted so the command fingerprint doesn't churn
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "template",
+		Description: "Template to change",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// webhookEventOption is the event choice for /config webhook add; leaving it out
+// subscribes to every event
+func webhookEventOption() *discordgo.ApplicationCommandOption {
+	choices := []*discordgo.ApplicationCommandOptionChoice{{Name: "all", Value: services.WebhookAllEvents}}
+	for _, name := range services.WebhookEvents {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "event",
+		Description: "Event to send (default: all)",
+		Choices:     choices,
+	}
+}
+
+// minChallengeDay is the first challenge day /edit and /setday accept
+var minChallengeDay = 1.0
+
+// minAdjustDays and maxAdjustDays bound the days /adjust moves an end date by
+var (
+	minAdjustDays = -365.0
+	maxAdjustDays = 365.0
+)
+
+// minPurchaseAmount is the smallest amount /finances purchase accepts
+var minPurchaseAmount = 0.01
+
+// minBookPages is the fewest pages /reading book and /reading pages accept
+var minBookPages = 1.0
+
+// purchaseCategoryOption is the category choice for /finances purchase
+func purchaseCategoryOption() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(services.PurchaseCategories))
+	for _, category := range services.PurchaseCategories {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: category, Value: category})
+	}
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "category",
+		Description: "What it was for",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// minWebhookID is the smallest webhook ID /config webhook remove and test accept
+var minWebhookID = 1.0
+
+// languageOption is the language choice for /settings language
+func languageOption() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(i18n.Names))
+	for _, locale := range i18n.Supported() { // Sorted so the command fingerprint doesn't churn
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: i18n.Names[locale], Value: locale})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "language",
+		Description: "Language to use",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// featureOption is the feature choice shared by /features enable and disable
+func featureOption() *discordgo.ApplicationCommandOption {
+	names := make([]string, 0, len(services.FeatureDefaults))
+	for name := range services.FeatureDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Stable order so the command fingerprint doesn't churn
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(names))
+	for _, name := range names {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "feature",
+		Description: "Feature to change",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+// RegisterCommands syncs slash commands with Discord in a single bulk overwrite.
+// Currently registered commands are diffed against the definitions first: nothing is sent
+// when they already match, and commands that no longer exist are removed by the overwrite.
+// An empty guildID registers commands globally; otherwise they are scoped to that guild.
+func RegisterCommands(session *discordgo.Session, guildID string) error {
+	appID := session.State.User.ID
+	commands := commandDefinitions()
+
+	scope := "global"
+	if guildID != "" {
+		scope = "guild " + guildID
+	}
+
+	logger.Info("Syncing %s slash commands...", scope)
+	existing, err := session.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to list registered commands: %w", err)
+	}
+
+	added, changed, removed := diffCommands(existing, commands)
+	if len(added) == 0 && len(changed) == 0 && len(removed) == 0 {
+		logger.Info("✅ %s slash commands up to date (%d registered)", scope, len(commands))
+		return nil
+	}
+
+	for _, name := range added {
+		logger.Info("➕ Adding command: /%s", name)
+	}
+	for _, name := range changed {
+		logger.Info("🔄 Updating command: /%s", name)
+	}
+	for _, name := range removed {
+		logger.Info("➖ Removing stale command: /%s", name)
+	}
+
+	if _, err := session.ApplicationCommandBulkOverwrite(appID, guildID, commands); err != nil {
+		logger.Error("Cannot bulk overwrite commands: %v", err)
+		return err
+	}
+
+	logger.Info("✅ Registered %d %s slash commands (%d added, %d updated, %d removed)",
+		len(commands), scope, len(added), len(changed), len(removed))
+	return nil
+}
+
+// SyncCommands registers slash commands over REST without opening a gateway session,
+// so commands can be (re)registered without starting the bot. Uses the same scope
+// and stale-command cleanup as Start.
+func SyncCommands(cfg *config.Config) error {
+	session, err := discordgo.New("Bot " + cfg.DiscordBotToken)
+	if err != nil {
+		return fmt.Errorf("error creating Discord session: %w", err)
+	}
+
+	// RegisterCommands reads the application ID from the state Ready would fill in
+	user, err := session.User("@me")
+	if err != nil {
+		return fmt.Errorf("failed to look up bot user: %w", err)
+	}
+	session.State.User = user
+
+	b := &Bot{session: session, config: cfg}
+	if err := RegisterCommands(session, b.commandGuildID()); err != nil {
+		return err
+	}
+
+	if cfg.DiscordDevGuildID != "" {
+		otherScope := cfg.DiscordDevGuildID
+		if b.commandGuildID() != "" {
+			otherScope = ""
+		}
+		if err := CleanupStaleCommands(session, otherScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CleanupStaleCommands deletes commands registered in a scope that are no longer defined.
+// RegisterCommands already prunes the scope it syncs; this covers the other scope, e.g.
+// global commands left behind while developing against a test guild, or guild commands
+// left in the dev guild after switching back to global registration.
+func CleanupStaleCommands(session *discordgo.Session, guildID string) error {
+	appID := session.State.User.ID
+
+	scope := "global"
+	if guildID != "" {
+		scope = "guild " + guildID
+	}
+
+	existing, err := session.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to list %s commands: %w", scope, err)
+	}
+
+	defined := make(map[string]bool)
+	for _, cmd := range commandDefinitions() {
+		defined[cmd.Name] = true
+	}
+
+	for _, cmd := range existing {
+		if defined[cmd.Name] {
+			continue
+		}
+		if err := session.ApplicationCommandDelete(appID, guildID, cmd.ID); err != nil {
+			return fmt.Errorf("failed to delete stale %s command /%s: %w", scope, cmd.Name, err)
+		}
+		logger.Info("➖ Removed stale %s command: /%s", scope, cmd.Name)
+	}
+	return nil
+}
+
+// diffCommands compares registered commands with the desired definitions by name
+func diffCommands(existing, desired []*discordgo.ApplicationCommand) (added, changed, removed []string) {
+	registered := make(map[string]*discordgo.ApplicationCommand, len(existing))
+	for _, cmd := range existing {
+		registered[cmd.Name] = cmd
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, cmd := range desired {
+		wanted[cmd.Name] = true
+		current, ok := registered[cmd.Name]
+		if !ok {
+			added = append(added, cmd.Name)
+		} else if commandFingerprint(current) != commandFingerprint(cmd) {
+			changed = append(changed, cmd.Name)
+		}
+	}
+
+	for _, cmd := range existing {
+		if !wanted[cmd.Name] {
+			removed = append(removed, cmd.Name)
+		}
+	}
+
+	return added, changed, removed
+}
+
+// commandFingerprint serializes the user-facing parts of a command for comparison,
+// ignoring server-assigned fields like ID and version
+func commandFingerprint(cmd *discordgo.ApplicationCommand) string {
+	var permissions int64
+	if cmd.DefaultMemberPermissions != nil {
+		permissions = *cmd.DefaultMemberPermissions
+	}
+
+	var localizations map[discordgo.Locale]string
+	if cmd.DescriptionLocalizations != nil {
+		localizations = *cmd.DescriptionLocalizations
+	}
+
+	data, err := json.Marshal(struct {
+		Name                     string                                `json:"name"`
+		Description              string                                `json:"description"`
+		DescriptionLocalizations map[discordgo.Locale]string           `json:"description_localizations"`
+		Options                  []*discordgo.ApplicationCommandOption `json:"options"`
+		Permissions              int64                                 `json:"permissions"`
+	}{cmd.Name, cmd.Description, localizations, cmd.Options, permissions})
+	if err != nil {
+		// Unserializable definitions are treated as changed
+		return ""
+	}
+	return string(data)
 }
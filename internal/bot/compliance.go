@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// complianceCheckInterval is how often the compliance job looks for days that have
+// ended. Days end at midnight in each member's zone, so zones are closed one by one.
+const complianceCheckInterval = 5 * time.Minute
+
+// complianceService returns the registered compliance service, or nil without a database
+func (b *Bot) complianceService() *services.ComplianceService {
+	if b.db == nil {
+		return nil
+	}
+	for _, svc := range b.services.GetServices() {
+		if cs, ok := svc.(*services.ComplianceService); ok {
+			return cs
+		}
+	}
+	return nil
+}
+
+// startComplianceChecks closes out members' days shortly after midnight in their
+// zone, marking water missed for days that ended under the goal and posting what was
+// actually logged to the check-in channel
+func (b *Bot) startComplianceChecks() {
+	compliance := b.complianceService()
+	if compliance == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(complianceCheckInterval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled end-of-day compliance checks (checked every %s)", complianceCheckInterval)
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+
+			// Missed feats are penalties, which guilds can turn off live
+			if !b.featureEnabled(services.FeaturePenalties) {
+				continue
+			}
+			b.closeWaterDays(compliance)
+		}
+	}()
+}
+
+// closeWaterDays marks water missed for the days that just ended short of the goal
+// and posts the digest of them
+func (b *Bot) closeWaterDays(compliance *services.ComplianceService) {
+	now := time.Now()
+	shortfalls, err := compliance.CloseWaterDays(now)
+	if err != nil {
+		logger.Error("Failed to close water days: %v", err)
+		return
+	}
+	if len(shortfalls) == 0 {
+		return
+	}
+	logger.Info("💧 Marked water missed on %d days short of the goal", len(shortfalls))
+
+	checkInChannel := b.channels().CheckIn
+	if checkInChannel == "" {
+		return
+	}
+	digest := waterShortfallDigest(b.locale(), shortfalls)
+	if digest == "" {
+		return
+	}
+	// Closed days aren't found again, so each run's digest is its own announcement
+	key := "water_shortfalls:" + strconv.FormatInt(now.Unix(), 10)
+	for i, chunk := range discord.SplitMessage(digest, discord.MaxMessageLength) {
+		b.announce(key+":"+strconv.Itoa(i), checkInChannel, chunk)
+	}
+}
+
+// waterShortfallDigest lists each shortfall with the water actually logged, in the
+// member's units, or returns "" when every member in it is hidden from public posts
+func waterShortfallDigest(locale string, shortfalls []services.WaterShortfall) string {
+	var entries strings.Builder
+	for _, shortfall := range shortfalls {
+		if shortfall.Privacy == services.PrivacyHidden {
+			continue
+		}
+		units := shortfall.Units
+		entries.WriteString(i18n.T(locale, "compliance.water_entry",
+			services.PublicName(locale, shortfall.Privacy, shortfall.UserID, shortfall.Username),
+			shortfall.ChallengeDay, units.FromOunces(shortfall.TotalOunces),
+			units.FromOunces(services.WaterGoalOunces), units.Volume))
+	}
+	if entries.Len() == 0 {
+		return ""
+	}
+	return i18n.T(locale, "compliance.water_digest") + entries.String()
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// dailyCheckInCheckInterval is how often the bot looks for a new day in the guild's zone.
+// Reactions are dated by the message they're on, so each day needs its own message soon
+// after midnight.
+const dailyCheckInCheckInterval = time.Minute
+
+// startDailyCheckIns posts a new check-in message when the day rolls over in the guild's
+// zone. Start posts the first day's; a failed post is retried on the next tick.
+func (b *Bot) startDailyCheckIns() {
+	go func() {
+		ticker := time.NewTicker(dailyCheckInCheckInterval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled daily check-in messages at midnight (%s)", b.timezone())
+		lastDate := time.Now().In(b.timezone()).Format("2006-01-02")
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+
+			// The guild's zone can change live, so look it up on every tick
+			today := time.Now().In(b.timezone()).Format("2006-01-02")
+			if today == lastDate {
+				continue
+			}
+			// A no-op when today's message is already pinned, e.g. after a reconnect
+			if err := b.ensureCheckInMessage(b.channels().CheckIn); err != nil {
+				logger.Error("❌ Failed to post the check-in message for %s: %v", today, err)
+				continue
+			}
+			lastDate = today
+		}
+	}()
+}
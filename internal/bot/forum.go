@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+const (
+	// weeklyRecapWeekday and weeklyRecapHour set when recaps go out, in the guild's time zone
+	weeklyRecapWeekday = time.Sunday
+	weeklyRecapHour    = 20
+	// weeklyRecapCheckInterval is how often the recap job checks whether it's due
+	weeklyRecapCheckInterval = time.Hour
+	// weeklyRecapMealWindow is how far back the meals listed in a recap go
+	weeklyRecapMealWindow = 7 * 24 * time.Hour
+)
+
+// startWeeklyRecaps posts each participant's progress summary to their forum post once a week
+func (b *Bot) startWeeklyRecaps() {
+	if b.db == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(weeklyRecapCheckInterval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled weekly forum recaps for %s %02d:00 (%s)", weeklyRecapWeekday, weeklyRecapHour, b.timezone())
+		lastYear, lastWeek := 0, 0
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+
+			// The guild's zone can change live, so look it up on every tick
+			now := time.Now().In(b.timezone())
+			year, week := now.ISOWeek()
+			if now.Weekday() != weeklyRecapWeekday || now.Hour() < weeklyRecapHour || (year == lastYear && week == lastWeek) {
+				continue
+			}
+			// Email reports ride along with the recaps but don't need a forum channel.
+			// Addresses already sent this week are skipped, so retrying each tick is safe.
+			if b.featureEnabled(services.FeatureEmailReports) {
+				b.sendEmailReports()
+			}
+			// The forum channel can be set or cleared live, so check on every tick
+			if b.channels().Forum == "" || !b.featureEnabled(services.FeatureForum) {
+				continue
+			}
+
+			if err := b.PostWeeklyRecaps(); err != nil {
+				logger.Error("Weekly forum recaps failed: %v", err)
+				continue
+			}
+			lastYear, lastWeek = year, week
+		}
+	}()
+}
+
+// sendEmailReports emails members who added an address their weekly report, when
+// email is configured
+func (b *Bot) sendEmailReports() {
+	for _, svc := range b.services.GetServices() {
+		if reports, ok := svc.(*services.EmailReportService); ok {
+			if _, err := reports.SendWeekly(); err != nil {
+				logger.Error("Weekly email reports failed: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// PostWeeklyRecaps appends a progress summary to every participant's forum post
+func (b *Bot) PostWeeklyRecaps() error {
+	var forumService *services.ForumService
+	var summaryService *services.SummaryService
+	var mealService *services.MealService
+	var financeService *services.FinanceService
+	for _, svc := range b.services.GetServices() {
+		switch s := svc.(type) {
+		case *services.ForumService:
+			forumService = s
+		case *services.SummaryService:
+			summaryService = s
+		case *services.MealService:
+			mealService = s
+		case *services.FinanceService:
+			financeService = s
+		}
+	}
+	if forumService == nil || summaryService == nil {
+		return fmt.Errorf("forum or summary service not available")
+	}
+
+	threads, err := forumService.ListThreads(b.channels().Forum)
+	if err != nil {
+		return err
+	}
+
+	locale := b.locale()
+	posted := 0
+	for _, thread := range threads {
+		// Recaps are digest posts, which members who chose to be hidden are left out of
+		if b.userPrivacy(thread.UserID) == services.PrivacyHidden {
+			continue
+		}
+
+		summary, err := summaryService.GetProgressSummary(context.Background(), locale, thread.Username, thread.UserID)
+		if err != nil {
+			logger.Error("Failed to build weekly recap for user_id=%s: %v", thread.UserID, err)
+			continue
+		}
+
+		recap := i18n.T(locale, "forum.weekly_recap") + summary
+		if mealService != nil {
+			recap += mealRecap(mealService, locale, thread.UserID)
+		}
+		if financeService != nil {
+			recap += spendRecap(financeService, locale, thread.UserID)
+		}
+		for _, chunk := range discord.SplitMessage(recap, discord.MaxMessageLength) {
+			err = withRetry("post weekly recap", func(opts ...discordgo.RequestOption) error {
+				_, err := b.rest.ChannelMessageSend(thread.ThreadID, chunk, opts...)
+				return err
+			})
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to post weekly recap for user_id=%s: %v", thread.UserID, err)
+			continue
+		}
+		posted++
+	}
+
+	logger.Info("✅ Posted %d/%d weekly forum recaps", posted, len(threads))
+	return nil
+}
+
+// mealRecap lists the meals the user logged this week, with links to their photos, or
+// returns "" when they logged none
+func mealRecap(mealService *services.MealService, locale, userID string) string {
+	meals, err := mealService.RecentMeals(userID, weeklyRecapMealWindow)
+	if err != nil {
+		logger.Error("Failed to load meals for weekly recap for user_id=%s: %v", userID, err)
+		return ""
+	}
+	if len(meals) == 0 {
+		return ""
+	}
+
+	recap := i18n.T(locale, "forum.recap_meals")
+	for _, meal := range meals {
+		recap += i18n.T(locale, "forum.recap_meal", meal.ChallengeDay, meal.Description)
+		link, err := mealService.PhotoURL(meal)
+		if err != nil {
+			logger.Error("Failed to link meal photo %s: %v", meal.PhotoKey, err)
+		} else if link != "" {
+			recap += i18n.T(locale, "forum.recap_meal_photo", link)
+		}
+	}
+	return recap
+}
+
+// spendRecap totals the purchases the user logged this week, or returns "" when they
+// logged none
+func spendRecap(financeService *services.FinanceService, locale, userID string) string {
+	spend, err := financeService.WeeklySpend(userID)
+	if err != nil {
+		logger.Error("Failed to load spending for weekly recap for user_id=%s: %v", userID, err)
+		return ""
+	}
+	if spend.Purchases == 0 {
+		return ""
+	}
+	return "\n\n" + services.FormatWeeklySpend(locale, spend)
+}
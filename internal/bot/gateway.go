@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// gatewayMonitor tracks gateway disconnects so state can be recovered on reconnect
+type gatewayMonitor struct {
+	mu             sync.Mutex
+	connected      bool
+	disconnectedAt time.Time
+}
+
+// Connected reports whether the gateway websocket is up (for health probes)
+func (b *Bot) Connected() bool {
+	b.gateway.mu.Lock()
+	defer b.gateway.mu.Unlock()
+	return b.gateway.connected
+}
+
+// registerGatewayHandlers watches Disconnect/Resumed/Ready events and recovers
+// bot state after a reconnect. discordgo reconnects on its own; this makes sure
+// the day's check-in message and slash commands survived the outage.
+func (b *Bot) registerGatewayHandlers() {
+	monitor := b.gateway
+
+	b.session.AddHandler(recoverHandler("disconnect", func(s *discordgo.Session, d *discordgo.Disconnect) {
+		monitor.mu.Lock()
+		defer monitor.mu.Unlock()
+
+		monitor.connected = false
+		if monitor.disconnectedAt.IsZero() {
+			monitor.disconnectedAt = time.Now()
+		}
+		if !b.shutdown.ShuttingDown() {
+			logger.Warn("⚠️  Disconnected from Discord gateway - waiting for reconnect...")
+		}
+	}))
+
+	b.session.AddHandler(recoverHandler("resumed", func(s *discordgo.Session, r *discordgo.Resumed) {
+		b.recoverAfterReconnect(monitor, "resumed")
+	}))
+
+	// A reconnect that can't resume starts a fresh session and fires Ready instead
+	b.session.AddHandler(recoverHandler("ready", func(s *discordgo.Session, r *discordgo.Ready) {
+		b.recoverAfterReconnect(monitor, "re-identified")
+	}))
+}
+
+// recoverAfterReconnect logs the outage and re-verifies state if we were disconnected
+func (b *Bot) recoverAfterReconnect(monitor *gatewayMonitor, how string) {
+	monitor.mu.Lock()
+	disconnectedAt := monitor.disconnectedAt
+	monitor.connected = true
+	monitor.disconnectedAt = time.Time{}
+	monitor.mu.Unlock()
+
+	// Initial Ready on startup, nothing to recover
+	if disconnectedAt.IsZero() || b.shutdown.ShuttingDown() {
+		return
+	}
+
+	gap := time.Since(disconnectedAt).Round(time.Second)
+	logger.Info("🔌 Gateway %s after %s offline - verifying bot state", how, gap)
+
+	// Events during the gap (reactions, commands) were not delivered; make that visible
+	if gap > time.Minute {
+		logger.Warn("⚠️  Gateway was offline for %s - reactions and commands in that window may have been missed", gap)
+	}
+
+	if err := b.ensureCheckInMessage(b.channels().CheckIn); err != nil {
+		logger.Error("Failed to verify check-in message after reconnect: %v", err)
+	}
+
+	// Diff-aware, so this is a no-op unless commands were lost
+	if err := RegisterCommands(b.session, b.commandGuildID()); err != nil {
+		logger.Error("Failed to re-register commands after reconnect: %v", err)
+	}
+}
+
+// ensureCheckInMessage re-sends today's check-in message if it is no longer pinned
+func (b *Bot) ensureCheckInMessage(channelID string) error {
+	var pins []*discordgo.Message
+	err := withRetry("list pinned messages", func(opts ...discordgo.RequestOption) error {
+		var err error
+		pins, err = b.rest.ChannelMessagesPinned(channelID, opts...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	header := checkInHeader(b.locale(), b.timezone(), time.Now())
+	botID := b.session.State.User.ID
+	for _, pin := range pins {
+		if pin.Author != nil && pin.Author.ID == botID && strings.Contains(pin.Content, header) {
+			b.checkInMessages.Remember(pin.ID, pin.Timestamp)
+			logger.Info("✅ Today's check-in message is still pinned (message_id=%s)", pin.ID)
+			return nil
+		}
+	}
+
+	logger.Info("📌 Today's check-in message is missing - sending a new one")
+	return b.SendCheckInMessage(channelID)
+}
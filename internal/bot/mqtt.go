@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/mqtt"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// mqttPayload is the JSON body of every MQTT message
+type mqttPayload struct {
+	Event      string                 `json:"event"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// subscribeMQTT publishes check-ins, water, weigh-ins, and finished challenges to the
+// MQTT broker, one topic per event: {prefix}/check_in/recorded, {prefix}/water/logged,
+// {prefix}/weigh_in/recorded, and {prefix}/challenge/completed
+func (b *Bot) subscribeMQTT() {
+	if b.mqtt == nil {
+		return
+	}
+
+	b.events.Subscribe(events.CheckInRecordedEvent, func(event events.Event) {
+		checkIn := event.(events.CheckInRecorded)
+		if !checkIn.FirstForDay {
+			return
+		}
+		b.publishMQTT(event.Name(), checkIn.UserID, checkIn.Username, map[string]interface{}{
+			"challenge_day": checkIn.ChallengeDay,
+			"date":          checkIn.Date.Format("2006-01-02"),
+		})
+	})
+
+	b.events.Subscribe(events.WaterLoggedEvent, func(event events.Event) {
+		water := event.(events.WaterLogged)
+		b.publishMQTT(event.Name(), water.UserID, water.Username, map[string]interface{}{
+			"challenge_day": water.ChallengeDay,
+			"total_ounces":  round(water.TotalOunces, 1),
+			"goal_ounces":   services.WaterGoalOunces,
+			"percent":       math.Floor(100 * water.TotalOunces / services.WaterGoalOunces),
+			"goal_reached":  water.GoalReached,
+		})
+	})
+
+	b.events.Subscribe(events.WeighInRecordedEvent, func(event events.Event) {
+		weighIn := event.(events.WeighInRecorded)
+		b.publishMQTT(event.Name(), weighIn.UserID, weighIn.Username, map[string]interface{}{
+			"challenge_day": weighIn.ChallengeDay,
+			"weight_lbs":    round(weighIn.Pounds, 1),
+			"weight_kg":     round(services.Units{Weight: services.WeightUnitKilograms}.FromPounds(weighIn.Pounds), 1),
+		})
+	})
+
+	b.events.Subscribe(events.ChallengeCompletedEvent, func(event events.Event) {
+		completed := event.(events.ChallengeCompleted)
+		b.publishMQTT(event.Name(), completed.UserID, completed.Username, map[string]interface{}{
+			"total_days": completed.TotalDays,
+		})
+	})
+}
+
+// publishMQTT queues one event for the broker. Payloads follow the user's privacy
+// preference like webhooks do: hidden users aren't published and anonymous users
+// appear under their pseudonym only.
+func (b *Bot) publishMQTT(event, userID, username string, data map[string]interface{}) {
+	switch privacy := b.userPrivacy(userID); privacy {
+	case services.PrivacyHidden:
+		return
+	case services.PrivacyAnonymous:
+		data["username"] = services.PublicName(b.locale(), privacy, userID, username)
+	default:
+		data["user_id"] = userID
+		if username != "" {
+			data["username"] = username
+		}
+	}
+
+	payload, err := json.Marshal(mqttPayload{Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		logger.Error("Failed to encode %s MQTT payload: %v", event, err)
+		return
+	}
+	b.mqtt.Publish(mqtt.Message{
+		Topic:   b.config.MQTT.TopicPrefix + "/" + strings.ReplaceAll(event, ".", "/"),
+		Payload: payload,
+	})
+}
+
+// round rounds x to the given number of decimal places
+func round(x float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(x*scale) / scale
+}
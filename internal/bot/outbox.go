@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+const (
+	// outboxPollInterval is how often the dispatcher looks for pending announcements
+	outboxPollInterval = 5 * time.Second
+	// outboxBatchSize caps deliveries per poll so a backlog drains gradually
+	outboxBatchSize = 20
+)
+
+// outboxService returns the registered outbox, or nil without a database
+func (b *Bot) outboxService() *services.OutboxService {
+	if b.db == nil {
+		return nil
+	}
+	for _, svc := range b.services.GetServices() {
+		if os, ok := svc.(*services.OutboxService); ok {
+			return os
+		}
+	}
+	return nil
+}
+
+// announce queues a public announcement for reliable delivery, falling back to a
+// direct send when the outbox isn't available
+func (b *Bot) announce(dedupeKey, channelID, content string) {
+	if outbox := b.outboxService(); outbox != nil {
+		err := outbox.Enqueue(dedupeKey, channelID, content)
+		if err == nil {
+			return
+		}
+		logger.Error("Failed to queue announcement %s, sending directly: %v", dedupeKey, err)
+	}
+
+	err := withRetry("send announcement", func(opts ...discordgo.RequestOption) error {
+		_, err := b.rest.ChannelMessageSend(channelID, content, opts...)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to send announcement %s: %v", dedupeKey, err)
+	}
+}
+
+// startOutboxDispatcher delivers queued announcements until the bot stops
+func (b *Bot) startOutboxDispatcher() {
+	outbox := b.outboxService()
+	if outbox == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+
+		for {
+			b.dispatchOutbox(outbox)
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+		}
+	}()
+}
+
+// dispatchOutbox sends one batch of due announcements and webhook deliveries
+func (b *Bot) dispatchOutbox(outbox *services.OutboxService) {
+	pending, err := outbox.Pending(outboxBatchSize)
+	if err != nil {
+		logger.Error("Failed to read announcement outbox: %v", err)
+		return
+	}
+
+	for _, announcement := range pending {
+		var messageID string
+		var err error
+		if announcement.WebhookID != 0 {
+			err = services.DeliverWebhook(announcement.WebhookURL, announcement.WebhookSecret, []byte(announcement.Content))
+		} else {
+			err = withRetry("deliver announcement", func(opts ...discordgo.RequestOption) error {
+				msg, err := b.rest.ChannelMessageSend(announcement.ChannelID, announcement.Content, opts...)
+				if err == nil {
+					messageID = msg.ID
+				}
+				return err
+			})
+		}
+		if err != nil {
+			abandoned, markErr := outbox.MarkFailed(announcement, err)
+			if markErr != nil {
+				logger.Error("%v", markErr)
+			}
+			if abandoned {
+				logger.Error("❌ Gave up delivering announcement %d after %d attempts: %v", announcement.ID, services.OutboxMaxAttempts, err)
+			} else {
+				logger.Warn("⚠️  Announcement %d delivery failed (attempt %d), will retry: %v", announcement.ID, announcement.Attempts+1, err)
+			}
+			continue
+		}
+
+		if err := outbox.MarkSent(announcement.ID, messageID); err != nil {
+			// Delivered but not recorded; it would be re-sent, so make this loud
+			logger.Error("❌ Announcement %d delivered but not marked sent: %v", announcement.ID, err)
+			continue
+		}
+		if announcement.WebhookID != 0 {
+			logger.Info("🔗 Delivered announcement %d to webhook %d", announcement.ID, announcement.WebhookID)
+			continue
+		}
+		logger.Info("📣 Delivered announcement %d to channel_id=%s", announcement.ID, announcement.ChannelID)
+	}
+}
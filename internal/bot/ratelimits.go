@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+const (
+	// rateLimitReportInterval is how often rate-limit stats are logged and reset
+	rateLimitReportInterval = time.Minute
+	// rateLimitAlertThreshold is how many 429s in one interval count as heavy throttling
+	rateLimitAlertThreshold = 20
+	// rateLimitAlertCooldown keeps a long reaction storm from flooding the admin channel
+	rateLimitAlertCooldown = 15 * time.Minute
+)
+
+// snowflakePattern matches Discord IDs in REST paths so stats group by route
+var snowflakePattern = regexp.MustCompile(`\d{15,}`)
+
+// rateLimitStats counts Discord REST rate limiting seen since the last report
+type rateLimitStats struct {
+	mu        sync.Mutex
+	limited   int            // 429 responses
+	global    int            // 429s that hit the global limit rather than a route bucket
+	exhausted int            // Responses that used up a bucket (X-RateLimit-Remaining: 0)
+	waited    time.Duration  // Total Retry-After Discord asked for
+	routes    map[string]int // 429s by route
+}
+
+// rateLimitSnapshot is one interval's stats
+type rateLimitSnapshot struct {
+	limited   int
+	global    int
+	exhausted int
+	waited    time.Duration
+	routes    map[string]int
+}
+
+// rateLimitTransport records rate-limit responses from every REST call the session makes
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	stats *rateLimitStats
+}
+
+// newRateLimitTransport wraps base, recording into stats
+func newRateLimitTransport(base http.RoundTripper, stats *rateLimitStats) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{base: base, stats: stats}
+}
+
+// RoundTrip passes the request through and inspects the rate-limit headers on the response
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		var retryAfter time.Duration
+		if seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+			retryAfter = time.Duration(seconds * float64(time.Second))
+		}
+		t.stats.recordLimited(req.Method+" "+snowflakePattern.ReplaceAllString(req.URL.Path, ":id"),
+			resp.Header.Get("X-RateLimit-Global") == "true", retryAfter)
+	case resp.Header.Get("X-RateLimit-Remaining") == "0":
+		t.stats.recordExhausted()
+	}
+	return resp, nil
+}
+
+// recordLimited counts a 429
+func (s *rateLimitStats) recordLimited(route string, global bool, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.routes == nil {
+		s.routes = make(map[string]int)
+	}
+	s.limited++
+	if global {
+		s.global++
+	}
+	s.waited += retryAfter
+	s.routes[route]++
+}
+
+// recordExhausted counts a response that emptied its bucket; discordgo waits before the next call
+func (s *rateLimitStats) recordExhausted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exhausted++
+}
+
+// snapshot returns the stats so far and starts a new interval
+func (s *rateLimitStats) snapshot() rateLimitSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := rateLimitSnapshot{
+		limited:   s.limited,
+		global:    s.global,
+		exhausted: s.exhausted,
+		waited:    s.waited,
+		routes:    s.routes,
+	}
+	s.limited, s.global, s.exhausted, s.waited, s.routes = 0, 0, 0, 0, nil
+	return snapshot
+}
+
+// topRoutes formats the most rate-limited routes, e.g. "PUT /channels/:id/... (12)"
+func (s rateLimitSnapshot) topRoutes(n int) string {
+	routes := make([]string, 0, len(s.routes))
+	for route := range s.routes {
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if s.routes[routes[i]] != s.routes[routes[j]] {
+			return s.routes[routes[i]] > s.routes[routes[j]]
+		}
+		return routes[i] < routes[j]
+	})
+	if len(routes) > n {
+		routes = routes[:n]
+	}
+	for i, route := range routes {
+		routes[i] = fmt.Sprintf("%s (%d)", route, s.routes[route])
+	}
+	return strings.Join(routes, ", ")
+}
+
+// startRateLimitReports logs rate-limit stats every interval and warns admins when
+// the bot is being throttled heavily
+func (b *Bot) startRateLimitReports() {
+	go func() {
+		ticker := time.NewTicker(rateLimitReportInterval)
+		defer ticker.Stop()
+
+		var lastAlert time.Time
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+
+			stats := b.rateLimits.snapshot()
+			if stats.limited == 0 {
+				if stats.exhausted > 0 {
+					logger.Debug("Discord rate-limit buckets exhausted %d times in the last %s", stats.exhausted, rateLimitReportInterval)
+				}
+				continue
+			}
+
+			logger.With("rate_limited", stats.limited, "global", stats.global, "exhausted", stats.exhausted,
+				"retry_after_ms", stats.waited.Milliseconds()).
+				Warn("⚠️  Discord rate limited %d requests in the last %s (%d global, %s total retry-after); top routes: %s",
+					stats.limited, rateLimitReportInterval, stats.global, stats.waited.Round(time.Millisecond), stats.topRoutes(3))
+
+			if stats.limited >= rateLimitAlertThreshold && time.Since(lastAlert) >= rateLimitAlertCooldown {
+				lastAlert = time.Now()
+				b.AlertAdmins(fmt.Sprintf("Discord is throttling the bot: %d requests were rate limited in the last %s (%d global). Replies and check-in confirmations may be delayed. Top routes: %s",
+					stats.limited, rateLimitReportInterval, stats.global, stats.topRoutes(3)))
+			}
+		}
+	}()
+}
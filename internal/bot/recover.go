@@ -0,0 +1,24 @@
+package bot
+
+import (
+	"runtime/debug"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// recoverHandler wraps a discordgo event handler so a panic is logged with its stack
+// (and reported to the error tracker when one is configured) instead of killing the bot.
+// Interactions also get RecoverMiddleware, which apologizes to the user.
+func recoverHandler[E any](name string, handler func(*discordgo.Session, E)) func(*discordgo.Session, E) {
+	return func(s *discordgo.Session, event E) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.With("panic", true, "handler", name, "stack", string(debug.Stack())).
+					Error("Panic in %s handler: %v", name, r)
+			}
+		}()
+
+		handler(s, event)
+	}
+}
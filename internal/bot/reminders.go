@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// reminderCheckInterval is how often the reminder job looks for due reminders.
+// Reminder times are per minute, so checking more often gains nothing.
+const reminderCheckInterval = time.Minute
+
+// reminderService returns the registered reminder service, or nil without a database
+func (b *Bot) reminderService() *services.ReminderService {
+	if b.db == nil {
+		return nil
+	}
+	for _, svc := range b.services.GetServices() {
+		if rs, ok := svc.(*services.ReminderService); ok {
+			return rs
+		}
+	}
+	return nil
+}
+
+// smsService returns the registered SMS service, or nil when Twilio isn't configured
+func (b *Bot) smsService() *services.SMSService {
+	for _, svc := range b.services.GetServices() {
+		if ss, ok := svc.(*services.SMSService); ok {
+			return ss
+		}
+	}
+	return nil
+}
+
+// startReminders nudges participants who haven't checked in by their reminder time,
+// by DM, SMS, or a mention in the check-in channel, outside their quiet hours
+func (b *Bot) startReminders() {
+	reminders := b.reminderService()
+	if reminders == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(reminderCheckInterval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled check-in reminders (checked every %s)", reminderCheckInterval)
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+
+			// The feature can be toggled live, so check on every tick
+			if !b.featureEnabled(services.FeatureReminders) {
+				continue
+			}
+			b.sendReminders(reminders)
+		}
+	}()
+}
+
+// sendReminders delivers every reminder that is due now
+func (b *Bot) sendReminders(reminders *services.ReminderService) {
+	due, err := reminders.Due(time.Now())
+	if err != nil {
+		logger.Error("Failed to load due reminders: %v", err)
+		return
+	}
+
+	locale := b.locale()
+	for _, reminder := range due {
+		// Channel delivery is also the fallback when a member's DMs are closed or a text fails
+		var delivered bool
+		switch reminder.Delivery {
+		case services.DeliveryDM:
+			delivered = b.sendReminderDM(locale, reminder)
+		case services.DeliverySMS:
+			delivered = b.sendReminderSMS(locale, reminder)
+		}
+		if !delivered {
+			checkInChannel := b.channels().CheckIn
+			if checkInChannel == "" {
+				continue
+			}
+			content := i18n.T(locale, "reminder.channel", reminder.UserID, reminder.ChallengeDay)
+			b.announce("reminder:"+reminder.UserID+":"+reminder.Date.Format("2006-01-02"), checkInChannel, content)
+		}
+
+		if err := reminders.MarkSent(reminder.UserID, reminder.Date); err != nil {
+			logger.Error("Failed to record reminder for user_id=%s: %v", reminder.UserID, err)
+		}
+	}
+	if len(due) > 0 {
+		logger.Info("⏰ Sent %d check-in reminders", len(due))
+	}
+}
+
+// sendReminderDM sends a reminder by DM and reports whether it was delivered
+func (b *Bot) sendReminderDM(locale string, reminder services.Reminder) bool {
+	channel, err := b.rest.UserChannelCreate(reminder.UserID)
+	if err != nil {
+		logger.Warn("Failed to open DM channel for user_id=%s, reminding in the channel: %v", reminder.UserID, err)
+		return false
+	}
+
+	err = withRetry("send reminder DM", func(opts ...discordgo.RequestOption) error {
+		_, err := b.rest.ChannelMessageSend(channel.ID, i18n.T(locale, "reminder.dm", reminder.ChallengeDay), opts...)
+		return err
+	})
+	if err != nil {
+		logger.Warn("Failed to DM reminder to user_id=%s, reminding in the channel: %v", reminder.UserID, err)
+		return false
+	}
+	return true
+}
+
+// sendReminderSMS texts a reminder to the member's verified number and reports whether
+// it was sent
+func (b *Bot) sendReminderSMS(locale string, reminder services.Reminder) bool {
+	sms := b.smsService()
+	if sms == nil {
+		return false
+	}
+
+	err := sms.Send(reminder.UserID, i18n.T(locale, "reminder.sms", reminder.ChallengeDay))
+	if errors.Is(err, services.ErrSMSNotVerified) {
+		logger.Warn("No verified phone number for user_id=%s, reminding in the channel", reminder.UserID)
+		return false
+	}
+	if err != nil {
+		logger.Warn("Failed to text reminder to user_id=%s, reminding in the channel: %v", reminder.UserID, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+const (
+	// restMaxAttempts is how many times a REST call is tried before giving up
+	restMaxAttempts = 5
+	// restBaseBackoff is the first backoff delay; it doubles on each attempt
+	restBaseBackoff = 500 * time.Millisecond
+	// restMaxBackoff caps any single wait, including server-requested rate-limit waits
+	restMaxBackoff = 30 * time.Second
+)
+
+// restCall performs one Discord REST request with the given request options
+type restCall func(opts ...discordgo.RequestOption) error
+
+// withRetry runs a Discord REST call, retrying rate limits (429) and transient
+// failures (5xx, network errors) with exponential backoff. Rate-limit waits use
+// the retry_after / Retry-After value Discord sends. Other errors (403, 404, ...)
+// are returned immediately since retrying won't help.
+func withRetry(op string, call restCall) error {
+	var err error
+	for attempt := 1; attempt <= restMaxAttempts; attempt++ {
+		// Surface 429s to us instead of discordgo sleeping on them indefinitely
+		err = call(discordgo.WithRetryOnRatelimit(false))
+		if err == nil {
+			return nil
+		}
+
+		wait, retryable := retryDelay(err, attempt)
+		if !retryable || attempt == restMaxAttempts {
+			break
+		}
+
+		logger.Warn("⚠️  %s failed (attempt %d/%d), retrying in %s: %v", op, attempt, restMaxAttempts, wait, err)
+		time.Sleep(wait)
+	}
+	return err
+}
+
+// retryDelay decides whether err is worth retrying and how long to wait first
+func retryDelay(err error, attempt int) (time.Duration, bool) {
+	backoff := restBaseBackoff << (attempt - 1)
+	if backoff > restMaxBackoff {
+		backoff = restMaxBackoff
+	}
+
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+		return capBackoff(rateLimitErr.RetryAfter, backoff), true
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		status := restErr.Response.StatusCode
+		switch {
+		case status == http.StatusTooManyRequests:
+			if seconds, parseErr := strconv.ParseFloat(restErr.Response.Header.Get("Retry-After"), 64); parseErr == nil {
+				return capBackoff(time.Duration(seconds*float64(time.Second)), backoff), true
+			}
+			return backoff, true
+		case status >= 500:
+			return backoff, true
+		}
+		return 0, false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return backoff, true
+	}
+
+	return 0, false
+}
+
+// capBackoff uses the server-requested wait when present, bounded by restMaxBackoff
+func capBackoff(retryAfter, fallback time.Duration) time.Duration {
+	if retryAfter <= 0 {
+		return fallback
+	}
+	if retryAfter > restMaxBackoff {
+		return restMaxBackoff
+	}
+	return retryAfter
+}
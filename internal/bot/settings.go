@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/config"
+	"github.com/75-hard-discord-bot/internal/handlers"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// channels returns the channel routing in effect: the environment config with any
+// guild settings applied on top
+func (b *Bot) channels() config.ChannelConfig {
+	b.channelsMu.RLock()
+	defer b.channelsMu.RUnlock()
+	return b.liveChannels
+}
+
+// locale returns the home guild's language for the bot's own posts
+func (b *Bot) locale() string {
+	b.channelsMu.RLock()
+	defer b.channelsMu.RUnlock()
+	return b.liveLocale
+}
+
+// timezone returns the home guild's time zone for dates in the bot's own posts
+func (b *Bot) timezone() *time.Location {
+	b.channelsMu.RLock()
+	defer b.channelsMu.RUnlock()
+	return b.liveTimezone
+}
+
+// guildTimezone returns a guild's time zone: its timezone setting, or BOT_TIMEZONE when unset
+func (b *Bot) guildTimezone(guildID string) *time.Location {
+	settingsService := b.settingsService()
+	if settingsService == nil || b.db == nil || guildID == "" {
+		return services.LoadTimezone(b.config.Timezone)
+	}
+
+	timezone, ok, err := settingsService.Get(guildID, services.SettingTimezone)
+	if err != nil {
+		logger.Error("Failed to load timezone for guild_id=%s: %v", guildID, err)
+	}
+	if !ok {
+		timezone = b.config.Timezone
+	}
+	return services.LoadTimezone(timezone)
+}
+
+// guildLocale returns a guild's language: its locale setting, or BOT_LOCALE when unset
+func (b *Bot) guildLocale(guildID string) string {
+	settingsService := b.settingsService()
+	if settingsService == nil || b.db == nil || guildID == "" {
+		return b.config.Locale
+	}
+
+	locale, ok, err := settingsService.Get(guildID, services.SettingLocale)
+	if err != nil {
+		logger.Error("Failed to load locale for guild_id=%s: %v", guildID, err)
+	}
+	if !ok {
+		return b.config.Locale
+	}
+	return locale
+}
+
+// settingsService returns the guild settings service, or nil if it isn't registered
+func (b *Bot) settingsService() *services.SettingsService {
+	for _, svc := range b.services.GetServices() {
+		if ss, ok := svc.(*services.SettingsService); ok {
+			return ss
+		}
+	}
+	return nil
+}
+
+// reloadSettings applies the home guild's settings without a restart and returns the
+// channel routing before and after
+func (b *Bot) reloadSettings(forum *handlers.ForumPublisher) (previous, next config.ChannelConfig) {
+	previous = b.channels()
+	settingsService := b.settingsService()
+	if settingsService == nil || b.db == nil {
+		return previous, previous
+	}
+
+	settings, err := settingsService.All(b.homeGuildID())
+	if err != nil {
+		logger.Error("Failed to load guild settings: %v", err)
+		return previous, previous
+	}
+
+	next = b.config.Channels
+	overrides := map[string]*string{
+		services.SettingCheckInChannel: &next.CheckIn,
+		services.SettingSummaryChannel: &next.Summaries,
+		services.SettingPhotosChannel:  &next.Photos,
+		services.SettingAdminChannel:   &next.Admin,
+		services.SettingForumChannel:   &next.Forum,
+	}
+	for setting, target := range overrides {
+		if value, ok := settings[setting]; ok {
+			*target = value
+		}
+	}
+	locale := b.config.Locale
+	if value, ok := settings[services.SettingLocale]; ok {
+		locale = value
+	}
+	timezone := b.config.Timezone
+	if value, ok := settings[services.SettingTimezone]; ok {
+		timezone = value
+	}
+
+	b.channelsMu.Lock()
+	previous = b.liveChannels
+	b.liveChannels = next
+	b.liveLocale = locale
+	b.liveTimezone = services.LoadTimezone(timezone)
+	b.channelsMu.Unlock()
+
+	// Members who haven't chosen a zone follow the home guild's
+	for _, svc := range b.services.GetServices() {
+		if us, ok := svc.(*services.UserService); ok {
+			us.SetDefaultTimezone(timezone)
+		}
+	}
+
+	if next == previous {
+		return previous, next
+	}
+	logger.Info("🔄 Applied guild settings: checkin=%s summaries=%s photos=%s admin=%s forum=%s",
+		next.CheckIn, next.Summaries, next.Photos, next.Admin, next.Forum)
+
+	if next.Forum != previous.Forum {
+		forum.SetChannel(next.Forum)
+	}
+	return previous, next
+}
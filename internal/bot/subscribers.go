@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/handlers"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// subscribe registers the bot's reactions to service events
+func (b *Bot) subscribe(forum *handlers.ForumPublisher) {
+	// Mirror check-ins to the user's forum progress post
+	b.events.Subscribe(events.CheckInRecordedEvent, func(event events.Event) {
+		checkIn := event.(events.CheckInRecorded)
+		if !checkIn.FirstForDay {
+			return
+		}
+		content := b.renderTemplate(services.TemplateDayComplete,
+			i18n.T(b.locale(), "checkin.complete", checkIn.ChallengeDay),
+			map[string]string{
+				"user": "<@" + checkIn.UserID + ">",
+				"day":  fmt.Sprint(checkIn.ChallengeDay),
+				"date": b.templateDate(),
+			})
+		forum.Post(b.rest, b.homeGuildID(), checkIn.UserID, checkIn.Username, content)
+	})
+
+	// Announce finished challenges in the check-in channel
+	b.events.Subscribe(events.ChallengeCompletedEvent, func(event events.Event) {
+		completed := event.(events.ChallengeCompleted)
+		user := "<@" + completed.UserID + ">"
+		switch b.userPrivacy(completed.UserID) {
+		case services.PrivacyHidden:
+			return
+		case services.PrivacyAnonymous:
+			user = services.PublicName(b.locale(), services.PrivacyAnonymous, completed.UserID, completed.Username)
+		}
+
+		announcement := b.renderTemplate(services.TemplateChallengeComplete,
+			i18n.T(b.locale(), "challenge.complete", user, completed.TotalDays),
+			map[string]string{
+				"user": user,
+				"day":  fmt.Sprint(completed.TotalDays),
+				"date": b.templateDate(),
+			})
+
+		b.announce("challenge_completed:"+completed.UserID+":"+fmt.Sprint(completed.TotalDays),
+			b.channels().CheckIn, announcement)
+	})
+
+	// Celebrate finished books in the check-in channel
+	b.events.Subscribe(events.BookFinishedEvent, func(event events.Event) {
+		finished := event.(events.BookFinished)
+		user := "<@" + finished.UserID + ">"
+		switch b.userPrivacy(finished.UserID) {
+		case services.PrivacyHidden:
+			return
+		case services.PrivacyAnonymous:
+			user = services.PublicName(b.locale(), services.PrivacyAnonymous, finished.UserID, finished.Username)
+		}
+
+		b.announce("book_finished:"+finished.UserID+":"+fmt.Sprint(finished.BookID), b.channels().CheckIn,
+			i18n.T(b.locale(), "book.finished", user, finished.Title, finished.Pages))
+	})
+
+	// Announce days added or taken off with /adjust in the check-in channel, unless the
+	// admin kept it quiet
+	b.events.Subscribe(events.PenaltyAppliedEvent, func(event events.Event) {
+		penalty := event.(events.PenaltyApplied)
+		if penalty.Silent {
+			return
+		}
+		user := "<@" + penalty.UserID + ">"
+		switch b.userPrivacy(penalty.UserID) {
+		case services.PrivacyHidden:
+			return
+		case services.PrivacyAnonymous:
+			user = services.PublicName(b.locale(), services.PrivacyAnonymous, penalty.UserID, penalty.Username)
+		}
+
+		key, days := "penalty.added", penalty.DaysAdded
+		if days < 0 {
+			key, days = "penalty.removed", -days
+		}
+		b.announce("penalty:"+penalty.UserID+":"+fmt.Sprint(penalty.AdjustmentID), b.channels().CheckIn,
+			i18n.T(b.locale(), key, user, days, penalty.Reason, i18n.FormatDate(b.locale(), penalty.LastDate)))
+	})
+
+	// Apply settings changed with /settings or in the database. A moved check-in
+	// channel gets today's check-in message, but no introduction as on a restart.
+	b.events.Subscribe(events.SettingsChangedEvent, func(event events.Event) {
+		changed := event.(events.SettingsChanged)
+		if changed.GuildID != "" && changed.GuildID != b.homeGuildID() {
+			return
+		}
+
+		previous, next := b.reloadSettings(forum)
+		if next.CheckIn != previous.CheckIn {
+			if err := b.ensureCheckInMessage(next.CheckIn); err != nil {
+				logger.Error("Failed to post check-in message to new channel_id=%s: %v", next.CheckIn, err)
+			}
+		}
+	})
+
+	// Forward events to admin-registered webhooks and the MQTT broker
+	b.subscribeWebhooks()
+	b.subscribeMQTT()
+}
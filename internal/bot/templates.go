@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// templateService returns the registered template service, if any
+func (b *Bot) templateService() *services.TemplateService {
+	for _, svc := range b.services.GetServices() {
+		if ts, ok := svc.(*services.TemplateService); ok {
+			return ts
+		}
+	}
+	return nil
+}
+
+// renderTemplate returns the home guild's override of a message template filled in
+// with vars, or builtin when the guild hasn't set one or it can't be loaded
+func (b *Bot) renderTemplate(name, builtin string, vars map[string]string) string {
+	templateService := b.templateService()
+	if templateService == nil || b.db == nil {
+		return builtin
+	}
+
+	body, ok, err := templateService.Get(b.homeGuildID(), name)
+	if err != nil {
+		logger.Error("Failed to load %s template, using the built-in message: %v", name, err)
+		return builtin
+	}
+	if !ok {
+		return builtin
+	}
+	return services.RenderTemplate(body, vars)
+}
+
+// templateDate is the {date} variable: today in the home guild's zone and language
+func (b *Bot) templateDate() string {
+	return i18n.FormatDateIn(b.locale(), time.Now(), b.timezone())
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// webhookService returns the registered webhook service, or nil without a database
+func (b *Bot) webhookService() *services.WebhookService {
+	if b.db == nil {
+		return nil
+	}
+	for _, svc := range b.services.GetServices() {
+		if ws, ok := svc.(*services.WebhookService); ok {
+			return ws
+		}
+	}
+	return nil
+}
+
+// subscribeWebhooks forwards challenge events to the home guild's outbound webhooks
+// through the outbox, which retries failed deliveries
+func (b *Bot) subscribeWebhooks() {
+	b.events.Subscribe(events.CheckInRecordedEvent, func(event events.Event) {
+		checkIn := event.(events.CheckInRecorded)
+		if !checkIn.FirstForDay {
+			return
+		}
+		b.sendWebhooks(event.Name(), checkIn.UserID, checkIn.Username, checkIn.Date.Format("2006-01-02"),
+			map[string]interface{}{
+				"challenge_day": checkIn.ChallengeDay,
+				"date":          checkIn.Date.Format("2006-01-02"),
+			})
+	})
+
+	b.events.Subscribe(events.PenaltyAppliedEvent, func(event events.Event) {
+		penalty := event.(events.PenaltyApplied)
+		b.sendWebhooks(event.Name(), penalty.UserID, penalty.Username, fmt.Sprint(penalty.AdjustmentID),
+			map[string]interface{}{
+				"challenge_day": penalty.ChallengeDay,
+				"days_added":    penalty.DaysAdded,
+				"reason":        penalty.Reason,
+				"last_day":      penalty.LastDate.Format("2006-01-02"),
+			})
+	})
+
+	b.events.Subscribe(events.ChallengeCompletedEvent, func(event events.Event) {
+		completed := event.(events.ChallengeCompleted)
+		b.sendWebhooks(event.Name(), completed.UserID, completed.Username, fmt.Sprint(completed.TotalDays),
+			map[string]interface{}{
+				"total_days": completed.TotalDays,
+			})
+	})
+}
+
+// sendWebhooks queues one event for every subscribed webhook. occurrence identifies
+// the event for its user (a date or day), so a re-published event isn't sent twice.
+// Payloads follow the user's privacy preference like public announcements do.
+func (b *Bot) sendWebhooks(event, userID, username, occurrence string, data map[string]interface{}) {
+	webhooks, outbox := b.webhookService(), b.outboxService()
+	if webhooks == nil || outbox == nil {
+		return
+	}
+
+	guildID := b.homeGuildID()
+	subscribed, err := webhooks.Subscribed(guildID, event)
+	if err != nil {
+		logger.Error("Failed to load webhooks for %s: %v", event, err)
+		return
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	switch privacy := b.userPrivacy(userID); privacy {
+	case services.PrivacyHidden:
+		return
+	case services.PrivacyAnonymous:
+		data["username"] = services.PublicName(b.locale(), privacy, userID, username)
+	default:
+		data["user_id"] = userID
+		if username != "" {
+			data["username"] = username
+		}
+	}
+
+	occurred := services.WebhookEvent{
+		Name:       event,
+		GuildID:    guildID,
+		OccurredAt: time.Now().UTC(),
+		Key:        fmt.Sprintf("%s:%s:%s", event, userID, occurrence),
+		Data:       data,
+	}
+	for _, webhook := range subscribed {
+		body, err := webhook.Payload(occurred)
+		if err != nil {
+			logger.Error("Failed to encode %s payload for webhook %d: %v", event, webhook.ID, err)
+			continue
+		}
+		dedupeKey := fmt.Sprintf("webhook:%d:%s", webhook.ID, occurred.Key)
+		if err := outbox.EnqueueWebhook(dedupeKey, webhook.ID, string(body)); err != nil {
+			logger.Error("Failed to queue %s for webhook %d: %v", event, webhook.ID, err)
+		}
+	}
+}
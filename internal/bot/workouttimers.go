@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/handlers"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// workoutTimerUpdateInterval is how often running workout timer messages are edited
+// with the elapsed time. They show minutes, so editing more often gains nothing.
+const workoutTimerUpdateInterval = time.Minute
+
+// workoutTimerService returns the registered workout timer service, or nil without a database
+func (b *Bot) workoutTimerService() *services.WorkoutTimerService {
+	if b.db == nil {
+		return nil
+	}
+	for _, svc := range b.services.GetServices() {
+		if ts, ok := svc.(*services.WorkoutTimerService); ok {
+			return ts
+		}
+	}
+	return nil
+}
+
+// startWorkoutTimers keeps the messages of running /exercise start timers showing how
+// long they've run, and expires ones left running. Timers live in the database, so
+// they carry on across restarts.
+func (b *Bot) startWorkoutTimers() {
+	timers := b.workoutTimerService()
+	if timers == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(workoutTimerUpdateInterval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled workout timer updates (every %s)", workoutTimerUpdateInterval)
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.stopped:
+				return
+			}
+			b.updateWorkoutTimers(timers)
+		}
+	}()
+}
+
+// updateWorkoutTimers edits every running timer's message with its elapsed time
+func (b *Bot) updateWorkoutTimers(timers *services.WorkoutTimerService) {
+	running, err := timers.Running()
+	if err != nil {
+		logger.Error("Failed to load running workout timers: %v", err)
+		return
+	}
+
+	now := time.Now()
+	locale := b.locale()
+	for _, timer := range running {
+		edit := discordgo.NewMessageEdit(timer.ChannelID, timer.MessageID)
+		elapsed := timer.Elapsed(now)
+		if elapsed > services.MaxWorkoutTimerDuration {
+			if err := timers.Expire(timer.ID); err != nil {
+				logger.Error("Failed to expire workout timer %d: %v", timer.ID, err)
+				continue
+			}
+			edit.SetContent(i18n.T(locale, "workout_timer.expired", int(services.MaxWorkoutTimerDuration.Hours())))
+			edit.Components = &[]discordgo.MessageComponent{}
+		} else {
+			edit.SetContent(handlers.WorkoutTimerContent(locale, elapsed))
+		}
+
+		if _, err := b.rest.ChannelMessageEditComplex(edit); err != nil {
+			logger.Warn("Failed to update workout timer %d message: %v", timer.ID, err)
+		}
+	}
+}
@@ -2,14 +2,103 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/email"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/llm"
+	"github.com/75-hard-discord-bot/internal/sheets"
 )
 
 // Config holds all application configuration
 type Config struct {
+	// File is the config file values were read from, if any
+	File string
+
 	DiscordBotToken  string
 	DiscordChannelID string
-	Database         *DatabaseConfig
+	// Channels routes posts by purpose; each falls back to DiscordChannelID
+	Channels ChannelConfig
+	// DiscordDevGuildID scopes slash commands to a test guild in dev mode
+	DiscordDevGuildID string
+	// SandboxChannelID receives every post, pin, and DM in dev mode, prefixed with [DEV]
+	SandboxChannelID string
+	// AdminRoleIDs grants admin commands to members with any of these roles
+	AdminRoleIDs []string
+	Database     *DatabaseConfig
+	Backup       *BackupConfig
+	// Tracing is set when spans should be exported to an OpenTelemetry collector
+	Tracing *TracingConfig
+	// ErrorReporting is set when errors and panics should go to a Sentry-compatible tracker
+	ErrorReporting *ErrorReportingConfig
+
+	// LeaderboardRefreshInterval controls how often the progress rollup is recomputed
+	LeaderboardRefreshInterval time.Duration
+
+	// HealthCheckInterval controls how often service health is checked
+	HealthCheckInterval time.Duration
+	// ShutdownTimeout bounds how long shutdown waits for in-flight handlers
+	ShutdownTimeout time.Duration
+	// SettingsPollInterval controls how often guild settings are checked for outside changes
+	SettingsPollInterval time.Duration
+	// HTTPAddr is where the health probe server listens; empty disables it
+	HTTPAddr string
+	// PprofAddr is the localhost address for net/http/pprof; empty disables it
+	PprofAddr string
+	// APIToken enables the REST API on HTTPAddr; requests must send it as a bearer token
+	APIToken string
+	// GRPC is set when the gRPC API for companion apps is served; calls send APIToken
+	GRPC *GRPCConfig
+	// PublicURL is where HTTPAddr is reachable from the internet, for OAuth redirects
+	// and provider webhooks
+	PublicURL string
+	// Strava is set when members can link Strava accounts with /connect strava
+	Strava *OAuthAppConfig
+	// Fitbit is set when members can link Fitbit accounts with /connect fitbit
+	Fitbit *OAuthAppConfig
+	// FitbitSyncInterval controls how often linked Fitbit accounts are polled
+	FitbitSyncInterval time.Duration
+	// Dashboard is set when members can sign in to the web dashboard with Discord
+	Dashboard *OAuthAppConfig
+	// DashboardURL is where members land after signing in to the dashboard
+	DashboardURL string
+	// GoogleSheets is set when guilds can mirror their data into a Google Sheet
+	GoogleSheets *GoogleSheetsConfig
+	// Twilio is set when members can get check-in reminders by SMS with /sms
+	Twilio *TwilioConfig
+	// Email is set when members can get weekly reports by email with /email
+	Email *EmailConfig
+	// Coach is set when members can ask an LLM for coaching with /coach
+	Coach *CoachConfig
+	// MQTT is set when check-ins, water, and weigh-ins are published to an MQTT broker
+	MQTT *MQTTConfig
+	// NaturalLogging offers to log water and workouts described in check-in channel
+	// messages; it needs the privileged Message Content intent
+	NaturalLogging bool
+	// EditWindow is how long after a day ends members can still correct it with /edit
+	EditWindow time.Duration
+	// UserCacheTTL is how long users' challenge dates and time zone are cached; 0 turns
+	// the cache off
+	UserCacheTTL time.Duration
+	// DisabledFeatures turns features off by default in guilds that haven't set them
+	DisabledFeatures []string
+	// Locale is the language for bot messages in guilds that haven't chosen one
+	Locale string
+	// Timezone is the IANA zone for dates in guilds that haven't chosen one
+	Timezone string
+}
+
+// ChannelConfig holds the channel ID used for each kind of bot post
+type ChannelConfig struct {
+	CheckIn   string // Daily check-in message and its reactions
+	Photos    string // Progress photo posts
+	Summaries string // Active-user rosters, summaries, and digests
+	Admin     string // Operational alerts for challenge admins
+	Forum     string // Optional forum channel with one progress post per participant
 }
 
 // DatabaseConfig holds database configuration
@@ -20,48 +109,481 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrationsDryRun prints pending migrations and exits instead of starting the bot
+	MigrationsDryRun bool
+	// ReadDSN optionally points summary/leaderboard queries at a read replica
+	ReadDSN string
+}
+
+// BackupConfig holds scheduled backup configuration
+type BackupConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Interval        time.Duration
+}
+
+// OAuthAppConfig holds the credentials of an app registered with a fitness provider
+type OAuthAppConfig struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// GoogleSheetsConfig holds the service account that writes guilds' Google Sheets
+type GoogleSheetsConfig struct {
+	ServiceAccount *sheets.ServiceAccount
+	SyncInterval   time.Duration
+}
+
+// TwilioConfig holds the Twilio account reminders are texted from
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	From       string // Twilio phone number in E.164 form, or a messaging service SID
+}
+
+// GRPCConfig holds where the gRPC API listens and the TLS certificate it serves,
+// since Go only speaks HTTP/2 over TLS
+type GRPCConfig struct {
+	Addr     string
+	CertFile string
+	KeyFile  string
 }
 
-// Load loads configuration from environment variables
-func Load() (*Config, error) {
+// EmailConfig holds how weekly reports are emailed: through SendGrid when its API key
+// is set, otherwise through an SMTP server
+type EmailConfig struct {
+	From           string // From header, e.g. "75 Hard Bot <bot@example.com>"
+	SendGridAPIKey string
+	SMTPHost       string
+	SMTPPort       int
+	SMTPUsername   string
+	SMTPPassword   string
+}
+
+// CoachConfig holds the LLM provider behind /coach and how often members may use it
+type CoachConfig struct {
+	Provider   string // anthropic, or openai for any OpenAI-compatible API
+	APIKey     string
+	Model      string
+	BaseURL    string // Overrides the provider's API address, e.g. for a local server
+	DailyLimit int    // Coaching requests per member per day
+}
+
+// MQTTConfig holds the broker activity is published to, e.g. for Home Assistant automations
+type MQTTConfig struct {
+	BrokerURL   string // mqtt://host:1883, or mqtts://host:8883 for TLS
+	Username    string
+	Password    string
+	ClientID    string
+	TopicPrefix string // Topics are {TopicPrefix}/water/logged and so on
+}
+
+// ErrorReportingConfig holds the Sentry-compatible error tracker settings
+type ErrorReportingConfig struct {
+	DSN         string
+	Environment string // Shown on each event, e.g. production or staging
+}
+
+// TracingConfig holds the OTLP/HTTP trace exporter settings
+type TracingConfig struct {
+	Endpoint    string            // Collector base URL; spans go to {Endpoint}/v1/traces
+	ServiceName string            // service.name on every span
+	Headers     map[string]string // Extra request headers, e.g. an API key for a hosted collector
+}
+
+// Load loads configuration from environment variables and, when path is set, a YAML
+// or TOML config file. Environment variables take precedence over file values.
+func Load(path string) (*Config, error) {
+	env := source{}
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		env.file = values
+	}
+
+	// Every problem below is collected and reported together
+	v := &validator{}
+
+	// Secrets missing from the environment and file can come from a secret store
+	provider, err := newSecretProvider(env)
+	if err != nil {
+		v.add("SECRETS_PROVIDER", err.Error(), "")
+	}
+	if provider != nil {
+		secrets, err := loadSecrets(env, provider)
+		if err != nil {
+			v.add("SECRETS_PROVIDER", err.Error(), "check the provider's address and credentials")
+		}
+		env.secrets = secrets
+	}
+
 	cfg := &Config{
-		DiscordBotToken:  os.Getenv("DISCORD_BOT_TOKEN"),
-		DiscordChannelID: os.Getenv("DISCORD_CHANNEL_ID"),
+		File: path,
+
+		DiscordBotToken:  env.get("DISCORD_BOT_TOKEN"),
+		DiscordChannelID: env.get("DISCORD_CHANNEL_ID"),
+
+		DiscordDevGuildID: env.get("DISCORD_DEV_GUILD_ID"),
+		SandboxChannelID:  env.get("DISCORD_SANDBOX_CHANNEL_ID"),
+		AdminRoleIDs:      splitList(env.get("ADMIN_ROLE_IDS")),
+		DisabledFeatures:  splitList(env.get("FEATURES_DISABLED")),
+		NaturalLogging:    isTruthy(env.get("NATURAL_LOGGING")),
+	}
+
+	// Validate Discord config
+	if v.required("DISCORD_BOT_TOKEN", cfg.DiscordBotToken, "create a bot in the Discord developer portal and copy its token") {
+		v.botToken("DISCORD_BOT_TOKEN", cfg.DiscordBotToken)
+	}
+	if v.required("DISCORD_CHANNEL_ID", cfg.DiscordChannelID, snowflakeHint) {
+		v.snowflake("DISCORD_CHANNEL_ID", cfg.DiscordChannelID)
+	}
+	v.snowflake("DISCORD_DEV_GUILD_ID", cfg.DiscordDevGuildID)
+	v.snowflake("DISCORD_SANDBOX_CHANNEL_ID", cfg.SandboxChannelID)
+	for _, roleID := range cfg.AdminRoleIDs {
+		v.snowflake("ADMIN_ROLE_IDS", roleID)
+	}
+	if level := env.get("LOG_LEVEL"); level != "" {
+		v.oneOf("LOG_LEVEL", level, "DEBUG", "INFO", "WARN", "WARNING", "ERROR")
 	}
 
-	// Validate required Discord config
-	if cfg.DiscordBotToken == "" {
-		return nil, fmt.Errorf("DISCORD_BOT_TOKEN environment variable is not set")
+	cfg.Locale = strings.ToLower(env.getOrDefault("BOT_LOCALE", i18n.Default))
+	v.oneOf("BOT_LOCALE", cfg.Locale, i18n.Supported()...)
+	cfg.Timezone = env.getOrDefault("BOT_TIMEZONE", "America/Denver")
+	v.timezone("BOT_TIMEZONE", cfg.Timezone)
+
+	cfg.Channels = ChannelConfig{
+		CheckIn:   env.getOrDefault("DISCORD_CHECKIN_CHANNEL_ID", cfg.DiscordChannelID),
+		Photos:    env.getOrDefault("DISCORD_PHOTOS_CHANNEL_ID", cfg.DiscordChannelID),
+		Summaries: env.getOrDefault("DISCORD_SUMMARY_CHANNEL_ID", cfg.DiscordChannelID),
+		Admin:     env.getOrDefault("DISCORD_ADMIN_CHANNEL_ID", cfg.DiscordChannelID),
+		Forum:     env.get("DISCORD_FORUM_CHANNEL_ID"),
 	}
-	if cfg.DiscordChannelID == "" {
-		return nil, fmt.Errorf("DISCORD_CHANNEL_ID environment variable is not set")
+	for _, key := range []string{"DISCORD_CHECKIN_CHANNEL_ID", "DISCORD_PHOTOS_CHANNEL_ID", "DISCORD_SUMMARY_CHANNEL_ID", "DISCORD_ADMIN_CHANNEL_ID", "DISCORD_FORUM_CHANNEL_ID"} {
+		v.snowflake(key, env.get(key))
 	}
 
 	// Load database config (optional)
-	dbHost := os.Getenv("DB_HOST")
+	dbHost := env.get("DB_HOST")
 	if dbHost != "" {
-		dbPassword := os.Getenv("DB_PASSWORD")
-		if dbPassword == "" {
-			return nil, fmt.Errorf("DB_PASSWORD is required when DB_HOST is set")
-		}
+		dbPassword := env.get("DB_PASSWORD")
+		v.required("DB_PASSWORD", dbPassword, "required when DB_HOST is set; SECRETS_PROVIDER can supply it")
 
 		cfg.Database = &DatabaseConfig{
 			Host:     dbHost,
-			Port:     getEnvOrDefault("DB_PORT", "5432"),
-			User:     getEnvOrDefault("DB_USER", "postgres"),
+			Port:     env.getOrDefault("DB_PORT", "5432"),
+			User:     env.getOrDefault("DB_USER", "postgres"),
 			Password: dbPassword,
-			DBName:   getEnvOrDefault("DB_NAME", "hard75"),
-			SSLMode:  getEnvOrDefault("DB_SSLMODE", "require"),
+			DBName:   env.getOrDefault("DB_NAME", "hard75"),
+			SSLMode:  env.getOrDefault("DB_SSLMODE", "require"),
+
+			MigrationsDryRun: isTruthy(env.get("MIGRATIONS_DRY_RUN")),
+			ReadDSN:          env.get("DB_READ_DSN"),
+		}
+		v.port("DB_PORT", cfg.Database.Port)
+		v.oneOf("DB_SSLMODE", cfg.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+		if cfg.Database.ReadDSN != "" {
+			v.postgresDSN("DB_READ_DSN", cfg.Database.ReadDSN)
+		}
+	} else if env.get("DB_READ_DSN") != "" {
+		v.add("DB_READ_DSN", "is set without DB_HOST", "the replica is only used alongside a primary database; set DB_HOST too")
+	}
+
+	cfg.LeaderboardRefreshInterval = v.duration(env, "LEADERBOARD_REFRESH_INTERVAL", "5m")
+	cfg.HealthCheckInterval = v.duration(env, "HEALTH_CHECK_INTERVAL", "1m")
+	cfg.ShutdownTimeout = v.duration(env, "SHUTDOWN_TIMEOUT", "30s")
+	cfg.SettingsPollInterval = v.duration(env, "SETTINGS_POLL_INTERVAL", "30s")
+	cfg.EditWindow = v.duration(env, "EDIT_WINDOW", "48h")
+	cfg.UserCacheTTL = v.duration(env, "USER_CACHE_TTL", "1m")
+
+	cfg.HTTPAddr = env.getOrDefault("HTTP_ADDR", ":8080")
+	if strings.EqualFold(cfg.HTTPAddr, "off") {
+		cfg.HTTPAddr = ""
+	} else {
+		v.listenAddr("HTTP_ADDR", cfg.HTTPAddr)
+	}
+
+	// Load gRPC config (optional); it shares API_TOKEN with the REST API
+	if grpcAddr := env.get("GRPC_ADDR"); grpcAddr != "" {
+		const grpcHint = "required when GRPC_ADDR is set; gRPC runs over HTTP/2, which needs TLS"
+		v.listenAddr("GRPC_ADDR", grpcAddr)
+		certFile := env.get("GRPC_TLS_CERT_FILE")
+		keyFile := env.get("GRPC_TLS_KEY_FILE")
+		v.required("GRPC_TLS_CERT_FILE", certFile, grpcHint+"; a PEM certificate file")
+		v.required("GRPC_TLS_KEY_FILE", keyFile, grpcHint+"; the certificate's PEM private key file")
+		if env.get("API_TOKEN") == "" {
+			v.add("GRPC_ADDR", "is set without API_TOKEN", "calls authenticate with the API token; set API_TOKEN too")
+		}
+		cfg.GRPC = &GRPCConfig{Addr: grpcAddr, CertFile: certFile, KeyFile: keyFile}
+	}
+
+	if cfg.APIToken = env.get("API_TOKEN"); cfg.APIToken != "" {
+		if cfg.HTTPAddr == "" && cfg.GRPC == nil {
+			v.add("API_TOKEN", "is set but HTTP_ADDR is off", "the API is served by the HTTP server; set HTTP_ADDR or GRPC_ADDR")
+		}
+		if cfg.Database == nil {
+			v.add("API_TOKEN", "is set without DB_HOST", "the API reads and records challenge data; set DB_HOST too")
+		}
+		if len(cfg.APIToken) < 32 {
+			v.add("API_TOKEN", "is shorter than 32 characters", "generate one with e.g. openssl rand -hex 32")
+		}
+	}
+
+	if cfg.PublicURL = strings.TrimRight(env.get("PUBLIC_URL"), "/"); cfg.PublicURL != "" {
+		v.httpURL("PUBLIC_URL", cfg.PublicURL, "https://hard75.example.com")
+	}
+
+	// Load fitness app configs (optional); apps are registered at strava.com/settings/api
+	// and dev.fitbit.com/apps
+	cfg.Strava = oauthApp(env, v, cfg, "STRAVA", "Strava")
+	cfg.Fitbit = oauthApp(env, v, cfg, "FITBIT", "Fitbit")
+	if cfg.Fitbit != nil {
+		cfg.FitbitSyncInterval = v.duration(env, "FITBIT_SYNC_INTERVAL", "30m")
+	}
+
+	// Load web dashboard config (optional); it signs members in with the bot's own
+	// application from the Discord developer portal
+	cfg.Dashboard = oauthApp(env, v, cfg, "DISCORD", "Discord")
+	if cfg.Dashboard != nil {
+		cfg.DashboardURL = strings.TrimRight(env.getOrDefault("DASHBOARD_URL", cfg.PublicURL), "/")
+		if cfg.DashboardURL != "" {
+			v.httpURL("DASHBOARD_URL", cfg.DashboardURL, "https://dashboard.hard75.example.com")
+		}
+	}
+
+	// Load Google Sheets config (optional); the key is a service account's JSON key
+	if key := env.get("GOOGLE_SERVICE_ACCOUNT_KEY"); key != "" {
+		if cfg.Database == nil {
+			v.add("GOOGLE_SERVICE_ACCOUNT_KEY", "is set without DB_HOST", "sheets mirror the challenge data in the database; set DB_HOST too")
+		}
+		account, err := sheets.ParseServiceAccount([]byte(key))
+		if err != nil {
+			v.add("GOOGLE_SERVICE_ACCOUNT_KEY", err.Error(), "paste the JSON key downloaded for the service account in Google Cloud")
+		}
+		cfg.GoogleSheets = &GoogleSheetsConfig{
+			ServiceAccount: account,
+			SyncInterval:   v.duration(env, "SHEETS_SYNC_INTERVAL", "10m"),
+		}
+	}
+
+	// Load Twilio config (optional); credentials are on the Twilio console's account page
+	if accountSID := env.get("TWILIO_ACCOUNT_SID"); accountSID != "" {
+		const twilioHint = "required when TWILIO_ACCOUNT_SID is set"
+		authToken := env.get("TWILIO_AUTH_TOKEN")
+		from := env.get("TWILIO_FROM_NUMBER")
+		v.required("TWILIO_AUTH_TOKEN", authToken, twilioHint)
+		v.required("TWILIO_FROM_NUMBER", from, twilioHint+"; a Twilio number like +15551234567 or a messaging service SID")
+		if cfg.Database == nil {
+			v.add("TWILIO_ACCOUNT_SID", "is set without DB_HOST", "verified phone numbers are stored in the database; set DB_HOST too")
+		}
+		cfg.Twilio = &TwilioConfig{AccountSID: accountSID, AuthToken: authToken, From: from}
+	}
+
+	// Load email config (optional); SendGrid takes precedence when both are set
+	smtpHost := env.get("SMTP_HOST")
+	sendGridKey := env.get("SENDGRID_API_KEY")
+	if smtpHost != "" || sendGridKey != "" {
+		const emailHint = "required when SMTP_HOST or SENDGRID_API_KEY is set"
+		from := env.get("EMAIL_FROM")
+		if v.required("EMAIL_FROM", from, emailHint+`; e.g. "75 Hard Bot <bot@example.com>"`) {
+			if _, err := email.NormalizeAddress(from); err != nil {
+				v.add("EMAIL_FROM", err.Error(), `use an address, optionally with a name, e.g. "75 Hard Bot <bot@example.com>"`)
+			}
+		}
+		smtpPort := env.getOrDefault("SMTP_PORT", "587")
+		v.port("SMTP_PORT", smtpPort)
+		port, _ := strconv.Atoi(smtpPort)
+		if cfg.Database == nil {
+			v.add("EMAIL_FROM", "is set without DB_HOST", "verified email addresses are stored in the database; set DB_HOST too")
+		}
+		cfg.Email = &EmailConfig{
+			From:           from,
+			SendGridAPIKey: sendGridKey,
+			SMTPHost:       smtpHost,
+			SMTPPort:       port,
+			SMTPUsername:   env.get("SMTP_USERNAME"),
+			SMTPPassword:   env.get("SMTP_PASSWORD"),
+		}
+	}
+
+	// Load coach config (optional)
+	if provider := strings.ToLower(env.get("LLM_PROVIDER")); provider != "" {
+		const coachHint = "required when LLM_PROVIDER is set"
+		v.oneOf("LLM_PROVIDER", provider, llm.Providers...)
+		apiKey, model, baseURL := env.get("LLM_API_KEY"), env.get("LLM_MODEL"), env.get("LLM_BASE_URL")
+		if baseURL == "" {
+			v.required("LLM_API_KEY", apiKey, coachHint+"; create one in the provider's console")
+		} else {
+			v.httpURL("LLM_BASE_URL", baseURL, "http://localhost:11434 for a local OpenAI-compatible server")
+		}
+		v.required("LLM_MODEL", model, coachHint+"; the model name from the provider's docs")
+		if cfg.Database == nil {
+			v.add("LLM_PROVIDER", "is set without DB_HOST", "/coach reads members' stats and daily usage from the database; set DB_HOST too")
+		}
+		cfg.Coach = &CoachConfig{
+			Provider:   provider,
+			APIKey:     apiKey,
+			Model:      model,
+			BaseURL:    baseURL,
+			DailyLimit: v.positiveInt(env, "COACH_DAILY_LIMIT", "3"),
+		}
+	}
+
+	// Load MQTT config (optional)
+	if brokerURL := env.get("MQTT_BROKER_URL"); brokerURL != "" {
+		v.mqttURL("MQTT_BROKER_URL", brokerURL)
+		prefix := strings.Trim(env.getOrDefault("MQTT_TOPIC_PREFIX", "hard75"), "/")
+		if prefix == "" || strings.ContainsAny(prefix, "+#") {
+			v.add("MQTT_TOPIC_PREFIX", fmt.Sprintf("%q is not a topic prefix", prefix), "wildcards aren't allowed in published topics; e.g. hard75 or home/hard75")
+		}
+		cfg.MQTT = &MQTTConfig{
+			BrokerURL:   brokerURL,
+			Username:    env.get("MQTT_USERNAME"),
+			Password:    env.get("MQTT_PASSWORD"),
+			ClientID:    env.getOrDefault("MQTT_CLIENT_ID", "hard75-bot"),
+			TopicPrefix: prefix,
+		}
+	}
+
+	if pprofAddr := env.get("PPROF_ADDR"); pprofAddr != "" {
+		host, _, err := net.SplitHostPort(pprofAddr)
+		if err != nil || (host != "localhost" && !net.ParseIP(host).IsLoopback()) {
+			v.add("PPROF_ADDR", fmt.Sprintf("%q is not a localhost address", pprofAddr), "profiles expose memory contents; use e.g. localhost:6060")
+		}
+		cfg.PprofAddr = pprofAddr
+	}
+
+	// Load tracing config (optional); variable names follow the OpenTelemetry conventions
+	if endpoint := env.get("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		v.httpURL("OTEL_EXPORTER_OTLP_ENDPOINT", endpoint, "http://otel-collector:4318")
+		headers := make(map[string]string)
+		for _, pair := range splitList(env.get("OTEL_EXPORTER_OTLP_HEADERS")) {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				v.add("OTEL_EXPORTER_OTLP_HEADERS", fmt.Sprintf("%q is not a name=value pair", pair), "e.g. x-api-key=secret,x-team=fitness")
+				continue
+			}
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		cfg.Tracing = &TracingConfig{
+			Endpoint:    endpoint,
+			ServiceName: env.getOrDefault("OTEL_SERVICE_NAME", "hard75-bot"),
+			Headers:     headers,
+		}
+	}
+
+	// Load error reporting config (optional)
+	if dsn := env.get("SENTRY_DSN"); dsn != "" {
+		v.sentryDSN("SENTRY_DSN", dsn)
+		defaultEnvironment := "production"
+		if isTruthy(env.get("DEV_MODE")) || strings.HasPrefix(strings.ToLower(env.get("DEV_MODE")), "dev") {
+			defaultEnvironment = "development"
+		}
+		cfg.ErrorReporting = &ErrorReportingConfig{
+			DSN:         dsn,
+			Environment: env.getOrDefault("SENTRY_ENVIRONMENT", defaultEnvironment),
+		}
+	}
+
+	// Load backup config (optional, requires a database)
+	backupBucket := env.get("BACKUP_S3_BUCKET")
+	if backupBucket != "" {
+		if cfg.Database == nil {
+			v.add("BACKUP_S3_BUCKET", "requires DB_HOST to be set", "backups dump the configured database")
+		}
+
+		const backupHint = "required when BACKUP_S3_BUCKET is set"
+		accessKeyID := env.get("BACKUP_S3_ACCESS_KEY_ID")
+		secretAccessKey := env.get("BACKUP_S3_SECRET_ACCESS_KEY")
+		v.required("BACKUP_S3_ACCESS_KEY_ID", accessKeyID, backupHint)
+		v.required("BACKUP_S3_SECRET_ACCESS_KEY", secretAccessKey, backupHint)
+
+		cfg.Backup = &BackupConfig{
+			Endpoint:        env.getOrDefault("BACKUP_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:          env.getOrDefault("BACKUP_S3_REGION", "us-east-1"),
+			Bucket:          backupBucket,
+			Prefix:          env.getOrDefault("BACKUP_S3_PREFIX", "backups"),
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			Interval:        v.duration(env, "BACKUP_INTERVAL", "24h"),
 		}
+		v.httpURL("BACKUP_S3_ENDPOINT", cfg.Backup.Endpoint, "https://s3.us-east-1.amazonaws.com")
 	}
 
+	if err := v.err(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
-// getEnvOrDefault returns environment variable value or default
-func getEnvOrDefault(key, defaultValue string) string {
+// oauthApp loads an OAuth app's credentials from {prefix}_CLIENT_ID and
+// {prefix}_CLIENT_SECRET, or returns nil when the provider isn't configured
+func oauthApp(env source, v *validator, cfg *Config, prefix, name string) *OAuthAppConfig {
+	idKey, secretKey := prefix+"_CLIENT_ID", prefix+"_CLIENT_SECRET"
+	clientID := env.get(idKey)
+	if clientID == "" {
+		return nil
+	}
+
+	hint := "required when " + idKey + " is set"
+	clientSecret := env.get(secretKey)
+	v.required(secretKey, clientSecret, hint)
+	v.required("PUBLIC_URL", cfg.PublicURL, hint+"; "+name+" redirects members there after they sign in")
+	if cfg.HTTPAddr == "" {
+		v.add(idKey, "is set but HTTP_ADDR is off", name+" calls back to the HTTP server; set HTTP_ADDR")
+	}
+	if cfg.Database == nil {
+		v.add(idKey, "is set without DB_HOST", "linked accounts, sign-ins, and synced data are stored in the database; set DB_HOST too")
+	}
+	return &OAuthAppConfig{ClientID: clientID, ClientSecret: clientSecret}
+}
+
+// source looks settings up in the environment first, then the config file, then the secret provider
+type source struct {
+	file    map[string]string
+	secrets map[string]string
+}
+
+// get returns the environment value, falling back to the config file and secrets
+func (s source) get(key string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
+	if value := s.file[key]; value != "" {
+		return value
+	}
+	return s.secrets[key]
+}
+
+// getOrDefault returns the setting value or default
+func (s source) getOrDefault(key, defaultValue string) string {
+	if value := s.get(key); value != "" {
+		return value
+	}
 	return defaultValue
 }
+
+// isTruthy reports whether an environment value enables a boolean setting
+func isTruthy(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// splitList splits a comma-separated environment value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
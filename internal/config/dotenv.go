@@ -0,0 +1,52 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadDotEnv sets environment variables from a .env file for local development.
+// Variables already in the environment are never overridden, and a missing file
+// is not an error. Returns the number of variables set.
+func LoadDotEnv(path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	set := 0
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return set, fmt.Errorf("%s line %d: expected KEY=value", path, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseScalar(strings.TrimSpace(value))
+		if err != nil {
+			return set, fmt.Errorf("%s line %d: %w", path, lineNo, err)
+		}
+
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return set, fmt.Errorf("%s line %d: %w", path, lineNo, err)
+		}
+		set++
+	}
+	return set, scanner.Err()
+}
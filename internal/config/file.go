@@ -0,0 +1,352 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fileKeys maps config file keys (section.key) to the environment variable they set.
+// Environment variables always win over the file, so a file can hold shared defaults
+// while secrets or per-deploy overrides stay in the environment.
+var fileKeys = map[string]string{
+	"discord.bot_token":          "DISCORD_BOT_TOKEN",
+	"discord.channel_id":         "DISCORD_CHANNEL_ID",
+	"discord.dev_guild_id":       "DISCORD_DEV_GUILD_ID",
+	"discord.sandbox_channel_id": "DISCORD_SANDBOX_CHANNEL_ID",
+	"discord.admin_role_ids":     "ADMIN_ROLE_IDS",
+	"discord.locale":             "BOT_LOCALE",
+	"discord.timezone":           "BOT_TIMEZONE",
+	"discord.natural_logging":    "NATURAL_LOGGING",
+	"discord.edit_window":        "EDIT_WINDOW",
+
+	"channels.checkin":   "DISCORD_CHECKIN_CHANNEL_ID",
+	"channels.photos":    "DISCORD_PHOTOS_CHANNEL_ID",
+	"channels.summaries": "DISCORD_SUMMARY_CHANNEL_ID",
+	"channels.admin":     "DISCORD_ADMIN_CHANNEL_ID",
+	"channels.forum":     "DISCORD_FORUM_CHANNEL_ID",
+
+	"database.host":               "DB_HOST",
+	"database.port":               "DB_PORT",
+	"database.user":               "DB_USER",
+	"database.password":           "DB_PASSWORD",
+	"database.name":               "DB_NAME",
+	"database.sslmode":            "DB_SSLMODE",
+	"database.read_dsn":           "DB_READ_DSN",
+	"database.migrations_dry_run": "MIGRATIONS_DRY_RUN",
+	"database.user_cache_ttl":     "USER_CACHE_TTL",
+
+	"schedule.leaderboard_refresh_interval": "LEADERBOARD_REFRESH_INTERVAL",
+	"schedule.health_check_interval":        "HEALTH_CHECK_INTERVAL",
+	"schedule.settings_poll_interval":       "SETTINGS_POLL_INTERVAL",
+	"schedule.shutdown_timeout":             "SHUTDOWN_TIMEOUT",
+
+	"backup.bucket":            "BACKUP_S3_BUCKET",
+	"backup.endpoint":          "BACKUP_S3_ENDPOINT",
+	"backup.region":            "BACKUP_S3_REGION",
+	"backup.prefix":            "BACKUP_S3_PREFIX",
+	"backup.access_key_id":     "BACKUP_S3_ACCESS_KEY_ID",
+	"backup.secret_access_key": "BACKUP_S3_SECRET_ACCESS_KEY",
+	"backup.interval":          "BACKUP_INTERVAL",
+
+	"features.disabled": "FEATURES_DISABLED",
+
+	"http.addr":       "HTTP_ADDR",
+	"http.pprof_addr": "PPROF_ADDR",
+	"http.api_token":  "API_TOKEN",
+	"http.public_url": "PUBLIC_URL",
+
+	"grpc.addr":          "GRPC_ADDR",
+	"grpc.tls_cert_file": "GRPC_TLS_CERT_FILE",
+	"grpc.tls_key_file":  "GRPC_TLS_KEY_FILE",
+
+	"strava.client_id":     "STRAVA_CLIENT_ID",
+	"strava.client_secret": "STRAVA_CLIENT_SECRET",
+
+	"fitbit.client_id":     "FITBIT_CLIENT_ID",
+	"fitbit.client_secret": "FITBIT_CLIENT_SECRET",
+	"fitbit.sync_interval": "FITBIT_SYNC_INTERVAL",
+
+	"dashboard.client_id":     "DISCORD_CLIENT_ID",
+	"dashboard.client_secret": "DISCORD_CLIENT_SECRET",
+	"dashboard.url":           "DASHBOARD_URL",
+
+	"google_sheets.service_account_key": "GOOGLE_SERVICE_ACCOUNT_KEY",
+	"google_sheets.sync_interval":       "SHEETS_SYNC_INTERVAL",
+
+	"twilio.account_sid": "TWILIO_ACCOUNT_SID",
+	"twilio.auth_token":  "TWILIO_AUTH_TOKEN",
+	"twilio.from_number": "TWILIO_FROM_NUMBER",
+
+	"email.from":             "EMAIL_FROM",
+	"email.sendgrid_api_key": "SENDGRID_API_KEY",
+	"email.smtp_host":        "SMTP_HOST",
+	"email.smtp_port":        "SMTP_PORT",
+	"email.smtp_username":    "SMTP_USERNAME",
+	"email.smtp_password":    "SMTP_PASSWORD",
+
+	"coach.provider":    "LLM_PROVIDER",
+	"coach.api_key":     "LLM_API_KEY",
+	"coach.model":       "LLM_MODEL",
+	"coach.base_url":    "LLM_BASE_URL",
+	"coach.daily_limit": "COACH_DAILY_LIMIT",
+
+	"mqtt.broker_url":   "MQTT_BROKER_URL",
+	"mqtt.username":     "MQTT_USERNAME",
+	"mqtt.password":     "MQTT_PASSWORD",
+	"mqtt.client_id":    "MQTT_CLIENT_ID",
+	"mqtt.topic_prefix": "MQTT_TOPIC_PREFIX",
+
+	"tracing.endpoint":     "OTEL_EXPORTER_OTLP_ENDPOINT",
+	"tracing.service_name": "OTEL_SERVICE_NAME",
+	"tracing.headers":      "OTEL_EXPORTER_OTLP_HEADERS",
+
+	"sentry.dsn":         "SENTRY_DSN",
+	"sentry.environment": "SENTRY_ENVIRONMENT",
+
+	"secrets.provider":   "SECRETS_PROVIDER",
+	"secrets.dir":        "SECRETS_DIR",
+	"secrets.ssm_prefix": "SECRETS_SSM_PREFIX",
+	"secrets.aws_region": "AWS_REGION",
+	"secrets.vault_addr": "VAULT_ADDR",
+	"secrets.vault_path": "SECRETS_VAULT_PATH",
+}
+
+// readConfigFile parses a YAML (.yaml/.yml) or TOML (.toml) config file into
+// environment variable names and values.
+//
+// Only the subset this config needs is supported, without a YAML or TOML library:
+//   - One level of sections ("discord:" or "[discord]") holding keys; top-level keys
+//     outside a section are allowed too. Deeper nesting is an error.
+//   - Values are scalars or lists of scalars. Lists are written inline (["a", "b"]) or,
+//     in YAML, as "- item" lines under the key, and become comma-separated values.
+//   - Scalars are bare (numbers, booleans, durations, kept as written), double-quoted
+//     with Go/TOML escapes, or single-quoted as literal text with quotes doubled.
+//   - # starts a comment at the start of a line or after whitespace, outside quotes.
+//
+// Not supported: YAML anchors, flow mappings, and block (| or >) scalars; TOML
+// dotted keys, inline tables, arrays of tables, and multi-line strings.
+func readConfigFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	var entries map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		entries, err = parseYAML(bufio.NewScanner(file))
+	case ".toml":
+		entries, err = parseTOML(bufio.NewScanner(file))
+	default:
+		return nil, fmt.Errorf("config file %s must end in .yaml, .yml, or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(entries))
+	for key, value := range entries {
+		envName, ok := fileKeys[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown config key %q in %s", key, path)
+		}
+		values[envName] = value
+	}
+	return values, nil
+}
+
+// parseYAML reads "section:" headers followed by indented "key: value" pairs.
+// A key with no value may be followed by indented "- item" list entries.
+func parseYAML(scanner *bufio.Scanner) (map[string]string, error) {
+	entries := make(map[string]string)
+	section, listKey := "", ""
+	keyIndent := "" // The indentation of the current section's keys
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := stripComment(scanner.Text())
+		line := strings.TrimSpace(raw)
+		if line == "" || line == "---" {
+			continue
+		}
+		indent := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]
+		indented := indent != ""
+
+		if strings.HasPrefix(line, "- ") {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: list item without a key", lineNo)
+			}
+			item, err := parseScalar(strings.TrimSpace(line[2:]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries[listKey] = joinList(entries[listKey], item)
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		listKey = ""
+
+		if !indented {
+			if value == "" {
+				section, keyIndent = name, ""
+				continue
+			}
+			section = ""
+		} else if section == "" {
+			return nil, fmt.Errorf("line %d: indented key %q outside a section", lineNo, name)
+		} else if keyIndent == "" {
+			keyIndent = indent
+		} else if indent != keyIndent {
+			return nil, fmt.Errorf("line %d: key %q is nested under another key; only one level of sections is supported", lineNo, name)
+		}
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			return nil, fmt.Errorf("line %d: block scalars aren't supported; write the value on one line", lineNo)
+		}
+		if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "&") || strings.HasPrefix(value, "*") {
+			return nil, fmt.Errorf("line %d: flow mappings and anchors aren't supported", lineNo)
+		}
+
+		key := name
+		if indented {
+			key = section + "." + name
+		}
+		if value == "" {
+			listKey = key
+			entries[key] = ""
+			continue
+		}
+
+		parsed, err := parseValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries[key] = parsed
+	}
+	return entries, scanner.Err()
+}
+
+// parseTOML reads "[section]" headers followed by "key = value" pairs
+func parseTOML(scanner *bufio.Scanner) (map[string]string, error) {
+	entries := make(map[string]string)
+	section := ""
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("line %d: arrays of tables aren't supported", lineNo)
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", lineNo)
+		}
+
+		key := strings.TrimSpace(name)
+		if strings.Contains(key, ".") {
+			return nil, fmt.Errorf("line %d: dotted key %q isn't supported; put it under a [section]", lineNo, key)
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "{") || strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, "'''") {
+			return nil, fmt.Errorf("line %d: inline tables and multi-line strings aren't supported", lineNo)
+		}
+		parsed, err := parseValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries[key] = parsed
+	}
+	return entries, scanner.Err()
+}
+
+// parseValue parses a scalar or an inline ["a", "b"] list
+func parseValue(value string) (string, error) {
+	if !strings.HasPrefix(value, "[") {
+		return parseScalar(value)
+	}
+	if !strings.HasSuffix(value, "]") {
+		return "", fmt.Errorf("unterminated list %s", value)
+	}
+
+	list := ""
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parsed, err := parseScalar(item)
+		if err != nil {
+			return "", err
+		}
+		list = joinList(list, parsed)
+	}
+	return list, nil
+}
+
+// parseScalar unquotes a quoted string; bare values (numbers, booleans, durations) are kept as written
+func parseScalar(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string %s", value)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("invalid quoted string %s", value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+	return value, nil
+}
+
+// stripComment removes a trailing # comment that isn't inside quotes. The # has to
+// start the line or follow whitespace, so values like URL fragments are kept.
+func stripComment(line string) string {
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// joinList appends an item to a comma-separated list value
+func joinList(list, item string) string {
+	if list == "" {
+		return item
+	}
+	return list + "," + item
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"bufio"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want map[string]string
+	}{
+		{
+			name: "sections and top-level keys",
+			yaml: "---\ntop: 1\ndiscord:\n  locale: es\n  timezone: Europe/Berlin\n\ndatabase:\n\thost: db\n",
+			want: map[string]string{"top": "1", "discord.locale": "es", "discord.timezone": "Europe/Berlin", "database.host": "db"},
+		},
+		{
+			name: "quoting",
+			yaml: `s:
+  double: "123456789012345678"
+  escaped: "tab\there \"quoted\" # kept"
+  single: 'it''s # kept'
+  bare: 48h
+  empty_quotes: ""
+`,
+			want: map[string]string{
+				"s.double": "123456789012345678", "s.escaped": "tab\there \"quoted\" # kept",
+				"s.single": "it's # kept", "s.bare": "48h", "s.empty_quotes": "",
+			},
+		},
+		{
+			name: "comments",
+			yaml: `# header
+s: # a section
+  # a commented-out key: 1
+  a: 1 # trailing
+  url: https://example.com/#fragment
+  hash: "#1"
+`,
+			want: map[string]string{"s.a": "1", "s.url": "https://example.com/#fragment", "s.hash": "#1"},
+		},
+		{
+			name: "lists",
+			yaml: `s:
+  block:
+    - "1"
+    - two   # comment
+  inline: ["a", 'b', c, ]
+  empty:
+  after: x
+`,
+			want: map[string]string{"s.block": "1,two", "s.inline": "a,b,c", "s.empty": "", "s.after": "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML(bufio.NewScanner(strings.NewReader(tt.yaml)))
+			if err != nil {
+				t.Fatalf("parseYAML() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseYAML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{name: "nested section", yaml: "s:\n  a: 1\n  sub:\n    b: 2\n", want: "line 4: key \"b\" is nested"},
+		{name: "uneven indentation", yaml: "s:\n  a: 1\n   b: 2\n", want: "line 3: key \"b\" is nested"},
+		{name: "indented key outside a section", yaml: "a: 1\n  b: 2\n", want: "line 2: indented key \"b\" outside a section"},
+		{name: "list item without a key", yaml: "s:\n  a: 1\n  - x\n", want: "line 3: list item without a key"},
+		{name: "not a key", yaml: "s:\n  just text\n", want: "line 2: expected \"key: value\""},
+		{name: "block scalar", yaml: "s:\n  a: |\n    text\n", want: "line 2: block scalars"},
+		{name: "folded scalar", yaml: "s:\n  a: >-\n", want: "block scalars"},
+		{name: "flow mapping", yaml: "s:\n  a: {b: 1}\n", want: "flow mappings"},
+		{name: "anchor", yaml: "s:\n  a: &x 1\n", want: "anchors"},
+		{name: "unterminated double quote", yaml: "s:\n  a: \"abc\n", want: "line 2: invalid quoted string"},
+		{name: "unterminated single quote", yaml: "s:\n  a: 'abc\n", want: "invalid quoted string"},
+		{name: "unterminated list", yaml: "s:\n  a: [1, 2\n", want: "unterminated list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAML(bufio.NewScanner(strings.NewReader(tt.yaml)))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseYAML() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	toml := `# header
+top = "1"
+
+[discord]
+locale = "es"   # trailing
+admin_role_ids = ["1", "2"]
+edit_window = 48h
+escaped = "say \"hi\" # not a comment"
+literal = 'C:\path'
+
+[ database ]
+host = "db#1"
+port = 5432
+`
+	want := map[string]string{
+		"top": "1", "discord.locale": "es", "discord.admin_role_ids": "1,2", "discord.edit_window": "48h",
+		"discord.escaped": `say "hi" # not a comment`, "discord.literal": `C:\path`,
+		"database.host": "db#1", "database.port": "5432",
+	}
+	got, err := parseTOML(bufio.NewScanner(strings.NewReader(toml)))
+	if err != nil {
+		t.Fatalf("parseTOML() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTOML() = %v, want %v", got, want)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		want string
+	}{
+		{name: "not a key", toml: "[s]\njust text\n", want: "line 2: expected \"key = value\""},
+		{name: "dotted key", toml: "discord.locale = \"es\"\n", want: "dotted key"},
+		{name: "array of tables", toml: "[[s]]\n", want: "arrays of tables"},
+		{name: "inline table", toml: "s = { a = 1 }\n", want: "inline tables"},
+		{name: "multi-line string", toml: "s = \"\"\"\ntext\n\"\"\"\n", want: "multi-line strings"},
+		{name: "bad escape", toml: "s = \"\\q\"\n", want: "invalid quoted string"},
+		{name: "unterminated list", toml: "s = [1, 2\n", want: "unterminated list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTOML(bufio.NewScanner(strings.NewReader(tt.toml)))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseTOML() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("example", func(t *testing.T) {
+		values, err := readConfigFile(filepath.Join("..", "..", "config.example.yaml"))
+		if err != nil {
+			t.Fatalf("readConfigFile() error = %v", err)
+		}
+		if values["DISCORD_CHANNEL_ID"] != "123456789012345678" || values["DB_HOST"] != "localhost" {
+			t.Errorf("readConfigFile() = %v, want the example's channel and database", values)
+		}
+	})
+	t.Run("yaml and toml agree", func(t *testing.T) {
+		yaml, err := readConfigFile(write("a.yml", "discord:\n  admin_role_ids:\n    - \"1\"\n    - \"2\"\ndatabase:\n  port: 5433\n"))
+		if err != nil {
+			t.Fatalf("readConfigFile(yaml) error = %v", err)
+		}
+		toml, err := readConfigFile(write("a.toml", "[discord]\nadmin_role_ids = [\"1\", \"2\"]\n[database]\nport = 5433\n"))
+		if err != nil {
+			t.Fatalf("readConfigFile(toml) error = %v", err)
+		}
+		want := map[string]string{"ADMIN_ROLE_IDS": "1,2", "DB_PORT": "5433"}
+		if !reflect.DeepEqual(yaml, want) || !reflect.DeepEqual(toml, want) {
+			t.Errorf("readConfigFile() = %v and %v, want %v", yaml, toml, want)
+		}
+	})
+
+	errorTests := []struct {
+		name, file, content, want string
+	}{
+		{name: "unknown key", file: "b.yaml", content: "discord:\n  colour: red\n", want: `unknown config key "discord.colour"`},
+		{name: "unknown extension", file: "b.json", content: "{}", want: "must end in .yaml, .yml, or .toml"},
+		{name: "parse error names the file", file: "c.toml", content: "[[x]]\n", want: "c.toml: line 1"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readConfigFile(write(tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("readConfigFile() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := readConfigFile(filepath.Join(dir, "missing.yaml")); err == nil {
+			t.Error("readConfigFile() succeeded, want an error")
+		}
+	})
+}
+
+func TestLoadEnvironmentOverridesFile(t *testing.T) {
+	token := base64.RawURLEncoding.EncodeToString([]byte("123456789012345678")) + ".AAAAAA.signature"
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := `discord:
+  bot_token: "` + token + `"
+  channel_id: "111111111111111111"
+  locale: es
+  timezone: Europe/Berlin
+schedule:
+  health_check_interval: 2m
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range fileKeys {
+		t.Setenv(key, "")
+	}
+	t.Setenv("DISCORD_CHANNEL_ID", "222222222222222222")
+	t.Setenv("BOT_TIMEZONE", "America/Chicago")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DiscordBotToken != token || cfg.Locale != "es" || cfg.HealthCheckInterval.String() != "2m0s" {
+		t.Errorf("Load() didn't read the file: token %q, locale %q, health check %v", cfg.DiscordBotToken, cfg.Locale, cfg.HealthCheckInterval)
+	}
+	if cfg.DiscordChannelID != "222222222222222222" || cfg.Timezone != "America/Chicago" {
+		t.Errorf("Load() = channel %q, zone %q; want the environment's values", cfg.DiscordChannelID, cfg.Timezone)
+	}
+	if cfg.Channels.CheckIn != "222222222222222222" {
+		t.Errorf("Channels.CheckIn = %q, want the overridden channel", cfg.Channels.CheckIn)
+	}
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/storage"
+)
+
+// secretKeys are the settings that may come from a secret provider
+var secretKeys = []string{
+	"DISCORD_BOT_TOKEN",
+	"DB_PASSWORD",
+	"BACKUP_S3_SECRET_ACCESS_KEY",
+	"SENTRY_DSN",
+	"API_TOKEN",
+	"STRAVA_CLIENT_SECRET",
+	"FITBIT_CLIENT_SECRET",
+	"DISCORD_CLIENT_SECRET",
+	"GOOGLE_SERVICE_ACCOUNT_KEY",
+	"TWILIO_AUTH_TOKEN",
+	"SENDGRID_API_KEY",
+	"SMTP_PASSWORD",
+	"MQTT_PASSWORD",
+	"LLM_API_KEY",
+}
+
+// ErrSecretNotFound is returned by a provider that has no value for a secret
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider fetches secrets by their environment variable name
+type SecretProvider interface {
+	Secret(name string) (string, error)
+}
+
+// secretTimeout bounds each request to a remote secret store
+const secretTimeout = 10 * time.Second
+
+// newSecretProvider builds the provider selected by SECRETS_PROVIDER, or nil for plain env vars
+func newSecretProvider(env source) (SecretProvider, error) {
+	switch provider := strings.ToLower(env.get("SECRETS_PROVIDER")); provider {
+	case "", "env":
+		return nil, nil
+	case "file":
+		return &FileSecretProvider{Dir: env.getOrDefault("SECRETS_DIR", "/run/secrets")}, nil
+	case "ssm":
+		creds := storage.AWSCredentials{
+			Region:          env.getOrDefault("AWS_REGION", "us-east-1"),
+			AccessKeyID:     env.get("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: env.get("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    env.get("AWS_SESSION_TOKEN"),
+		}
+		if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+			return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required when SECRETS_PROVIDER=ssm")
+		}
+		return &SSMSecretProvider{
+			Prefix:      env.getOrDefault("SECRETS_SSM_PREFIX", "/hard75/"),
+			Credentials: creds,
+			httpClient:  &http.Client{Timeout: secretTimeout},
+		}, nil
+	case "vault":
+		addr, token, path := env.get("VAULT_ADDR"), env.get("VAULT_TOKEN"), env.get("SECRETS_VAULT_PATH")
+		if addr == "" || token == "" || path == "" {
+			return nil, fmt.Errorf("VAULT_ADDR, VAULT_TOKEN, and SECRETS_VAULT_PATH are required when SECRETS_PROVIDER=vault")
+		}
+		return &VaultSecretProvider{
+			Addr:       addr,
+			Token:      token,
+			Path:       path,
+			httpClient: &http.Client{Timeout: secretTimeout},
+		}, nil
+	default:
+		return nil, fmt.Errorf("SECRETS_PROVIDER must be env, file, ssm, or vault (got %q)", provider)
+	}
+}
+
+// loadSecrets fetches secrets that aren't already set in the environment or config file
+func loadSecrets(env source, provider SecretProvider) (map[string]string, error) {
+	secrets := make(map[string]string)
+	for _, name := range secretKeys {
+		if env.get(name) != "" {
+			continue
+		}
+		value, err := provider.Secret(name)
+		if errors.Is(err, ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch secret %s: %w", name, err)
+		}
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// FileSecretProvider reads secrets from files in a directory, one file per secret, as
+// mounted by Docker or Kubernetes. A secret is read from DISCORD_BOT_TOKEN or discord_bot_token.
+type FileSecretProvider struct {
+	Dir string
+}
+
+// Secret reads the named secret file, trimming a trailing newline
+func (p *FileSecretProvider) Secret(name string) (string, error) {
+	for _, fileName := range []string{name, strings.ToLower(name)} {
+		data, err := os.ReadFile(filepath.Join(p.Dir, fileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	return "", ErrSecretNotFound
+}
+
+// SSMSecretProvider reads SecureString parameters from AWS SSM Parameter Store.
+// A secret is stored at Prefix + name, e.g. /hard75/DISCORD_BOT_TOKEN.
+type SSMSecretProvider struct {
+	Prefix      string
+	Credentials storage.AWSCredentials
+	httpClient  *http.Client
+}
+
+// Secret fetches and decrypts one parameter
+func (p *SSMSecretProvider) Secret(name string) (string, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"Name":           p.Prefix + name,
+		"WithDecryption": true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("https://ssm.%s.amazonaws.com/", p.Credentials.Region)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build SSM request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonSSM.GetParameter")
+	storage.SignV4(req, payload, "ssm", p.Credentials, time.Now().UTC())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("SSM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read SSM response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if strings.Contains(string(body), "ParameterNotFound") {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("SSM returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Parameter struct {
+			Value string `json:"Value"`
+		} `json:"Parameter"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode SSM response: %w", err)
+	}
+	return result.Parameter.Value, nil
+}
+
+// VaultSecretProvider reads secrets from one HashiCorp Vault KV secret, keyed by name.
+// Path is the API path after /v1/, e.g. secret/data/hard75 for a KV v2 mount.
+type VaultSecretProvider struct {
+	Addr       string
+	Token      string
+	Path       string
+	httpClient *http.Client
+	values     map[string]string // Fetched once on first use
+}
+
+// Secret returns one key of the Vault secret
+func (p *VaultSecretProvider) Secret(name string) (string, error) {
+	if p.values == nil {
+		values, err := p.fetch()
+		if err != nil {
+			return "", err
+		}
+		p.values = values
+	}
+
+	value, ok := p.values[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// fetch reads the whole secret, supporting both KV v1 and v2 response shapes
+func (p *VaultSecretProvider) fetch() (map[string]string, error) {
+	endpoint := strings.TrimRight(p.Addr, "/") + "/v1/" + strings.TrimLeft(p.Path, "/")
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Vault returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	// KV v2 nests the values under data.data
+	data := result.Data
+	if nested, ok := data["data"]; ok {
+		data = nil
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode Vault secret data: %w", err)
+		}
+	}
+
+	values := make(map[string]string, len(data))
+	for key, raw := range data {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			continue // Only string values are secrets we can use
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -4,8 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
-
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"strings"
 
 	"github.com/75-hard-discord-bot/internal/database/migrations"
 )
@@ -18,6 +17,8 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrationsDryRun prints pending migrations instead of applying them
+	MigrationsDryRun bool
 }
 
 // GetConfigFromEnv reads database configuration from environment variables
@@ -68,7 +69,7 @@ func Connect(config *Config) (*sql.DB, error) {
 
 	dsn := config.BuildDSN()
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -81,11 +82,17 @@ func Connect(config *Config) (*sql.DB, error) {
 
 	// Run migrations
 	mgr := migrations.NewManager(db)
+	mgr.SetDryRun(config.MigrationsDryRun)
 	if err := mgr.Run(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("migration failed: %w", err)
 	}
 
+	// Dry run only reports what would change, so leave the trigger alone too
+	if config.MigrationsDryRun {
+		return db, nil
+	}
+
 	// Ensure trigger function exists (applied separately due to migration complexity)
 	if err := ensureAutoPopulateTrigger(db); err != nil {
 		db.Close()
@@ -121,100 +128,21 @@ func ensureAutoPopulateTrigger(db *sql.DB) error {
 		return fmt.Errorf("failed to check for autopopulated column: %w", err)
 	}
 
-	// Build function SQL based on whether autopopulated column exists
-	var functionSQL string
-	if hasAutopopulated {
-		// Use autopopulated-aware logic
-		functionSQL = `
-			CREATE OR REPLACE FUNCTION auto_populate_feats_on_checkin()
-			RETURNS TRIGGER AS $$
-			BEGIN
-				-- Insert or update exercise completion (only if doesn't exist or was autopopulated)
-				INSERT INTO exercise_completions (user_id, challenge_day, completed_at, autopopulated)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at, true)
-				ON CONFLICT (user_id, challenge_day) 
-				DO UPDATE SET 
-					completed_at = NEW.completed_at,
-					autopopulated = true
-				WHERE exercise_completions.autopopulated IS NULL OR exercise_completions.autopopulated = true;
-
-				-- Insert or update diet completion (only if doesn't exist or was autopopulated)
-				INSERT INTO diet_completions (user_id, challenge_day, completed_at, autopopulated)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at, true)
-				ON CONFLICT (user_id, challenge_day) 
-				DO UPDATE SET 
-					completed_at = NEW.completed_at,
-					autopopulated = true
-				WHERE diet_completions.autopopulated IS NULL OR diet_completions.autopopulated = true;
-
-				-- Insert or update water completion (only if doesn't exist or was autopopulated)
-				INSERT INTO water_completions (user_id, challenge_day, completed_at, autopopulated)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at, true)
-				ON CONFLICT (user_id, challenge_day) 
-				DO UPDATE SET 
-					completed_at = NEW.completed_at,
-					autopopulated = true
-				WHERE water_completions.autopopulated IS NULL OR water_completions.autopopulated = true;
-
-				-- Insert or update self-improvement completion (only if doesn't exist or was autopopulated)
-				INSERT INTO self_improvement_completions (user_id, challenge_day, completed_at, autopopulated)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at, true)
-				ON CONFLICT (user_id, challenge_day) 
-				DO UPDATE SET 
-					completed_at = NEW.completed_at,
-					autopopulated = true
-				WHERE self_improvement_completions.autopopulated IS NULL OR self_improvement_completions.autopopulated = true;
-
-				-- Insert or update finances completion (only if doesn't exist or was autopopulated)
-				INSERT INTO finances_completions (user_id, challenge_day, completed_at, autopopulated)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at, true)
-				ON CONFLICT (user_id, challenge_day) 
-				DO UPDATE SET 
-					completed_at = NEW.completed_at,
-					autopopulated = true
-				WHERE finances_completions.autopopulated IS NULL OR finances_completions.autopopulated = true;
-
-				RETURN NEW;
-			END;
-			$$ LANGUAGE plpgsql;
-		`
-	} else {
-		// Fallback: simple insert without autopopulated column (for backwards compatibility)
-		functionSQL = `
-			CREATE OR REPLACE FUNCTION auto_populate_feats_on_checkin()
-			RETURNS TRIGGER AS $$
-			BEGIN
-				-- Insert exercise completion with defaults
-				INSERT INTO exercise_completions (user_id, challenge_day, completed_at)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at)
-				ON CONFLICT (user_id, challenge_day) DO NOTHING;
-
-				-- Insert diet completion with defaults
-				INSERT INTO diet_completions (user_id, challenge_day, completed_at)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at)
-				ON CONFLICT (user_id, challenge_day) DO NOTHING;
-
-				-- Insert water completion with defaults
-				INSERT INTO water_completions (user_id, challenge_day, completed_at)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at)
-				ON CONFLICT (user_id, challenge_day) DO NOTHING;
-
-				-- Insert self-improvement completion with defaults
-				INSERT INTO self_improvement_completions (user_id, challenge_day, completed_at)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at)
-				ON CONFLICT (user_id, challenge_day) DO NOTHING;
-
-				-- Insert finances completion with defaults
-				INSERT INTO finances_completions (user_id, challenge_day, completed_at)
-				VALUES (NEW.user_id, NEW.challenge_day, NEW.completed_at)
-				ON CONFLICT (user_id, challenge_day) DO NOTHING;
-
-				RETURN NEW;
-			END;
-			$$ LANGUAGE plpgsql;
-		`
+	// Check if completion_date column exists (added after autopopulated)
+	var hasCompletionDate bool
+	err = db.QueryRow(
+		`SELECT EXISTS(
+			SELECT 1 FROM information_schema.columns 
+			WHERE table_name = 'exercise_completions' AND column_name = 'completion_date'
+		)`,
+	).Scan(&hasCompletionDate)
+	if err != nil {
+		return fmt.Errorf("failed to check for completion_date column: %w", err)
 	}
 
+	// Build function SQL based on which columns exist
+	functionSQL := buildAutoPopulateFunction(hasAutopopulated, hasCompletionDate)
+
 	_, err = db.Exec(functionSQL)
 	if err != nil {
 		return fmt.Errorf("failed to create trigger function: %w", err)
@@ -270,3 +198,57 @@ func ensureAutoPopulateTrigger(db *sql.DB) error {
 
 	return nil
 }
+
+// autoPopulateTables lists the feat tables filled in by a daily check-in
+var autoPopulateTables = []string{
+	"exercise_completions",
+	"diet_completions",
+	"water_completions",
+	"self_improvement_completions",
+	"finances_completions",
+}
+
+// buildAutoPopulateFunction builds the trigger function SQL for the columns present in the schema
+func buildAutoPopulateFunction(hasAutopopulated, hasCompletionDate bool) string {
+	columns := "user_id, challenge_day, completed_at"
+	values := "NEW.user_id, NEW.challenge_day, NEW.completed_at"
+	if hasCompletionDate {
+		columns += ", completion_date"
+		values += ", NEW.completion_date"
+	}
+
+	var body strings.Builder
+	for _, table := range autoPopulateTables {
+		if hasAutopopulated {
+			// Insert or update (only if doesn't exist or was autopopulated)
+			updates := "completed_at = NEW.completed_at,\n\t\t\t\t\tautopopulated = true"
+			if hasCompletionDate {
+				updates = "completed_at = NEW.completed_at,\n\t\t\t\t\tcompletion_date = NEW.completion_date,\n\t\t\t\t\tautopopulated = true"
+			}
+			body.WriteString(fmt.Sprintf(`
+				INSERT INTO %[1]s (%[2]s, autopopulated)
+				VALUES (%[3]s, true)
+				ON CONFLICT (user_id, challenge_day) 
+				DO UPDATE SET 
+					%[4]s
+				WHERE %[1]s.autopopulated IS NULL OR %[1]s.autopopulated = true;
+`, table, columns, values, updates))
+		} else {
+			// Fallback: simple insert without autopopulated column (for backwards compatibility)
+			body.WriteString(fmt.Sprintf(`
+				INSERT INTO %s (%s)
+				VALUES (%s)
+				ON CONFLICT (user_id, challenge_day) DO NOTHING;
+`, table, columns, values))
+		}
+	}
+
+	return `
+			CREATE OR REPLACE FUNCTION auto_populate_feats_on_checkin()
+			RETURNS TRIGGER AS $$
+			BEGIN` + body.String() + `
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql;
+		`
+}
@@ -18,7 +18,8 @@ type Migration struct {
 
 // Manager handles database migrations
 type Manager struct {
-	db *sql.DB
+	db     *sql.DB
+	dryRun bool
 }
 
 // NewManager creates a new migration manager
@@ -26,6 +27,23 @@ func NewManager(db *sql.DB) *Manager {
 	return &Manager{db: db}
 }
 
+// SetDryRun enables or disables dry-run mode.
+// In dry-run mode Run prints the statements each pending migration would execute
+// (after BEGIN/COMMIT stripping and statement splitting) without writing anything.
+func (m *Manager) SetDryRun(enabled bool) {
+	m.dryRun = enabled
+}
+
+// migrationsTableExists reports whether schema_migrations has been created yet
+func (m *Manager) migrationsTableExists() (bool, error) {
+	var exists bool
+	err := m.db.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for schema_migrations table: %w", err)
+	}
+	return exists, nil
+}
+
 // EnsureMigrationsTable creates the schema_migrations table if it doesn't exist
 func (m *Manager) EnsureMigrationsTable() error {
 	query := `
@@ -216,6 +234,22 @@ func (m *Manager) ApplyMigration(migration Migration) error {
 	return nil
 }
 
+// PrintMigration prints the statements a migration would execute without applying it
+func (m *Manager) PrintMigration(migration Migration) {
+	sql := stripTransactionStatements(migration.SQL)
+	statements := splitSQLStatements(sql)
+
+	log.Printf("📝 [DRY RUN] Migration %04d_%s (%d statement(s), checksum %s)",
+		migration.Version, migration.Name, len(statements), CalculateChecksum(sql))
+	for i, stmt := range statements {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		fmt.Printf("-- %04d_%s statement %d\n%s\n\n", migration.Version, migration.Name, i+1, stmt)
+	}
+}
+
 // ValidateChecksums validates that all applied migrations match their stored checksums
 func (m *Manager) ValidateChecksums(migrations []Migration) error {
 	applied, err := m.GetAppliedMigrations()
@@ -251,11 +285,27 @@ func (m *Manager) ValidateChecksums(migrations []Migration) error {
 // 2. Scans for migration files
 // 3. Validates checksums of already-applied migrations
 // 4. Applies pending migrations
+//
+// In dry-run mode no table is created and pending migrations are printed instead of applied.
 func (m *Manager) Run() error {
-	log.Println("🔄 Starting database migrations...")
+	if m.dryRun {
+		log.Println("🔄 Starting database migrations (DRY RUN - nothing will be applied)...")
+	} else {
+		log.Println("🔄 Starting database migrations...")
+	}
 
 	// Step 1: Ensure migrations table exists
-	if err := m.EnsureMigrationsTable(); err != nil {
+	tableExists := true
+	if m.dryRun {
+		var err error
+		tableExists, err = m.migrationsTableExists()
+		if err != nil {
+			return err
+		}
+		if !tableExists {
+			log.Println("📝 [DRY RUN] Would create schema_migrations table")
+		}
+	} else if err := m.EnsureMigrationsTable(); err != nil {
 		return fmt.Errorf("failed to ensure migrations table: %w", err)
 	}
 
@@ -272,15 +322,18 @@ func (m *Manager) Run() error {
 
 	log.Printf("📁 Found %d migration file(s)", len(migrations))
 
-	// Step 3: Get applied migrations
-	applied, err := m.GetAppliedMigrations()
-	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
-	}
+	// Step 3: Get applied migrations (none if the tracking table doesn't exist yet)
+	applied := make(map[int]Migration)
+	if tableExists {
+		applied, err = m.GetAppliedMigrations()
+		if err != nil {
+			return fmt.Errorf("failed to get applied migrations: %w", err)
+		}
 
-	// Step 4: Validate checksums of already-applied migrations
-	if err := m.ValidateChecksums(migrations); err != nil {
-		return fmt.Errorf("checksum validation failed: %w", err)
+		// Step 4: Validate checksums of already-applied migrations
+		if err := m.ValidateChecksums(migrations); err != nil {
+			return fmt.Errorf("checksum validation failed: %w", err)
+		}
 	}
 
 	// Step 5: Apply pending migrations
@@ -291,6 +344,12 @@ func (m *Manager) Run() error {
 			continue
 		}
 
+		if m.dryRun {
+			m.PrintMigration(migration)
+			pendingCount++
+			continue
+		}
+
 		log.Printf("🔄 Applying migration %04d_%s...", migration.Version, migration.Name)
 		if err := m.ApplyMigration(migration); err != nil {
 			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
@@ -300,6 +359,8 @@ func (m *Manager) Run() error {
 
 	if pendingCount == 0 {
 		log.Println("✅ All migrations are up to date")
+	} else if m.dryRun {
+		log.Printf("📝 [DRY RUN] %d pending migration(s) would be applied", pendingCount)
 	} else {
 		log.Printf("✅ Applied %d pending migration(s)", pendingCount)
 	}
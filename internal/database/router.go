@@ -0,0 +1,67 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Router sends writes to the primary database and heavy reads to an optional replica.
+// When no replica is configured every query goes to the primary.
+type Router struct {
+	primary *sql.DB
+	replica *sql.DB
+}
+
+// NewRouter creates a query router; replica may be nil
+func NewRouter(primary, replica *sql.DB) *Router {
+	return &Router{
+		primary: primary,
+		replica: replica,
+	}
+}
+
+// Primary returns the connection used for writes and read-your-writes queries
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Read returns the connection used for summary, leaderboard, and analytics queries
+func (r *Router) Read() *sql.DB {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.primary
+}
+
+// HasReplica reports whether reads are routed to a separate replica
+func (r *Router) HasReplica() bool {
+	return r.replica != nil
+}
+
+// Close closes the replica connection (the primary is owned by the caller)
+func (r *Router) Close() error {
+	if r.replica != nil {
+		return r.replica.Close()
+	}
+	return nil
+}
+
+// ConnectReplica opens a read-only connection to a replica using a full DSN.
+// Migrations are never run against the replica; it receives schema changes via replication.
+func ConnectReplica(dsn string) (*sql.DB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("replica DSN is required")
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replica connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping replica: %w", err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/75-hard-discord-bot/internal/tracing"
+)
+
+// driverName is the PostgreSQL driver wrapped so queries run with a traced context
+// (QueryContext, ExecContext, ...) are recorded as child spans
+const driverName = "postgres-traced"
+
+func init() {
+	sql.Register(driverName, tracedDriver{base: &pq.Driver{}})
+}
+
+type tracedDriver struct {
+	base driver.Driver
+}
+
+// Open opens a pq connection and wraps it
+func (d tracedDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.base.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn}, nil
+}
+
+// tracedConn forwards to the pq connection, recording a span for each query.
+// pq implements every optional interface used here.
+type tracedConn struct {
+	driver.Conn
+}
+
+// QueryContext records a span for a query returning rows
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	_, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	span.RecordError(err)
+	return rows, err
+}
+
+// ExecContext records a span for a statement without rows
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	_, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	result, err := c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	span.RecordError(err)
+	return result, err
+}
+
+// PrepareContext forwards to pq
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+// BeginTx forwards to pq
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+// Ping forwards to pq
+func (c *tracedConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// ResetSession forwards to pq
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+// IsValid forwards to pq
+func (c *tracedConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+// startQuerySpan starts a span named after the SQL verb, e.g. "SQL SELECT"
+func startQuerySpan(ctx context.Context, query string) (context.Context, *tracing.Span) {
+	statement := strings.TrimSpace(query)
+	operation := statement
+	if fields := strings.Fields(statement); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return tracing.StartChild(ctx, "SQL "+operation, tracing.KindClient,
+		"db.system", "postgresql",
+		"db.operation.name", operation,
+		"db.query.text", statement,
+	)
+}
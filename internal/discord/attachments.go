@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxUploadBytes is the largest file a bot can attach in a server without boosts
+const MaxUploadBytes = 10 << 20
+
+// attachmentClient downloads attachments from Discord's CDN
+var attachmentClient = &http.Client{Timeout: 5 * time.Minute}
+
+// DownloadAttachment copies an attachment's content to dst, failing when it's larger
+// than maxBytes
+func DownloadAttachment(attachment *discordgo.MessageAttachment, maxBytes int64, dst io.Writer) error {
+	if int64(attachment.Size) > maxBytes {
+		return fmt.Errorf("%s is %d MB; the limit is %d MB", attachment.Filename, attachment.Size>>20, maxBytes>>20)
+	}
+
+	resp, err := attachmentClient.Get(attachment.URL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", attachment.Filename, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", attachment.Filename, resp.Status)
+	}
+
+	// The reported size is trusted only so far
+	written, err := io.Copy(dst, io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", attachment.Filename, err)
+	}
+	if written > maxBytes {
+		return fmt.Errorf("%s is larger than %d MB", attachment.Filename, maxBytes>>20)
+	}
+	return nil
+}
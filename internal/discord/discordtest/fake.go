@@ -0,0 +1,288 @@
+// Package discordtest provides an in-memory discord.Session for exercising
+// handlers without a live gateway connection.
+package discordtest
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+)
+
+// SentMessage is a message sent to a channel through the fake session
+type SentMessage struct {
+	ChannelID string
+	Content   string
+	Files     []*discordgo.File
+}
+
+// Reaction is a reaction added through the fake session
+type Reaction struct {
+	ChannelID string
+	MessageID string
+	Emoji     string
+}
+
+// FakeSession records every call made through it. Seed Messages and Users to
+// control what lookups return; set Err to make every call fail.
+type FakeSession struct {
+	mu sync.Mutex
+
+	BotID    string
+	Messages map[string]*discordgo.Message // by message ID
+	Users    map[string]*discordgo.User    // by user ID
+	Err      error
+
+	Responses []*discordgo.InteractionResponse
+	Edits     []*discordgo.WebhookEdit
+	Followups []*discordgo.WebhookParams
+	Sent      []SentMessage
+	Edited    []*discordgo.MessageEdit
+	Reactions []Reaction
+	Pinned    map[string][]string // channel ID -> message IDs
+	Threads   map[string]string   // thread ID -> name
+	DMs       map[string]string   // user ID -> DM channel ID
+
+	nextID int
+}
+
+var _ discord.Session = (*FakeSession)(nil)
+
+// NewFakeSession creates an empty fake session for a bot with the given user ID
+func NewFakeSession(botID string) *FakeSession {
+	return &FakeSession{
+		BotID:    botID,
+		Messages: make(map[string]*discordgo.Message),
+		Users:    make(map[string]*discordgo.User),
+		Pinned:   make(map[string][]string),
+		Threads:  make(map[string]string),
+		DMs:      make(map[string]string),
+	}
+}
+
+// BotUserID returns the fake bot's user ID
+func (f *FakeSession) BotUserID() string {
+	return f.BotID
+}
+
+// InteractionRespond records the interaction response
+func (f *FakeSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.Responses = append(f.Responses, resp)
+	return nil
+}
+
+// InteractionResponseEdit records an edit to a deferred response
+func (f *FakeSession) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.Edits = append(f.Edits, newresp)
+	content := ""
+	if newresp.Content != nil {
+		content = *newresp.Content
+	}
+	return f.storeMessage(interaction.ChannelID, content), nil
+}
+
+// FollowupMessageCreate records a follow-up message
+func (f *FakeSession) FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.Followups = append(f.Followups, data)
+	return f.storeMessage(interaction.ChannelID, data.Content), nil
+}
+
+// ChannelMessage returns a seeded or previously sent message
+func (f *FakeSession) ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	message, ok := f.Messages[messageID]
+	if !ok {
+		return nil, fmt.Errorf("unknown message %s", messageID)
+	}
+	return message, nil
+}
+
+// ChannelMessageSend records a plain message send
+func (f *FakeSession) ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return f.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Content: content}, options...)
+}
+
+// ChannelMessageSendComplex records a message send, including attached files
+func (f *FakeSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.Sent = append(f.Sent, SentMessage{ChannelID: channelID, Content: data.Content, Files: data.Files})
+	return f.storeMessage(channelID, data.Content), nil
+}
+
+// ChannelMessageEditComplex records a message edit and updates the stored message
+func (f *FakeSession) ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	message, ok := f.Messages[m.ID]
+	if !ok {
+		return nil, fmt.Errorf("unknown message %s", m.ID)
+	}
+	f.Edited = append(f.Edited, m)
+	if m.Content != nil {
+		message.Content = *m.Content
+	}
+	return message, nil
+}
+
+// MessageReactionAdd records a reaction
+func (f *FakeSession) MessageReactionAdd(channelID, messageID, emojiID string, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.Reactions = append(f.Reactions, Reaction{ChannelID: channelID, MessageID: messageID, Emoji: emojiID})
+	return nil
+}
+
+// ChannelMessagePin pins a message in the channel
+func (f *FakeSession) ChannelMessagePin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.Pinned[channelID] = append(f.Pinned[channelID], messageID)
+	return nil
+}
+
+// ChannelMessageUnpin unpins a message in the channel
+func (f *FakeSession) ChannelMessageUnpin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	pins := f.Pinned[channelID][:0]
+	for _, id := range f.Pinned[channelID] {
+		if id != messageID {
+			pins = append(pins, id)
+		}
+	}
+	f.Pinned[channelID] = pins
+	return nil
+}
+
+// ChannelMessagesPinned returns the pinned messages that are known to the fake
+func (f *FakeSession) ChannelMessagesPinned(channelID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	var pins []*discordgo.Message
+	for _, id := range f.Pinned[channelID] {
+		if message, ok := f.Messages[id]; ok {
+			pins = append(pins, message)
+		}
+	}
+	return pins, nil
+}
+
+// ForumThreadStart creates a forum post; the starter message is stored in the new thread
+func (f *FakeSession) ForumThreadStart(channelID, name string, archiveDuration int, content string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.nextID++
+	thread := &discordgo.Channel{
+		ID:       "thread-" + strconv.Itoa(f.nextID),
+		ParentID: channelID,
+		Name:     name,
+		Type:     discordgo.ChannelTypeGuildPublicThread,
+	}
+	f.Threads[thread.ID] = name
+	f.Sent = append(f.Sent, SentMessage{ChannelID: thread.ID, Content: content})
+	f.storeMessage(thread.ID, content)
+	return thread, nil
+}
+
+// MessageThreadStart starts a thread from a message
+func (f *FakeSession) MessageThreadStart(channelID, messageID string, name string, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.nextID++
+	thread := &discordgo.Channel{
+		ID:       "thread-" + strconv.Itoa(f.nextID),
+		ParentID: channelID,
+		Name:     name,
+		Type:     discordgo.ChannelTypeGuildPublicThread,
+	}
+	f.Threads[thread.ID] = name
+	return thread, nil
+}
+
+// User returns a seeded user
+func (f *FakeSession) User(userID string, options ...discordgo.RequestOption) (*discordgo.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	user, ok := f.Users[userID]
+	if !ok {
+		return nil, fmt.Errorf("unknown user %s", userID)
+	}
+	return user, nil
+}
+
+// UserChannelCreate returns a stable DM channel for the user
+func (f *FakeSession) UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	channelID, ok := f.DMs[recipientID]
+	if !ok {
+		channelID = "dm-" + recipientID
+		f.DMs[recipientID] = channelID
+	}
+	return &discordgo.Channel{ID: channelID, Type: discordgo.ChannelTypeDM}, nil
+}
+
+// storeMessage saves a bot-authored message so later lookups can find it; callers hold mu
+func (f *FakeSession) storeMessage(channelID, content string) *discordgo.Message {
+	f.nextID++
+	message := &discordgo.Message{
+		ID:        strconv.Itoa(f.nextID),
+		ChannelID: channelID,
+		Content:   content,
+		Author:    &discordgo.User{ID: f.BotID},
+	}
+	f.Messages[message.ID] = message
+	return message
+}
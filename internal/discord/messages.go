@@ -0,0 +1,96 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxMessageLength is Discord's limit on message content, in characters
+const MaxMessageLength = 2000
+
+// SplitMessage breaks content into chunks of at most limit characters, splitting
+// on line boundaries so lines stay intact. A single line longer than limit is
+// split mid-line as a last resort.
+func SplitMessage(content string, limit int) []string {
+	if utf8.RuneCountInString(content) <= limit {
+		return []string{content}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		// Discord rejects empty messages, so drop chunks that are only blank lines
+		if chunk := strings.TrimRight(current.String(), "\n"); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+		currentLen = 0
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		lineLen := utf8.RuneCountInString(line)
+
+		if currentLen+lineLen > limit {
+			flush()
+		}
+
+		// Hard-split lines that can't fit in a chunk on their own
+		for lineLen > limit {
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+			lineLen -= limit
+		}
+
+		current.WriteString(line)
+		currentLen += lineLen
+	}
+	flush()
+
+	return chunks
+}
+
+// RespondLong responds to an interaction with content of any length. The first
+// chunk is the interaction response; the rest are sent as follow-up messages
+// with the same flags (so ephemeral responses stay ephemeral).
+func RespondLong(s Session, interaction *discordgo.Interaction, content string, flags discordgo.MessageFlags, options ...discordgo.RequestOption) error {
+	chunks := SplitMessage(content, MaxMessageLength)
+
+	err := s.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: chunks[0],
+			Flags:   flags,
+		},
+	}, options...)
+	if err != nil {
+		return fmt.Errorf("failed to respond: %w", err)
+	}
+
+	for idx, chunk := range chunks[1:] {
+		_, err := s.FollowupMessageCreate(interaction, true, &discordgo.WebhookParams{
+			Content: chunk,
+			Flags:   flags,
+		}, options...)
+		if err != nil {
+			return fmt.Errorf("failed to send follow-up %d/%d: %w", idx+2, len(chunks), err)
+		}
+	}
+	return nil
+}
+
+// SendLong sends content of any length to a channel as one or more messages
+func SendLong(s Session, channelID, content string, options ...discordgo.RequestOption) error {
+	chunks := SplitMessage(content, MaxMessageLength)
+	for idx, chunk := range chunks {
+		if _, err := s.ChannelMessageSend(channelID, chunk, options...); err != nil {
+			return fmt.Errorf("failed to send message %d/%d: %w", idx+1, len(chunks), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// SandboxPrefix marks every message sent while sandboxed
+const SandboxPrefix = "[DEV] "
+
+// sandboxDMPrefix marks the fake DM channel IDs handed out while sandboxed
+const sandboxDMPrefix = "sandbox-dm-"
+
+// sandboxSession sends every channel message, pin, thread, and DM to one sandbox channel
+// and prefixes messages with [DEV]. Interaction responses are left alone: they only
+// reach the developer who ran the command.
+type sandboxSession struct {
+	Session
+	channelID string
+}
+
+// Sandbox wraps a session so a dev instance running against a copy of production data
+// can't post to the real server. Forum posts become plain messages, and DMs become
+// messages that mention the recipient.
+func Sandbox(s Session, channelID string) Session {
+	return &sandboxSession{Session: s, channelID: channelID}
+}
+
+// prefix adds the [DEV] marker, plus the original destination for DMs
+func (s *sandboxSession) prefix(channelID, content string) string {
+	if recipientID, ok := strings.CutPrefix(channelID, sandboxDMPrefix); ok {
+		return fmt.Sprintf("%s(DM to <@%s>) %s", SandboxPrefix, recipientID, content)
+	}
+	return SandboxPrefix + content
+}
+
+// ChannelMessage reads from the sandbox channel, where every bot message was sent
+func (s *sandboxSession) ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.Session.ChannelMessage(s.channelID, messageID, options...)
+}
+
+// ChannelMessageSend sends to the sandbox channel
+func (s *sandboxSession) ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.Session.ChannelMessageSend(s.channelID, s.prefix(channelID, content), options...)
+}
+
+// ChannelMessageSendComplex sends to the sandbox channel, without pinging anyone.
+// Replies to messages in other channels are sent as plain messages.
+func (s *sandboxSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	sandboxed := *data
+	sandboxed.Content = s.prefix(channelID, data.Content)
+	sandboxed.AllowedMentions = &discordgo.MessageAllowedMentions{}
+	if channelID != s.channelID {
+		sandboxed.Reference = nil
+	}
+	return s.Session.ChannelMessageSendComplex(s.channelID, &sandboxed, options...)
+}
+
+// ChannelMessageEditComplex edits a message in the sandbox channel, keeping the [DEV] marker
+func (s *sandboxSession) ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	sandboxed := *m
+	if m.Content != nil {
+		content := s.prefix(m.Channel, *m.Content)
+		sandboxed.Content = &content
+	}
+	sandboxed.Channel = s.channelID
+	sandboxed.AllowedMentions = &discordgo.MessageAllowedMentions{}
+	return s.Session.ChannelMessageEditComplex(&sandboxed, options...)
+}
+
+// MessageReactionAdd reacts in the sandbox channel
+func (s *sandboxSession) MessageReactionAdd(channelID, messageID, emojiID string, options ...discordgo.RequestOption) error {
+	return s.Session.MessageReactionAdd(s.channelID, messageID, emojiID, options...)
+}
+
+// ChannelMessagePin pins in the sandbox channel
+func (s *sandboxSession) ChannelMessagePin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	return s.Session.ChannelMessagePin(s.channelID, messageID, options...)
+}
+
+// ChannelMessageUnpin unpins in the sandbox channel
+func (s *sandboxSession) ChannelMessageUnpin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	return s.Session.ChannelMessageUnpin(s.channelID, messageID, options...)
+}
+
+// ChannelMessagesPinned lists the sandbox channel's pins
+func (s *sandboxSession) ChannelMessagesPinned(channelID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error) {
+	return s.Session.ChannelMessagesPinned(s.channelID, options...)
+}
+
+// ForumThreadStart posts the starter message in the sandbox channel and returns it as
+// the "thread", so later posts to the thread land there too
+func (s *sandboxSession) ForumThreadStart(channelID, name string, archiveDuration int, content string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	if _, err := s.ChannelMessageSend(channelID, fmt.Sprintf("(forum post %q) %s", name, content), options...); err != nil {
+		return nil, err
+	}
+	return &discordgo.Channel{ID: s.channelID, ParentID: channelID, Name: name, Type: discordgo.ChannelTypeGuildText}, nil
+}
+
+// MessageThreadStart starts the thread in the sandbox channel
+func (s *sandboxSession) MessageThreadStart(channelID, messageID string, name string, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return s.Session.MessageThreadStart(s.channelID, messageID, SandboxPrefix+name, archiveDuration, options...)
+}
+
+// UserChannelCreate returns a placeholder DM channel; messages sent to it go to the sandbox
+func (s *sandboxSession) UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return &discordgo.Channel{ID: sandboxDMPrefix + recipientID, Type: discordgo.ChannelTypeDM}, nil
+}
@@ -0,0 +1,56 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// Session is the narrow slice of the Discord API that handlers depend on.
+// The live gateway session satisfies it via Wrap; discordtest.FakeSession is an
+// in-memory implementation for exercising handlers without a connection.
+type Session interface {
+	// Interactions
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error
+	InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// Messages
+	ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	MessageReactionAdd(channelID, messageID, emojiID string, options ...discordgo.RequestOption) error
+
+	// Pins
+	ChannelMessagePin(channelID, messageID string, options ...discordgo.RequestOption) error
+	ChannelMessageUnpin(channelID, messageID string, options ...discordgo.RequestOption) error
+	ChannelMessagesPinned(channelID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error)
+
+	// Threads
+	ForumThreadStart(channelID, name string, archiveDuration int, content string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	MessageThreadStart(channelID, messageID string, name string, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	// Users
+	User(userID string, options ...discordgo.RequestOption) (*discordgo.User, error)
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	// BotUserID returns the bot's own user ID
+	BotUserID() string
+}
+
+// liveSession adapts a gateway-connected discordgo session to Session
+type liveSession struct {
+	*discordgo.Session
+}
+
+// Wrap adapts a live discordgo session to the Session interface
+func Wrap(s *discordgo.Session) Session {
+	return liveSession{s}
+}
+
+// BotUserID returns the bot's user ID from the session's READY state
+func (s liveSession) BotUserID() string {
+	if s.State == nil || s.State.User == nil {
+		return ""
+	}
+	return s.State.User.ID
+}
@@ -0,0 +1,123 @@
+// Package ui provides shared builders for embeds, progress bars, and button rows
+// so every feature renders messages the same way.
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Embed colors shared by every feature
+const (
+	ColorSuccess  = 0x2ECC71
+	ColorError    = 0xE74C3C
+	ColorWarning  = 0xF1C40F
+	ColorProgress = 0x3498DB
+)
+
+// DefaultBarWidth is the number of segments in a progress bar
+const DefaultBarWidth = 10
+
+// SuccessEmbed builds a green embed for a completed action
+func SuccessEmbed(title, description string) *discordgo.MessageEmbed {
+	return newEmbed("✅ "+title, description, ColorSuccess)
+}
+
+// ErrorEmbed builds a red embed for a failed action
+func ErrorEmbed(title, description string) *discordgo.MessageEmbed {
+	return newEmbed("❌ "+title, description, ColorError)
+}
+
+// WarningEmbed builds a yellow embed for confirmations and destructive prompts
+func WarningEmbed(title, description string) *discordgo.MessageEmbed {
+	return newEmbed("⚠️ "+title, description, ColorWarning)
+}
+
+// ProgressEmbed builds a blue embed with a progress bar for current out of total
+func ProgressEmbed(title string, current, total int, description string) *discordgo.MessageEmbed {
+	embed := newEmbed("📊 "+title, description, ColorProgress)
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  "Progress",
+		Value: ProgressBar(current, total, DefaultBarWidth),
+	})
+	return embed
+}
+
+// Field appends an inline-or-not field to an embed and returns it for chaining
+func Field(embed *discordgo.MessageEmbed, name, value string, inline bool) *discordgo.MessageEmbed {
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   name,
+		Value:  value,
+		Inline: inline,
+	})
+	return embed
+}
+
+// newEmbed builds an embed with the shared footer and timestamp
+func newEmbed(title, description string, color int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       color,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer:      &discordgo.MessageEmbedFooter{Text: "75 Half Chub Bot"},
+	}
+}
+
+// ProgressBar renders a text progress bar, e.g. "▰▰▰▰▱▱▱▱▱▱ 40% (30/75)"
+func ProgressBar(current, total, width int) string {
+	if total <= 0 {
+		total = 1
+	}
+	if current < 0 {
+		current = 0
+	}
+	if current > total {
+		current = total
+	}
+	if width <= 0 {
+		width = DefaultBarWidth
+	}
+
+	filled := current * width / total
+	percent := current * 100 / total
+	return fmt.Sprintf("%s%s %d%% (%d/%d)",
+		strings.Repeat("▰", filled), strings.Repeat("▱", width-filled), percent, current, total)
+}
+
+// Button builds a button with a routed CustomID
+func Button(label string, style discordgo.ButtonStyle, customID string) discordgo.Button {
+	return discordgo.Button{
+		Label:    label,
+		Style:    style,
+		CustomID: customID,
+	}
+}
+
+// ButtonRow wraps buttons in a single action row
+func ButtonRow(buttons ...discordgo.Button) discordgo.ActionsRow {
+	components := make([]discordgo.MessageComponent, 0, len(buttons))
+	for _, button := range buttons {
+		components = append(components, button)
+	}
+	return discordgo.ActionsRow{Components: components}
+}
+
+// ConfirmRow builds the standard confirm/cancel pair. Destructive confirmations
+// use a red confirm button; otherwise confirm is green and cancel is red.
+// Labels are passed in already translated.
+func ConfirmRow(confirmLabel, cancelLabel, confirmID, cancelID string, destructive bool) discordgo.ActionsRow {
+	if destructive {
+		return ButtonRow(
+			Button(confirmLabel, discordgo.DangerButton, confirmID),
+			Button(cancelLabel, discordgo.SecondaryButton, cancelID),
+		)
+	}
+	return ButtonRow(
+		Button(confirmLabel, discordgo.SuccessButton, confirmID),
+		Button(cancelLabel, discordgo.DangerButton, cancelID),
+	)
+}
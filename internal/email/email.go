@@ -0,0 +1,231 @@
+// Package email sends HTML email with a plain-text alternative, through an SMTP
+// server or the SendGrid API.
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// Message is one email to one recipient
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string // Plain-text alternative for clients that don't show HTML
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(m Message) error
+}
+
+// NormalizeAddress returns the bare address from user input such as
+// "Name <name@example.com>", or an error when it isn't an email address
+func NormalizeAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(strings.TrimSpace(address))
+	if err != nil || !strings.Contains(parsed.Address[strings.LastIndex(parsed.Address, "@")+1:], ".") {
+		return "", fmt.Errorf("%q is not an email address", address)
+	}
+	return parsed.Address, nil
+}
+
+// SMTP sends through an SMTP server. Port 465 uses implicit TLS; other ports
+// upgrade with STARTTLS when the server offers it.
+type SMTP struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTP creates an SMTP sender. from is the From header, e.g.
+// "75 Hard Bot <bot@example.com>". Without a username, mail is sent unauthenticated.
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	return &SMTP{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send delivers m
+func (s *SMTP) Send(m Message) error {
+	sender, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	body, err := buildMIME(s.from, m)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	var conn net.Conn
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if s.port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: s.host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(time.Minute))
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && s.port != 465 {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if err := client.Mail(sender.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	if err := client.Rcpt(m.To); err != nil {
+		return fmt.Errorf("recipient rejected: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMIME renders m as a multipart/alternative message
+func buildMIME(from string, m Message) ([]byte, error) {
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate boundary: %w", err)
+	}
+	boundary := hex.EncodeToString(raw)
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", from)
+	header("To", m.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `multipart/alternative; boundary="`+boundary+`"`)
+	b.WriteString("\r\n")
+
+	// Clients show the last part they understand, so HTML goes last
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		header("Content-Type", part.contentType)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		b.WriteString("\r\n")
+		qp := quotedprintable.NewWriter(&b)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+		qp.Close()
+		b.WriteString("\r\n")
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+	return b.Bytes(), nil
+}
+
+// SendGrid sends through the SendGrid v3 API
+type SendGrid struct {
+	apiKey     string
+	from       string
+	httpClient *http.Client
+}
+
+// NewSendGrid creates a SendGrid sender. from must be a verified sender in SendGrid,
+// optionally with a display name, e.g. "75 Hard Bot <bot@example.com>".
+func NewSendGrid(apiKey, from string) *SendGrid {
+	return &SendGrid{
+		apiKey:     apiKey,
+		from:       from,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Send delivers m
+func (s *SendGrid) Send(m Message) error {
+	sender, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"personalizations": []map[string]interface{}{{"to": []address{{Email: m.To}}}},
+		"from":             address{Email: sender.Address, Name: sender.Name},
+		"subject":          m.Subject,
+		"content":          []content{{"text/plain", m.Text}, {"text/html", m.HTML}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sendGridURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package errreport forwards error log lines and handler panics to a Sentry-compatible
+// error tracker (Sentry, GlitchTip, Bugsink, ...) using the store API.
+package errreport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queueSize bounds how many events can wait for delivery; extra events are dropped
+const queueSize = 100
+
+// SentryReporter sends events to a Sentry-compatible DSN in the background
+type SentryReporter struct {
+	storeURL    string
+	authHeader  string
+	environment string
+	serverName  string
+	httpClient  *http.Client
+	queue       chan map[string]interface{}
+	pending     sync.WaitGroup
+}
+
+// NewSentryReporter parses a DSN (https://{key}@{host}/{project_id}) and starts the sender
+func NewSentryReporter(dsn, environment string) (*SentryReporter, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil || parsed.User == nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN")
+	}
+	publicKey := parsed.User.Username()
+	path := strings.Trim(parsed.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if publicKey == "" || projectID == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing key or project ID")
+	}
+
+	prefix := ""
+	if slash >= 0 {
+		prefix = "/" + path[:slash]
+	}
+	hostname, _ := os.Hostname()
+
+	r := &SentryReporter{
+		storeURL:    fmt.Sprintf("%s://%s%s/api/%s/store/", parsed.Scheme, parsed.Host, prefix, projectID),
+		authHeader:  fmt.Sprintf("Sentry sentry_version=7, sentry_client=hard75-bot/1.0, sentry_key=%s", publicKey),
+		environment: environment,
+		serverName:  hostname,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan map[string]interface{}, queueSize),
+	}
+	go r.run()
+	return r, nil
+}
+
+// CaptureError queues an error event. Well-known fields (user_id, guild_id, command,
+// correlation_id) become tags; a "stack" field is attached as the stack trace.
+func (r *SentryReporter) CaptureError(message string, fields map[string]interface{}) {
+	event := map[string]interface{}{
+		"event_id":    eventID(),
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		"level":       "error",
+		"platform":    "go",
+		"logger":      "hard75",
+		"message":     map[string]string{"formatted": message},
+		"environment": r.environment,
+		"server_name": r.serverName,
+	}
+
+	tags := map[string]string{}
+	extra := map[string]interface{}{}
+	for key, value := range fields {
+		switch key {
+		case "user_id":
+			event["user"] = map[string]string{"id": fmt.Sprint(value)}
+			tags[key] = fmt.Sprint(value)
+		case "guild_id", "command", "correlation_id", "category":
+			tags[key] = fmt.Sprint(value)
+		case "fatal":
+			event["level"] = "fatal"
+		case "panic":
+			if value == true {
+				event["level"] = "fatal"
+				tags["panic"] = "true"
+			}
+		default:
+			extra[key] = value
+		}
+	}
+	event["tags"] = tags
+	event["extra"] = extra
+
+	r.pending.Add(1)
+	select {
+	case r.queue <- event:
+	default:
+		r.pending.Done()
+		fmt.Fprintln(os.Stderr, "error report dropped: queue full")
+	}
+}
+
+// Flush waits up to timeout for queued events to be sent; reports whether the queue drained
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// run delivers queued events one at a time
+func (r *SentryReporter) run() {
+	for event := range r.queue {
+		if err := r.send(event); err != nil {
+			// Not logged through the logger, which would report the failure again
+			fmt.Fprintf(os.Stderr, "error report failed: %v\n", err)
+		}
+		r.pending.Done()
+	}
+}
+
+// send posts one event to the store endpoint
+func (r *SentryReporter) send(event map[string]interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.authHeader)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("store API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// eventID returns a random 32-character hex ID as Sentry expects
+func eventID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
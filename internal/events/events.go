@@ -0,0 +1,160 @@
+// Package events is a lightweight in-process pub/sub bus. Services publish what
+// happened (a check-in, a penalty, a finished challenge) and subscribers such as
+// announcers, achievements, streaks, and webhooks react without the publisher
+// knowing about them.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Event names
+const (
+	CheckInRecordedEvent    = "check_in.recorded"
+	PenaltyAppliedEvent     = "penalty.applied"
+	ChallengeCompletedEvent = "challenge.completed"
+	SettingsChangedEvent    = "settings.changed"
+	WaterLoggedEvent        = "water.logged"
+	WeighInRecordedEvent    = "weigh_in.recorded"
+	BookFinishedEvent       = "book.finished"
+)
+
+// Event is anything published on the bus
+type Event interface {
+	// Name identifies the event type subscribers register for
+	Name() string
+}
+
+// CheckInRecorded is published when a user checks in for a challenge day
+type CheckInRecorded struct {
+	UserID       string
+	Username     string
+	ChallengeDay int
+	Date         time.Time // The challenge date checked in for (user's local day)
+	FirstForDay  bool      // False when the day was already checked in
+}
+
+// Name returns the event name
+func (CheckInRecorded) Name() string { return CheckInRecordedEvent }
+
+// PenaltyApplied is published when an admin adds days to or takes days off a user's
+// challenge
+type PenaltyApplied struct {
+	UserID       string
+	Username     string
+	ChallengeDay int
+	DaysAdded    int // Negative when days were taken off
+	Reason       string
+	LastDate     time.Time // The challenge's final day after the change
+	Silent       bool      // The admin chose not to announce it
+	AdjustmentID int64     // Unique per change, so two on one day are told apart
+}
+
+// Name returns the event name
+func (PenaltyApplied) Name() string { return PenaltyAppliedEvent }
+
+// ChallengeCompleted is published when a user checks in on the final day of their challenge
+type ChallengeCompleted struct {
+	UserID    string
+	Username  string
+	TotalDays int
+}
+
+// Name returns the event name
+func (ChallengeCompleted) Name() string { return ChallengeCompletedEvent }
+
+// SettingsChanged is published when guild settings change and should be re-applied
+type SettingsChanged struct {
+	GuildID string // Empty when the change was detected by polling and the guild is unknown
+}
+
+// Name returns the event name
+func (SettingsChanged) Name() string { return SettingsChangedEvent }
+
+// WaterLogged is published when a user's water total for today changes
+type WaterLogged struct {
+	UserID       string
+	Username     string
+	ChallengeDay int
+	TotalOunces  float64
+	GoalReached  bool // True only for the change that brought the total up to the goal
+}
+
+// Name returns the event name
+func (WaterLogged) Name() string { return WaterLoggedEvent }
+
+// WeighInRecorded is published when a user logs a weigh-in; imported history isn't published
+type WeighInRecorded struct {
+	UserID       string
+	Username     string
+	ChallengeDay int
+	Pounds       float64
+}
+
+// Name returns the event name
+func (WeighInRecorded) Name() string { return WeighInRecordedEvent }
+
+// BookFinished is published when the pages a user logs reach the end of their book
+type BookFinished struct {
+	UserID       string
+	Username     string
+	BookID       int64
+	Title        string
+	Pages        int
+	ChallengeDay int
+}
+
+// Name returns the event name
+func (BookFinished) Name() string { return BookFinishedEvent }
+
+// Handler reacts to a published event
+type Handler func(event Event)
+
+// Bus delivers published events to subscribers. A nil *Bus is valid and drops
+// every event, so publishers don't need to check whether one was configured.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]Handler
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for events with the given name
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[name] = append(b.subscribers[name], handler)
+}
+
+// Publish delivers event to its subscribers in registration order. Handlers run
+// synchronously on the publisher's goroutine; a panicking handler is logged and
+// does not stop the others or the publisher.
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := b.subscribers[event.Name()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(event, handler)
+	}
+}
+
+// deliver runs one handler, containing any panic
+func deliver(event Event, handler Handler) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Event subscriber for %s panicked: %v", event.Name(), r)
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,104 @@
+// Package expiring provides a map whose entries are forgotten after a time to live,
+// for the bot's in-memory caches and dedupe records.
+package expiring
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often writes scan for expired entries to drop. Expired entries
+// are never returned, so this only bounds memory: an entry is held at most this long
+// past its expiry.
+const sweepInterval = time.Minute
+
+// Map is a map safe for concurrent use whose entries each expire after their own TTL.
+// There's no background goroutine; writes sweep out expired entries at most once per
+// sweep interval, so the cost of a sweep is spread over the writes in between.
+type Map[K comparable, V any] struct {
+	mu        sync.Mutex
+	entries   map[K]entry[V]
+	nextSweep time.Time
+	now       func() time.Time // Replaced in tests
+}
+
+// entry is a value and when it expires
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// New creates an empty map
+func New[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{entries: make(map[K]entry[V]), now: time.Now}
+}
+
+// Get returns the value for key, if it's there and hasn't expired
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok || !m.now().Before(e.expires) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value under key for ttl, replacing any earlier value
+func (m *Map[K, V]) Set(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	m.entries[key] = entry[V]{value: value, expires: now.Add(ttl)}
+	m.sweep(now)
+}
+
+// LoadOrStore returns the unexpired value under key and true. Otherwise it stores value
+// for ttl and returns it and false. Checking and storing happen as one step, so of
+// concurrent callers with the same key only one stores.
+func (m *Map[K, V]) LoadOrStore(key K, value V, ttl time.Duration) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if e, ok := m.entries[key]; ok && now.Before(e.expires) {
+		return e.value, true
+	}
+	m.entries[key] = entry[V]{value: value, expires: now.Add(ttl)}
+	m.sweep(now)
+	return value, false
+}
+
+// Delete removes key
+func (m *Map[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// Clear removes every entry
+func (m *Map[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[K]entry[V])
+}
+
+// Len returns the number of entries held, including expired ones not yet swept
+func (m *Map[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// sweep drops expired entries when a sweep is due; callers hold mu
+func (m *Map[K, V]) sweep(now time.Time) {
+	if now.Before(m.nextSweep) {
+		return
+	}
+	m.nextSweep = now.Add(sweepInterval)
+	for key, e := range m.entries {
+		if !now.Before(e.expires) {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package expiring
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// clock is a settable time source for tests
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestMap returns an empty map on a clock the test controls
+func newTestMap() (*Map[string, int], *clock) {
+	c := &clock{t: time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)}
+	m := New[string, int]()
+	m.now = c.now
+	return m, c
+}
+
+func TestGetSet(t *testing.T) {
+	m, c := newTestMap()
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("Get() on an empty map found a value")
+	}
+
+	m.Set("a", 1, time.Minute)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get() = %d, %v; want 1, true", v, ok)
+	}
+
+	c.advance(59 * time.Second)
+	if _, ok := m.Get("a"); !ok {
+		t.Error("Get() just before expiry found nothing")
+	}
+	c.advance(time.Second)
+	if v, ok := m.Get("a"); ok || v != 0 {
+		t.Errorf("Get() at expiry = %d, %v; want 0, false", v, ok)
+	}
+
+	m.Set("a", 2, time.Minute)
+	if v, ok := m.Get("a"); !ok || v != 2 {
+		t.Errorf("Get() after setting again = %d, %v; want 2, true", v, ok)
+	}
+	m.Delete("a")
+	if _, ok := m.Get("a"); ok {
+		t.Error("Get() after Delete() found a value")
+	}
+}
+
+func TestLoadOrStore(t *testing.T) {
+	m, c := newTestMap()
+	if v, loaded := m.LoadOrStore("a", 1, time.Minute); loaded || v != 1 {
+		t.Errorf("first LoadOrStore() = %d, %v; want 1, false", v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("a", 2, time.Minute); !loaded || v != 1 {
+		t.Errorf("second LoadOrStore() = %d, %v; want 1, true", v, loaded)
+	}
+
+	// The first value's TTL isn't extended by a load
+	c.advance(time.Minute)
+	if v, loaded := m.LoadOrStore("a", 3, time.Minute); loaded || v != 3 {
+		t.Errorf("LoadOrStore() after expiry = %d, %v; want 3, false", v, loaded)
+	}
+}
+
+func TestLoadOrStoreConcurrent(t *testing.T) {
+	m := New[string, int]()
+	var wg sync.WaitGroup
+	stored := make(chan int, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, loaded := m.LoadOrStore("key", i, time.Minute); !loaded {
+				stored <- i
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(stored)
+	if len(stored) != 1 {
+		t.Errorf("%d callers stored a value, want 1", len(stored))
+	}
+}
+
+func TestSweep(t *testing.T) {
+	m, c := newTestMap()
+	for i := 0; i < 100; i++ {
+		m.Set("short"+strconv.Itoa(i), i, time.Second)
+	}
+	m.Set("long", 0, time.Hour)
+	if m.Len() != 101 {
+		t.Fatalf("Len() = %d, want 101", m.Len())
+	}
+
+	// Expired entries stay until the next sweep is due, then go on the next write
+	c.advance(30 * time.Second)
+	m.Set("x", 0, time.Hour)
+	if m.Len() != 102 {
+		t.Errorf("Len() before a sweep is due = %d, want 102", m.Len())
+	}
+	c.advance(30 * time.Second)
+	m.Set("y", 0, time.Hour)
+	if m.Len() != 3 {
+		t.Errorf("Len() after the sweep = %d, want 3", m.Len())
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", m.Len())
+	}
+}
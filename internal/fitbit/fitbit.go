@@ -0,0 +1,187 @@
+// Package fitbit is a small client for the parts of the Fitbit Web API the bot uses:
+// OAuth and the daily water, food, and activity summaries.
+package fitbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	authorizeURL = "https://www.fitbit.com/oauth2/authorize"
+	tokenURL     = "https://api.fitbit.com/oauth2/token"
+	revokeURL    = "https://api.fitbit.com/oauth2/revoke"
+	apiURL       = "https://api.fitbit.com/1/user/-"
+
+	// Scope covers steps (activity) and water and food logs (nutrition)
+	Scope = "activity nutrition"
+)
+
+// Token is an OAuth token for one Fitbit user
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	UserID       string // The Fitbit user ID
+	Scope        string // Granted scopes; users can decline some
+}
+
+// Client calls the Fitbit Web API with the app's client credentials
+type Client struct {
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+}
+
+// NewClient creates a new Fitbit client
+func NewClient(clientID, clientSecret string) *Client {
+	return &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// AuthorizeURL returns the page where a user approves the bot. Fitbit redirects back
+// to redirectURL with state and a code for Exchange.
+func (c *Client) AuthorizeURL(redirectURL, state string) string {
+	query := url.Values{
+		"client_id":     {c.clientID},
+		"response_type": {"code"},
+		"redirect_uri":  {redirectURL},
+		"scope":         {Scope},
+		"state":         {state},
+	}
+	return authorizeURL + "?" + query.Encode()
+}
+
+// tokenResponse is the body of a token exchange or refresh
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // Seconds
+	UserID       string `json:"user_id"`
+	Scope        string `json:"scope"`
+}
+
+// Exchange trades an authorization code for a token. redirectURL must match the
+// one the sign-in started with.
+func (c *Client) Exchange(code, redirectURL string) (Token, error) {
+	return c.token(url.Values{"code": {code}, "grant_type": {"authorization_code"}, "redirect_uri": {redirectURL}})
+}
+
+// Refresh gets a new token. Fitbit refresh tokens are single-use, so the new one
+// must be stored before the next refresh.
+func (c *Client) Refresh(refreshToken string) (Token, error) {
+	return c.token(url.Values{"refresh_token": {refreshToken}, "grant_type": {"refresh_token"}})
+}
+
+// token posts a grant to the token endpoint
+func (c *Client) token(form url.Values) (Token, error) {
+	var body tokenResponse
+	if err := c.do(http.MethodPost, tokenURL, "", form, &body); err != nil {
+		return Token{}, fmt.Errorf("failed to get token: %w", err)
+	}
+	return Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+		UserID:       body.UserID,
+		Scope:        body.Scope,
+	}, nil
+}
+
+// Revoke revokes the bot's access to the user's account
+func (c *Client) Revoke(accessToken string) error {
+	if err := c.do(http.MethodPost, revokeURL, "", url.Values{"token": {accessToken}}, nil); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// WaterOunces returns the water the user logged on date, in US fluid ounces
+func (c *Client) WaterOunces(accessToken string, date time.Time) (float64, error) {
+	var body struct {
+		Summary struct {
+			Water float64 `json:"water"`
+		} `json:"summary"`
+	}
+	if err := c.do(http.MethodGet, apiURL+"/foods/log/water/date/"+date.Format("2006-01-02")+".json", accessToken, nil, &body); err != nil {
+		return 0, fmt.Errorf("failed to get water for %s: %w", date.Format("2006-01-02"), err)
+	}
+	return body.Summary.Water, nil
+}
+
+// Calories returns the calories in the user's food log on date, and whether they
+// logged any food. Apps such as MyFitnessPal can sync their food diary here.
+func (c *Client) Calories(accessToken string, date time.Time) (int, bool, error) {
+	var body struct {
+		Foods   []json.RawMessage `json:"foods"`
+		Summary struct {
+			Calories int `json:"calories"`
+		} `json:"summary"`
+	}
+	if err := c.do(http.MethodGet, apiURL+"/foods/log/date/"+date.Format("2006-01-02")+".json", accessToken, nil, &body); err != nil {
+		return 0, false, fmt.Errorf("failed to get food log for %s: %w", date.Format("2006-01-02"), err)
+	}
+	return body.Summary.Calories, len(body.Foods) > 0, nil
+}
+
+// Steps returns the user's step count on date
+func (c *Client) Steps(accessToken string, date time.Time) (int, error) {
+	var body struct {
+		Summary struct {
+			Steps int `json:"steps"`
+		} `json:"summary"`
+	}
+	if err := c.do(http.MethodGet, apiURL+"/activities/date/"+date.Format("2006-01-02")+".json", accessToken, nil, &body); err != nil {
+		return 0, fmt.Errorf("failed to get steps for %s: %w", date.Format("2006-01-02"), err)
+	}
+	return body.Summary.Steps, nil
+}
+
+// do sends a request and decodes a JSON reply into out. Token requests (no access
+// token) authenticate with the client credentials.
+func (c *Client) do(method, target, accessToken string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	} else {
+		req.SetBasicAuth(c.clientID, c.clientSecret)
+	}
+	// Units follow this header; en_US means fluid ounces for water
+	req.Header.Set("Accept-Language", "en_US")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("fitbit responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
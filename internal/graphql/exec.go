@@ -0,0 +1,166 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// MaxDepth caps how deeply selections nest, so a query can't fan out without bound
+	MaxDepth = 5
+	// MaxFields caps how many fields a query selects in total
+	MaxFields = 200
+)
+
+// Error is one entry of a response's errors; Path names the field that failed
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Response is the JSON body of every GraphQL response. Data is left out when the
+// query couldn't run at all.
+type Response struct {
+	Data   *orderedObject `json:"data,omitempty"`
+	Errors []Error        `json:"errors,omitempty"`
+}
+
+// object is a value of one of the schema's object types
+type object interface {
+	// typeName is the type's name in the schema, for __typename and errors
+	typeName() string
+	// resolve returns the value of field: a scalar, an object, or a slice of either.
+	// Unknown fields return errUnknownField.
+	resolve(field *Field) (interface{}, error)
+}
+
+// errUnknownField is returned by resolve for a field the type doesn't have
+var errUnknownField = fmt.Errorf("unknown field")
+
+// orderedObject is a result object; its keys are written in selection order, as the
+// spec requires
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// execution collects the errors of one query
+type execution struct {
+	errors []Error
+}
+
+// execute runs a parsed query against root, enforcing MaxDepth and MaxFields first.
+// Parse already rejects queries over them; fields built another way are checked here.
+func execute(root object, fields []*Field) Response {
+	if depth := depth(fields); depth > MaxDepth {
+		return Response{Errors: []Error{{Message: fmt.Sprintf("query is nested %d levels deep; the limit is %d", depth, MaxDepth)}}}
+	}
+	if count := count(fields); count > MaxFields {
+		return Response{Errors: []Error{{Message: fmt.Sprintf("query selects %d fields; the limit is %d", count, MaxFields)}}}
+	}
+
+	var e execution
+	data := e.object(root, fields, nil)
+	return Response{Data: data, Errors: e.errors}
+}
+
+// object resolves the selected fields of obj. Fields that fail are null, with an error.
+func (e *execution) object(obj object, fields []*Field, path []interface{}) *orderedObject {
+	result := &orderedObject{values: make(map[string]interface{})}
+	for _, field := range fields {
+		fieldPath := append(append([]interface{}{}, path...), field.Key())
+		if field.Name == "__typename" {
+			result.set(field.Key(), obj.typeName())
+			continue
+		}
+
+		value, err := obj.resolve(field)
+		if err == errUnknownField {
+			err = fmt.Errorf("cannot query field %q on type %s", field.Name, obj.typeName())
+		}
+		if err == nil {
+			value, err = e.complete(value, field, fieldPath)
+		}
+		if err != nil {
+			e.errors = append(e.errors, Error{Message: err.Error(), Path: fieldPath})
+			value = nil
+		}
+		result.set(field.Key(), value)
+	}
+	return result
+}
+
+// complete turns a resolved value into its result, descending into objects and lists
+func (e *execution) complete(value interface{}, field *Field, path []interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case object:
+		if len(field.Selections) == 0 {
+			return nil, fmt.Errorf("field %q of type %s must have a selection of subfields", field.Name, v.typeName())
+		}
+		return e.object(v, field.Selections, path), nil
+	case []object:
+		if len(field.Selections) == 0 {
+			return nil, fmt.Errorf("field %q is a list of objects and must have a selection of subfields", field.Name)
+		}
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			if item == nil {
+				continue
+			}
+			items[i] = e.object(item, field.Selections, append(append([]interface{}{}, path...), i))
+		}
+		return items, nil
+	}
+	if len(field.Selections) > 0 {
+		return nil, fmt.Errorf("field %q is a scalar and can't have a selection of subfields", field.Name)
+	}
+	return value, nil
+}
+
+// depth returns how deeply fields nest; a query with only scalar fields is 1 deep
+func depth(fields []*Field) int {
+	deepest := 0
+	for _, f := range fields {
+		if d := 1 + depth(f.Selections); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
+
+// count returns how many fields are selected, at every level
+func count(fields []*Field) int {
+	n := len(fields)
+	for _, f := range fields {
+		n += count(f.Selections)
+	}
+	return n
+}
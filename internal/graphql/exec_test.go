@@ -0,0 +1,148 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// testType is an object whose fields are given by a map: scalars, objects, lists of
+// objects, or errors
+type testType struct {
+	name   string
+	fields map[string]interface{}
+}
+
+func (o *testType) typeName() string { return o.name }
+
+func (o *testType) resolve(f *Field) (interface{}, error) {
+	value, ok := o.fields[f.Name]
+	if !ok {
+		return nil, errUnknownField
+	}
+	if err, ok := value.(error); ok {
+		return nil, err
+	}
+	return value, nil
+}
+
+// testRoot is a Query with a user, a list of days, and a field that fails
+func testRoot() object {
+	day := func(n int) object { return &testType{name: "Day", fields: map[string]interface{}{"day": n}} }
+	user := &testType{name: "User", fields: map[string]interface{}{
+		"id":       "1",
+		"username": "jess",
+		"days":     []object{day(1), nil, day(3)},
+	}}
+	return &testType{name: "Query", fields: map[string]interface{}{
+		"user":   user,
+		"broken": errors.New("summary service not available"),
+	}}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "keys follow the selection order",
+			query: `{ user { username id who: __typename } }`,
+			want:  `{"data":{"user":{"username":"jess","id":"1","who":"User"}}}`,
+		},
+		{
+			name:  "lists of objects",
+			query: `{ user { days { day } } }`,
+			want:  `{"data":{"user":{"days":[{"day":1},null,{"day":3}]}}}`,
+		},
+		{
+			name:  "failed fields are null with their path",
+			query: `{ broken user { id } }`,
+			want:  `{"data":{"broken":null,"user":{"id":"1"}},"errors":[{"message":"summary service not available","path":["broken"]}]}`,
+		},
+		{
+			name:  "unknown field",
+			query: `{ user { email } }`,
+			want:  `{"data":{"user":{"email":null}},"errors":[{"message":"cannot query field \"email\" on type User","path":["user","email"]}]}`,
+		},
+		{
+			name:  "object without a selection",
+			query: `{ user }`,
+			want:  `{"data":{"user":null},"errors":[{"message":"field \"user\" of type User must have a selection of subfields","path":["user"]}]}`,
+		},
+		{
+			name:  "list without a selection",
+			query: `{ user { days } }`,
+			want:  `{"data":{"user":{"days":null}},"errors":[{"message":"field \"days\" is a list of objects and must have a selection of subfields","path":["user","days"]}]}`,
+		},
+		{
+			name:  "scalar with a selection",
+			query: `{ user { id { value } } }`,
+			want:  `{"data":{"user":{"id":null}},"errors":[{"message":"field \"id\" is a scalar and can't have a selection of subfields","path":["user","id"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := Parse(tt.query, "", nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := json.Marshal(execute(testRoot(), fields))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("execute() = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// chain returns fields nested levels deep
+func chain(levels int) []*Field {
+	field := &Field{Name: "a"}
+	for i := 1; i < levels; i++ {
+		field = &Field{Name: "a", Selections: []*Field{field}}
+	}
+	return []*Field{field}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	many := make([]*Field, MaxFields+1)
+	for i := range many {
+		many[i] = &Field{Name: "a"}
+	}
+
+	tests := []struct {
+		name   string
+		fields []*Field
+		want   string
+	}{
+		{"over the depth limit", chain(MaxDepth + 1), "query is nested 6 levels deep; the limit is 5"},
+		{"over the field limit", many, "query selects 201 fields; the limit is 200"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := execute(testRoot(), tt.fields)
+			if response.Data != nil {
+				t.Errorf("execute() ran the query, want it rejected")
+			}
+			if len(response.Errors) != 1 || response.Errors[0].Message != tt.want {
+				t.Errorf("execute() errors = %+v, want %q", response.Errors, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteParseErrorHasNoData(t *testing.T) {
+	response := New("en", nil).Execute(nested(MaxDepth+1), "", nil)
+	got, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"errors":[{"message":"syntax error at 1:21: query is nested more than 5 levels deep"}]}`
+	if string(got) != want {
+		t.Errorf("Execute() = %s, want %s", got, want)
+	}
+}
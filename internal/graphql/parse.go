@@ -0,0 +1,561 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Field is one selected field of a query, with variables already substituted into
+// its arguments. Argument values are nil, bool, string (also enum values), int,
+// float64, []interface{}, or map[string]interface{}.
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]interface{}
+	Selections []*Field
+}
+
+// Key is the field's name in the response: its alias, or its name without one
+func (f *Field) Key() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// token kinds
+const (
+	tokenEOF = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  int
+	value string
+	pos   int
+}
+
+// parser reads one query document. Only the parts of the language a read-only API
+// needs are supported: query operations, variables, aliases, arguments, and nested
+// selections. Fragments and directives are rejected.
+//
+// MaxDepth and MaxFields are enforced while parsing, so an oversized query is
+// rejected before it's fully read rather than after it's been built in memory.
+// Argument values nest at most MaxDepth deep too.
+type parser struct {
+	src       string
+	pos       int
+	tok       token
+	variables map[string]interface{}
+	defined   map[string]bool
+	depth     int // Selection sets open around the current token
+	values    int // Lists and objects open around the current value
+	fields    int // Fields selected so far in the current operation
+}
+
+// Parse parses query and returns the top-level fields of the operation named
+// operationName, or of its only operation when operationName is empty. variables
+// are the request's variable values, usually decoded from JSON.
+func Parse(query, operationName string, variables map[string]interface{}) ([]*Field, error) {
+	p := &parser{src: query}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	// Operations are parsed once to find the one to run, then again with its variables
+	type operation struct {
+		name  string
+		start int
+	}
+	var operations []operation
+	for p.tok.kind != tokenEOF {
+		start := p.tok.pos
+		name, _, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation{name, start})
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("document has no operations")
+	}
+
+	chosen := -1
+	for i, op := range operations {
+		if op.name == operationName || (operationName == "" && len(operations) == 1) {
+			chosen = i
+		}
+	}
+	if chosen < 0 {
+		if operationName == "" {
+			return nil, fmt.Errorf("document has several operations; set operationName")
+		}
+		return nil, fmt.Errorf("unknown operation %q", operationName)
+	}
+
+	p = &parser{src: query, pos: operations[chosen].start, variables: variables, defined: make(map[string]bool)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	_, fields, err := p.operation()
+	return fields, err
+}
+
+// operation parses an operation definition and returns its name and selections.
+// Until the parser has a variables map, variables are neither checked nor filled in.
+func (p *parser) operation() (string, []*Field, error) {
+	p.fields = 0
+	name := ""
+	if p.tok.kind == tokenName {
+		switch p.tok.value {
+		case "query":
+		case "mutation", "subscription":
+			return "", nil, p.errorf("%ss are not supported; the API is read-only", p.tok.value)
+		case "fragment":
+			return "", nil, p.errorf("fragments are not supported")
+		default:
+			return "", nil, p.errorf("expected an operation, found %q", p.tok.value)
+		}
+		if err := p.next(); err != nil {
+			return "", nil, err
+		}
+		if p.tok.kind == tokenName {
+			name = p.tok.value
+			if err := p.next(); err != nil {
+				return "", nil, err
+			}
+		}
+		if p.is("(") {
+			if err := p.variableDefinitions(); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	if p.is("@") {
+		return "", nil, p.errorf("directives are not supported")
+	}
+	fields, err := p.selectionSet()
+	return name, fields, err
+}
+
+// variableDefinitions parses ($name: Type = default, ...), recording which variables
+// exist and filling in defaults for ones the request left out
+func (p *parser) variableDefinitions() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		required, err := p.typeRef()
+		if err != nil {
+			return err
+		}
+
+		var value interface{}
+		hasDefault := false
+		if p.is("=") {
+			if err := p.next(); err != nil {
+				return err
+			}
+			if value, err = p.value(true); err != nil {
+				return err
+			}
+			hasDefault = true
+		}
+		if p.defined != nil {
+			p.defined[name] = true
+			if given, ok := p.variables[name]; ok {
+				value = given
+			} else if required && !hasDefault {
+				return fmt.Errorf("variable $%s is required", name)
+			}
+			if p.variables == nil {
+				p.variables = make(map[string]interface{})
+			}
+			p.variables[name] = normalize(value)
+		}
+	}
+	return p.next()
+}
+
+// typeRef parses a variable's type and reports whether it's non-null. Types are
+// otherwise only checked by the fields that use the variable.
+func (p *parser) typeRef() (bool, error) {
+	if p.is("[") {
+		if err := p.next(); err != nil {
+			return false, err
+		}
+		if _, err := p.typeRef(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.name(); err != nil {
+		return false, err
+	}
+	if p.is("!") {
+		return true, p.next()
+	}
+	return false, nil
+}
+
+// selectionSet parses { field ... }
+func (p *parser) selectionSet() ([]*Field, error) {
+	if p.depth == MaxDepth {
+		return nil, p.errorf("query is nested more than %d levels deep", MaxDepth)
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var fields []*Field
+	for !p.is("}") {
+		if p.is("...") {
+			return nil, p.errorf("fragments are not supported")
+		}
+		if p.fields == MaxFields {
+			return nil, p.errorf("query selects more than %d fields", MaxFields)
+		}
+		p.fields++
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf("selection set is empty")
+	}
+	return fields, p.next()
+}
+
+// field parses alias: name(arguments) { selections }
+func (p *parser) field() (*Field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Name: name}
+	if p.is(":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		field.Alias = name
+		if field.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.is("(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		field.Arguments = make(map[string]interface{})
+		for !p.is(")") {
+			arg, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if field.Arguments[arg], err = p.value(false); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("@") {
+		return nil, p.errorf("directives are not supported")
+	}
+	if p.is("{") {
+		if field.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+// value parses an argument or default value. Variables aren't allowed in defaults.
+func (p *parser) value(constant bool) (interface{}, error) {
+	tok := p.tok
+	if tok.kind == tokenPunctuator && (tok.value == "[" || tok.value == "{") {
+		if p.values == MaxDepth {
+			return nil, p.errorf("value is nested more than %d levels deep", MaxDepth)
+		}
+		p.values++
+		defer func() { p.values-- }()
+	}
+	switch {
+	case tok.kind == tokenPunctuator && tok.value == "$" && !constant:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if p.defined != nil && !p.defined[name] {
+			return nil, fmt.Errorf("variable $%s is not defined by the operation", name)
+		}
+		return p.variables[name], nil
+
+	case tok.kind == tokenPunctuator && tok.value == "[":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		list := []interface{}{}
+		for !p.is("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, p.next()
+
+	case tok.kind == tokenPunctuator && tok.value == "{":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{})
+		for !p.is("}") {
+			key, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[key], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return object, p.next()
+
+	case tok.kind == tokenInt:
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, p.errorf("integer %s is out of range", tok.value)
+		}
+		return n, p.next()
+
+	case tok.kind == tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", tok.value)
+		}
+		return f, p.next()
+
+	case tok.kind == tokenString:
+		return tok.value, p.next()
+
+	case tok.kind == tokenName:
+		var v interface{} = tok.value
+		switch tok.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		}
+		return v, p.next()
+	}
+	return nil, p.errorf("expected a value, found %q", tok.value)
+}
+
+// normalize turns whole JSON numbers from variables into ints, as literals are
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalize(v[k])
+		}
+	}
+	return v
+}
+
+func (p *parser) is(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.is(punctuator) {
+		return p.errorf("expected %q, found %q", punctuator, p.describe())
+	}
+	return p.next()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.errorf("expected a name, found %q", p.describe())
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) describe() string {
+	if p.tok.kind == tokenEOF {
+		return "end of query"
+	}
+	return p.tok.value
+}
+
+// errorf reports a syntax error at the current token's line and column
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := 1 + strings.Count(p.src[:p.tok.pos], "\n")
+	column := 1 + utf8.RuneCountInString(p.src[strings.LastIndex(p.src[:p.tok.pos], "\n")+1:p.tok.pos])
+	return fmt.Errorf("syntax error at %d:%d: %s", line, column, fmt.Sprintf(format, args...))
+}
+
+// next reads the next token, skipping whitespace, commas, and comments
+func (p *parser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.tok = token{pos: p.pos}
+	if p.pos >= len(p.src) {
+		p.tok.kind = tokenEOF
+		return nil
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok.kind, p.tok.value = tokenPunctuator, "..."
+
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		p.pos++
+		p.tok.kind, p.tok.value = tokenPunctuator, string(c)
+
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind, p.tok.value = tokenName, p.src[start:p.pos]
+
+	case c == '-' || isDigit(c):
+		p.pos++
+		p.tok.kind = tokenInt
+		for p.pos < len(p.src) {
+			c := p.src[p.pos]
+			if c == '.' || c == 'e' || c == 'E' {
+				p.tok.kind = tokenFloat
+			} else if !isDigit(c) && !((c == '+' || c == '-') && (p.src[p.pos-1] == 'e' || p.src[p.pos-1] == 'E')) {
+				break
+			}
+			p.pos++
+		}
+		p.tok.value = p.src[start:p.pos]
+
+	case c == '"':
+		if strings.HasPrefix(p.src[p.pos:], `"""`) {
+			return p.errorf("block strings are not supported")
+		}
+		value, err := p.string()
+		if err != nil {
+			return err
+		}
+		p.tok.kind, p.tok.value = tokenString, value
+
+	default:
+		return p.errorf("unexpected character %q", c)
+	}
+	return nil
+}
+
+// string reads a quoted string, unescaping it
+func (p *parser) string() (string, error) {
+	var b strings.Builder
+	i := p.pos + 1
+	for i < len(p.src) && p.src[i] != '"' && p.src[i] != '\n' {
+		if p.src[i] != '\\' {
+			b.WriteByte(p.src[i])
+			i++
+			continue
+		}
+		if i+1 >= len(p.src) {
+			break
+		}
+		switch e := p.src[i+1]; e {
+		case '"', '\\', '/':
+			b.WriteByte(e)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+6 > len(p.src) {
+				return "", p.errorf("invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(p.src[i+2:i+6], 16, 16)
+			if err != nil {
+				return "", p.errorf("invalid unicode escape \\u%s", p.src[i+2:i+6])
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			return "", p.errorf("invalid escape \\%c", e)
+		}
+		i += 2
+	}
+	if i >= len(p.src) || p.src[i] != '"' {
+		return "", p.errorf("unterminated string")
+	}
+	p.pos = i + 1
+	return b.String(), nil
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,189 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          []*Field
+	}{
+		{
+			name:  "shorthand",
+			query: `{ user(id: "1") { username } }`,
+			want: []*Field{{Name: "user", Arguments: map[string]interface{}{"id": "1"},
+				Selections: []*Field{{Name: "username"}}}},
+		},
+		{
+			name:  "aliases, commas, and comments",
+			query: "query {\n  # who\n  a: user(id: \"1\") { id, name: username }\n  b: user(id: \"2\") { id }\n}",
+			want: []*Field{
+				{Alias: "a", Name: "user", Arguments: map[string]interface{}{"id": "1"},
+					Selections: []*Field{{Name: "id"}, {Alias: "name", Name: "username"}}},
+				{Alias: "b", Name: "user", Arguments: map[string]interface{}{"id": "2"},
+					Selections: []*Field{{Name: "id"}}},
+			},
+		},
+		{
+			name:  "argument values",
+			query: `{ f(i: -3, f: 1.5e2, s: "a\"b\\cé\n", t: true, n: null, e: WEEK, l: [1, [2]], o: {k: "v"}) }`,
+			want: []*Field{{Name: "f", Arguments: map[string]interface{}{
+				"i": -3, "f": 150.0, "s": "a\"b\\cé\n", "t": true, "n": nil, "e": "WEEK",
+				"l": []interface{}{1, []interface{}{2}}, "o": map[string]interface{}{"k": "v"},
+			}}},
+		},
+		{
+			name:      "variables, with JSON numbers made ints",
+			query:     `query Days($id: ID!, $last: Int) { user(id: $id) { days(last: $last) { day } } }`,
+			variables: map[string]interface{}{"id": "1", "last": float64(7)},
+			want: []*Field{{Name: "user", Arguments: map[string]interface{}{"id": "1"},
+				Selections: []*Field{{Name: "days", Arguments: map[string]interface{}{"last": 7},
+					Selections: []*Field{{Name: "day"}}}}}},
+		},
+		{
+			name:  "variable defaults",
+			query: `query ($limit: Int = 5, $ids: [ID!]) { leaderboard(limit: $limit) { rank } users(ids: $ids) { id } }`,
+			want: []*Field{
+				{Name: "leaderboard", Arguments: map[string]interface{}{"limit": 5}, Selections: []*Field{{Name: "rank"}}},
+				{Name: "users", Arguments: map[string]interface{}{"ids": nil}, Selections: []*Field{{Name: "id"}}},
+			},
+		},
+		{
+			name:          "named operation",
+			query:         `query A { a } query B { b }`,
+			operationName: "B",
+			want:          []*Field{{Name: "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query, tt.operationName, tt.variables)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %s, want %s", describe(got), describe(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          string
+	}{
+		{"empty document", "  # nothing\n", "", nil, "no operations"},
+		{"mutation", `mutation { checkIn }`, "", nil, "mutations are not supported"},
+		{"subscription", `subscription { days }`, "", nil, "subscriptions are not supported"},
+		{"fragment definition", `fragment F on User { id }`, "", nil, "fragments are not supported"},
+		{"fragment spread", `{ user(id: "1") { ...F } }`, "", nil, "fragments are not supported"},
+		{"directive", `{ user(id: "1") @include(if: true) { id } }`, "", nil, "directives are not supported"},
+		{"unknown keyword", `select { id }`, "", nil, `expected an operation, found "select"`},
+		{"several operations without a name", `query A { a } query B { b }`, "", nil, "set operationName"},
+		{"unknown operation", `query A { a }`, "B", nil, `unknown operation "B"`},
+		{"empty selection", `{ user(id: "1") { } }`, "", nil, "selection set is empty"},
+		{"unclosed selection", `{ user(id: "1") { id }`, "", nil, "end of query"},
+		{"unclosed arguments", `{ user(id: "1" { id } }`, "", nil, "expected a name"},
+		{"missing colon", `{ user(id "1") { id } }`, "", nil, `expected ":"`},
+		{"missing value", `{ user(id: ) { id } }`, "", nil, "expected a value"},
+		{"unterminated string", `{ user(id: "1) { id } }`, "", nil, "unterminated string"},
+		{"string across lines", "{ user(id: \"1\n\") { id } }", "", nil, "unterminated string"},
+		{"block string", `{ user(id: """1""") { id } }`, "", nil, "block strings are not supported"},
+		{"bad escape", `{ user(id: "\q") { id } }`, "", nil, `invalid escape \q`},
+		{"bad unicode escape", `{ user(id: "\u12G4") { id } }`, "", nil, "invalid unicode escape"},
+		{"short unicode escape", `{ user(id: "\u12`, "", nil, "invalid unicode escape"},
+		{"unexpected character", `{ user(id: 1) { id; } }`, "", nil, `unexpected character ';'`},
+		{"integer out of range", `{ f(n: 99999999999999999999) }`, "", nil, "out of range"},
+		{"invalid number", `{ f(n: 1e) }`, "", nil, "invalid number"},
+		{"undefined variable", `query { user(id: $id) { id } }`, "", nil, "$id is not defined"},
+		{"missing required variable", `query ($id: ID!) { user(id: $id) { id } }`, "", nil, "$id is required"},
+		{"variable in a default", `query ($a: Int = $b) { f(a: $a) }`, "", nil, "expected a value"},
+		{"unclosed list type", `query ($ids: [ID!) { users(ids: $ids) { id } }`, "", nil, `expected "]"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query, tt.operationName, tt.variables)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := Parse("{\n  user(id: \"1\") {\n    id;\n  }\n}", "", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "syntax error at 3:7:") {
+		t.Errorf("Parse() error = %v, want it at 3:7", err)
+	}
+}
+
+// nested returns a query whose selections nest levels deep
+func nested(levels int) string {
+	return strings.Repeat("{ a ", levels-1) + "{ a" + strings.Repeat(" }", levels)
+}
+
+// wide returns a query selecting n fields in total
+func wide(n int) string {
+	return "{ " + strings.Repeat("a ", n) + "}"
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string // Empty when the query is within the limits
+	}{
+		{"at the depth limit", nested(MaxDepth), ""},
+		{"over the depth limit", nested(MaxDepth + 1), "nested more than 5 levels deep"},
+		{"far over the depth limit", strings.Repeat("{ a ", 100000), "nested more than 5 levels deep"},
+		{"at the field limit", wide(MaxFields), ""},
+		{"over the field limit", wide(MaxFields + 1), "selects more than 200 fields"},
+		{"over the field limit when nested", "{ a { " + strings.Repeat("b ", MaxFields) + "} }", "selects more than 200 fields"},
+		{"field limit is per operation", "query A " + wide(MaxFields) + " query B " + wide(MaxFields), ""},
+		{"at the value depth limit", "{ f(l: " + strings.Repeat("[", MaxDepth) + strings.Repeat("]", MaxDepth) + ") }", ""},
+		{"over the value depth limit", "{ f(l: " + strings.Repeat("[", MaxDepth+1) + strings.Repeat("]", MaxDepth+1) + ") }", "value is nested"},
+		{"far over the value depth limit", "{ f(o: " + strings.Repeat("{k: ", 100000), "value is nested"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operationName := ""
+			if strings.HasPrefix(tt.query, "query A") {
+				operationName = "B"
+			}
+			_, err := Parse(tt.query, operationName, nil)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Parse() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// describe renders fields for failure messages
+func describe(fields []*Field) string {
+	var parts []string
+	for _, f := range fields {
+		part := f.Alias + ":" + f.Name + fmt.Sprint(f.Arguments)
+		if len(f.Selections) > 0 {
+			part += "{" + describe(f.Selections) + "}"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
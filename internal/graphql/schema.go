@@ -0,0 +1,575 @@
+// Package graphql serves read-only progress queries in GraphQL, so power users can
+// ask for e.g. water by day for the last three weeks for two members without a new
+// REST endpoint each time. It implements the part of the language such queries
+// need and resolves fields with the same services as the bot.
+package graphql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// maxUsers caps how many members one users query can name
+const maxUsers = 10
+
+// SDL is the schema the API serves, in the GraphQL schema language
+const SDL = `# Dates are YYYY-MM-DD on the member's challenge calendar. Weights and water
+# amounts are in the member's preferred units.
+type Query {
+  user(id: ID!): User
+  # Members in the order given; unknown IDs are null. At most 10.
+  users(ids: [ID!]!): [User]!
+  # The /leaderboard standings, with members named as the bot names them
+  leaderboard(limit: Int = 10): [LeaderboardEntry!]!
+}
+
+type User {
+  id: ID!
+  username: String!
+  startDate: String!
+  endDate: String!
+  date: String!            # Today in the member's time zone
+  challengeDay: Int!
+  totalDays: Int!
+  daysCompleted: Int!
+  currentStreak: Int!      # Consecutive check-ins through today, or yesterday
+  longestStreak: Int!
+  weightUnit: String!      # lbs or kg
+  volumeUnit: String!      # oz or l
+  # Challenge days from the first day (or from) through today (or to), or the
+  # last n of them. Days after today aren't returned.
+  days(from: String, to: String, last: Int): [Day!]!
+  # The latest weigh-ins, oldest first
+  weighIns(last: Int = 30): [WeighIn!]!
+}
+
+type Day {
+  day: Int!
+  date: String!
+  checkIn: Boolean!
+  exercise: Boolean!
+  diet: Boolean!
+  water: Boolean!
+  reading: Boolean!
+  finances: Boolean!
+  waterAmount: Float!
+}
+
+type WeighIn {
+  date: String!
+  weight: Float!
+}
+
+type LeaderboardEntry {
+  rank: Int!
+  name: String!
+  daysCompleted: Int!
+  exerciseDays: Int!
+  waterGoalDays: Int!
+  weighIns: Int!
+}
+`
+
+// Schema runs queries against the services in a registry
+type Schema struct {
+	registry *services.ServiceRegistry
+	locale   string
+}
+
+// New creates a schema over the services in registry. Pseudonyms in the leaderboard
+// are written in locale.
+func New(locale string, registry *services.ServiceRegistry) *Schema {
+	return &Schema{registry: registry, locale: locale}
+}
+
+// Execute parses and runs a query. Problems with the query itself come back as a
+// response without data; fields that fail are null, with an error naming them.
+func (s *Schema) Execute(query, operationName string, variables map[string]interface{}) Response {
+	fields, err := Parse(query, operationName, variables)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	return execute(&queryType{services: lookupServices(s.registry), locale: s.locale}, fields)
+}
+
+// resolverServices are the services fields resolve with; nil when not registered
+type resolverServices struct {
+	users       *services.UserService
+	preferences *services.PreferencesService
+	summaries   *services.SummaryService
+	leaderboard *services.LeaderboardService
+}
+
+// lookupServices finds the services fields resolve with in the registry
+func lookupServices(registry *services.ServiceRegistry) resolverServices {
+	var found resolverServices
+	for _, svc := range registry.GetServices() {
+		switch s := svc.(type) {
+		case *services.UserService:
+			found.users = s
+		case *services.PreferencesService:
+			found.preferences = s
+		case *services.SummaryService:
+			found.summaries = s
+		case *services.LeaderboardService:
+			found.leaderboard = s
+		}
+	}
+	return found
+}
+
+// queryType is the Query root
+type queryType struct {
+	services resolverServices
+	locale   string
+}
+
+func (q *queryType) typeName() string { return "Query" }
+
+func (q *queryType) resolve(f *Field) (interface{}, error) {
+	switch f.Name {
+	case "user":
+		if err := checkArguments(f, "id"); err != nil {
+			return nil, err
+		}
+		id, err := stringArgument(f, "id", true)
+		if err != nil {
+			return nil, err
+		}
+		return q.user(id)
+
+	case "users":
+		if err := checkArguments(f, "ids"); err != nil {
+			return nil, err
+		}
+		ids, err := stringListArgument(f, "ids")
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > maxUsers {
+			return nil, fmt.Errorf("users takes at most %d IDs", maxUsers)
+		}
+		users := make([]object, len(ids))
+		for i, id := range ids {
+			user, err := q.user(id)
+			if err != nil {
+				return nil, err
+			}
+			if user != nil {
+				users[i] = user
+			}
+		}
+		return users, nil
+
+	case "leaderboard":
+		if err := checkArguments(f, "limit"); err != nil {
+			return nil, err
+		}
+		limit, err := intArgument(f, "limit", 10)
+		if err != nil {
+			return nil, err
+		}
+		if limit < 1 || limit > 100 {
+			return nil, fmt.Errorf("limit must be between 1 and 100")
+		}
+		return q.leaderboardEntries(limit)
+	}
+	return nil, errUnknownField
+}
+
+// user loads a member, or returns nil for one who hasn't used the bot
+func (q *queryType) user(id string) (object, error) {
+	if q.services.users == nil {
+		return nil, fmt.Errorf("user service not available")
+	}
+	progress, err := q.services.users.GetProgress(id)
+	if err == services.ErrUserNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", id, err)
+	}
+	return &userType{services: q.services, progress: progress}, nil
+}
+
+// leaderboardEntries loads the standings
+func (q *queryType) leaderboardEntries(limit int) ([]object, error) {
+	if q.services.leaderboard == nil {
+		return nil, fmt.Errorf("leaderboard service not available")
+	}
+	entries, err := q.services.leaderboard.GetLeaderboard(limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load leaderboard: %w", err)
+	}
+	rows := make([]object, len(entries))
+	for i, e := range entries {
+		rows[i] = &leaderboardEntryType{rank: i + 1, entry: e, locale: q.locale}
+	}
+	return rows, nil
+}
+
+// userType is a User; preferences and streaks are loaded on first use
+type userType struct {
+	services resolverServices
+	progress services.Progress
+	prefs    *services.Preferences
+	streaks  *[2]int
+}
+
+func (u *userType) typeName() string { return "User" }
+
+func (u *userType) resolve(f *Field) (interface{}, error) {
+	if f.Name != "days" && f.Name != "weighIns" {
+		if err := checkArguments(f); err != nil {
+			return nil, err
+		}
+	}
+	p := u.progress
+	switch f.Name {
+	case "id":
+		return p.UserID, nil
+	case "username":
+		return p.Username, nil
+	case "startDate":
+		return p.StartDate.Format("2006-01-02"), nil
+	case "endDate":
+		return p.EndDate.Format("2006-01-02"), nil
+	case "date":
+		return p.Date.Format("2006-01-02"), nil
+	case "challengeDay":
+		return p.ChallengeDay, nil
+	case "totalDays":
+		return p.TotalDays, nil
+	case "daysCompleted":
+		return p.DaysCompleted, nil
+	case "currentStreak", "longestStreak":
+		streaks, err := u.loadStreaks()
+		if err != nil {
+			return nil, err
+		}
+		if f.Name == "currentStreak" {
+			return streaks[0], nil
+		}
+		return streaks[1], nil
+	case "weightUnit", "volumeUnit":
+		prefs, err := u.loadPreferences()
+		if err != nil {
+			return nil, err
+		}
+		if f.Name == "weightUnit" {
+			return prefs.Units.Weight, nil
+		}
+		return prefs.Units.Volume, nil
+	case "days":
+		return u.days(f)
+	case "weighIns":
+		return u.weighIns(f)
+	}
+	return nil, errUnknownField
+}
+
+func (u *userType) loadPreferences() (services.Preferences, error) {
+	if u.prefs == nil {
+		if u.services.preferences == nil {
+			return services.Preferences{}, fmt.Errorf("preferences service not available")
+		}
+		prefs, err := u.services.preferences.Get(u.progress.UserID)
+		if err != nil {
+			return services.Preferences{}, fmt.Errorf("failed to load preferences: %w", err)
+		}
+		u.prefs = &prefs
+	}
+	return *u.prefs, nil
+}
+
+func (u *userType) loadStreaks() ([2]int, error) {
+	if u.streaks == nil {
+		if u.services.summaries == nil {
+			return [2]int{}, fmt.Errorf("summary service not available")
+		}
+		current, longest, err := u.services.summaries.GetStreaks(u.progress.UserID, u.progress.ChallengeDay)
+		if err != nil {
+			return [2]int{}, err
+		}
+		u.streaks = &[2]int{current, longest}
+	}
+	return *u.streaks, nil
+}
+
+// days resolves days(from, to, last) to the challenge days it covers
+func (u *userType) days(f *Field) (interface{}, error) {
+	if err := checkArguments(f, "from", "to", "last"); err != nil {
+		return nil, err
+	}
+	if u.services.summaries == nil {
+		return nil, fmt.Errorf("summary service not available")
+	}
+
+	// Days after today have nothing logged yet
+	lastDay := u.progress.ChallengeDay
+	if lastDay > u.progress.TotalDays {
+		lastDay = u.progress.TotalDays
+	}
+	firstDay := 1
+
+	last, err := intArgument(f, "last", 0)
+	if err != nil {
+		return nil, err
+	}
+	from, err := u.dayArgument(f, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.dayArgument(f, "to")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case last != 0 && (from != 0 || to != 0):
+		return nil, fmt.Errorf("days takes either last or from and to, not both")
+	case last < 0:
+		return nil, fmt.Errorf("last can't be negative")
+	case last > 0:
+		firstDay = lastDay - last + 1
+	default:
+		if from != 0 {
+			firstDay = from
+		}
+		if to != 0 && to < lastDay {
+			lastDay = to
+		}
+	}
+	if firstDay < 1 {
+		firstDay = 1
+	}
+	if firstDay > lastDay {
+		return []object{}, nil
+	}
+
+	prefs, err := u.loadPreferences()
+	if err != nil {
+		return nil, err
+	}
+	completions, err := u.services.summaries.GetCompletions(u.progress.UserID, u.progress.StartDate, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	ounces, err := u.services.summaries.GetWaterOunces(u.progress.UserID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	days := make([]object, len(completions))
+	for i, c := range completions {
+		days[i] = &dayType{completion: c, water: prefs.Units.FromOunces(ounces[c.Day])}
+	}
+	return days, nil
+}
+
+// dayArgument reads a date argument as a challenge day, or 0 when it isn't given
+func (u *userType) dayArgument(f *Field, name string) (int, error) {
+	value, err := stringArgument(f, name, false)
+	if err != nil || value == "" {
+		return 0, err
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a date like 2024-01-31", name)
+	}
+	day := services.ChallengeDayForDate(u.progress.StartDate, date)
+	if day < 1 {
+		// Before the challenge started: from covers everything, to nothing
+		if name == "to" {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	return day, nil
+}
+
+// weighIns resolves weighIns(last)
+func (u *userType) weighIns(f *Field) (interface{}, error) {
+	if err := checkArguments(f, "last"); err != nil {
+		return nil, err
+	}
+	if u.services.summaries == nil {
+		return nil, fmt.Errorf("summary service not available")
+	}
+	last, err := intArgument(f, "last", 30)
+	if err != nil {
+		return nil, err
+	}
+	if last < 1 || last > 366 {
+		return nil, fmt.Errorf("last must be between 1 and 366")
+	}
+
+	prefs, err := u.loadPreferences()
+	if err != nil {
+		return nil, err
+	}
+	weighIns, err := u.services.summaries.GetWeighIns(u.progress.UserID, last, prefs)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]object, len(weighIns))
+	for i, w := range weighIns {
+		objects[i] = &weighInType{weighIn: w}
+	}
+	return objects, nil
+}
+
+// dayType is a Day
+type dayType struct {
+	completion services.DayCompletion
+	water      float64 // In the member's units
+}
+
+func (d *dayType) typeName() string { return "Day" }
+
+func (d *dayType) resolve(f *Field) (interface{}, error) {
+	if err := checkArguments(f); err != nil {
+		return nil, err
+	}
+	switch f.Name {
+	case "day":
+		return d.completion.Day, nil
+	case "date":
+		return d.completion.Date.Format("2006-01-02"), nil
+	case "waterAmount":
+		return d.water, nil
+	}
+	for i, feat := range services.CompletionFeats {
+		if dayFeatFields[f.Name] == feat {
+			return d.completion.Done[i], nil
+		}
+	}
+	return nil, errUnknownField
+}
+
+// dayFeatFields maps Day's feat fields to the CompletionFeats they report
+var dayFeatFields = map[string]string{
+	"checkIn":  "checkin",
+	"exercise": "exercise",
+	"diet":     "diet",
+	"water":    "water",
+	"reading":  "reading",
+	"finances": "finances",
+}
+
+// weighInType is a WeighIn
+type weighInType struct {
+	weighIn services.WeighIn
+}
+
+func (w *weighInType) typeName() string { return "WeighIn" }
+
+func (w *weighInType) resolve(f *Field) (interface{}, error) {
+	if err := checkArguments(f); err != nil {
+		return nil, err
+	}
+	switch f.Name {
+	case "date":
+		return w.weighIn.Date.Format("2006-01-02"), nil
+	case "weight":
+		return w.weighIn.Weight, nil
+	}
+	return nil, errUnknownField
+}
+
+// leaderboardEntryType is a LeaderboardEntry
+type leaderboardEntryType struct {
+	rank   int
+	entry  services.LeaderboardEntry
+	locale string
+}
+
+func (e *leaderboardEntryType) typeName() string { return "LeaderboardEntry" }
+
+func (e *leaderboardEntryType) resolve(f *Field) (interface{}, error) {
+	if err := checkArguments(f); err != nil {
+		return nil, err
+	}
+	switch f.Name {
+	case "rank":
+		return e.rank, nil
+	case "name":
+		return services.PublicName(e.locale, e.entry.Privacy, e.entry.UserID, e.entry.Username), nil
+	case "daysCompleted":
+		return e.entry.DaysCompleted, nil
+	case "exerciseDays":
+		return e.entry.ExerciseDays, nil
+	case "waterGoalDays":
+		return e.entry.WaterGoalDays, nil
+	case "weighIns":
+		return e.entry.WeighInCount, nil
+	}
+	return nil, errUnknownField
+}
+
+// checkArguments rejects arguments the field doesn't take
+func checkArguments(f *Field, allowed ...string) error {
+	for name := range f.Arguments {
+		known := false
+		for _, a := range allowed {
+			known = known || a == name
+		}
+		if !known {
+			return fmt.Errorf("unknown argument %q on field %q", name, f.Name)
+		}
+	}
+	return nil
+}
+
+// stringArgument reads a String or ID argument
+func stringArgument(f *Field, name string, required bool) (string, error) {
+	value, ok := f.Arguments[name]
+	if !ok || value == nil {
+		if required {
+			return "", fmt.Errorf("argument %q is required", name)
+		}
+		return "", nil
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int:
+		// IDs may be written as numbers
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("argument %q must be a string", name)
+}
+
+// stringListArgument reads a required [ID!] argument
+func stringListArgument(f *Field, name string) ([]string, error) {
+	list, ok := f.Arguments[name].([]interface{})
+	if !ok {
+		// A single value is accepted as a list of one, as the spec allows
+		if s, err := stringArgument(f, name, true); err == nil {
+			return []string{s}, nil
+		}
+		return nil, fmt.Errorf("argument %q must be a list of IDs", name)
+	}
+	values := make([]string, len(list))
+	for i, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %q must be a list of IDs", name)
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+// intArgument reads an Int argument, or fallback when it isn't given
+func intArgument(f *Field, name string, fallback int) (int, error) {
+	value, ok := f.Arguments[name]
+	if !ok || value == nil {
+		return fallback, nil
+	}
+	n, ok := value.(int)
+	if !ok {
+		return 0, fmt.Errorf("argument %q must be an integer", name)
+	}
+	return n, nil
+}
@@ -0,0 +1,230 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// callServices are the services the API calls; nil when not registered
+type callServices struct {
+	users       *services.UserService
+	checkIns    *services.CheckInService
+	water       *services.WaterService
+	exercise    *services.ExerciseService
+	weighIns    *services.WeighInService
+	preferences *services.PreferencesService
+	summaries   *services.SummaryService
+}
+
+// call is one authenticated call
+type call struct {
+	services callServices
+	log      *logger.Logger
+	locale   string // Bot's default language
+}
+
+// lookupServices finds the services the API calls in the registry
+func lookupServices(registry *services.ServiceRegistry) callServices {
+	var found callServices
+	for _, svc := range registry.GetServices() {
+		switch s := svc.(type) {
+		case *services.UserService:
+			found.users = s
+		case *services.CheckInService:
+			found.checkIns = s
+		case *services.WaterService:
+			found.water = s
+		case *services.ExerciseService:
+			found.exercise = s
+		case *services.WeighInService:
+			found.weighIns = s
+		case *services.PreferencesService:
+			found.preferences = s
+		case *services.SummaryService:
+			found.summaries = s
+		}
+	}
+	return found
+}
+
+// progress loads the challenge progress of an existing user
+func (c call) progress(userID string) (services.Progress, error) {
+	if c.services.users == nil {
+		return services.Progress{}, status(codeUnavailable, "user service not available")
+	}
+	if userID == "" {
+		return services.Progress{}, status(codeInvalidArgument, "user_id is required")
+	}
+	progress, err := c.services.users.GetProgress(userID)
+	if errors.Is(err, services.ErrUserNotFound) {
+		return services.Progress{}, status(codeNotFound, "user not found; they must use the bot in Discord first")
+	}
+	return progress, err
+}
+
+// units returns the user's preferred units, or the defaults if they can't be loaded
+func (c call) units(userID string) services.Units {
+	if c.services.preferences == nil {
+		return services.DefaultUnits
+	}
+	units, err := c.services.preferences.GetUnits(userID)
+	if err != nil {
+		c.log.Error("gRPC failed to load units, using the defaults: %v", err)
+	}
+	return units
+}
+
+// getProgress returns the user's challenge standing, today's water, and latest weigh-in
+// in the user's preferred units
+func (c call) getProgress(request message) (message, error) {
+	progress, err := c.progress(request.(*userRequest).UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	units := c.units(progress.UserID)
+	response := &progressResponse{
+		UserID:        progress.UserID,
+		Username:      progress.Username,
+		StartDate:     progress.StartDate.Format("2006-01-02"),
+		EndDate:       progress.EndDate.Format("2006-01-02"),
+		Date:          progress.Date.Format("2006-01-02"),
+		ChallengeDay:  progress.ChallengeDay,
+		TotalDays:     progress.TotalDays,
+		DaysCompleted: progress.DaysCompleted,
+		WaterToday:    &amount{Unit: units.Volume},
+	}
+	if c.services.water != nil {
+		water, err := c.services.water.GetWaterIntake(progress.UserID, units)
+		if err != nil {
+			c.log.Error("gRPC failed to load water intake: %v", err)
+		}
+		response.WaterToday.Amount = water
+	}
+	if c.services.weighIns != nil {
+		weight, _, err := c.services.weighIns.GetLatestWeighIn(progress.UserID, units)
+		if err != nil {
+			c.log.Error("gRPC failed to load latest weigh-in: %v", err)
+		} else if weight > 0 {
+			response.LatestWeight = &amount{Amount: weight, Unit: units.Weight}
+		}
+	}
+	return response, nil
+}
+
+// checkIn records today's check-in for the user
+func (c call) checkIn(request message) (message, error) {
+	if c.services.checkIns == nil {
+		return nil, status(codeUnavailable, "check-in service not available")
+	}
+	progress, err := c.progress(request.(*userRequest).UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.services.checkIns.RecordCheckIn(c.log, progress.UserID, progress.Username); err != nil {
+		return nil, err
+	}
+	c.log.Info("Check-in recorded via gRPC for day %d", progress.ChallengeDay)
+	return &dayResponse{ChallengeDay: progress.ChallengeDay}, nil
+}
+
+// logWater adds (or with a negative amount, removes) water for today
+func (c call) logWater(request message) (message, error) {
+	if c.services.water == nil {
+		return nil, status(codeUnavailable, "water service not available")
+	}
+	req := request.(*logWaterRequest)
+	progress, err := c.progress(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	units := c.units(progress.UserID)
+	if req.Unit != "" {
+		units.Volume = req.Unit
+	}
+	if err := services.ValidateUnits(units); err != nil || req.Amount == 0 {
+		return nil, status(codeInvalidArgument, "amount must be non-zero and unit must be oz or l")
+	}
+
+	var total float64
+	if req.Amount > 0 {
+		_, total, err = c.services.water.AddWater(progress.UserID, progress.Username, req.Amount, units)
+	} else {
+		_, total, err = c.services.water.SubtractWater(progress.UserID, progress.Username, -req.Amount, units)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("Water logged via gRPC: %.2f %s", req.Amount, units.Volume)
+	return &logWaterResponse{
+		Total: amount{Amount: total, Unit: units.Volume},
+		Goal:  amount{Amount: units.FromOunces(services.WaterGoalOunces), Unit: units.Volume},
+	}, nil
+}
+
+// logExercise logs today's workout
+func (c call) logExercise(request message) (message, error) {
+	if c.services.exercise == nil {
+		return nil, status(codeUnavailable, "exercise service not available")
+	}
+	req := request.(*logExerciseRequest)
+	if req.WorkoutMinutes < 0 || req.CoreMinutes < 0 {
+		return nil, status(codeInvalidArgument, "durations can't be negative")
+	}
+	progress, err := c.progress(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WorkoutMinutes == 0 {
+		err = c.services.exercise.LogExerciseQuick(progress.UserID, progress.Username)
+	} else {
+		err = c.services.exercise.LogExerciseDetailed(progress.UserID, progress.Username, req.WorkoutMinutes,
+			orDefault(req.WorkoutType, "general"), orDefault(req.WorkoutLocation, "indoor"),
+			req.CoreMinutes, orDefault(req.CoreType, "general"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("Exercise logged via gRPC for day %d", progress.ChallengeDay)
+	return &dayResponse{ChallengeDay: progress.ChallengeDay}, nil
+}
+
+// getSummary returns the user's progress summary as /summary would show it to them
+func (c call) getSummary(request message) (message, error) {
+	if c.services.summaries == nil {
+		return nil, status(codeUnavailable, "summary service not available")
+	}
+	req := request.(*getSummaryRequest)
+	progress, err := c.progress(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := c.locale
+	if req.Locale != "" {
+		if !i18n.IsSupported(req.Locale) {
+			return nil, status(codeInvalidArgument, "unsupported locale %q", req.Locale)
+		}
+		locale = req.Locale
+	}
+	text, err := c.services.summaries.GetProgressSummary(context.Background(), locale, progress.Username, progress.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &getSummaryResponse{Text: text}, nil
+}
+
+// orDefault returns value, or fallback when value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,280 @@
+package grpcapi
+
+// Messages from proto/hard75/v1/hard75.proto. Field numbers must match the .proto
+// file, which is what clients generate their code from.
+
+// amount is hard75.v1.Amount
+type amount struct {
+	Amount float64
+	Unit   string
+}
+
+func (m *amount) marshal(e *encoder) {
+	e.double(1, m.Amount)
+	e.string(2, m.Unit)
+}
+
+func (m *amount) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			m.Amount = f.double()
+			return f.check(wireFixed64)
+		case 2:
+			m.Unit = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
+
+// userRequest is any request that names only a user: hard75.v1.GetProgressRequest
+// and hard75.v1.CheckInRequest
+type userRequest struct {
+	UserID string
+}
+
+func (m *userRequest) marshal(e *encoder) {
+	e.string(1, m.UserID)
+}
+
+func (m *userRequest) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		if f.number == 1 {
+			m.UserID = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
+
+// progressResponse is hard75.v1.Progress
+type progressResponse struct {
+	UserID        string
+	Username      string
+	StartDate     string
+	EndDate       string
+	Date          string
+	ChallengeDay  int
+	TotalDays     int
+	DaysCompleted int
+	WaterToday    *amount
+	LatestWeight  *amount // Nil until the user weighs in
+}
+
+func (m *progressResponse) marshal(e *encoder) {
+	e.string(1, m.UserID)
+	e.string(2, m.Username)
+	e.string(3, m.StartDate)
+	e.string(4, m.EndDate)
+	e.string(5, m.Date)
+	e.int32(6, m.ChallengeDay)
+	e.int32(7, m.TotalDays)
+	e.int32(8, m.DaysCompleted)
+	if m.WaterToday != nil {
+		e.message(9, m.WaterToday)
+	}
+	if m.LatestWeight != nil {
+		e.message(10, m.LatestWeight)
+	}
+}
+
+func (m *progressResponse) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			m.UserID = f.string()
+			return f.check(wireBytes)
+		case 2:
+			m.Username = f.string()
+			return f.check(wireBytes)
+		case 3:
+			m.StartDate = f.string()
+			return f.check(wireBytes)
+		case 4:
+			m.EndDate = f.string()
+			return f.check(wireBytes)
+		case 5:
+			m.Date = f.string()
+			return f.check(wireBytes)
+		case 6:
+			m.ChallengeDay = f.int32()
+			return f.check(wireVarint)
+		case 7:
+			m.TotalDays = f.int32()
+			return f.check(wireVarint)
+		case 8:
+			m.DaysCompleted = f.int32()
+			return f.check(wireVarint)
+		case 9:
+			m.WaterToday = &amount{}
+			return f.message(m.WaterToday)
+		case 10:
+			m.LatestWeight = &amount{}
+			return f.message(m.LatestWeight)
+		}
+		return nil
+	})
+}
+
+// dayResponse is any response that names only a challenge day:
+// hard75.v1.CheckInResponse and hard75.v1.LogExerciseResponse
+type dayResponse struct {
+	ChallengeDay int
+}
+
+func (m *dayResponse) marshal(e *encoder) {
+	e.int32(1, m.ChallengeDay)
+}
+
+func (m *dayResponse) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		if f.number == 1 {
+			m.ChallengeDay = f.int32()
+			return f.check(wireVarint)
+		}
+		return nil
+	})
+}
+
+// logWaterRequest is hard75.v1.LogWaterRequest
+type logWaterRequest struct {
+	UserID string
+	Amount float64
+	Unit   string
+}
+
+func (m *logWaterRequest) marshal(e *encoder) {
+	e.string(1, m.UserID)
+	e.double(2, m.Amount)
+	e.string(3, m.Unit)
+}
+
+func (m *logWaterRequest) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			m.UserID = f.string()
+			return f.check(wireBytes)
+		case 2:
+			m.Amount = f.double()
+			return f.check(wireFixed64)
+		case 3:
+			m.Unit = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
+
+// logWaterResponse is hard75.v1.LogWaterResponse
+type logWaterResponse struct {
+	Total amount
+	Goal  amount
+}
+
+func (m *logWaterResponse) marshal(e *encoder) {
+	e.message(1, &m.Total)
+	e.message(2, &m.Goal)
+}
+
+func (m *logWaterResponse) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			return f.message(&m.Total)
+		case 2:
+			return f.message(&m.Goal)
+		}
+		return nil
+	})
+}
+
+// logExerciseRequest is hard75.v1.LogExerciseRequest
+type logExerciseRequest struct {
+	UserID          string
+	WorkoutMinutes  int
+	WorkoutType     string
+	WorkoutLocation string
+	CoreMinutes     int
+	CoreType        string
+}
+
+func (m *logExerciseRequest) marshal(e *encoder) {
+	e.string(1, m.UserID)
+	e.int32(2, m.WorkoutMinutes)
+	e.string(3, m.WorkoutType)
+	e.string(4, m.WorkoutLocation)
+	e.int32(5, m.CoreMinutes)
+	e.string(6, m.CoreType)
+}
+
+func (m *logExerciseRequest) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			m.UserID = f.string()
+			return f.check(wireBytes)
+		case 2:
+			m.WorkoutMinutes = f.int32()
+			return f.check(wireVarint)
+		case 3:
+			m.WorkoutType = f.string()
+			return f.check(wireBytes)
+		case 4:
+			m.WorkoutLocation = f.string()
+			return f.check(wireBytes)
+		case 5:
+			m.CoreMinutes = f.int32()
+			return f.check(wireVarint)
+		case 6:
+			m.CoreType = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
+
+// getSummaryRequest is hard75.v1.GetSummaryRequest
+type getSummaryRequest struct {
+	UserID string
+	Locale string
+}
+
+func (m *getSummaryRequest) marshal(e *encoder) {
+	e.string(1, m.UserID)
+	e.string(2, m.Locale)
+}
+
+func (m *getSummaryRequest) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		switch f.number {
+		case 1:
+			m.UserID = f.string()
+			return f.check(wireBytes)
+		case 2:
+			m.Locale = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
+
+// getSummaryResponse is hard75.v1.GetSummaryResponse
+type getSummaryResponse struct {
+	Text string
+}
+
+func (m *getSummaryResponse) marshal(e *encoder) {
+	e.string(1, m.Text)
+}
+
+func (m *getSummaryResponse) unmarshal(data []byte) error {
+	return decode(data, func(f field) error {
+		if f.number == 1 {
+			m.Text = f.string()
+			return f.check(wireBytes)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,239 @@
+// Package grpcapi serves the check-in, water, exercise, and summary services over gRPC
+// for companion apps, as defined in proto/hard75/v1/hard75.proto. It speaks the gRPC
+// wire protocol directly on net/http's HTTP/2 support, which Go only offers over TLS.
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+const (
+	// servicePath prefixes every method's path, e.g. /hard75.v1.Hard75/CheckIn
+	servicePath = "/hard75.v1.Hard75/"
+	// maxMessageBytes caps request messages; every request is a few small fields
+	maxMessageBytes = 64 << 10
+	// shutdownTimeout bounds how long Stop waits for in-flight calls
+	shutdownTimeout = 5 * time.Second
+)
+
+// Status codes from the gRPC spec that the API returns
+const (
+	codeOK                 = 0
+	codeInvalidArgument    = 3
+	codeNotFound           = 5
+	codeResourceExhausted  = 8
+	codeFailedPrecondition = 9
+	codeUnimplemented      = 12
+	codeInternal           = 13
+	codeUnavailable        = 14
+	codeUnauthenticated    = 16
+)
+
+// statusError is a call's failure, sent to the client as grpc-status and grpc-message
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("grpc status %d: %s", e.code, e.message)
+}
+
+func status(code int, format string, args ...interface{}) error {
+	return &statusError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// Server serves the gRPC API on its own TLS listener
+type Server struct {
+	server   *http.Server
+	certFile string
+	keyFile  string
+}
+
+// New creates a server for addr (e.g. ":9090") with a TLS certificate and key.
+// Calls must send token as bearer metadata. Summaries are written in locale unless
+// a call asks for another.
+func New(addr, certFile, keyFile, token, locale string, registry *services.ServiceRegistry) *Server {
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           &handler{token: token, locale: locale, services: registry},
+			ReadHeaderTimeout: 10 * time.Second,
+			TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
+
+// Start loads the certificate and binds the address, so either problem is reported
+// here, then serves in the background
+func (s *Server) Start() error {
+	cert, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	s.server.TLSConfig.Certificates = []tls.Certificate{cert}
+
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	go func() {
+		// Certificates are already in TLSConfig; ServeTLS also enables HTTP/2
+		if err := s.server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("gRPC server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops accepting connections and waits for in-flight calls to finish
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		logger.Warn("⚠️  gRPC server did not shut down cleanly: %v", err)
+	}
+}
+
+// handler answers unary gRPC calls
+type handler struct {
+	token    string
+	locale   string
+	services *services.ServiceRegistry
+}
+
+// methods maps each method name to the message it takes and the call that answers it
+var methods = map[string]struct {
+	request func() message
+	call    func(c call, request message) (message, error)
+}{
+	"GetProgress": {func() message { return &userRequest{} }, call.getProgress},
+	"CheckIn":     {func() message { return &userRequest{} }, call.checkIn},
+	"LogWater":    {func() message { return &logWaterRequest{} }, call.logWater},
+	"LogExercise": {func() message { return &logExerciseRequest{} }, call.logExercise},
+	"GetSummary":  {func() message { return &getSummaryRequest{} }, call.getSummary},
+}
+
+// ServeHTTP reads one framed request message, runs the method, and writes the framed
+// response followed by the status trailers
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+	name := strings.TrimPrefix(r.URL.Path, servicePath)
+	log := logger.With("request_id", logger.NewCorrelationID(), "grpc_method", name)
+
+	response, err := h.serve(log, name, r)
+	if err == nil {
+		_, err = w.Write(frame(marshal(response)))
+		if err != nil {
+			log.Warn("gRPC response not delivered: %v", err)
+			return
+		}
+	}
+
+	var st *statusError
+	var dayErr *services.DayError
+	if errors.As(err, &dayErr) {
+		// Logs for a day outside the user's challenge say which days are allowed
+		st = &statusError{code: codeFailedPrecondition, message: dayErr.Error()}
+	} else if err != nil && !errors.As(err, &st) {
+		log.Error("gRPC call failed: %v", err)
+		st = &statusError{code: codeInternal, message: "internal error"}
+	}
+	if st == nil {
+		st = &statusError{code: codeOK}
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(st.code))
+	if st.message != "" {
+		w.Header().Set("Grpc-Message", encodeMessage(st.message))
+	}
+}
+
+// serve authenticates the call, decodes its request, and runs the method
+func (h *handler) serve(log *logger.Logger, name string, r *http.Request) (message, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		return nil, status(codeUnauthenticated, "missing or invalid bearer token")
+	}
+
+	method, ok := methods[name]
+	if !strings.HasPrefix(r.URL.Path, servicePath) || !ok {
+		return nil, status(codeUnimplemented, "unknown method %s", r.URL.Path)
+	}
+
+	payload, err := readFrame(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	request := method.request()
+	if err := request.unmarshal(payload); err != nil {
+		return nil, status(codeInvalidArgument, "invalid request message: %v", err)
+	}
+
+	c := call{services: lookupServices(h.services), log: log, locale: h.locale}
+	return method.call(c, request)
+}
+
+// readFrame reads the single length-prefixed message of a unary call
+func readFrame(body io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(body, header[:]); err != nil {
+		return nil, status(codeInvalidArgument, "missing request message")
+	}
+	if header[0] != 0 {
+		return nil, status(codeUnimplemented, "compressed messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > maxMessageBytes {
+		return nil, status(codeResourceExhausted, "request message is larger than %d bytes", maxMessageBytes)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(body, payload); err != nil {
+		return nil, status(codeInvalidArgument, "truncated request message")
+	}
+	return payload, nil
+}
+
+// frame prefixes an uncompressed message with its length
+func frame(payload []byte) []byte {
+	framed := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(framed[1:], uint32(len(payload)))
+	return append(framed, payload...)
+}
+
+// encodeMessage percent-encodes a status message for the grpc-message trailer
+func encodeMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
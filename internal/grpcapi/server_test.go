@@ -0,0 +1,126 @@
+package grpcapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	payload := marshal(&userRequest{UserID: "42"})
+	got, err := readFrame(bytes.NewReader(frame(payload)))
+	if err != nil {
+		t.Fatalf("readFrame() error = %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("readFrame() = %x, want %x", got, payload)
+	}
+
+	empty, err := readFrame(bytes.NewReader(frame(nil)))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("readFrame(empty) = %x, %v, want an empty message", empty, err)
+	}
+}
+
+func TestReadFrameMalformed(t *testing.T) {
+	header := func(compressed byte, length uint32) []byte {
+		h := []byte{compressed, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(h[1:], length)
+		return h
+	}
+	tests := []struct {
+		name string
+		body []byte
+		code int
+	}{
+		{"no body", nil, codeInvalidArgument},
+		{"short header", []byte{0, 0, 0}, codeInvalidArgument},
+		{"compressed", append(header(1, 2), 0x0a, 0), codeUnimplemented},
+		{"over the size limit", header(0, maxMessageBytes+1), codeResourceExhausted},
+		{"largest length", header(0, 1<<32-1), codeResourceExhausted},
+		{"shorter than its length", append(header(0, 10), 0x0a, 0x02), codeInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readFrame(bytes.NewReader(tt.body))
+			var st *statusError
+			if !errors.As(err, &st) {
+				t.Fatalf("readFrame() error = %v, want a status", err)
+			}
+			if st.code != tt.code {
+				t.Errorf("readFrame() code = %d (%s), want %d", st.code, st.message, tt.code)
+			}
+		})
+	}
+}
+
+func TestServeHTTPStatus(t *testing.T) {
+	h := &handler{token: "secret", locale: "en", services: services.NewServiceRegistry()}
+	valid := frame(marshal(&userRequest{UserID: "42"}))
+
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		body  []byte
+		code  int
+	}{
+		{"no token", "/hard75.v1.Hard75/CheckIn", "", valid, codeUnauthenticated},
+		{"wrong token", "/hard75.v1.Hard75/CheckIn", "guess", valid, codeUnauthenticated},
+		{"unknown method", "/hard75.v1.Hard75/DeleteEverything", "secret", valid, codeUnimplemented},
+		{"other service", "/other.v1.Service/CheckIn", "secret", valid, codeUnimplemented},
+		{"malformed message", "/hard75.v1.Hard75/CheckIn", "secret", frame([]byte{0x0a, 0x05}), codeInvalidArgument},
+		{"oversized frame", "/hard75.v1.Hard75/CheckIn", "secret", []byte{0, 0xff, 0xff, 0xff, 0xff}, codeResourceExhausted},
+		// No services are registered, so a well-formed call gets as far as the lookup
+		{"well-formed", "/hard75.v1.Hard75/CheckIn", "secret", valid, codeUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(tt.body))
+			r.ProtoMajor = 2
+			r.Header.Set("Content-Type", "application/grpc")
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Grpc-Status"); got != strconv.Itoa(tt.code) {
+				t.Errorf("Grpc-Status = %s (%s), want %d", got, w.Header().Get("Grpc-Message"), tt.code)
+			}
+			if w.Body.Len() != 0 {
+				t.Errorf("failed call wrote a response message: %x", w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestServeHTTPRejectsNonGRPC(t *testing.T) {
+	h := &handler{token: "secret", services: services.NewServiceRegistry()}
+	r := httptest.NewRequest(http.MethodPost, "/hard75.v1.Hard75/CheckIn", nil)
+	r.Header.Set("Content-Type", "application/grpc")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("HTTP/1.1 request status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	tests := map[string]string{
+		"user_id is required": "user_id is required",
+		"100% done":           "100%25 done",
+		"día 3\n":             "d%C3%ADa 3%0A",
+	}
+	for in, want := range tests {
+		if got := encodeMessage(in); got != want {
+			t.Errorf("encodeMessage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
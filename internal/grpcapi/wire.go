@@ -0,0 +1,168 @@
+package grpcapi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Protobuf wire types used by the API's messages
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// errTruncated is returned for a message that ends in the middle of a field
+var errTruncated = errors.New("truncated message")
+
+// message is a protobuf message from hard75.proto. Fields are encoded by number,
+// and zero values are left out, as proto3 does.
+type message interface {
+	marshal(e *encoder)
+	unmarshal(data []byte) error
+}
+
+// encoder appends protobuf fields to a buffer
+type encoder struct {
+	buf []byte
+}
+
+// marshal encodes m
+func marshal(m message) []byte {
+	var e encoder
+	m.marshal(&e)
+	return e.buf
+}
+
+func (e *encoder) varint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *encoder) tag(field, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *encoder) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	e.tag(field, wireBytes)
+	e.varint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) int32(field int, v int) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireVarint)
+	// Negative numbers are sign-extended to 64 bits, as protobuf requires
+	e.varint(uint64(int64(int32(v))))
+}
+
+func (e *encoder) double(field int, v float64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireFixed64)
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v))
+}
+
+// message encodes m as a nested message. Unlike scalars it's always written, so
+// callers leave out unset messages themselves.
+func (e *encoder) message(field int, m message) {
+	encoded := marshal(m)
+	e.tag(field, wireBytes)
+	e.varint(uint64(len(encoded)))
+	e.buf = append(e.buf, encoded...)
+}
+
+// field is one decoded protobuf field. For varint and fixed fields the value is in
+// number; for length-delimited ones it's in bytes.
+type field struct {
+	number   int
+	wireType int
+	value    uint64
+	bytes    []byte
+}
+
+func (f field) string() string {
+	return string(f.bytes)
+}
+
+func (f field) int32() int {
+	return int(int32(f.value))
+}
+
+func (f field) double() float64 {
+	return math.Float64frombits(f.value)
+}
+
+// message decodes a nested message into m
+func (f field) message(m message) error {
+	if err := f.check(wireBytes); err != nil {
+		return err
+	}
+	return m.unmarshal(f.bytes)
+}
+
+// check reports a field whose wire type doesn't match its declared type
+func (f field) check(wireType int) error {
+	if f.wireType != wireType {
+		return fmt.Errorf("field %d has wire type %d, want %d", f.number, f.wireType, wireType)
+	}
+	return nil
+}
+
+// decode calls fn for each field in data. Unknown fields are passed too, so newer
+// clients can send fields this server doesn't know; fn ignores them.
+func decode(data []byte, fn func(f field) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		f := field{number: int(key >> 3), wireType: int(key & 7)}
+		if f.number == 0 {
+			return fmt.Errorf("invalid field number 0")
+		}
+		switch f.wireType {
+		case wireVarint:
+			f.value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			f.value = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			f.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			f.value = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		default:
+			return fmt.Errorf("field %d has unsupported wire type %d", f.number, f.wireType)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalMatchesProtobuf(t *testing.T) {
+	// Expected bytes are what protoc-generated code writes for the same messages
+	tests := []struct {
+		name string
+		m    message
+		want string
+	}{
+		{"empty", &userRequest{}, ""},
+		{"string", &userRequest{UserID: "42"}, "0a023432"},
+		{"int32", &dayResponse{ChallengeDay: 150}, "089601"},
+		{"negative int32 is sign-extended", &dayResponse{ChallengeDay: -1}, "08ffffffffffffffffff01"},
+		{"double", &amount{Amount: 1.5, Unit: "oz"}, "09000000000000f83f12026f7a"},
+		{"nested messages are always written", &logWaterResponse{Goal: amount{Amount: 1}}, "0a00120909000000000000f03f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(marshal(tt.m)); got != tt.want {
+				t.Errorf("marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   message
+		out  message
+	}{
+		{"progress", &progressResponse{
+			UserID:        "123456789012345678",
+			Username:      "jess",
+			StartDate:     "2026-09-01",
+			EndDate:       "2026-11-15",
+			Date:          "2026-10-18",
+			ChallengeDay:  48,
+			TotalDays:     75,
+			DaysCompleted: 47,
+			WaterToday:    &amount{Amount: 96.5, Unit: "oz"},
+		}, &progressResponse{}},
+		{"progress with weight", &progressResponse{UserID: "1", LatestWeight: &amount{Amount: 81.2, Unit: "kg"}}, &progressResponse{}},
+		{"water", &logWaterRequest{UserID: "1", Amount: -8, Unit: "oz"}, &logWaterRequest{}},
+		{"water response", &logWaterResponse{Total: amount{Amount: 64, Unit: "oz"}, Goal: amount{Amount: 128, Unit: "oz"}}, &logWaterResponse{}},
+		{"exercise", &logExerciseRequest{UserID: "1", WorkoutMinutes: 45, WorkoutType: "run", WorkoutLocation: "outdoor", CoreMinutes: 10, CoreType: "yoga"}, &logExerciseRequest{}},
+		{"summary", &getSummaryRequest{UserID: "1", Locale: "es"}, &getSummaryRequest{}},
+		{"summary response", &getSummaryResponse{Text: "📊 día 12 ✅"}, &getSummaryResponse{}},
+		{"day", &dayResponse{ChallengeDay: -5}, &dayResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.out.unmarshal(marshal(tt.in)); err != nil {
+				t.Fatalf("unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.in, tt.out) {
+				t.Errorf("round trip = %+v, want %+v", tt.out, tt.in)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSkipsUnknownFields(t *testing.T) {
+	var e encoder
+	e.string(1, "42")
+	// Fields a newer client might send, one of each wire type
+	e.int32(20, 7)
+	e.double(21, 2.5)
+	e.string(22, "extra")
+	e.tag(23, wireFixed32)
+	e.buf = append(e.buf, 1, 2, 3, 4)
+	e.message(24, &amount{Amount: 3})
+
+	var m userRequest
+	if err := m.unmarshal(e.buf); err != nil {
+		t.Fatalf("unmarshal() error = %v", err)
+	}
+	if m.UserID != "42" {
+		t.Errorf("UserID = %q, want %q", m.UserID, "42")
+	}
+}
+
+func TestUnmarshalLastFieldWins(t *testing.T) {
+	data := append(marshal(&userRequest{UserID: "old"}), marshal(&userRequest{UserID: "new"})...)
+	var m userRequest
+	if err := m.unmarshal(data); err != nil {
+		t.Fatalf("unmarshal() error = %v", err)
+	}
+	if m.UserID != "new" {
+		t.Errorf("UserID = %q, want %q", m.UserID, "new")
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		truncated bool // The error is errTruncated rather than a description
+	}{
+		{"truncated key", "80", true},
+		{"truncated varint", "0880", true},
+		{"varint longer than 10 bytes", "08ffffffffffffffffffff01", true},
+		{"truncated fixed64", "09000000", true},
+		{"truncated fixed32", "7d0000", true},
+		{"truncated length", "0a", true},
+		{"length past the end", "0a056162", true},
+		{"length larger than any buffer", "0affffffffffffffffff01", true},
+		{"length overflowing int64", "0a8080808080808080ff01", true},
+		{"start group", "0b", false},
+		{"end group", "0c", false},
+		{"reserved wire type 6", "0e", false},
+		{"reserved wire type 7", "0f", false},
+		{"field number 0", "0001", false},
+		{"string sent as varint", "0801", false},
+		{"int32 sent as string", "120131", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m logExerciseRequest
+			err = m.unmarshal(data)
+			if err == nil {
+				t.Fatalf("unmarshal(%s) succeeded, want an error", tt.data)
+			}
+			if got := errors.Is(err, errTruncated); got != tt.truncated {
+				t.Errorf("unmarshal(%s) error = %v, truncated = %v, want %v", tt.data, err, got, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMalformedNestedMessage(t *testing.T) {
+	// A WaterToday whose length fits the outer message but whose contents are cut short
+	data, _ := hex.DecodeString("4a020900")
+	var m progressResponse
+	if err := m.unmarshal(data); !errors.Is(err, errTruncated) {
+		t.Errorf("unmarshal() error = %v, want %v", err, errTruncated)
+	}
+}
+
+func TestDecodeFields(t *testing.T) {
+	data := marshal(&getSummaryRequest{UserID: "1", Locale: "es"})
+	var fields [][]byte
+	err := decode(data, func(f field) error {
+		fields = append(fields, f.bytes)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if len(fields) != 2 || !bytes.Equal(fields[0], []byte("1")) || !bytes.Equal(fields[1], []byte("es")) {
+		t.Errorf("decode() fields = %q, want [1 es]", fields)
+	}
+}
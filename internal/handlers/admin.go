@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleBackupCommand handles the /backup slash command
+func (h *InteractionHandler) handleBackupCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get backup service from registry
+	var backupService *services.BackupService
+	for _, svc := range h.services.GetServices() {
+		if bs, ok := svc.(*services.BackupService); ok {
+			backupService = bs
+			break
+		}
+	}
+
+	if backupService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "backup.not_configured"),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0].Name
+	if subcommand != "now" {
+		return
+	}
+
+	// pg_dump and upload can take longer than the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	RequestLogger(i).Info("Manual backup requested by user_id=%s", i.Member.User.ID)
+	key, size, err := backupService.RunBackup()
+
+	var content string
+	if err != nil {
+		RequestLogger(i).Error("Manual backup failed: %v", err)
+		content = i18n.T(locale, "backup.failed", err)
+	} else {
+		content = i18n.T(locale, "backup.complete", key, float64(size)/1024)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing backup response: %v", err)
+	}
+}
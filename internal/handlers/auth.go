@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+)
+
+// AdminRoutes lists the commands and components restricted to admins
+var AdminRoutes = []string{
+	"backup",
+	"export",
+	"features",
+	"settings",
+	"botstats",
+	"config",
+	"config_template",
+	"adjust",
+	"setstart",
+	"setday",
+	"backfill",
+}
+
+// adminPermissions are Discord permissions that grant admin access without a configured role
+const adminPermissions = discordgo.PermissionAdministrator | discordgo.PermissionManageServer
+
+// Authorizer restricts admin routes to members with a configured role or admin permission
+type Authorizer struct {
+	adminRoleIDs map[string]bool
+	adminRoutes  map[string]bool
+}
+
+// NewAuthorizer creates an authorizer for the given admin role IDs and routes
+func NewAuthorizer(adminRoleIDs []string, adminRoutes []string) *Authorizer {
+	a := &Authorizer{
+		adminRoleIDs: make(map[string]bool, len(adminRoleIDs)),
+		adminRoutes:  make(map[string]bool, len(adminRoutes)),
+	}
+	for _, roleID := range adminRoleIDs {
+		a.adminRoleIDs[roleID] = true
+	}
+	for _, route := range adminRoutes {
+		a.adminRoutes[route] = true
+	}
+	return a
+}
+
+// IsAdmin reports whether a guild member may use admin routes
+func (a *Authorizer) IsAdmin(member *discordgo.Member) bool {
+	if member == nil {
+		return false // DMs never get admin access
+	}
+	if member.Permissions&adminPermissions != 0 {
+		return true
+	}
+	for _, roleID := range member.Roles {
+		if a.adminRoleIDs[roleID] {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware rejects admin routes for members without admin access
+func (a *Authorizer) Middleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		name := RouteName(i)
+		if !a.adminRoutes[name] || a.IsAdmin(i.Member) {
+			next(s, i)
+			return
+		}
+
+		userID := ""
+		if user := InteractionUser(i); user != nil {
+			userID = user.ID
+		}
+		RequestLogger(i).Info("Denied admin route %s for user_id=%s", name, userID)
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(RequestLocale(i), "error.admin_only"),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// RuntimeStats is the bot-level state /botstats reports alongside service health
+type RuntimeStats struct {
+	StartedAt        time.Time
+	GatewayConnected bool
+	GatewayLatency   time.Duration // Last heartbeat round trip
+	Commands         []string      // Registered slash commands
+}
+
+// SetRuntimeStats sets where /botstats gets uptime, gateway, and command info
+func (h *InteractionHandler) SetRuntimeStats(stats func() RuntimeStats) {
+	h.runtimeStats = stats
+}
+
+// handleBotStatsCommand handles the /botstats slash command
+func (h *InteractionHandler) handleBotStatsCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+	var b strings.Builder
+	b.WriteString(i18n.T(locale, "botstats.title"))
+
+	if h.runtimeStats != nil {
+		stats := h.runtimeStats()
+		b.WriteString(i18n.T(locale, "botstats.uptime", time.Since(stats.StartedAt).Round(time.Second), stats.StartedAt.Unix()))
+		if stats.GatewayConnected {
+			b.WriteString(i18n.T(locale, "botstats.gateway_connected", stats.GatewayLatency.Round(time.Millisecond)))
+		} else {
+			b.WriteString(i18n.T(locale, "botstats.gateway_disconnected"))
+		}
+		b.WriteString(i18n.T(locale, "botstats.commands", len(stats.Commands), strings.Join(stats.Commands, ", ")))
+	}
+
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+	b.WriteString(i18n.T(locale, "botstats.goroutines", runtime.NumGoroutine()))
+	b.WriteString(i18n.T(locale, "botstats.memory",
+		float64(memory.HeapInuse)/(1024*1024), float64(memory.Sys)/(1024*1024), memory.NumGC))
+
+	// Health monitor: database pool and the latest health check results
+	var monitor *services.HealthMonitor
+	for _, svc := range h.services.GetServices() {
+		if hm, ok := svc.(*services.HealthMonitor); ok {
+			monitor = hm
+			break
+		}
+	}
+	if monitor != nil {
+		if pool, ok := monitor.DBStats(); ok {
+			b.WriteString(i18n.T(locale, "botstats.db_pool",
+				pool.InUse, pool.Idle, pool.OpenConnections, pool.MaxOpenConnections, pool.WaitCount, pool.WaitDuration.Round(time.Millisecond)))
+		} else {
+			b.WriteString(i18n.T(locale, "botstats.db_pool_none"))
+		}
+
+		b.WriteString(i18n.T(locale, "botstats.health"))
+		for _, status := range monitor.Statuses() {
+			if status.Healthy {
+				fmt.Fprintf(&b, "✅ %s\n", status.Name)
+			} else {
+				b.WriteString(i18n.T(locale, "botstats.unhealthy", status.Name, status.Error, status.Since.Unix()))
+			}
+		}
+	}
+
+	// Last run of each background job
+	b.WriteString(i18n.T(locale, "botstats.jobs"))
+	for _, svc := range h.services.GetServices() {
+		job, ok := svc.(services.Scheduled)
+		if !ok {
+			continue
+		}
+		lastRun, err := job.LastRun()
+		switch {
+		case lastRun.IsZero():
+			b.WriteString(i18n.T(locale, "botstats.job_not_run", job.Name()))
+		case err != nil:
+			fmt.Fprintf(&b, "• %s: <t:%d:R> ❌ %v\n", job.Name(), lastRun.Unix(), err)
+		default:
+			fmt.Fprintf(&b, "• %s: <t:%d:R> ✅\n", job.Name(), lastRun.Unix())
+		}
+	}
+
+	if err := discord.RespondLong(s, i.Interaction, b.String(), discordgo.MessageFlagsEphemeral); err != nil {
+		RequestLogger(i).Error("Error sending bot stats: %v", err)
+	}
+}
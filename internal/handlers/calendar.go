@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleCalendarCommand handles the /calendar slash command, which manages the user's
+// calendar feed URL
+func (h *InteractionHandler) handleCalendarCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get calendar and user services from registry
+	var calendarService *services.CalendarService
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		switch s := svc.(type) {
+		case *services.CalendarService:
+			calendarService = s
+		case *services.UserService:
+			userService = s
+		}
+	}
+
+	if calendarService == nil || userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.calendar")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	var content string
+	switch i.ApplicationCommandData().Options[0].Name {
+	case "link":
+		// The feed is built from the user's challenge dates
+		if _, err := userService.GetProgress(userID); errors.Is(err, services.ErrUserNotFound) {
+			content = i18n.T(locale, "calendar.not_started")
+			break
+		} else if err != nil {
+			content = i18n.T(locale, "calendar.error", err)
+			break
+		}
+
+		url, err := calendarService.Link(userID, locale)
+		if err != nil {
+			content = i18n.T(locale, "calendar.error", err)
+			break
+		}
+		RequestLogger(i).Info("Calendar feed linked for user_id=%s", userID)
+		content = i18n.T(locale, "calendar.link", url)
+
+	case "revoke":
+		revoked, err := calendarService.Revoke(userID)
+		if err != nil {
+			content = i18n.T(locale, "calendar.error", err)
+			break
+		}
+		if !revoked {
+			content = i18n.T(locale, "calendar.no_link")
+			break
+		}
+		RequestLogger(i).Info("Calendar feed revoked for user_id=%s", userID)
+		content = i18n.T(locale, "calendar.revoked")
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// maxCoachReply keeps a reply and the usage note within Discord's message limit
+const maxCoachReply = 1800
+
+// handleCoachCommand handles the /coach slash command, which asks the LLM coach
+// about the user's recent stats
+func (h *InteractionHandler) handleCoachCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get coach service from registry
+	var coachService *services.CoachService
+	for _, svc := range h.services.GetServices() {
+		if cs, ok := svc.(*services.CoachService); ok {
+			coachService = cs
+			break
+		}
+	}
+
+	if coachService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.coach")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// The model can take several seconds, so reply once it's done
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	var question string
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "question" {
+			question = option.StringValue()
+		}
+	}
+
+	var content string
+	reply, remaining, err := coachService.Coach(RequestContext(i), userID, locale, question)
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		content = i18n.T(locale, "coach.not_started")
+	case errors.Is(err, services.ErrCoachLimitReached):
+		content = i18n.T(locale, "coach.limit", coachService.DailyLimit())
+	case err != nil:
+		RequestLogger(i).Error("Failed to get coaching: %v", err)
+		content = i18n.T(locale, "coach.error", err)
+	default:
+		RequestLogger(i).Info("Coaching sent to user_id=%s, %d left today", userID, remaining)
+		if runes := []rune(reply); len(runes) > maxCoachReply {
+			reply = string(runes[:maxCoachReply-1]) + "…"
+		}
+		content = i18n.T(locale, "coach.reply", reply) + i18n.T(locale, "coach.remaining", remaining, coachService.DailyLimit())
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing coach response: %v", err)
+	}
+}
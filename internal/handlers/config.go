@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleConfigCommand handles the /config slash command (admin only)
+func (h *InteractionHandler) handleConfigCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	switch i.ApplicationCommandData().Options[0].Name {
+	case "webhook":
+		h.handleConfigWebhook(s, i)
+		return
+	case "sheet":
+		h.handleConfigSheet(s, i)
+		return
+	}
+
+	// Get template service from registry
+	var templateService *services.TemplateService
+	for _, svc := range h.services.GetServices() {
+		if ts, ok := svc.(*services.TemplateService); ok {
+			templateService = ts
+			break
+		}
+	}
+
+	if templateService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.templates")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// /config template list|set|reset
+	subcommand := i.ApplicationCommandData().Options[0].Options[0]
+	var content string
+
+	switch subcommand.Name {
+	case "list":
+		templates, err := templateService.All(i.GuildID)
+		if err != nil {
+			content = i18n.T(locale, "templates.error_load", err)
+			break
+		}
+
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "templates.title"))
+		for _, name := range services.TemplateNames() {
+			status := i18n.T(locale, "templates.builtin")
+			if _, ok := templates[name]; ok {
+				status = i18n.T(locale, "templates.custom")
+			}
+			message.WriteString(i18n.T(locale, "templates.entry", name, status,
+				i18n.T(locale, "template."+name), services.FormatTemplateVariables(name)))
+		}
+		content = message.String()
+
+	case "set":
+		// Template text can span lines, which slash command options can't, so edit it in a modal
+		name := subcommand.Options[0].StringValue()
+		current, _, err := templateService.Get(i.GuildID, name)
+		if err != nil {
+			content = i18n.T(locale, "templates.error_load", err)
+			break
+		}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseModal,
+			Data: &discordgo.InteractionResponseData{
+				CustomID: CustomID("config_template", name),
+				Title:    i18n.T(locale, "templates.modal_title", name),
+				Components: []discordgo.MessageComponent{
+					discordgo.ActionsRow{
+						Components: []discordgo.MessageComponent{
+							discordgo.TextInput{
+								CustomID:    "body",
+								Label:       i18n.T(locale, "templates.modal_text"),
+								Style:       discordgo.TextInputParagraph,
+								Placeholder: services.FormatTemplateVariables(name),
+								Value:       current,
+								Required:    true,
+								MaxLength:   services.MaxTemplateLength,
+							},
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			RequestLogger(i).Error("Error showing template modal: %v", err)
+		}
+		return
+
+	case "reset":
+		name := subcommand.Options[0].StringValue()
+		if err := templateService.Reset(i.GuildID, name); err != nil {
+			content = i18n.T(locale, "templates.error_reset", err)
+			break
+		}
+
+		RequestLogger(i).Info("Template %s reset in guild_id=%s by user_id=%s", name, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "templates.reset", name)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleConfigTemplateModal saves a template edited with /config template set
+func (h *InteractionHandler) handleConfigTemplateModal(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get template service from registry
+	var templateService *services.TemplateService
+	for _, svc := range h.services.GetServices() {
+		if ts, ok := svc.(*services.TemplateService); ok {
+			templateService = ts
+			break
+		}
+	}
+
+	if templateService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.templates")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	_, args := ParseCustomID(i.ModalSubmitData().CustomID)
+	if len(args) != 1 {
+		RequestLogger(i).Error("Malformed template modal CustomID: %s", i.ModalSubmitData().CustomID)
+		return
+	}
+	name := args[0]
+	body := ParseModalFields(i.ModalSubmitData()).String("body", "")
+
+	var content string
+	if err := templateService.Set(i.GuildID, name, body, i.Member.User.ID); err != nil {
+		content = i18n.T(locale, "templates.error_update", err)
+	} else {
+		RequestLogger(i).Info("Template %s set in guild_id=%s by user_id=%s", name, i.GuildID, i.Member.User.ID)
+
+		// Preview with the admin as the member, partway through the challenge
+		preview := services.RenderTemplate(body, map[string]string{
+			"user": "<@" + i.Member.User.ID + ">",
+			"day":  "12",
+			"date": i18n.FormatDateIn(GuildLocale(i), time.Now(), GuildTimezone(i)),
+		})
+		content = i18n.T(locale, "templates.set", name, preview)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
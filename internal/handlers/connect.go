@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// accountLinker returns the registered service that links accounts at provider, or
+// nil when the provider isn't configured
+func (h *InteractionHandler) accountLinker(provider string) services.AccountLinker {
+	for _, svc := range h.services.GetServices() {
+		if linker, ok := svc.(services.AccountLinker); ok && linker.Provider() == provider {
+			return linker
+		}
+	}
+	return nil
+}
+
+// handleConnectCommand handles the /connect slash command, which links a fitness app.
+// The subcommand names the provider.
+func (h *InteractionHandler) handleConnectCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+	provider := i.ApplicationCommandData().Options[0].Name
+
+	// Get the provider's service and the user service from registry
+	linker := h.accountLinker(provider)
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		if us, ok := svc.(*services.UserService); ok {
+			userService = us
+			break
+		}
+	}
+
+	if linker == nil || userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service."+provider)),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	var content string
+	// Linked accounts belong to an existing participant
+	if _, err := userService.GetProgress(userID); errors.Is(err, services.ErrUserNotFound) {
+		content = i18n.T(locale, "connect.not_started")
+	} else if err != nil {
+		content = i18n.T(locale, "connect.error", err)
+	} else if authorizeURL, err := linker.AuthorizeURL(userID); err != nil {
+		content = i18n.T(locale, "connect.error", err)
+	} else {
+		RequestLogger(i).Info("%s sign-in started for user_id=%s", provider, userID)
+		content = i18n.T(locale, "connect."+provider, authorizeURL)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleDisconnectCommand handles the /disconnect slash command, which unlinks a fitness
+// app. The subcommand names the provider.
+func (h *InteractionHandler) handleDisconnectCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+	provider := i.ApplicationCommandData().Options[0].Name
+
+	linker := h.accountLinker(provider)
+	if linker == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service."+provider)),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	var content string
+	disconnected, err := linker.Disconnect(userID)
+	switch {
+	case err != nil:
+		content = i18n.T(locale, "disconnect.error", err)
+	case !disconnected:
+		content = i18n.T(locale, "disconnect.not_found", i18n.T(locale, "service."+provider))
+	default:
+		RequestLogger(i).Info("%s unlinked for user_id=%s", provider, userID)
+		content = i18n.T(locale, "disconnect.done", i18n.T(locale, "service."+provider))
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"math"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/expiring"
+	"github.com/75-hard-discord-bot/internal/i18n"
+)
+
+// DefaultCommandCooldowns limits how often one user can run expensive commands
+var DefaultCommandCooldowns = map[string]time.Duration{
+	"summary":      30 * time.Second,
+	"leaderboard":  30 * time.Second,
+	"exportmydata": 5 * time.Minute,
+	"backup":       time.Minute,
+	"coach":        30 * time.Second,
+}
+
+// DefaultReactionCooldown throttles reaction-triggered check-in writes per user
+const DefaultReactionCooldown = 5 * time.Second
+
+// CooldownLimiter tracks the last time each user triggered a rate-limited action
+type CooldownLimiter struct {
+	cooldowns map[string]time.Duration
+	lastUsed  *expiring.Map[string, time.Time] // Until the cooldown ends
+}
+
+// NewCooldownLimiter creates a limiter with per-route cooldowns
+func NewCooldownLimiter(cooldowns map[string]time.Duration) *CooldownLimiter {
+	return &CooldownLimiter{
+		cooldowns: cooldowns,
+		lastUsed:  expiring.New[string, time.Time](),
+	}
+}
+
+// Allow reports whether key may run now given the cooldown; if not, it returns the time left.
+// Allowed calls start a new cooldown window.
+func (c *CooldownLimiter) Allow(key string, cooldown time.Duration) (bool, time.Duration) {
+	now := time.Now()
+	if last, cooling := c.lastUsed.LoadOrStore(key, now, cooldown); cooling {
+		return false, cooldown - now.Sub(last)
+	}
+	return true, 0
+}
+
+// Cooling reports whether key's cooldown, started with Start, is still running, and
+// the time left. Unlike Allow it doesn't start a window, for callers that only count
+// attempts that succeed.
+func (c *CooldownLimiter) Cooling(key string, cooldown time.Duration) (bool, time.Duration) {
+	if last, cooling := c.lastUsed.Get(key); cooling {
+		return true, cooldown - time.Since(last)
+	}
+	return false, 0
+}
+
+// Start starts a new cooldown window for key
+func (c *CooldownLimiter) Start(key string, cooldown time.Duration) {
+	c.lastUsed.Set(key, time.Now(), cooldown)
+}
+
+// Middleware rejects routed interactions that are still cooling down for the user
+func (c *CooldownLimiter) Middleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		name := RouteName(i)
+		cooldown, limited := c.cooldowns[name]
+		user := InteractionUser(i)
+		if !limited || user == nil {
+			next(s, i)
+			return
+		}
+
+		allowed, remaining := c.Allow(name+":"+user.ID, cooldown)
+		if !allowed {
+			RequestLogger(i).Info("Cooldown: user_id=%s tried %s again with %s remaining", user.ID, name, remaining.Round(time.Second))
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: i18n.T(RequestLocale(i), "error.cooldown",
+						name, int(math.Ceil(remaining.Seconds()))),
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+			})
+			return
+		}
+
+		next(s, i)
+	}
+}
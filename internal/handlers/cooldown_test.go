@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCooldownLimiterAllow(t *testing.T) {
+	c := NewCooldownLimiter(nil)
+	if allowed, _ := c.Allow("summary:42", time.Minute); !allowed {
+		t.Fatal("first Allow() was refused")
+	}
+	allowed, remaining := c.Allow("summary:42", time.Minute)
+	if allowed || remaining <= 0 || remaining > time.Minute {
+		t.Errorf("second Allow() = %v, %s; want refused with up to a minute left", allowed, remaining)
+	}
+	if allowed, _ := c.Allow("summary:43", time.Minute); !allowed {
+		t.Error("Allow() for another user was refused")
+	}
+}
+
+func TestCooldownLimiterCoolingAndStart(t *testing.T) {
+	c := NewCooldownLimiter(nil)
+
+	// Checking doesn't start a window, so attempts that fail can be retried at once
+	for i := 0; i < 3; i++ {
+		if cooling, _ := c.Cooling("reaction:42", DefaultReactionCooldown); cooling {
+			t.Fatalf("Cooling() check %d = true before any Start()", i+1)
+		}
+	}
+
+	c.Start("reaction:42", DefaultReactionCooldown)
+	cooling, remaining := c.Cooling("reaction:42", DefaultReactionCooldown)
+	if !cooling || remaining <= 0 || remaining > DefaultReactionCooldown {
+		t.Errorf("Cooling() after Start() = %v, %s; want true with up to %s left", cooling, remaining, DefaultReactionCooldown)
+	}
+	if cooling, _ := c.Cooling("reaction:43", DefaultReactionCooldown); cooling {
+		t.Error("Cooling() for another user = true")
+	}
+
+	c.Start("reaction:44", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if cooling, _ := c.Cooling("reaction:44", time.Nanosecond); cooling {
+		t.Error("Cooling() after the window ended = true")
+	}
+}
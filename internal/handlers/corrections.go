@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// correctionService returns the correction service from the registry, or nil
+func (h *InteractionHandler) correctionService() *services.CorrectionService {
+	for _, svc := range h.services.GetServices() {
+		if cs, ok := svc.(*services.CorrectionService); ok {
+			return cs
+		}
+	}
+	return nil
+}
+
+// handleAdjustCommand handles /adjust, which adds penalty days to a member's challenge
+// or takes them off
+func (h *InteractionHandler) handleAdjustCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	correctionService := h.correctionService()
+	if correctionService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.corrections")))
+		return
+	}
+
+	var userID, reason string
+	var days int
+	var silent bool
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "user":
+			userID = option.UserValue(nil).ID
+		case "days":
+			days = int(option.IntValue())
+		case "reason":
+			reason = option.StringValue()
+		case "silent":
+			silent = option.BoolValue()
+		}
+	}
+	if days == 0 {
+		respondEphemeral(s, i, i18n.T(locale, "adjust.zero"))
+		return
+	}
+
+	adjustment, err := correctionService.AdjustDays(userID, i.Member.User.ID, days, reason, silent)
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		respondEphemeral(s, i, i18n.T(locale, "corrections.not_enrolled", userID))
+		return
+	case errors.Is(err, services.ErrTooManyDaysRemoved):
+		respondEphemeral(s, i, i18n.T(locale, "adjust.too_many", userID))
+		return
+	case err != nil:
+		RequestLogger(i).Error("Adjusting user_id=%s by %d days failed: %v", userID, days, err)
+		respondEphemeral(s, i, i18n.T(locale, "adjust.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Adjusted user_id=%s by %+d days (%s), now ending %s", userID, days, reason, adjustment.LastDate.Format("2006-01-02"))
+	key := "adjust.added"
+	if days < 0 {
+		key, days = "adjust.removed", -days
+	}
+	content := i18n.T(locale, key, days, userID, i18n.FormatDate(locale, adjustment.LastDate), adjustment.TotalAdded)
+	if silent {
+		content += i18n.T(locale, "adjust.silent")
+	}
+	respondEphemeral(s, i, content)
+}
+
+// handleSetStartCommand handles /setstart, which corrects the date a member's
+// challenge began and re-links their logs to the right days
+func (h *InteractionHandler) handleSetStartCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	correctionService := h.correctionService()
+	if correctionService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.corrections")))
+		return
+	}
+
+	var userID, rawDate, reason string
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "user":
+			userID = option.UserValue(nil).ID
+		case "date":
+			rawDate = option.StringValue()
+		case "reason":
+			reason = option.StringValue()
+		}
+	}
+	startDate, err := ParseDate("date", rawDate)
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+
+	change, err := correctionService.SetStartDate(userID, i.Member.User.ID, startDate, reason)
+	h.respondStartChange(s, i, userID, change, err)
+}
+
+// handleSetDayCommand handles /setday, which moves a member's start date so today
+// is the given challenge day
+func (h *InteractionHandler) handleSetDayCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	correctionService := h.correctionService()
+	if correctionService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.corrections")))
+		return
+	}
+
+	var userID, reason string
+	var day int
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "user":
+			userID = option.UserValue(nil).ID
+		case "day":
+			day = int(option.IntValue())
+		case "reason":
+			reason = option.StringValue()
+		}
+	}
+
+	change, err := correctionService.SetDay(userID, i.Member.User.ID, day, reason)
+	h.respondStartChange(s, i, userID, change, err)
+}
+
+// respondStartChange tells the admin how /setstart or /setday went
+func (h *InteractionHandler) respondStartChange(s discord.Session, i *discordgo.InteractionCreate, userID string, change services.StartChange, err error) {
+	locale := RequestLocale(i)
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		respondEphemeral(s, i, i18n.T(locale, "corrections.not_enrolled", userID))
+		return
+	case errors.Is(err, services.ErrLogsBeforeStart):
+		respondEphemeral(s, i, i18n.T(locale, "setstart.logs_before", userID, err))
+		return
+	case err != nil:
+		RequestLogger(i).Error("Moving start date for user_id=%s failed: %v", userID, err)
+		respondEphemeral(s, i, i18n.T(locale, "setstart.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Moved user_id=%s's start from %s to %s, re-linking %d rows", userID,
+		change.PreviousStart.Format("2006-01-02"), change.StartDate.Format("2006-01-02"), change.RowsMoved)
+	respondEphemeral(s, i, i18n.T(locale, "setstart.done", userID,
+		i18n.FormatDate(locale, change.PreviousStart), i18n.FormatDate(locale, change.StartDate),
+		change.ChallengeDay, i18n.FormatDate(locale, change.LastDate), change.RowsMoved))
+}
+
+// handleBackfillCommand handles /backfill, which records a feat a member did on a past
+// day, such as a workout they reported while Discord was down
+func (h *InteractionHandler) handleBackfillCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	correctionService := h.correctionService()
+	if correctionService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.corrections")))
+		return
+	}
+
+	var userID, feat, rawDate, reason string
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "user":
+			userID = option.UserValue(nil).ID
+		case "feat":
+			feat = option.StringValue()
+		case "date":
+			rawDate = option.StringValue()
+		case "reason":
+			reason = option.StringValue()
+		}
+	}
+	date, err := ParseDate("date", rawDate)
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+
+	featName := i18n.T(locale, "email.column_"+feat)
+	backfill, err := correctionService.Backfill(userID, i.Member.User.ID, feat, date, reason)
+	var dayErr *services.DayError
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		respondEphemeral(s, i, i18n.T(locale, "corrections.not_enrolled", userID))
+		return
+	case errors.As(err, &dayErr):
+		respondEphemeral(s, i, i18n.T(locale, "backfill.outside", i18n.FormatDate(locale, date), userID,
+			i18n.FormatDate(locale, dayErr.StartDate), i18n.FormatDate(locale, dayErr.LastDate)))
+		return
+	case errors.Is(err, services.ErrAlreadyLogged):
+		respondEphemeral(s, i, i18n.T(locale, "backfill.already_logged", featName, userID, backfill.ChallengeDay))
+		return
+	case err != nil:
+		RequestLogger(i).Error("Backfilling %s on %s for user_id=%s failed: %v", feat, rawDate, userID, err)
+		respondEphemeral(s, i, i18n.T(locale, "backfill.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Backfilled %s on day %d for user_id=%s (%s)", feat, backfill.ChallengeDay, userID, reason)
+	respondEphemeral(s, i, i18n.T(locale, "backfill.done", featName, userID, backfill.ChallengeDay, i18n.FormatDate(locale, date)))
+}
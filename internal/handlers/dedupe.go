@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/expiring"
+	"github.com/75-hard-discord-bot/internal/i18n"
+)
+
+// interactionIDTTL is how long handled interaction IDs are remembered. Discord stops
+// accepting responses to an interaction after 15 minutes, so it won't redeliver one later.
+const interactionIDTTL = 15 * time.Minute
+
+// DefaultRepeatWindow is how long after a button press or form submit the same one from
+// the same user is treated as a double click rather than a new action
+const DefaultRepeatWindow = 3 * time.Second
+
+// InteractionDeduper remembers recently handled interactions so redeliveries and double
+// clicks are only handled once
+type InteractionDeduper struct {
+	repeatWindow time.Duration
+	seen         *expiring.Map[string, struct{}] // Dedupe keys until they expire
+}
+
+// NewInteractionDeduper creates a deduper that treats repeats of a button press or form
+// submit within repeatWindow as duplicates
+func NewInteractionDeduper(repeatWindow time.Duration) *InteractionDeduper {
+	return &InteractionDeduper{
+		repeatWindow: repeatWindow,
+		seen:         expiring.New[string, struct{}](),
+	}
+}
+
+// claim records key for ttl, reporting false when it's already recorded
+func (d *InteractionDeduper) claim(key string, ttl time.Duration) bool {
+	_, seen := d.seen.LoadOrStore(key, struct{}{}, ttl)
+	return !seen
+}
+
+// repeatKey identifies a button press or form submit by who made it and what it was for,
+// so two clicks on the same button share a key; commands have none, since running one
+// twice is deliberate
+func repeatKey(i *discordgo.InteractionCreate) string {
+	user := InteractionUser(i)
+	if user == nil {
+		return ""
+	}
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		messageID := ""
+		if i.Message != nil {
+			messageID = i.Message.ID
+		}
+		return "component:" + user.ID + ":" + messageID + ":" + i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return "modal:" + user.ID + ":" + i.ModalSubmitData().CustomID
+	}
+	return ""
+}
+
+// Middleware handles each interaction once. A redelivered interaction is dropped, as
+// its first delivery was already answered. A repeat of a button press within the repeat
+// window is acknowledged without changing anything, and a repeated form submit gets an
+// ephemeral note, so a double click can't log water twice or start two challenges.
+func (d *InteractionDeduper) Middleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		if !d.claim("interaction:"+i.ID, interactionIDTTL) {
+			RequestLogger(i).Info("Dropped redelivered interaction %s", i.ID)
+			return
+		}
+
+		key := repeatKey(i)
+		if key == "" || d.claim(key, d.repeatWindow) {
+			next(s, i)
+			return
+		}
+
+		RequestLogger(i).Info("Ignored repeated %s within %s", RouteName(i), d.repeatWindow)
+		response := &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredMessageUpdate}
+		if i.Type == discordgo.InteractionModalSubmit {
+			response = &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: i18n.T(RequestLocale(i), "error.duplicate_submit"),
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			}
+		}
+		s.InteractionRespond(i.Interaction, response)
+	}
+}
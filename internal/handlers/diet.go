@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// maxMealPhotoBytes caps /diet meal photos; phone photos are a few MB
+const maxMealPhotoBytes = 10 << 20
+
+// mealService returns the meal service from the registry, or nil
+func (h *InteractionHandler) mealService() *services.MealService {
+	for _, svc := range h.services.GetServices() {
+		if ms, ok := svc.(*services.MealService); ok {
+			return ms
+		}
+	}
+	return nil
+}
+
+// handleDietCommand handles /diet, whose meal subcommand logs what the user ate with
+// an optional photo
+func (h *InteractionHandler) handleDietCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	username := i.Member.User.Username
+	locale := RequestLocale(i)
+
+	mealService := h.mealService()
+	if mealService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.meals")))
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	var description string
+	var attachment *discordgo.MessageAttachment
+	for _, option := range data.Options[0].Options {
+		switch option.Name {
+		case "description":
+			description = option.StringValue()
+		case "photo":
+			attachment = data.Resolved.Attachments[option.Value.(string)]
+		}
+	}
+	if attachment != nil {
+		if !strings.HasPrefix(attachment.ContentType, "image/") {
+			respondEphemeral(s, i, i18n.T(locale, "diet.not_image", attachment.Filename))
+			return
+		}
+		if !mealService.CanStorePhotos() {
+			respondEphemeral(s, i, i18n.T(locale, "diet.photo_unavailable"))
+			return
+		}
+	}
+
+	// Downloading and storing a photo can take longer than the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	content, meal := h.logMeal(i, mealService, description, attachment)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing meal response: %v", err)
+	}
+	if meal != nil {
+		key := "diet.forum_meal"
+		if meal.PhotoKey != "" {
+			key = "diet.forum_meal_photo"
+		}
+		h.forum.Post(s, i.GuildID, userID, username, i18n.T(GuildLocale(i), key, meal.Description))
+	}
+}
+
+// logMeal downloads the photo, if any, and records the meal, describing the result;
+// the meal is returned only when it was recorded
+func (h *InteractionHandler) logMeal(i *discordgo.InteractionCreate, mealService *services.MealService, description string, attachment *discordgo.MessageAttachment) (string, *services.Meal) {
+	locale := RequestLocale(i)
+
+	var photo *services.MealPhoto
+	if attachment != nil {
+		var file bytes.Buffer
+		if err := discord.DownloadAttachment(attachment, maxMealPhotoBytes, &file); err != nil {
+			return i18n.T(locale, "diet.error", err), nil
+		}
+		photo = &services.MealPhoto{Filename: attachment.Filename, ContentType: attachment.ContentType, Data: file.Bytes()}
+	}
+
+	meal, err := mealService.LogMeal(i.Member.User.ID, i.Member.User.Username, description, photo)
+	if errors.Is(err, services.ErrPhotosUnavailable) {
+		return i18n.T(locale, "diet.photo_unavailable"), nil
+	}
+	if err != nil {
+		RequestLogger(i).Error("Logging meal failed: %v", err)
+		return writeErrorMessage(locale, "diet.error", err), nil
+	}
+
+	RequestLogger(i).Info("Logged meal for day %d (photo: %t)", meal.ChallengeDay, meal.PhotoKey != "")
+	content := i18n.T(locale, "diet.meal_logged", meal.ChallengeDay, meal.Description)
+	if meal.PhotoKey != "" {
+		content += i18n.T(locale, "diet.meal_photo")
+	}
+	return content, &meal
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// editService returns the edit service from the registry, or nil
+func (h *InteractionHandler) editService() *services.EditService {
+	for _, svc := range h.services.GetServices() {
+		if es, ok := svc.(*services.EditService); ok {
+			return es
+		}
+	}
+	return nil
+}
+
+// handleEditCommand handles /edit, which opens a form prefilled with what's logged for
+// a recent day so the member can correct it
+func (h *InteractionHandler) handleEditCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	editService := h.editService()
+	if editService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.edit")))
+		return
+	}
+
+	day := int(i.ApplicationCommandData().Options[0].IntValue())
+	values, err := editService.GetDay(userID, day)
+	if err != nil {
+		respondEphemeral(s, i, editErrorMessage(locale, editService, err))
+		return
+	}
+
+	// Water is entered in the user's units; four places keep liters from drifting
+	// when the form is saved unchanged
+	units := h.userUnits(i)
+	water := math.Round(units.FromOunces(values.WaterOunces)*10000) / 10000
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: CustomID("edit_day", strconv.Itoa(day)),
+			Title:    i18n.T(locale, "edit.modal_title", day),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "workout_minutes",
+							Label:     i18n.T(locale, "edit.field.workout", services.MinWorkoutMinutes),
+							Style:     discordgo.TextInputShort,
+							Value:     strconv.Itoa(values.WorkoutMinutes),
+							Required:  true,
+							MaxLength: 4,
+						},
+					},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "water",
+							Label:     i18n.T(locale, "edit.field.water", i18n.T(locale, "unit."+units.Volume)),
+							Style:     discordgo.TextInputShort,
+							Value:     strconv.FormatFloat(water, 'f', -1, 64),
+							Required:  true,
+							MaxLength: 10,
+						},
+					},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "diet",
+							Label:     i18n.T(locale, "edit.field.diet"),
+							Style:     discordgo.TextInputShort,
+							Value:     yesNo(locale, values.DietFollowed),
+							Required:  true,
+							MaxLength: 5,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		RequestLogger(i).Error("Error showing edit modal: %v", err)
+	}
+}
+
+// handleEditModal saves a day corrected in the /edit form
+func (h *InteractionHandler) handleEditModal(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	editService := h.editService()
+	if editService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.edit")))
+		return
+	}
+
+	_, args := ParseCustomID(i.ModalSubmitData().CustomID)
+	day := 0
+	if len(args) == 1 {
+		day, _ = strconv.Atoi(args[0])
+	}
+	if day < 1 {
+		RequestLogger(i).Error("Malformed edit modal CustomID: %s", i.ModalSubmitData().CustomID)
+		return
+	}
+
+	fields := ParseModalFields(i.ModalSubmitData())
+	units := h.userUnits(i)
+	volumeUnit := i18n.T(locale, "unit."+units.Volume)
+
+	workoutField := i18n.T(locale, "edit.field.workout", services.MinWorkoutMinutes)
+	minutes, err := fields.Int("workout_minutes", workoutField)
+	if err == nil && minutes != 0 {
+		err = RequireAtLeast(workoutField, minutes, services.MinWorkoutMinutes, i18n.T(locale, "unit.minutes"))
+	}
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+
+	waterField := i18n.T(locale, "edit.field.water", volumeUnit)
+	water, err := fields.Float("water", waterField)
+	if err == nil && water < 0 {
+		err = invalid(waterField, "validation.at_least", waterField, 0, volumeUnit)
+	}
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+
+	diet, err := ParseYesNo(i18n.T(locale, "edit.field.diet"), fields["diet"])
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+
+	values := services.DayValues{
+		WorkoutMinutes: minutes,
+		WaterOunces:    units.ToOunces(water),
+		DietFollowed:   diet,
+	}
+	before, after, err := editService.EditDay(userID, day, values)
+	if err != nil {
+		RequestLogger(i).Error("Edit of day %d failed: %v", day, err)
+		respondEphemeral(s, i, editErrorMessage(locale, editService, err))
+		return
+	}
+	if before == after {
+		respondEphemeral(s, i, i18n.T(locale, "edit.unchanged", day))
+		return
+	}
+
+	RequestLogger(i).Info("Edited day %d: %+v -> %+v", day, before, after)
+	respondEphemeral(s, i, i18n.T(locale, "edit.saved", day,
+		after.WorkoutMinutes, units.FromOunces(after.WaterOunces), volumeUnit, yesNo(locale, after.DietFollowed)))
+}
+
+// editErrorMessage explains why a day couldn't be loaded or edited
+func editErrorMessage(locale string, editService *services.EditService, err error) string {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return i18n.T(locale, "edit.not_started")
+	case errors.Is(err, services.ErrEditWindowClosed):
+		return i18n.T(locale, "edit.window_closed", int(editService.Window().Hours()))
+	}
+	return writeErrorMessage(locale, "edit.error", err)
+}
+
+// yesNo returns the localized yes or no /edit shows for value
+func yesNo(locale string, value bool) string {
+	if value {
+		return i18n.T(locale, "edit.yes")
+	}
+	return i18n.T(locale, "edit.no")
+}
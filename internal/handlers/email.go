@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/email"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleEmailCommand handles the /email slash command, which manages the address
+// weekly reports are emailed to
+func (h *InteractionHandler) handleEmailCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get email report and user services from registry
+	var reportService *services.EmailReportService
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		switch s := svc.(type) {
+		case *services.EmailReportService:
+			reportService = s
+		case *services.UserService:
+			userService = s
+		}
+	}
+
+	if reportService == nil || userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.email")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Sending mail can outlast the interaction deadline, so reply once it's done
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var content string
+	switch subcommand.Name {
+	case "add":
+		address, err := email.NormalizeAddress(subcommand.Options[0].StringValue())
+		if err != nil {
+			content = i18n.T(locale, "email.invalid", err)
+			break
+		}
+		// Reports follow the user's challenge days
+		if _, err := userService.GetProgress(userID); errors.Is(err, services.ErrUserNotFound) {
+			content = i18n.T(locale, "email.not_started")
+			break
+		} else if err != nil {
+			content = i18n.T(locale, "email.error", err)
+			break
+		}
+
+		address, err = reportService.Add(userID, address, locale)
+		if errors.Is(err, services.ErrVerificationTooSoon) {
+			content = i18n.T(locale, "email.too_soon")
+			break
+		}
+		if err != nil {
+			RequestLogger(i).Error("Failed to email verification code: %v", err)
+			content = i18n.T(locale, "email.error", err)
+			break
+		}
+		RequestLogger(i).Info("Verification code emailed for user_id=%s", userID)
+		content = i18n.T(locale, "email.sent", address, int(services.VerificationCodeExpiry.Minutes()))
+
+	case "verify":
+		code := strings.ReplaceAll(subcommand.Options[0].StringValue(), " ", "")
+		err := reportService.Verify(userID, code)
+		switch {
+		case errors.Is(err, services.ErrEmailNotFound):
+			content = i18n.T(locale, "email.none")
+		case errors.Is(err, services.ErrVerificationCodeInvalid):
+			content = i18n.T(locale, "email.wrong_code")
+		case errors.Is(err, services.ErrVerificationCodeExpired):
+			content = i18n.T(locale, "email.expired")
+		case err != nil:
+			content = i18n.T(locale, "email.error", err)
+		default:
+			RequestLogger(i).Info("Email address verified for user_id=%s", userID)
+			content = i18n.T(locale, "email.verified")
+		}
+
+	case "send":
+		err := reportService.Send(userID)
+		if errors.Is(err, services.ErrEmailNotVerified) {
+			content = i18n.T(locale, "email.none")
+			break
+		}
+		if err != nil {
+			RequestLogger(i).Error("Failed to email report: %v", err)
+			content = i18n.T(locale, "email.error", err)
+			break
+		}
+		RequestLogger(i).Info("Report emailed on request for user_id=%s", userID)
+		content = i18n.T(locale, "email.report_sent")
+
+	case "remove":
+		removed, err := reportService.Remove(userID)
+		if err != nil {
+			content = i18n.T(locale, "email.error", err)
+			break
+		}
+		if !removed {
+			content = i18n.T(locale, "email.none")
+			break
+		}
+		RequestLogger(i).Info("Email address removed for user_id=%s", userID)
+		content = i18n.T(locale, "email.removed")
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing email response: %v", err)
+	}
+}
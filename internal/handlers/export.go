@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleExportCommand handles the /export slash command (admin only), which exports
+// every completion in a date range as a zip of CSVs
+func (h *InteractionHandler) handleExportCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get export service from registry
+	var exportService *services.ExportService
+	for _, svc := range h.services.GetServices() {
+		if es, ok := svc.(*services.ExportService); ok {
+			exportService = es
+			break
+		}
+	}
+
+	if exportService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.export")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var rawFrom, rawTo, userID string
+	for _, option := range subcommand.Options {
+		switch option.Name {
+		case "from":
+			rawFrom = option.StringValue()
+		case "to":
+			rawTo = option.StringValue()
+		case "user":
+			userID = option.UserValue(nil).ID
+		}
+	}
+
+	from, err := ParseDate("from", rawFrom)
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+	to, err := ParseDate("to", rawTo)
+	if err != nil {
+		respondModalError(s, i, err)
+		return
+	}
+	if to.Before(from) {
+		respondModalError(s, i, errors.New(i18n.T(locale, "admin_export.range", rawFrom, rawTo)))
+		return
+	}
+
+	// Reading every table and zipping can take longer than the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	respond := func(edit *discordgo.WebhookEdit) {
+		if _, err := s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+			RequestLogger(i).Error("Error editing export response: %v", err)
+		}
+	}
+	respondText := func(content string) {
+		respond(&discordgo.WebhookEdit{Content: &content})
+	}
+
+	RequestLogger(i).Info("Completion export from %s to %s (user_id=%q) requested by user_id=%s", rawFrom, rawTo, userID, i.Member.User.ID)
+	export, err := exportService.ExportCompletions(from, to, userID)
+	if err != nil {
+		respondText(i18n.T(locale, "export.error", err))
+		return
+	}
+	data, err := export.ToZip()
+	if err != nil {
+		respondText(i18n.T(locale, "export.format_error", err))
+		return
+	}
+
+	filename := fmt.Sprintf("completions-%s-to-%s.zip", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if userID != "" {
+		filename = fmt.Sprintf("completions-%s-%s-to-%s.zip", userID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	if len(data) <= discord.MaxUploadBytes {
+		content := i18n.T(locale, "admin_export.attached", export.RowCount(), rawFrom, rawTo)
+		respond(&discordgo.WebhookEdit{
+			Content: &content,
+			Files: []*discordgo.File{
+				{
+					Name:        filename,
+					ContentType: "application/zip",
+					Reader:      bytes.NewReader(data),
+				},
+			},
+		})
+		return
+	}
+
+	// Too large for Discord, so it goes to the backup bucket behind a temporary link
+	if !exportService.CanUpload() {
+		respondText(i18n.T(locale, "admin_export.too_large", len(data)>>20, discord.MaxUploadBytes>>20))
+		return
+	}
+	link, err := exportService.Upload(export.GeneratedAt.Format("20060102-150405")+"-"+filename, data, "application/zip")
+	if err != nil {
+		RequestLogger(i).Error("Failed to upload completion export: %v", err)
+		respondText(i18n.T(locale, "admin_export.upload_failed", err))
+		return
+	}
+	respondText(i18n.T(locale, "admin_export.uploaded", export.RowCount(), rawFrom, rawTo, link))
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// FeatureRoutes maps routes to the feature flag that must be on to use them
+var FeatureRoutes = map[string]string{
+	"leaderboard": services.FeatureLeaderboards,
+	"email":       services.FeatureEmailReports,
+	"coach":       services.FeatureCoach,
+	"adjust":      services.FeaturePenalties,
+
+	"natlog_confirm": services.FeatureNaturalLogging,
+}
+
+// FeatureGate turns away interactions for features a guild has switched off
+type FeatureGate struct {
+	services *services.ServiceRegistry
+	routes   map[string]string
+}
+
+// NewFeatureGate creates a gate for the given route -> feature mapping
+func NewFeatureGate(serviceRegistry *services.ServiceRegistry, routes map[string]string) *FeatureGate {
+	return &FeatureGate{
+		services: serviceRegistry,
+		routes:   routes,
+	}
+}
+
+// Middleware rejects gated routes whose feature is disabled in the interaction's guild
+func (g *FeatureGate) Middleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		feature, gated := g.routes[RouteName(i)]
+		if !gated || featureEnabled(g.services, i.GuildID, feature) {
+			next(s, i)
+			return
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(RequestLocale(i), "error.feature_disabled", feature),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+}
+
+// featureEnabled checks a guild's feature flag; features are on when flags aren't available
+func featureEnabled(serviceRegistry *services.ServiceRegistry, guildID, feature string) bool {
+	for _, svc := range serviceRegistry.GetServices() {
+		if fs, ok := svc.(*services.FeatureFlagService); ok {
+			return fs.IsEnabled(guildID, feature)
+		}
+	}
+	return services.FeatureDefaults[feature]
+}
+
+// handleFeaturesCommand handles the /features slash command (admin only)
+func (h *InteractionHandler) handleFeaturesCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get feature flag service from registry
+	var featureService *services.FeatureFlagService
+	for _, svc := range h.services.GetServices() {
+		if fs, ok := svc.(*services.FeatureFlagService); ok {
+			featureService = fs
+			break
+		}
+	}
+
+	if featureService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.features")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var content string
+
+	switch subcommand.Name {
+	case "list":
+		flags, err := featureService.Flags(i.GuildID)
+		if err != nil {
+			content = i18n.T(locale, "features.error_load", err)
+			break
+		}
+
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "features.title"))
+		for _, name := range names {
+			state := i18n.T(locale, "features.on")
+			if !flags[name] {
+				state = i18n.T(locale, "features.off")
+			}
+			message.WriteString(i18n.T(locale, "features.entry", name, state))
+		}
+		content = message.String()
+
+	case "enable", "disable":
+		feature := subcommand.Options[0].StringValue()
+		enabled := subcommand.Name == "enable"
+		if err := featureService.SetEnabled(i.GuildID, feature, enabled, i.Member.User.ID); err != nil {
+			content = i18n.T(locale, "features.error_update", err)
+			break
+		}
+
+		RequestLogger(i).Info("Feature %s set to %t in guild_id=%s by user_id=%s", feature, enabled, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "features."+subcommand.Name+"d", feature)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
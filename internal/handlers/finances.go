@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// financeService returns the finance service from the registry, or nil
+func (h *InteractionHandler) financeService() *services.FinanceService {
+	for _, svc := range h.services.GetServices() {
+		if fs, ok := svc.(*services.FinanceService); ok {
+			return fs
+		}
+	}
+	return nil
+}
+
+// handleFinancesCommand handles /finances: purchase logs spending, where a purchase
+// that wasn't necessary makes the day's finances non-compliant, and summary shows the
+// week's spending
+func (h *InteractionHandler) handleFinancesCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	financeService := h.financeService()
+	if financeService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.finances")))
+		return
+	}
+
+	if i.ApplicationCommandData().Options[0].Name == "summary" {
+		h.handleFinancesSummary(s, i, financeService)
+		return
+	}
+
+	var amount float64
+	var category string
+	var necessary bool
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "amount":
+			amount = option.FloatValue()
+		case "category":
+			category = option.StringValue()
+		case "necessary":
+			necessary = option.BoolValue()
+		}
+	}
+
+	purchase, err := financeService.LogPurchase(i.Member.User.ID, i.Member.User.Username, amount, category, necessary)
+	if err != nil {
+		RequestLogger(i).Error("Logging purchase failed: %v", err)
+		respondEphemeral(s, i, writeErrorMessage(locale, "finances.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Logged %.2f purchase (%s, necessary: %t) for day %d", purchase.Amount, purchase.Category, purchase.Necessary, purchase.ChallengeDay)
+	if purchase.Necessary {
+		respondEphemeral(s, i, i18n.T(locale, "finances.purchase_logged", purchase.Amount, purchase.Category, purchase.ChallengeDay))
+		return
+	}
+	respondEphemeral(s, i, i18n.T(locale, "finances.purchase_non_compliant", purchase.Amount, purchase.Category, purchase.ChallengeDay))
+}
+
+// handleFinancesSummary handles /finances summary, which shows the user's spending for
+// the last seven days
+func (h *InteractionHandler) handleFinancesSummary(s discord.Session, i *discordgo.InteractionCreate, financeService *services.FinanceService) {
+	locale := RequestLocale(i)
+
+	spend, err := financeService.WeeklySpend(i.Member.User.ID)
+	if errors.Is(err, services.ErrUserNotFound) {
+		respondEphemeral(s, i, i18n.T(locale, "import.not_started"))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Loading weekly spending failed: %v", err)
+		respondEphemeral(s, i, i18n.T(locale, "finances.summary_error", err))
+		return
+	}
+	if spend.Purchases == 0 {
+		respondEphemeral(s, i, i18n.T(locale, "finances.week_none", i18n.FormatDate(locale, spend.From), i18n.FormatDate(locale, spend.To)))
+		return
+	}
+	respondEphemeral(s, i, services.FormatWeeklySpend(locale, spend))
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// forumArchiveMinutes is the longest auto-archive Discord allows (7 days); posting unarchives it
+const forumArchiveMinutes = 10080
+
+// ForumPublisher mirrors each participant's logs into their own post in a forum channel.
+// A nil publisher, or one without a channel, is forum mode turned off.
+type ForumPublisher struct {
+	mu          sync.RWMutex
+	channelID   string
+	services    *services.ServiceRegistry
+	guildLocale func(guildID string) string
+}
+
+// NewForumPublisher creates a publisher for the forum channel (empty disables forum mode).
+// guildLocale picks the language new posts are titled in.
+func NewForumPublisher(channelID string, serviceRegistry *services.ServiceRegistry, guildLocale func(guildID string) string) *ForumPublisher {
+	return &ForumPublisher{
+		channelID:   channelID,
+		services:    serviceRegistry,
+		guildLocale: guildLocale,
+	}
+}
+
+// Enabled reports whether forum-channel mode is configured
+func (p *ForumPublisher) Enabled() bool {
+	return p != nil && p.channel() != ""
+}
+
+// SetChannel switches the forum channel live; existing posts in the old channel are left as-is
+func (p *ForumPublisher) SetChannel(channelID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.channelID = channelID
+}
+
+// channel returns the current forum channel ID
+func (p *ForumPublisher) channel() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.channelID
+}
+
+// Post appends content to the user's progress post, creating the post on first use.
+// Failures are logged rather than surfaced, since the log itself was already recorded.
+func (p *ForumPublisher) Post(s discord.Session, guildID, userID, username, content string) {
+	if !p.Enabled() || !featureEnabled(p.services, guildID, services.FeatureForum) {
+		return
+	}
+
+	var forumService *services.ForumService
+	for _, svc := range p.services.GetServices() {
+		if fs, ok := svc.(*services.ForumService); ok {
+			forumService = fs
+			break
+		}
+	}
+	if forumService == nil {
+		return
+	}
+
+	if err := p.post(s, forumService, p.guildLocale(guildID), userID, username, content); err != nil {
+		logger.Error("Failed to post to forum for user_id=%s: %v", userID, err)
+	}
+}
+
+// post sends content to the stored thread, recreating the post if it was deleted
+func (p *ForumPublisher) post(s discord.Session, forumService *services.ForumService, locale, userID, username, content string) error {
+	channelID := p.channel()
+	threadID, err := forumService.GetThreadID(userID, channelID)
+	if err != nil {
+		return err
+	}
+
+	if threadID != "" {
+		_, err := s.ChannelMessageSend(threadID, content)
+		if err == nil {
+			return nil
+		}
+		var restErr *discordgo.RESTError
+		if !errors.As(err, &restErr) || restErr.Response == nil || restErr.Response.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to post to thread %s: %w", threadID, err)
+		}
+		logger.Info("Forum post for user_id=%s was deleted - creating a new one", userID)
+	}
+
+	thread, err := s.ForumThreadStart(channelID, i18n.T(locale, "forum.thread_title", username), forumArchiveMinutes, content)
+	if err != nil {
+		return fmt.Errorf("failed to create forum post: %w", err)
+	}
+	logger.Info("🧵 Created forum progress post for %s (thread_id=%s)", username, thread.ID)
+	return forumService.SetThreadID(userID, channelID, thread.ID)
+}
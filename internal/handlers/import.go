@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/applehealth"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// maxHealthExportBytes caps Apple Health uploads; export.zip is compressed well, so
+// even years of data fit
+const maxHealthExportBytes = 100 << 20
+
+// maxHistoryCSVBytes caps /import csv uploads, far above a challenge's worth of rows
+const maxHistoryCSVBytes = 1 << 20
+
+// handleImportCommand handles the /import slash command, which backfills the user's
+// challenge from another app's data or a CSV of past days
+func (h *InteractionHandler) handleImportCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get import services from registry
+	var healthImport *services.HealthImportService
+	var historyImport *services.HistoryImportService
+	for _, svc := range h.services.GetServices() {
+		switch is := svc.(type) {
+		case *services.HealthImportService:
+			healthImport = is
+		case *services.HistoryImportService:
+			historyImport = is
+		}
+	}
+
+	data := i.ApplicationCommandData()
+	subcommand := data.Options[0]
+	if (subcommand.Name == "apple-health" && healthImport == nil) || (subcommand.Name == "csv" && historyImport == nil) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.import")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Downloading and parsing an export can take longer than the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	// A CSV import only previews unless dry-run is turned off
+	var attachment *discordgo.MessageAttachment
+	dryRun := true
+	for _, option := range subcommand.Options {
+		switch option.Name {
+		case "file":
+			attachment = data.Resolved.Attachments[option.Value.(string)]
+		case "dry-run":
+			dryRun = option.BoolValue()
+		}
+	}
+
+	var content string
+	switch subcommand.Name {
+	case "apple-health":
+		content = h.importAppleHealth(i, healthImport, userID, attachment)
+	case "csv":
+		content = h.importHistoryCSV(i, historyImport, userID, attachment, dryRun)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing import response: %v", err)
+	}
+}
+
+// importAppleHealth downloads and imports an Apple Health export, describing the result
+func (h *InteractionHandler) importAppleHealth(i *discordgo.InteractionCreate, importService *services.HealthImportService, userID string, attachment *discordgo.MessageAttachment) string {
+	locale := RequestLocale(i)
+
+	// export.xml can be far larger than the zip, so it's streamed from disk
+	file, err := os.CreateTemp("", "apple-health-*")
+	if err != nil {
+		return i18n.T(locale, "import.error", err)
+	}
+	defer os.Remove(file.Name())
+	err = discord.DownloadAttachment(attachment, maxHealthExportBytes, file)
+	file.Close()
+	if err != nil {
+		return i18n.T(locale, "import.error", err)
+	}
+
+	export, err := applehealth.ParseFile(file.Name())
+	if err != nil {
+		return i18n.T(locale, "import.invalid", err)
+	}
+
+	summary, err := importService.Import(userID, export)
+	if errors.Is(err, services.ErrUserNotFound) {
+		return i18n.T(locale, "import.not_started")
+	}
+	if err != nil {
+		RequestLogger(i).Error("Apple Health import failed: %v", err)
+		return i18n.T(locale, "import.error", err)
+	}
+
+	RequestLogger(i).Info("Apple Health import for user_id=%s: %d workouts, %d water days, %d weigh-ins",
+		userID, summary.WorkoutDays, summary.WaterDays, summary.WeighIns)
+	return i18n.T(locale, "import.apple_health", summary.WorkoutDays, summary.WaterDays, summary.WeighIns,
+		summary.Kept, summary.Short, services.MinWorkoutMinutes, summary.Outside, summary.Skipped)
+}
+
+// importHistoryCSV downloads and imports (or previews) a history CSV, describing the result
+func (h *InteractionHandler) importHistoryCSV(i *discordgo.InteractionCreate, importService *services.HistoryImportService, userID string, attachment *discordgo.MessageAttachment, dryRun bool) string {
+	locale := RequestLocale(i)
+
+	var file bytes.Buffer
+	if err := discord.DownloadAttachment(attachment, maxHistoryCSVBytes, &file); err != nil {
+		return i18n.T(locale, "import.error", err)
+	}
+
+	// Problems in the file and days outside the challenge are reported the same way
+	days, err := services.ParseHistoryCSV(&file)
+	var summary services.HistoryImportSummary
+	if err == nil {
+		summary, err = importService.Import(userID, days, dryRun)
+	}
+	var problems services.ImportProblems
+	if errors.As(err, &problems) {
+		return i18n.T(locale, "import.csv_invalid", "• "+strings.Join(problems, "\n• "))
+	}
+	if errors.Is(err, services.ErrUserNotFound) {
+		return i18n.T(locale, "import.not_started")
+	}
+	if err != nil {
+		RequestLogger(i).Error("History CSV import failed: %v", err)
+		return i18n.T(locale, "import.error", err)
+	}
+
+	RequestLogger(i).Info("History CSV import for user_id=%s (dry_run=%t): %d days, %d feats added, %d kept, %d check-ins",
+		userID, dryRun, summary.Days, summary.Feats, summary.Kept, summary.CheckIns)
+	key := "import.csv"
+	if dryRun {
+		key = "import.csv_preview"
+	}
+	return i18n.T(locale, key, summary.Days, summary.First.Format("2006-01-02"), summary.Last.Format("2006-01-02"),
+		summary.Feats, summary.Kept, summary.CheckIns)
+}
@@ -1,59 +1,87 @@
 package handlers
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/discord/ui"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/services"
 )
 
 // InteractionHandler handles slash command interactions
 type InteractionHandler struct {
-	services *services.ServiceRegistry
+	services     *services.ServiceRegistry
+	forum        *ForumPublisher
+	runtimeStats func() RuntimeStats
 }
 
-// NewInteractionHandler creates a new interaction handler
-func NewInteractionHandler(serviceRegistry *services.ServiceRegistry) *InteractionHandler {
+// NewInteractionHandler creates a new interaction handler; forum mirrors logs to progress posts
+func NewInteractionHandler(serviceRegistry *services.ServiceRegistry, forum *ForumPublisher) *InteractionHandler {
 	return &InteractionHandler{
 		services: serviceRegistry,
+		forum:    forum,
 	}
 }
 
-// HandleSlashCommand routes slash commands to appropriate handlers
-func (h *InteractionHandler) HandleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	cmdName := i.ApplicationCommandData().Name
-
-	switch cmdName {
-	case "exercise":
-		h.handleExerciseCommand(s, i)
-	case "summary":
-		h.handleSummaryCommand(s, i)
-	case "weighin":
-		h.handleWeighInCommand(s, i)
-	case "start":
-		h.handleStartCommand(s, i)
-	case "water":
-		h.handleWaterCommand(s, i)
-	default:
-		logger.Error("Unknown command: %s", cmdName)
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Unknown command: %s", cmdName),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-	}
+// RegisterRoutes registers slash command and button handlers with the router
+func (h *InteractionHandler) RegisterRoutes(r *Router) {
+	r.Command("exercise", h.handleExerciseCommand)
+	r.Command("diet", h.handleDietCommand)
+	r.Command("finances", h.handleFinancesCommand)
+	r.Command("reading", h.handleReadingCommand)
+	r.Command("selfimprovement", h.handleSelfImprovementCommand)
+	r.Command("summary", h.handleSummaryCommand)
+	r.Command("leaderboard", h.handleLeaderboardCommand)
+	r.Command("weighin", h.handleWeighInCommand)
+	r.Command("start", h.handleStartCommand)
+	r.Command("water", h.handleWaterCommand)
+	r.Command("deletemydata", h.handleDeleteMyDataCommand)
+	r.Command("exportmydata", h.handleExportMyDataCommand)
+	r.Command("backup", h.handleBackupCommand)
+	r.Command("export", h.handleExportCommand)
+	r.Command("features", h.handleFeaturesCommand)
+	r.Command("settings", h.handleSettingsCommand)
+	r.Command("botstats", h.handleBotStatsCommand)
+	r.Command("config", h.handleConfigCommand)
+	r.Command("preferences", h.handlePreferencesCommand)
+	r.Command("shortcut", h.handleShortcutCommand)
+	r.Command("connect", h.handleConnectCommand)
+	r.Command("disconnect", h.handleDisconnectCommand)
+	r.Command("import", h.handleImportCommand)
+	r.Command("calendar", h.handleCalendarCommand)
+	r.Command("sms", h.handleSMSCommand)
+	r.Command("email", h.handleEmailCommand)
+	r.Command("coach", h.handleCoachCommand)
+	r.Command("undo", h.handleUndoCommand)
+	r.Command("edit", h.handleEditCommand)
+	r.Command("adjust", h.handleAdjustCommand)
+	r.Command("setstart", h.handleSetStartCommand)
+	r.Command("setday", h.handleSetDayCommand)
+	r.Command("backfill", h.handleBackfillCommand)
+
+	r.Component("start_confirm", h.handleStartConfirmation)
+	r.Component("start_cancel", h.handleStartCancel)
+	r.Component("deletemydata_confirm", h.handleDeleteMyDataConfirmation)
+	r.Component("deletemydata_cancel", h.handleDeleteMyDataCancel)
+	r.Component("natlog_confirm", h.handleNaturalLogConfirm)
+	r.Component("natlog_dismiss", h.handleNaturalLogDismiss)
+	r.Component("workout_stop", h.handleWorkoutTimerStop)
+	r.Component("workout_cancel", h.handleWorkoutTimerCancel)
+
+	r.Modal("config_template", h.handleConfigTemplateModal)
+	r.Modal("edit_day", h.handleEditModal)
+	r.Modal("workout_timer", h.handleWorkoutTimerModal)
 }
 
 // handleExerciseCommand handles the /exercise slash command
-func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *InteractionHandler) handleExerciseCommand(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
+	locale := RequestLocale(i)
 
 	// Get exercise service from registry
 	var exerciseService *services.ExerciseService
@@ -68,7 +96,7 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Exercise service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.exercise")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -84,7 +112,7 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("❌ Error logging exercise: %v", err),
+					Content: writeErrorMessage(locale, "exercise.error", err),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
@@ -94,26 +122,28 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "✅ **Exercise logged!**\n" +
-					"Workout: 30 minutes\n" +
-					"Core/Mobility: 10 minutes\n\n" +
-					"Use `/exercise detailed` for custom durations.",
-				Flags: discordgo.MessageFlagsEphemeral,
+				Content: i18n.T(locale, "exercise.quick_logged"),
+				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
+		h.forum.Post(s, i.GuildID, userID, username, i18n.T(GuildLocale(i), "exercise.forum_quick"))
+	} else if subcommand == "upload" {
+		h.handleExerciseUpload(s, i, exerciseService)
+	} else if subcommand == "start" {
+		h.handleExerciseStart(s, i)
 	} else if subcommand == "detailed" {
 		// Show modal for detailed input
 		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseModal,
 			Data: &discordgo.InteractionResponseData{
 				CustomID: "exercise_modal",
-				Title:    "Log Exercise",
+				Title:    i18n.T(locale, "exercise.modal.title"),
 				Components: []discordgo.MessageComponent{
 					discordgo.ActionsRow{
 						Components: []discordgo.MessageComponent{
 							discordgo.TextInput{
 								CustomID:    "workout_duration",
-								Label:       "Workout Duration (minutes)",
+								Label:       i18n.T(locale, "exercise.modal.workout_duration"),
 								Style:       discordgo.TextInputShort,
 								Placeholder: "30",
 								Required:    true,
@@ -126,9 +156,9 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 						Components: []discordgo.MessageComponent{
 							discordgo.TextInput{
 								CustomID:    "workout_type",
-								Label:       "Workout Type",
+								Label:       i18n.T(locale, "exercise.modal.workout_type"),
 								Style:       discordgo.TextInputShort,
-								Placeholder: "e.g., running, weights, cycling",
+								Placeholder: i18n.T(locale, "exercise.modal.workout_type_placeholder"),
 								Required:    false,
 								MaxLength:   50,
 							},
@@ -138,9 +168,9 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 						Components: []discordgo.MessageComponent{
 							discordgo.TextInput{
 								CustomID:    "workout_location",
-								Label:       "Location (indoor/outdoor)",
+								Label:       i18n.T(locale, "exercise.modal.location"),
 								Style:       discordgo.TextInputShort,
-								Placeholder: "indoor or outdoor",
+								Placeholder: i18n.T(locale, "exercise.modal.location_placeholder"),
 								Required:    false,
 								MaxLength:   10,
 							},
@@ -150,7 +180,7 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 						Components: []discordgo.MessageComponent{
 							discordgo.TextInput{
 								CustomID:    "core_duration",
-								Label:       "Core/Mobility Duration (minutes)",
+								Label:       i18n.T(locale, "exercise.modal.core_duration"),
 								Style:       discordgo.TextInputShort,
 								Placeholder: "10",
 								Required:    true,
@@ -163,9 +193,9 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 						Components: []discordgo.MessageComponent{
 							discordgo.TextInput{
 								CustomID:    "core_type",
-								Label:       "Core/Mobility Type",
+								Label:       i18n.T(locale, "exercise.modal.core_type"),
 								Style:       discordgo.TextInputShort,
-								Placeholder: "e.g., abs, planks, stretching, yoga",
+								Placeholder: i18n.T(locale, "exercise.modal.core_type_placeholder"),
 								Required:    false,
 								MaxLength:   50,
 							},
@@ -175,13 +205,15 @@ func (h *InteractionHandler) handleExerciseCommand(s *discordgo.Session, i *disc
 			},
 		})
 		if err != nil {
-			logger.Error("Error responding to exercise command: %v", err)
+			RequestLogger(i).Error("Error responding to exercise command: %v", err)
 		}
 	}
 }
 
 // handleSummaryCommand handles the /summary slash command
-func (h *InteractionHandler) handleSummaryCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *InteractionHandler) handleSummaryCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
 	// Get summary service from registry
 	var summaryService *services.SummaryService
 	for _, svc := range h.services.GetServices() {
@@ -195,7 +227,7 @@ func (h *InteractionHandler) handleSummaryCommand(s *discordgo.Session, i *disco
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Summary service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.summary")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -208,30 +240,78 @@ func (h *InteractionHandler) handleSummaryCommand(s *discordgo.Session, i *disco
 		targetUsername = i.ApplicationCommandData().Options[0].StringValue()
 	}
 
-	summary, err := summaryService.GetProgressSummary(targetUsername)
+	ctx := RequestContext(i)
+	viewer := InteractionUser(i)
+	summary, err := summaryService.GetProgressSummary(ctx, locale, targetUsername, viewer.ID)
 	if err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Error getting summary: %v", err),
+				Content: i18n.T(locale, "summary.error", err),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		}, discordgo.WithContext(ctx))
+		return
+	}
+
+	// Members who aren't public still see their own summary, just not in the channel
+	var flags discordgo.MessageFlags
+	if strings.EqualFold(targetUsername, viewer.Username) && h.userPreferences(i).Privacy != services.PrivacyPublic {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+
+	// All-user summaries grow with the roster and can exceed Discord's message limit
+	if err := discord.RespondLong(s, i.Interaction, summary, flags, discordgo.WithContext(ctx)); err != nil {
+		RequestLogger(i).Error("Error sending summary: %v", err)
+	}
+}
+
+// handleLeaderboardCommand handles the /leaderboard slash command
+func (h *InteractionHandler) handleLeaderboardCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get leaderboard service from registry
+	var leaderboardService *services.LeaderboardService
+	for _, svc := range h.services.GetServices() {
+		if ls, ok := svc.(*services.LeaderboardService); ok {
+			leaderboardService = ls
+			break
+		}
+	}
+
+	if leaderboardService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.leaderboard")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 		return
 	}
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: summary,
-		},
-	})
+	entries, err := leaderboardService.GetLeaderboard(10)
+	if err != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "leaderboard.error", err),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	if err := discord.RespondLong(s, i.Interaction, services.FormatLeaderboard(locale, entries), 0); err != nil {
+		RequestLogger(i).Error("Error sending leaderboard: %v", err)
+	}
 }
 
 // handleWeighInCommand handles the /weighin slash command
-func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *InteractionHandler) handleWeighInCommand(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
+	locale := RequestLocale(i)
 
 	// Get weigh-in service from registry
 	var weighInService *services.WeighInService
@@ -246,7 +326,7 @@ func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *disco
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Weigh-in service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.weighin")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -265,12 +345,14 @@ func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *disco
 		}
 	}
 
-	// Validate weight
-	if weight <= 0 || weight >= 1000 {
+	// Validate weight (entered in the user's units, limited to under 1000 lbs)
+	units := h.userUnits(i)
+	weightUnit := i18n.T(locale, "unit."+units.Weight)
+	if weight <= 0 || units.ToPounds(weight) >= 1000 {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Weight must be between 0.01 and 999.99 pounds.",
+				Content: i18n.T(locale, "weighin.invalid", units.FromPounds(999.99), weightUnit),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -278,12 +360,12 @@ func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *disco
 	}
 
 	// Record weigh-in
-	err := weighInService.RecordWeighIn(userID, username, weight, notes)
+	err := weighInService.RecordWeighIn(userID, username, weight, units, notes)
 	if err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Error recording weigh-in: %v", err),
+				Content: writeErrorMessage(locale, "weighin.error", err),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -291,18 +373,18 @@ func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *disco
 	}
 
 	// Get latest weigh-in for comparison
-	latestWeight, challengeDay, err := weighInService.GetLatestWeighIn(userID)
-	responseText := fmt.Sprintf("✅ **Weigh-in recorded!**\n**Weight:** %.2f lbs", weight)
+	latestWeight, challengeDay, err := weighInService.GetLatestWeighIn(userID, units)
+	responseText := i18n.T(locale, "weighin.recorded", weight, weightUnit)
 	if err == nil && latestWeight != weight {
 		diff := weight - latestWeight
 		if diff > 0 {
-			responseText += fmt.Sprintf("\n📈 **Change:** +%.2f lbs from last weigh-in (Day %d)", diff, challengeDay)
+			responseText += i18n.T(locale, "weighin.change_up", diff, weightUnit, challengeDay)
 		} else {
-			responseText += fmt.Sprintf("\n📉 **Change:** %.2f lbs from last weigh-in (Day %d)", diff, challengeDay)
+			responseText += i18n.T(locale, "weighin.change_down", diff, weightUnit, challengeDay)
 		}
 	}
 	if notes != "" {
-		responseText += fmt.Sprintf("\n📝 **Notes:** %s", notes)
+		responseText += i18n.T(locale, "weighin.notes", notes)
 	}
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -312,38 +394,36 @@ func (h *InteractionHandler) handleWeighInCommand(s *discordgo.Session, i *disco
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
+	guildLocale := GuildLocale(i)
+	h.forum.Post(s, i.GuildID, userID, username,
+		i18n.T(guildLocale, "weighin.forum", weight, i18n.T(guildLocale, "unit."+units.Weight)))
 }
 
-// HandleButtonClick handles button click interactions
-func (h *InteractionHandler) HandleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	customID := i.MessageComponentData().CustomID
-
-	if strings.HasPrefix(customID, "start_confirm_") {
-		h.handleStartConfirmation(s, i, customID)
-	} else if strings.HasPrefix(customID, "start_cancel_") {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseUpdateMessage,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Challenge start cancelled.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-				Components: []discordgo.MessageComponent{},
-			},
-		})
-	}
+// handleStartCancel handles the cancel button click for starting challenge
+func (h *InteractionHandler) handleStartCancel(s discord.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(RequestLocale(i), "start.cancelled"),
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
 }
 
 // handleStartConfirmation handles the confirmation button click for starting challenge
-func (h *InteractionHandler) handleStartConfirmation(s *discordgo.Session, i *discordgo.InteractionCreate, customID string) {
+func (h *InteractionHandler) handleStartConfirmation(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
+	locale := RequestLocale(i)
 
-	// Parse custom ID: start_confirm_{userID}_{timestamp}
-	parts := strings.Split(customID, "_")
-	if len(parts) < 4 {
+	// Parse custom ID: start_confirm:{userID}:{timestamp}
+	_, args := ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) < 2 {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Invalid confirmation. Please try /start again.",
+				Content: i18n.T(locale, "start.invalid_confirmation"),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -351,27 +431,23 @@ func (h *InteractionHandler) handleStartConfirmation(s *discordgo.Session, i *di
 	}
 
 	// Get timestamp from custom ID
-	timestampStr := parts[3]
+	timestampStr := args[1]
 	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
 	if err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Invalid confirmation. Please try /start again.",
+				Content: i18n.T(locale, "start.invalid_confirmation"),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 		return
 	}
 
-	// Load MST location
-	mst, err := time.LoadLocation("America/Denver")
-	if err != nil {
-		mst = time.FixedZone("MST", -7*3600)
-	}
-
-	startDate := time.Unix(timestamp, 0).In(mst)
-	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, mst)
+	// The timestamp is midnight of the chosen day in the user's zone
+	loc := h.userTimezone(i)
+	startDate := time.Unix(timestamp, 0).In(loc)
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
 
 	// Get user service
 	var userService *services.UserService
@@ -386,7 +462,7 @@ func (h *InteractionHandler) handleStartConfirmation(s *discordgo.Session, i *di
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ User service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.user")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -399,8 +475,8 @@ func (h *InteractionHandler) handleStartConfirmation(s *discordgo.Session, i *di
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Error starting challenge: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
+				Content:    i18n.T(locale, "start.error", err),
+				Flags:      discordgo.MessageFlagsEphemeral,
 				Components: []discordgo.MessageComponent{},
 			},
 		})
@@ -408,49 +484,59 @@ func (h *InteractionHandler) handleStartConfirmation(s *discordgo.Session, i *di
 	}
 
 	// Calculate challenge day (should be 1 on start date)
-	challengeDay := 1
-	now := time.Now().In(mst)
-	if now.After(actualStartDate) {
-		daysSinceStart := int(now.Sub(actualStartDate).Hours() / 24)
-		if daysSinceStart >= 0 {
-			challengeDay = daysSinceStart + 1
-		}
+	challengeDay := services.CurrentChallengeDay(actualStartDate, loc)
+	if challengeDay < 1 {
+		challengeDay = 1
 	}
 
-	startDateStr := actualStartDate.Format("January 2, 2006")
-	endDateStr := endDate.Format("January 2, 2006")
-
 	// Update the confirmation message
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseUpdateMessage,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("✅ **Challenge Started!**\n\n"+
-				"📅 **Start Date:** %s (MST)\n"+
-				"🏁 **End Date:** %s (MST)\n"+
-				"📊 **Current Day:** Day %d\n\n"+
-				"Good luck! You've got this! 💪", startDateStr, endDateStr, challengeDay),
+			Content: i18n.T(locale, "start.started",
+				i18n.FormatDayIn(locale, actualStartDate, loc), i18n.FormatDayIn(locale, endDate, loc), challengeDay),
 			Flags:      discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{},
 		},
 	})
 
-	// Send public announcement
-	announcement := fmt.Sprintf("🎉 **%s** has started the 75 Half Chub Challenge!\n\n"+
-		"📅 Started on: **%s** (MST)\n"+
-		"🏁 Challenge will complete on: **%s** (MST)\n"+
-		"📊 Currently on: **Day %d**\n\n"+
-		"Let's support them on this journey! 💪", username, startDateStr, endDateStr, challengeDay)
+	// Send public announcement in the guild's language. The dates are days on the
+	// user's calendar, so they keep the user's zone rather than the guild's.
+	guildLocale := GuildLocale(i)
+	announcement := i18n.T(guildLocale, "start.announcement", username,
+		i18n.FormatDayIn(guildLocale, actualStartDate, loc), i18n.FormatDayIn(guildLocale, endDate, loc), challengeDay)
+
+	// Queue through the outbox so a Discord error doesn't lose the announcement
+	dedupeKey := CustomID("challenge_started", userID, actualStartDate.Format("2006-01-02"))
+	if outboxService := h.outboxService(); outboxService != nil {
+		err := outboxService.Enqueue(dedupeKey, i.ChannelID, announcement)
+		if err == nil {
+			return
+		}
+		RequestLogger(i).Error("Failed to queue announcement, sending directly: %v", err)
+	}
 
 	_, err = s.ChannelMessageSend(i.ChannelID, announcement)
 	if err != nil {
-		logger.Error("Failed to send announcement: %v", err)
+		RequestLogger(i).Error("Failed to send announcement: %v", err)
+	}
+}
+
+// outboxService returns the registered announcement outbox, if any
+func (h *InteractionHandler) outboxService() *services.OutboxService {
+	for _, svc := range h.services.GetServices() {
+		if os, ok := svc.(*services.OutboxService); ok {
+			return os
+		}
 	}
+	return nil
 }
 
 // handleWaterCommand handles the /water slash command
-func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *InteractionHandler) handleWaterCommand(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
+	locale := RequestLocale(i)
 
 	// Get water service from registry
 	var waterService *services.WaterService
@@ -465,7 +551,7 @@ func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discord
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Water service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.water")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -475,26 +561,31 @@ func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discord
 	// Get subcommand
 	subcommand := i.ApplicationCommandData().Options[0].Name
 
+	// Amounts are entered and shown in the user's units
+	units := h.userUnits(i)
+	volumeUnit := i18n.T(locale, "unit."+units.Volume)
+	goal := units.FromOunces(services.WaterGoalOunces)
+
 	if subcommand == "summary" {
 		// Show today's total
-		currentTotal, err := waterService.GetWaterIntake(userID)
+		currentTotal, err := waterService.GetWaterIntake(userID, units)
 		if err != nil {
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("❌ Error getting water intake: %v", err),
+					Content: i18n.T(locale, "water.error_get", err),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
 			return
 		}
 
-		responseText := fmt.Sprintf("💧 **Today's Water Intake**\n**Total:** %.2f / 128 oz", currentTotal)
-		if currentTotal >= 128.0 {
-			responseText += "\n\n🎉 **Goal reached!** You've hit 1 gallon (128 oz)!"
+		responseText := i18n.T(locale, "water.today", currentTotal, goal, volumeUnit)
+		if currentTotal >= goal {
+			responseText += i18n.T(locale, "water.goal_reached", goal, volumeUnit)
 		} else {
-			remaining := 128.0 - currentTotal
-			responseText += fmt.Sprintf("\n📊 **Remaining:** %.2f oz to reach 1 gallon", remaining)
+			remaining := goal - currentTotal
+			responseText += i18n.T(locale, "water.remaining", remaining, volumeUnit)
 		}
 
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -507,21 +598,21 @@ func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discord
 		return
 	}
 
-	// Get ounces from subcommand options
-	var ounces float64
+	// Get amount from subcommand options
+	var amount float64
 	for _, option := range i.ApplicationCommandData().Options[0].Options {
-		if option.Name == "ounces" {
-			ounces = option.FloatValue()
+		if option.Name == "amount" {
+			amount = option.FloatValue()
 			break
 		}
 	}
 
-	// Validate ounces
-	if ounces <= 0 {
+	// Validate amount
+	if amount <= 0 {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Ounces must be greater than 0.",
+				Content: i18n.T(locale, "water.invalid"),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -533,37 +624,37 @@ func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discord
 	var actualAmount, newTotal float64
 
 	if subcommand == "subtract" {
-		actualAmount, newTotal, err = waterService.SubtractWater(userID, username, ounces)
+		actualAmount, newTotal, err = waterService.SubtractWater(userID, username, amount, units)
 		if err != nil {
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("❌ Error subtracting water: %v", err),
+					Content: writeErrorMessage(locale, "water.error_subtract", err),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
 			return
 		}
-		responseText = fmt.Sprintf("💧 **Water subtracted!**\n**Subtracted:** %.2f oz\n**Total today:** %.2f / 128 oz", actualAmount, newTotal)
+		responseText = i18n.T(locale, "water.subtracted", actualAmount, newTotal, goal, volumeUnit)
 	} else if subcommand == "add" {
-		actualAmount, newTotal, err = waterService.AddWater(userID, username, ounces)
+		actualAmount, newTotal, err = waterService.AddWater(userID, username, amount, units)
 		if err != nil {
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("❌ Error adding water: %v", err),
+					Content: writeErrorMessage(locale, "water.error_add", err),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
 			return
 		}
-		responseText = fmt.Sprintf("💧 **Water added!**\n**Added:** %.2f oz\n**Total today:** %.2f / 128 oz", actualAmount, newTotal)
-		
-		if newTotal >= 128.0 {
-			responseText += "\n\n🎉 **Goal reached!** You've hit 1 gallon (128 oz)!"
+		responseText = i18n.T(locale, "water.added", actualAmount, newTotal, goal, volumeUnit)
+
+		if newTotal >= goal {
+			responseText += i18n.T(locale, "water.goal_reached", goal, volumeUnit)
 		} else {
-			remaining := 128.0 - newTotal
-			responseText += fmt.Sprintf("\n📊 **Remaining:** %.2f oz to reach 1 gallon", remaining)
+			remaining := goal - newTotal
+			responseText += i18n.T(locale, "water.remaining", remaining, volumeUnit)
 		}
 	}
 
@@ -574,11 +665,17 @@ func (h *InteractionHandler) handleWaterCommand(s *discordgo.Session, i *discord
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
+	if subcommand == "add" {
+		guildLocale := GuildLocale(i)
+		h.forum.Post(s, i.GuildID, userID, username,
+			i18n.T(guildLocale, "water.forum", actualAmount, newTotal, goal, i18n.T(guildLocale, "unit."+units.Volume)))
+	}
 }
 
 // handleStartCommand handles the /start slash command
-func (h *InteractionHandler) handleStartCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *InteractionHandler) handleStartCommand(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
+	locale := RequestLocale(i)
 
 	// Get user service from registry
 	var userService *services.UserService
@@ -593,14 +690,14 @@ func (h *InteractionHandler) handleStartCommand(s *discordgo.Session, i *discord
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ User service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.user")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 		return
 	}
 
-	// Parse date (default to today MST)
+	// Parse date (default to today in the user's zone)
 	var startDate time.Time
 	dateStr := ""
 	for _, option := range i.ApplicationCommandData().Options {
@@ -609,24 +706,20 @@ func (h *InteractionHandler) handleStartCommand(s *discordgo.Session, i *discord
 		}
 	}
 
-	// Load MST location
-	mst, err := time.LoadLocation("America/Denver")
-	if err != nil {
-		mst = time.FixedZone("MST", -7*3600) // Fallback to UTC-7
-	}
+	loc := h.userTimezone(i)
 
 	if dateStr == "" {
-		// Default to today in MST
-		now := time.Now().In(mst)
-		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, mst)
+		// Default to today in the user's zone
+		now := time.Now().In(loc)
+		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
 	} else {
-		// Parse provided date (assume MST)
-		parsedDate, err := time.ParseInLocation("2006-01-02", dateStr, mst)
+		// Parse provided date (in the user's zone)
+		parsedDate, err := time.ParseInLocation("2006-01-02", dateStr, loc)
 		if err != nil {
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: "❌ Invalid date format. Use YYYY-MM-DD (e.g., 2024-01-15)",
+					Content: i18n.T(locale, "start.invalid_date"),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
@@ -636,28 +729,12 @@ func (h *InteractionHandler) handleStartCommand(s *discordgo.Session, i *discord
 	}
 
 	endDate := startDate.AddDate(0, 0, 75)
-	startDateStr := startDate.Format("January 2, 2006")
-	endDateStr := endDate.Format("January 2, 2006")
 
 	// Show confirmation with rules
-	rulesText := fmt.Sprintf("**75 Half Chub Challenge Rules:**\n\n"+
-		"1. Follow a diet (no cheat meals, no alcohol)\n"+
-		"2. One 30+ minute workout (indoor/outdoor doesn't matter; walking only counts with weight vest)\n"+
-		"3. 10+ minutes of core/mobility\n"+
-		"4. Drink 1 gallon of water (doesn't have to be plain)\n"+
-		"5. 30 minutes of intentional self-improvement (reading, learning, journaling, studying, etc.)\n"+
-		"6. Daily check-in (react with ✅)\n"+
-		"7. Weekly progress photo\n"+
-		"8. Finances: necessities only\n\n"+
-		"**Challenge Details:**\n"+
-		"📅 **Start Date:** %s (MST)\n"+
-		"🏁 **End Date:** %s (MST)\n"+
-		"📊 **Duration:** 75 days (base)\n\n"+
-		"⚠️ **Failure Rule:** If you miss any task, add 7 days to your end date. You may publicly request forgiveness for emergencies (sick kids, etc.) to waive penalties.\n\n"+
-		"Ready to begin?", startDateStr, endDateStr)
+	rulesText := i18n.T(locale, "start.rules", i18n.FormatDayIn(locale, startDate, loc), i18n.FormatDayIn(locale, endDate, loc))
 
 	// Store start date in custom ID for button handler
-	customID := fmt.Sprintf("start_confirm_%s_%d", userID, startDate.Unix())
+	customID := CustomID("start_confirm", userID, strconv.FormatInt(startDate.Unix(), 10))
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -665,20 +742,8 @@ func (h *InteractionHandler) handleStartCommand(s *discordgo.Session, i *discord
 			Content: rulesText,
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.Button{
-							Label:    "Yes, Start Challenge",
-							Style:    discordgo.SuccessButton,
-							CustomID: customID,
-						},
-						discordgo.Button{
-							Label:    "Cancel",
-							Style:    discordgo.DangerButton,
-							CustomID: fmt.Sprintf("start_cancel_%s", userID),
-						},
-					},
-				},
+				ui.ConfirmRow(i18n.T(locale, "start.confirm_button"), i18n.T(locale, "button.cancel"),
+					customID, CustomID("start_cancel", userID), false),
 			},
 		},
 	})
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/discord/discordtest"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// testInteractionID numbers the interactions the tests build, since the deduper drops
+// repeated IDs
+var testInteractionID int
+
+// interaction builds an interaction from user 42 in a guild
+func interaction(kind discordgo.InteractionType, data discordgo.InteractionData) *discordgo.InteractionCreate {
+	testInteractionID++
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        strconv.Itoa(testInteractionID),
+		Type:      kind,
+		GuildID:   "guild",
+		ChannelID: "channel",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "42", Username: "runner"}},
+		Data:      data,
+	}}
+}
+
+// command builds a slash command interaction, with a subcommand when one is given
+func command(name, subcommand string) *discordgo.InteractionCreate {
+	data := discordgo.ApplicationCommandInteractionData{Name: name}
+	if subcommand != "" {
+		data.Options = []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: subcommand, Type: discordgo.ApplicationCommandOptionSubCommand},
+		}
+	}
+	return interaction(discordgo.InteractionApplicationCommand, data)
+}
+
+// modalSubmit builds a modal submit interaction from text input values
+func modalSubmit(customID string, values map[string]string) *discordgo.InteractionCreate {
+	data := discordgo.ModalSubmitInteractionData{CustomID: customID}
+	for id, value := range values {
+		data.Components = append(data.Components, &discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{&discordgo.TextInput{CustomID: id, Value: value}},
+		})
+	}
+	return interaction(discordgo.InteractionModalSubmit, data)
+}
+
+// newTestRouter builds a router with the bot's interaction and modal routes, and the
+// middleware that doesn't need a database
+func newTestRouter(registry *services.ServiceRegistry, cooldowns map[string]time.Duration) *Router {
+	r := NewRouter()
+	r.Use(
+		RecoverMiddleware,
+		LocaleMiddleware(func(string) string { return i18n.Default }, func(string) *time.Location { return time.UTC }),
+		NewInteractionDeduper(time.Minute).Middleware,
+		NewCooldownLimiter(cooldowns).Middleware,
+	)
+	NewInteractionHandler(registry, nil).RegisterRoutes(r)
+	NewModalHandler(registry, nil).RegisterRoutes(r)
+	return r
+}
+
+// exerciseRegistry registers an exercise service with no database, so every write fails
+func exerciseRegistry() *services.ServiceRegistry {
+	registry := services.NewServiceRegistry()
+	registry.Register(services.NewExerciseService(services.NewUserService(0, "UTC")))
+	return registry
+}
+
+// onlyResponse returns the one response the interaction got
+func onlyResponse(t *testing.T, s *discordtest.FakeSession) *discordgo.InteractionResponse {
+	t.Helper()
+	if len(s.Responses) != 1 {
+		t.Fatalf("got %d responses, want 1", len(s.Responses))
+	}
+	return s.Responses[0]
+}
+
+// wantEphemeral checks for an ephemeral message response with the given content
+func wantEphemeral(t *testing.T, response *discordgo.InteractionResponse, content string) {
+	t.Helper()
+	if response.Type != discordgo.InteractionResponseChannelMessageWithSource || response.Data == nil {
+		t.Fatalf("response = %+v, want a message", response)
+	}
+	if response.Data.Content != content {
+		t.Errorf("content = %q, want %q", response.Data.Content, content)
+	}
+	if response.Data.Flags&discordgo.MessageFlagsEphemeral == 0 {
+		t.Error("response isn't ephemeral")
+	}
+}
+
+func TestExerciseCommand(t *testing.T) {
+	locale := i18n.Default
+
+	t.Run("service not registered", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		newTestRouter(services.NewServiceRegistry(), nil).Dispatch(s, command("exercise", "quick"))
+		wantEphemeral(t, onlyResponse(t, s), i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.exercise")))
+	})
+
+	t.Run("quick log fails without a database", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		newTestRouter(exerciseRegistry(), nil).Dispatch(s, command("exercise", "quick"))
+		wantEphemeral(t, onlyResponse(t, s), i18n.T(locale, "exercise.error", errors.New("database not available")))
+		if len(s.Sent) != 0 {
+			t.Errorf("sent %+v, want no forum post for a failed log", s.Sent)
+		}
+	})
+
+	t.Run("detailed opens the form", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		newTestRouter(exerciseRegistry(), nil).Dispatch(s, command("exercise", "detailed"))
+		response := onlyResponse(t, s)
+		if response.Type != discordgo.InteractionResponseModal || response.Data.CustomID != "exercise_modal" {
+			t.Fatalf("response = type %d, custom ID %q; want the exercise_modal form", response.Type, response.Data.CustomID)
+		}
+		var inputs []string
+		for _, row := range response.Data.Components {
+			for _, component := range row.(discordgo.ActionsRow).Components {
+				inputs = append(inputs, component.(discordgo.TextInput).CustomID)
+			}
+		}
+		if got := strings.Join(inputs, ","); got != "workout_duration,workout_type,workout_location,core_duration,core_type" {
+			t.Errorf("form inputs = %s", got)
+		}
+	})
+}
+
+func TestExerciseModal(t *testing.T) {
+	locale := i18n.Default
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   string
+	}{
+		{
+			name:   "workout too short",
+			values: map[string]string{"workout_duration": "20", "core_duration": "10"},
+			want: i18n.T(locale, "error.invalid_input", i18n.T(locale, "validation.at_least",
+				i18n.T(locale, "exercise.field.workout_duration"), 30, i18n.T(locale, "unit.minutes"))),
+		},
+		{
+			name:   "not a number",
+			values: map[string]string{"workout_duration": "half an hour", "core_duration": "10"},
+			want: i18n.T(locale, "error.invalid_input", i18n.T(locale, "validation.not_number",
+				i18n.T(locale, "exercise.field.workout_duration"), "half an hour")),
+		},
+		{
+			name:   "not a whole number",
+			values: map[string]string{"workout_duration": "45", "core_duration": "12.5"},
+			want: i18n.T(locale, "error.invalid_input", i18n.T(locale, "validation.not_whole_number",
+				i18n.T(locale, "exercise.field.core_duration"), "12.5")),
+		},
+		{
+			name:   "valid form reaches the service",
+			values: map[string]string{"workout_duration": "45", "core_duration": "15", "workout_type": "run"},
+			want:   i18n.T(locale, "exercise.error", errors.New("database not available")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := discordtest.NewFakeSession("bot")
+			newTestRouter(exerciseRegistry(), nil).Dispatch(s, modalSubmit("exercise_modal", tt.values))
+			wantEphemeral(t, onlyResponse(t, s), tt.want)
+		})
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	locale := i18n.Default
+
+	t.Run("unknown command", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		newTestRouter(services.NewServiceRegistry(), nil).Dispatch(s, command("nope", ""))
+		wantEphemeral(t, onlyResponse(t, s), i18n.T(locale, "error.unknown_route", "command", "nope"))
+	})
+
+	t.Run("user's Discord language", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		i := command("exercise", "quick")
+		i.Locale = discordgo.SpanishES
+		newTestRouter(services.NewServiceRegistry(), nil).Dispatch(s, i)
+		wantEphemeral(t, onlyResponse(t, s), i18n.T("es", "error.service_unavailable", i18n.T("es", "service.exercise")))
+	})
+
+	t.Run("redelivered interaction is dropped", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		r := newTestRouter(exerciseRegistry(), nil)
+		i := command("exercise", "detailed")
+		r.Dispatch(s, i)
+		r.Dispatch(s, i)
+		if len(s.Responses) != 1 {
+			t.Errorf("got %d responses, want only the first delivery answered", len(s.Responses))
+		}
+	})
+
+	t.Run("repeated form submit", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		r := newTestRouter(exerciseRegistry(), nil)
+		values := map[string]string{"workout_duration": "45", "core_duration": "15"}
+		r.Dispatch(s, modalSubmit("exercise_modal", values))
+		r.Dispatch(s, modalSubmit("exercise_modal", values))
+		if len(s.Responses) != 2 {
+			t.Fatalf("got %d responses, want 2", len(s.Responses))
+		}
+		wantEphemeral(t, s.Responses[1], i18n.T(locale, "error.duplicate_submit"))
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		r := newTestRouter(services.NewServiceRegistry(), map[string]time.Duration{"exercise": time.Minute})
+		r.Dispatch(s, command("exercise", "quick"))
+		r.Dispatch(s, command("exercise", "quick"))
+		if len(s.Responses) != 2 {
+			t.Fatalf("got %d responses, want 2", len(s.Responses))
+		}
+		wantEphemeral(t, s.Responses[1], i18n.T(locale, "error.cooldown", "exercise", 60))
+	})
+
+	t.Run("panic is answered", func(t *testing.T) {
+		s := discordtest.NewFakeSession("bot")
+		r := newTestRouter(services.NewServiceRegistry(), nil)
+		r.Command("boom", func(discord.Session, *discordgo.InteractionCreate) { panic("boom") })
+		r.Dispatch(s, command("boom", ""))
+		wantEphemeral(t, onlyResponse(t, s), i18n.T(locale, "error.panic"))
+	})
+}
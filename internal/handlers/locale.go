@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// requestLocales holds the locales resolved for each interaction being handled, keyed by interaction ID
+var requestLocales sync.Map
+
+// guildTimezones resolves a guild's zone for GuildTimezone outside LocaleMiddleware; it's
+// the func the middleware was built with, or UTC before that
+var guildTimezones atomic.Value
+
+// interactionLocales is what LocaleMiddleware resolved for one interaction
+type interactionLocales struct {
+	user     string         // Replies to the user
+	guild    string         // Public posts in the guild
+	timezone *time.Location // Dates in public posts, and for members who haven't chosen a zone
+}
+
+// LocaleMiddleware resolves the interaction's locales once, before any handler replies.
+// guildLocale and guildTimezone return the guild's configured language and time zone
+// (its settings, or the bot defaults).
+func LocaleMiddleware(guildLocale func(guildID string) string, guildTimezone func(guildID string) *time.Location) Middleware {
+	guildTimezones.Store(guildTimezone)
+	return func(next HandlerFunc) HandlerFunc {
+		return func(s discord.Session, i *discordgo.InteractionCreate) {
+			guild := guildLocale(i.GuildID)
+			user := i18n.Match(string(i.Locale))
+			if user == "" {
+				user = guild
+			}
+
+			requestLocales.Store(i.ID, interactionLocales{user: user, guild: guild, timezone: guildTimezone(i.GuildID)})
+			defer requestLocales.Delete(i.ID)
+
+			next(s, i)
+		}
+	}
+}
+
+// RequestLocale returns the locale for replies to the user behind i: their Discord
+// language when there is a catalog for it, otherwise the guild's locale
+func RequestLocale(i *discordgo.InteractionCreate) string {
+	if locales, ok := requestLocales.Load(i.ID); ok {
+		return locales.(interactionLocales).user
+	}
+	// Outside LocaleMiddleware (e.g. middleware that runs before it)
+	if locale := i18n.Match(string(i.Locale)); locale != "" {
+		return locale
+	}
+	return i18n.Default
+}
+
+// GuildLocale returns the locale for public posts about i, such as announcements and
+// forum progress posts, which everyone in the guild reads
+func GuildLocale(i *discordgo.InteractionCreate) string {
+	if locales, ok := requestLocales.Load(i.ID); ok {
+		return locales.(interactionLocales).guild
+	}
+	return i18n.Default
+}
+
+// GuildTimezone returns the time zone for dates in public posts about i
+func GuildTimezone(i *discordgo.InteractionCreate) *time.Location {
+	if locales, ok := requestLocales.Load(i.ID); ok {
+		return locales.(interactionLocales).timezone
+	}
+	// Outside LocaleMiddleware (e.g. middleware that runs before it)
+	if guildTimezone, ok := guildTimezones.Load().(func(guildID string) *time.Location); ok {
+		return guildTimezone(i.GuildID)
+	}
+	return time.UTC
+}
+
+// userTimezone returns the time zone for dates shown to the user behind i: the one
+// in their preferences, or the guild's if they haven't chosen one
+func (h *InteractionHandler) userTimezone(i *discordgo.InteractionCreate) *time.Location {
+	for _, svc := range h.services.GetServices() {
+		if ps, ok := svc.(*services.PreferencesService); ok {
+			prefs, err := ps.Get(InteractionUser(i).ID)
+			if err != nil {
+				RequestLogger(i).Error("Failed to load preferences, using the guild's time zone: %v", err)
+			}
+			if prefs.Timezone != "" {
+				return prefs.Location(GuildTimezone(i))
+			}
+			break
+		}
+	}
+	return GuildTimezone(i)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+)
+
+// ModalFields holds submitted modal TextInput values keyed by CustomID, so
+// handlers don't depend on the position of inputs in the modal layout
+type ModalFields map[string]string
+
+// ParseModalFields collects every TextInput value in a modal submission by CustomID
+func ParseModalFields(data discordgo.ModalSubmitInteractionData) ModalFields {
+	fields := make(ModalFields)
+	for _, component := range data.Components {
+		collectTextInputs(component, fields)
+	}
+	return fields
+}
+
+// collectTextInputs walks action rows and records TextInput values
+func collectTextInputs(component discordgo.MessageComponent, fields ModalFields) {
+	switch c := component.(type) {
+	case *discordgo.ActionsRow:
+		for _, child := range c.Components {
+			collectTextInputs(child, fields)
+		}
+	case *discordgo.TextInput:
+		fields[c.CustomID] = strings.TrimSpace(c.Value)
+	}
+}
+
+// String returns the trimmed value for customID, or def when it is missing or blank
+func (f ModalFields) String(customID, def string) string {
+	if value := f[customID]; value != "" {
+		return value
+	}
+	return def
+}
+
+// Int parses the value for customID as a whole number. field names the input
+// in validation errors so they can be shown to the user as-is.
+func (f ModalFields) Int(customID, field string) (int, error) {
+	return ParseWholeNumber(field, f[customID])
+}
+
+// Float parses the value for customID as a decimal number
+func (f ModalFields) Float(customID, field string) (float64, error) {
+	return ParseDecimal(field, f[customID])
+}
+
+// respondModalError replies to a modal submission with an ephemeral validation error
+func respondModalError(s discord.Session, i *discordgo.InteractionCreate, err error) {
+	locale := RequestLocale(i)
+	message := err.Error()
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message(locale)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.T(locale, "error.invalid_input", message),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
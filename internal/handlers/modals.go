@@ -1,48 +1,36 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/bwmarrin/discordgo"
-	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/services"
 )
 
 // ModalHandler handles modal submission interactions
 type ModalHandler struct {
 	services *services.ServiceRegistry
+	forum    *ForumPublisher
 }
 
-// NewModalHandler creates a new modal handler
-func NewModalHandler(serviceRegistry *services.ServiceRegistry) *ModalHandler {
+// NewModalHandler creates a new modal handler; forum mirrors logs to progress posts
+func NewModalHandler(serviceRegistry *services.ServiceRegistry, forum *ForumPublisher) *ModalHandler {
 	return &ModalHandler{
 		services: serviceRegistry,
+		forum:    forum,
 	}
 }
 
-// HandleModalSubmit routes modal submissions to appropriate handlers
-func (h *ModalHandler) HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	customID := i.ModalSubmitData().CustomID
-
-	switch customID {
-	case "exercise_modal":
-		h.handleExerciseModal(s, i)
-	default:
-		logger.Error("Unknown modal: %s", customID)
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Unknown modal: %s", customID),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-	}
+// RegisterRoutes registers modal submit handlers with the router
+func (h *ModalHandler) RegisterRoutes(r *Router) {
+	r.Modal("exercise_modal", h.handleExerciseModal)
 }
 
 // handleExerciseModal handles the exercise modal submission
-func (h *ModalHandler) handleExerciseModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *ModalHandler) handleExerciseModal(s discord.Session, i *discordgo.InteractionCreate) {
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
+	locale := RequestLocale(i)
 
 	// Get exercise service from registry
 	var exerciseService *services.ExerciseService
@@ -57,65 +45,46 @@ func (h *ModalHandler) handleExerciseModal(s *discordgo.Session, i *discordgo.In
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Exercise service not available.",
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.exercise")),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 		return
 	}
 
-	data := i.ModalSubmitData()
-	workoutDurationStr := data.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	workoutType := data.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	workoutLocation := data.Components[2].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	coreDurationStr := data.Components[3].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	coreType := data.Components[4].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-
-	// Parse durations
-	var workoutDuration, coreDuration int
-	fmt.Sscanf(workoutDurationStr, "%d", &workoutDuration)
-	fmt.Sscanf(coreDurationStr, "%d", &coreDuration)
+	fields := ParseModalFields(i.ModalSubmitData())
 
-	// Validate minimums
-	if workoutDuration < 30 {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Workout duration must be at least 30 minutes.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-		return
+	workoutField := i18n.T(locale, "exercise.field.workout_duration")
+	workoutDuration, err := fields.Int("workout_duration", workoutField)
+	if err == nil {
+		err = RequireAtLeast(workoutField, workoutDuration, 30, i18n.T(locale, "unit.minutes"))
 	}
-
-	if coreDuration < 10 {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Core/mobility duration must be at least 10 minutes.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+	if err != nil {
+		respondModalError(s, i, err)
 		return
 	}
 
-	// Set defaults for empty fields
-	if workoutType == "" {
-		workoutType = "general"
-	}
-	if workoutLocation == "" {
-		workoutLocation = "indoor"
+	coreField := i18n.T(locale, "exercise.field.core_duration")
+	coreDuration, err := fields.Int("core_duration", coreField)
+	if err == nil {
+		err = RequireAtLeast(coreField, coreDuration, 10, i18n.T(locale, "unit.minutes"))
 	}
-	if coreType == "" {
-		coreType = "general"
+	if err != nil {
+		respondModalError(s, i, err)
+		return
 	}
 
-	err := exerciseService.LogExerciseDetailed(userID, username, workoutDuration, workoutType, workoutLocation, coreDuration, coreType)
+	// Defaults for empty optional fields
+	workoutType := fields.String("workout_type", "general")
+	workoutLocation := fields.String("workout_location", "indoor")
+	coreType := fields.String("core_type", "general")
+
+	err = exerciseService.LogExerciseDetailed(userID, username, workoutDuration, workoutType, workoutLocation, coreDuration, coreType)
 	if err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("❌ Error logging exercise: %v", err),
+				Content: writeErrorMessage(locale, "exercise.error", err),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
@@ -125,11 +94,11 @@ func (h *ModalHandler) handleExerciseModal(s *discordgo.Session, i *discordgo.In
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("✅ **Exercise logged!**\n"+
-				"**Workout:** %d minutes (%s, %s)\n"+
-				"**Core/Mobility:** %d minutes (%s)",
+			Content: i18n.T(locale, "exercise.detailed_logged",
 				workoutDuration, workoutType, workoutLocation, coreDuration, coreType),
 			Flags: discordgo.MessageFlagsEphemeral,
 		},
 	})
+	h.forum.Post(s, i.GuildID, userID, username, i18n.T(GuildLocale(i), "exercise.forum_detailed",
+		workoutDuration, workoutType, workoutLocation, coreDuration, coreType))
 }
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/discord/ui"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// NaturalLogHandler offers to log water and workouts that members mention in chat
+type NaturalLogHandler struct {
+	services    *services.ServiceRegistry
+	guildLocale func(guildID string) string
+}
+
+// NewNaturalLogHandler creates a natural-language logging handler; guildLocale picks
+// the language of its prompts
+func NewNaturalLogHandler(serviceRegistry *services.ServiceRegistry, guildLocale func(guildID string) string) *NaturalLogHandler {
+	return &NaturalLogHandler{
+		services:    serviceRegistry,
+		guildLocale: guildLocale,
+	}
+}
+
+// HandleMessage replies to a message such as "drank 32oz" with buttons that log it.
+// Only participants are prompted, and only the author can press the buttons.
+func (h *NaturalLogHandler) HandleMessage(s discord.Session, m *discordgo.MessageCreate) {
+	if m.Author == nil || m.Author.Bot || !featureEnabled(h.services, m.GuildID, services.FeatureNaturalLogging) {
+		return
+	}
+	entry, ok := services.ParseNaturalLog(m.Content)
+	if !ok {
+		return
+	}
+	log := logger.With("correlation_id", logger.NewCorrelationID(), "user_id", m.Author.ID, "guild_id", m.GuildID, "message_id", m.ID)
+
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		if us, ok := svc.(*services.UserService); ok {
+			userService = us
+			break
+		}
+	}
+	if userService == nil {
+		return
+	}
+	if _, err := userService.GetProgress(m.Author.ID); err != nil {
+		if !errors.Is(err, services.ErrUserNotFound) {
+			log.Error("Failed to load progress for natural log: %v", err)
+		}
+		return
+	}
+
+	locale := h.guildLocale(m.GuildID)
+	userID := m.Author.ID
+	confirmID := CustomID("natlog_confirm", append([]string{userID}, naturalLogArgs(entry)...)...)
+
+	_, err := s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Content: i18n.T(locale, "natlog.prompt", describeNaturalLog(locale, entry)),
+		Components: []discordgo.MessageComponent{
+			ui.ConfirmRow(i18n.T(locale, "natlog.confirm_button"), i18n.T(locale, "natlog.dismiss_button"),
+				confirmID, CustomID("natlog_dismiss", userID), false),
+		},
+		Reference:       m.Reference(),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Error("Failed to offer natural log: %v", err)
+		return
+	}
+	log.Info("Offered to log %s from chat", entry.Kind)
+}
+
+// naturalLogArgs encodes an entry for its confirm button: water, amount, and unit, or
+// workout, minutes, and type
+func naturalLogArgs(entry services.QuickLog) []string {
+	if entry.Kind == services.QuickLogWater {
+		return []string{entry.Kind, strconv.FormatFloat(entry.Amount, 'f', -1, 64), entry.Unit}
+	}
+	return []string{entry.Kind, strconv.Itoa(entry.Minutes), entry.Type}
+}
+
+// describeNaturalLog names an entry in a prompt, e.g. "32 oz of water"
+func describeNaturalLog(locale string, entry services.QuickLog) string {
+	if entry.Kind == services.QuickLogWater {
+		return i18n.T(locale, "natlog.water", entry.Amount, i18n.T(locale, "unit."+entry.Unit))
+	}
+	workoutType := entry.Type
+	if workoutType == "" {
+		workoutType = "general"
+	}
+	return i18n.T(locale, "natlog.workout", entry.Minutes, workoutType)
+}
+
+// parseNaturalLog reads the entry back from a confirm button:
+// natlog_confirm:{userID}:water:{amount}:{unit} or natlog_confirm:{userID}:workout:{minutes}:{type}
+func parseNaturalLog(args []string) (services.QuickLog, bool) {
+	if len(args) < 4 {
+		return services.QuickLog{}, false
+	}
+	switch args[1] {
+	case services.QuickLogWater:
+		amount, err := strconv.ParseFloat(args[2], 64)
+		if err != nil || amount <= 0 || (args[3] != services.VolumeUnitOunces && args[3] != services.VolumeUnitLiters) {
+			return services.QuickLog{}, false
+		}
+		return services.QuickLog{Kind: services.QuickLogWater, Amount: amount, Unit: args[3]}, true
+	case services.QuickLogWorkout:
+		minutes, err := strconv.Atoi(args[2])
+		if err != nil || minutes < services.MinWorkoutMinutes {
+			return services.QuickLog{}, false
+		}
+		return services.QuickLog{Kind: services.QuickLogWorkout, Minutes: minutes, Type: args[3]}, true
+	}
+	return services.QuickLog{}, false
+}
+
+// handleNaturalLogConfirm logs the entry offered by HandleMessage and replaces the
+// prompt with what was logged
+func (h *InteractionHandler) handleNaturalLogConfirm(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	username := i.Member.User.Username
+	locale := RequestLocale(i)
+
+	_, args := ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) == 0 || args[0] != userID {
+		respondEphemeral(s, i, i18n.T(locale, "natlog.not_yours"))
+		return
+	}
+	entry, ok := parseNaturalLog(args)
+	if !ok {
+		respondEphemeral(s, i, i18n.T(locale, "natlog.invalid"))
+		return
+	}
+
+	guildLocale := GuildLocale(i)
+	var content, forumContent string
+	switch entry.Kind {
+	case services.QuickLogWater:
+		var waterService *services.WaterService
+		for _, svc := range h.services.GetServices() {
+			if ws, ok := svc.(*services.WaterService); ok {
+				waterService = ws
+				break
+			}
+		}
+		if waterService == nil {
+			respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.water")))
+			return
+		}
+
+		units := h.userUnits(i)
+		units.Volume = entry.Unit
+		actualAmount, newTotal, err := waterService.AddWater(userID, username, entry.Amount, units)
+		if err != nil {
+			RequestLogger(i).Error("Failed to log water from chat: %v", err)
+			respondEphemeral(s, i, writeErrorMessage(locale, "water.error_add", err))
+			return
+		}
+		goal := units.FromOunces(services.WaterGoalOunces)
+		volumeUnit := i18n.T(guildLocale, "unit."+units.Volume)
+		content = i18n.T(guildLocale, "natlog.water_logged", actualAmount, newTotal, goal, volumeUnit)
+		forumContent = i18n.T(guildLocale, "water.forum", actualAmount, newTotal, goal, volumeUnit)
+
+	case services.QuickLogWorkout:
+		var exerciseService *services.ExerciseService
+		for _, svc := range h.services.GetServices() {
+			if es, ok := svc.(*services.ExerciseService); ok {
+				exerciseService = es
+				break
+			}
+		}
+		if exerciseService == nil {
+			respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.exercise")))
+			return
+		}
+
+		// Like quick logs from phone automations, the core/mobility work gets the minimum
+		workoutType := entry.Type
+		if workoutType == "" {
+			workoutType = "general"
+		}
+		err := exerciseService.LogExerciseDetailed(userID, username, entry.Minutes, workoutType, "indoor", 10, "general")
+		if err != nil {
+			RequestLogger(i).Error("Failed to log workout from chat: %v", err)
+			respondEphemeral(s, i, writeErrorMessage(locale, "exercise.error", err))
+			return
+		}
+		content = i18n.T(guildLocale, "natlog.workout_logged", entry.Minutes, workoutType)
+		forumContent = i18n.T(guildLocale, "exercise.forum_detailed", entry.Minutes, workoutType, "indoor", 10, "general")
+	}
+
+	RequestLogger(i).Info("Logged %s from chat", entry.Kind)
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	h.forum.Post(s, i.GuildID, userID, username, forumContent)
+}
+
+// handleNaturalLogDismiss closes a natural-language logging prompt without logging
+func (h *InteractionHandler) handleNaturalLogDismiss(s discord.Session, i *discordgo.InteractionCreate) {
+	_, args := ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) == 0 || args[0] != i.Member.User.ID {
+		respondEphemeral(s, i, i18n.T(RequestLocale(i), "natlog.not_yours"))
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(GuildLocale(i), "natlog.dismissed"),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// respondEphemeral answers an interaction with a message only its user sees
+func respondEphemeral(s discord.Session, i *discordgo.InteractionCreate, content string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
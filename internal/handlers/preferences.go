@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handlePreferencesCommand handles the /preferences slash command
+func (h *InteractionHandler) handlePreferencesCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get preferences service from registry
+	var preferencesService *services.PreferencesService
+	for _, svc := range h.services.GetServices() {
+		if ps, ok := svc.(*services.PreferencesService); ok {
+			preferencesService = ps
+			break
+		}
+	}
+
+	if preferencesService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.preferences")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	prefs, err := preferencesService.Get(userID)
+	if err != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "preferences.error_load", err),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var content string
+
+	switch subcommand.Name {
+	case "view":
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "preferences.title"))
+		for _, name := range services.PreferenceNames() {
+			value := prefs.Value(name)
+			if name == services.PreferenceTimezone && value == "" {
+				value = i18n.T(locale, "preferences.default_timezone", GuildTimezone(i).String())
+			}
+			message.WriteString(i18n.T(locale, "preferences.entry", name, value, i18n.T(locale, "preference."+name)))
+		}
+		content = message.String()
+
+	case "set":
+		name := subcommand.Options[0].StringValue()
+		value := subcommand.Options[1].StringValue()
+		if err := preferencesService.Set(userID, name, value); err != nil {
+			content = i18n.T(locale, "preferences.error_update", err)
+			break
+		}
+
+		RequestLogger(i).Info("Preference %s set to %s for user_id=%s", name, value, userID)
+		prefs, _ = preferencesService.Get(userID)
+		content = i18n.T(locale, "preferences.set", name, prefs.Value(name))
+
+	case "reset":
+		name := subcommand.Options[0].StringValue()
+		if err := preferencesService.Reset(userID, name); err != nil {
+			content = i18n.T(locale, "preferences.error_reset", err)
+			break
+		}
+
+		RequestLogger(i).Info("Preference %s reset for user_id=%s", name, userID)
+		content = i18n.T(locale, "preferences.reset", name)
+
+	case "units":
+		content = h.updateUnits(i, preferencesService, prefs.Units)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// updateUnits handles /preferences units [weight] [volume]; with no options it just
+// shows the current units
+func (h *InteractionHandler) updateUnits(i *discordgo.InteractionCreate, preferencesService *services.PreferencesService, units services.Units) string {
+	locale := RequestLocale(i)
+	userID := InteractionUser(i).ID
+
+	var content string
+	options := i.ApplicationCommandData().Options[0].Options
+	if len(options) > 0 {
+		for _, option := range options {
+			switch option.Name {
+			case "weight":
+				units.Weight = option.StringValue()
+			case "volume":
+				units.Volume = option.StringValue()
+			}
+		}
+
+		if err := preferencesService.SetUnits(userID, units); err != nil {
+			return i18n.T(locale, "preferences.error_update", err)
+		}
+		RequestLogger(i).Info("Units set to %s/%s for user_id=%s", units.Weight, units.Volume, userID)
+		content = i18n.T(locale, "preferences.units_saved")
+	}
+
+	return content + i18n.T(locale, "preferences.units",
+		i18n.T(locale, "unit."+units.Weight), i18n.T(locale, "unit."+units.Volume))
+}
+
+// userPreferences returns the preferences of the user behind i, falling back to
+// the defaults if they can't be loaded
+func (h *InteractionHandler) userPreferences(i *discordgo.InteractionCreate) services.Preferences {
+	for _, svc := range h.services.GetServices() {
+		if ps, ok := svc.(*services.PreferencesService); ok {
+			prefs, err := ps.Get(InteractionUser(i).ID)
+			if err != nil {
+				RequestLogger(i).Error("Failed to load preferences, using the defaults: %v", err)
+			}
+			return prefs
+		}
+	}
+	return services.DefaultPreferences
+}
+
+// userUnits returns the units the user behind i enters and reads amounts in
+func (h *InteractionHandler) userUnits(i *discordgo.InteractionCreate) services.Units {
+	return h.userPreferences(i).Units
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/discord/ui"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleDeleteMyDataCommand handles the /deletemydata slash command
+func (h *InteractionHandler) handleDeleteMyDataCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Ask for confirmation before erasing anything
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.T(locale, "privacy.delete_warning"),
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				ui.ConfirmRow(i18n.T(locale, "privacy.delete_button"), i18n.T(locale, "button.cancel"),
+					CustomID("deletemydata_confirm", userID), CustomID("deletemydata_cancel", userID), true),
+			},
+		},
+	})
+}
+
+// handleDeleteMyDataConfirmation handles the confirmation button click for data deletion
+func (h *InteractionHandler) handleDeleteMyDataConfirmation(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Parse custom ID: deletemydata_confirm:{userID}
+	// Only the user who requested deletion may confirm it
+	_, args := ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) < 1 || args[0] != userID {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "privacy.delete_not_yours"),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Get user service from registry
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		if us, ok := svc.(*services.UserService); ok {
+			userService = us
+			break
+		}
+	}
+
+	if userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.user")),
+				Flags:      discordgo.MessageFlagsEphemeral,
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	// Meal photos live in object storage, so they go before the rows pointing at them
+	var err error
+	if mealService := h.mealService(); mealService != nil {
+		err = mealService.DeletePhotos(userID)
+	}
+	var deleted int64
+	if err == nil {
+		deleted, err = userService.DeleteUserData(userID)
+	}
+	if err != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    i18n.T(locale, "privacy.delete_error", err),
+				Flags:      discordgo.MessageFlagsEphemeral,
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	RequestLogger(i).Info("Erased all data for user_id=%s (%d rows)", userID, deleted)
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(locale, "privacy.deleted", deleted),
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// handleDeleteMyDataCancel handles the cancel button click for data deletion
+func (h *InteractionHandler) handleDeleteMyDataCancel(s discord.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(RequestLocale(i), "privacy.delete_cancelled"),
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// handleExportMyDataCommand handles the /exportmydata slash command
+func (h *InteractionHandler) handleExportMyDataCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get export service from registry
+	var exportService *services.ExportService
+	for _, svc := range h.services.GetServices() {
+		if es, ok := svc.(*services.ExportService); ok {
+			exportService = es
+			break
+		}
+	}
+
+	if exportService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.export")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	format := "json"
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "format" {
+			format = option.StringValue()
+		}
+	}
+
+	// Collecting every table can take a moment, so acknowledge first
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	respond := func(content string) {
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+			RequestLogger(i).Error("Error editing export response: %v", err)
+		}
+	}
+
+	export, err := exportService.ExportUserData(userID)
+	if err != nil {
+		respond(i18n.T(locale, "export.error", err))
+		return
+	}
+
+	var content []byte
+	var contentType string
+	if format == "csv" {
+		content, err = export.ToCSV()
+		contentType = "text/csv"
+	} else {
+		format = "json"
+		content, err = export.ToJSON()
+		contentType = "application/json"
+	}
+	if err != nil {
+		respond(i18n.T(locale, "export.format_error", err))
+		return
+	}
+
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		RequestLogger(i).Error("Failed to open DM channel for user_id=%s: %v", userID, err)
+		respond(i18n.T(locale, "export.dm_open_failed"))
+		return
+	}
+
+	filename := fmt.Sprintf("75-half-chub-data-%s.%s", export.GeneratedAt.Format("2006-01-02"), format)
+	_, err = s.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content: i18n.T(locale, "export.dm_message"),
+		Files: []*discordgo.File{
+			{
+				Name:        filename,
+				ContentType: contentType,
+				Reader:      bytes.NewReader(content),
+			},
+		},
+	})
+	if err != nil {
+		RequestLogger(i).Error("Failed to DM export to user_id=%s: %v", userID, err)
+		respond(i18n.T(locale, "export.dm_send_failed"))
+		return
+	}
+
+	RequestLogger(i).Info("Exported data for user_id=%s (%s, %d bytes)", userID, format, len(content))
+	respond(i18n.T(locale, "export.sent"))
+}
@@ -1,53 +1,155 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/expiring"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/logger"
 	"github.com/75-hard-discord-bot/internal/services"
 )
 
+// checkInMessageTTL is how long a message's verdict is remembered; a day's check-in
+// message stops accepting check-ins at the end of the next day
+const checkInMessageTTL = 72 * time.Hour
+
+// CheckInMessages remembers which messages are check-in messages and when each was
+// posted, so reactions can be handled without fetching the message from Discord
+type CheckInMessages struct {
+	messages *expiring.Map[string, checkInMessage] // Message ID -> verdict
+
+	mu     sync.Mutex
+	latest string // The newest check-in message's ID
+}
+
+// checkInMessage is what's remembered about a reacted-to message
+type checkInMessage struct {
+	isCheckIn bool
+	posted    time.Time
+}
+
+// NewCheckInMessages creates an empty check-in message registry
+func NewCheckInMessages() *CheckInMessages {
+	return &CheckInMessages{messages: expiring.New[string, checkInMessage]()}
+}
+
+// Remember records messageID as a check-in message posted at posted
+func (c *CheckInMessages) Remember(messageID string, posted time.Time) {
+	c.remember(messageID, checkInMessage{isCheckIn: true, posted: posted})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if latest, ok := c.messages.Get(c.latest); !ok || !posted.Before(latest.posted) {
+		c.latest = messageID
+	}
+}
+
+// IsLatest reports whether messageID is the newest check-in message the bot has posted
+func (c *CheckInMessages) IsLatest(messageID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest != "" && c.latest == messageID
+}
+
+// remember records the verdict for messageID
+func (c *CheckInMessages) remember(messageID string, message checkInMessage) {
+	c.messages.Set(messageID, message, checkInMessageTTL)
+}
+
+// lookup returns the verdict for messageID, if there is one
+func (c *CheckInMessages) lookup(messageID string) (checkInMessage, bool) {
+	return c.messages.Get(messageID)
+}
+
 // ReactionHandler handles message reaction events
 type ReactionHandler struct {
-	services *services.ServiceRegistry
+	services       *services.ServiceRegistry
+	limiter        *CooldownLimiter
+	messages       *CheckInMessages
+	checkInChannel func() string
+	guildLocale    func(guildID string) string
+	guildTimezone  func(guildID string) *time.Location
 }
 
-// NewReactionHandler creates a new reaction handler
-func NewReactionHandler(serviceRegistry *services.ServiceRegistry) *ReactionHandler {
+// NewReactionHandler creates a new reaction handler; limiter throttles check-in writes per
+// user, messages and checkInChannel tell check-in messages apart, guildLocale picks the
+// language of check-in guidance, and guildTimezone gives the zone check-in messages are
+// dated in
+func NewReactionHandler(serviceRegistry *services.ServiceRegistry, limiter *CooldownLimiter, messages *CheckInMessages, checkInChannel func() string, guildLocale func(guildID string) string, guildTimezone func(guildID string) *time.Location) *ReactionHandler {
 	return &ReactionHandler{
-		services: serviceRegistry,
+		services:       serviceRegistry,
+		limiter:        limiter,
+		messages:       messages,
+		checkInChannel: checkInChannel,
+		guildLocale:    guildLocale,
+		guildTimezone:  guildTimezone,
 	}
 }
 
-// HandleMessageReaction handles message reaction add events
-func (h *ReactionHandler) HandleMessageReaction(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
-	// Ignore bot's own reactions
-	if r.UserID == s.State.User.ID {
-		return
+// checkInMessage reports whether the reacted-to message is a check-in message and when
+// it was posted. Known messages are answered from memory. Others are only fetched in the
+// check-in channel, where check-in messages are posted, and the verdict is remembered, so
+// reactions elsewhere never cost a REST call.
+func (h *ReactionHandler) checkInMessage(s discord.Session, log *logger.Logger, r *discordgo.MessageReactionAdd) (time.Time, bool) {
+	if message, ok := h.messages.lookup(r.MessageID); ok {
+		return message.posted, message.isCheckIn
 	}
-
-	// Get user information
-	user, err := s.User(r.UserID)
-	if err != nil {
-		logger.Error("Error getting user: %v", err)
-		return
+	if r.ChannelID != h.checkInChannel() {
+		return time.Time{}, false
 	}
 
-	// Get the message to check if it's our check-in message
 	message, err := s.ChannelMessage(r.ChannelID, r.MessageID)
 	if err != nil {
-		logger.Error("Error getting message: %v", err)
+		log.Error("Error getting message: %v", err)
+		return time.Time{}, false
+	}
+
+	// Check if this is our check-in message by its dated title (in whichever language it was
+	// posted); the text below the title may be a guild's custom template
+	isCheckIn := message.Author != nil && message.Author.ID == s.BotUserID() &&
+		strings.Contains(message.Content, "📅 **") &&
+		i18n.Contains(message.Content, "checkin.title")
+	h.messages.remember(r.MessageID, checkInMessage{isCheckIn: isCheckIn, posted: message.Timestamp})
+	return message.Timestamp, isCheckIn
+}
+
+// HandleMessageReaction handles message reaction add events
+func (h *ReactionHandler) HandleMessageReaction(s discord.Session, r *discordgo.MessageReactionAdd) {
+	// Ignore bot's own reactions
+	if r.UserID == s.BotUserID() {
 		return
 	}
+	// Reactions have no ID of their own, so generate one to group this event's log lines
+	log := logger.With("correlation_id", logger.NewCorrelationID(), "user_id", r.UserID, "guild_id", r.GuildID, "message_id", r.MessageID)
 
-	// Check if this is our check-in message (datestamped format)
-	isCheckInMessage := message.Author.ID == s.State.User.ID &&
-		strings.Contains(message.Content, "Daily Check-In") &&
-		strings.Contains(message.Content, "Check this message to confirm you completed the challenges today")
+	// Only checkmarks record check-ins, so other reactions need no lookups, except in dev
+	// mode where every reaction on the check-in message is confirmed
+	emojiNameLower := strings.ToLower(r.Emoji.Name)
+	isCheckMark := emojiNameLower == "✅" || emojiNameLower == "white_check_mark" || emojiNameLower == "check"
+	if !isCheckMark && !logger.IsDevMode() {
+		return
+	}
 
+	posted, isCheckInMessage := h.checkInMessage(s, log, r)
 	if isCheckInMessage {
+		// Guild reactions carry the member, so the user only needs fetching in DMs
+		var user *discordgo.User
+		if r.Member != nil && r.Member.User != nil {
+			user = r.Member.User
+		} else {
+			var err error
+			if user, err = s.User(r.UserID); err != nil {
+				log.Error("Error getting user: %v", err)
+				return
+			}
+		}
+
 		// Format emoji name
 		emojiName := r.Emoji.Name
 		if r.Emoji.ID != "" {
@@ -66,10 +168,6 @@ func (h *ReactionHandler) HandleMessageReaction(s *discordgo.Session, r *discord
 			confirmation = "✅ Check-in recorded!"
 		}
 
-		// If database is available and emoji is ✅ (or white_check_mark), record check-in
-		emojiNameLower := strings.ToLower(r.Emoji.Name)
-		isCheckMark := emojiNameLower == "✅" || emojiNameLower == "white_check_mark" || emojiNameLower == "check"
-
 		// Get check-in service from registry
 		var checkInService *services.CheckInService
 		for _, svc := range h.services.GetServices() {
@@ -79,26 +177,101 @@ func (h *ReactionHandler) HandleMessageReaction(s *discordgo.Session, r *discord
 			}
 		}
 
+		// Throttle repeated check-in writes (e.g. rapid react/unreact). Only recorded
+		// check-ins start the window, so a rejected reaction doesn't swallow the member's
+		// next one on the right message.
+		throttleKey := "reaction:" + r.UserID
+		if checkInService != nil && isCheckMark && h.limiter != nil {
+			if cooling, _ := h.limiter.Cooling(throttleKey, DefaultReactionCooldown); cooling {
+				log.Info("Throttled check-in reaction from user_id=%s", r.UserID)
+				return
+			}
+		}
+
 		if checkInService != nil && isCheckMark {
-			logger.Info("Processing check-in for user: %s (user_id=%s)", user.Username, r.UserID)
-			dbInfo, err := checkInService.RecordCheckIn(r.UserID, user.Username)
+			log.Info("Processing check-in for user: %s (user_id=%s)", user.Username, r.UserID)
+			// The message is dated in the guild's zone when it's posted, so a reaction after
+			// midnight to yesterday's message counts for yesterday
+			messageDate := posted.In(h.guildTimezone(r.GuildID))
+			checkIn, err := checkInService.RecordCheckInForDate(log, r.UserID, user.Username, messageDate)
+			if errors.Is(err, services.ErrCheckInClosed) && h.messages.IsLatest(r.MessageID) {
+				// Today's message hasn't been posted (the daily post failed or is late), so
+				// the newest one stands in for it rather than turning everyone away
+				log.Warn("Check-in on stale %s check-in message counted for today", messageDate.Format("2006-01-02"))
+				checkIn, err = checkInService.RecordCheckIn(log, r.UserID, user.Username)
+			}
+			if errors.Is(err, services.ErrCheckInClosed) {
+				log.Info("Rejected check-in on the %s check-in message", messageDate.Format("2006-01-02"))
+				locale := h.guildLocale(r.GuildID)
+				h.sendDM(s, log, r.UserID, i18n.T(locale, "checkin.closed", i18n.FormatDate(locale, messageDate)))
+				return
+			}
+			var dayErr *services.DayError
+			if errors.Is(err, services.ErrUserNotFound) || errors.As(err, &dayErr) {
+				log.Info("Check-in for %s is outside user_id=%s's challenge: %v", messageDate.Format("2006-01-02"), r.UserID, err)
+				h.sendCheckInGuidance(s, log, r, dayErr)
+				return
+			}
+			if err == nil && h.limiter != nil {
+				h.limiter.Start(throttleKey, DefaultReactionCooldown)
+			}
+			if err == nil && !checkIn.FirstForDay {
+				// Another checkmark on a day that's already checked in; it was acknowledged
+				// the first time
+				log.Info("Day %d is already checked in for user_id=%s", checkIn.ChallengeDay, r.UserID)
+				return
+			}
 			if err != nil {
-				logger.Error("Error recording check-in: %v", err)
+				log.Error("Error recording check-in: %v", err)
 				if logger.IsDevMode() {
 					confirmation += "\n\n⚠️ Database recording failed (see logs)"
 				}
-			} else if logger.IsDevMode() && dbInfo != "" {
+			} else if logger.IsDevMode() && checkIn.DBInfo != "" {
 				// Only show DB entries in dev mode
-				confirmation += "\n\n" + dbInfo
+				confirmation += "\n\n" + checkIn.DBInfo
 			}
 		}
 
 		// Only send confirmation message in dev mode
 		if logger.IsDevMode() {
-			_, err = s.ChannelMessageSend(r.ChannelID, confirmation)
+			_, err := s.ChannelMessageSend(r.ChannelID, confirmation)
 			if err != nil {
-				logger.Error("Error sending confirmation: %v", err)
+				log.Error("Error sending confirmation: %v", err)
 			}
 		}
 	}
 }
+
+// sendCheckInGuidance DMs a member whose check-in wasn't recorded because they haven't
+// started (dayErr is nil) or the message's day is outside their challenge, explaining
+// how to fix it. Reactions can't be answered privately in the channel.
+func (h *ReactionHandler) sendCheckInGuidance(s discord.Session, log *logger.Logger, r *discordgo.MessageReactionAdd, dayErr *services.DayError) {
+	locale := h.guildLocale(r.GuildID)
+
+	content := i18n.T(locale, "checkin.not_enrolled")
+	if dayErr != nil {
+		day := i18n.FormatDate(locale, dayErr.Date)
+		switch {
+		case errors.Is(dayErr, services.ErrChallengeNotStarted):
+			content = i18n.T(locale, "checkin.not_started", day, i18n.FormatDate(locale, dayErr.StartDate))
+		case errors.Is(dayErr, services.ErrChallengeEnded):
+			content = i18n.T(locale, "checkin.ended", day, i18n.FormatDate(locale, dayErr.LastDate))
+		default:
+			content = i18n.T(locale, "checkin.future", day, i18n.FormatDate(locale, dayErr.Today))
+		}
+	}
+
+	h.sendDM(s, log, r.UserID, content)
+}
+
+// sendDM tells userID why their check-in wasn't recorded
+func (h *ReactionHandler) sendDM(s discord.Session, log *logger.Logger, userID, content string) {
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		log.Error("Failed to open DM channel for check-in guidance: %v", err)
+		return
+	}
+	if _, err := s.ChannelMessageSend(channel.ID, content); err != nil {
+		log.Error("Failed to send check-in guidance: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/discord/ui"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// bookService returns the book service from the registry, or nil
+func (h *InteractionHandler) bookService() *services.BookService {
+	for _, svc := range h.services.GetServices() {
+		if bs, ok := svc.(*services.BookService); ok {
+			return bs
+		}
+	}
+	return nil
+}
+
+// handleReadingCommand handles /reading: book starts the book the user is reading and
+// pages logs their progress through it
+func (h *InteractionHandler) handleReadingCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	bookService := h.bookService()
+	if bookService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.books")))
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var title string
+	var pages int
+	for _, option := range subcommand.Options {
+		switch option.Name {
+		case "title":
+			title = option.StringValue()
+		case "pages":
+			pages = int(option.IntValue())
+		}
+	}
+
+	if subcommand.Name == "book" {
+		book, shelved, err := bookService.StartBook(i.Member.User.ID, i.Member.User.Username, title, pages)
+		if err != nil {
+			RequestLogger(i).Error("Starting book failed: %v", err)
+			respondEphemeral(s, i, i18n.T(locale, "reading.error", err))
+			return
+		}
+		RequestLogger(i).Info("Started book %d (%d pages)", book.ID, book.TotalPages)
+		content := i18n.T(locale, "reading.book_started", book.Title, book.TotalPages)
+		if shelved != nil {
+			content += i18n.T(locale, "reading.book_shelved", shelved.Title, shelved.PagesRead, shelved.TotalPages)
+		}
+		respondEphemeral(s, i, content)
+		return
+	}
+
+	book, err := bookService.LogPages(i.Member.User.ID, i.Member.User.Username, pages)
+	if errors.Is(err, services.ErrNoBook) {
+		respondEphemeral(s, i, i18n.T(locale, "reading.no_book"))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Logging pages failed: %v", err)
+		respondEphemeral(s, i, writeErrorMessage(locale, "reading.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Logged %d pages of book %d (%d/%d)", pages, book.ID, book.PagesRead, book.TotalPages)
+	if book.Status == services.BookFinished {
+		respondEphemeral(s, i, i18n.T(locale, "reading.book_finished", book.Title, book.TotalPages))
+		return
+	}
+	respondEphemeral(s, i, i18n.T(locale, "reading.pages_logged", pages, book.Title, book.PagesRead, book.TotalPages,
+		ui.ProgressBar(book.PagesRead, book.TotalPages, ui.DefaultBarWidth)))
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/shutdown"
+	"github.com/75-hard-discord-bot/internal/tracing"
+)
+
+// HandlerFunc handles a single routed interaction
+type HandlerFunc func(s discord.Session, i *discordgo.InteractionCreate)
+
+// Middleware wraps a HandlerFunc with shared behaviour (logging, auth, cooldowns, ...)
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// customIDSeparator separates the route prefix from arguments in component CustomIDs
+const customIDSeparator = ":"
+
+// CustomID builds a component CustomID from a route prefix and arguments,
+// e.g. CustomID("start_confirm", userID, "1700000000") -> "start_confirm:123:1700000000"
+func CustomID(prefix string, args ...string) string {
+	return strings.Join(append([]string{prefix}, args...), customIDSeparator)
+}
+
+// ParseCustomID splits a component CustomID into its route prefix and arguments
+func ParseCustomID(customID string) (string, []string) {
+	parts := strings.Split(customID, customIDSeparator)
+	return parts[0], parts[1:]
+}
+
+// RouteName returns the name an interaction is routed by: the command name for
+// slash commands and the CustomID prefix for modals and components
+func RouteName(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		return i.ApplicationCommandData().Name
+	case discordgo.InteractionModalSubmit:
+		prefix, _ := ParseCustomID(i.ModalSubmitData().CustomID)
+		return prefix
+	case discordgo.InteractionMessageComponent:
+		prefix, _ := ParseCustomID(i.MessageComponentData().CustomID)
+		return prefix
+	}
+	return ""
+}
+
+// InteractionUser returns the user behind an interaction (guild member or DM user)
+func InteractionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// Router dispatches interactions to registered handlers through a middleware chain
+type Router struct {
+	commands   map[string]HandlerFunc
+	modals     map[string]HandlerFunc
+	components map[string]HandlerFunc
+	middleware []Middleware
+}
+
+// NewRouter creates a new interaction router
+func NewRouter() *Router {
+	return &Router{
+		commands:   make(map[string]HandlerFunc),
+		modals:     make(map[string]HandlerFunc),
+		components: make(map[string]HandlerFunc),
+	}
+}
+
+// Use appends middleware; the first added is the outermost wrapper
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Command registers a slash command handler by command name
+func (r *Router) Command(name string, handler HandlerFunc) {
+	r.commands[name] = handler
+}
+
+// Modal registers a modal submit handler by CustomID prefix (see CustomID)
+func (r *Router) Modal(customID string, handler HandlerFunc) {
+	r.modals[customID] = handler
+}
+
+// Component registers a button/select handler by CustomID prefix (see CustomID)
+func (r *Router) Component(prefix string, handler HandlerFunc) {
+	r.components[prefix] = handler
+}
+
+// Handle is the discordgo InteractionCreate handler for every routed interaction
+func (r *Router) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) {
+	r.Dispatch(discord.Wrap(session), i)
+}
+
+// Dispatch routes an interaction through the middleware chain to its handler
+func (r *Router) Dispatch(s discord.Session, i *discordgo.InteractionCreate) {
+	var routes map[string]HandlerFunc
+	var kind string
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		routes, kind = r.commands, "command"
+	case discordgo.InteractionModalSubmit:
+		routes, kind = r.modals, "modal"
+	case discordgo.InteractionMessageComponent:
+		routes, kind = r.components, "component"
+	default:
+		return
+	}
+
+	name := RouteName(i)
+	handler, ok := routes[name]
+	if !ok {
+		handler = unknownRoute(kind, name)
+	}
+
+	// Wrap in reverse so the first middleware added runs first
+	for idx := len(r.middleware) - 1; idx >= 0; idx-- {
+		handler = r.middleware[idx](handler)
+	}
+	handler(s, i)
+}
+
+// unknownRoute responds to interactions with no registered handler
+func unknownRoute(kind, name string) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		logger.Error("Unknown %s: %s", kind, name)
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(RequestLocale(i), "error.unknown_route", kind, name),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+}
+
+// RequestLogger returns a logger tagged with the interaction's correlation ID, command, user,
+// and guild. The interaction's snowflake ID is the correlation ID: it is unique per interaction
+// and is the same ID Discord reports for it, so every line logged while handling it can be grouped.
+func RequestLogger(i *discordgo.InteractionCreate) *logger.Logger {
+	userID := ""
+	if user := InteractionUser(i); user != nil {
+		userID = user.ID
+	}
+	return logger.With("correlation_id", i.ID, "command", RouteName(i), "user_id", userID, "guild_id", i.GuildID)
+}
+
+// requestContexts holds the traced context of each interaction being handled, keyed by interaction ID
+var requestContexts sync.Map
+
+// RequestContext returns the interaction's traced context, for passing to services and to
+// Discord REST calls as discordgo.WithContext(ctx) so they show up as child spans
+func RequestContext(i *discordgo.InteractionCreate) context.Context {
+	if ctx, ok := requestContexts.Load(i.ID); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
+
+// TracingMiddleware records each interaction as a span tagged with its command, guild, and user
+func TracingMiddleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		userID := ""
+		if user := InteractionUser(i); user != nil {
+			userID = user.ID
+		}
+		ctx, span := tracing.Start(context.Background(), "interaction "+RouteName(i), tracing.KindServer,
+			"discord.command", RouteName(i),
+			"discord.guild_id", i.GuildID,
+			"discord.user_id", userID,
+			"correlation_id", i.ID,
+		)
+		defer span.End()
+
+		requestContexts.Store(i.ID, ctx)
+		defer requestContexts.Delete(i.ID)
+
+		next(s, i)
+	}
+}
+
+// LoggingMiddleware logs every routed interaction with the user and how long it took
+func LoggingMiddleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		started := time.Now()
+		name := RouteName(i)
+		userID := ""
+		if user := InteractionUser(i); user != nil {
+			userID = user.ID
+		}
+
+		next(s, i)
+
+		elapsed := time.Since(started)
+		RequestLogger(i).With("duration_ms", elapsed.Milliseconds()).
+			Info("Handled interaction %s (user_id=%s) in %s", name, userID, elapsed.Round(time.Millisecond))
+	}
+}
+
+// RecoverMiddleware keeps a panicking handler from crashing the bot: the panic is logged
+// with its stack and interaction context (which also reports it to the error tracker when
+// one is configured) and the user gets an ephemeral apology
+func RecoverMiddleware(next HandlerFunc) HandlerFunc {
+	return func(s discord.Session, i *discordgo.InteractionCreate) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			RequestLogger(i).With("panic", true, "stack", string(debug.Stack())).
+				Error("Panic handling %s: %v", RouteName(i), r)
+
+			apology := i18n.T(RequestLocale(i), "error.panic")
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: apology,
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			})
+			if err != nil {
+				// The handler already acknowledged the interaction, so follow up instead
+				s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+					Content: apology,
+					Flags:   discordgo.MessageFlagsEphemeral,
+				})
+			}
+		}()
+
+		next(s, i)
+	}
+}
+
+// DrainMiddleware tracks in-flight interactions so shutdown can wait for them,
+// and turns new interactions away once shutdown has started
+func DrainMiddleware(coordinator *shutdown.Coordinator) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(s discord.Session, i *discordgo.InteractionCreate) {
+			if !coordinator.Acquire() {
+				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Content: i18n.T(RequestLocale(i), "error.restarting"),
+						Flags:   discordgo.MessageFlagsEphemeral,
+					},
+				})
+				return
+			}
+			defer coordinator.Release()
+
+			next(s, i)
+		}
+	}
+}
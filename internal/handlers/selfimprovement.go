@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// selfImprovementService returns the self-improvement service from the registry, or nil
+func (h *InteractionHandler) selfImprovementService() *services.SelfImprovementService {
+	for _, svc := range h.services.GetServices() {
+		if ss, ok := svc.(*services.SelfImprovementService); ok {
+			return ss
+		}
+	}
+	return nil
+}
+
+// handleSelfImprovementCommand handles /selfimprovement: start times a session and
+// stop logs the minutes it ran
+func (h *InteractionHandler) handleSelfImprovementCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	sessionService := h.selfImprovementService()
+	if sessionService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.selfimprovement")))
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	if subcommand.Name == "stop" {
+		session, err := sessionService.StopSession(i.Member.User.ID)
+		if errors.Is(err, services.ErrNoSession) {
+			respondEphemeral(s, i, i18n.T(locale, "selfimprovement.no_session"))
+			return
+		}
+		if err != nil {
+			RequestLogger(i).Error("Stopping self-improvement session failed: %v", err)
+			respondEphemeral(s, i, writeErrorMessage(locale, "selfimprovement.error", err))
+			return
+		}
+		RequestLogger(i).Info("Stopped self-improvement session %d: %d minutes (%s)", session.ID, session.Minutes, session.Status)
+		respondEphemeral(s, i, sessionClosedMessage(locale, session))
+		return
+	}
+
+	var activity string
+	for _, option := range subcommand.Options {
+		if option.Name == "activity" {
+			activity = option.StringValue()
+		}
+	}
+
+	session, closed, err := sessionService.StartSession(i.Member.User.ID, i.Member.User.Username, activity)
+	if errors.Is(err, services.ErrSessionRunning) {
+		respondEphemeral(s, i, i18n.T(locale, "selfimprovement.already_running", session.ActivityType, int(time.Since(session.StartedAt).Minutes())))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Starting self-improvement session failed: %v", err)
+		respondEphemeral(s, i, writeErrorMessage(locale, "selfimprovement.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Started self-improvement session %d for day %d", session.ID, session.ChallengeDay)
+	content := i18n.T(locale, "selfimprovement.started", session.ChallengeDay, session.ActivityType)
+	if closed != nil {
+		content += "\n\n" + sessionClosedMessage(locale, *closed)
+	}
+	respondEphemeral(s, i, content)
+}
+
+// sessionClosedMessage describes a stopped session: what it logged and how far the
+// day's feat has come, or why nothing was logged
+func sessionClosedMessage(locale string, session services.SelfImprovementSession) string {
+	if session.Status == services.SessionExpired {
+		return i18n.T(locale, "selfimprovement.expired", session.ChallengeDay, int(services.MaxSessionDuration.Hours()))
+	}
+
+	content := i18n.T(locale, "selfimprovement.logged", session.Minutes, session.ChallengeDay, session.ActivityType)
+	if session.CutOff {
+		content += i18n.T(locale, "selfimprovement.cut_off", session.ChallengeDay)
+	}
+	if session.DayMinutes >= services.SelfImprovementMinutes {
+		return content + i18n.T(locale, "selfimprovement.feat_done", session.DayMinutes)
+	}
+	return content + i18n.T(locale, "selfimprovement.feat_remaining", session.DayMinutes, services.SelfImprovementMinutes-session.DayMinutes)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleSettingsCommand handles the /settings slash command (admin only)
+func (h *InteractionHandler) handleSettingsCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get settings service from registry
+	var settingsService *services.SettingsService
+	for _, svc := range h.services.GetServices() {
+		if ss, ok := svc.(*services.SettingsService); ok {
+			settingsService = ss
+			break
+		}
+	}
+
+	if settingsService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.settings")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Applying a change can post the check-in message, which may outlast the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var content string
+
+	switch subcommand.Name {
+	case "list":
+		settings, err := settingsService.All(i.GuildID)
+		if err != nil {
+			content = i18n.T(locale, "settings.error_load", err)
+			break
+		}
+
+		names := make([]string, 0, len(services.SettingDescriptions))
+		for name := range services.SettingDescriptions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "settings.title"))
+		for _, name := range names {
+			value := i18n.T(locale, "settings.default")
+			if stored, ok := settings[name]; ok && name == services.SettingLocale {
+				value = i18n.Names[stored]
+			} else if ok && name == services.SettingTimezone {
+				value = stored
+			} else if ok {
+				value = fmt.Sprintf("<#%s>", stored)
+			}
+			message.WriteString(i18n.T(locale, "settings.entry", name, value, i18n.T(locale, "setting."+name)))
+		}
+		content = message.String()
+
+	case "set":
+		setting := subcommand.Options[0].StringValue()
+		channelID := subcommand.Options[1].ChannelValue(nil).ID
+		if err := settingsService.Set(i.GuildID, setting, channelID, i.Member.User.ID); err != nil {
+			content = i18n.T(locale, "settings.error_update", err)
+			break
+		}
+
+		RequestLogger(i).Info("Setting %s set to %s in guild_id=%s by user_id=%s", setting, channelID, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "settings.set", setting, channelID)
+
+	case "language":
+		language := subcommand.Options[0].StringValue()
+		if err := settingsService.Set(i.GuildID, services.SettingLocale, language, i.Member.User.ID); err != nil {
+			content = i18n.T(locale, "settings.error_update", err)
+			break
+		}
+
+		RequestLogger(i).Info("Setting %s set to %s in guild_id=%s by user_id=%s", services.SettingLocale, language, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "settings.language_set", i18n.Names[language])
+
+	case "timezone":
+		timezone := subcommand.Options[0].StringValue()
+		if err := settingsService.Set(i.GuildID, services.SettingTimezone, timezone, i.Member.User.ID); err != nil {
+			content = i18n.T(locale, "settings.error_update", err)
+			break
+		}
+
+		RequestLogger(i).Info("Setting %s set to %s in guild_id=%s by user_id=%s", services.SettingTimezone, timezone, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "settings.timezone_set", timezone)
+
+	case "reset":
+		setting := subcommand.Options[0].StringValue()
+		if err := settingsService.Reset(i.GuildID, setting); err != nil {
+			content = i18n.T(locale, "settings.error_reset", err)
+			break
+		}
+
+		RequestLogger(i).Info("Setting %s reset in guild_id=%s by user_id=%s", setting, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "settings.reset", setting)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing settings response: %v", err)
+	}
+}
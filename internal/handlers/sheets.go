@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleConfigSheet handles /config sheet set|status|sync|clear, which manages the
+// Google Sheet the server's challenge data is mirrored into
+func (h *InteractionHandler) handleConfigSheet(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get sheet service from registry
+	var sheetService *services.SheetService
+	for _, svc := range h.services.GetServices() {
+		if ss, ok := svc.(*services.SheetService); ok {
+			sheetService = ss
+			break
+		}
+	}
+
+	if sheetService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.sheets")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Syncing rewrites the whole sheet, which may outlast the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	subcommand := i.ApplicationCommandData().Options[0].Options[0]
+	email := sheetService.ServiceAccountEmail()
+	var content string
+
+	switch subcommand.Name {
+	case "set":
+		spreadsheetID, err := services.ParseSpreadsheetID(subcommand.Options[0].StringValue())
+		if err != nil {
+			content = i18n.T(locale, "sheets.invalid", err)
+			break
+		}
+		if err := sheetService.Set(i.GuildID, spreadsheetID, i.Member.User.ID); err != nil {
+			content = i18n.T(locale, "sheets.error", err)
+			break
+		}
+		RequestLogger(i).Info("Google Sheet %s set in guild_id=%s by user_id=%s", spreadsheetID, i.GuildID, i.Member.User.ID)
+
+		// The first sync shows right away whether the sheet was shared with the bot
+		sheet := services.GuildSheet{SpreadsheetID: spreadsheetID}
+		if err := sheetService.SyncGuild(i.GuildID); err != nil {
+			content = i18n.T(locale, "sheets.set_failed", sheet.URL(), err, email)
+			break
+		}
+		content = i18n.T(locale, "sheets.set", sheet.URL())
+
+	case "status":
+		sheet, err := sheetService.Get(i.GuildID)
+		if errors.Is(err, services.ErrSheetNotFound) {
+			content = i18n.T(locale, "sheets.none", email)
+			break
+		}
+		if err != nil {
+			content = i18n.T(locale, "sheets.error", err)
+			break
+		}
+
+		content = i18n.T(locale, "sheets.status", sheet.URL(), email)
+		if sheet.SyncedAt.Valid {
+			content += i18n.T(locale, "sheets.status_synced", sheet.SyncedAt.Time.Unix())
+		} else {
+			content += i18n.T(locale, "sheets.status_never")
+		}
+		if sheet.LastError != "" {
+			content += i18n.T(locale, "sheets.status_error", sheet.LastError)
+		}
+
+	case "sync":
+		err := sheetService.SyncGuild(i.GuildID)
+		if errors.Is(err, services.ErrSheetNotFound) {
+			content = i18n.T(locale, "sheets.none", email)
+			break
+		}
+		if err != nil {
+			content = i18n.T(locale, "sheets.sync_failed", err, email)
+			break
+		}
+		RequestLogger(i).Info("Google Sheet synced in guild_id=%s by user_id=%s", i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "sheets.synced")
+
+	case "clear":
+		cleared, err := sheetService.Clear(i.GuildID)
+		if err != nil {
+			content = i18n.T(locale, "sheets.error", err)
+			break
+		}
+		if !cleared {
+			content = i18n.T(locale, "sheets.none", email)
+			break
+		}
+		RequestLogger(i).Info("Google Sheet cleared in guild_id=%s by user_id=%s", i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "sheets.cleared")
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing sheet response: %v", err)
+	}
+}
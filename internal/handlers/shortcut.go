@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleShortcutCommand handles the /shortcut slash command, which manages the user's
+// key for the inbound logging webhook
+func (h *InteractionHandler) handleShortcutCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get webhook key and user services from registry
+	var webhookKeyService *services.WebhookKeyService
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		switch s := svc.(type) {
+		case *services.WebhookKeyService:
+			webhookKeyService = s
+		case *services.UserService:
+			userService = s
+		}
+	}
+
+	if webhookKeyService == nil || userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.shortcut")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	var content string
+	switch i.ApplicationCommandData().Options[0].Name {
+	case "key":
+		// Keys belong to an existing participant, whose username the webhook logs under
+		if _, err := userService.GetProgress(userID); errors.Is(err, services.ErrUserNotFound) {
+			content = i18n.T(locale, "shortcut.not_started")
+			break
+		} else if err != nil {
+			content = i18n.T(locale, "shortcut.error", err)
+			break
+		}
+
+		key, err := webhookKeyService.Rotate(userID)
+		if err != nil {
+			content = i18n.T(locale, "shortcut.error", err)
+			break
+		}
+		RequestLogger(i).Info("Webhook key rotated for user_id=%s", userID)
+		content = i18n.T(locale, "shortcut.key", key, userID)
+
+	case "revoke":
+		revoked, err := webhookKeyService.Revoke(userID)
+		if err != nil {
+			content = i18n.T(locale, "shortcut.error", err)
+			break
+		}
+		if !revoked {
+			content = i18n.T(locale, "shortcut.no_key")
+			break
+		}
+		RequestLogger(i).Info("Webhook key revoked for user_id=%s", userID)
+		content = i18n.T(locale, "shortcut.revoked")
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
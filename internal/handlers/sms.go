@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+	"github.com/75-hard-discord-bot/internal/twilio"
+)
+
+// handleSMSCommand handles the /sms slash command, which manages the phone number
+// check-in reminders are texted to
+func (h *InteractionHandler) handleSMSCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get SMS and user services from registry
+	var smsService *services.SMSService
+	var userService *services.UserService
+	for _, svc := range h.services.GetServices() {
+		switch s := svc.(type) {
+		case *services.SMSService:
+			smsService = s
+		case *services.UserService:
+			userService = s
+		}
+	}
+
+	if smsService == nil || userService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.sms")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var content string
+	switch subcommand.Name {
+	case "add":
+		number, err := twilio.NormalizeNumber(subcommand.Options[0].StringValue())
+		if err != nil {
+			content = i18n.T(locale, "sms.invalid", err)
+			break
+		}
+		// Reminders follow the user's challenge days
+		if _, err := userService.GetProgress(userID); errors.Is(err, services.ErrUserNotFound) {
+			content = i18n.T(locale, "sms.not_started")
+			break
+		} else if err != nil {
+			content = i18n.T(locale, "sms.error", err)
+			break
+		}
+
+		number, err = smsService.Add(userID, number, locale)
+		if errors.Is(err, services.ErrVerificationTooSoon) {
+			content = i18n.T(locale, "sms.too_soon")
+			break
+		}
+		if err != nil {
+			RequestLogger(i).Error("Failed to text verification code: %v", err)
+			content = i18n.T(locale, "sms.error", err)
+			break
+		}
+		RequestLogger(i).Info("Verification code texted for user_id=%s", userID)
+		content = i18n.T(locale, "sms.sent", number, int(services.VerificationCodeExpiry.Minutes()))
+
+	case "verify":
+		code := strings.ReplaceAll(subcommand.Options[0].StringValue(), " ", "")
+		err := smsService.Verify(userID, code)
+		switch {
+		case errors.Is(err, services.ErrSMSNotFound):
+			content = i18n.T(locale, "sms.none")
+		case errors.Is(err, services.ErrVerificationCodeInvalid):
+			content = i18n.T(locale, "sms.wrong_code")
+		case errors.Is(err, services.ErrVerificationCodeExpired):
+			content = i18n.T(locale, "sms.expired")
+		case err != nil:
+			content = i18n.T(locale, "sms.error", err)
+		default:
+			RequestLogger(i).Info("Phone number verified for user_id=%s", userID)
+			content = i18n.T(locale, "sms.verified")
+		}
+
+	case "remove":
+		removed, err := smsService.Remove(userID)
+		if err != nil {
+			content = i18n.T(locale, "sms.error", err)
+			break
+		}
+		if !removed {
+			content = i18n.T(locale, "sms.none")
+			break
+		}
+		RequestLogger(i).Info("Phone number removed for user_id=%s", userID)
+		content = i18n.T(locale, "sms.removed")
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleUndoCommand handles /undo, which reverses the user's latest water, exercise,
+// or check-in log from the last few minutes
+func (h *InteractionHandler) handleUndoCommand(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+
+	// Get journal service from registry
+	var journalService *services.JournalService
+	for _, svc := range h.services.GetServices() {
+		if js, ok := svc.(*services.JournalService); ok {
+			journalService = js
+			break
+		}
+	}
+
+	if journalService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.undo")))
+		return
+	}
+
+	undone, err := journalService.UndoLast(userID)
+	if errors.Is(err, services.ErrNothingToUndo) {
+		respondEphemeral(s, i, i18n.T(locale, "undo.nothing", int(services.UndoWindow.Minutes())))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Undo failed: %v", err)
+		respondEphemeral(s, i, i18n.T(locale, "undo.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Undid %s for day %d logged at %s", undone.Action, undone.ChallengeDay, undone.LoggedAt.Format("15:04:05"))
+	respondEphemeral(s, i, i18n.T(locale, "undo.done", i18n.T(locale, "undo.action."+undone.Action), undone.ChallengeDay))
+}
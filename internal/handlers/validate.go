@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// ValidationError is a user-facing input error; its message is shown verbatim.
+// Field is the input's name as shown to the user, already translated.
+type ValidationError struct {
+	Field string
+	key   string
+	args  []interface{}
+}
+
+// Error returns the user-facing message in the default locale
+func (e *ValidationError) Error() string {
+	return e.Message(i18n.Default)
+}
+
+// Message returns the user-facing message in locale
+func (e *ValidationError) Message(locale string) string {
+	return i18n.T(locale, e.key, e.args...)
+}
+
+// invalid builds a ValidationError for field from a catalog message
+func invalid(field, key string, args ...interface{}) error {
+	return &ValidationError{Field: field, key: key, args: args}
+}
+
+// ParseWholeNumber parses raw as a whole number, rejecting blanks, words, and decimals
+// with a message that names what was typed (e.g. "'abc' is not a number")
+func ParseWholeNumber(field, raw string) (int, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, invalid(field, "validation.required", field)
+	}
+
+	n, err := strconv.Atoi(value)
+	if err == nil {
+		return n, nil
+	}
+	if _, floatErr := strconv.ParseFloat(value, 64); floatErr == nil {
+		return 0, invalid(field, "validation.not_whole_number", field, value)
+	}
+	return 0, invalid(field, "validation.not_number", field, value)
+}
+
+// ParseDecimal parses raw as a number that may have a fractional part
+func ParseDecimal(field, raw string) (float64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, invalid(field, "validation.required", field)
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, invalid(field, "validation.not_number", field, value)
+	}
+	return n, nil
+}
+
+// ParseDate parses raw as a YYYY-MM-DD calendar date
+func ParseDate(field, raw string) (time.Time, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return time.Time{}, invalid(field, "validation.required", field)
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, invalid(field, "validation.not_date", field, value)
+	}
+	return date, nil
+}
+
+// ParseYesNo parses raw as yes or no, in English or Spanish
+func ParseYesNo(field, raw string) (bool, error) {
+	value := strings.TrimSpace(raw)
+	switch strings.ToLower(value) {
+	case "yes", "y", "sí", "si", "s", "true", "1", "✅":
+		return true, nil
+	case "no", "n", "false", "0", "❌":
+		return false, nil
+	case "":
+		return false, invalid(field, "validation.required", field)
+	}
+	return false, invalid(field, "validation.not_yes_no", field, value)
+}
+
+// RequireAtLeast rejects values below min; unit is appended to the limit (e.g. "minutes")
+func RequireAtLeast(field string, n, min int, unit string) error {
+	if n < min {
+		return invalid(field, "validation.at_least", field, min, unit)
+	}
+	return nil
+}
+
+// writeErrorMessage explains a failed log to the user: writes rejected for their
+// challenge day say why and how to fix it, and other errors are shown with key
+func writeErrorMessage(locale, key string, err error) string {
+	var dayErr *services.DayError
+	if !errors.As(err, &dayErr) {
+		return i18n.T(locale, key, err)
+	}
+	switch {
+	case errors.Is(err, services.ErrChallengeNotStarted):
+		return i18n.T(locale, "day.not_started", i18n.FormatDate(locale, dayErr.StartDate))
+	case errors.Is(err, services.ErrChallengeEnded):
+		return i18n.T(locale, "day.ended", i18n.FormatDate(locale, dayErr.LastDate))
+	}
+	return i18n.T(locale, "day.future", i18n.FormatDate(locale, dayErr.Date), i18n.FormatDate(locale, dayErr.Today))
+}
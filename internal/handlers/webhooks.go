@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// handleConfigWebhook handles /config webhook add|list|remove|test, which manages the
+// URLs that receive challenge events
+func (h *InteractionHandler) handleConfigWebhook(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	// Get webhook service from registry
+	var webhookService *services.WebhookService
+	for _, svc := range h.services.GetServices() {
+		if ws, ok := svc.(*services.WebhookService); ok {
+			webhookService = ws
+			break
+		}
+	}
+
+	if webhookService == nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.webhooks")),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0].Options[0]
+	if subcommand.Name == "test" {
+		h.testWebhook(s, i, webhookService, subcommand.Options[0].IntValue())
+		return
+	}
+	var content string
+
+	switch subcommand.Name {
+	case "add":
+		url, event, format := "", services.WebhookAllEvents, services.WebhookFormatStandard
+		for _, option := range subcommand.Options {
+			switch option.Name {
+			case "url":
+				url = strings.TrimSpace(option.StringValue())
+			case "event":
+				event = option.StringValue()
+			case "format":
+				format = option.StringValue()
+			}
+		}
+		if err := services.ValidateWebhookURL(url); err != nil {
+			content = i18n.T(locale, "webhooks.invalid", err)
+			break
+		}
+
+		webhook, err := webhookService.Add(i.GuildID, url, event, format, i.Member.User.ID)
+		if err != nil {
+			content = i18n.T(locale, "webhooks.error", err)
+			break
+		}
+		RequestLogger(i).Info("Webhook %d added in guild_id=%s by user_id=%s", webhook.ID, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "webhooks.added", webhook.ID, event, webhook.URL, webhook.Secret)
+		if format == services.WebhookFormatFlat {
+			content += i18n.T(locale, "webhooks.added_flat", webhook.ID)
+		}
+
+	case "list":
+		webhooks, err := webhookService.List(i.GuildID)
+		if err != nil {
+			content = i18n.T(locale, "webhooks.error", err)
+			break
+		}
+		if len(webhooks) == 0 {
+			content = i18n.T(locale, "webhooks.none")
+			break
+		}
+
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "webhooks.title"))
+		for _, webhook := range webhooks {
+			message.WriteString(i18n.T(locale, "webhooks.entry", webhook.ID, webhook.URL, strings.Join(webhook.Events, ", "), webhook.Format))
+		}
+		content = message.String()
+
+	case "remove":
+		webhookID := subcommand.Options[0].IntValue()
+		removed, err := webhookService.Remove(i.GuildID, webhookID)
+		if err != nil {
+			content = i18n.T(locale, "webhooks.error", err)
+			break
+		}
+		if !removed {
+			content = i18n.T(locale, "webhooks.missing", webhookID)
+			break
+		}
+		RequestLogger(i).Info("Webhook %d removed in guild_id=%s by user_id=%s", webhookID, i.GuildID, i.Member.User.ID)
+		content = i18n.T(locale, "webhooks.removed", webhookID)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// testWebhook sends a sample of each event the webhook receives right away, so
+// automation tools such as Zapier and Make can learn the fields, and reports how
+// each delivery went
+func (h *InteractionHandler) testWebhook(s discord.Session, i *discordgo.InteractionCreate, webhookService *services.WebhookService, webhookID int64) {
+	locale := RequestLocale(i)
+
+	// Each delivery can take up to its timeout, so reply once they're done
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	var content string
+	webhook, found, err := webhookService.Get(i.GuildID, webhookID)
+	switch {
+	case err != nil:
+		content = i18n.T(locale, "webhooks.error", err)
+	case !found:
+		content = i18n.T(locale, "webhooks.missing", webhookID)
+	default:
+		var message strings.Builder
+		message.WriteString(i18n.T(locale, "webhooks.test_title", webhook.ID))
+		for _, event := range services.WebhookEvents {
+			if !webhook.Wants(event) {
+				continue
+			}
+			body, err := webhook.Payload(services.WebhookSample(i.GuildID, event))
+			if err == nil {
+				err = services.DeliverWebhook(webhook.URL, webhook.Secret, body)
+			}
+			if err != nil {
+				RequestLogger(i).Warn("Test %s delivery to webhook %d failed: %v", event, webhook.ID, err)
+				message.WriteString(i18n.T(locale, "webhooks.test_failed", event, err))
+				continue
+			}
+			message.WriteString(i18n.T(locale, "webhooks.test_sent", event))
+		}
+		content = message.String()
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing webhook test response: %v", err)
+	}
+}
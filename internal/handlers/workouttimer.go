@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// workoutTimerService returns the workout timer service from the registry, or nil
+func (h *InteractionHandler) workoutTimerService() *services.WorkoutTimerService {
+	for _, svc := range h.services.GetServices() {
+		if ts, ok := svc.(*services.WorkoutTimerService); ok {
+			return ts
+		}
+	}
+	return nil
+}
+
+// WorkoutTimerContent is a workout timer message showing elapsed, which the bot keeps
+// editing while the timer runs
+func WorkoutTimerContent(locale string, elapsed time.Duration) string {
+	minutes := int(elapsed.Minutes())
+	return i18n.T(locale, "workout_timer.running", fmt.Sprintf("%d:%02d", minutes/60, minutes%60))
+}
+
+// handleExerciseStart handles /exercise start, which posts a timer message with Stop
+// and Cancel buttons for the user in the channel
+func (h *InteractionHandler) handleExerciseStart(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	locale := RequestLocale(i)
+	guildLocale := GuildLocale(i)
+
+	timerService := h.workoutTimerService()
+	if timerService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.workout_timer")))
+		return
+	}
+
+	timer, err := timerService.Start(userID, i.Member.User.Username, i.ChannelID)
+	if errors.Is(err, services.ErrTimerRunning) {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.already_open", int(timer.Elapsed(time.Now()).Minutes())))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Starting workout timer failed: %v", err)
+		respondEphemeral(s, i, writeErrorMessage(locale, "exercise.error", err))
+		return
+	}
+
+	timerID := strconv.FormatInt(timer.ID, 10)
+	message, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Content: WorkoutTimerContent(guildLocale, 0),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(guildLocale, "workout_timer.stop_button"),
+						Style:    discordgo.SuccessButton,
+						CustomID: CustomID("workout_stop", userID, timerID),
+					},
+					discordgo.Button{
+						Label:    i18n.T(guildLocale, "workout_timer.cancel_button"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: CustomID("workout_cancel", userID, timerID),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		err = timerService.SetMessage(timer.ID, message.ID)
+	}
+	if err != nil {
+		RequestLogger(i).Error("Posting workout timer %d failed: %v", timer.ID, err)
+		if cancelErr := timerService.Cancel(userID, timer.ID); cancelErr != nil {
+			RequestLogger(i).Error("Cancelling workout timer %d failed: %v", timer.ID, cancelErr)
+		}
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.post_failed"))
+		return
+	}
+
+	RequestLogger(i).Info("Started workout timer %d", timer.ID)
+	respondEphemeral(s, i, i18n.T(locale, "workout_timer.started"))
+}
+
+// workoutTimerArgs returns the timer ID from a workout timer button's CustomID, or false
+// when the button isn't the user's
+func workoutTimerArgs(i *discordgo.InteractionCreate) (int64, bool) {
+	_, args := ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 || args[0] != i.Member.User.ID {
+		return 0, false
+	}
+	timerID, err := strconv.ParseInt(args[1], 10, 64)
+	return timerID, err == nil
+}
+
+// handleWorkoutTimerStop stops a workout timer and asks for the workout's type and
+// location, which are logged with the measured duration
+func (h *InteractionHandler) handleWorkoutTimerStop(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	timerID, ok := workoutTimerArgs(i)
+	if !ok {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.not_yours"))
+		return
+	}
+	timerService := h.workoutTimerService()
+	if timerService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.workout_timer")))
+		return
+	}
+
+	timer, err := timerService.Stop(i.Member.User.ID, timerID)
+	switch {
+	case errors.Is(err, services.ErrWorkoutTooShort):
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.too_short", timer.Minutes, services.MinWorkoutMinutes))
+		return
+	case errors.Is(err, services.ErrTimerClosed), errors.Is(err, services.ErrTimerNotFound):
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.closed"))
+		return
+	case err != nil:
+		RequestLogger(i).Error("Stopping workout timer %d failed: %v", timerID, err)
+		respondEphemeral(s, i, i18n.T(locale, "exercise.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Stopped workout timer %d at %d minutes", timer.ID, timer.Minutes)
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: CustomID("workout_timer", strconv.FormatInt(timer.ID, 10)),
+			Title:    i18n.T(locale, "workout_timer.modal_title", timer.Minutes),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "workout_type",
+							Label:       i18n.T(locale, "exercise.modal.workout_type"),
+							Style:       discordgo.TextInputShort,
+							Placeholder: i18n.T(locale, "exercise.modal.workout_type_placeholder"),
+							Required:    false,
+							MaxLength:   50,
+						},
+					},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "workout_location",
+							Label:       i18n.T(locale, "exercise.modal.location"),
+							Style:       discordgo.TextInputShort,
+							Placeholder: i18n.T(locale, "exercise.modal.location_placeholder"),
+							Required:    false,
+							MaxLength:   10,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		RequestLogger(i).Error("Error showing workout timer form: %v", err)
+	}
+}
+
+// handleWorkoutTimerModal logs a stopped timer's workout with the type and location
+// from its form, and closes the timer message
+func (h *InteractionHandler) handleWorkoutTimerModal(s discord.Session, i *discordgo.InteractionCreate) {
+	userID := i.Member.User.ID
+	username := i.Member.User.Username
+	locale := RequestLocale(i)
+
+	_, args := ParseCustomID(i.ModalSubmitData().CustomID)
+	var timerID int64
+	var err error
+	if len(args) == 1 {
+		timerID, err = strconv.ParseInt(args[0], 10, 64)
+	}
+	if len(args) != 1 || err != nil {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.closed"))
+		return
+	}
+
+	timerService := h.workoutTimerService()
+	var exerciseService *services.ExerciseService
+	for _, svc := range h.services.GetServices() {
+		if es, ok := svc.(*services.ExerciseService); ok {
+			exerciseService = es
+			break
+		}
+	}
+	if timerService == nil || exerciseService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.exercise")))
+		return
+	}
+
+	// Stopping again returns the measurement the Stop button took
+	timer, err := timerService.Stop(userID, timerID)
+	if errors.Is(err, services.ErrTimerClosed) || errors.Is(err, services.ErrTimerNotFound) {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.closed"))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Loading workout timer %d failed: %v", timerID, err)
+		respondEphemeral(s, i, i18n.T(locale, "exercise.error", err))
+		return
+	}
+
+	fields := ParseModalFields(i.ModalSubmitData())
+	workoutType := fields.String("workout_type", "general")
+	workoutLocation := fields.String("workout_location", "indoor")
+
+	// Like quick logs, the core/mobility work gets the minimum
+	err = exerciseService.LogExerciseDetailed(userID, username, timer.Minutes, workoutType, workoutLocation, 10, "general")
+	if err != nil {
+		RequestLogger(i).Error("Logging timed workout failed: %v", err)
+		respondEphemeral(s, i, writeErrorMessage(locale, "exercise.error", err))
+		return
+	}
+	if err := timerService.MarkLogged(userID, timer.ID); err != nil {
+		RequestLogger(i).Error("Closing workout timer %d failed: %v", timer.ID, err)
+	}
+
+	RequestLogger(i).Info("Logged timed workout %d: %d min %s (%s)", timer.ID, timer.Minutes, workoutType, workoutLocation)
+	guildLocale := GuildLocale(i)
+	content := i18n.T(guildLocale, "workout_timer.logged", timer.Minutes, workoutType, workoutLocation)
+	if i.Message != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	} else {
+		respondEphemeral(s, i, content)
+	}
+	h.forum.Post(s, i.GuildID, userID, username, i18n.T(guildLocale, "exercise.forum_detailed",
+		timer.Minutes, workoutType, workoutLocation, 10, "general"))
+}
+
+// handleWorkoutTimerCancel discards a workout timer without logging it
+func (h *InteractionHandler) handleWorkoutTimerCancel(s discord.Session, i *discordgo.InteractionCreate) {
+	locale := RequestLocale(i)
+
+	timerID, ok := workoutTimerArgs(i)
+	if !ok {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.not_yours"))
+		return
+	}
+	timerService := h.workoutTimerService()
+	if timerService == nil {
+		respondEphemeral(s, i, i18n.T(locale, "error.service_unavailable", i18n.T(locale, "service.workout_timer")))
+		return
+	}
+
+	err := timerService.Cancel(i.Member.User.ID, timerID)
+	if errors.Is(err, services.ErrTimerClosed) || errors.Is(err, services.ErrTimerNotFound) {
+		respondEphemeral(s, i, i18n.T(locale, "workout_timer.closed"))
+		return
+	}
+	if err != nil {
+		RequestLogger(i).Error("Cancelling workout timer %d failed: %v", timerID, err)
+		respondEphemeral(s, i, i18n.T(locale, "exercise.error", err))
+		return
+	}
+
+	RequestLogger(i).Info("Cancelled workout timer %d", timerID)
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(GuildLocale(i), "workout_timer.cancelled"),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
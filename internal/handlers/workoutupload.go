@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/75-hard-discord-bot/internal/discord"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/services"
+	"github.com/75-hard-discord-bot/internal/workoutfile"
+)
+
+// maxWorkoutFileBytes caps /exercise upload files; a long GPS track is a few MB
+const maxWorkoutFileBytes = 25 << 20
+
+// workoutUploadSource marks uploaded workouts in exercise_completions metadata
+const workoutUploadSource = "upload"
+
+// handleExerciseUpload handles /exercise upload, which logs the workout in a GPX or
+// FIT file on the day it was recorded
+func (h *InteractionHandler) handleExerciseUpload(s discord.Session, i *discordgo.InteractionCreate, exerciseService *services.ExerciseService) {
+	userID := i.Member.User.ID
+	username := i.Member.User.Username
+
+	// Downloading and parsing a long track can take longer than the interaction deadline
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	data := i.ApplicationCommandData()
+	var attachment *discordgo.MessageAttachment
+	for _, option := range data.Options[0].Options {
+		if option.Name == "file" {
+			attachment = data.Resolved.Attachments[option.Value.(string)]
+		}
+	}
+
+	content, workout := h.uploadWorkout(i, exerciseService, userID, attachment)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		RequestLogger(i).Error("Error editing exercise upload response: %v", err)
+	}
+	if workout != nil {
+		h.forum.Post(s, i.GuildID, userID, username, i18n.T(GuildLocale(i), "exercise.forum_upload",
+			workout.Minutes, workout.Type, workoutLocation(workout)))
+	}
+}
+
+// uploadWorkout downloads, parses, and records the workout file, describing the
+// result; the workout is returned only when it was recorded
+func (h *InteractionHandler) uploadWorkout(i *discordgo.InteractionCreate, exerciseService *services.ExerciseService, userID string, attachment *discordgo.MessageAttachment) (string, *workoutfile.Workout) {
+	locale := RequestLocale(i)
+
+	var file bytes.Buffer
+	if err := discord.DownloadAttachment(attachment, maxWorkoutFileBytes, &file); err != nil {
+		return i18n.T(locale, "exercise.error", err), nil
+	}
+	workout, err := workoutfile.Parse(file.Bytes())
+	if err != nil {
+		return i18n.T(locale, "exercise.upload_invalid", err), nil
+	}
+	if workout.Minutes < services.MinWorkoutMinutes {
+		return i18n.T(locale, "exercise.upload_short", workout.Minutes, services.MinWorkoutMinutes), nil
+	}
+
+	// The file's clock is UTC; the workout counts on the day it was in the user's zone
+	loc := h.userTimezone(i)
+	location := workoutLocation(workout)
+	challengeDay, recorded, err := exerciseService.ImportWorkout(userID, workout.Start.In(loc), workout.Minutes,
+		workout.Type, location, workoutUploadSource, attachment.Filename)
+	if errors.Is(err, services.ErrUserNotFound) {
+		return i18n.T(locale, "import.not_started"), nil
+	}
+	if err != nil {
+		RequestLogger(i).Error("Workout upload failed: %v", err)
+		return i18n.T(locale, "exercise.error", err), nil
+	}
+	if !recorded {
+		return i18n.T(locale, "exercise.upload_not_recorded", challengeDay, workout.Start.In(loc).Format("2006-01-02")), nil
+	}
+
+	RequestLogger(i).Info("Uploaded %d min %s (%s) from %s for day %d", workout.Minutes, workout.Type, location, attachment.Filename, challengeDay)
+	content := i18n.T(locale, "exercise.upload_logged", challengeDay, workout.Minutes, workout.Type, location)
+	if workout.Meters > 0 {
+		content += i18n.T(locale, "exercise.upload_distance", workout.Meters/1000, workout.Miles())
+	}
+	return content, workout
+}
+
+// workoutLocation returns the location logged for a workout
+func workoutLocation(workout *workoutfile.Workout) string {
+	if workout.Outdoor {
+		return "outdoor"
+	}
+	return "indoor"
+}
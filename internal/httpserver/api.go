@@ -0,0 +1,368 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// maxAPIBodyBytes caps request bodies; every endpoint takes a small JSON object
+const maxAPIBodyBytes = 64 << 10
+
+// API serves the token-authenticated REST API under /api/v1/ so companion scripts and
+// widgets can use the same services as the bot:
+//
+//	GET  /api/v1/users/{user_id}/progress
+//	POST /api/v1/users/{user_id}/checkins
+//	POST /api/v1/users/{user_id}/water     {"amount": 16, "unit": "oz"}
+//	POST /api/v1/users/{user_id}/exercise  {"workout_minutes": 45, "workout_type": "run", ...}
+//
+// Users must have used the bot in Discord first, so their username is known.
+type API struct {
+	// Token is the bearer token every request must send
+	Token    string
+	Services *services.ServiceRegistry
+}
+
+// apiServices are the services the API calls; nil when not registered
+type apiServices struct {
+	users       *services.UserService
+	checkIns    *services.CheckInService
+	water       *services.WaterService
+	exercise    *services.ExerciseService
+	weighIns    *services.WeighInService
+	preferences *services.PreferencesService
+	webhookKeys *services.WebhookKeyService
+	summaries   *services.SummaryService
+	leaderboard *services.LeaderboardService
+}
+
+// apiRequest is one authenticated request for an existing user
+type apiRequest struct {
+	apiServices
+	log      *logger.Logger
+	progress services.Progress
+}
+
+// apiError is the JSON body of every error response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// amountResponse is an amount in the unit it's expressed in
+type amountResponse struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+// progressResponse is the body of GET /progress
+type progressResponse struct {
+	UserID        string          `json:"user_id"`
+	Username      string          `json:"username"`
+	StartDate     string          `json:"start_date"`
+	EndDate       string          `json:"end_date"`
+	Date          string          `json:"date"`
+	ChallengeDay  int             `json:"challenge_day"`
+	TotalDays     int             `json:"total_days"`
+	DaysCompleted int             `json:"days_completed"`
+	WaterToday    amountResponse  `json:"water_today"`
+	LatestWeight  *amountResponse `json:"latest_weight,omitempty"`
+}
+
+// waterRequest is the body of POST /water; a negative amount removes water
+type waterRequest struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"` // oz or l; defaults to the user's preference
+}
+
+// exerciseRequest is the body of POST /exercise; an empty body logs a default workout
+type exerciseRequest struct {
+	WorkoutMinutes  int    `json:"workout_minutes"`
+	WorkoutType     string `json:"workout_type"`
+	WorkoutLocation string `json:"workout_location"`
+	CoreMinutes     int    `json:"core_minutes"`
+	CoreType        string `json:"core_type"`
+}
+
+// Register adds the API routes to the server
+func (a API) Register(s *Server) {
+	s.Handle("/api/v1/", a.authenticate(http.HandlerFunc(a.route)))
+}
+
+// authenticate rejects requests without the bearer token
+func (a API) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			writeJSON(w, http.StatusUnauthorized, apiError{Error: "missing or invalid bearer token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// route dispatches /api/v1/users/{user_id}/{resource} by resource and method
+func (a API) route(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/"), "/")
+	if len(parts) != 3 || parts[0] != "users" || parts[1] == "" {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "not found"})
+		return
+	}
+	userID, resource := parts[1], parts[2]
+	log := logger.With("request_id", logger.NewCorrelationID(), "user_id", userID, "api_path", r.URL.Path)
+
+	handlers := map[string]map[string]func(apiRequest, http.ResponseWriter, *http.Request){
+		"progress": {http.MethodGet: apiRequest.getProgress},
+		"checkins": {http.MethodPost: apiRequest.postCheckIn},
+		"water":    {http.MethodPost: apiRequest.postWater},
+		"exercise": {http.MethodPost: apiRequest.postExercise},
+	}
+	methods, ok := handlers[resource]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "not found"})
+		return
+	}
+	handler, ok := methods[r.Method]
+	if !ok {
+		for method := range methods {
+			w.Header().Set("Allow", method)
+		}
+		writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed"})
+		return
+	}
+
+	request := apiRequest{apiServices: lookupServices(a.Services), log: log}
+	if request.users == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "user service not available"})
+		return
+	}
+	progress, err := request.users.GetProgress(userID)
+	if errors.Is(err, services.ErrUserNotFound) {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "user not found; they must use the bot in Discord first"})
+		return
+	}
+	if err != nil {
+		log.Error("API failed to load progress: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load user"})
+		return
+	}
+
+	request.progress = progress
+	r.Body = http.MaxBytesReader(w, r.Body, maxAPIBodyBytes)
+	handler(request, w, r)
+}
+
+// lookupServices finds the services the API, webhooks, and dashboard call in the registry
+func lookupServices(registry *services.ServiceRegistry) apiServices {
+	var found apiServices
+	for _, svc := range registry.GetServices() {
+		switch s := svc.(type) {
+		case *services.UserService:
+			found.users = s
+		case *services.CheckInService:
+			found.checkIns = s
+		case *services.WaterService:
+			found.water = s
+		case *services.ExerciseService:
+			found.exercise = s
+		case *services.WeighInService:
+			found.weighIns = s
+		case *services.PreferencesService:
+			found.preferences = s
+		case *services.WebhookKeyService:
+			found.webhookKeys = s
+		case *services.SummaryService:
+			found.summaries = s
+		case *services.LeaderboardService:
+			found.leaderboard = s
+		}
+	}
+	return found
+}
+
+// getProgress returns the user's challenge standing, today's water, and latest weigh-in
+// in the user's preferred units
+func (req apiRequest) getProgress(w http.ResponseWriter, r *http.Request) {
+	units := req.units()
+	response := progressResponse{
+		UserID:        req.progress.UserID,
+		Username:      req.progress.Username,
+		StartDate:     req.progress.StartDate.Format("2006-01-02"),
+		EndDate:       req.progress.EndDate.Format("2006-01-02"),
+		Date:          req.progress.Date.Format("2006-01-02"),
+		ChallengeDay:  req.progress.ChallengeDay,
+		TotalDays:     req.progress.TotalDays,
+		DaysCompleted: req.progress.DaysCompleted,
+		WaterToday:    amountResponse{Unit: units.Volume},
+	}
+
+	if req.water != nil {
+		amount, err := req.water.GetWaterIntake(req.progress.UserID, units)
+		if err != nil {
+			req.log.Error("API failed to load water intake: %v", err)
+		}
+		response.WaterToday.Amount = amount
+	}
+	if req.weighIns != nil {
+		weight, _, err := req.weighIns.GetLatestWeighIn(req.progress.UserID, units)
+		if err != nil {
+			req.log.Error("API failed to load latest weigh-in: %v", err)
+		} else if weight > 0 {
+			response.LatestWeight = &amountResponse{Amount: weight, Unit: units.Weight}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// postCheckIn records today's check-in for the user
+func (req apiRequest) postCheckIn(w http.ResponseWriter, r *http.Request) {
+	if req.checkIns == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "check-in service not available"})
+		return
+	}
+
+	checkIn, err := req.checkIns.RecordCheckIn(req.log, req.progress.UserID, req.progress.Username)
+	if writeDayError(w, err) {
+		return
+	}
+	if err != nil {
+		req.log.Error("API check-in failed: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to record check-in"})
+		return
+	}
+	// Retried requests land on a day that's already checked in and change nothing
+	status := http.StatusCreated
+	if !checkIn.FirstForDay {
+		status = http.StatusOK
+	}
+	req.log.Info("Check-in recorded via API for day %d", checkIn.ChallengeDay)
+	writeJSON(w, status, map[string]interface{}{"challenge_day": checkIn.ChallengeDay})
+}
+
+// postWater adds (or with a negative amount, removes) water for today
+func (req apiRequest) postWater(w http.ResponseWriter, r *http.Request) {
+	if req.water == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "water service not available"})
+		return
+	}
+
+	var body waterRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: fmt.Sprintf("invalid JSON body: %v", err)})
+		return
+	}
+	units := req.units()
+	if body.Unit != "" {
+		units.Volume = body.Unit
+	}
+	if err := services.ValidateUnits(units); err != nil || body.Amount == 0 {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: "amount must be non-zero and unit must be oz or l"})
+		return
+	}
+
+	var total float64
+	var err error
+	if body.Amount > 0 {
+		_, total, err = req.water.AddWater(req.progress.UserID, req.progress.Username, body.Amount, units)
+	} else {
+		_, total, err = req.water.SubtractWater(req.progress.UserID, req.progress.Username, -body.Amount, units)
+	}
+	if writeDayError(w, err) {
+		return
+	}
+	if err != nil {
+		req.log.Error("API water log failed: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to log water"})
+		return
+	}
+	req.log.Info("Water logged via API: %.2f %s", body.Amount, units.Volume)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total": amountResponse{Amount: total, Unit: units.Volume},
+		"goal":  amountResponse{Amount: units.FromOunces(services.WaterGoalOunces), Unit: units.Volume},
+	})
+}
+
+// postExercise logs today's workout
+func (req apiRequest) postExercise(w http.ResponseWriter, r *http.Request) {
+	if req.exercise == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "exercise service not available"})
+		return
+	}
+
+	var body exerciseRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: fmt.Sprintf("invalid JSON body: %v", err)})
+		return
+	}
+	if body.WorkoutMinutes < 0 || body.CoreMinutes < 0 {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: "durations can't be negative"})
+		return
+	}
+
+	var err error
+	if body.WorkoutMinutes == 0 {
+		err = req.exercise.LogExerciseQuick(req.progress.UserID, req.progress.Username)
+	} else {
+		err = req.exercise.LogExerciseDetailed(req.progress.UserID, req.progress.Username, body.WorkoutMinutes,
+			orDefault(body.WorkoutType, "general"), orDefault(body.WorkoutLocation, "indoor"),
+			body.CoreMinutes, orDefault(body.CoreType, "general"))
+	}
+	if writeDayError(w, err) {
+		return
+	}
+	if err != nil {
+		req.log.Error("API exercise log failed: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to log exercise"})
+		return
+	}
+	req.log.Info("Exercise logged via API for day %d", req.progress.ChallengeDay)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"challenge_day": req.progress.ChallengeDay})
+}
+
+// units returns the user's preferred units, or the defaults if they can't be loaded
+func (req apiRequest) units() services.Units {
+	if req.preferences == nil {
+		return services.DefaultUnits
+	}
+	units, err := req.preferences.GetUnits(req.progress.UserID)
+	if err != nil {
+		req.log.Error("API failed to load units, using the defaults: %v", err)
+	}
+	return units
+}
+
+// orDefault returns value, or fallback when value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// writeJSON writes body as JSON with the given status
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeDayError answers 409 for a log rejected because of its challenge day, such as
+// one before the user's start date, and reports whether err was one
+func writeDayError(w http.ResponseWriter, err error) bool {
+	var dayErr *services.DayError
+	if !errors.As(err, &dayErr) {
+		return false
+	}
+	writeJSON(w, http.StatusConflict, apiError{Error: dayErr.Error()})
+	return true
+}
@@ -0,0 +1,55 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// Calendar serves each member's challenge schedule to calendar apps, at the secret
+// URL from /calendar link:
+//
+//	GET /calendar/{token}.ics
+type Calendar struct {
+	Service *services.CalendarService
+}
+
+// Register adds the calendar route to the server
+func (h Calendar) Register(s *Server) {
+	s.Handle(services.CalendarPath, http.HandlerFunc(h.feed))
+}
+
+// feed writes the calendar for the token in the path
+func (h Calendar) feed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, services.CalendarPath), ".ics")
+	if !ok || token == "" || strings.Contains(token, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	calendar, err := h.Service.Feed(token)
+	if errors.Is(err, services.ErrCalendarNotFound) || errors.Is(err, services.ErrUserNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to build calendar feed: %v", err)
+		http.Error(w, "calendar unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	if err := calendar.Write(w, time.Now()); err != nil {
+		logger.Warn("Failed to write calendar feed: %v", err)
+	}
+}
@@ -0,0 +1,404 @@
+package httpserver
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+const (
+	// dashboardSessionCookie holds the session token after signing in
+	dashboardSessionCookie = "dashboard_session"
+	// dashboardStateCookie ties Discord's redirect back to the browser that started signing in
+	dashboardStateCookie = "dashboard_state"
+	// dashboardStateMaxAge is how long a member has to approve signing in, in seconds
+	dashboardStateMaxAge = 10 * 60
+	// dashboardLeaderboardSize is how many members the dashboard leaderboard lists
+	dashboardLeaderboardSize = 25
+)
+
+// Dashboard serves the web dashboard's sign-in and the JSON it's drawn from. Members
+// sign in with Discord and are then identified by a session cookie:
+//
+//	GET  /dashboard/v1/login        redirects to Discord to sign in
+//	GET  /dashboard/v1/callback     where Discord sends members back
+//	POST /dashboard/v1/logout
+//	GET  /dashboard/v1/me           the same progress as GET /api/v1/users/{user_id}/progress
+//	GET  /dashboard/v1/summary      the member's /summary text (?locale=es)
+//	GET  /dashboard/v1/calendar     completed feats for every day so far, and streaks
+//	GET  /dashboard/v1/charts       weigh-ins and daily water in the member's units
+//	GET  /dashboard/v1/leaderboard  the /leaderboard standings, names as the bot shows them
+//
+// Everything comes from the services the bot uses, so the dashboard never shows
+// different numbers than Discord does.
+type Dashboard struct {
+	Service  *services.DashboardService
+	Services *services.ServiceRegistry
+	// HomeURL is where members land after signing in; if it's on another origin, that
+	// origin may call the JSON endpoints with credentials
+	HomeURL string
+	// Locale is the language of summaries and pseudonyms unless a request asks for another
+	Locale string
+	// Secure marks cookies HTTPS-only; set it when PUBLIC_URL is https
+	Secure bool
+}
+
+// dashboardRequest is one signed-in request
+type dashboardRequest struct {
+	apiServices
+	log      *logger.Logger
+	progress services.Progress
+	locale   string
+}
+
+// featDay is one day of GET /calendar
+type featDay struct {
+	Day  int             `json:"day"`
+	Date string          `json:"date"`
+	Done map[string]bool `json:"done"`
+}
+
+// chartPoint is one point of a GET /charts series
+type chartPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// leaderboardRow is one member of GET /leaderboard
+type leaderboardRow struct {
+	Rank          int    `json:"rank"`
+	Name          string `json:"name"`
+	DaysCompleted int    `json:"days_completed"`
+	ExerciseDays  int    `json:"exercise_days"`
+	WaterGoalDays int    `json:"water_goal_days"`
+	WeighIns      int    `json:"weigh_ins"`
+	You           bool   `json:"you,omitempty"`
+}
+
+// Register adds the dashboard routes to the server
+func (h Dashboard) Register(s *Server) {
+	s.Handle(services.DashboardLoginPath, http.HandlerFunc(h.login))
+	s.Handle(services.DashboardCallbackPath, http.HandlerFunc(h.callback))
+	s.Handle(services.DashboardPath+"logout", h.cors(http.HandlerFunc(h.logout)))
+	s.Handle(services.DashboardPath, h.cors(http.HandlerFunc(h.route)))
+}
+
+// login sends the browser to Discord, remembering the state it must come back with
+func (h Dashboard) login(w http.ResponseWriter, r *http.Request) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		logger.Error("Failed to generate dashboard sign-in state: %v", err)
+		writePage(w, http.StatusInternalServerError, "Something went wrong. Please try again.")
+		return
+	}
+	state := hex.EncodeToString(raw)
+
+	h.setCookie(w, dashboardStateCookie, state, dashboardStateMaxAge)
+	http.Redirect(w, r, h.Service.AuthorizeURL(state), http.StatusFound)
+}
+
+// callback finishes signing in and sends the member on to the dashboard
+func (h Dashboard) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		writePage(w, http.StatusOK, "You weren't signed in. Open the dashboard again to try once more.")
+		return
+	}
+	cookie, err := r.Cookie(dashboardStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(query.Get("state"))) != 1 {
+		writePage(w, http.StatusBadRequest, "This sign-in link has expired or was opened in another browser. Open the dashboard again to sign in.")
+		return
+	}
+	h.setCookie(w, dashboardStateCookie, "", -1)
+
+	token, userID, err := h.Service.SignIn(query.Get("code"))
+	if errors.Is(err, services.ErrUserNotFound) {
+		writePage(w, http.StatusForbidden, "The dashboard shows your challenge progress, so use the bot in Discord first, then sign in again.")
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to sign in to the dashboard: %v", err)
+		writePage(w, http.StatusBadGateway, "Something went wrong signing in with Discord. Please try again.")
+		return
+	}
+
+	logger.With("user_id", userID).Info("Signed in to the dashboard")
+	h.setCookie(w, dashboardSessionCookie, token, int(services.DashboardSessionTTL.Seconds()))
+	http.Redirect(w, r, h.HomeURL, http.StatusFound)
+}
+
+// logout ends the browser's session, if it has one
+func (h Dashboard) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed"})
+		return
+	}
+
+	if cookie, err := r.Cookie(dashboardSessionCookie); err == nil {
+		if err := h.Service.SignOut(cookie.Value); err != nil {
+			logger.Error("Dashboard sign-out failed: %v", err)
+			writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to sign out"})
+			return
+		}
+	}
+	h.setCookie(w, dashboardSessionCookie, "", -1)
+	writeJSON(w, http.StatusOK, map[string]string{"status": "signed out"})
+}
+
+// route dispatches the signed-in endpoints by resource and method
+func (h Dashboard) route(w http.ResponseWriter, r *http.Request) {
+	resource := strings.Trim(strings.TrimPrefix(r.URL.Path, services.DashboardPath), "/")
+	log := logger.With("request_id", logger.NewCorrelationID(), "dashboard_path", r.URL.Path)
+
+	handlers := map[string]map[string]func(dashboardRequest, http.ResponseWriter, *http.Request){
+		"me":          {http.MethodGet: dashboardRequest.getMe},
+		"summary":     {http.MethodGet: dashboardRequest.getSummary},
+		"calendar":    {http.MethodGet: dashboardRequest.getCalendar},
+		"charts":      {http.MethodGet: dashboardRequest.getCharts},
+		"leaderboard": {http.MethodGet: dashboardRequest.getLeaderboard},
+	}
+	methods, ok := handlers[resource]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "not found"})
+		return
+	}
+	handler, ok := methods[r.Method]
+	if !ok {
+		for method := range methods {
+			w.Header().Set("Allow", method)
+		}
+		writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed"})
+		return
+	}
+
+	cookie, err := r.Cookie(dashboardSessionCookie)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, apiError{Error: "not signed in"})
+		return
+	}
+	userID, err := h.Service.Session(cookie.Value)
+	if errors.Is(err, services.ErrDashboardSessionNotFound) {
+		h.setCookie(w, dashboardSessionCookie, "", -1)
+		writeJSON(w, http.StatusUnauthorized, apiError{Error: "session expired; sign in again"})
+		return
+	}
+	if err != nil {
+		log.Error("Dashboard failed to load session: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load session"})
+		return
+	}
+	log = log.With("user_id", userID)
+
+	locale := h.Locale
+	if requested := r.URL.Query().Get("locale"); requested != "" {
+		if !i18n.IsSupported(requested) {
+			writeJSON(w, http.StatusBadRequest, apiError{Error: "unsupported locale"})
+			return
+		}
+		locale = requested
+	}
+
+	request := dashboardRequest{apiServices: lookupServices(h.Services), log: log, locale: locale}
+	if request.users == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "user service not available"})
+		return
+	}
+	progress, err := request.users.GetProgress(userID)
+	if err != nil {
+		// Deleting a user's data deletes their sessions, so this is a real failure
+		log.Error("Dashboard failed to load progress: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load user"})
+		return
+	}
+	request.progress = progress
+	handler(request, w, r)
+}
+
+// cors lets a dashboard served from HomeURL's origin call the JSON endpoints with
+// its cookies. Same-origin dashboards don't need it.
+func (h Dashboard) cors(next http.Handler) http.Handler {
+	origin := ""
+	if home, err := url.Parse(h.HomeURL); err == nil && home.Scheme != "" && home.Host != "" {
+		origin = home.Scheme + "://" + home.Host
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin != "" && r.Header.Get("Origin") == origin {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setCookie sets (or with maxAge -1, clears) a cookie scoped to the dashboard paths.
+// Lax keeps other sites from posting with it while letting Discord's redirect carry it.
+func (h Dashboard) setCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     services.DashboardPath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// getMe returns the member's progress exactly as the REST API does
+func (req dashboardRequest) getMe(w http.ResponseWriter, r *http.Request) {
+	apiRequest{apiServices: req.apiServices, log: req.log, progress: req.progress}.getProgress(w, r)
+}
+
+// getSummary returns the member's progress summary as /summary shows it to them
+func (req dashboardRequest) getSummary(w http.ResponseWriter, r *http.Request) {
+	if req.summaries == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "summary service not available"})
+		return
+	}
+
+	text, err := req.summaries.GetProgressSummary(r.Context(), req.locale, req.progress.Username, req.progress.UserID)
+	if err != nil {
+		req.log.Error("Dashboard failed to load summary: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load summary"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"text": text})
+}
+
+// getCalendar returns which feats the member completed on each day so far
+func (req dashboardRequest) getCalendar(w http.ResponseWriter, r *http.Request) {
+	if req.summaries == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "summary service not available"})
+		return
+	}
+
+	completions, err := req.summaries.GetCompletions(req.progress.UserID, req.progress.StartDate, 1, req.lastDay())
+	if err != nil {
+		req.log.Error("Dashboard failed to load completions: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load calendar"})
+		return
+	}
+	current, longest, err := req.summaries.GetStreaks(req.progress.UserID, req.progress.ChallengeDay)
+	if err != nil {
+		req.log.Error("Dashboard failed to load streaks: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load calendar"})
+		return
+	}
+
+	days := make([]featDay, len(completions))
+	for i, c := range completions {
+		days[i] = featDay{Day: c.Day, Date: c.Date.Format("2006-01-02"), Done: make(map[string]bool)}
+		for feat, done := range c.Done {
+			days[i].Done[services.CompletionFeats[feat]] = done
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"feats":          services.CompletionFeats,
+		"days":           days,
+		"current_streak": current,
+		"longest_streak": longest,
+	})
+}
+
+// getCharts returns the member's weigh-ins and daily water totals in their units
+func (req dashboardRequest) getCharts(w http.ResponseWriter, r *http.Request) {
+	if req.summaries == nil || req.preferences == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "summary service not available"})
+		return
+	}
+
+	prefs, err := req.preferences.Get(req.progress.UserID)
+	if err != nil {
+		req.log.Error("Dashboard failed to load preferences: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load charts"})
+		return
+	}
+	// Up to a weigh-in for every day of the challenge
+	weighIns, err := req.summaries.GetWeighIns(req.progress.UserID, req.progress.TotalDays, prefs)
+	if err != nil {
+		req.log.Error("Dashboard failed to load weigh-ins: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load charts"})
+		return
+	}
+	lastDay := req.lastDay()
+	ounces, err := req.summaries.GetWaterOunces(req.progress.UserID, 1, lastDay)
+	if err != nil {
+		req.log.Error("Dashboard failed to load water: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load charts"})
+		return
+	}
+
+	weight := make([]chartPoint, len(weighIns))
+	for i, w := range weighIns {
+		weight[i] = chartPoint{Date: w.Date.Format("2006-01-02"), Value: w.Weight}
+	}
+	// Every day gets a water point, so days without any show as zero
+	water := make([]chartPoint, 0, lastDay)
+	for day := 1; day <= lastDay; day++ {
+		water = append(water, chartPoint{
+			Date:  req.progress.StartDate.AddDate(0, 0, day-1).Format("2006-01-02"),
+			Value: prefs.Units.FromOunces(ounces[day]),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"weight": map[string]interface{}{"unit": prefs.Units.Weight, "points": weight},
+		"water": map[string]interface{}{
+			"unit":   prefs.Units.Volume,
+			"goal":   prefs.Units.FromOunces(services.WaterGoalOunces),
+			"points": water,
+		},
+	})
+}
+
+// getLeaderboard returns the standings, naming members as the bot's /leaderboard does
+func (req dashboardRequest) getLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if req.leaderboard == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "leaderboard service not available"})
+		return
+	}
+
+	entries, err := req.leaderboard.GetLeaderboard(dashboardLeaderboardSize)
+	if err != nil {
+		req.log.Error("Dashboard failed to load leaderboard: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load leaderboard"})
+		return
+	}
+
+	rows := make([]leaderboardRow, len(entries))
+	for i, e := range entries {
+		rows[i] = leaderboardRow{
+			Rank:          i + 1,
+			Name:          services.PublicName(req.locale, e.Privacy, e.UserID, e.Username),
+			DaysCompleted: e.DaysCompleted,
+			ExerciseDays:  e.ExerciseDays,
+			WaterGoalDays: e.WaterGoalDays,
+			WeighIns:      e.WeighInCount,
+			You:           e.UserID == req.progress.UserID,
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"entries": rows})
+}
+
+// lastDay is the latest challenge day with anything to show: today, or the final day
+// once the challenge is over
+func (req dashboardRequest) lastDay() int {
+	if req.progress.ChallengeDay > req.progress.TotalDays {
+		return req.progress.TotalDays
+	}
+	return req.progress.ChallengeDay
+}
@@ -0,0 +1,46 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// Fitbit serves the page members land on after approving the bot in /connect fitbit:
+//
+//	GET /oauth/fitbit/callback
+//
+// Fitbit data is polled, so unlike Strava there is no event endpoint.
+type Fitbit struct {
+	Service *services.FitbitService
+}
+
+// Register adds the Fitbit route to the server
+func (h Fitbit) Register(s *Server) {
+	s.Handle(services.FitbitCallbackPath, http.HandlerFunc(h.callback))
+}
+
+// callback finishes linking an account. The member sees the reply in their browser.
+func (h Fitbit) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		writePage(w, http.StatusOK, "Fitbit wasn't connected. Run /connect fitbit in Discord to try again.")
+		return
+	}
+
+	userID, err := h.Service.Complete(query.Get("state"), query.Get("code"))
+	if errors.Is(err, services.ErrInvalidOAuthState) {
+		writePage(w, http.StatusBadRequest, "This link has expired or was already used. Run /connect fitbit in Discord for a new one.")
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to link Fitbit account: %v", err)
+		writePage(w, http.StatusBadGateway, "Something went wrong linking Fitbit. Please try /connect fitbit again.")
+		return
+	}
+
+	logger.With("user_id", userID).Info("Fitbit connected")
+	writePage(w, http.StatusOK, "✅ Fitbit connected! Your water and steps will sync automatically. You can close this tab.")
+}
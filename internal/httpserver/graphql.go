@@ -0,0 +1,75 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/75-hard-discord-bot/internal/graphql"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// GraphQL serves read-only progress queries next to the REST API, with the same
+// bearer token:
+//
+//	POST /api/graphql         {"query": "...", "variables": {...}, "operationName": "..."}
+//	GET  /api/graphql/schema  the schema in the GraphQL schema language
+//
+// Query errors come back in the body's errors with status 200, as GraphQL clients expect.
+type GraphQL struct {
+	// Token is the bearer token every request must send
+	Token  string
+	Schema *graphql.Schema
+}
+
+// graphQLRequest is the body of POST /api/graphql
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Register adds the GraphQL routes to the server
+func (g GraphQL) Register(s *Server) {
+	authenticate := API{Token: g.Token}.authenticate
+	s.Handle("/api/graphql", authenticate(http.HandlerFunc(g.query)))
+	s.Handle("/api/graphql/schema", authenticate(http.HandlerFunc(g.schema)))
+}
+
+// query runs one query
+func (g GraphQL) query(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, graphql.Response{Errors: []graphql.Error{{Message: "method not allowed"}}})
+		return
+	}
+
+	var body graphQLRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxAPIBodyBytes)).Decode(&body); err != nil || body.Query == "" {
+		message := "body must be JSON with a query"
+		if err != nil {
+			message = fmt.Sprintf("invalid JSON body: %v", err)
+		}
+		writeJSON(w, http.StatusBadRequest, graphql.Response{Errors: []graphql.Error{{Message: message}}})
+		return
+	}
+
+	response := g.Schema.Execute(body.Query, body.OperationName, body.Variables)
+	if len(response.Errors) > 0 {
+		logger.With("request_id", logger.NewCorrelationID(), "graphql_operation", body.OperationName).
+			Warn("GraphQL query answered with %d errors, first: %s", len(response.Errors), response.Errors[0].Message)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// schema returns the schema so clients can generate types or check their queries
+func (g GraphQL) schema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, graphql.SDL)
+}
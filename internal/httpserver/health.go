@@ -0,0 +1,82 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// Probes answers /healthz and /readyz for container orchestrators
+type Probes struct {
+	// Connected reports whether the Discord gateway is up
+	Connected func() bool
+	// ShuttingDown reports whether graceful shutdown has started
+	ShuttingDown func() bool
+	// Monitor holds the latest service Health() and database ping results; nil without a database
+	Monitor *services.HealthMonitor
+}
+
+// probeResponse is the JSON body of both probes
+type probeResponse struct {
+	Status     string            `json:"status"`
+	Gateway    bool              `json:"gateway_connected"`
+	Components []componentStatus `json:"components,omitempty"`
+}
+
+type componentStatus struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Register adds /healthz and /readyz to the server
+func (p Probes) Register(s *Server) {
+	s.Handle("/healthz", http.HandlerFunc(p.healthz))
+	s.Handle("/readyz", http.HandlerFunc(p.readyz))
+}
+
+// healthz fails when the gateway is down or the last health check of the database
+// or any service failed, so the orchestrator can restart a stuck bot
+func (p Probes) healthz(w http.ResponseWriter, r *http.Request) {
+	response := probeResponse{Gateway: p.Connected()}
+	healthy := response.Gateway
+
+	if p.Monitor != nil {
+		for _, status := range p.Monitor.Statuses() {
+			response.Components = append(response.Components, componentStatus{
+				Name:      status.Name,
+				Healthy:   status.Healthy,
+				Error:     status.Error,
+				CheckedAt: status.CheckedAt,
+			})
+		}
+		healthy = healthy && p.Monitor.Healthy()
+	}
+
+	writeProbe(w, healthy, response)
+}
+
+// readyz fails until the gateway is connected and again once shutdown starts,
+// so traffic and rollouts wait for a bot that can actually respond
+func (p Probes) readyz(w http.ResponseWriter, r *http.Request) {
+	response := probeResponse{Gateway: p.Connected()}
+	writeProbe(w, response.Gateway && !p.ShuttingDown(), response)
+}
+
+// writeProbe writes the JSON body with 200 when ok, 503 otherwise
+func writeProbe(w http.ResponseWriter, ok bool, response probeResponse) {
+	response.Status = "ok"
+	code := http.StatusOK
+	if !ok {
+		response.Status = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(response)
+}
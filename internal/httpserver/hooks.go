@@ -0,0 +1,169 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// signatureHeader carries the HMAC-SHA256 of the timestamp and body for clients that can
+// compute one
+const signatureHeader = "X-Signature-256"
+
+// timestampHeader carries the Unix time, in seconds, that a signed request was signed at
+const timestampHeader = "X-Signature-Timestamp"
+
+// Hooks serves the inbound logging webhook for phone automations such as iOS Shortcuts
+// and Tasker, authenticated with the per-user key from /shortcut key:
+//
+//	POST /hooks/v1/users/{user_id}/log   water +16oz | workout 45min run
+//
+// The body is the entry as plain text, or JSON {"text": "..."}. Requests send the key
+// as "Authorization: Bearer <key>", or sign "<timestamp>.<body>" with it in
+// X-Signature-256 ("sha256=<hex HMAC-SHA256>"), with the Unix timestamp in
+// X-Signature-Timestamp, so the key itself never travels and a captured request can't
+// be replayed later.
+type Hooks struct {
+	Services *services.ServiceRegistry
+}
+
+// hookText is the JSON form of a webhook body
+type hookText struct {
+	Text string `json:"text"`
+}
+
+// Register adds the webhook route to the server
+func (h Hooks) Register(s *Server) {
+	s.Handle("/hooks/v1/users/", http.HandlerFunc(h.log))
+}
+
+// log records one quick log entry for the user in the path
+func (h Hooks) log(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/hooks/v1/users/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "log" {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "not found"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed"})
+		return
+	}
+	userID := parts[0]
+	log := logger.With("request_id", logger.NewCorrelationID(), "user_id", userID, "api_path", r.URL.Path)
+
+	found := lookupServices(h.Services)
+	if found.webhookKeys == nil || found.users == nil {
+		writeJSON(w, http.StatusServiceUnavailable, apiError{Error: "webhooks not available"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAPIBodyBytes))
+	if err != nil {
+		writeJSON(w, http.StatusRequestEntityTooLarge, apiError{Error: "body too large"})
+		return
+	}
+
+	bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	ok, err := found.webhookKeys.Verify(userID, bearer, r.Header.Get(signatureHeader), r.Header.Get(timestampHeader), body)
+	if err != nil {
+		log.Error("Webhook key check failed: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to check key"})
+		return
+	}
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, apiError{Error: "missing or invalid key or signature, or a stale timestamp"})
+		return
+	}
+
+	text := string(body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var payload hookText
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeJSON(w, http.StatusBadRequest, apiError{Error: fmt.Sprintf("invalid JSON body: %v", err)})
+			return
+		}
+		text = payload.Text
+	}
+	entry, err := services.ParseQuickLog(text)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+		return
+	}
+
+	progress, err := found.users.GetProgress(userID)
+	if errors.Is(err, services.ErrUserNotFound) {
+		writeJSON(w, http.StatusNotFound, apiError{Error: "user not found"})
+		return
+	}
+	if err != nil {
+		log.Error("Webhook failed to load progress: %v", err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: "failed to load user"})
+		return
+	}
+
+	request := apiRequest{apiServices: found, log: log, progress: progress}
+	var message string
+	switch entry.Kind {
+	case services.QuickLogWater:
+		message, err = request.logWater(entry)
+	case services.QuickLogWorkout:
+		message, err = request.logWorkout(entry)
+	}
+	if writeDayError(w, err) {
+		return
+	}
+	if err != nil {
+		log.Error("Webhook %s log failed: %v", entry.Kind, err)
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: fmt.Sprintf("failed to log %s", entry.Kind)})
+		return
+	}
+
+	log.Info("Webhook logged %s for day %d", entry.Kind, progress.ChallengeDay)
+	// Shortcuts shows the message as-is, so keep it readable
+	writeJSON(w, http.StatusOK, map[string]interface{}{"message": message, "challenge_day": progress.ChallengeDay})
+}
+
+// logWater records a quick water entry and describes the new total
+func (req apiRequest) logWater(entry services.QuickLog) (string, error) {
+	if req.water == nil {
+		return "", fmt.Errorf("water service not available")
+	}
+
+	units := req.units()
+	if entry.Unit != "" {
+		units.Volume = entry.Unit
+	}
+	var total float64
+	var err error
+	if entry.Amount > 0 {
+		_, total, err = req.water.AddWater(req.progress.UserID, req.progress.Username, entry.Amount, units)
+	} else {
+		_, total, err = req.water.SubtractWater(req.progress.UserID, req.progress.Username, -entry.Amount, units)
+	}
+	if err != nil {
+		return "", err
+	}
+	goal := units.FromOunces(services.WaterGoalOunces)
+	return fmt.Sprintf("💧 %.4g %s logged - %.4g/%.4g %s today", entry.Amount, units.Volume, total, goal, units.Volume), nil
+}
+
+// logWorkout records a quick workout entry
+func (req apiRequest) logWorkout(entry services.QuickLog) (string, error) {
+	if req.exercise == nil {
+		return "", fmt.Errorf("exercise service not available")
+	}
+
+	err := req.exercise.LogExerciseDetailed(req.progress.UserID, req.progress.Username, entry.Minutes,
+		orDefault(entry.Type, "general"), "indoor", 10, "general")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("💪 %d min workout logged for day %d", entry.Minutes, req.progress.ChallengeDay), nil
+}
@@ -0,0 +1,16 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// RegisterPprof adds the net/http/pprof profiling endpoints under /debug/pprof/.
+// They expose goroutine stacks and memory contents, so only serve them on localhost.
+func RegisterPprof(s *Server) {
+	s.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
+	s.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
+	s.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
+	s.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	s.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+}
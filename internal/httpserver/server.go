@@ -0,0 +1,65 @@
+// Package httpserver runs the bot's small HTTP server for health probes and other endpoints
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// shutdownTimeout bounds how long Stop waits for in-flight requests
+const shutdownTimeout = 5 * time.Second
+
+// Server is an HTTP server that is started alongside the bot and stopped on shutdown
+type Server struct {
+	mux    *http.ServeMux
+	server *http.Server
+}
+
+// New creates a server for addr (e.g. ":8080"); register handlers before Start
+func New(addr string) *Server {
+	mux := http.NewServeMux()
+	return &Server{
+		mux: mux,
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Handle registers a handler for a path pattern
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start binds the address, so a port conflict is reported here, then serves in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops accepting connections and waits for in-flight requests to finish
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		logger.Warn("⚠️  HTTP server did not shut down cleanly: %v", err)
+	}
+}
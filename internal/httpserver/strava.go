@@ -0,0 +1,97 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+	"github.com/75-hard-discord-bot/internal/strava"
+)
+
+// Strava serves the endpoints Strava calls:
+//
+//	GET  /oauth/strava/callback   where members land after approving the bot in /connect strava
+//	GET  /hooks/strava            push subscription confirmation
+//	POST /hooks/strava            activity and deauthorization events
+type Strava struct {
+	Service *services.StravaService
+}
+
+// Register adds the Strava routes to the server
+func (h Strava) Register(s *Server) {
+	s.Handle(services.StravaCallbackPath, http.HandlerFunc(h.callback))
+	s.Handle(services.StravaEventsPath, http.HandlerFunc(h.events))
+}
+
+// callback finishes linking an account. The member sees the reply in their browser.
+func (h Strava) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		writePage(w, http.StatusOK, "Strava wasn't connected. Run /connect strava in Discord to try again.")
+		return
+	}
+	if !strings.Contains(query.Get("scope"), strava.Scope) {
+		writePage(w, http.StatusBadRequest, "The bot needs to see your activities to log them. Run /connect strava again and leave that box checked.")
+		return
+	}
+
+	userID, err := h.Service.Complete(query.Get("state"), query.Get("code"))
+	if errors.Is(err, services.ErrInvalidOAuthState) {
+		writePage(w, http.StatusBadRequest, "This link has expired or was already used. Run /connect strava in Discord for a new one.")
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to link Strava account: %v", err)
+		writePage(w, http.StatusBadGateway, "Something went wrong linking Strava. Please try /connect strava again.")
+		return
+	}
+
+	logger.With("user_id", userID).Info("Strava connected")
+	writePage(w, http.StatusOK, fmt.Sprintf("✅ Strava connected! Workouts of %d minutes or more will be logged for you. You can close this tab.", services.MinWorkoutMinutes))
+}
+
+// events confirms the push subscription and receives activity events
+func (h Strava) events(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		if query.Get("hub.mode") != "subscribe" || query.Get("hub.verify_token") != h.Service.VerifyToken() {
+			writeJSON(w, http.StatusForbidden, apiError{Error: "invalid verify token"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"hub.challenge": query.Get("hub.challenge")})
+
+	case http.MethodPost:
+		var event strava.Event
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxAPIBodyBytes)).Decode(&event); err != nil {
+			writeJSON(w, http.StatusBadRequest, apiError{Error: fmt.Sprintf("invalid JSON body: %v", err)})
+			return
+		}
+
+		// Strava wants an answer within two seconds and fetching the activity can take
+		// longer, so reply first. A lost event isn't retried; the member can log by hand.
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		go func() {
+			if err := h.Service.HandleEvent(event); err != nil {
+				logger.With("strava_owner_id", event.OwnerID, "strava_object_id", event.ObjectID).
+					Error("Failed to handle Strava %s %s event: %v", event.ObjectType, event.AspectType, err)
+			}
+		}()
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed"})
+	}
+}
+
+// writePage replies with a short plain-text message for a browser
+func writePage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, message)
+}
@@ -0,0 +1,588 @@
+package i18n
+
+// english is the reference catalog that other locales fall back to
+var english = map[string]string{
+	// Dates (arguments: month name, day, year)
+	"date.long":      "%[1]s %[2]d, %[3]d",
+	"date.short":     "%[1]s %[2]d, %[3]d",
+	"date.zoned":     "%s (%s)",
+	"date.month_day": "%[1]s %[2]d",
+
+	"month.long.january":   "January",
+	"month.long.february":  "February",
+	"month.long.march":     "March",
+	"month.long.april":     "April",
+	"month.long.may":       "May",
+	"month.long.june":      "June",
+	"month.long.july":      "July",
+	"month.long.august":    "August",
+	"month.long.september": "September",
+	"month.long.october":   "October",
+	"month.long.november":  "November",
+	"month.long.december":  "December",
+
+	"month.short.january":   "Jan",
+	"month.short.february":  "Feb",
+	"month.short.march":     "Mar",
+	"month.short.april":     "Apr",
+	"month.short.may":       "May",
+	"month.short.june":      "Jun",
+	"month.short.july":      "Jul",
+	"month.short.august":    "Aug",
+	"month.short.september": "Sep",
+	"month.short.october":   "Oct",
+	"month.short.november":  "Nov",
+	"month.short.december":  "Dec",
+
+	// Shared
+	"error.service_unavailable": "❌ %s service not available.",
+	"error.unknown_route":       "❌ Unknown %s: %s",
+	"error.panic":               "❌ Something went wrong handling that. The error has been logged; please try again.",
+	"error.restarting":          "🔄 The bot is restarting. Please try again in a minute.",
+	"error.admin_only":          "⛔ This command is restricted to challenge admins.",
+	"error.feature_disabled":    "🚫 The %s feature is turned off in this server.",
+	"error.cooldown":            "🐢 Slow down! You can use `/%s` again in %d seconds.",
+	"error.duplicate_submit":    "⏳ That form was already submitted, so it was only saved once.",
+	"error.invalid_input":       "❌ %s",
+	"button.cancel":             "Cancel",
+
+	"service.exercise":        "Exercise",
+	"service.summary":         "Summary",
+	"service.leaderboard":     "Leaderboard",
+	"service.weighin":         "Weigh-in",
+	"service.user":            "User",
+	"service.water":           "Water",
+	"service.export":          "Export",
+	"service.settings":        "Settings",
+	"service.features":        "Feature flag",
+	"service.templates":       "Template",
+	"service.webhooks":        "Webhook",
+	"service.preferences":     "Preferences",
+	"service.shortcut":        "Shortcut",
+	"service.strava":          "Strava",
+	"service.fitbit":          "Fitbit",
+	"service.import":          "Import",
+	"service.sheets":          "Google Sheets",
+	"service.calendar":        "Calendar",
+	"service.sms":             "SMS",
+	"service.email":           "Email",
+	"service.coach":           "Coach",
+	"service.undo":            "Undo",
+	"service.edit":            "Edit",
+	"service.corrections":     "Corrections",
+	"service.meals":           "Meals",
+	"service.finances":        "Finances",
+	"service.books":           "Books",
+	"service.selfimprovement": "Self-improvement",
+	"service.workout_timer":   "Workout timer",
+
+	// Input validation
+	"validation.required":         "%s is required",
+	"validation.not_whole_number": "%s: '%s' is not a whole number",
+	"validation.not_number":       "%s: '%s' is not a number",
+	"validation.at_least":         "%s must be at least %d %s.",
+	"validation.not_date":         "%s: '%s' is not a date like 2026-01-31",
+	"validation.not_yes_no":       "%s: '%s' isn't yes or no",
+	"unit.minutes":                "minutes",
+	"unit.lbs":                    "lbs",
+	"unit.kg":                     "kg",
+	"unit.oz":                     "oz",
+	"unit.l":                      "L",
+
+	// Writes rejected for their challenge day
+	"day.not_started": "⏳ Nothing was logged: your challenge starts %s. Use `/start` to change it.",
+	"day.ended":       "🏁 Nothing was logged: your challenge's last day was %s. Use `/start` to begin a new one.",
+	"day.future":      "⏳ Nothing was logged: %s hasn't started yet in your time zone, where it's %s. Check your time zone with `/preferences`.",
+
+	// /exercise
+	"exercise.error": "❌ Error logging exercise: %v",
+	"exercise.quick_logged": "✅ **Exercise logged!**\n" +
+		"Workout: 30 minutes\n" +
+		"Core/Mobility: 10 minutes\n\n" +
+		"Use `/exercise detailed` for custom durations.",
+	"exercise.forum_quick": "💪 Exercise logged: 30 min workout, 10 min core/mobility",
+	"exercise.detailed_logged": "✅ **Exercise logged!**\n" +
+		"**Workout:** %d minutes (%s, %s)\n" +
+		"**Core/Mobility:** %d minutes (%s)",
+	"exercise.forum_detailed":                 "💪 Exercise logged: %d min %s (%s), %d min %s",
+	"exercise.forum_upload":                   "💪 Workout uploaded: %d min %s (%s)",
+	"exercise.upload_logged":                  "✅ **Workout logged for day %d!**\n**Workout:** %d minutes (%s, %s)",
+	"exercise.upload_distance":                "\n**Distance:** %.2f km (%.2f mi)",
+	"exercise.upload_short":                   "❌ That workout is %d minutes long; only workouts of %d minutes or more count.",
+	"exercise.upload_not_recorded":            "ℹ️ Nothing changed: day %d (%s) is outside your challenge, or already has a workout you logged by hand or a longer upload.",
+	"exercise.upload_invalid":                 "❌ Couldn't read that file: %v\nUpload a .gpx or .fit activity file exported from your watch or fitness app.",
+	"exercise.modal.title":                    "Log Exercise",
+	"exercise.modal.workout_duration":         "Workout Duration (minutes)",
+	"exercise.modal.workout_type":             "Workout Type",
+	"exercise.modal.workout_type_placeholder": "e.g., running, weights, cycling",
+	"exercise.modal.location":                 "Location (indoor/outdoor)",
+	"exercise.modal.location_placeholder":     "indoor or outdoor",
+	"exercise.modal.core_duration":            "Core/Mobility Duration (minutes)",
+	"exercise.modal.core_type":                "Core/Mobility Type",
+	"exercise.modal.core_type_placeholder":    "e.g., abs, planks, stretching, yoga",
+	"exercise.field.workout_duration":         "Workout duration",
+	"exercise.field.core_duration":            "Core/mobility duration",
+
+	// /diet meal
+	"diet.meal_logged":       "✅ **Meal logged for day %d:** %s",
+	"diet.meal_photo":        "\n📷 Photo saved with it.",
+	"diet.not_image":         "❌ %s isn't an image. Attach a photo of the meal, such as a JPEG or PNG.",
+	"diet.photo_unavailable": "❌ This server has no storage set up for meal photos. Log the meal again without the photo.",
+	"diet.error":             "❌ Error logging meal: %v",
+	"diet.forum_meal":        "🍽️ Meal: %s",
+	"diet.forum_meal_photo":  "🍽️ Meal (with photo): %s",
+
+	// /finances purchase
+	"finances.purchase_logged":        "✅ Logged a necessary purchase of %.2f (%s) for day %d.",
+	"finances.purchase_non_compliant": "⚠️ Logged %.2f (%s) for day %d. It wasn't a necessity, so today's finances feat is marked missed.",
+	"finances.error":                  "❌ Error logging purchase: %v",
+	"finances.week_title":             "💰 **Spending %s to %s:** %.2f over %d purchase(s)",
+	"finances.week_necessary":         "\n• Necessities: %.2f",
+	"finances.week_unnecessary":       "\n• Non-necessities: %.2f",
+	"finances.week_flagged":           " (%d day(s) marked missed)",
+	"finances.week_categories":        "\n**By category:**",
+	"finances.week_category":          "\n• %s: %.2f",
+	"finances.week_none":              "ℹ️ No purchases logged from %s to %s.",
+	"finances.summary_error":          "❌ Error getting your spending: %v",
+
+	// /reading
+	"reading.book_started":  "📖 Started **%s** (%d pages). Log what you read with `/reading pages`.",
+	"reading.book_shelved":  "\nSet aside **%s** at page %d of %d.",
+	"reading.pages_logged":  "📖 Logged %d pages of **%s**: %d/%d\n%s",
+	"reading.book_finished": "🎉 You finished **%s** (%d pages)! It's on your `/summary` reading list.",
+	"reading.no_book":       "ℹ️ You're not reading a book yet. Start one with `/reading book title:<title> pages:<pages>`.",
+	"reading.error":         "❌ Error logging reading: %v",
+
+	// /selfimprovement
+	"selfimprovement.started":         "⏱️ Timer started for day %d (%s). Run `/selfimprovement stop` when you're done.",
+	"selfimprovement.already_running": "⏱️ Your timer (%s) has been running for %d minute(s). Stop it with `/selfimprovement stop` first.",
+	"selfimprovement.no_session":      "ℹ️ You don't have a timer running. Start one with `/selfimprovement start`.",
+	"selfimprovement.logged":          "⏹️ Logged %d minute(s) for day %d (%s).",
+	"selfimprovement.cut_off":         " It was still running when day %d ended, so only the minutes up to midnight count.",
+	"selfimprovement.feat_done":       "\n✅ That day's total is %d minutes, which completes the self-improvement feat.",
+	"selfimprovement.feat_remaining":  "\nThat day's total is %d minutes; %d more to complete the self-improvement feat.",
+	"selfimprovement.expired":         "⚠️ Your timer from day %d counted more than %d hours, so it was treated as left running and nothing was logged.",
+	"selfimprovement.error":           "❌ Error with your self-improvement timer: %v",
+
+	// /exercise start
+	"workout_timer.running":       "⏱️ **Workout in progress:** %s elapsed\nPress **Stop** when you're done to log it.",
+	"workout_timer.stop_button":   "Stop",
+	"workout_timer.cancel_button": "Cancel",
+	"workout_timer.started":       "⏱️ Timer started. Press **Stop** on its message when you're done.",
+	"workout_timer.already_open":  "⏱️ You already have a workout timer open, started %d minute(s) ago. Stop or cancel it from its message first.",
+	"workout_timer.post_failed":   "❌ Couldn't post the timer in this channel. Try another channel, or check the bot can send messages here.",
+	"workout_timer.not_yours":     "❌ Only the person who started this timer can stop or cancel it.",
+	"workout_timer.closed":        "ℹ️ This timer is no longer running.",
+	"workout_timer.too_short":     "⏱️ The timer has only run %d minute(s); workouts need at least %d to count. Keep going, or press **Cancel** to discard it.",
+	"workout_timer.modal_title":   "Log your %d min workout",
+	"workout_timer.logged":        "✅ **Workout logged:** %d min %s (%s), plus 10 min core/mobility",
+	"workout_timer.cancelled":     "🚫 Workout timer cancelled. Nothing was logged.",
+	"workout_timer.expired":       "⌛ This workout timer ran for %d hours without being stopped, so it expired and nothing was logged.",
+
+	// /summary
+	"summary.error":               "❌ Error getting summary: %v",
+	"summary.all_title":           "📊 **Challenge Progress Summary (All Users)**\n\n",
+	"summary.all_user":            "**%s** (Day %d/%d",
+	"summary.all_days_completed":  "  ✅ Days Completed: %d\n\n",
+	"summary.no_users":            "No users found.",
+	"summary.user_not_found":      "❌ User '%s' not found.",
+	"summary.user_title":          "📊 **Challenge Progress Summary: %s**\n\n",
+	"summary.user_challenge_day":  "**Challenge:** Day %d/%d",
+	"summary.user_days_added":     " (+%d days added)",
+	"summary.user_started":        "\n**Started:** %s\n\n",
+	"summary.user_days_completed": "**Days Completed:** %d\n",
+	"summary.user_calories":       "**Calories Today:** %d kcal (from %s)\n",
+	"summary.user_progress":       "\n**Progress:** %s",
+	"summary.user_books":          "\n\n📚 **Books finished (%d):**",
+	"summary.user_book":           "\n• %s (%d pages)",
+
+	// /leaderboard
+	"leaderboard.error": "❌ Error getting leaderboard: %v",
+	"leaderboard.title": "🏆 **Challenge Leaderboard**\n\n",
+	"leaderboard.empty": "No progress recorded yet.",
+	"leaderboard.entry": "%s **%s** - %d days ✅ | 💪 %d | 💧 %d\n",
+
+	// /weighin
+	"weighin.invalid":     "❌ Weight must be between 0.01 and %.2f %s.",
+	"weighin.error":       "❌ Error recording weigh-in: %v",
+	"weighin.recorded":    "✅ **Weigh-in recorded!**\n**Weight:** %.2f %s",
+	"weighin.change_up":   "\n📈 **Change:** +%.2f %s from last weigh-in (Day %d)",
+	"weighin.change_down": "\n📉 **Change:** %.2f %s from last weigh-in (Day %d)",
+	"weighin.notes":       "\n📝 **Notes:** %s",
+	"weighin.forum":       "⚖️ Weigh-in: %.2f %s",
+
+	// /start
+	"start.invalid_date":         "❌ Invalid date format. Use YYYY-MM-DD (e.g., 2024-01-15)",
+	"start.invalid_confirmation": "❌ Invalid confirmation. Please try /start again.",
+	"start.cancelled":            "❌ Challenge start cancelled.",
+	"start.error":                "❌ Error starting challenge: %v",
+	"start.confirm_button":       "Yes, Start Challenge",
+	"start.rules": "**75 Half Chub Challenge Rules:**\n\n" +
+		"1. Follow a diet (no cheat meals, no alcohol)\n" +
+		"2. One 30+ minute workout (indoor/outdoor doesn't matter; walking only counts with weight vest)\n" +
+		"3. 10+ minutes of core/mobility\n" +
+		"4. Drink 1 gallon of water (doesn't have to be plain)\n" +
+		"5. 30 minutes of intentional self-improvement (reading, learning, journaling, studying, etc.)\n" +
+		"6. Daily check-in (react with ✅)\n" +
+		"7. Weekly progress photo\n" +
+		"8. Finances: necessities only\n\n" +
+		"**Challenge Details:**\n" +
+		"📅 **Start Date:** %s\n" +
+		"🏁 **End Date:** %s\n" +
+		"📊 **Duration:** 75 days (base)\n\n" +
+		"⚠️ **Failure Rule:** If you miss any task, add 7 days to your end date. You may publicly request forgiveness for emergencies (sick kids, etc.) to waive penalties.\n\n" +
+		"Ready to begin?",
+	"start.started": "✅ **Challenge Started!**\n\n" +
+		"📅 **Start Date:** %s\n" +
+		"🏁 **End Date:** %s\n" +
+		"📊 **Current Day:** Day %d\n\n" +
+		"Good luck! You've got this! 💪",
+	"start.announcement": "🎉 **%s** has started the 75 Half Chub Challenge!\n\n" +
+		"📅 Started on: **%s**\n" +
+		"🏁 Challenge will complete on: **%s**\n" +
+		"📊 Currently on: **Day %d**\n\n" +
+		"Let's support them on this journey! 💪",
+
+	// /water
+	"water.invalid":        "❌ The amount must be greater than 0.",
+	"water.error_get":      "❌ Error getting water intake: %v",
+	"water.error_add":      "❌ Error adding water: %v",
+	"water.error_subtract": "❌ Error subtracting water: %v",
+	"water.today":          "💧 **Today's Water Intake**\n**Total:** %.2f / %.4g %s",
+	"water.added":          "💧 **Water added!**\n**Added:** %[1].2f %[4]s\n**Total today:** %[2].2f / %[3].4g %[4]s",
+	"water.subtracted":     "💧 **Water subtracted!**\n**Subtracted:** %[1].2f %[4]s\n**Total today:** %[2].2f / %[3].4g %[4]s",
+	"water.goal_reached":   "\n\n🎉 **Goal reached!** You've hit 1 gallon (%.4g %s)!",
+	"water.remaining":      "\n📊 **Remaining:** %.2f %s to reach 1 gallon",
+	"water.forum":          "💧 Water: +%[1].2f %[4]s (%[2].2f / %[3].4g %[4]s today)",
+
+	// /deletemydata
+	"privacy.delete_warning": "⚠️ **Delete all of your data?**\n\n" +
+		"This permanently removes your challenge record, check-ins, exercise, diet, water, " +
+		"self-improvement and finance entries, weigh-ins, progress photos, and failure history.\n\n" +
+		"**This cannot be undone.**",
+	"privacy.delete_button":    "Yes, Delete Everything",
+	"privacy.delete_not_yours": "❌ You can only delete your own data.",
+	"privacy.delete_error":     "❌ Error deleting data: %v",
+	"privacy.deleted": "🗑️ **All of your data has been deleted.**\n" +
+		"Removed %d record(s). Use `/start` if you ever want to rejoin the challenge.",
+	"privacy.delete_cancelled": "❌ Data deletion cancelled. Nothing was removed.",
+
+	// /exportmydata
+	"export.error":          "❌ Error exporting data: %v",
+	"export.format_error":   "❌ Error formatting export: %v",
+	"export.dm_open_failed": "❌ Could not open a DM with you. Check that DMs from server members are enabled.",
+	"export.dm_send_failed": "❌ Could not send you a DM. Check that DMs from server members are enabled.",
+	"export.dm_message":     "📦 Here is everything the 75 Half Chub Bot has stored about you.",
+	"export.sent":           "✅ Your data export has been sent to your DMs.",
+
+	// /export
+	"admin_export.range":         "The 'to' date (%[2]s) is before the 'from' date (%[1]s).",
+	"admin_export.attached":      "📦 %d completions from %s to %s, one CSV per table.",
+	"admin_export.too_large":     "❌ The export is %d MB, over Discord's %d MB limit, and no object storage is configured. Try a shorter date range or one member.",
+	"admin_export.upload_failed": "❌ The export was too large for Discord, and uploading it failed: %v",
+	"admin_export.uploaded":      "📦 %d completions from %s to %s, one CSV per table. The export was too large for Discord, so download it here (the link works for 24 hours):\n%s",
+
+	// /backup
+	"backup.not_configured": "❌ Backups are not configured. Set `BACKUP_S3_BUCKET` and credentials to enable them.",
+	"backup.failed":         "❌ Backup failed: %v",
+	"backup.complete":       "✅ **Backup complete**\n**Object:** `%s`\n**Size:** %.1f KB",
+
+	// /features
+	"features.error_load":   "❌ Error loading feature flags: %v",
+	"features.error_update": "❌ Error updating feature flag: %v",
+	"features.title":        "🎛️ **Feature Flags**\n\n",
+	"features.entry":        "**%s** - %s\n",
+	"features.on":           "✅ on",
+	"features.off":          "🚫 off",
+	"features.enabled":      "✅ **%s** is now enabled.",
+	"features.disabled":     "✅ **%s** is now disabled.",
+
+	// /settings
+	"settings.error_load":        "❌ Error loading settings: %v",
+	"settings.error_update":      "❌ Error updating setting: %v",
+	"settings.error_reset":       "❌ Error resetting setting: %v",
+	"settings.title":             "⚙️ **Settings**\n\n",
+	"settings.entry":             "**%s** - %s\n  %s\n",
+	"settings.default":           "_environment default_",
+	"settings.set":               "✅ **%s** is now <#%s> - applied without a restart.",
+	"settings.language_set":      "✅ Bot messages in this server are now in **%s** - applied without a restart.",
+	"settings.timezone_set":      "✅ Dates in this server are now shown in **%s** - applied without a restart.",
+	"settings.reset":             "✅ **%s** is back to the environment default.",
+	"setting.checkin_channel_id": "Channel for the daily check-in message",
+	"setting.summary_channel_id": "Channel for active-user rosters and summaries",
+	"setting.photos_channel_id":  "Channel for progress photo posts",
+	"setting.admin_channel_id":   "Channel for health and backup alerts",
+	"setting.forum_channel_id":   "Forum channel for per-user progress posts",
+	"setting.timezone":           "Time zone for dates in channel posts and for members who haven't chosen one",
+	"setting.locale":             "Language for bot messages (members whose Discord language has a translation see their own)",
+
+	// /preferences
+	"preferences.error_load":       "❌ Error loading your preferences: %v",
+	"preferences.error_update":     "❌ Error saving your preferences: %v",
+	"preferences.units_saved":      "✅ Preferences saved.\n\n",
+	"preferences.units":            "📏 **Your units**\n**Weight:** %s\n**Water:** %s",
+	"preferences.error_reset":      "❌ Error resetting your preference: %v",
+	"preferences.title":            "⚙️ **Your preferences**\n\n",
+	"preferences.entry":            "**%s** - %s\n  %s\n",
+	"preferences.default_timezone": "_server default (%s)_",
+	"preferences.set":              "✅ **%s** is now **%s**.",
+	"preferences.reset":            "✅ **%s** is back to its default.",
+
+	"preference.timezone":      "Time zone for your challenge days and dates",
+	"preference.weight_unit":   "Weigh-in unit: lbs or kg",
+	"preference.volume_unit":   "Water unit: oz or l",
+	"preference.reminders":     "Daily reminders: on or off",
+	"preference.reminder_time": "When to remind you, as HH:MM in your time zone",
+	"preference.quiet_hours":   "When the bot won't ping you, as HH:MM-HH:MM in your time zone, or off",
+	"preference.privacy":       "How you appear in public posts: public, anonymous, or hidden",
+	"preference.delivery":      "Where nudges reach you: channel, dm, or sms",
+
+	// Privacy
+	"privacy.pseudonym": "Participant %s",
+
+	// /shortcut
+	"shortcut.key":         "🔑 **Your webhook key** (shown once - keep it secret):\n`%s`\n\nPOST entries like `water +16oz` or `workout 45min run` to `/hooks/v1/users/%s/log` on the bot's HTTP address, with the header `Authorization: Bearer <key>`. Run `/shortcut key` again to replace it.",
+	"shortcut.revoked":     "✅ Your webhook key is revoked.",
+	"shortcut.no_key":      "ℹ️ You don't have a webhook key.",
+	"shortcut.not_started": "❌ Run `/start` first, so the bot knows you.",
+	"shortcut.error":       "❌ Error managing your webhook key: %v",
+
+	// /calendar
+	"calendar.link":        "📅 **Your calendar feed** (keep it private):\n%s\n\nSubscribe to it from your calendar app (e.g. Google Calendar → Other calendars → From URL, or Apple Calendar → File → New Calendar Subscription). It follows your challenge dates, including added days. `/calendar revoke` turns it off.",
+	"calendar.revoked":     "✅ Your calendar feed is revoked. Subscriptions to it will stop updating.",
+	"calendar.no_link":     "ℹ️ You don't have a calendar feed.",
+	"calendar.not_started": "❌ Run `/start` first, so the bot knows your challenge dates.",
+	"calendar.error":       "❌ Error managing your calendar feed: %v",
+
+	// Calendar feed events
+	"calendar.name":              "75 Hard - %s",
+	"calendar.start":             "🏁 75 Hard: Day 1",
+	"calendar.start_description": "Your %d-day challenge starts today.",
+	"calendar.photo":             "📸 75 Hard: week %d progress photo",
+	"calendar.photo_description": "Take this week's progress photo.",
+	"calendar.milestone":         "⭐ 75 Hard: day %d of %d",
+	"calendar.finish":            "🏆 75 Hard: final day (day %d)",
+
+	// /sms
+	"sms.sent":        "📱 Texted a code to %s. Enter it with `/sms verify code:<code>` within %d minutes to get check-in reminders by text.",
+	"sms.verified":    "✅ Your number is verified. Check-in reminders now come by text, outside your quiet hours. Set `delivery` with `/preferences set` to change that, or run `/sms remove` to delete your number.",
+	"sms.wrong_code":  "❌ That code isn't right. Check the text and try again.",
+	"sms.expired":     "❌ That code has expired. Run `/sms add` to get a new one.",
+	"sms.too_soon":    "⏳ A code was just sent. Wait a minute before asking for another.",
+	"sms.invalid":     "❌ %v",
+	"sms.none":        "ℹ️ You haven't added a phone number. Add one with `/sms add number:+15551234567`.",
+	"sms.removed":     "✅ Your phone number is deleted. Reminders that came by text now go to the check-in channel.",
+	"sms.not_started": "❌ Run `/start` first, so the bot knows your challenge dates.",
+	"sms.error":       "❌ Error managing SMS reminders: %v",
+	"sms.code":        "Your 75 Hard bot code is %s. It expires in %d minutes.",
+
+	// /email
+	"email.sent":         "📧 Emailed a code to %s. Enter it with `/email verify code:<code>` within %d minutes to get a weekly report every Sunday evening.",
+	"email.verified":     "✅ Your address is verified. Your report arrives every Sunday evening; run `/email send` to get this week's now, or `/email remove` to stop.",
+	"email.wrong_code":   "❌ That code isn't right. Check the email and try again.",
+	"email.expired":      "❌ That code has expired. Run `/email add` to get a new one.",
+	"email.too_soon":     "⏳ A code was just sent. Wait a minute before asking for another.",
+	"email.invalid":      "❌ %v",
+	"email.none":         "ℹ️ You haven't added a verified email address. Add one with `/email add address:<address>`.",
+	"email.report_sent":  "✅ This week's report is on its way to your inbox.",
+	"email.removed":      "✅ Your email address is deleted, and reports have stopped.",
+	"email.not_started":  "❌ Run `/start` first, so the bot knows your challenge dates.",
+	"email.error":        "❌ Error managing email reports: %v",
+	"email.code_subject": "Your 75 Hard bot code",
+	"email.code_body":    "Your 75 Hard bot code is %s. It expires in %d minutes. If you didn't ask for it, you can ignore this email.",
+
+	// /coach
+	"coach.reply":       "🧑‍🏫 %s",
+	"coach.remaining":   "\n\n-# %d of %d coaching replies left today",
+	"coach.limit":       "⏳ You've used all %d coaching replies for today. Try again tomorrow.",
+	"coach.not_started": "❌ Run `/start` first, so the coach has stats to look at.",
+	"coach.error":       "❌ The coach couldn't answer right now, and it didn't count toward your limit: %v",
+
+	// /undo
+	"undo.done":                  "↩️ Undid %s for day %d. Run `/undo` again to undo the log before it.",
+	"undo.nothing":               "🤷 There's nothing to undo. Only water, exercise, and check-in logs from the last %d minutes can be undone.",
+	"undo.error":                 "❌ Error undoing your last log: %v",
+	"undo.action.water_add":      "your water log",
+	"undo.action.water_subtract": "your water removal",
+	"undo.action.exercise":       "your exercise log",
+	"undo.action.check_in":       "your check-in",
+
+	// /edit
+	"edit.modal_title":   "Edit day %d",
+	"edit.field.workout": "Workout minutes (0 or at least %d)",
+	"edit.field.water":   "Water (%s, 0 for none)",
+	"edit.field.diet":    "Followed your diet? (yes/no)",
+	"edit.yes":           "yes",
+	"edit.no":            "no",
+	"edit.saved":         "✏️ **Day %d updated**\n**Workout:** %d min\n**Water:** %.2f %s\n**Diet followed:** %s",
+	"edit.unchanged":     "Nothing changed for day %d.",
+	"edit.not_started":   "❌ You haven't started the challenge. Run `/start` first.",
+	"edit.window_closed": "⏰ That day can't be edited anymore. Days can be corrected until %d hours after they end.",
+	"edit.error":         "❌ Error editing that day: %v",
+
+	// Admin corrections: /adjust, /setstart, /setday, /backfill
+	"corrections.not_enrolled": "❌ <@%s> hasn't started the challenge.",
+	"adjust.zero":              "❌ Days must be a positive number to add days or a negative one to take them off.",
+	"adjust.too_many":          "❌ That would take off more days than <@%s> has had added. Their original end date can't move earlier.",
+	"adjust.error":             "❌ Error adjusting the end date: %v",
+	"adjust.added":             "✅ Added %d day(s) to <@%s>'s challenge. Their last day is now %s, with %d day(s) added in all.",
+	"adjust.removed":           "✅ Took %d day(s) off <@%s>'s challenge. Their last day is now %s, with %d day(s) added in all.",
+	"adjust.silent":            "\nIt wasn't announced.",
+	"setstart.logs_before":     "❌ Some of <@%s>'s logs would fall before the new start (%v). Pick an earlier date.",
+	"setstart.error":           "❌ Error moving the start date: %v",
+	"setstart.done":            "✅ Moved <@%s>'s start from %s to %s. Today is day %d and their last day is %s; %d logged row(s) were re-linked.",
+	"backfill.outside":         "❌ %s isn't a day <@%s> can have logged: their challenge runs %s to %s, up to today.",
+	"backfill.already_logged":  "ℹ️ %s is already logged for <@%s> on day %d. Nothing was changed.",
+	"backfill.error":           "❌ Error backfilling: %v",
+	"backfill.done":            "✅ Logged %s for <@%s> on day %d (%s). It's marked as admin-entered in the audit log.",
+
+	// Natural-language logging from check-in channel messages
+	"natlog.prompt":         "📝 Log %s?",
+	"natlog.water":          "**%.4g %s** of water",
+	"natlog.workout":        "a **%d min** %s workout",
+	"natlog.confirm_button": "Log it",
+	"natlog.dismiss_button": "No thanks",
+	"natlog.water_logged":   "💧 Logged %[1].4g %[4]s of water - %[2].4g / %[3].4g %[4]s today",
+	"natlog.workout_logged": "💪 Logged a %d min %s workout, with 10 min of core/mobility",
+	"natlog.dismissed":      "👌 Not logged.",
+	"natlog.not_yours":      "❌ Only the person who posted that message can log it.",
+	"natlog.invalid":        "❌ This log button is no longer valid.",
+
+	// Weekly email report
+	"email.subject":         "75 Hard weekly report: day %d of %d",
+	"email.heading":         "%s's weekly report",
+	"email.progress":        "Day %d of %d, with %d days completed.",
+	"email.week_title":      "This week",
+	"email.column_day":      "Day",
+	"email.column_checkin":  "Check-in",
+	"email.column_exercise": "Exercise",
+	"email.column_diet":     "Diet",
+	"email.column_water":    "Water",
+	"email.column_reading":  "Reading",
+	"email.column_finances": "Finances",
+	"email.day":             "Day %d (%s)",
+	"email.streaks_title":   "Streaks",
+	"email.streaks":         "Current streak: %d days. Longest streak: %d days.",
+	"email.weight_title":    "Weight (%s)",
+	"email.latest_weight":   "Latest weigh-in: %.1f %s on %s",
+	"email.no_weigh_ins":    "No weigh-ins yet. Log one with /weighin.",
+	"email.footer":          "You get this email because you added your address with /email in Discord. Run /email remove to stop.",
+
+	// /connect, /disconnect
+	"connect.strava":       "🔗 [Connect your Strava account](%s)\n\nOnce it's linked, every Strava activity of 30 minutes or more is logged as your workout for that day. The link works once and expires in 15 minutes.",
+	"connect.fitbit":       "🔗 [Connect your Fitbit account](%s)\n\nOnce it's linked, your Fitbit water, steps, and food log calories sync every so often (to bring in MyFitnessPal, connect it to Fitbit in its app). When you also log water here, the day's total is whichever is higher, so the same glass isn't counted twice. The link works once and expires in 15 minutes.",
+	"connect.not_started":  "❌ Run `/start` first, so the bot knows you.",
+	"connect.error":        "❌ Error linking your account: %v",
+	"disconnect.done":      "✅ %s is unlinked. Anything already synced stays logged.",
+	"disconnect.not_found": "ℹ️ You haven't linked %s.",
+	"disconnect.error":     "❌ Error unlinking your account: %v",
+
+	// /import
+	"import.apple_health": "✅ **Apple Health import complete**\n💪 Workouts logged on %d days\n💧 Water synced on %d days\n⚖️ %d weigh-ins added\n\nKept your existing workout on %d days, and skipped %d days whose longest workout was under %d minutes. %d entries were outside your challenge so far and %d were in units the bot can't read.",
+	"import.invalid":      "❌ Couldn't read that file: %v\nUpload the export.zip from Health → your profile → Export All Health Data, or a Health Auto Export JSON file.",
+	"import.not_started":  "❌ Run `/start` first, so the bot knows your challenge dates.",
+	"import.error":        "❌ Error importing your data: %v",
+
+	"import.csv":         "✅ **CSV import complete** (%d days, %s to %s)\n➕ %d feat entries added\n📌 %d already recorded and kept as they were\n✅ %d days checked in with every feat done",
+	"import.csv_preview": "🔍 **CSV import preview** (%d days, %s to %s)\n➕ %d feat entries would be added\n📌 %d are already recorded and would be kept as they are\n✅ %d days would be checked in with every feat done\n\nNothing was saved. Run the command again with `dry-run: False` to import.",
+	"import.csv_invalid": "❌ Nothing was imported, because the file has problems:\n%s\n\nThe first row names a `date` (YYYY-MM-DD) or `day` column, then any of `exercise`, `diet`, `water`, `reading`, and `finances`. Mark feats done with yes, x, or ✅.",
+
+	// Check-in reminders
+	"reminder.channel": "⏰ <@%s> reminder: you haven't checked in for day %d yet.",
+	"reminder.dm":      "⏰ Reminder: you haven't checked in for day %d of the challenge yet.",
+	"reminder.sms":     "75 Hard: you haven't checked in for day %d yet. Reply STOP to stop these texts.",
+
+	// End-of-day water check
+	"compliance.water_digest": "💧 **Water Shortfalls**\nThese days ended under the water goal, so water is marked missed:\n",
+	"compliance.water_entry":  "• **%s** - day %d: %.4g / %.4g %s\n",
+
+	// /config template
+	"templates.error_load":        "❌ Error loading templates: %v",
+	"templates.error_update":      "❌ Error saving template: %v",
+	"templates.error_reset":       "❌ Error resetting template: %v",
+	"templates.title":             "📝 **Message Templates**\n\n",
+	"templates.entry":             "**%s** - %s\n  %s\n  Variables: %s\n",
+	"templates.custom":            "✏️ custom",
+	"templates.builtin":           "_built-in_",
+	"templates.modal_title":       "Edit %s template",
+	"templates.modal_text":        "Message text",
+	"templates.set":               "✅ **%s** template saved. Preview:\n\n%s",
+	"templates.reset":             "✅ **%s** is back to the built-in message.",
+	"template.checkin":            "Daily check-in message text, below the dated title",
+	"template.day_complete":       "Forum post when a member finishes a day",
+	"template.challenge_complete": "Announcement when a member finishes the challenge",
+
+	// /config webhook
+	"webhooks.error":       "❌ Error managing webhooks: %v",
+	"webhooks.invalid":     "❌ %v",
+	"webhooks.title":       "🔗 **Webhooks**\n\n",
+	"webhooks.entry":       "`%d` %s - %s (%s)\n",
+	"webhooks.none":        "No webhooks yet. Add one with `/config webhook add`.",
+	"webhooks.added":       "✅ Webhook `%d` will receive **%s** at %s.\n\nEach request is signed with this secret, shown only once:\n`%s`\nCheck the `X-Signature-256` header, `sha256=` followed by the hex HMAC-SHA256 of the body.",
+	"webhooks.added_flat":  "\n\nPayloads are flat JSON for Zapier or Make. Run `/config webhook test id:%d` once your Catch Hook or Custom webhook is listening, so it can learn the fields.",
+	"webhooks.test_title":  "🧪 **Sample events sent to webhook `%d`**\n\n",
+	"webhooks.test_sent":   "✅ `%s` delivered\n",
+	"webhooks.test_failed": "❌ `%s` failed: %v\n",
+	"webhooks.removed":     "✅ Webhook `%d` removed.",
+	"webhooks.missing":     "❌ No webhook `%d` in this server.",
+
+	// /config sheet
+	"sheets.error":         "❌ Error managing the Google Sheet: %v",
+	"sheets.invalid":       "❌ %v",
+	"sheets.set":           "✅ Check-ins, feats, and weigh-ins are now mirrored into %s. The **Check-ins**, **Feats**, and **Weigh-ins** tabs are rewritten on every sync, so build pivot tables on other tabs.",
+	"sheets.set_failed":    "⚠️ Saved %s, but the first sync failed: %v\nShare the spreadsheet with `%s` as an editor, then run `/config sheet sync`.",
+	"sheets.none":          "No Google Sheet yet. Share a spreadsheet with `%s` as an editor, then run `/config sheet set`.",
+	"sheets.status":        "📄 **Google Sheet:** %s\nShared with: `%s`\n",
+	"sheets.status_synced": "Last synced: <t:%d:R>\n",
+	"sheets.status_never":  "Not synced yet\n",
+	"sheets.status_error":  "❌ Last sync failed: %s\n",
+	"sheets.synced":        "✅ Google Sheet synced.",
+	"sheets.sync_failed":   "❌ Sync failed: %v\nMake sure the spreadsheet is shared with `%s` as an editor.",
+	"sheets.cleared":       "✅ Stopped mirroring. The spreadsheet keeps the data already written.",
+
+	// /botstats
+	"botstats.title":                "📈 **Bot Stats**\n",
+	"botstats.uptime":               "**Uptime:** %s (since <t:%d:f>)\n",
+	"botstats.gateway_connected":    "**Gateway:** connected, %s latency\n",
+	"botstats.gateway_disconnected": "**Gateway:** ⚠️ disconnected\n",
+	"botstats.commands":             "**Commands:** %d registered (%s)\n",
+	"botstats.goroutines":           "**Goroutines:** %d\n",
+	"botstats.memory":               "**Memory:** %.1f MB heap in use, %.1f MB from OS, %d GC cycles\n",
+	"botstats.db_pool":              "**DB pool:** %d in use, %d idle, %d open (max %d), %d waits totaling %s\n",
+	"botstats.db_pool_none":         "**DB pool:** no database configured\n",
+	"botstats.health":               "\n**Health**\n",
+	"botstats.unhealthy":            "❌ %s: %s (since <t:%d:R>)\n",
+	"botstats.jobs":                 "\n**Scheduled jobs**\n",
+	"botstats.job_not_run":          "• %s: not run yet\n",
+
+	// Posts from the bot
+	"bot.introduction": "👋 75 Half Chub Bot here! I'll help you track your daily challenge progress.",
+	"bot.active_title": "📊 **Active Challenge Participants** - %s\n\n",
+	"bot.active_user":  "**%s** - Day %d/%d",
+	"bot.active_dates": "\n  Started: %s | Ends: %s\n\n",
+	"bot.active_total": "_Total active participants: %d_",
+	"checkin.title":    "Daily Check-In",
+	"checkin.header":   "%s - %s",
+	"checkin.prompt":   "Check this message to confirm you completed the challenges today",
+	"checkin.thread":   "Day — %s discussion",
+	"checkin.complete": "✅ Day %d check-in complete",
+	"checkin.not_enrolled": "👋 Your check-in wasn't recorded because you haven't started the challenge. " +
+		"Run `/start` in the server to pick your start date, then check in again.",
+	"checkin.not_started": "⏳ Your check-in for %s wasn't recorded: your challenge starts %s. " +
+		"Use `/start` to change it.",
+	"checkin.ended": "🏁 Your check-in for %s wasn't recorded: your challenge's last day was %s. " +
+		"Use `/start` to begin a new one.",
+	"checkin.future": "⏳ Your check-in for %s wasn't recorded: that day hasn't started yet in your time zone, where it's %s. " +
+		"Check your time zone with `/preferences`.",
+	"checkin.closed": "🔒 Your check-in for %s wasn't recorded: that day's check-in closed at the end of the next day. " +
+		"React to today's pinned check-in message instead.",
+	"challenge.complete":     "🏁🎉 **%s has completed the challenge!** All %d days done - congratulations!",
+	"penalty.added":          "⚠️ %s has %d penalty day(s) added: %s. Their challenge now ends %s.",
+	"penalty.removed":        "↩️ %s has %d day(s) taken off their challenge: %s. Their challenge now ends %s.",
+	"book.finished":          "📚🎉 %s finished **%s** (%d pages)!",
+	"forum.thread_title":     "%s's 75 Half Chub progress",
+	"forum.weekly_recap":     "🗓️ **Weekly Recap**\n\n",
+	"forum.recap_meals":      "\n\n🍽️ **Meals this week**",
+	"forum.recap_meal":       "\n• Day %d: %s",
+	"forum.recap_meal_photo": " ([photo](%s))",
+}
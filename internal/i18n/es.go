@@ -0,0 +1,746 @@
+package i18n
+
+// spanish translates the English catalog. The command.* keys have no English
+// entries: English command descriptions live in the command definitions.
+var spanish = map[string]string{
+	// Fechas (argumentos: nombre del mes, día, año)
+	"date.long":      "%[2]d de %[1]s de %[3]d",
+	"date.short":     "%[2]d %[1]s %[3]d",
+	"date.zoned":     "%s (%s)",
+	"date.month_day": "%[2]d %[1]s",
+
+	"month.long.january":   "enero",
+	"month.long.february":  "febrero",
+	"month.long.march":     "marzo",
+	"month.long.april":     "abril",
+	"month.long.may":       "mayo",
+	"month.long.june":      "junio",
+	"month.long.july":      "julio",
+	"month.long.august":    "agosto",
+	"month.long.september": "septiembre",
+	"month.long.october":   "octubre",
+	"month.long.november":  "noviembre",
+	"month.long.december":  "diciembre",
+
+	"month.short.january":   "ene",
+	"month.short.february":  "feb",
+	"month.short.march":     "mar",
+	"month.short.april":     "abr",
+	"month.short.may":       "may",
+	"month.short.june":      "jun",
+	"month.short.july":      "jul",
+	"month.short.august":    "ago",
+	"month.short.september": "sept",
+	"month.short.october":   "oct",
+	"month.short.november":  "nov",
+	"month.short.december":  "dic",
+
+	// Compartidos
+	"error.service_unavailable": "❌ El servicio de %s no está disponible.",
+	"error.unknown_route":       "❌ %s desconocido: %s",
+	"error.panic":               "❌ Algo salió mal al procesar eso. El error quedó registrado; inténtalo de nuevo.",
+	"error.restarting":          "🔄 El bot se está reiniciando. Inténtalo de nuevo en un minuto.",
+	"error.admin_only":          "⛔ Este comando está reservado para los administradores del reto.",
+	"error.feature_disabled":    "🚫 La función %s está desactivada en este servidor.",
+	"error.cooldown":            "🐢 ¡Más despacio! Podrás usar `/%s` de nuevo en %d segundos.",
+	"error.duplicate_submit":    "⏳ Ese formulario ya se había enviado, así que solo se guardó una vez.",
+	"error.invalid_input":       "❌ %s",
+	"button.cancel":             "Cancelar",
+
+	"service.exercise":        "ejercicio",
+	"service.summary":         "resumen",
+	"service.leaderboard":     "clasificación",
+	"service.weighin":         "pesaje",
+	"service.user":            "usuarios",
+	"service.water":           "agua",
+	"service.export":          "exportación",
+	"service.settings":        "ajustes",
+	"service.features":        "funciones",
+	"service.templates":       "plantillas",
+	"service.webhooks":        "webhooks",
+	"service.preferences":     "preferencias",
+	"service.shortcut":        "atajos",
+	"service.strava":          "Strava",
+	"service.fitbit":          "Fitbit",
+	"service.import":          "importación",
+	"service.sheets":          "Google Sheets",
+	"service.calendar":        "calendario",
+	"service.sms":             "SMS",
+	"service.email":           "Correo",
+	"service.coach":           "Entrenador",
+	"service.undo":            "Deshacer",
+	"service.edit":            "Edición",
+	"service.corrections":     "Correcciones",
+	"service.meals":           "Comidas",
+	"service.finances":        "Finanzas",
+	"service.books":           "Libros",
+	"service.selfimprovement": "Superación personal",
+	"service.workout_timer":   "Cronómetro de entrenamiento",
+
+	// Validación de datos
+	"validation.required":         "%s: campo obligatorio",
+	"validation.not_whole_number": "%s: '%s' no es un número entero",
+	"validation.not_number":       "%s: '%s' no es un número",
+	"validation.at_least":         "%s: debe ser de al menos %d %s.",
+	"validation.not_date":         "%s: '%s' no es una fecha como 2026-01-31",
+	"validation.not_yes_no":       "%s: '%s' no es sí ni no",
+	"unit.minutes":                "minutos",
+	"unit.lbs":                    "lb",
+	"unit.kg":                     "kg",
+	"unit.oz":                     "oz",
+	"unit.l":                      "L",
+
+	// Writes rejected for their challenge day
+	"day.not_started": "⏳ No se registró nada: tu reto empieza el %s. Usa `/start` para cambiarlo.",
+	"day.ended":       "🏁 No se registró nada: el último día de tu reto fue el %s. Usa `/start` para empezar uno nuevo.",
+	"day.future":      "⏳ No se registró nada: el %s aún no empieza en tu zona horaria, donde es %s. Revisa tu zona horaria con `/preferences`.",
+
+	// /exercise
+	"exercise.error": "❌ Error al registrar el ejercicio: %v",
+	"exercise.quick_logged": "✅ **¡Ejercicio registrado!**\n" +
+		"Entrenamiento: 30 minutos\n" +
+		"Core/movilidad: 10 minutos\n\n" +
+		"Usa `/exercise detailed` para duraciones personalizadas.",
+	"exercise.forum_quick": "💪 Ejercicio registrado: 30 min de entrenamiento, 10 min de core/movilidad",
+	"exercise.detailed_logged": "✅ **¡Ejercicio registrado!**\n" +
+		"**Entrenamiento:** %d minutos (%s, %s)\n" +
+		"**Core/movilidad:** %d minutos (%s)",
+	"exercise.forum_detailed":                 "💪 Ejercicio registrado: %d min de %s (%s), %d min de %s",
+	"exercise.forum_upload":                   "💪 Entrenamiento subido: %d min de %s (%s)",
+	"exercise.upload_logged":                  "✅ **¡Entrenamiento registrado para el día %d!**\n**Entrenamiento:** %d minutos (%s, %s)",
+	"exercise.upload_distance":                "\n**Distancia:** %.2f km (%.2f mi)",
+	"exercise.upload_short":                   "❌ Ese entrenamiento dura %d minutos; solo cuentan los de %d minutos o más.",
+	"exercise.upload_not_recorded":            "ℹ️ No cambió nada: el día %d (%s) está fuera de tu reto, o ya tiene un entrenamiento registrado a mano o una subida más larga.",
+	"exercise.upload_invalid":                 "❌ No se pudo leer ese archivo: %v\nSube un archivo de actividad .gpx o .fit exportado de tu reloj o app de ejercicio.",
+	"exercise.modal.title":                    "Registrar ejercicio",
+	"exercise.modal.workout_duration":         "Duración del entrenamiento (minutos)",
+	"exercise.modal.workout_type":             "Tipo de entrenamiento",
+	"exercise.modal.workout_type_placeholder": "p. ej., correr, pesas, ciclismo",
+	"exercise.modal.location":                 "Lugar (indoor/outdoor)",
+	"exercise.modal.location_placeholder":     "indoor u outdoor",
+	"exercise.modal.core_duration":            "Duración de core/movilidad (minutos)",
+	"exercise.modal.core_type":                "Tipo de core/movilidad",
+	"exercise.modal.core_type_placeholder":    "p. ej., abdominales, planchas, estiramientos, yoga",
+	"exercise.field.workout_duration":         "Duración del entrenamiento",
+	"exercise.field.core_duration":            "Duración de core/movilidad",
+
+	// /diet meal
+	"diet.meal_logged":       "✅ **Comida registrada para el día %d:** %s",
+	"diet.meal_photo":        "\n📷 Foto guardada con ella.",
+	"diet.not_image":         "❌ %s no es una imagen. Adjunta una foto de la comida, como un JPEG o PNG.",
+	"diet.photo_unavailable": "❌ Este servidor no tiene almacenamiento configurado para fotos de comidas. Registra la comida de nuevo sin la foto.",
+	"diet.error":             "❌ Error al registrar la comida: %v",
+	"diet.forum_meal":        "🍽️ Comida: %s",
+	"diet.forum_meal_photo":  "🍽️ Comida (con foto): %s",
+
+	// /finances purchase
+	"finances.purchase_logged":        "✅ Se registró una compra necesaria de %.2f (%s) para el día %d.",
+	"finances.purchase_non_compliant": "⚠️ Se registraron %.2f (%s) para el día %d. No era una necesidad, así que el logro de finanzas de hoy queda como no cumplido.",
+	"finances.error":                  "❌ Error al registrar la compra: %v",
+	"finances.week_title":             "💰 **Gastos del %s al %s:** %.2f en %d compra(s)",
+	"finances.week_necessary":         "\n• Necesidades: %.2f",
+	"finances.week_unnecessary":       "\n• No necesarias: %.2f",
+	"finances.week_flagged":           " (%d día(s) marcados como no cumplidos)",
+	"finances.week_categories":        "\n**Por categoría:**",
+	"finances.week_category":          "\n• %s: %.2f",
+	"finances.week_none":              "ℹ️ No hay compras registradas del %s al %s.",
+	"finances.summary_error":          "❌ Error al obtener tus gastos: %v",
+
+	// /reading
+	"reading.book_started":  "📖 Empezaste **%s** (%d páginas). Registra lo que leas con `/reading pages`.",
+	"reading.book_shelved":  "\nDejaste **%s** en la página %d de %d.",
+	"reading.pages_logged":  "📖 Se registraron %d páginas de **%s**: %d/%d\n%s",
+	"reading.book_finished": "🎉 ¡Terminaste **%s** (%d páginas)! Ya está en tu lista de lectura de `/summary`.",
+	"reading.no_book":       "ℹ️ Todavía no estás leyendo un libro. Empieza uno con `/reading book title:<título> pages:<páginas>`.",
+	"reading.error":         "❌ Error al registrar la lectura: %v",
+
+	// /selfimprovement
+	"selfimprovement.started":         "⏱️ Cronómetro iniciado para el día %d (%s). Usa `/selfimprovement stop` cuando termines.",
+	"selfimprovement.already_running": "⏱️ Tu cronómetro (%s) lleva %d minuto(s) corriendo. Detenlo primero con `/selfimprovement stop`.",
+	"selfimprovement.no_session":      "ℹ️ No tienes un cronómetro corriendo. Inicia uno con `/selfimprovement start`.",
+	"selfimprovement.logged":          "⏹️ Se registraron %d minuto(s) para el día %d (%s).",
+	"selfimprovement.cut_off":         " Seguía corriendo cuando terminó el día %d, así que solo cuentan los minutos hasta la medianoche.",
+	"selfimprovement.feat_done":       "\n✅ El total de ese día es de %d minutos, lo que completa el logro de superación personal.",
+	"selfimprovement.feat_remaining":  "\nEl total de ese día es de %d minutos; faltan %d para completar el logro de superación personal.",
+	"selfimprovement.expired":         "⚠️ Tu cronómetro del día %d contó más de %d horas, así que se tomó como olvidado y no se registró nada.",
+	"selfimprovement.error":           "❌ Error con tu cronómetro de superación personal: %v",
+
+	// /exercise start
+	"workout_timer.running":       "⏱️ **Entrenamiento en curso:** %s transcurrido\nPulsa **Detener** cuando termines para registrarlo.",
+	"workout_timer.stop_button":   "Detener",
+	"workout_timer.cancel_button": "Cancelar",
+	"workout_timer.started":       "⏱️ Cronómetro iniciado. Pulsa **Detener** en su mensaje cuando termines.",
+	"workout_timer.already_open":  "⏱️ Ya tienes un cronómetro de entrenamiento abierto, iniciado hace %d minuto(s). Detenlo o cancélalo primero desde su mensaje.",
+	"workout_timer.post_failed":   "❌ No se pudo publicar el cronómetro en este canal. Prueba en otro canal o revisa que el bot pueda enviar mensajes aquí.",
+	"workout_timer.not_yours":     "❌ Solo quien inició este cronómetro puede detenerlo o cancelarlo.",
+	"workout_timer.closed":        "ℹ️ Este cronómetro ya no está corriendo.",
+	"workout_timer.too_short":     "⏱️ El cronómetro lleva solo %d minuto(s); los entrenamientos necesitan al menos %d para contar. Sigue, o pulsa **Cancelar** para descartarlo.",
+	"workout_timer.modal_title":   "Registra tu entrenamiento de %d min",
+	"workout_timer.logged":        "✅ **Entrenamiento registrado:** %d min de %s (%s), más 10 min de core/movilidad",
+	"workout_timer.cancelled":     "🚫 Cronómetro cancelado. No se registró nada.",
+	"workout_timer.expired":       "⌛ Este cronómetro corrió %d horas sin detenerse, así que expiró y no se registró nada.",
+
+	// /summary
+	"summary.error":               "❌ Error al obtener el resumen: %v",
+	"summary.all_title":           "📊 **Resumen del progreso del reto (todos los participantes)**\n\n",
+	"summary.all_user":            "**%s** (Día %d/%d",
+	"summary.all_days_completed":  "  ✅ Días completados: %d\n\n",
+	"summary.no_users":            "No se encontraron participantes.",
+	"summary.user_not_found":      "❌ No se encontró al usuario '%s'.",
+	"summary.user_title":          "📊 **Resumen del progreso del reto: %s**\n\n",
+	"summary.user_challenge_day":  "**Reto:** Día %d/%d",
+	"summary.user_days_added":     " (+%d días añadidos)",
+	"summary.user_started":        "\n**Inicio:** %s\n\n",
+	"summary.user_days_completed": "**Días completados:** %d\n",
+	"summary.user_calories":       "**Calorías de hoy:** %d kcal (de %s)\n",
+	"summary.user_progress":       "\n**Progreso:** %s",
+	"summary.user_books":          "\n\n📚 **Libros terminados (%d):**",
+	"summary.user_book":           "\n• %s (%d páginas)",
+
+	// /leaderboard
+	"leaderboard.error": "❌ Error al obtener la clasificación: %v",
+	"leaderboard.title": "🏆 **Clasificación del reto**\n\n",
+	"leaderboard.empty": "Todavía no hay progreso registrado.",
+	"leaderboard.entry": "%s **%s** - %d días ✅ | 💪 %d | 💧 %d\n",
+
+	// /weighin
+	"weighin.invalid":     "❌ El peso debe estar entre 0.01 y %.2f %s.",
+	"weighin.error":       "❌ Error al registrar el pesaje: %v",
+	"weighin.recorded":    "✅ **¡Pesaje registrado!**\n**Peso:** %.2f %s",
+	"weighin.change_up":   "\n📈 **Cambio:** +%.2f %s desde el último pesaje (Día %d)",
+	"weighin.change_down": "\n📉 **Cambio:** %.2f %s desde el último pesaje (Día %d)",
+	"weighin.notes":       "\n📝 **Notas:** %s",
+	"weighin.forum":       "⚖️ Pesaje: %.2f %s",
+
+	// /start
+	"start.invalid_date":         "❌ Formato de fecha no válido. Usa AAAA-MM-DD (p. ej., 2024-01-15)",
+	"start.invalid_confirmation": "❌ Confirmación no válida. Vuelve a intentarlo con /start.",
+	"start.cancelled":            "❌ Inicio del reto cancelado.",
+	"start.error":                "❌ Error al iniciar el reto: %v",
+	"start.confirm_button":       "Sí, empezar el reto",
+	"start.rules": "**Reglas del reto 75 Half Chub:**\n\n" +
+		"1. Sigue una dieta (sin comidas trampa ni alcohol)\n" +
+		"2. Un entrenamiento de 30+ minutos (da igual si es dentro o fuera; caminar solo cuenta con chaleco lastrado)\n" +
+		"3. 10+ minutos de core/movilidad\n" +
+		"4. Bebe 1 galón de agua (no tiene que ser agua sola)\n" +
+		"5. 30 minutos de superación personal intencional (leer, aprender, escribir un diario, estudiar, etc.)\n" +
+		"6. Registro diario (reacciona con ✅)\n" +
+		"7. Foto de progreso semanal\n" +
+		"8. Finanzas: solo lo necesario\n\n" +
+		"**Detalles del reto:**\n" +
+		"📅 **Fecha de inicio:** %s\n" +
+		"🏁 **Fecha de fin:** %s\n" +
+		"📊 **Duración:** 75 días (base)\n\n" +
+		"⚠️ **Regla de fallo:** Si te saltas alguna tarea, se añaden 7 días a tu fecha de fin. Puedes pedir perdón públicamente por emergencias (niños enfermos, etc.) para evitar la penalización.\n\n" +
+		"¿Listo para empezar?",
+	"start.started": "✅ **¡Reto iniciado!**\n\n" +
+		"📅 **Fecha de inicio:** %s\n" +
+		"🏁 **Fecha de fin:** %s\n" +
+		"📊 **Día actual:** Día %d\n\n" +
+		"¡Mucha suerte! ¡Tú puedes! 💪",
+	"start.announcement": "🎉 ¡**%s** ha empezado el reto 75 Half Chub!\n\n" +
+		"📅 Empezó el: **%s**\n" +
+		"🏁 El reto terminará el: **%s**\n" +
+		"📊 Va por el: **Día %d**\n\n" +
+		"¡Apoyémosle en este camino! 💪",
+
+	// /water
+	"water.invalid":        "❌ La cantidad debe ser mayor que 0.",
+	"water.error_get":      "❌ Error al obtener el consumo de agua: %v",
+	"water.error_add":      "❌ Error al añadir agua: %v",
+	"water.error_subtract": "❌ Error al restar agua: %v",
+	"water.today":          "💧 **Consumo de agua de hoy**\n**Total:** %.2f / %.4g %s",
+	"water.added":          "💧 **¡Agua añadida!**\n**Añadido:** %[1].2f %[4]s\n**Total de hoy:** %[2].2f / %[3].4g %[4]s",
+	"water.subtracted":     "💧 **¡Agua restada!**\n**Restado:** %[1].2f %[4]s\n**Total de hoy:** %[2].2f / %[3].4g %[4]s",
+	"water.goal_reached":   "\n\n🎉 **¡Meta alcanzada!** ¡Llegaste a 1 galón (%.4g %s)!",
+	"water.remaining":      "\n📊 **Faltan:** %.2f %s para llegar a 1 galón",
+	"water.forum":          "💧 Agua: +%[1].2f %[4]s (%[2].2f / %[3].4g %[4]s hoy)",
+
+	// /deletemydata
+	"privacy.delete_warning": "⚠️ **¿Borrar todos tus datos?**\n\n" +
+		"Esto elimina para siempre tu registro del reto, registros diarios, ejercicio, dieta, agua, " +
+		"superación personal y finanzas, pesajes, fotos de progreso e historial de fallos.\n\n" +
+		"**Esto no se puede deshacer.**",
+	"privacy.delete_button":    "Sí, borrar todo",
+	"privacy.delete_not_yours": "❌ Solo puedes borrar tus propios datos.",
+	"privacy.delete_error":     "❌ Error al borrar los datos: %v",
+	"privacy.deleted": "🗑️ **Todos tus datos han sido borrados.**\n" +
+		"Se eliminaron %d registro(s). Usa `/start` si algún día quieres volver al reto.",
+	"privacy.delete_cancelled": "❌ Borrado cancelado. No se eliminó nada.",
+
+	// /exportmydata
+	"export.error":          "❌ Error al exportar los datos: %v",
+	"export.format_error":   "❌ Error al dar formato a la exportación: %v",
+	"export.dm_open_failed": "❌ No pude abrir un mensaje directo contigo. Comprueba que tienes activados los MD de miembros del servidor.",
+	"export.dm_send_failed": "❌ No pude enviarte un mensaje directo. Comprueba que tienes activados los MD de miembros del servidor.",
+	"export.dm_message":     "📦 Aquí tienes todo lo que el bot 75 Half Chub ha guardado sobre ti.",
+	"export.sent":           "✅ Tu exportación de datos se envió a tus mensajes directos.",
+
+	// /export
+	"admin_export.range":         "La fecha 'to' (%[2]s) es anterior a la fecha 'from' (%[1]s).",
+	"admin_export.attached":      "📦 %d registros del %s al %s, un CSV por tabla.",
+	"admin_export.too_large":     "❌ La exportación ocupa %d MB, más que el límite de %d MB de Discord, y no hay almacenamiento de objetos configurado. Prueba con un rango de fechas más corto o un solo miembro.",
+	"admin_export.upload_failed": "❌ La exportación era demasiado grande para Discord y no se pudo subir: %v",
+	"admin_export.uploaded":      "📦 %d registros del %s al %s, un CSV por tabla. La exportación era demasiado grande para Discord, así que descárgala aquí (el enlace funciona durante 24 horas):\n%s",
+
+	// /backup
+	"backup.not_configured": "❌ Las copias de seguridad no están configuradas. Define `BACKUP_S3_BUCKET` y las credenciales para activarlas.",
+	"backup.failed":         "❌ La copia de seguridad falló: %v",
+	"backup.complete":       "✅ **Copia de seguridad completada**\n**Objeto:** `%s`\n**Tamaño:** %.1f KB",
+
+	// /features
+	"features.error_load":   "❌ Error al cargar las funciones: %v",
+	"features.error_update": "❌ Error al actualizar la función: %v",
+	"features.title":        "🎛️ **Funciones**\n\n",
+	"features.entry":        "**%s** - %s\n",
+	"features.on":           "✅ activada",
+	"features.off":          "🚫 desactivada",
+	"features.enabled":      "✅ **%s** ahora está activada.",
+	"features.disabled":     "✅ **%s** ahora está desactivada.",
+
+	// /settings
+	"settings.error_load":        "❌ Error al cargar los ajustes: %v",
+	"settings.error_update":      "❌ Error al actualizar el ajuste: %v",
+	"settings.error_reset":       "❌ Error al restablecer el ajuste: %v",
+	"settings.title":             "⚙️ **Ajustes**\n\n",
+	"settings.entry":             "**%s** - %s\n  %s\n",
+	"settings.default":           "_valor del entorno_",
+	"settings.set":               "✅ **%s** ahora es <#%s>; aplicado sin reiniciar.",
+	"settings.language_set":      "✅ Los mensajes del bot en este servidor ahora están en **%s**; aplicado sin reiniciar.",
+	"settings.timezone_set":      "✅ Las fechas en este servidor ahora se muestran en **%s**; aplicado sin reiniciar.",
+	"settings.reset":             "✅ **%s** vuelve al valor del entorno.",
+	"setting.checkin_channel_id": "Canal para el mensaje de registro diario",
+	"setting.summary_channel_id": "Canal para las listas de participantes y resúmenes",
+	"setting.photos_channel_id":  "Canal para las fotos de progreso",
+	"setting.admin_channel_id":   "Canal para las alertas de salud y copias de seguridad",
+	"setting.forum_channel_id":   "Canal de foro para las publicaciones de progreso de cada participante",
+	"setting.timezone":           "Zona horaria de las fechas en los canales y de los miembros que no han elegido una",
+	"setting.locale":             "Idioma de los mensajes del bot (los miembros cuyo idioma de Discord tiene traducción ven el suyo)",
+
+	// /preferences
+	"preferences.error_load":       "❌ Error al cargar tus preferencias: %v",
+	"preferences.error_update":     "❌ Error al guardar tus preferencias: %v",
+	"preferences.units_saved":      "✅ Preferencias guardadas.\n\n",
+	"preferences.units":            "📏 **Tus unidades**\n**Peso:** %s\n**Agua:** %s",
+	"preferences.error_reset":      "❌ Error al restablecer tu preferencia: %v",
+	"preferences.title":            "⚙️ **Tus preferencias**\n\n",
+	"preferences.entry":            "**%s** - %s\n  %s\n",
+	"preferences.default_timezone": "_predeterminada del servidor (%s)_",
+	"preferences.set":              "✅ **%s** ahora es **%s**.",
+	"preferences.reset":            "✅ **%s** volvió a su valor predeterminado.",
+
+	"preference.timezone":      "Zona horaria de tus días del reto y fechas",
+	"preference.weight_unit":   "Unidad de pesaje: lbs o kg",
+	"preference.volume_unit":   "Unidad de agua: oz o l",
+	"preference.reminders":     "Recordatorios diarios: on u off",
+	"preference.reminder_time": "Hora del recordatorio, como HH:MM en tu zona horaria",
+	"preference.quiet_hours":   "Horas en que el bot no te avisará, como HH:MM-HH:MM en tu zona horaria, u off",
+	"preference.privacy":       "Cómo apareces en publicaciones públicas: public, anonymous o hidden",
+	"preference.delivery":      "Dónde te llegan los avisos: channel, dm o sms",
+
+	// Privacy
+	"privacy.pseudonym": "Participante %s",
+
+	// /shortcut
+	"shortcut.key":         "🔑 **Tu clave de webhook** (se muestra una vez, mantenla en secreto):\n`%s`\n\nEnvía por POST entradas como `water +16oz` o `workout 45min run` a `/hooks/v1/users/%s/log` en la dirección HTTP del bot, con el encabezado `Authorization: Bearer <clave>`. Ejecuta `/shortcut key` otra vez para reemplazarla.",
+	"shortcut.revoked":     "✅ Tu clave de webhook fue revocada.",
+	"shortcut.no_key":      "ℹ️ No tienes una clave de webhook.",
+	"shortcut.not_started": "❌ Ejecuta `/start` primero, para que el bot te conozca.",
+	"shortcut.error":       "❌ Error al gestionar tu clave de webhook: %v",
+
+	// /calendar
+	"calendar.link":        "📅 **Tu calendario** (mantenlo privado):\n%s\n\nSuscríbete desde tu app de calendario (por ejemplo, Google Calendar → Otros calendarios → Desde URL, o Calendario de Apple → Archivo → Nueva suscripción a calendario). Sigue las fechas de tu reto, incluidos los días agregados. `/calendar revoke` lo desactiva.",
+	"calendar.revoked":     "✅ Tu calendario fue revocado. Las suscripciones dejarán de actualizarse.",
+	"calendar.no_link":     "ℹ️ No tienes un calendario.",
+	"calendar.not_started": "❌ Ejecuta `/start` primero, para que el bot conozca las fechas de tu reto.",
+	"calendar.error":       "❌ Error al gestionar tu calendario: %v",
+
+	// Calendar feed events
+	"calendar.name":              "75 Hard - %s",
+	"calendar.start":             "🏁 75 Hard: día 1",
+	"calendar.start_description": "Tu reto de %d días empieza hoy.",
+	"calendar.photo":             "📸 75 Hard: foto de progreso de la semana %d",
+	"calendar.photo_description": "Toma la foto de progreso de esta semana.",
+	"calendar.milestone":         "⭐ 75 Hard: día %d de %d",
+	"calendar.finish":            "🏆 75 Hard: último día (día %d)",
+
+	// /sms
+	"sms.sent":        "📱 Enviamos un código a %s. Escríbelo con `/sms verify code:<código>` en los próximos %d minutos para recibir recordatorios por mensaje de texto.",
+	"sms.verified":    "✅ Tu número está verificado. Los recordatorios ahora llegan por mensaje de texto, fuera de tus horas de silencio. Cambia `delivery` con `/preferences set` para modificarlo, o ejecuta `/sms remove` para borrar tu número.",
+	"sms.wrong_code":  "❌ Ese código no es correcto. Revisa el mensaje e inténtalo de nuevo.",
+	"sms.expired":     "❌ Ese código caducó. Ejecuta `/sms add` para recibir uno nuevo.",
+	"sms.too_soon":    "⏳ Acabamos de enviar un código. Espera un minuto antes de pedir otro.",
+	"sms.invalid":     "❌ %v",
+	"sms.none":        "ℹ️ No has agregado un número de teléfono. Agrega uno con `/sms add number:+15551234567`.",
+	"sms.removed":     "✅ Tu número de teléfono fue borrado. Los recordatorios que llegaban por mensaje de texto ahora van al canal de registro.",
+	"sms.not_started": "❌ Ejecuta `/start` primero, para que el bot conozca las fechas de tu reto.",
+	"sms.error":       "❌ Error al gestionar los recordatorios por SMS: %v",
+	"sms.code":        "Tu código del bot de 75 Hard es %s. Caduca en %d minutos.",
+
+	// /email
+	"email.sent":         "📧 Enviamos un código a %s. Escríbelo con `/email verify code:<código>` en los próximos %d minutos para recibir un informe semanal cada domingo por la noche.",
+	"email.verified":     "✅ Tu dirección está verificada. Tu informe llega cada domingo por la noche; ejecuta `/email send` para recibir el de esta semana ahora, o `/email remove` para dejar de recibirlos.",
+	"email.wrong_code":   "❌ Ese código no es correcto. Revisa el correo e inténtalo de nuevo.",
+	"email.expired":      "❌ Ese código caducó. Ejecuta `/email add` para recibir uno nuevo.",
+	"email.too_soon":     "⏳ Acabamos de enviar un código. Espera un minuto antes de pedir otro.",
+	"email.invalid":      "❌ %v",
+	"email.none":         "ℹ️ No has agregado una dirección de correo verificada. Agrega una con `/email add address:<dirección>`.",
+	"email.report_sent":  "✅ El informe de esta semana va camino a tu bandeja de entrada.",
+	"email.removed":      "✅ Tu dirección de correo fue borrada y ya no recibirás informes.",
+	"email.not_started":  "❌ Ejecuta `/start` primero, para que el bot conozca las fechas de tu reto.",
+	"email.error":        "❌ Error al gestionar los informes por correo: %v",
+	"email.code_subject": "Tu código del bot de 75 Hard",
+	"email.code_body":    "Tu código del bot de 75 Hard es %s. Caduca en %d minutos. Si no lo pediste, puedes ignorar este correo.",
+
+	// /coach
+	"coach.reply":       "🧑‍🏫 %s",
+	"coach.remaining":   "\n\n-# Te quedan %d de %d respuestas del entrenador hoy",
+	"coach.limit":       "⏳ Ya usaste las %d respuestas del entrenador de hoy. Vuelve a intentarlo mañana.",
+	"coach.not_started": "❌ Ejecuta `/start` primero, para que el entrenador tenga estadísticas que revisar.",
+	"coach.error":       "❌ El entrenador no pudo responder ahora, y no contó para tu límite: %v",
+
+	// /undo
+	"undo.done":                  "↩️ Se deshizo %s del día %d. Ejecuta `/undo` de nuevo para deshacer el registro anterior.",
+	"undo.nothing":               "🤷 No hay nada que deshacer. Solo se pueden deshacer registros de agua, ejercicio y registro diario de los últimos %d minutos.",
+	"undo.error":                 "❌ Error al deshacer tu último registro: %v",
+	"undo.action.water_add":      "tu registro de agua",
+	"undo.action.water_subtract": "tu resta de agua",
+	"undo.action.exercise":       "tu registro de ejercicio",
+	"undo.action.check_in":       "tu registro diario",
+
+	// /edit
+	"edit.modal_title":   "Editar el día %d",
+	"edit.field.workout": "Minutos de entrenamiento (0 o al menos %d)",
+	"edit.field.water":   "Agua (%s, 0 si no)",
+	"edit.field.diet":    "¿Seguiste tu dieta? (sí/no)",
+	"edit.yes":           "sí",
+	"edit.no":            "no",
+	"edit.saved":         "✏️ **Día %d actualizado**\n**Entrenamiento:** %d min\n**Agua:** %.2f %s\n**Dieta cumplida:** %s",
+	"edit.unchanged":     "No cambió nada del día %d.",
+	"edit.not_started":   "❌ No has empezado el reto. Ejecuta `/start` primero.",
+	"edit.window_closed": "⏰ Ese día ya no se puede editar. Los días se pueden corregir hasta %d horas después de terminar.",
+	"edit.error":         "❌ Error al editar ese día: %v",
+
+	// Correcciones de administradores: /adjust, /setstart, /setday, /backfill
+	"corrections.not_enrolled": "❌ <@%s> no ha empezado el reto.",
+	"adjust.zero":              "❌ Los días deben ser un número positivo para agregar días o negativo para quitarlos.",
+	"adjust.too_many":          "❌ Eso quitaría más días de los que se le han agregado a <@%s>. Su fecha de fin original no puede adelantarse.",
+	"adjust.error":             "❌ Error al ajustar la fecha de fin: %v",
+	"adjust.added":             "✅ Se agregaron %d día(s) al reto de <@%s>. Su último día ahora es %s, con %d día(s) agregados en total.",
+	"adjust.removed":           "✅ Se quitaron %d día(s) del reto de <@%s>. Su último día ahora es %s, con %d día(s) agregados en total.",
+	"adjust.silent":            "\nNo se anunció.",
+	"setstart.logs_before":     "❌ Algunos registros de <@%s> quedarían antes del nuevo inicio (%v). Elige una fecha anterior.",
+	"setstart.error":           "❌ Error al mover la fecha de inicio: %v",
+	"setstart.done":            "✅ Se movió el inicio de <@%s> del %s al %s. Hoy es el día %d y su último día es el %s; se reasignaron %d registro(s).",
+	"backfill.outside":         "❌ %s no es un día que <@%s> pueda tener registrado: su reto va del %s al %s, hasta hoy.",
+	"backfill.already_logged":  "ℹ️ %s ya está registrado para <@%s> en el día %d. No se cambió nada.",
+	"backfill.error":           "❌ Error al registrar el día pasado: %v",
+	"backfill.done":            "✅ Se registró %s para <@%s> en el día %d (%s). Queda marcado como ingresado por un administrador en el registro de auditoría.",
+
+	// Registro en lenguaje natural desde mensajes del canal de registro
+	"natlog.prompt":         "📝 ¿Registrar %s?",
+	"natlog.water":          "**%.4g %s** de agua",
+	"natlog.workout":        "un entrenamiento de %[2]s de **%[1]d min**",
+	"natlog.confirm_button": "Registrar",
+	"natlog.dismiss_button": "No, gracias",
+	"natlog.water_logged":   "💧 Registrados %[1].4g %[4]s de agua - %[2].4g / %[3].4g %[4]s hoy",
+	"natlog.workout_logged": "💪 Registrado un entrenamiento de %[2]s de %[1]d min, con 10 min de core/movilidad",
+	"natlog.dismissed":      "👌 No se registró.",
+	"natlog.not_yours":      "❌ Solo quien publicó ese mensaje puede registrarlo.",
+	"natlog.invalid":        "❌ Este botón de registro ya no es válido.",
+
+	// Informe semanal por correo
+	"email.subject":         "Informe semanal de 75 Hard: día %d de %d",
+	"email.heading":         "Informe semanal de %s",
+	"email.progress":        "Día %d de %d, con %d días completados.",
+	"email.week_title":      "Esta semana",
+	"email.column_day":      "Día",
+	"email.column_checkin":  "Registro",
+	"email.column_exercise": "Ejercicio",
+	"email.column_diet":     "Dieta",
+	"email.column_water":    "Agua",
+	"email.column_reading":  "Lectura",
+	"email.column_finances": "Finanzas",
+	"email.day":             "Día %d (%s)",
+	"email.streaks_title":   "Rachas",
+	"email.streaks":         "Racha actual: %d días. Racha más larga: %d días.",
+	"email.weight_title":    "Peso (%s)",
+	"email.latest_weight":   "Último pesaje: %.1f %s el %s",
+	"email.no_weigh_ins":    "Aún no hay pesajes. Registra uno con /weighin.",
+	"email.footer":          "Recibes este correo porque agregaste tu dirección con /email en Discord. Ejecuta /email remove para dejar de recibirlo.",
+
+	// /connect, /disconnect
+	"connect.strava":       "🔗 [Conecta tu cuenta de Strava](%s)\n\nUna vez vinculada, cada actividad de Strava de 30 minutos o más se registra como tu entrenamiento de ese día. El enlace funciona una vez y caduca en 15 minutos.",
+	"connect.fitbit":       "🔗 [Conecta tu cuenta de Fitbit](%s)\n\nUna vez vinculada, tu agua, tus pasos y las calorías de tu registro de comidas de Fitbit se sincronizan periódicamente (para traer MyFitnessPal, conéctalo a Fitbit desde su app). Si también registras agua aquí, el total del día es el mayor de los dos, para no contar dos veces el mismo vaso. El enlace funciona una vez y caduca en 15 minutos.",
+	"connect.not_started":  "❌ Ejecuta `/start` primero, para que el bot te conozca.",
+	"connect.error":        "❌ Error al vincular tu cuenta: %v",
+	"disconnect.done":      "✅ %s está desvinculado. Lo ya sincronizado se conserva.",
+	"disconnect.not_found": "ℹ️ No has vinculado %s.",
+	"disconnect.error":     "❌ Error al desvincular tu cuenta: %v",
+
+	// /import
+	"import.apple_health": "✅ **Importación de Apple Health completa**\n💪 Entrenamientos registrados en %d días\n💧 Agua sincronizada en %d días\n⚖️ %d pesajes agregados\n\nSe conservó tu entrenamiento existente en %d días y se omitieron %d días cuyo entrenamiento más largo duró menos de %d minutos. %d entradas estaban fuera de tu reto hasta hoy y %d usaban unidades que el bot no puede leer.",
+	"import.invalid":      "❌ No se pudo leer ese archivo: %v\nSube el export.zip de Salud → tu perfil → Exportar todos los datos de salud, o un archivo JSON de Health Auto Export.",
+	"import.not_started":  "❌ Ejecuta `/start` primero, para que el bot conozca las fechas de tu reto.",
+	"import.error":        "❌ Error al importar tus datos: %v",
+
+	"import.csv":         "✅ **Importación de CSV completa** (%d días, del %s al %s)\n➕ %d registros de logros agregados\n📌 %d ya estaban registrados y se conservaron tal cual\n✅ %d días registrados con todas los logros cumplidos",
+	"import.csv_preview": "🔍 **Vista previa de la importación de CSV** (%d días, del %s al %s)\n➕ Se agregarían %d registros de logros\n📌 %d ya están registrados y se conservarían tal cual\n✅ Se registrarían %d días con todas los logros cumplidos\n\nNo se guardó nada. Vuelve a ejecutar el comando con `dry-run: False` para importar.",
+	"import.csv_invalid": "❌ No se importó nada porque el archivo tiene problemas:\n%s\n\nLa primera fila nombra una columna `date` (AAAA-MM-DD) o `day`, y luego cualquiera de `exercise`, `diet`, `water`, `reading` y `finances`. Marca los logros cumplidos con yes, x o ✅.",
+
+	// Check-in reminders
+	"reminder.channel": "⏰ <@%s> recordatorio: aún no te has registrado en el día %d.",
+	"reminder.dm":      "⏰ Recordatorio: aún no te has registrado en el día %d del reto.",
+	"reminder.sms":     "75 Hard: aún no te has registrado en el día %d. Responde STOP para dejar de recibir estos mensajes.",
+
+	// End-of-day water check
+	"compliance.water_digest": "💧 **Agua incompleta**\nEstos días terminaron por debajo de la meta de agua, así que el agua queda como no cumplida:\n",
+	"compliance.water_entry":  "• **%s** - día %d: %.4g / %.4g %s\n",
+
+	// /config template
+	"templates.error_load":        "❌ Error al cargar las plantillas: %v",
+	"templates.error_update":      "❌ Error al guardar la plantilla: %v",
+	"templates.error_reset":       "❌ Error al restablecer la plantilla: %v",
+	"templates.title":             "📝 **Plantillas de mensajes**\n\n",
+	"templates.entry":             "**%s** - %s\n  %s\n  Variables: %s\n",
+	"templates.custom":            "✏️ personalizada",
+	"templates.builtin":           "_predeterminada_",
+	"templates.modal_title":       "Editar la plantilla %s",
+	"templates.modal_text":        "Texto del mensaje",
+	"templates.set":               "✅ Plantilla **%s** guardada. Vista previa:\n\n%s",
+	"templates.reset":             "✅ **%s** vuelve al mensaje predeterminado.",
+	"template.checkin":            "Texto del mensaje de registro diario, bajo el título con la fecha",
+	"template.day_complete":       "Publicación en el foro cuando un miembro completa un día",
+	"template.challenge_complete": "Anuncio cuando un miembro completa el reto",
+
+	// /config webhook
+	"webhooks.error":       "❌ Error al gestionar los webhooks: %v",
+	"webhooks.invalid":     "❌ %v",
+	"webhooks.title":       "🔗 **Webhooks**\n\n",
+	"webhooks.entry":       "`%d` %s - %s (%s)\n",
+	"webhooks.none":        "Aún no hay webhooks. Agrega uno con `/config webhook add`.",
+	"webhooks.added":       "✅ El webhook `%d` recibirá **%s** en %s.\n\nCada solicitud se firma con este secreto, que solo se muestra una vez:\n`%s`\nVerifica el encabezado `X-Signature-256`: `sha256=` seguido del HMAC-SHA256 del cuerpo en hexadecimal.",
+	"webhooks.added_flat":  "\n\nLos payloads son JSON plano para Zapier o Make. Ejecuta `/config webhook test id:%d` cuando tu Catch Hook o Custom webhook esté escuchando, para que aprenda los campos.",
+	"webhooks.test_title":  "🧪 **Eventos de ejemplo enviados al webhook `%d`**\n\n",
+	"webhooks.test_sent":   "✅ `%s` entregado\n",
+	"webhooks.test_failed": "❌ `%s` falló: %v\n",
+	"webhooks.removed":     "✅ Webhook `%d` eliminado.",
+	"webhooks.missing":     "❌ No existe el webhook `%d` en este servidor.",
+
+	// /config sheet
+	"sheets.error":         "❌ Error al gestionar la hoja de Google: %v",
+	"sheets.invalid":       "❌ %v",
+	"sheets.set":           "✅ Los registros, los logros y los pesajes ahora se copian en %s. Las pestañas **Check-ins**, **Feats** y **Weigh-ins** se reescriben en cada sincronización, así que crea las tablas dinámicas en otras pestañas.",
+	"sheets.set_failed":    "⚠️ Se guardó %s, pero la primera sincronización falló: %v\nComparte la hoja de cálculo con `%s` como editor y luego ejecuta `/config sheet sync`.",
+	"sheets.none":          "Aún no hay hoja de Google. Comparte una hoja de cálculo con `%s` como editor y luego ejecuta `/config sheet set`.",
+	"sheets.status":        "📄 **Hoja de Google:** %s\nCompartida con: `%s`\n",
+	"sheets.status_synced": "Última sincronización: <t:%d:R>\n",
+	"sheets.status_never":  "Aún no se ha sincronizado\n",
+	"sheets.status_error":  "❌ La última sincronización falló: %s\n",
+	"sheets.synced":        "✅ Hoja de Google sincronizada.",
+	"sheets.sync_failed":   "❌ La sincronización falló: %v\nAsegúrate de que la hoja de cálculo esté compartida con `%s` como editor.",
+	"sheets.cleared":       "✅ Se dejó de copiar. La hoja de cálculo conserva los datos ya escritos.",
+
+	// /botstats
+	"botstats.title":                "📈 **Estadísticas del bot**\n",
+	"botstats.uptime":               "**Tiempo activo:** %s (desde <t:%d:f>)\n",
+	"botstats.gateway_connected":    "**Gateway:** conectado, %s de latencia\n",
+	"botstats.gateway_disconnected": "**Gateway:** ⚠️ desconectado\n",
+	"botstats.commands":             "**Comandos:** %d registrados (%s)\n",
+	"botstats.goroutines":           "**Goroutines:** %d\n",
+	"botstats.memory":               "**Memoria:** %.1f MB de heap en uso, %.1f MB del SO, %d ciclos de GC\n",
+	"botstats.db_pool":              "**Pool de BD:** %d en uso, %d inactivas, %d abiertas (máx. %d), %d esperas que suman %s\n",
+	"botstats.db_pool_none":         "**Pool de BD:** no hay base de datos configurada\n",
+	"botstats.health":               "\n**Salud**\n",
+	"botstats.unhealthy":            "❌ %s: %s (desde <t:%d:R>)\n",
+	"botstats.jobs":                 "\n**Tareas programadas**\n",
+	"botstats.job_not_run":          "• %s: aún no se ha ejecutado\n",
+
+	// Publicaciones del bot
+	"bot.introduction": "👋 ¡Aquí el bot 75 Half Chub! Te ayudaré a seguir tu progreso diario en el reto.",
+	"bot.active_title": "📊 **Participantes activos del reto** - %s\n\n",
+	"bot.active_user":  "**%s** - Día %d/%d",
+	"bot.active_dates": "\n  Inicio: %s | Fin: %s\n\n",
+	"bot.active_total": "_Total de participantes activos: %d_",
+	"checkin.title":    "Registro diario",
+	"checkin.header":   "%s - %s",
+	"checkin.prompt":   "Marca este mensaje para confirmar que completaste los retos de hoy",
+	"checkin.thread":   "Conversación del día — %s",
+	"checkin.complete": "✅ Registro del día %d completado",
+	"checkin.not_enrolled": "👋 Tu registro no se guardó porque no has empezado el reto. " +
+		"Ejecuta `/start` en el servidor para elegir tu fecha de inicio y vuelve a registrarte.",
+	"checkin.not_started": "⏳ Tu registro del %s no se guardó: tu reto empieza el %s. " +
+		"Usa `/start` para cambiarlo.",
+	"checkin.ended": "🏁 Tu registro del %s no se guardó: el último día de tu reto fue el %s. " +
+		"Usa `/start` para empezar uno nuevo.",
+	"checkin.future": "⏳ Tu registro del %s no se guardó: ese día aún no empieza en tu zona horaria, donde es %s. " +
+		"Revisa tu zona horaria con `/preferences`.",
+	"checkin.closed": "🔒 Tu registro del %s no se guardó: el registro de ese día cerró al terminar el día siguiente. " +
+		"Reacciona al mensaje de registro fijado de hoy.",
+	"challenge.complete":     "🏁🎉 **¡%s ha completado el reto!** Los %d días hechos. ¡Felicidades!",
+	"penalty.added":          "⚠️ %s tiene %d día(s) de penalización: %s. Su reto ahora termina el %s.",
+	"penalty.removed":        "↩️ A %s se le quitaron %d día(s) del reto: %s. Su reto ahora termina el %s.",
+	"book.finished":          "📚🎉 ¡%s terminó **%s** (%d páginas)!",
+	"forum.thread_title":     "Progreso 75 Half Chub de %s",
+	"forum.weekly_recap":     "🗓️ **Resumen semanal**\n\n",
+	"forum.recap_meals":      "\n\n🍽️ **Comidas de esta semana**",
+	"forum.recap_meal":       "\n• Día %d: %s",
+	"forum.recap_meal_photo": " ([foto](%s))",
+
+	// Descripciones de comandos (las de inglés están en las definiciones)
+	"command.exercise":                       "Registra tu ejercicio diario (entrenamiento + core/movilidad)",
+	"command.exercise.quick":                 "Registro rápido con valores por defecto (30 min de entrenamiento, 10 min de core)",
+	"command.exercise.detailed":              "Registro con todos los detalles (abre un formulario)",
+	"command.exercise.start":                 "Cronometrar un entrenamiento; detenlo al terminar para registrar cuánto duró",
+	"command.exercise.upload":                "Registrar un entrenamiento desde un archivo del reloj o de una app (.gpx o .fit)",
+	"command.exercise.upload.file":           "Archivo GPX o FIT exportado de tu reloj, Garmin Connect, Strava o similar",
+	"command.diet":                           "Registra lo que comes para tu dieta",
+	"command.diet.meal":                      "Registrar una comida, con foto si quieres",
+	"command.diet.meal.description":          "Lo que comiste, p. ej. pollo a la plancha, arroz y brócoli",
+	"command.diet.meal.photo":                "Una foto de la comida",
+	"command.finances":                       "Registra tus gastos para el logro de finanzas",
+	"command.finances.purchase":              "Registrar una compra; si no era necesaria, rompe el logro de finanzas de hoy",
+	"command.finances.purchase.amount":       "Cuánto gastaste",
+	"command.finances.purchase.category":     "Para qué fue",
+	"command.finances.purchase.necessary":    "Si era una necesidad, como la compra del súper o la renta",
+	"command.finances.summary":               "Ver lo que gastaste en los últimos 7 días",
+	"command.reading":                        "Lleva la cuenta del libro que estás leyendo",
+	"command.reading.book":                   "Empezar un libro; el que estabas leyendo se deja de lado",
+	"command.reading.book.title":             "El título del libro",
+	"command.reading.book.pages":             "Cuántas páginas tiene el libro",
+	"command.reading.pages":                  "Registrar las páginas que leíste hoy de tu libro actual",
+	"command.reading.pages.pages":            "Cuántas páginas leíste",
+	"command.selfimprovement":                "Cronometra tu superación personal para no tener que adivinar cuánto duró",
+	"command.selfimprovement.start":          "Iniciar el cronómetro",
+	"command.selfimprovement.start.activity": "Qué estás haciendo, p. ej. leer o un curso",
+	"command.selfimprovement.stop":           "Detener el cronómetro y registrar los minutos",
+	"command.summary":                        "Ver el resumen del progreso del reto",
+	"command.summary.user":                   "Usuario del que ver el resumen (vacío para todos)",
+	"command.leaderboard":                    "Ver la clasificación del reto",
+	"command.weighin":                        "Registra tu pesaje diario",
+	"command.weighin.weight":                 "Tu peso, en tus unidades de /preferences (libras por defecto)",
+	"command.weighin.notes":                  "Notas opcionales sobre tu pesaje",
+	"command.start":                          "Empieza tu reto 75 Hard",
+	"command.start.date":                     "Fecha de inicio (AAAA-MM-DD); por defecto hoy en tu zona horaria",
+	"command.water":                          "Registra tu consumo diario de agua",
+	"command.water.summary":                  "Ver el total de agua de hoy",
+	"command.water.add":                      "Añadir agua al total de hoy",
+	"command.water.add.amount":               "Cantidad de agua a añadir, en tus unidades de /preferences (onzas por defecto)",
+	"command.water.subtract":                 "Restar agua del total de hoy",
+	"command.water.subtract.amount":          "Cantidad de agua a restar, en tus unidades de /preferences (onzas por defecto)",
+	"command.deletemydata":                   "Borra para siempre todos tus datos del reto",
+	"command.exportmydata":                   "Recibe por MD una copia de todos tus datos del reto",
+	"command.exportmydata.format":            "Formato del archivo (JSON por defecto)",
+	"command.backup":                         "Copias de seguridad de la base de datos (solo administradores)",
+	"command.backup.now":                     "Hacer una copia de seguridad ahora",
+	"command.features":                       "Activa o desactiva funciones del bot en este servidor (solo administradores)",
+	"command.features.list":                  "Ver qué funciones están activadas",
+	"command.features.enable":                "Activar una función",
+	"command.features.enable.feature":        "Función a cambiar",
+	"command.features.disable":               "Desactivar una función",
+	"command.features.disable.feature":       "Función a cambiar",
+	"command.botstats":                       "Ver tiempo activo, gateway, memoria, base de datos y tareas (solo administradores)",
+	"command.settings":                       "Cambia los ajustes del bot en este servidor sin reiniciar (solo administradores)",
+	"command.settings.list":                  "Ver los ajustes actuales",
+	"command.settings.set":                   "Cambiar un canal sin reiniciar el bot",
+	"command.settings.set.setting":           "Ajuste a cambiar",
+	"command.settings.set.channel":           "Canal a usar",
+	"command.settings.language":              "Cambiar el idioma de los mensajes del bot",
+	"command.settings.language.language":     "Idioma a usar",
+	"command.settings.timezone":              "Cambiar la zona horaria de las fechas",
+	"command.settings.timezone.timezone":     "Zona horaria IANA, p. ej. America/Mexico_City o Europe/Madrid",
+	"command.settings.reset":                 "Volver a la configuración del entorno",
+	"command.settings.reset.setting":         "Ajuste a cambiar",
+	"command.preferences":                    "Tus preferencias personales",
+	"command.shortcut":                       "Registra agua y entrenamientos desde Atajos de iOS o Tasker",
+	"command.shortcut.key":                   "Crear una nueva clave de webhook, reemplazando la anterior",
+	"command.shortcut.revoke":                "Borrar tu clave de webhook para que deje de funcionar",
+	"command.calendar":                       "Suscríbete al calendario de tu reto desde tu app de calendario",
+	"command.calendar.link":                  "Obtener la URL de tu calendario",
+	"command.calendar.revoke":                "Borrar la URL de tu calendario para que deje de funcionar",
+	"command.sms":                            "Recordatorios de registro por mensaje de texto",
+	"command.sms.add":                        "Agregar tu número de teléfono y recibir un código de verificación",
+	"command.sms.add.number":                 "Tu número con el código de país, como +15551234567",
+	"command.sms.verify":                     "Confirmar tu número con el código que te enviamos",
+	"command.sms.verify.code":                "El código de 6 dígitos del mensaje",
+	"command.sms.remove":                     "Borrar tu número y dejar de recibir mensajes",
+	"command.email":                          "Informe semanal de progreso por correo",
+	"command.email.add":                      "Agregar tu dirección de correo y recibir un código de verificación",
+	"command.email.add.address":              "La dirección a la que enviar los informes",
+	"command.email.verify":                   "Confirmar tu dirección con el código que te enviamos",
+	"command.email.verify.code":              "El código de 6 dígitos del correo",
+	"command.email.send":                     "Enviarte ahora el informe de esta semana",
+	"command.email.remove":                   "Borrar tu dirección y dejar de recibir informes",
+	"command.coach":                          "Recibir ánimo y sugerencias según tus estadísticas recientes",
+	"command.coach.question":                 "Algo que preguntarle al entrenador, como cómo tomar agua en días ocupados",
+	"command.undo":                           "Deshacer tu último registro de agua, ejercicio o registro diario de hace unos minutos",
+	"command.edit":                           "Corregir el entrenamiento, el agua o la dieta de un día reciente",
+	"command.edit.day":                       "Día del reto a corregir",
+	"command.adjust":                         "Agregar días de penalización al reto de un miembro o quitarlos (solo administradores)",
+	"command.adjust.user":                    "Miembro cuya fecha de fin mover",
+	"command.adjust.days":                    "Días a agregar, o un número negativo para quitar días",
+	"command.adjust.reason":                  "Por qué; se muestra en el anuncio y queda en el registro de auditoría",
+	"command.adjust.silent":                  "No anunciarlo en el canal de registro diario (predeterminado: false)",
+	"command.setstart":                       "Corregir la fecha de inicio del reto de un miembro (solo administradores)",
+	"command.setstart.user":                  "Miembro cuya fecha de inicio corregir",
+	"command.setstart.date":                  "Fecha de inicio correcta, como AAAA-MM-DD",
+	"command.setstart.reason":                "Por qué; queda en el registro de auditoría",
+	"command.setday":                         "Corregir en qué día del reto está hoy un miembro (solo administradores)",
+	"command.setday.user":                    "Miembro cuyo día corregir",
+	"command.setday.day":                     "Día del reto que debería ser hoy",
+	"command.setday.reason":                  "Por qué; queda en el registro de auditoría",
+	"command.backfill":                       "Registrar un logro de un miembro en un día pasado (solo administradores)",
+	"command.backfill.user":                  "Miembro para quien registrarlo",
+	"command.backfill.feat":                  "Qué logro registrar",
+	"command.backfill.date":                  "El día en que lo hizo, como AAAA-MM-DD",
+	"command.backfill.reason":                "Por qué; queda en el registro de auditoría",
+	"command.connect":                        "Vincula una app de ejercicio para registrar tus entrenamientos automáticamente",
+	"command.connect.strava":                 "Vincular tu cuenta de Strava",
+	"command.connect.fitbit":                 "Vincular tu cuenta de Fitbit",
+	"command.disconnect":                     "Desvincula una app de ejercicio",
+	"command.disconnect.strava":              "Desvincular tu cuenta de Strava",
+	"command.disconnect.fitbit":              "Desvincular tu cuenta de Fitbit",
+	"command.import":                         "Completa tu reto con datos de otra app",
+	"command.import.apple-health":            "Importar entrenamientos, agua y peso de una exportación de Apple Health",
+	"command.import.apple-health.file":       "export.zip de la app Salud, o un archivo JSON de Health Auto Export",
+	"command.import.csv":                     "Importar días pasados de un CSV con fechas y los logros cumplidos",
+	"command.import.csv.file":                "CSV con una columna date o day, y luego exercise, diet, water, reading, finances",
+	"command.import.csv.dry-run":             "Vista previa sin guardar (predeterminado: true)",
+	"command.export":                         "Exportar datos del reto (solo administradores)",
+	"command.export.csv":                     "Todos los registros de un rango de fechas, como un zip de CSV",
+	"command.export.csv.from":                "Primera fecha, como AAAA-MM-DD",
+	"command.export.csv.to":                  "Última fecha, como AAAA-MM-DD",
+	"command.export.csv.user":                "Solo este miembro (predeterminado: todos)",
+	"command.preferences.units":              "Ver o cambiar las unidades de peso y agua",
+	"command.preferences.units.weight":       "Unidad de peso",
+	"command.preferences.units.volume":       "Unidad de agua",
+	"command.preferences.view":               "Ver todas tus preferencias",
+	"command.preferences.set":                "Cambiar una de tus preferencias",
+	"command.preferences.set.preference":     "Preferencia a cambiar",
+	"command.preferences.set.value":          "Nuevo valor, como America/Chicago, kg, off, 07:30, anonymous o dm",
+	"command.preferences.reset":              "Volver una preferencia a su valor predeterminado",
+	"command.preferences.reset.preference":   "Preferencia a cambiar",
+	"command.config":                         "Personaliza el bot en este servidor (solo administradores)",
+	"command.config.template":                "Plantillas de mensajes",
+	"command.config.template.list":           "Ver las plantillas y sus variables",
+	"command.config.template.set":            "Editar el texto de una plantilla",
+	"command.config.template.set.template":   "Plantilla a cambiar",
+	"command.config.template.reset":          "Volver al mensaje predeterminado",
+	"command.config.template.reset.template": "Plantilla a cambiar",
+	"command.config.webhook":                 "Webhooks que reciben los eventos del reto en JSON",
+	"command.config.webhook.add":             "Registrar una URL para recibir eventos",
+	"command.config.webhook.add.url":         "URL http(s) a la que enviar los eventos",
+	"command.config.webhook.add.event":       "Evento a enviar (predeterminado: todos)",
+	"command.config.webhook.add.format":      "Forma del payload (predeterminado: standard)",
+	"command.config.webhook.list":            "Ver los webhooks de este servidor",
+	"command.config.webhook.remove":          "Dejar de enviar eventos a un webhook",
+	"command.config.webhook.remove.id":       "ID del webhook de /config webhook list",
+	"command.config.webhook.test":            "Enviar ya eventos de ejemplo a un webhook",
+	"command.config.webhook.test.id":         "ID del webhook de /config webhook list",
+	"command.config.sheet":                   "Hoja de Google con los registros, logros y pesajes",
+	"command.config.sheet.set":               "Copiar los datos del reto de este servidor en una hoja de cálculo",
+	"command.config.sheet.set.spreadsheet":   "URL de Google Sheets o ID de la hoja de cálculo",
+	"command.config.sheet.status":            "Ver la hoja de cálculo y cuándo se sincronizó",
+	"command.config.sheet.sync":              "Sincronizar la hoja de cálculo ahora",
+	"command.config.sheet.clear":             "Dejar de copiar (la hoja conserva sus datos)",
+}
@@ -0,0 +1,129 @@
+// Package i18n holds the bot's user-facing message catalogs and picks the
+// translation for a locale, falling back to English for anything missing.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Supported locales
+const (
+	English = "en"
+	Spanish = "es"
+)
+
+// Default is the locale used when none is configured or a translation is missing
+const Default = English
+
+// catalogs maps each locale to its messages, keyed by message ID. Messages are
+// fmt format strings; translations may reorder arguments with %[n]s verbs.
+var catalogs = map[string]map[string]string{
+	English: english,
+	Spanish: spanish,
+}
+
+// Names lists each supported locale in its own language, for choices and settings output
+var Names = map[string]string{
+	English: "English",
+	Spanish: "Español",
+}
+
+// T returns the message for key in locale, formatted with args like fmt.Sprintf.
+// Unknown locales and missing translations fall back to English, then to the key itself.
+func T(locale, key string, args ...interface{}) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Lookup returns the untranslated catalog entry for key in locale, if there is one
+func Lookup(locale, key string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	return message, ok
+}
+
+// IsSupported reports whether there is a catalog for locale
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Supported returns every locale with a catalog, sorted
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match maps a language tag such as Discord's "es-ES" or "en-US" to a supported
+// locale, or returns "" when there is no catalog for the language
+func Match(tag string) string {
+	language := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+	if IsSupported(language) {
+		return language
+	}
+	return ""
+}
+
+// Contains reports whether text contains key's message in any locale. Used to
+// recognise the bot's own posts whatever language they were sent in.
+func Contains(text, key string) bool {
+	for _, catalog := range catalogs {
+		if message, ok := catalog[key]; ok && strings.Contains(text, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDate renders t as a long date ("January 2, 2006" / "2 de enero de 2006")
+func FormatDate(locale string, t time.Time) string {
+	return formatDate(locale, t, "date.long")
+}
+
+// FormatShortDate renders t as a short date ("Jan 2, 2006" / "2 ene 2006")
+func FormatShortDate(locale string, t time.Time) string {
+	return formatDate(locale, t, "date.short")
+}
+
+// FormatMonthDay renders t as a short month and day ("Jan 2" / "2 ene")
+func FormatMonthDay(locale string, t time.Time) string {
+	return formatDate(locale, t, "date.month_day")
+}
+
+// FormatDateIn renders the day t falls on in loc as a long date labelled with the
+// zone's abbreviation that day: "January 2, 2006 (MST)", "July 4, 2006 (MDT)"
+func FormatDateIn(locale string, t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	return T(locale, "date.zoned", FormatDate(locale, t), t.Format("MST"))
+}
+
+// FormatDayIn is FormatDateIn for a calendar date, such as a challenge start date read
+// from a DATE column: it is labelled with loc's zone but never shifted into it
+func FormatDayIn(locale string, date time.Time, loc *time.Location) string {
+	return FormatDateIn(locale, time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc), loc)
+}
+
+// formatDate fills a date layout message with the localized month name, day, and year
+func formatDate(locale string, t time.Time, layout string) string {
+	monthKey := "month.short."
+	if layout == "date.long" {
+		monthKey = "month.long."
+	}
+	month := T(locale, monthKey+strings.ToLower(t.Month().String()))
+	return T(locale, layout, month, t.Day(), t.Year())
+}
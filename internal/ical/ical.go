@@ -0,0 +1,90 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events, enough for
+// calendar apps to subscribe to a challenge schedule.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event is a single all-day event
+type Event struct {
+	UID         string // Stable across fetches, so apps update events instead of duplicating them
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// Calendar is a named feed of events
+type Calendar struct {
+	Name    string
+	Refresh time.Duration // How often subscribers should refetch; zero leaves it to the app
+	Events  []Event
+}
+
+// Write renders the calendar. stamp is when the feed was generated.
+func (c Calendar) Write(w io.Writer, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//75 Hard Discord Bot//Challenge Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escape(c.Name),
+	}
+	if c.Refresh > 0 {
+		duration := fmt.Sprintf("PT%dM", int(c.Refresh.Minutes()))
+		lines = append(lines, "REFRESH-INTERVAL;VALUE=DURATION:"+duration, "X-PUBLISHED-TTL:"+duration)
+	}
+
+	for _, event := range c.Events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escape(event.UID),
+			"DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"),
+			"DTSTART;VALUE=DATE:"+event.Date.Format("20060102"),
+			"DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+escape(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escape(event.Description))
+		}
+		lines = append(lines, "TRANSP:TRANSPARENT", "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(fold(line))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escape escapes text values
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// fold ends a content line with CRLF, splitting it into continuation lines of at most
+// 75 octets without breaking a UTF-8 character
+func fold(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
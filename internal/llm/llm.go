@@ -0,0 +1,185 @@
+// Package llm asks a hosted large language model for a short reply, through the
+// Anthropic Messages API or an OpenAI-compatible chat completions API (OpenAI,
+// OpenRouter, or a local server such as Ollama).
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Supported providers
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+)
+
+// Providers lists the supported providers
+var Providers = []string{ProviderAnthropic, ProviderOpenAI}
+
+// defaultBaseURLs are each provider's API address when no base URL is configured
+var defaultBaseURLs = map[string]string{
+	ProviderAnthropic: "https://api.anthropic.com",
+	ProviderOpenAI:    "https://api.openai.com",
+}
+
+// anthropicVersion is the Messages API version requests are written against
+const anthropicVersion = "2023-06-01"
+
+// maxResponseBytes bounds a response body; replies are a few paragraphs
+const maxResponseBytes = 1 << 20
+
+// Client sends prompts to one model
+type Client struct {
+	provider   string
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for provider's model. An empty baseURL uses the
+// provider's public API; the API key may be empty for local servers.
+func NewClient(provider, baseURL, apiKey, model string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURLs[provider]
+	}
+	return &Client{
+		provider:   provider,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Complete returns the model's reply to prompt under the system instructions, at
+// most maxTokens long
+func (c *Client) Complete(ctx context.Context, system, prompt string, maxTokens int) (string, error) {
+	if c.provider == ProviderAnthropic {
+		return c.anthropic(ctx, system, prompt, maxTokens)
+	}
+	return c.openAI(ctx, system, prompt, maxTokens)
+}
+
+// message is one chat turn in either API
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// anthropic calls POST /v1/messages
+func (c *Client) anthropic(ctx context.Context, system, prompt string, maxTokens int) (string, error) {
+	body := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": maxTokens,
+		"system":     system,
+		"messages":   []message{{Role: "user", Content: prompt}},
+	}
+	headers := map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := c.post(ctx, "/v1/messages", headers, body, &response); err != nil {
+		return "", err
+	}
+
+	var reply strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			reply.WriteString(block.Text)
+		}
+	}
+	return checkReply(reply.String())
+}
+
+// openAI calls POST /v1/chat/completions
+func (c *Client) openAI(ctx context.Context, system, prompt string, maxTokens int) (string, error) {
+	body := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": maxTokens,
+		"messages":   []message{{Role: "system", Content: system}, {Role: "user", Content: prompt}},
+	}
+	headers := map[string]string{}
+	if c.apiKey != "" {
+		headers["Authorization"] = "Bearer " + c.apiKey
+	}
+
+	var response struct {
+		Choices []struct {
+			Message message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := c.post(ctx, "/v1/chat/completions", headers, body, &response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("%s returned no choices", c.provider)
+	}
+	return checkReply(response.Choices[0].Message.Content)
+}
+
+// post sends a JSON request and decodes a 2xx response into out. Both APIs describe
+// failures as {"error": {"message": "..."}}.
+func (c *Client) post(ctx context.Context, path string, headers map[string]string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", c.provider, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", c.provider, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &failure) == nil && failure.Error.Message != "" {
+			return fmt.Errorf("%s responded %s: %s", c.provider, resp.Status, failure.Error.Message)
+		}
+		return fmt.Errorf("%s responded %s", c.provider, resp.Status)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", c.provider, err)
+	}
+	return nil
+}
+
+// checkReply rejects an empty reply, which would otherwise be posted as nothing
+func checkReply(reply string) (string, error) {
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return "", fmt.Errorf("model returned an empty reply")
+	}
+	return reply, nil
+}
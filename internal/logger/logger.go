@@ -1,43 +1,106 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 )
 
-// LogLevel represents the logging level
-type LogLevel int
+var (
+	isDevMode bool = false
 
-const (
-	ERROR LogLevel = iota
-	INFO
-)
+	// level filters records for the active handler; Init sets it from LOG_LEVEL
+	level = func() *slog.LevelVar {
+		v := new(slog.LevelVar)
+		v.Set(slog.LevelError)
+		return v
+	}()
+	base = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	std  = &Logger{}
 
-var (
-	currentLevel LogLevel = ERROR
-	isDevMode    bool     = false
+	// output is where log lines go: stderr, plus the log file when enabled
+	output io.Writer = os.Stderr
+
+	// errorHook receives every Error and Fatal line when error reporting is enabled
+	errorHook ErrorHook
 )
 
-// Init initializes the logger with the specified log level and dev mode
+// ErrorHook forwards error lines and their fields to an error tracker
+type ErrorHook interface {
+	CaptureError(message string, fields map[string]interface{})
+	Flush(timeout time.Duration) bool
+}
+
+// SetErrorHook installs the hook that receives Error and Fatal lines; call after Init
+func SetErrorHook(hook ErrorHook) {
+	errorHook = hook
+}
+
+// Flush waits up to timeout for the error hook to deliver pending reports
+func Flush(timeout time.Duration) {
+	if errorHook != nil {
+		errorHook.Flush(timeout)
+	}
+}
+
+// Init initializes the logger with the specified log level and dev mode.
+// Dev mode writes human-readable text; otherwise each line is a JSON object.
 func Init(logLevel string, devMode bool) {
 	isDevMode = devMode
-	
+
 	switch strings.ToUpper(logLevel) {
+	case "DEBUG":
+		level.Set(slog.LevelDebug)
 	case "INFO":
-		currentLevel = INFO
+		level.Set(slog.LevelInfo)
+	case "WARN", "WARNING":
+		level.Set(slog.LevelWarn)
 	case "ERROR":
-		currentLevel = ERROR
+		level.Set(slog.LevelError)
 	default:
-		currentLevel = ERROR
+		level.Set(slog.LevelError)
 	}
-	
+
+	handler := newHandler()
+	base = slog.New(handler)
+
 	// Log initialization message (always log this, even at ERROR level)
+	mode := "PROD"
+	if isDevMode {
+		mode = "DEV"
+	}
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, fmt.Sprintf("Logger initialized in %s mode with level: %s", mode, logLevel), 0)
+	handler.Handle(context.Background(), record)
+}
+
+// EnableFileOutput also writes logs to a rotating file; call after Init
+func EnableFileOutput(options FileOptions) error {
+	if options.Path == "" {
+		return nil
+	}
+
+	file, err := openRotatingFile(options)
+	if err != nil {
+		return err
+	}
+	output = io.MultiWriter(os.Stderr, file)
+	base = slog.New(newHandler())
+	return nil
+}
+
+// newHandler builds the handler for the current mode and output
+func newHandler() slog.Handler {
+	options := &slog.HandlerOptions{Level: level}
 	if isDevMode {
-		log.Printf("[INFO] Logger initialized in DEV mode with level: %s", logLevel)
-	} else {
-		log.Printf("[INFO] Logger initialized in PROD mode with level: %s", logLevel)
+		return slog.NewTextHandler(output, options)
 	}
+	return slog.NewJSONHandler(output, options)
 }
 
 // IsDevMode returns whether the app is running in dev mode
@@ -45,28 +108,120 @@ func IsDevMode() bool {
 	return isDevMode
 }
 
-// Info logs an informational message if log level is INFO or higher
-func Info(format string, v ...interface{}) {
-	if currentLevel >= INFO {
-		log.Printf("[INFO] "+format, v...)
+// Logger writes printf-style messages with structured fields attached to every line
+type Logger struct {
+	attrs []any
+}
+
+// With returns a logger that adds key/value fields to every line, e.g.
+// logger.With("user_id", userID, "challenge_day", day).Info("Check-in recorded")
+func With(args ...any) *Logger {
+	return std.With(args...)
+}
+
+// With returns a copy of the logger with more key/value fields
+func (l *Logger) With(args ...any) *Logger {
+	attrs := make([]any, 0, len(l.attrs)+len(args))
+	attrs = append(attrs, l.attrs...)
+	return &Logger{attrs: append(attrs, args...)}
+}
+
+// Debug logs a diagnostic message if log level is DEBUG
+func (l *Logger) Debug(format string, v ...interface{}) {
+	l.log(slog.LevelDebug, "", format, v...)
+}
+
+// Info logs an informational message if log level is INFO or lower
+func (l *Logger) Info(format string, v ...interface{}) {
+	l.log(slog.LevelInfo, "", format, v...)
+}
+
+// Warn logs a recoverable problem if log level is WARN or lower
+func (l *Logger) Warn(format string, v ...interface{}) {
+	l.log(slog.LevelWarn, "", format, v...)
+}
+
+// Error logs an error message (always logged)
+func (l *Logger) Error(format string, v ...interface{}) {
+	l.log(slog.LevelError, "", format, v...)
+}
+
+// DB logs database operations at DEBUG level
+func (l *Logger) DB(format string, v ...interface{}) {
+	l.log(slog.LevelDebug, "db", format, v...)
+}
+
+// log formats the message and writes it with the logger's fields
+func (l *Logger) log(lvl slog.Level, category, format string, v ...interface{}) {
+	ctx := context.Background()
+	if !base.Enabled(ctx, lvl) {
+		return
+	}
+
+	attrs := l.attrs
+	if category != "" {
+		attrs = append(attrs[:len(attrs):len(attrs)], "category", category)
+	}
+	message := fmt.Sprintf(format, v...)
+	base.Log(ctx, lvl, message, attrs...)
+
+	if lvl >= slog.LevelError && errorHook != nil {
+		errorHook.CaptureError(message, fields(attrs))
 	}
 }
 
+// fields turns key/value pairs into a map for the error hook
+func fields(attrs []any) map[string]interface{} {
+	result := make(map[string]interface{}, len(attrs)/2)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		result[fmt.Sprint(attrs[i])] = attrs[i+1]
+	}
+	return result
+}
+
+// Debug logs a diagnostic message if log level is DEBUG
+func Debug(format string, v ...interface{}) {
+	std.Debug(format, v...)
+}
+
+// NewCorrelationID returns a short random ID that ties together the log lines of one event
+func NewCorrelationID() string {
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("t%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
+
+// Info logs an informational message if log level is INFO or lower
+func Info(format string, v ...interface{}) {
+	std.Info(format, v...)
+}
+
+// Warn logs a recoverable problem if log level is WARN or lower
+func Warn(format string, v ...interface{}) {
+	std.Warn(format, v...)
+}
+
 // Error logs an error message (always logged)
 func Error(format string, v ...interface{}) {
-	log.Printf("[ERROR] "+format, v...)
+	std.Error(format, v...)
 }
 
 // Fatal logs a fatal error and exits
 func Fatal(format string, v ...interface{}) {
-	log.Fatalf("[FATAL] "+format, v...)
+	message := fmt.Sprintf(format, v...)
+	base.Log(context.Background(), slog.LevelError, message, "fatal", true)
+	if errorHook != nil {
+		errorHook.CaptureError(message, map[string]interface{}{"fatal": true})
+		errorHook.Flush(5 * time.Second)
+	}
+	os.Exit(1)
 }
 
-// DB logs database operations at INFO level
+// DB logs database operations at DEBUG level
 func DB(format string, v ...interface{}) {
-	if currentLevel >= INFO {
-		log.Printf("[DB] "+format, v...)
-	}
+	std.DB(format, v...)
 }
 
 // GetLogLevelFromEnv reads LOG_LEVEL from environment, defaults to ERROR
@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FileOptions configures log file output and rotation
+type FileOptions struct {
+	Path       string        // Empty disables file output
+	MaxSize    int64         // Rotate once the file would exceed this many bytes (0 = no limit)
+	MaxAge     time.Duration // Rotate once the file has been open this long (0 = no limit)
+	MaxBackups int           // Rotated files to keep (0 = keep all)
+}
+
+// GetFileOptionsFromEnv reads LOG_FILE and its rotation settings from environment
+func GetFileOptionsFromEnv() (FileOptions, error) {
+	options := FileOptions{Path: os.Getenv("LOG_FILE")}
+	if options.Path == "" {
+		return options, nil
+	}
+
+	maxSizeMB, err := strconv.Atoi(envOrDefault("LOG_FILE_MAX_SIZE_MB", "10"))
+	if err != nil || maxSizeMB < 0 {
+		return options, fmt.Errorf("LOG_FILE_MAX_SIZE_MB must be a whole number of megabytes")
+	}
+	options.MaxSize = int64(maxSizeMB) * 1024 * 1024
+
+	options.MaxAge, err = time.ParseDuration(envOrDefault("LOG_FILE_MAX_AGE", "24h"))
+	if err != nil || options.MaxAge < 0 {
+		return options, fmt.Errorf("LOG_FILE_MAX_AGE must be a duration (e.g. 24h)")
+	}
+
+	options.MaxBackups, err = strconv.Atoi(envOrDefault("LOG_FILE_MAX_BACKUPS", "7"))
+	if err != nil || options.MaxBackups < 0 {
+		return options, fmt.Errorf("LOG_FILE_MAX_BACKUPS must be a whole number")
+	}
+	return options, nil
+}
+
+// envOrDefault returns environment variable value or default
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// rotatingFile is an io.Writer that appends to a file and rotates it by size and age.
+// Rotated files are renamed to {path}.{timestamp} and the oldest beyond MaxBackups are removed.
+type rotatingFile struct {
+	options  FileOptions
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// openRotatingFile opens (or creates) the log file for appending
+func openRotatingFile(options FileOptions) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	r := &rotatingFile{options: options}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first if it would exceed the size or age limit
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tooBig := r.options.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.options.MaxSize
+	tooOld := r.options.MaxAge > 0 && time.Since(r.openedAt) >= r.options.MaxAge
+	if tooBig || tooOld {
+		if err := r.rotate(); err != nil {
+			// Keep logging to the current file rather than dropping lines
+			fmt.Fprintf(os.Stderr, "log rotation failed: %v\n", err)
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// open opens the log file, picking up the size of an existing file
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.options.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+// rotate renames the current file aside, opens a fresh one, and prunes old backups
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	backup := r.options.Path + "." + time.Now().UTC().Format("20060102T150405.000Z")
+	if err := os.Rename(r.options.Path, backup); err != nil {
+		// Reopen so writes keep working
+		if openErr := r.open(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rename log file: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	return r.prune()
+}
+
+// prune removes the oldest rotated files beyond MaxBackups
+func (r *rotatingFile) prune() error {
+	if r.options.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(r.options.Path + ".*")
+	if err != nil {
+		return err
+	}
+	if len(backups) <= r.options.MaxBackups {
+		return nil
+	}
+
+	// Timestamp suffixes sort chronologically
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-r.options.MaxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return fmt.Errorf("failed to remove old log file: %w", err)
+		}
+	}
+	return nil
+}
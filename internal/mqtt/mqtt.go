@@ -0,0 +1,392 @@
+// Package mqtt publishes messages to an MQTT 3.1.1 broker, such as the Mosquitto
+// add-on of Home Assistant.
+//
+// Only what the bot needs is implemented: QoS 0 publishes over one long-lived
+// connection, kept alive with pings and re-established after failures.
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+const (
+	// keepAlive is the interval announced to the broker; a ping goes out at half of it
+	keepAlive = 60 * time.Second
+	// dialTimeout bounds connecting, including the TLS handshake and CONNACK
+	dialTimeout = 10 * time.Second
+	// writeTimeout bounds writing one packet, so a stalled broker is noticed
+	writeTimeout = 10 * time.Second
+	// maxQueued drops messages beyond this many waiting for the broker
+	maxQueued = 256
+	// maxBackoff caps the wait between reconnect attempts
+	maxBackoff = time.Minute
+	// maxPacketBytes bounds packets read from the broker; it only sends acks and pongs
+	maxPacketBytes = 64 << 10
+)
+
+// Control packet types, already shifted into the fixed header's high nibble
+const (
+	packetConnect    = 0x10
+	packetConnAck    = 0x20
+	packetPublish    = 0x30
+	packetPingReq    = 0xC0
+	packetDisconnect = 0xE0
+)
+
+// connAckErrors explains CONNACK return codes 1-5
+var connAckErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client ID rejected",
+	3: "server unavailable",
+	4: "bad username or password",
+	5: "not authorized",
+}
+
+// Message is one publish
+type Message struct {
+	Topic   string
+	Payload []byte
+	// Retain asks the broker to keep the message for clients that subscribe later
+	Retain bool
+}
+
+// Publisher sends messages to a broker in the background. Messages published while
+// the broker is unreachable wait in a bounded queue.
+type Publisher struct {
+	addr      string
+	tlsConfig *tls.Config // nil for plain TCP
+	clientID  string
+	username  string
+	password  string
+
+	queue   chan Message
+	mu      sync.Mutex
+	dropped int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPublisher creates a publisher for brokerURL: mqtt://host[:1883] (or tcp://) in
+// the clear, mqtts://host[:8883] (or ssl://) over TLS. The password is only sent
+// along with a username, as the protocol requires.
+func NewPublisher(brokerURL, clientID, username, password string) (*Publisher, error) {
+	parsed, err := url.Parse(brokerURL)
+	if err != nil || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("invalid broker URL %q", brokerURL)
+	}
+
+	p := &Publisher{
+		clientID: clientID,
+		username: username,
+		password: password,
+		queue:    make(chan Message, maxQueued),
+	}
+	port := parsed.Port()
+	switch parsed.Scheme {
+	case "mqtt", "tcp":
+		if port == "" {
+			port = "1883"
+		}
+	case "mqtts", "ssl":
+		if port == "" {
+			port = "8883"
+		}
+		p.tlsConfig = &tls.Config{ServerName: parsed.Hostname(), MinVersion: tls.VersionTLS12}
+	default:
+		return nil, fmt.Errorf("unsupported broker URL scheme %q", parsed.Scheme)
+	}
+	p.addr = net.JoinHostPort(parsed.Hostname(), port)
+	return p, nil
+}
+
+// Start connects and begins publishing in the background
+func (p *Publisher) Start() {
+	if p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run(p.stop, p.done)
+}
+
+// Stop sends what's queued if the broker is connected, disconnects, and stops the
+// background publisher
+func (p *Publisher) Stop() {
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.stop = nil
+}
+
+// Publish queues m without blocking; when the queue is full, m is dropped
+func (p *Publisher) Publish(m Message) {
+	select {
+	case p.queue <- m:
+	default:
+		p.mu.Lock()
+		p.dropped++
+		p.mu.Unlock()
+	}
+}
+
+// run keeps a connection open until stop is closed, reconnecting with backoff
+func (p *Publisher) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	backoff := time.Second
+	var pending *Message // A message whose write failed, retried on the next connection
+	for {
+		conn, err := p.connect()
+		if err != nil {
+			logger.Warn("⚠️  Failed to connect to MQTT broker %s: %v; retrying in %s", p.addr, err, backoff)
+			select {
+			case <-time.After(backoff):
+			case <-stop:
+				return
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		logger.Info("📡 Connected to MQTT broker %s", p.addr)
+		backoff = time.Second
+
+		var stopped bool
+		pending, stopped = p.serve(conn, pending, stop)
+		conn.Close()
+		if stopped {
+			return
+		}
+	}
+}
+
+// serve publishes queued messages on conn until the connection fails or stop is
+// closed. It returns the message that couldn't be written, if any, and whether it
+// stopped because of stop.
+func (p *Publisher) serve(conn net.Conn, pending *Message, stop <-chan struct{}) (*Message, bool) {
+	// The broker only ever answers pings here; reading notices a closed connection
+	lost := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(conn)
+		for {
+			conn.SetReadDeadline(time.Now().Add(keepAlive * 3 / 2))
+			if _, _, err := readPacket(reader); err != nil {
+				lost <- err
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(keepAlive / 2)
+	defer ping.Stop()
+
+	if pending != nil {
+		if err := p.publish(conn, *pending); err != nil {
+			logger.Warn("⚠️  Lost connection to MQTT broker %s: %v", p.addr, err)
+			return pending, false
+		}
+	}
+	for {
+		p.reportDropped()
+		select {
+		case m := <-p.queue:
+			if err := p.publish(conn, m); err != nil {
+				logger.Warn("⚠️  Lost connection to MQTT broker %s: %v", p.addr, err)
+				return &m, false
+			}
+		case <-ping.C:
+			if err := p.write(conn, []byte{packetPingReq, 0}); err != nil {
+				logger.Warn("⚠️  Lost connection to MQTT broker %s: %v", p.addr, err)
+				return nil, false
+			}
+		case err := <-lost:
+			logger.Warn("⚠️  Lost connection to MQTT broker %s: %v", p.addr, err)
+			return nil, false
+		case <-stop:
+			p.drain(conn)
+			p.write(conn, []byte{packetDisconnect, 0})
+			return nil, true
+		}
+	}
+}
+
+// drain publishes what's already queued, giving up at the first failure
+func (p *Publisher) drain(conn net.Conn) {
+	for {
+		select {
+		case m := <-p.queue:
+			if err := p.publish(conn, m); err != nil {
+				logger.Warn("⚠️  Failed to publish queued MQTT messages before disconnecting: %v", err)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// reportDropped logs how many messages the full queue dropped since the last report
+func (p *Publisher) reportDropped() {
+	p.mu.Lock()
+	dropped := p.dropped
+	p.dropped = 0
+	p.mu.Unlock()
+	if dropped > 0 {
+		logger.Warn("⚠️  Dropped %d MQTT messages: publish queue full", dropped)
+	}
+}
+
+// connect dials the broker and completes the CONNECT/CONNACK handshake
+func (p *Publisher) connect() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if p.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", p.addr, p.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", p.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+	if _, err := conn.Write(connectPacket(p.clientID, p.username, p.password)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT: %w", err)
+	}
+	header, body, err := readPacket(bufio.NewReader(conn))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNACK: %w", err)
+	}
+	if header&0xF0 != packetConnAck || len(body) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("expected CONNACK, got packet type %d", header>>4)
+	}
+	if code := body[1]; code != 0 {
+		conn.Close()
+		if reason, ok := connAckErrors[code]; ok {
+			return nil, fmt.Errorf("broker refused connection: %s", reason)
+		}
+		return nil, fmt.Errorf("broker refused connection with code %d", code)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// publish writes m as a QoS 0 PUBLISH
+func (p *Publisher) publish(conn net.Conn, m Message) error {
+	if len(m.Topic) == 0 || len(m.Topic) > 0xFFFF {
+		logger.Warn("⚠️  Skipping MQTT message with invalid topic %q", m.Topic)
+		return nil
+	}
+	header := byte(packetPublish)
+	if m.Retain {
+		header |= 0x01
+	}
+	body := appendString(nil, m.Topic)
+	body = append(body, m.Payload...)
+	return p.write(conn, packet(header, body))
+}
+
+// write sends one packet within writeTimeout
+func (p *Publisher) write(conn net.Conn, b []byte) error {
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := conn.Write(b)
+	return err
+}
+
+// connectPacket builds a clean-session CONNECT for protocol level 4 (3.1.1)
+func connectPacket(clientID, username, password string) []byte {
+	flags := byte(0x02) // Clean session
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+
+	seconds := int(keepAlive / time.Second)
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags, byte(seconds>>8), byte(seconds))
+	body = appendString(body, clientID)
+	if username != "" {
+		body = appendString(body, username)
+		if password != "" {
+			body = appendString(body, password)
+		}
+	}
+	return packet(packetConnect, body)
+}
+
+// packet prefixes body with the fixed header: the type and flags byte, then the
+// remaining length in 7-bit groups, least significant first
+func packet(header byte, body []byte) []byte {
+	b := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(b, body...)
+}
+
+// appendString appends s with its two-byte length prefix
+func appendString(b []byte, s string) []byte {
+	return append(append(b, byte(len(s)>>8), byte(len(s))), s...)
+}
+
+// readPacket reads one control packet, returning its fixed header byte and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	if length > maxPacketBytes {
+		return 0, nil, fmt.Errorf("packet of %d bytes is too large", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
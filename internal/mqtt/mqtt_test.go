@@ -0,0 +1,317 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPacketRemainingLength(t *testing.T) {
+	// The boundaries from the MQTT 3.1.1 spec's remaining length table
+	tests := []struct {
+		length int
+		want   string
+	}{
+		{0, "00"},
+		{1, "01"},
+		{127, "7f"},
+		{128, "8001"},
+		{16383, "ff7f"},
+		{16384, "808001"},
+		{2097151, "ffff7f"},
+		{2097152, "80808001"},
+	}
+	for _, tt := range tests {
+		b := packet(packetPublish, make([]byte, tt.length))
+		if got := hex.EncodeToString(b[1 : len(b)-tt.length]); b[0] != packetPublish || got != tt.want {
+			t.Errorf("packet() of %d bytes has header %02x %s, want 30 %s", tt.length, b[0], got, tt.want)
+		}
+	}
+}
+
+func TestReadPacket(t *testing.T) {
+	for _, length := range []int{0, 2, 127, 128, 16383, 16384, maxPacketBytes} {
+		body := bytes.Repeat([]byte{0xA5}, length)
+		header, got, err := readPacket(bufio.NewReader(bytes.NewReader(packet(packetConnAck, body))))
+		if err != nil {
+			t.Fatalf("readPacket() of %d bytes error = %v", length, err)
+		}
+		if header != packetConnAck || !bytes.Equal(got, body) {
+			t.Errorf("readPacket() of %d bytes = %02x and %d bytes", length, header, len(got))
+		}
+	}
+}
+
+func TestReadPacketErrors(t *testing.T) {
+	tests := []struct {
+		name, packet, want string
+	}{
+		{name: "empty", packet: "", want: "EOF"},
+		{name: "no remaining length", packet: "20", want: "EOF"},
+		{name: "unfinished remaining length", packet: "208080", want: "EOF"},
+		{name: "remaining length over four bytes", packet: "20ffffffff01", want: "malformed remaining length"},
+		{name: "largest remaining length", packet: "20ffffff7f", want: "packet of 268435455 bytes is too large"},
+		{name: "over the size limit", packet: "2081800400", want: "packet of 65537 bytes is too large"},
+		{name: "truncated body", packet: "200200", want: "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.packet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _, err = readPacket(bufio.NewReader(bytes.NewReader(b)))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("readPacket() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectPacket(t *testing.T) {
+	// Header, remaining length, "MQTT", level 4, flags, keep-alive 60s, then the payload
+	tests := []struct {
+		name, clientID, username, password, want string
+	}{
+		{name: "anonymous", clientID: "bot", want: "100f00044d5154540402003c0003626f74"},
+		{name: "username only", clientID: "bot", username: "ha", want: "101300044d5154540482003c0003626f7400026861"},
+		{name: "username and password", clientID: "bot", username: "ha", password: "pw", want: "101700044d51545404c2003c0003626f740002686100027077"},
+		{name: "password without a username is not sent", clientID: "bot", password: "pw", want: "100f00044d5154540402003c0003626f74"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(connectPacket(tt.clientID, tt.username, tt.password)); got != tt.want {
+				t.Errorf("connectPacket() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishPacket(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+		want    string
+	}{
+		{name: "QoS 0", message: Message{Topic: "a/b", Payload: []byte("hi")}, want: "30070003612f626869"},
+		{name: "retained", message: Message{Topic: "a/b", Payload: []byte("hi"), Retain: true}, want: "31070003612f626869"},
+		{name: "empty payload", message: Message{Topic: "t"}, want: "30030001" + "74"},
+		{name: "empty topic is skipped", message: Message{Payload: []byte("hi")}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer server.Close()
+			written := make(chan []byte)
+			go func() {
+				b := new(bytes.Buffer)
+				b.ReadFrom(server)
+				written <- b.Bytes()
+			}()
+
+			p := &Publisher{}
+			if err := p.publish(client, tt.message); err != nil {
+				t.Fatalf("publish() error = %v", err)
+			}
+			client.Close()
+			if got := hex.EncodeToString(<-written); got != tt.want {
+				t.Errorf("publish() wrote %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishDropsWhenQueueFull(t *testing.T) {
+	p, err := NewPublisher("mqtt://localhost", "bot", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxQueued+3; i++ {
+		p.Publish(Message{Topic: "t"})
+	}
+	if len(p.queue) != maxQueued || p.dropped != 3 {
+		t.Errorf("queued %d and dropped %d, want %d and 3", len(p.queue), p.dropped, maxQueued)
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	tests := []struct {
+		url, addr string
+		tls       bool
+	}{
+		{url: "mqtt://broker", addr: "broker:1883"},
+		{url: "tcp://broker:1884", addr: "broker:1884"},
+		{url: "mqtts://broker", addr: "broker:8883", tls: true},
+		{url: "ssl://[::1]:8884", addr: "[::1]:8884", tls: true},
+	}
+	for _, tt := range tests {
+		p, err := NewPublisher(tt.url, "bot", "", "")
+		if err != nil {
+			t.Errorf("NewPublisher(%q) error = %v", tt.url, err)
+			continue
+		}
+		if p.addr != tt.addr || (p.tlsConfig != nil) != tt.tls {
+			t.Errorf("NewPublisher(%q) = %s (TLS %v), want %s (TLS %v)", tt.url, p.addr, p.tlsConfig != nil, tt.addr, tt.tls)
+		}
+	}
+	for _, url := range []string{"", "broker:1883", "http://broker", "mqtt://"} {
+		if _, err := NewPublisher(url, "bot", "", ""); err == nil {
+			t.Errorf("NewPublisher(%q) succeeded, want an error", url)
+		}
+	}
+}
+
+// fakeBroker accepts connections, answers CONNECT with returnCode, and hands each
+// connection's packets to the test
+type fakeBroker struct {
+	t          *testing.T
+	listener   net.Listener
+	returnCode byte
+	conns      chan *brokerConn
+
+	mu     sync.Mutex
+	opened []net.Conn // Closed with the listener when the test ends
+}
+
+// brokerConn is one client connection to the fake broker
+type brokerConn struct {
+	conn    net.Conn
+	packets chan []byte // Each packet's header byte followed by its body, without the length
+}
+
+func newFakeBroker(t *testing.T, returnCode byte) *fakeBroker {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("can't listen on loopback: %v", err)
+	}
+	b := &fakeBroker{t: t, listener: listener, returnCode: returnCode, conns: make(chan *brokerConn, 4)}
+	t.Cleanup(func() {
+		listener.Close()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for _, conn := range b.opened {
+			conn.Close()
+		}
+	})
+	go b.accept()
+	return b
+}
+
+func (b *fakeBroker) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.opened = append(b.opened, conn)
+		b.mu.Unlock()
+		go b.serve(conn)
+	}
+}
+
+func (b *fakeBroker) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	header, _, err := readPacket(reader)
+	if err != nil || header != packetConnect {
+		conn.Close()
+		return
+	}
+	conn.Write([]byte{packetConnAck, 2, 0, b.returnCode})
+
+	c := &brokerConn{conn: conn, packets: make(chan []byte, 16)}
+	b.conns <- c
+	for {
+		header, body, err := readPacket(reader)
+		if err != nil {
+			close(c.packets)
+			return
+		}
+		c.packets <- append([]byte{header}, body...)
+	}
+}
+
+// next waits for the broker's next connection
+func (b *fakeBroker) next() *brokerConn {
+	b.t.Helper()
+	select {
+	case c := <-b.conns:
+		return c
+	case <-time.After(5 * time.Second):
+		b.t.Fatal("timed out waiting for the publisher to connect")
+		return nil
+	}
+}
+
+// next waits for the connection's next packet, returning nil once it's closed
+func (c *brokerConn) next(t *testing.T) []byte {
+	t.Helper()
+	select {
+	case p := <-c.packets:
+		return p
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a packet")
+		return nil
+	}
+}
+
+func TestPublisherReconnects(t *testing.T) {
+	broker := newFakeBroker(t, 0)
+	p, err := NewPublisher("mqtt://"+broker.listener.Addr().String(), "bot", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Start()
+	defer p.Stop()
+
+	first := broker.next()
+	p.Publish(Message{Topic: "hard75/water", Payload: []byte("1")})
+	if got := hex.EncodeToString(first.next(t)); got != "30000c6861726437352f776174657231" {
+		t.Errorf("first connection got %s, want the PUBLISH", got)
+	}
+
+	// The broker drops the connection; the publisher notices and connects again
+	first.conn.Close()
+	second := broker.next()
+	p.Publish(Message{Topic: "hard75/water", Payload: []byte("2")})
+	if got := hex.EncodeToString(second.next(t)); got != "30000c6861726437352f776174657232" {
+		t.Errorf("second connection got %s, want the PUBLISH", got)
+	}
+
+	// Stopping sends what's queued, then DISCONNECT
+	p.Publish(Message{Topic: "t", Payload: []byte("3")})
+	p.Stop()
+	if got := hex.EncodeToString(second.next(t)); got != "3000017433" {
+		t.Errorf("got %s before disconnecting, want the queued PUBLISH", got)
+	}
+	if got := second.next(t); !bytes.Equal(got, []byte{packetDisconnect}) {
+		t.Errorf("got %x, want DISCONNECT", got)
+	}
+}
+
+func TestConnectRefused(t *testing.T) {
+	tests := []struct {
+		code byte
+		want string
+	}{
+		{4, "broker refused connection: bad username or password"},
+		{5, "broker refused connection: not authorized"},
+		{9, "broker refused connection with code 9"},
+	}
+	for _, tt := range tests {
+		broker := newFakeBroker(t, tt.code)
+		p, err := NewPublisher("mqtt://"+broker.listener.Addr().String(), "bot", "ha", "wrong")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.connect(); err == nil || err.Error() != tt.want {
+			t.Errorf("connect() error = %v, want %q", err, tt.want)
+		}
+	}
+}
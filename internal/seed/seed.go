@@ -0,0 +1,207 @@
+package seed
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/services"
+)
+
+// UserIDPrefix marks seeded users so they can be found and removed later
+const UserIDPrefix = "seed_"
+
+// usernames are assigned to seeded users in order
+var usernames = []string{
+	"dadbod_dave", "marathon_mike", "couch_carl", "gallon_greg", "iron_ian",
+	"steady_steve", "plank_pete", "hydro_hank", "late_larry", "perfect_paul",
+}
+
+// Options controls what the seeder generates
+type Options struct {
+	Users int   // Number of fake users (max len(usernames))
+	Days  int   // Days of history per user (75 for a full challenge)
+	Seed  int64 // Random seed, so runs are reproducible
+	Reset bool  // Remove previously seeded users first
+}
+
+// DefaultOptions returns options for a full 75-day data set
+func DefaultOptions() Options {
+	return Options{
+		Users: 6,
+		Days:  75,
+		Seed:  75,
+		Reset: true,
+	}
+}
+
+// Run populates fake users with partially complete feat data and weigh-ins.
+// Water is logged as manual water entries, as the bot logs it. Check-ins then fire
+// the auto-populate trigger, some days get manual exercise details, and some days
+// are skipped entirely.
+func Run(db *sql.DB, opts Options) error {
+	if opts.Users <= 0 || opts.Users > len(usernames) {
+		return fmt.Errorf("users must be between 1 and %d", len(usernames))
+	}
+	if opts.Days <= 0 {
+		return fmt.Errorf("days must be greater than 0")
+	}
+
+	if opts.Reset {
+		if err := Reset(db); err != nil {
+			return err
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	mst, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		mst = time.FixedZone("MST", -7*3600)
+	}
+	now := time.Now().In(mst)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, mst)
+
+	for n := 0; n < opts.Users; n++ {
+		userID := fmt.Sprintf("%s%02d", UserIDPrefix, n+1)
+		username := usernames[n]
+
+		// Stagger start dates so users sit at different challenge days
+		startDate := today.AddDate(0, 0, -(opts.Days - 1 - rng.Intn(opts.Days/3+1)))
+		// Each user has their own reliability between 55% and 100%
+		completionRate := 0.55 + rng.Float64()*0.45
+
+		if err := seedUser(db, rng, userID, username, startDate, today, completionRate); err != nil {
+			return fmt.Errorf("failed to seed %s: %w", username, err)
+		}
+		logger.Info("🌱 Seeded %s (started %s, %.0f%% completion rate)", username, startDate.Format("2006-01-02"), completionRate*100)
+	}
+
+	return nil
+}
+
+// Reset removes every seeded user; feat rows cascade
+func Reset(db *sql.DB) error {
+	result, err := db.Exec(`DELETE FROM users WHERE user_id LIKE $1`, UserIDPrefix+"%")
+	if err != nil {
+		return fmt.Errorf("failed to remove seeded users: %w", err)
+	}
+	removed, _ := result.RowsAffected()
+	if removed > 0 {
+		logger.Info("🧹 Removed %d previously seeded user(s)", removed)
+	}
+	return nil
+}
+
+// seedUser writes one user's challenge history in a single transaction
+func seedUser(db *sql.DB, rng *rand.Rand, userID, username string, startDate, today time.Time, completionRate float64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	endDate := startDate.AddDate(0, 0, 75)
+	_, err = tx.Exec(
+		`INSERT INTO users (user_id, username, challenge_start_date, original_challenge_end_date, current_challenge_end_date)
+		 VALUES ($1, $2, $3, $4, $4)`,
+		userID, username, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	weight := 190 + rng.Float64()*60
+	for date, day := startDate, 1; !date.After(today); date, day = date.AddDate(0, 0, 1), day+1 {
+		dateStr := date.Format("2006-01-02")
+		completedAt := date.Add(time.Duration(18+rng.Intn(5)) * time.Hour)
+
+		if rng.Float64() < completionRate {
+			// Most checked-in days reach the gallon in a few glasses; some fall short
+			glasses := 2 + rng.Intn(3)
+			if rng.Float64() < 0.15 {
+				glasses = 1
+			}
+			for i := 0; i < glasses; i++ {
+				ounces := services.WaterGoalOunces / float64(glasses)
+				if glasses == 1 {
+					ounces = float64(16 * (2 + rng.Intn(5)))
+				}
+				if err := logSeedWater(tx, userID, day, date, ounces, completedAt); err != nil {
+					return err
+				}
+			}
+
+			// Check-in auto-populates every other feat table via the trigger; water is
+			// already logged, so the trigger leaves its total alone
+			_, err = tx.Exec(
+				`INSERT INTO accountability_checkins (user_id, challenge_day, completion_date, completed_at, check_in_method)
+				 VALUES ($1, $2, $3, $4, 'seed')`,
+				userID, day, dateStr, completedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert check-in for day %d: %w", day, err)
+			}
+
+			// Roughly half the time the user logged exercise details manually
+			if rng.Float64() < 0.5 {
+				workoutTypes := []string{"running", "weights", "cycling", "walking (vest)", "swimming"}
+				_, err = tx.Exec(
+					`UPDATE exercise_completions
+					 SET workout_duration_minutes = $3, workout_type = $4, workout_location = $5,
+					     core_mobility_duration_minutes = $6, core_mobility_type = 'planks', autopopulated = false
+					 WHERE user_id = $1 AND challenge_day = $2`,
+					userID, day, 30+rng.Intn(60), workoutTypes[rng.Intn(len(workoutTypes))],
+					[]string{"indoor", "outdoor"}[rng.Intn(2)], 10+rng.Intn(20),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to update exercise for day %d: %w", day, err)
+				}
+			}
+		} else if rng.Float64() < 0.5 {
+			// Missed day with some water logged but no check-in
+			if err := logSeedWater(tx, userID, day, date, float64(16*(1+rng.Intn(6))), completedAt); err != nil {
+				return err
+			}
+		}
+
+		// Weigh in every few days with a gentle downward trend
+		if rng.Intn(3) == 0 {
+			weight += rng.Float64()*1.6 - 1.0
+			_, err = tx.Exec(
+				`INSERT INTO weigh_ins (user_id, challenge_day, completion_date, weight_lbs, weighed_at)
+				 VALUES ($1, $2, $3, $4, $5)`,
+				userID, day, dateStr, fmt.Sprintf("%.2f", weight), date.Add(7*time.Hour),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert weigh-in for day %d: %w", day, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// logSeedWater logs ounces of water on the user's challenge day the way the bot does,
+// as a manual water entry rolled up into the day's total, then backdates both to
+// loggedAt
+func logSeedWater(tx *sql.Tx, userID string, day int, date time.Time, ounces float64, loggedAt time.Time) error {
+	if _, _, err := services.LogWater(tx, userID, day, date, ounces, services.SourceManual); err != nil {
+		return fmt.Errorf("failed to log water for day %d: %w", day, err)
+	}
+	_, err := tx.Exec(
+		`UPDATE water_entries SET logged_at = $3 WHERE user_id = $1 AND challenge_day = $2 AND logged_at > $3`,
+		userID, day, loggedAt,
+	)
+	if err == nil {
+		_, err = tx.Exec(
+			`UPDATE water_completions SET completed_at = $3 WHERE user_id = $1 AND challenge_day = $2`,
+			userID, day, loggedAt,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to backdate water for day %d: %w", day, err)
+	}
+	return nil
+}
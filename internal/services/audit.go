@@ -0,0 +1,44 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditEditDay    = "edit_day"
+	AuditAdjustDays = "adjust_days"
+	AuditSetStart   = "set_start"
+	AuditSetDay     = "set_day"
+	AuditBackfill   = "backfill"
+)
+
+// AuditEntry is a correction made to a member's challenge record after the fact
+type AuditEntry struct {
+	UserID       string
+	ActorID      string // Who made the change; the member themself for /edit
+	Action       string
+	ChallengeDay int                    // 0 when the change isn't for one day
+	Details      map[string]interface{} // What changed, e.g. values before and after
+}
+
+// writeAudit records entry in tx, so a correction and its audit row commit together,
+// and returns the entry's ID
+func writeAudit(tx *sql.Tx, entry AuditEntry) (int64, error) {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	challengeDay := sql.NullInt64{Int64: int64(entry.ChallengeDay), Valid: entry.ChallengeDay > 0}
+	var id int64
+	err = tx.QueryRow(
+		`INSERT INTO audit_log (user_id, actor_id, action, challenge_day, details) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		entry.UserID, entry.ActorID, entry.Action, challengeDay, string(details),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/config"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/storage"
+)
+
+// BackupService takes scheduled pg_dump snapshots and uploads them to S3-compatible storage.
+//
+// Snapshots are plain-format SQL dumps compressed with gzip, stored as
+// {prefix}/hard75-YYYYMMDDTHHMMSSZ.sql.gz. The dump includes DROP ... IF EXISTS
+// statements, so restoring replaces the current schema and data:
+//
+//  1. Stop the bot so nothing writes during the restore.
+//  2. Download the snapshot, e.g. `aws s3 cp s3://bucket/backups/hard75-...sql.gz .`
+//     (add `--endpoint-url` for MinIO/R2/B2).
+//  3. Restore it: `gunzip -c hard75-...sql.gz | psql "host=... user=... dbname=hard75"`
+//  4. Start the bot. Migrations already recorded in schema_migrations are skipped.
+type BackupService struct {
+	db           *sql.DB
+	dbConfig     *config.DatabaseConfig
+	backupConfig *config.BackupConfig
+	client       *storage.S3Client
+	alert        Alerter
+	mu           sync.Mutex
+	stop         chan struct{}
+	done         chan struct{}
+	jobStatus
+}
+
+// NewBackupService creates a new backup service
+func NewBackupService(dbConfig *config.DatabaseConfig, backupConfig *config.BackupConfig) *BackupService {
+	return &BackupService{
+		dbConfig:     dbConfig,
+		backupConfig: backupConfig,
+		client: storage.NewS3Client(storage.S3Config{
+			Endpoint:        backupConfig.Endpoint,
+			Region:          backupConfig.Region,
+			Bucket:          backupConfig.Bucket,
+			AccessKeyID:     backupConfig.AccessKeyID,
+			SecretAccessKey: backupConfig.SecretAccessKey,
+		}),
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *BackupService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *BackupService) Name() string {
+	return "BackupService"
+}
+
+// Health checks the service health
+func (s *BackupService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if _, err := exec.LookPath("pg_dump"); err != nil {
+		return fmt.Errorf("pg_dump not found in PATH")
+	}
+	return s.db.Ping()
+}
+
+// SetAlerter reports scheduled backup failures to admins; call before Start
+func (s *BackupService) SetAlerter(alert Alerter) {
+	s.alert = alert
+}
+
+// Start begins taking backups on the configured interval
+func (s *BackupService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.backupConfig.Interval)
+		defer ticker.Stop()
+
+		logger.Info("Scheduled database backups every %s", s.backupConfig.Interval)
+		for {
+			select {
+			case <-ticker.C:
+				_, _, err := s.RunBackup()
+				if err != nil {
+					logger.Error("Scheduled backup failed: %v", err)
+					if s.alert != nil {
+						s.alert(fmt.Sprintf("❌ Scheduled database backup failed: %v", err))
+					}
+				}
+				s.recordRun(err)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts scheduled backups
+func (s *BackupService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done // Let a run in progress finish
+		s.stop = nil
+	}
+}
+
+// RunBackup dumps the database, compresses it, and uploads it.
+// Returns the object key and compressed size.
+func (s *BackupService) RunBackup() (string, int, error) {
+	// Only one backup at a time (scheduled and /backup now can overlap)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := time.Now().UTC()
+	key := path.Join(s.backupConfig.Prefix, fmt.Sprintf("hard75-%s.sql.gz", started.Format("20060102T150405Z")))
+
+	logger.Info("Starting database backup to %s/%s", s.backupConfig.Bucket, key)
+	dump, err := s.dump()
+	if err != nil {
+		return "", 0, err
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(dump); err != nil {
+		return "", 0, fmt.Errorf("failed to compress backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", 0, fmt.Errorf("failed to compress backup: %w", err)
+	}
+
+	if err := s.client.PutObject(key, compressed.Bytes(), "application/gzip"); err != nil {
+		return "", 0, fmt.Errorf("failed to upload backup: %w", err)
+	}
+
+	logger.Info("✅ Backup uploaded: %s (%d bytes, took %s)", key, compressed.Len(), time.Since(started).Round(time.Millisecond))
+	return key, compressed.Len(), nil
+}
+
+// dump runs pg_dump against the configured database and returns the SQL output
+func (s *BackupService) dump() ([]byte, error) {
+	cmd := exec.Command("pg_dump",
+		"--host", s.dbConfig.Host,
+		"--port", s.dbConfig.Port,
+		"--username", s.dbConfig.User,
+		"--dbname", s.dbConfig.DBName,
+		"--format", "plain",
+		"--clean",
+		"--if-exists",
+		"--no-owner",
+	)
+	cmd.Env = append(os.Environ(),
+		"PGPASSWORD="+s.dbConfig.Password,
+		"PGSSLMODE="+s.dbConfig.SSLMode,
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pg_dump failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// MaxBookTitleLength caps a book's title
+const MaxBookTitleLength = 200
+
+// MaxBookPages is the longest book, and the most pages logged at once, that's accepted
+const MaxBookPages = 10000
+
+// Book statuses
+const (
+	BookReading  = "reading"
+	BookFinished = "finished"
+	BookShelved  = "shelved" // Set aside when another book was started
+)
+
+// ErrNoBook is returned when pages are logged without a book in progress
+var ErrNoBook = errors.New("no book in progress")
+
+// Book is a book a member is reading, or has read, for the reading feat
+type Book struct {
+	ID          int64
+	Title       string
+	TotalPages  int
+	PagesRead   int
+	Status      string
+	StartedAt   time.Time
+	FinishedAt  time.Time // Zero until finished
+	FinishedDay int       // Challenge day it was finished on; 0 until finished
+}
+
+// BookService tracks the book each member is reading and the pages they log toward
+// it, celebrating the ones they finish
+type BookService struct {
+	db          *sql.DB
+	userService *UserService
+	events      *events.Bus
+}
+
+// NewBookService creates a new book service. Finished books are published on bus.
+func NewBookService(userService *UserService, bus *events.Bus) *BookService {
+	return &BookService{
+		userService: userService,
+		events:      bus,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *BookService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *BookService) Name() string {
+	return "BookService"
+}
+
+// Health checks the service health
+func (s *BookService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// StartBook makes title, totalPages long, the user's book in progress. A book they
+// were already reading is shelved and returned, or nil when there wasn't one.
+func (s *BookService) StartBook(userID, username, title string, totalPages int) (Book, *Book, error) {
+	if s.db == nil {
+		return Book{}, nil, fmt.Errorf("database not available")
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return Book{}, nil, fmt.Errorf("give the book's title")
+	}
+	if len(title) > MaxBookTitleLength {
+		return Book{}, nil, fmt.Errorf("title must be at most %d characters", MaxBookTitleLength)
+	}
+	if totalPages < 1 || totalPages > MaxBookPages {
+		return Book{}, nil, fmt.Errorf("pages must be between 1 and %d", MaxBookPages)
+	}
+
+	if err := s.userService.EnsureUserExists(userID, username); err != nil {
+		return Book{}, nil, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Book{}, nil, fmt.Errorf("failed to start book: %w", err)
+	}
+	defer tx.Rollback()
+
+	var shelved *Book
+	previous := Book{Status: BookShelved}
+	err = tx.QueryRow(
+		`UPDATE books SET status = $2
+		 WHERE user_id = $1 AND status = $3
+		 RETURNING id, title, total_pages, pages_read, started_at`,
+		userID, BookShelved, BookReading,
+	).Scan(&previous.ID, &previous.Title, &previous.TotalPages, &previous.PagesRead, &previous.StartedAt)
+	if err == nil {
+		shelved = &previous
+	} else if err != sql.ErrNoRows {
+		return Book{}, nil, fmt.Errorf("failed to shelve current book: %w", err)
+	}
+
+	logger.DB("Starting book: user_id=%s, pages=%d", userID, totalPages)
+	book := Book{Title: title, TotalPages: totalPages, Status: BookReading}
+	err = tx.QueryRow(
+		`INSERT INTO books (user_id, title, total_pages, status)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, started_at`,
+		userID, title, totalPages, BookReading,
+	).Scan(&book.ID, &book.StartedAt)
+	if err != nil {
+		return Book{}, nil, fmt.Errorf("failed to start book: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Book{}, nil, fmt.Errorf("failed to commit book: %w", err)
+	}
+	return book, shelved, nil
+}
+
+// LogPages adds pages read on the user's writable day to their book in progress,
+// returning ErrNoBook when there isn't one. Reaching the book's length finishes it
+// and publishes BookFinished.
+func (s *BookService) LogPages(userID, username string, pages int) (Book, error) {
+	if s.db == nil {
+		return Book{}, fmt.Errorf("database not available")
+	}
+	if pages < 1 || pages > MaxBookPages {
+		return Book{}, fmt.Errorf("pages must be between 1 and %d", MaxBookPages)
+	}
+
+	date, challengeDay, err := s.userService.GetWritableDay(userID)
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to start page log: %w", err)
+	}
+	defer tx.Rollback()
+
+	var book Book
+	err = tx.QueryRow(
+		`SELECT id, title, total_pages, pages_read, status, started_at
+		 FROM books
+		 WHERE user_id = $1 AND status = $2
+		 FOR UPDATE`,
+		userID, BookReading,
+	).Scan(&book.ID, &book.Title, &book.TotalPages, &book.PagesRead, &book.Status, &book.StartedAt)
+	if err == sql.ErrNoRows {
+		return Book{}, ErrNoBook
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to load current book: %w", err)
+	}
+
+	logger.DB("Logging pages: user_id=%s, book_id=%d, challenge_day=%d, pages=%d", userID, book.ID, challengeDay, pages)
+	_, err = tx.Exec(
+		`INSERT INTO book_pages (user_id, book_id, challenge_day, completion_date, pages)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		userID, book.ID, challengeDay, date.Format("2006-01-02"), pages,
+	)
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to log pages: %w", err)
+	}
+
+	// Pages past the end are kept in book_pages but the book stops at its length
+	book.PagesRead += pages
+	if book.PagesRead >= book.TotalPages {
+		book.PagesRead = book.TotalPages
+		book.Status = BookFinished
+		book.FinishedAt = time.Now()
+		book.FinishedDay = challengeDay
+	}
+	_, err = tx.Exec(
+		`UPDATE books SET
+			pages_read = $2,
+			status = $3,
+			finished_at = CASE WHEN $4 > 0 THEN NOW() END,
+			finished_day = NULLIF($4, 0)
+		 WHERE id = $1`,
+		book.ID, book.PagesRead, book.Status, book.FinishedDay,
+	)
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to update book: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Book{}, fmt.Errorf("failed to commit pages: %w", err)
+	}
+
+	if book.Status == BookFinished {
+		s.events.Publish(events.BookFinished{
+			UserID:       userID,
+			Username:     username,
+			BookID:       book.ID,
+			Title:        book.Title,
+			Pages:        book.TotalPages,
+			ChallengeDay: challengeDay,
+		})
+	}
+	return book, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/ical"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// CalendarPath is where the HTTP server serves calendar feeds, as CalendarPath + token + ".ics"
+const CalendarPath = "/calendar/"
+
+// calendarMilestoneDays is the spacing of milestone events, the thirds of a standard
+// 75-day challenge
+const calendarMilestoneDays = 25
+
+// ErrCalendarNotFound is returned for a feed token that doesn't exist (e.g. revoked)
+var ErrCalendarNotFound = errors.New("calendar feed not found")
+
+// CalendarService gives each user a secret iCal URL with their challenge schedule:
+// start and final days, weekly progress photo days, and milestones. The feed is built
+// on every fetch, so added days and restarts show up at the next refresh.
+type CalendarService struct {
+	db          *sql.DB
+	userService *UserService
+	publicURL   string
+}
+
+// NewCalendarService creates a new calendar service. publicURL is where the HTTP
+// server is reachable by calendar apps.
+func NewCalendarService(userService *UserService, publicURL string) *CalendarService {
+	return &CalendarService{
+		userService: userService,
+		publicURL:   publicURL,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *CalendarService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *CalendarService) Name() string {
+	return "CalendarService"
+}
+
+// Health checks the service health
+func (s *CalendarService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Link returns the user's feed URL, creating it on first use. The URL stays the same
+// until revoked so existing subscriptions keep working; locale sets the language of
+// event titles. The user must already exist.
+func (s *CalendarService) Link(userID, locale string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+
+	logger.DB("Linking calendar feed for user_id=%s", userID)
+	var token string
+	err := s.db.QueryRow(`
+		INSERT INTO calendar_feeds (user_id, token, locale)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET locale = EXCLUDED.locale
+		RETURNING token
+	`, userID, hex.EncodeToString(raw), locale).Scan(&token)
+	if err != nil {
+		return "", fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+	return s.publicURL + CalendarPath + token + ".ics", nil
+}
+
+// Revoke deletes the user's feed URL and reports whether they had one
+func (s *CalendarService) Revoke(userID string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Revoking calendar feed for user_id=%s", userID)
+	result, err := s.db.Exec(`DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke calendar feed: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Feed builds the calendar for a feed token, or returns ErrCalendarNotFound
+func (s *CalendarService) Feed(token string) (ical.Calendar, error) {
+	if s.db == nil {
+		return ical.Calendar{}, fmt.Errorf("database not available")
+	}
+
+	var userID, locale string
+	err := s.db.QueryRow(`SELECT user_id, locale FROM calendar_feeds WHERE token = $1`, token).Scan(&userID, &locale)
+	if err == sql.ErrNoRows {
+		return ical.Calendar{}, ErrCalendarNotFound
+	}
+	if err != nil {
+		return ical.Calendar{}, fmt.Errorf("failed to load calendar feed: %w", err)
+	}
+	if _, err := s.db.Exec(`UPDATE calendar_feeds SET last_fetched_at = NOW() WHERE user_id = $1`, userID); err != nil {
+		logger.Error("Failed to record calendar fetch for user_id=%s: %v", userID, err)
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return ical.Calendar{}, err
+	}
+	return challengeCalendar(progress, locale), nil
+}
+
+// challengeCalendar lays out the schedule of a user's challenge
+func challengeCalendar(progress Progress, locale string) ical.Calendar {
+	calendar := ical.Calendar{
+		Name:    i18n.T(locale, "calendar.name", progress.Username),
+		Refresh: 12 * time.Hour,
+	}
+	add := func(kind string, day int, summary, description string) {
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         fmt.Sprintf("%s-%s-%d@hard75", progress.UserID, kind, day),
+			Date:        progress.StartDate.AddDate(0, 0, day-1),
+			Summary:     summary,
+			Description: description,
+		})
+	}
+
+	add("start", 1, i18n.T(locale, "calendar.start"), i18n.T(locale, "calendar.start_description", progress.TotalDays))
+	for day := 1; day <= progress.TotalDays; day += 7 {
+		week := (day-1)/7 + 1
+		add("photo", day, i18n.T(locale, "calendar.photo", week), i18n.T(locale, "calendar.photo_description"))
+	}
+	for day := calendarMilestoneDays; day < progress.TotalDays; day += calendarMilestoneDays {
+		add("milestone", day, i18n.T(locale, "calendar.milestone", day, progress.TotalDays), "")
+	}
+	add("finish", progress.TotalDays, i18n.T(locale, "calendar.finish", progress.TotalDays), "")
+	return calendar
+}
@@ -2,10 +2,13 @@ package services
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/75-hard-discord-bot/internal/events"
 	"github.com/75-hard-discord-bot/internal/logger"
 )
 
@@ -13,12 +16,18 @@ import (
 type CheckInService struct {
 	db           *sql.DB
 	userService  *UserService
+	events       *events.Bus
+	mu           sync.RWMutex
+	queue        chan *checkInRequest // Check-ins waiting for the batch writer; nil when it isn't running
+	stop         chan struct{}
+	done         chan struct{}
 }
 
-// NewCheckInService creates a new check-in service
-func NewCheckInService(userService *UserService) *CheckInService {
+// NewCheckInService creates a new check-in service; recorded check-ins are published on bus
+func NewCheckInService(userService *UserService, bus *events.Bus) *CheckInService {
 	return &CheckInService{
 		userService: userService,
+		events:      bus,
 	}
 }
 
@@ -41,61 +50,133 @@ func (s *CheckInService) Health() error {
 	return s.db.Ping()
 }
 
-// RecordCheckIn records a check-in for the user and returns formatted DB entry info
-func (s *CheckInService) RecordCheckIn(userID, username string) (string, error) {
+// ErrCheckInClosed is returned for check-ins on a day before yesterday; a day's check-in
+// message stays open until the end of the next day for late-night check-ins
+var ErrCheckInClosed = errors.New("check-in for that day is closed")
+
+// CheckInResult is the outcome of recording a check-in
+type CheckInResult struct {
+	ChallengeDay int
+	FirstForDay  bool   // False when the day was already checked in and nothing changed
+	DBInfo       string // The day's feat rows, formatted; only filled in dev mode
+}
+
+// RecordCheckIn records today's check-in for the user. Checking in is idempotent per
+// user and day: repeats, such as reacting with several checkmarks, change nothing and
+// return FirstForDay false so callers acknowledge only the first.
+// log carries the caller's correlation ID so every step of the check-in can be traced.
+// Users must have run /start: ErrUserNotFound, or a *DayError for days outside their
+// challenge, is returned instead of recording the check-in.
+func (s *CheckInService) RecordCheckIn(log *logger.Logger, userID, username string) (CheckInResult, error) {
+	return s.recordCheckIn(log, userID, username, time.Time{})
+}
+
+// RecordCheckInForDate records the check-in for the calendar date of a check-in message,
+// so reacting after midnight to yesterday's message counts for yesterday. The date is
+// read in its own location; it may be today or yesterday in the user's zone. A date
+// ahead of the user's today counts for today: the message was posted for a day that
+// has started in the guild's zone but not yet in theirs, further west.
+func (s *CheckInService) RecordCheckInForDate(log *logger.Logger, userID, username string, date time.Time) (CheckInResult, error) {
+	return s.recordCheckIn(log, userID, username, date)
+}
+
+// recordCheckIn records the check-in for date, or for today when date is zero
+func (s *CheckInService) recordCheckIn(log *logger.Logger, userID, username string, date time.Time) (CheckInResult, error) {
 	if s.db == nil {
-		return "", fmt.Errorf("database not available")
+		return CheckInResult{}, fmt.Errorf("database not available")
 	}
+	log = log.With("user_id", userID)
 
-	// Ensure user exists in database (create if not exists)
-	logger.DB("Ensuring user exists: user_id=%s, username=%s", userID, username)
-	err := s.userService.EnsureUserExists(userID, username)
+	// Get the user's challenge dates; checking in doesn't enroll anyone, /start does
+	log.DB("Getting challenge dates for user_id=%s", userID)
+	progress, err := s.userService.challengeDates(userID)
+	if errors.Is(err, ErrUserNotFound) {
+		return CheckInResult{}, err
+	}
 	if err != nil {
-		logger.Error("Failed to ensure user exists: %v", err)
-		return "", fmt.Errorf("failed to ensure user exists: %w", err)
+		log.Error("Failed to get challenge day: %v", err)
+		return CheckInResult{}, fmt.Errorf("failed to get challenge day: %w", err)
 	}
-
-	// Get current challenge day for user
-	logger.DB("Getting current challenge day for user_id=%s", userID)
-	challengeDay, err := s.userService.GetCurrentChallengeDay(userID)
+	completionDate, err := checkInDate(progress.Date, date)
 	if err != nil {
-		logger.Error("Failed to get challenge day: %v", err)
-		return "", fmt.Errorf("failed to get challenge day: %w", err)
-	}
-
-	// Record check-in (this will trigger auto-population of all feat tables)
-	logger.DB("Recording check-in: user_id=%s, challenge_day=%d", userID, challengeDay)
-	result, err := s.db.Exec(
-		`INSERT INTO accountability_checkins (user_id, challenge_day, check_in_method) 
-		 VALUES ($1, $2, $3) 
-		 ON CONFLICT (user_id, challenge_day) DO UPDATE SET completed_at = NOW()`,
-		userID, challengeDay, "emoji_reaction",
-	)
+		return CheckInResult{}, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, completionDate)
 	if err != nil {
-		logger.Error("Failed to record check-in: %v", err)
-		return "", fmt.Errorf("failed to record check-in: %w", err)
+		return CheckInResult{}, err
 	}
+	log = log.With("challenge_day", challengeDay)
+
+	// Record check-in (this will trigger auto-population of all feat tables). Repeats
+	// leave the row alone: updating it would fire the trigger again and refill feats
+	// removed since the first check-in. Check-ins arriving together are written in
+	// one batch; see submitCheckIn.
+	log.DB("Recording check-in: user_id=%s, challenge_day=%d", userID, challengeDay)
+	inserted, err := s.submitCheckIn(userDay{userID: userID, challengeDay: challengeDay}, completionDate)
+	if err != nil {
+		log.Error("Failed to record check-in: %v", err)
+		return CheckInResult{}, fmt.Errorf("failed to record check-in: %w", err)
+	}
+	checkIn := CheckInResult{ChallengeDay: challengeDay, FirstForDay: inserted}
 
-	// Log if this was a new insert (trigger should fire)
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected > 0 {
-		logger.DB("✅ Check-in recorded for user %s, day %d (trigger should fire)", userID, challengeDay)
+	if inserted {
+		log.DB("✅ Check-in recorded for user %s, day %d (trigger should fire)", userID, challengeDay)
 	} else {
-		logger.DB("⚠️ Check-in updated for user %s, day %d (trigger may not fire on UPDATE)", userID, challengeDay)
+		log.DB("Check-in already recorded for user %s, day %d; nothing changed", userID, challengeDay)
+	}
+
+	s.events.Publish(events.CheckInRecorded{
+		UserID:       userID,
+		Username:     username,
+		ChallengeDay: challengeDay,
+		Date:         completionDate,
+		FirstForDay:  inserted,
+	})
+	if inserted {
+		s.publishIfCompleted(userID, username, challengeDay, progress.TotalDays)
 	}
 
 	// Query all feat tables to show what was created (only in dev mode)
-	var dbInfo string
-	if logger.IsDevMode() {
+	if inserted && logger.IsDevMode() {
 		logger.DB("Querying DB entries info for user_id=%s, challenge_day=%d", userID, challengeDay)
-		dbInfo, err = s.GetDBEntriesInfo(userID, challengeDay)
+		checkIn.DBInfo, err = s.GetDBEntriesInfo(userID, challengeDay)
 		if err != nil {
 			logger.Error("Failed to get DB entries info: %v", err)
-			return "", fmt.Errorf("failed to get DB entries info: %w", err)
+			return checkIn, fmt.Errorf("failed to get DB entries info: %w", err)
 		}
 	}
 
-	return dbInfo, nil
+	return checkIn, nil
+}
+
+// checkInDate returns the date in today's location that a check-in on a message dated
+// date counts for, where today is the user's date; a zero date is today. Messages from
+// before yesterday are closed (ErrCheckInClosed), and ones dated after today count
+// for today.
+func checkInDate(today, date time.Time) (time.Time, error) {
+	if date.IsZero() {
+		return today, nil
+	}
+	switch days := CalendarDaysBetween(date, today); {
+	case days > 1:
+		return time.Time{}, ErrCheckInClosed
+	case days < 0:
+		return today, nil
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, today.Location()), nil
+}
+
+// publishIfCompleted publishes ChallengeCompleted when challengeDay is the user's final
+// day of totalDays
+func (s *CheckInService) publishIfCompleted(userID, username string, challengeDay, totalDays int) {
+	if challengeDay == totalDays {
+		logger.Info("🏁 %s completed their challenge (%d days)", username, totalDays)
+		s.events.Publish(events.ChallengeCompleted{
+			UserID:    userID,
+			Username:  username,
+			TotalDays: totalDays,
+		})
+	}
 }
 
 // GetDBEntriesInfo queries all feat tables and returns formatted info
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckInDate(t *testing.T) {
+	berlin := LoadTimezone("Europe/Berlin")
+	denver := LoadTimezone("America/Denver")
+	tokyo := LoadTimezone("Asia/Tokyo")
+
+	// The user's today, as challengeDates builds it: midnight in their zone
+	userToday := func(now time.Time, loc *time.Location) time.Time {
+		local := now.In(loc)
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	}
+	// A check-in message posted at posted, dated in the guild's zone like the reaction handler does
+	messageDate := func(posted time.Time, guild *time.Location) time.Time {
+		return posted.In(guild)
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		user    *time.Location
+		date    time.Time
+		want    string
+		wantErr error
+	}{
+		{
+			name: "no message date is today",
+			now:  time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC), user: denver,
+			want: "2026-10-18",
+		},
+		{
+			name: "today's message",
+			now:  time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC), user: denver,
+			date: messageDate(time.Date(2026, time.October, 18, 6, 1, 0, 0, time.UTC), denver),
+			want: "2026-10-18",
+		},
+		{
+			name: "yesterday's message after midnight",
+			now:  time.Date(2026, time.October, 19, 7, 0, 0, 0, time.UTC), user: denver,
+			date: messageDate(time.Date(2026, time.October, 18, 6, 1, 0, 0, time.UTC), denver),
+			want: "2026-10-18",
+		},
+		{
+			name: "message from two days ago",
+			now:  time.Date(2026, time.October, 20, 7, 0, 0, 0, time.UTC), user: denver,
+			date:    messageDate(time.Date(2026, time.October, 18, 6, 1, 0, 0, time.UTC), denver),
+			wantErr: ErrCheckInClosed,
+		},
+		{
+			// Berlin's new message goes up at 00:01 on the 18th, while it's still the
+			// afternoon of the 17th in Denver
+			name: "user west of the guild reacts to the new message before their midnight",
+			now:  time.Date(2026, time.October, 17, 22, 30, 0, 0, time.UTC), user: denver,
+			date: messageDate(time.Date(2026, time.October, 17, 22, 1, 0, 0, time.UTC), berlin),
+			want: "2026-10-17",
+		},
+		{
+			name: "user west of the guild reacts to the new message after their midnight",
+			now:  time.Date(2026, time.October, 18, 7, 0, 0, 0, time.UTC), user: denver,
+			date: messageDate(time.Date(2026, time.October, 17, 22, 1, 0, 0, time.UTC), berlin),
+			want: "2026-10-18",
+		},
+		{
+			name: "user east of the guild reacts after their midnight",
+			now:  time.Date(2026, time.October, 18, 16, 0, 0, 0, time.UTC), user: tokyo,
+			date: messageDate(time.Date(2026, time.October, 17, 22, 1, 0, 0, time.UTC), berlin),
+			want: "2026-10-18",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkInDate(userToday(tt.now, tt.user), tt.date)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("checkInDate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkInDate() error = %v", err)
+			}
+			if got.Format("2006-01-02") != tt.want || got.Location() != tt.user {
+				t.Errorf("checkInDate() = %s in %s, want %s in %s", got.Format("2006-01-02"), got.Location(), tt.want, tt.user)
+			}
+			progress := Progress{StartDate: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), Date: userToday(tt.now, tt.user), TotalDays: 75}
+			if _, err := ValidateChallengeDay(progress, got); err != nil {
+				t.Errorf("ValidateChallengeDay(%s) error = %v", got.Format("2006-01-02"), err)
+			}
+		})
+	}
+}
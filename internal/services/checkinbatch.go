@@ -0,0 +1,205 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/lib/pq"
+)
+
+// checkInBatchWindow is how long the batch writer waits after a check-in arrives for
+// others to write with it. When the daily message posts, a dozen reactions can land
+// within a second.
+const checkInBatchWindow = 100 * time.Millisecond
+
+// maxCheckInBatch caps how many check-ins are written in one statement
+const maxCheckInBatch = 100
+
+// checkInRequest is a check-in waiting to be written
+type checkInRequest struct {
+	day            userDay
+	completionDate time.Time
+	done           chan checkInOutcome
+}
+
+// checkInOutcome is what happened to a check-in request
+type checkInOutcome struct {
+	inserted bool // False when the day was already checked in
+	err      error
+}
+
+// Start runs the batch writer, so check-ins that arrive together share one round of
+// queries instead of several queries each
+func (s *CheckInService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = make(chan *checkInRequest, maxCheckInBatch)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.runBatches(s.queue, s.stop, s.done)
+}
+
+// Stop writes any check-ins still waiting and halts the batch writer; later check-ins
+// are written one at a time
+func (s *CheckInService) Stop() {
+	s.mu.Lock()
+	if s.queue == nil {
+		s.mu.Unlock()
+		return
+	}
+	close(s.stop)
+	done := s.done
+	s.queue = nil
+	s.mu.Unlock()
+	<-done
+}
+
+// submitCheckIn writes the user's check-in for day, through the batch writer when it's
+// running, and reports whether it was the day's first
+func (s *CheckInService) submitCheckIn(day userDay, completionDate time.Time) (bool, error) {
+	req := &checkInRequest{day: day, completionDate: completionDate, done: make(chan checkInOutcome, 1)}
+
+	s.mu.RLock()
+	if s.queue != nil {
+		// Sent under the lock, so Stop can't close the writer with this request unread
+		s.queue <- req
+		s.mu.RUnlock()
+	} else {
+		s.mu.RUnlock()
+		s.writeCheckIns([]*checkInRequest{req})
+	}
+
+	outcome := <-req.done
+	return outcome.inserted, outcome.err
+}
+
+// runBatches collects check-ins from queue for up to checkInBatchWindow after the
+// first arrives and writes them together, until stop is closed
+func (s *CheckInService) runBatches(queue chan *checkInRequest, stop, done chan struct{}) {
+	defer close(done)
+	for {
+		var batch []*checkInRequest
+		select {
+		case req := <-queue:
+			batch = append(batch, req)
+		case <-stop:
+			// Stop holds the lock while closing, so nothing more can be queued
+			for {
+				select {
+				case req := <-queue:
+					batch = append(batch, req)
+				default:
+					if len(batch) > 0 {
+						s.writeCheckIns(batch)
+					}
+					return
+				}
+			}
+		}
+
+		timer := time.NewTimer(checkInBatchWindow)
+	collect:
+		for len(batch) < maxCheckInBatch {
+			select {
+			case req := <-queue:
+				batch = append(batch, req)
+			case <-timer.C:
+				break collect
+			case <-stop:
+				break collect
+			}
+		}
+		timer.Stop()
+		s.writeCheckIns(batch)
+	}
+}
+
+// writeCheckIns records a batch of check-ins with one multi-row insert and answers
+// each request. Requests for the same user and day are coalesced: the first is written
+// and the rest are told the day was already checked in. Only a day's first check-in
+// is journaled for /undo; repeats change nothing worth reversing.
+func (s *CheckInService) writeCheckIns(batch []*checkInRequest) {
+	var unique []*checkInRequest
+	repeats := make(map[userDay][]*checkInRequest)
+	for _, req := range batch {
+		if _, seen := repeats[req.day]; !seen {
+			unique = append(unique, req)
+		}
+		repeats[req.day] = append(repeats[req.day], req)
+	}
+	answer := func(req *checkInRequest, outcome checkInOutcome) {
+		for i, r := range repeats[req.day] {
+			if i > 0 && outcome.err == nil {
+				outcome.inserted = false
+			}
+			r.done <- outcome
+		}
+	}
+
+	inserted, err := s.insertCheckIns(unique)
+	if err != nil && len(unique) > 1 {
+		// One bad row, such as a user deleted meanwhile, shouldn't fail everyone else's
+		logger.Error("Batched check-in insert failed, writing %d check-ins one at a time: %v", len(unique), err)
+		for _, req := range unique {
+			s.writeCheckIns(repeats[req.day])
+		}
+		return
+	}
+	for _, req := range unique {
+		answer(req, checkInOutcome{inserted: inserted[req.day], err: err})
+	}
+}
+
+// insertCheckIns snapshots the days for /undo, inserts check-ins for the days that
+// don't have one, journals those, and returns which days were inserted
+func (s *CheckInService) insertCheckIns(batch []*checkInRequest) (map[userDay]bool, error) {
+	days := make([]userDay, len(batch))
+	userIDs := make([]string, len(batch))
+	challengeDays := make([]int64, len(batch))
+	dates := make([]string, len(batch))
+	for i, req := range batch {
+		days[i] = req.day
+		userIDs[i] = req.day.userID
+		challengeDays[i] = int64(req.day.challengeDay)
+		dates[i] = req.completionDate.Format("2006-01-02")
+	}
+
+	journal, err := snapshotDays(s.db, ActionCheckIn, days)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.DB("Inserting %d check-in(s) in one batch", len(batch))
+	rows, err := s.db.Query(
+		`INSERT INTO accountability_checkins (user_id, challenge_day, completion_date, check_in_method)
+		 SELECT d.user_id, d.challenge_day, d.completion_date, 'emoji_reaction'
+		 FROM unnest($1::text[], $2::int[], $3::date[]) AS d(user_id, challenge_day, completion_date)
+		 ON CONFLICT (user_id, challenge_day) DO NOTHING
+		 RETURNING user_id, challenge_day`,
+		pq.Array(userIDs), pq.Array(challengeDays), pq.Array(dates),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inserted := make(map[userDay]bool)
+	var entries []*journalEntry
+	for rows.Next() {
+		var day userDay
+		if err := rows.Scan(&day.userID, &day.challengeDay); err != nil {
+			return nil, fmt.Errorf("failed to read inserted check-ins: %w", err)
+		}
+		inserted[day] = true
+		if entry, ok := journal[day]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	recordActions(s.db, entries)
+	return inserted, nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/llm"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// ErrCoachLimitReached is returned when the user has used up today's coaching replies
+var ErrCoachLimitReached = errors.New("daily coaching limit reached")
+
+const (
+	// coachDays is how many recent challenge days of stats the coach sees
+	coachDays = 7
+	// coachWeighIns is how many recent weigh-ins the coach sees for the weight trend
+	coachWeighIns = 8
+	// coachMaxTokens bounds a reply, which has to fit in one Discord message
+	coachMaxTokens = 500
+)
+
+// coachSystemPrompt gives the model the challenge rules it coaches within; %s is the
+// language to reply in
+const coachSystemPrompt = `You are an upbeat, practical coach for a member of the 75 Half Chub challenge, a gentler take on 75 Hard for busy dads. Every day for at least 75 days, members:
+1. Follow a diet: no cheat meals and no alcohol
+2. Do one workout of 30+ minutes, indoors or outdoors; walking only counts with a weight vest
+3. Do 10+ minutes of core or mobility work
+4. Drink a gallon (128 oz) of water, which doesn't have to be plain
+5. Spend 30 minutes on intentional self-improvement: reading, learning, journaling, or studying
+6. Check in
+7. Post a weekly progress photo
+8. Spend money on necessities only
+Missing any task adds 7 days to the end date. Members can ask the group to waive that for emergencies such as sick kids.
+
+From the member's stats below, give personal encouragement and two or three concrete suggestions for the next few days, focused on what they miss most. Stay within the rules: never suggest skipping or bending one. Don't give medical advice; point them to a doctor for injuries, illness, or fast weight changes. If they ask a question, answer it first. Be warm and brief: under 150 words of plain text or simple Markdown, without headings. Reply in %s.`
+
+// CoachService asks an LLM for personalized encouragement based on a member's
+// recent stats, at most dailyLimit times per member per day
+type CoachService struct {
+	db          *sql.DB
+	client      *llm.Client
+	dailyLimit  int
+	userService *UserService
+	preferences *PreferencesService
+	summaries   *SummaryService
+}
+
+// NewCoachService creates a new coach service
+func NewCoachService(client *llm.Client, dailyLimit int, userService *UserService, preferences *PreferencesService, summaries *SummaryService) *CoachService {
+	return &CoachService{
+		client:      client,
+		dailyLimit:  dailyLimit,
+		userService: userService,
+		preferences: preferences,
+		summaries:   summaries,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *CoachService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *CoachService) Name() string {
+	return "CoachService"
+}
+
+// Health checks the service health
+func (s *CoachService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// DailyLimit is how many replies each member gets per day
+func (s *CoachService) DailyLimit() int {
+	return s.dailyLimit
+}
+
+// Coach returns coaching for the user in locale, answering question when it isn't
+// empty, and how many replies they have left today. Only stats are sent to the
+// provider, never names. Returns ErrUserNotFound before the challenge starts and
+// ErrCoachLimitReached once today's replies are used up; failed replies aren't counted.
+func (s *CoachService) Coach(ctx context.Context, userID, locale, question string) (string, int, error) {
+	if s.db == nil {
+		return "", 0, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return "", 0, err
+	}
+	prefs, err := s.preferences.Get(userID)
+	if err != nil {
+		return "", 0, err
+	}
+	prompt, err := s.stats(progress, prefs)
+	if err != nil {
+		return "", 0, err
+	}
+	if question = strings.TrimSpace(question); question != "" {
+		prompt += "\nThe member asks: " + question + "\n"
+	}
+
+	// Reserve one of today's replies first, so concurrent requests can't exceed the cap
+	usageDate := progress.Date.Format("2006-01-02")
+	var used int
+	err = s.db.QueryRow(`
+		INSERT INTO coach_usage (user_id, usage_date, requests)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (user_id, usage_date) DO UPDATE SET requests = coach_usage.requests + 1
+		WHERE coach_usage.requests < $3
+		RETURNING requests
+	`, userID, usageDate, s.dailyLimit).Scan(&used)
+	if err == sql.ErrNoRows {
+		return "", 0, ErrCoachLimitReached
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to record coaching request: %w", err)
+	}
+
+	language, ok := i18n.Names[locale]
+	if !ok {
+		language = i18n.Names[i18n.Default]
+	}
+	reply, err := s.client.Complete(ctx, fmt.Sprintf(coachSystemPrompt, language), prompt, coachMaxTokens)
+	if err != nil {
+		if _, refundErr := s.db.Exec(
+			`UPDATE coach_usage SET requests = requests - 1 WHERE user_id = $1 AND usage_date = $2 AND requests > 0`,
+			userID, usageDate,
+		); refundErr != nil {
+			logger.Error("Failed to refund coaching request for user_id=%s: %v", userID, refundErr)
+		}
+		return "", 0, fmt.Errorf("failed to get coaching: %w", err)
+	}
+	return reply, s.dailyLimit - used, nil
+}
+
+// stats describes the user's challenge for the model: where they are, the last
+// coachDays days' feats, water, and workouts, and their weight trend, in their units
+func (s *CoachService) stats(progress Progress, prefs Preferences) (string, error) {
+	firstDay := progress.ChallengeDay - coachDays + 1
+	if firstDay < 1 {
+		firstDay = 1
+	}
+	lastDay := progress.ChallengeDay
+	if lastDay > progress.TotalDays {
+		lastDay = progress.TotalDays
+	}
+
+	days, err := s.summaries.GetCompletions(progress.UserID, progress.StartDate, firstDay, lastDay)
+	if err != nil {
+		return "", err
+	}
+	current, longest, err := s.summaries.GetStreaks(progress.UserID, progress.ChallengeDay)
+	if err != nil {
+		return "", err
+	}
+	water, err := s.summaries.GetWaterOunces(progress.UserID, firstDay, lastDay)
+	if err != nil {
+		return "", err
+	}
+	workouts, err := s.workouts(progress.UserID, firstDay, lastDay)
+	if err != nil {
+		return "", err
+	}
+	weighIns, err := s.summaries.GetWeighIns(progress.UserID, coachWeighIns, prefs)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Today is challenge day %d of %d", progress.ChallengeDay, progress.TotalDays)
+	if extra := progress.TotalDays - 75; extra > 0 {
+		fmt.Fprintf(&b, " (%d penalty days added)", extra)
+	}
+	fmt.Fprintf(&b, ". Check-in streak: %d days, longest %d. Days completed: %d.\n", current, longest, progress.DaysCompleted)
+
+	fmt.Fprintf(&b, "\nLast %d days (today's tasks may still be in progress):\n", len(days))
+	for _, day := range days {
+		var done, missed []string
+		for i, feat := range CompletionFeats {
+			if day.Done[i] {
+				done = append(done, feat)
+			} else {
+				missed = append(missed, feat)
+			}
+		}
+		fmt.Fprintf(&b, "- Day %d (%s): done %s; missed %s; water %.1f %s",
+			day.Day, day.Date.Format("Mon Jan 2"), listOrNone(done), listOrNone(missed),
+			prefs.Units.FromOunces(water[day.Day]), prefs.Units.Volume)
+		if workout, ok := workouts[day.Day]; ok {
+			fmt.Fprintf(&b, "; workout %s", workout)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(weighIns) > 0 {
+		fmt.Fprintf(&b, "\nRecent weigh-ins (%s, oldest first):", prefs.Units.Weight)
+		for _, weighIn := range weighIns {
+			fmt.Fprintf(&b, " %s %.1f;", weighIn.Date.Format("Jan 2"), weighIn.Weight)
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString("\nNo weigh-ins logged.\n")
+	}
+	return b.String(), nil
+}
+
+// workouts describes the user's logged workouts for challenge days firstDay through
+// lastDay, keyed by day, e.g. "45 min run outdoor + 10 min core yoga"
+func (s *CoachService) workouts(userID string, firstDay, lastDay int) (map[int]string, error) {
+	rows, err := s.summaries.reader().Query(`
+		SELECT challenge_day, COALESCE(workout_duration_minutes, 0), COALESCE(workout_type, ''),
+		       COALESCE(workout_location, ''), COALESCE(core_mobility_duration_minutes, 0), COALESCE(core_mobility_type, '')
+		FROM exercise_completions
+		WHERE user_id = $1 AND challenge_day BETWEEN $2 AND $3
+	`, userID, firstDay, lastDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workouts: %w", err)
+	}
+	defer rows.Close()
+
+	workouts := make(map[int]string)
+	for rows.Next() {
+		var day, minutes, coreMinutes int
+		var workoutType, location, coreType string
+		if err := rows.Scan(&day, &minutes, &workoutType, &location, &coreMinutes, &coreType); err != nil {
+			return nil, fmt.Errorf("failed to scan workout: %w", err)
+		}
+		workouts[day] = strings.Join(strings.Fields(fmt.Sprintf("%d min %s %s + %d min core %s",
+			minutes, workoutType, location, coreMinutes, coreType)), " ")
+	}
+	return workouts, rows.Err()
+}
+
+// listOrNone joins items for the prompt, or returns "none"
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
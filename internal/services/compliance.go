@@ -0,0 +1,187 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// FeatWater is water's name in challenge_failures.failed_feats
+const FeatWater = "water"
+
+// WaterShortfall is a day a participant ended under the water goal
+type WaterShortfall struct {
+	UserID       string
+	Username     string
+	Privacy      string
+	Units        Units
+	ChallengeDay int
+	Date         time.Time // The user's calendar date that ended
+	TotalOunces  float64   // What the day's water entries add up to
+}
+
+// ComplianceService closes out participants' days once they end in their zone
+type ComplianceService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewComplianceService creates a new compliance service; days end at midnight in
+// userService's default zone for users who haven't chosen one
+func NewComplianceService(userService *UserService) *ComplianceService {
+	return &ComplianceService{userService: userService}
+}
+
+// Initialize initializes the service with database connection
+func (s *ComplianceService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *ComplianceService) Name() string {
+	return "ComplianceService"
+}
+
+// Health checks the service health
+func (s *ComplianceService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// CloseWaterDays checks the water of every participant whose day has ended by now in
+// their zone. A check-in fills in a gallon for a day with no water logged, so a day's
+// water entries rather than its water_completions row say what was drunk; rollup
+// entries only record totals changed outside the entry log, such as that gallon, and
+// aren't counted. A day whose entries fall short of the goal has its row lowered to
+// what was logged and water added to its challenge_failures row. Days an admin or
+// import set (autopopulated = false) are left as they are, and each day is closed once.
+// Returns the shortfalls found.
+func (s *ComplianceService) CloseWaterDays(now time.Time) ([]WaterShortfall, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	// Yesterday in each user's zone is the day that ended; only days still claiming
+	// water they didn't log are candidates
+	rows, err := s.db.Query(`
+		SELECT u.user_id, u.username, u.challenge_start_date, u.current_challenge_end_date,
+			p.timezone, COALESCE(p.privacy, $3), COALESCE(p.volume_unit, $4)
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		JOIN water_completions w ON w.user_id = u.user_id
+			AND w.challenge_day = ($1::timestamptz AT TIME ZONE COALESCE(p.timezone, $2))::date - u.challenge_start_date::date
+			AND COALESCE(w.autopopulated, true)
+		WHERE (SELECT COALESCE(SUM(e.amount_ounces), 0) FROM water_entries e
+			WHERE e.user_id = w.user_id AND e.challenge_day = w.challenge_day AND e.source <> $5) < $6
+	`, now, s.userService.DefaultTimezone(), DefaultPreferences.Privacy, DefaultUnits.Volume, SourceRollup, WaterGoalOunces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query water days to close: %w", err)
+	}
+	defer rows.Close()
+
+	fallback := LoadTimezone(s.userService.DefaultTimezone())
+	var candidates []WaterShortfall
+	for rows.Next() {
+		var shortfall WaterShortfall
+		var startDate, endDate time.Time
+		var timezone sql.NullString
+		shortfall.Units = DefaultUnits
+		if err := rows.Scan(&shortfall.UserID, &shortfall.Username, &startDate, &endDate,
+			&timezone, &shortfall.Privacy, &shortfall.Units.Volume); err != nil {
+			logger.Error("Failed to scan water day row: %v", err)
+			continue
+		}
+		prefs := Preferences{Timezone: timezone.String}
+		day, date, ok := endedChallengeDay(now.In(prefs.Location(fallback)), startDate, endDate)
+		if !ok {
+			continue
+		}
+		shortfall.ChallengeDay, shortfall.Date = day, date
+		candidates = append(candidates, shortfall)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read water days to close: %w", err)
+	}
+
+	var shortfalls []WaterShortfall
+	for _, shortfall := range candidates {
+		total, missed, err := s.markWaterMissed(shortfall.UserID, shortfall.ChallengeDay)
+		if err != nil {
+			logger.Error("Failed to close water for user_id=%s, challenge_day=%d: %v", shortfall.UserID, shortfall.ChallengeDay, err)
+			continue
+		}
+		if missed {
+			shortfall.TotalOunces = total
+			shortfalls = append(shortfalls, shortfall)
+		}
+	}
+
+	logger.DB("Closed %d water days short of the goal", len(shortfalls))
+	return shortfalls, nil
+}
+
+// endedChallengeDay returns the challenge day and date before local's date, the day
+// that has ended, and whether it falls within the challenge running from startDate
+// to endDate. endDate is the day after the last, as StartChallenge stores it.
+func endedChallengeDay(local, startDate, endDate time.Time) (int, time.Time, bool) {
+	yesterday := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, local.Location())
+	day := ChallengeDayForDate(startDate, yesterday)
+	if day < 1 || day > CalendarDaysBetween(startDate, endDate) {
+		return 0, time.Time{}, false
+	}
+	return day, yesterday, true
+}
+
+// markWaterMissed settles the user's water row for challengeDay to what was logged and
+// records water as a failed feat, when the row is still autopopulated and what was
+// logged falls short of the goal. Returns the day's logged total and whether the day
+// was marked missed.
+func (s *ComplianceService) markWaterMissed(userID string, challengeDay int) (float64, bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to start water check: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The row stays locked, so a late log or edit can't land between the check and the update
+	claimed, total, autopopulated, err := settleClaimedWater(tx, userID, challengeDay)
+	if err != nil {
+		return 0, false, err
+	}
+	if !autopopulated || total >= WaterGoalOunces {
+		return total, false, nil
+	}
+
+	logger.DB("Marking water missed: user_id=%s, challenge_day=%d, claimed=%.2f oz, logged=%.2f oz", userID, challengeDay, claimed, total)
+	_, err = tx.Exec(
+		`UPDATE water_completions SET
+			autopopulated = false,
+			metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('missed', true, 'claimed_ounces', $3::numeric)
+		 WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay, claimed,
+	)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to update water total: %w", err)
+	}
+	_, err = tx.Exec(
+		`INSERT INTO challenge_failures (user_id, challenge_day, failed_feats, metadata)
+		 VALUES ($1, $2, ARRAY[$3::text], jsonb_build_object('water_ounces', $4::numeric))
+		 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			failed_feats = array_append(COALESCE(challenge_failures.failed_feats, '{}'), $3::text),
+			metadata = COALESCE(challenge_failures.metadata, '{}'::jsonb) || EXCLUDED.metadata
+		 WHERE NOT ($3::text = ANY(COALESCE(challenge_failures.failed_feats, '{}')))`,
+		userID, challengeDay, FeatWater, total,
+	)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to record missed water: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit water check: %w", err)
+	}
+	return total, true, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"database/sql"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/database"
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// testDB connects to the Postgres database set by TEST_DB_HOST and TEST_DB_PASSWORD
+// (and TEST_DB_PORT, TEST_DB_USER, TEST_DB_NAME, and TEST_DB_SSLMODE when they differ
+// from the defaults), running the migrations, or skips the test without one. Tests
+// write to it, so point it at a scratch database.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	host, password := os.Getenv("TEST_DB_HOST"), os.Getenv("TEST_DB_PASSWORD")
+	if host == "" || password == "" {
+		t.Skip("TEST_DB_HOST and TEST_DB_PASSWORD aren't set")
+	}
+	getenv := func(key, fallback string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return fallback
+	}
+	db, err := database.Connect(&database.Config{
+		Host:     host,
+		Port:     getenv("TEST_DB_PORT", "5432"),
+		User:     getenv("TEST_DB_USER", "postgres"),
+		Password: password,
+		DBName:   getenv("TEST_DB_NAME", "hard75_test"),
+		SSLMode:  getenv("TEST_DB_SSLMODE", "disable"),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestEndedChallengeDay(t *testing.T) {
+	start := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 75) // As StartChallenge stores it, the day after the last
+	denver := LoadTimezone("America/Denver")
+	tokyo := LoadTimezone("Asia/Tokyo")
+
+	tests := []struct {
+		name     string
+		local    time.Time
+		wantDay  int
+		wantDate string
+		wantOK   bool
+	}{
+		{name: "just after midnight", local: time.Date(2026, time.October, 18, 0, 5, 0, 0, denver), wantDay: 17, wantDate: "2026-10-17", wantOK: true},
+		{name: "late in the day", local: time.Date(2026, time.October, 18, 23, 55, 0, 0, denver), wantDay: 17, wantDate: "2026-10-17", wantOK: true},
+		{name: "the zone decides the date", local: time.Date(2026, time.October, 18, 1, 0, 0, 0, tokyo), wantDay: 17, wantDate: "2026-10-17", wantOK: true},
+		{name: "first day ended", local: time.Date(2026, time.October, 2, 0, 5, 0, 0, denver), wantDay: 1, wantDate: "2026-10-01", wantOK: true},
+		{name: "first day still going", local: time.Date(2026, time.October, 1, 23, 0, 0, 0, denver)},
+		{name: "before the start", local: time.Date(2026, time.September, 20, 12, 0, 0, 0, denver)},
+		{name: "last day ended", local: time.Date(2026, time.December, 15, 0, 5, 0, 0, denver), wantDay: 75, wantDate: "2026-12-14", wantOK: true},
+		{name: "last day still going", local: time.Date(2026, time.December, 14, 23, 0, 0, 0, denver), wantDay: 74, wantDate: "2026-12-13", wantOK: true},
+		{name: "after the last day", local: time.Date(2026, time.December, 16, 0, 5, 0, 0, denver)},
+		{name: "across a DST change", local: time.Date(2026, time.November, 2, 0, 5, 0, 0, denver), wantDay: 32, wantDate: "2026-11-01", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, date, ok := endedChallengeDay(tt.local, start, end)
+			if ok != tt.wantOK || day != tt.wantDay {
+				t.Fatalf("endedChallengeDay() = day %d, %v; want day %d, %v", day, ok, tt.wantDay, tt.wantOK)
+			}
+			if ok && date.Format("2006-01-02") != tt.wantDate {
+				t.Errorf("endedChallengeDay() date = %s, want %s", date.Format("2006-01-02"), tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestCloseWaterDaysAfterCheckIn(t *testing.T) {
+	db := testDB(t)
+	userService := NewUserService(0, "UTC")
+	bus := events.NewBus()
+	checkIns := NewCheckInService(userService, bus)
+	water := NewWaterService(userService, bus)
+	compliance := NewComplianceService(userService)
+	for _, svc := range []Service{userService, checkIns, water, compliance} {
+		if err := svc.Initialize(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userID := "test-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	today := time.Now().UTC()
+	if _, _, err := userService.StartChallenge(userID, "tester", today.AddDate(0, 0, -3)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { userService.DeleteUserData(userID) })
+
+	// The check-in claims a gallon, then the member logs half of one
+	checkIn, err := checkIns.RecordCheckIn(logger.With(), userID, "tester")
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, total, err := water.AddWater(userID, "tester", 64, DefaultUnits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 64 || total != 64 {
+		t.Errorf("AddWater() after a check-in = %.2f added, %.2f total; want 64 and 64", added, total)
+	}
+
+	shortfalls, err := compliance.CloseWaterDays(today.Add(24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, shortfall := range shortfalls {
+		if shortfall.UserID != userID {
+			continue
+		}
+		found = true
+		if shortfall.ChallengeDay != checkIn.ChallengeDay || shortfall.TotalOunces != 64 {
+			t.Errorf("shortfall = day %d, %.2f oz; want day %d, 64 oz", shortfall.ChallengeDay, shortfall.TotalOunces, checkIn.ChallengeDay)
+		}
+	}
+	if !found {
+		t.Fatalf("CloseWaterDays() = %+v, want a shortfall for %s", shortfalls, userID)
+	}
+
+	var manual, entries, row float64
+	err = db.QueryRow(
+		`SELECT COALESCE(SUM(amount_ounces) FILTER (WHERE source = $3), 0), COALESCE(SUM(amount_ounces), 0)
+		 FROM water_entries WHERE user_id = $1 AND challenge_day = $2`,
+		userID, checkIn.ChallengeDay, SourceManual,
+	).Scan(&manual, &entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.QueryRow(`SELECT amount_ounces FROM water_completions WHERE user_id = $1 AND challenge_day = $2`,
+		userID, checkIn.ChallengeDay).Scan(&row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manual != 64 || entries != 64 || row != 64 {
+		t.Errorf("manual entries %.2f oz, all entries %.2f oz, day total %.2f oz; want 64 each", manual, entries, row)
+	}
+	var missed bool
+	err = db.QueryRow(`SELECT $3 = ANY(failed_feats) FROM challenge_failures WHERE user_id = $1 AND challenge_day = $2`,
+		userID, checkIn.ChallengeDay, FeatWater).Scan(&missed)
+	if err != nil || !missed {
+		t.Errorf("challenge_failures has water missed = %v (%v), want true", missed, err)
+	}
+
+	// The day is closed once
+	shortfalls, err = compliance.CloseWaterDays(today.Add(24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, shortfall := range shortfalls {
+		if shortfall.UserID == userID {
+			t.Errorf("CloseWaterDays() closed day %d again", shortfall.ChallengeDay)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Providers users can link with /connect
+const (
+	ProviderStrava = "strava"
+	ProviderFitbit = "fitbit"
+)
+
+// OAuthStateTTL is how long a /connect link stays usable
+const OAuthStateTTL = 15 * time.Minute
+
+// ErrConnectionNotFound is returned when a user hasn't linked the provider
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// ErrInvalidOAuthState is returned for an unknown, used, or expired OAuth state
+var ErrInvalidOAuthState = errors.New("sign-in link is invalid or expired")
+
+// AccountLinker is a service that links users' accounts at a provider
+type AccountLinker interface {
+	// Provider is the provider's name, one of the Provider constants
+	Provider() string
+	// AuthorizeURL starts linking the user's account
+	AuthorizeURL(userID string) (string, error)
+	// Disconnect unlinks the user's account and reports whether one was linked
+	Disconnect(userID string) (bool, error)
+}
+
+// Connection is a user's linked account at a provider
+type Connection struct {
+	UserID       string
+	Provider     string
+	ExternalID   string
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// ConnectionService stores OAuth tokens for linked fitness accounts
+type ConnectionService struct {
+	db *sql.DB
+}
+
+// NewConnectionService creates a new connection service
+func NewConnectionService() *ConnectionService {
+	return &ConnectionService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *ConnectionService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *ConnectionService) Name() string {
+	return "ConnectionService"
+}
+
+// Health checks the service health
+func (s *ConnectionService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// NewState starts an OAuth sign-in for the user and returns its state parameter
+func (s *ConnectionService) NewState(userID, provider string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	state := hex.EncodeToString(raw)
+
+	// Expired states are cleared as new ones are made
+	if _, err := s.db.Exec(`DELETE FROM oauth_states WHERE created_at < NOW() - $1 * INTERVAL '1 second'`,
+		int(OAuthStateTTL.Seconds())); err != nil {
+		logger.Warn("Failed to clear expired OAuth states: %v", err)
+	}
+
+	logger.DB("Starting OAuth sign-in: user_id=%s, provider=%s", userID, provider)
+	_, err := s.db.Exec(`INSERT INTO oauth_states (state, user_id, provider) VALUES ($1, $2, $3)`, state, userID, provider)
+	if err != nil {
+		return "", fmt.Errorf("failed to save state: %w", err)
+	}
+	return state, nil
+}
+
+// ConsumeState ends a sign-in and returns whose it was, or ErrInvalidOAuthState.
+// A state works once.
+func (s *ConnectionService) ConsumeState(state, provider string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	var userID string
+	err := s.db.QueryRow(`
+		DELETE FROM oauth_states
+		WHERE state = $1 AND provider = $2 AND created_at >= NOW() - $3 * INTERVAL '1 second'
+		RETURNING user_id
+	`, state, provider, int(OAuthStateTTL.Seconds())).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", ErrInvalidOAuthState
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check state: %w", err)
+	}
+	return userID, nil
+}
+
+// Save stores a connection, replacing the user's previous one for the provider.
+// An account linked to another user moves to this one.
+func (s *ConnectionService) Save(c Connection) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	logger.DB("Saving connection: user_id=%s, provider=%s, external_id=%s", c.UserID, c.Provider, c.ExternalID)
+	if _, err := tx.Exec(`DELETE FROM oauth_connections WHERE provider = $1 AND external_id = $2 AND user_id <> $3`,
+		c.Provider, c.ExternalID, c.UserID); err != nil {
+		return fmt.Errorf("failed to unlink previous owner: %w", err)
+	}
+	_, err = tx.Exec(`
+		INSERT INTO oauth_connections (user_id, provider, external_id, access_token, refresh_token, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			external_id = EXCLUDED.external_id,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			expires_at = EXCLUDED.expires_at,
+			created_at = NOW()
+	`, c.UserID, c.Provider, c.ExternalID, c.AccessToken, c.RefreshToken, c.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save connection: %w", err)
+	}
+	return tx.Commit()
+}
+
+// UpdateTokens stores refreshed tokens for a connection
+func (s *ConnectionService) UpdateTokens(c Connection) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	_, err := s.db.Exec(`
+		UPDATE oauth_connections SET access_token = $3, refresh_token = $4, expires_at = $5
+		WHERE user_id = $1 AND provider = $2
+	`, c.UserID, c.Provider, c.AccessToken, c.RefreshToken, c.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to update tokens: %w", err)
+	}
+	return nil
+}
+
+// Get returns the user's connection to provider, or ErrConnectionNotFound
+func (s *ConnectionService) Get(userID, provider string) (Connection, error) {
+	return s.get(`user_id = $1 AND provider = $2`, userID, provider)
+}
+
+// ByExternalID returns the connection for a provider account, or ErrConnectionNotFound
+func (s *ConnectionService) ByExternalID(provider, externalID string) (Connection, error) {
+	return s.get(`provider = $1 AND external_id = $2`, provider, externalID)
+}
+
+// get loads the connection matching where
+func (s *ConnectionService) get(where string, args ...interface{}) (Connection, error) {
+	if s.db == nil {
+		return Connection{}, fmt.Errorf("database not available")
+	}
+
+	var c Connection
+	err := s.db.QueryRow(`
+		SELECT user_id, provider, external_id, access_token, refresh_token, expires_at
+		FROM oauth_connections WHERE `+where, args...,
+	).Scan(&c.UserID, &c.Provider, &c.ExternalID, &c.AccessToken, &c.RefreshToken, &c.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return Connection{}, ErrConnectionNotFound
+	}
+	if err != nil {
+		return Connection{}, fmt.Errorf("failed to get connection: %w", err)
+	}
+	return c, nil
+}
+
+// List returns every connection to provider
+func (s *ConnectionService) List(provider string) ([]Connection, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT user_id, provider, external_id, access_token, refresh_token, expires_at
+		FROM oauth_connections WHERE provider = $1
+		ORDER BY user_id
+	`, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+	defer rows.Close()
+
+	var connections []Connection
+	for rows.Next() {
+		var c Connection
+		if err := rows.Scan(&c.UserID, &c.Provider, &c.ExternalID, &c.AccessToken, &c.RefreshToken, &c.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		connections = append(connections, c)
+	}
+	return connections, rows.Err()
+}
+
+// Delete unlinks the user's account at provider and reports whether one was linked
+func (s *ConnectionService) Delete(userID, provider string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Deleting connection: user_id=%s, provider=%s", userID, provider)
+	result, err := s.db.Exec(`DELETE FROM oauth_connections WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete connection: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// SourceAdmin marks feat rows an admin entered with /backfill in their metadata
+const SourceAdmin = "admin"
+
+// ErrTooManyDaysRemoved is returned when an adjustment would take off more days than
+// were ever added; the original end date can't move earlier
+var ErrTooManyDaysRemoved = errors.New("can't remove more days than were added")
+
+// ErrLogsBeforeStart is returned when a new start date would leave logged days
+// before the challenge begins
+var ErrLogsBeforeStart = errors.New("logged days would fall before the new start date")
+
+// ErrAlreadyLogged is returned when a backfilled feat is already logged for the day
+var ErrAlreadyLogged = errors.New("already logged for that day")
+
+// relinkOffset is added to re-linked days while rows move, so a shifted day doesn't
+// collide with a row that hasn't moved yet
+const relinkOffset = 1000000
+
+// relinkDayTables hold per-day rows without a completion date, which move by the
+// same number of days as the start date
+var relinkDayTables = []string{
+	"challenge_failures",
+	"council_exceptions",
+}
+
+// CorrectionService makes admin corrections to members' challenges, each recorded
+// in the audit log
+type CorrectionService struct {
+	db          *sql.DB
+	userService *UserService
+	leaderboard *LeaderboardService
+	events      *events.Bus
+}
+
+// NewCorrectionService creates a new correction service; leaderboard is refreshed
+// after corrections that change which days count, and adjustments are published on bus
+func NewCorrectionService(userService *UserService, leaderboard *LeaderboardService, bus *events.Bus) *CorrectionService {
+	return &CorrectionService{
+		userService: userService,
+		leaderboard: leaderboard,
+		events:      bus,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *CorrectionService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *CorrectionService) Name() string {
+	return "CorrectionService"
+}
+
+// Health checks the service health
+func (s *CorrectionService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Adjustment is a change to a member's end date made with /adjust
+type Adjustment struct {
+	Username   string
+	DaysAdded  int       // Negative when days were taken off
+	TotalAdded int       // Days now added to the challenge in all
+	LastDate   time.Time // The challenge's final day after the change
+}
+
+// AdjustDays adds days to the user's challenge, or takes them off when days is
+// negative, moving their end date. actorID is the admin making the change and reason
+// is required. The change is audited and published as a penalty; silent asks
+// subscribers not to announce it.
+func (s *CorrectionService) AdjustDays(userID, actorID string, days int, reason string, silent bool) (Adjustment, error) {
+	if s.db == nil {
+		return Adjustment{}, fmt.Errorf("database not available")
+	}
+	reason = strings.TrimSpace(reason)
+	if days == 0 {
+		return Adjustment{}, fmt.Errorf("days must not be 0")
+	}
+	if reason == "" {
+		return Adjustment{}, fmt.Errorf("a reason is required")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Adjustment{}, fmt.Errorf("failed to start adjustment: %w", err)
+	}
+	defer tx.Rollback()
+
+	adjustment := Adjustment{DaysAdded: days}
+	var endDate time.Time
+	var added int
+	err = tx.QueryRow(
+		`SELECT username, current_challenge_end_date, COALESCE(days_added, 0) FROM users WHERE user_id = $1 FOR UPDATE`,
+		userID,
+	).Scan(&adjustment.Username, &endDate, &added)
+	if err == sql.ErrNoRows {
+		return Adjustment{}, ErrUserNotFound
+	}
+	if err != nil {
+		return Adjustment{}, fmt.Errorf("failed to load user: %w", err)
+	}
+	if added+days < 0 {
+		return Adjustment{}, ErrTooManyDaysRemoved
+	}
+
+	logger.DB("Adjusting challenge: user_id=%s, days=%+d, by actor_id=%s", userID, days, actorID)
+	var newEndDate time.Time
+	err = tx.QueryRow(
+		`UPDATE users
+		 SET current_challenge_end_date = current_challenge_end_date + $2::integer,
+		     days_added = COALESCE(days_added, 0) + $2::integer,
+		     updated_at = NOW()
+		 WHERE user_id = $1
+		 RETURNING current_challenge_end_date, days_added`,
+		userID, days,
+	).Scan(&newEndDate, &adjustment.TotalAdded)
+	if err != nil {
+		return Adjustment{}, fmt.Errorf("failed to adjust end date: %w", err)
+	}
+	adjustment.LastDate = newEndDate.AddDate(0, 0, -1)
+
+	id, err := writeAudit(tx, AuditEntry{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  AuditAdjustDays,
+		Details: map[string]interface{}{
+			"days":            days,
+			"reason":          reason,
+			"silent":          silent,
+			"end_date_before": endDate.Format("2006-01-02"),
+			"end_date_after":  newEndDate.Format("2006-01-02"),
+		},
+	})
+	if err != nil {
+		return Adjustment{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Adjustment{}, fmt.Errorf("failed to commit adjustment: %w", err)
+	}
+	s.userService.ForgetUser(userID)
+
+	challengeDay := 0
+	if progress, err := s.userService.GetProgress(userID); err == nil {
+		challengeDay = progress.ChallengeDay
+	}
+	s.events.Publish(events.PenaltyApplied{
+		UserID:       userID,
+		Username:     adjustment.Username,
+		ChallengeDay: challengeDay,
+		DaysAdded:    days,
+		Reason:       reason,
+		LastDate:     adjustment.LastDate,
+		Silent:       silent,
+		AdjustmentID: id,
+	})
+	return adjustment, nil
+}
+
+// StartChange is a start date corrected with /setstart or /setday
+type StartChange struct {
+	Username      string
+	PreviousStart time.Time
+	StartDate     time.Time
+	LastDate      time.Time // The challenge's final day, which moves with the start
+	ChallengeDay  int       // Today's challenge day in the user's zone after the change
+	RowsMoved     int64     // Logged rows re-linked to their new challenge day
+}
+
+// SetStartDate moves the user's challenge to begin on startDate. Both end dates move
+// by the same number of days, so added penalty days are kept, and every logged row is
+// re-linked to the challenge day of the date it was logged for. Rows that would fall
+// before the new start return ErrLogsBeforeStart and nothing changes.
+func (s *CorrectionService) SetStartDate(userID, actorID string, startDate time.Time, reason string) (StartChange, error) {
+	return s.moveStart(userID, actorID, AuditSetStart, startDate, reason)
+}
+
+// SetDay moves the user's start date so today, in their zone, is challenge day day
+func (s *CorrectionService) SetDay(userID, actorID string, day int, reason string) (StartChange, error) {
+	if day < 1 {
+		return StartChange{}, fmt.Errorf("day must be at least 1")
+	}
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return StartChange{}, err
+	}
+	return s.moveStart(userID, actorID, AuditSetDay, progress.Date.AddDate(0, 0, 1-day), reason)
+}
+
+// moveStart changes the start date and re-links logged rows, audited as action
+func (s *CorrectionService) moveStart(userID, actorID, action string, startDate time.Time, reason string) (StartChange, error) {
+	if s.db == nil {
+		return StartChange{}, fmt.Errorf("database not available")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return StartChange{}, fmt.Errorf("a reason is required")
+	}
+	newStart := startDate.Format("2006-01-02")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return StartChange{}, fmt.Errorf("failed to start correction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var change StartChange
+	err = tx.QueryRow(
+		`SELECT username, challenge_start_date FROM users WHERE user_id = $1 FOR UPDATE`,
+		userID,
+	).Scan(&change.Username, &change.PreviousStart)
+	if err == sql.ErrNoRows {
+		return StartChange{}, ErrUserNotFound
+	}
+	if err != nil {
+		return StartChange{}, fmt.Errorf("failed to load user: %w", err)
+	}
+	shift := CalendarDaysBetween(change.PreviousStart, startDate)
+
+	// Rows with a completion date move to that date's day; older rows without one,
+	// and tables that never had one, move by the shift
+	type relink struct {
+		table, day string
+		args       []interface{} // user_id, then the values day refers to
+	}
+	byDate := `COALESCE(completion_date - $3::date + 1, challenge_day - $2::integer)`
+	var relinks []relink
+	for _, table := range completionTables {
+		if table != "accountability_checkins" {
+			relinks = append(relinks, relink{table, byDate, []interface{}{userID, shift, newStart}})
+		}
+	}
+	for _, table := range relinkDayTables {
+		relinks = append(relinks, relink{table, `challenge_day - $2::integer`, []interface{}{userID, shift}})
+	}
+	// Check-ins move last, after the feats they fill in; see removeTriggeredFeats
+	relinks = append(relinks, relink{"accountability_checkins", byDate, []interface{}{userID, shift, newStart}})
+
+	for _, r := range relinks {
+		var before int
+		err := tx.QueryRow(fmt.Sprintf(
+			`SELECT COUNT(*) FROM %s WHERE user_id = $1 AND %s < 1`, r.table, r.day),
+			r.args...,
+		).Scan(&before)
+		if err != nil {
+			return StartChange{}, fmt.Errorf("failed to check %s: %w", r.table, err)
+		}
+		if before > 0 {
+			return StartChange{}, fmt.Errorf("%w: %d in %s", ErrLogsBeforeStart, before, r.table)
+		}
+	}
+
+	logger.DB("Moving challenge start: user_id=%s, %s -> %s, by actor_id=%s", userID, change.PreviousStart.Format("2006-01-02"), newStart, actorID)
+	var endDate time.Time
+	err = tx.QueryRow(
+		`UPDATE users
+		 SET challenge_start_date = $2,
+		     original_challenge_end_date = original_challenge_end_date + $3::integer,
+		     current_challenge_end_date = current_challenge_end_date + $3::integer,
+		     updated_at = NOW()
+		 WHERE user_id = $1
+		 RETURNING challenge_start_date, current_challenge_end_date`,
+		userID, newStart, shift,
+	).Scan(&change.StartDate, &endDate)
+	if err != nil {
+		return StartChange{}, fmt.Errorf("failed to move start date: %w", err)
+	}
+	change.LastDate = endDate.AddDate(0, 0, -1)
+
+	// Unique days are checked row by row, so rows land past relinkOffset first and
+	// come back down once every row has moved
+	var feats map[string]map[int]bool
+	for _, r := range relinks {
+		if r.table == "accountability_checkins" {
+			if feats, err = featDays(tx, userID); err != nil {
+				return StartChange{}, err
+			}
+		}
+		result, err := tx.Exec(fmt.Sprintf(
+			`UPDATE %s SET challenge_day = %s + %d WHERE user_id = $1`, r.table, r.day, relinkOffset),
+			r.args...,
+		)
+		if err != nil {
+			return StartChange{}, fmt.Errorf("failed to re-link %s: %w", r.table, err)
+		}
+		_, err = tx.Exec(fmt.Sprintf(
+			`UPDATE %s SET challenge_day = challenge_day - %d WHERE user_id = $1`, r.table, relinkOffset),
+			userID,
+		)
+		if err != nil {
+			return StartChange{}, fmt.Errorf("failed to re-link %s: %w", r.table, err)
+		}
+		rows, _ := result.RowsAffected()
+		change.RowsMoved += rows
+	}
+	if err := removeTriggeredFeats(tx, userID, feats); err != nil {
+		return StartChange{}, err
+	}
+
+	// Journaled snapshots are keyed by the old days, so they can't be undone anymore
+	if _, err := tx.Exec(`DELETE FROM action_journal WHERE user_id = $1`, userID); err != nil {
+		return StartChange{}, fmt.Errorf("failed to clear action journal: %w", err)
+	}
+
+	_, err = writeAudit(tx, AuditEntry{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  action,
+		Details: map[string]interface{}{
+			"reason":       reason,
+			"start_before": change.PreviousStart.Format("2006-01-02"),
+			"start_after":  newStart,
+			"rows_moved":   change.RowsMoved,
+		},
+	})
+	if err != nil {
+		return StartChange{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return StartChange{}, fmt.Errorf("failed to commit correction: %w", err)
+	}
+	s.userService.ForgetUser(userID)
+	if progress, err := s.userService.GetProgress(userID); err == nil {
+		change.ChallengeDay = ChallengeDayForDate(progress.StartDate, progress.Date)
+	}
+
+	if s.leaderboard != nil {
+		if err := s.leaderboard.Refresh(); err != nil {
+			logger.Error("Failed to refresh progress after moving user_id=%s's start: %v", userID, err)
+		}
+	}
+	return change, nil
+}
+
+// Backfill is a feat an admin recorded for a past day with /backfill
+type Backfill struct {
+	Feat         string // One of CompletionFeats
+	Date         time.Time
+	ChallengeDay int
+}
+
+// Backfill records feat, one of CompletionFeats, as done on date for the user, such
+// as a workout they reported while Discord was down. The date must be a day of their
+// challenge up to today, or a *DayError is returned; a feat that's already logged
+// returns ErrAlreadyLogged. A backfilled check-in fills in the day's other feats, as
+// check-ins do. The row is marked as admin-entered and audited with the admin's
+// reason, and the leaderboard rollup is refreshed.
+func (s *CorrectionService) Backfill(userID, actorID, feat string, date time.Time, reason string) (Backfill, error) {
+	if s.db == nil {
+		return Backfill{}, fmt.Errorf("database not available")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return Backfill{}, fmt.Errorf("a reason is required")
+	}
+	table := ""
+	for i, name := range CompletionFeats {
+		if name == feat {
+			table = featTables[i]
+		}
+	}
+	if table == "" {
+		return Backfill{}, fmt.Errorf("unknown feat %q", feat)
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return Backfill{}, err
+	}
+	backfill := Backfill{Feat: feat, Date: date}
+	backfill.ChallengeDay, err = ValidateChallengeDay(progress, date)
+	if err != nil {
+		return backfill, err
+	}
+	completionDate := date.Format("2006-01-02")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return backfill, fmt.Errorf("failed to start backfill: %w", err)
+	}
+	defer tx.Rollback()
+
+	logger.DB("Backfilling %s: user_id=%s, challenge_day=%d, by actor_id=%s", table, userID, backfill.ChallengeDay, actorID)
+	var result sql.Result
+	if table == "accountability_checkins" {
+		result, err = tx.Exec(
+			`INSERT INTO accountability_checkins (user_id, challenge_day, completion_date, check_in_method, metadata)
+			 VALUES ($1, $2, $3, 'admin', jsonb_build_object('source', $4::text, 'actor_id', $5::text))
+			 ON CONFLICT (user_id, challenge_day) DO NOTHING`,
+			userID, backfill.ChallengeDay, completionDate, SourceAdmin, actorID,
+		)
+	} else {
+		// Columns left at their defaults, which meet each feat's minimums
+		result, err = tx.Exec(fmt.Sprintf(
+			`INSERT INTO %s (user_id, challenge_day, completion_date, metadata, autopopulated)
+			 VALUES ($1, $2, $3, jsonb_build_object('source', $4::text, 'actor_id', $5::text), false)
+			 ON CONFLICT (user_id, challenge_day) DO NOTHING`, table),
+			userID, backfill.ChallengeDay, completionDate, SourceAdmin, actorID,
+		)
+	}
+	if err != nil {
+		return backfill, fmt.Errorf("failed to backfill %s: %w", table, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return backfill, ErrAlreadyLogged
+	}
+
+	_, err = writeAudit(tx, AuditEntry{
+		UserID:       userID,
+		ActorID:      actorID,
+		Action:       AuditBackfill,
+		ChallengeDay: backfill.ChallengeDay,
+		Details: map[string]interface{}{
+			"feat":   feat,
+			"date":   completionDate,
+			"reason": reason,
+		},
+	})
+	if err != nil {
+		return backfill, err
+	}
+	if err := tx.Commit(); err != nil {
+		return backfill, fmt.Errorf("failed to commit backfill: %w", err)
+	}
+
+	if s.leaderboard != nil {
+		if err := s.leaderboard.Refresh(); err != nil {
+			logger.Error("Failed to refresh progress after backfilling day %d for user_id=%s: %v", backfill.ChallengeDay, userID, err)
+		}
+	}
+	return backfill, nil
+}
+
+// featDays returns the days each feat a check-in fills in is logged for
+func featDays(tx *sql.Tx, userID string) (map[string]map[int]bool, error) {
+	days := make(map[string]map[int]bool)
+	for _, table := range featTables[1:] {
+		rows, err := tx.Query(fmt.Sprintf(`SELECT challenge_day FROM %s WHERE user_id = $1`, table), userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		days[table] = make(map[int]bool)
+		for rows.Next() {
+			var day int
+			if err := rows.Scan(&day); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to read %s: %w", table, err)
+			}
+			days[table][day] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+	}
+	return days, nil
+}
+
+// removeTriggeredFeats deletes feats the check-in trigger added while check-ins were
+// re-linked: it fills in every feat for a check-in row that changes, which would undo
+// feats removed on purpose and leave rows at the days check-ins passed through.
+// before is what featDays returned just before check-ins moved.
+func removeTriggeredFeats(tx *sql.Tx, userID string, before map[string]map[int]bool) error {
+	for table, days := range before {
+		kept := make([]string, 0, len(days))
+		for day := range days {
+			kept = append(kept, strconv.Itoa(day))
+		}
+		query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table)
+		if len(kept) > 0 {
+			query += fmt.Sprintf(` AND challenge_day NOT IN (%s)`, strings.Join(kept, ", "))
+		}
+		if _, err := tx.Exec(query, userID); err != nil {
+			return fmt.Errorf("failed to tidy %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/discordoauth"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Paths the HTTP server serves for the web dashboard, under PUBLIC_URL
+const (
+	DashboardPath         = "/dashboard/v1/"
+	DashboardLoginPath    = DashboardPath + "login"
+	DashboardCallbackPath = DashboardPath + "callback"
+)
+
+// DashboardSessionTTL is how long a dashboard sign-in lasts
+const DashboardSessionTTL = 30 * 24 * time.Hour
+
+// ErrDashboardSessionNotFound is returned for a session token that doesn't exist,
+// expired, or was signed out
+var ErrDashboardSessionNotFound = errors.New("dashboard session not found")
+
+// DashboardService signs members in to the web dashboard with their Discord account.
+// Sessions are random tokens kept in a cookie; only their hashes are stored.
+type DashboardService struct {
+	db          *sql.DB
+	client      *discordoauth.Client
+	publicURL   string
+	userService *UserService
+}
+
+// NewDashboardService creates a new dashboard service. publicURL is where the HTTP
+// server is reachable by browsers, and must match a redirect registered with the
+// Discord application.
+func NewDashboardService(clientID, clientSecret, publicURL string, userService *UserService) *DashboardService {
+	return &DashboardService{
+		client:      discordoauth.NewClient(clientID, clientSecret),
+		publicURL:   publicURL,
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *DashboardService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *DashboardService) Name() string {
+	return "DashboardService"
+}
+
+// Health checks the service health
+func (s *DashboardService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// AuthorizeURL starts signing in. The caller keeps state (e.g. in a cookie) to check
+// against the one Discord sends back.
+func (s *DashboardService) AuthorizeURL(state string) string {
+	return s.client.AuthorizeURL(s.publicURL+DashboardCallbackPath, state)
+}
+
+// SignIn finishes signing in with the code Discord sent back and returns a new session
+// token and whose it is. Only members who have used the bot can sign in.
+func (s *DashboardService) SignIn(code string) (string, string, error) {
+	if s.db == nil {
+		return "", "", fmt.Errorf("database not available")
+	}
+
+	user, err := s.client.Identify(code, s.publicURL+DashboardCallbackPath)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := s.userService.GetProgress(user.ID); err != nil {
+		return "", "", err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	// Expired sessions are swept on each sign-in rather than on a schedule
+	if _, err := s.db.Exec(`DELETE FROM dashboard_sessions WHERE expires_at < NOW()`); err != nil {
+		logger.Warn("Failed to delete expired dashboard sessions: %v", err)
+	}
+
+	logger.DB("Creating dashboard session for user_id=%s", user.ID)
+	_, err = s.db.Exec(
+		`INSERT INTO dashboard_sessions (token_hash, user_id, expires_at) VALUES ($1, $2, $3)`,
+		hashSessionToken(token), user.ID, time.Now().Add(DashboardSessionTTL),
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to save dashboard session: %w", err)
+	}
+	return token, user.ID, nil
+}
+
+// Session returns the user signed in with token
+func (s *DashboardService) Session(token string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	var userID string
+	err := s.db.QueryRow(
+		`SELECT user_id FROM dashboard_sessions WHERE token_hash = $1 AND expires_at > NOW()`,
+		hashSessionToken(token),
+	).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", ErrDashboardSessionNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query dashboard session: %w", err)
+	}
+	return userID, nil
+}
+
+// SignOut ends the session with token; signing out twice is not an error
+func (s *DashboardService) SignOut(token string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	if _, err := s.db.Exec(`DELETE FROM dashboard_sessions WHERE token_hash = $1`, hashSessionToken(token)); err != nil {
+		return fmt.Errorf("failed to delete dashboard session: %w", err)
+	}
+	return nil
+}
+
+// hashSessionToken hashes a session token so a leaked table can't be used to sign in
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// SourceEdit marks feat rows added with /edit in their metadata
+const SourceEdit = "edit"
+
+// ErrEditWindowClosed is returned for edits to a day that ended longer ago than the
+// edit window
+var ErrEditWindowClosed = errors.New("edit window has closed")
+
+// DayValues are the parts of a day /edit can correct
+type DayValues struct {
+	WorkoutMinutes int     // 0 when no workout is logged
+	WaterOunces    float64 // 0 when no water is logged
+	DietFollowed   bool
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// EditService corrects members' recent days after the fact
+type EditService struct {
+	db          *sql.DB
+	userService *UserService
+	leaderboard *LeaderboardService
+	window      time.Duration
+}
+
+// NewEditService creates a new edit service; days can be edited until window after
+// they end, and leaderboard is refreshed after each edit
+func NewEditService(userService *UserService, leaderboard *LeaderboardService, window time.Duration) *EditService {
+	return &EditService{
+		userService: userService,
+		leaderboard: leaderboard,
+		window:      window,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *EditService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *EditService) Name() string {
+	return "EditService"
+}
+
+// Health checks the service health
+func (s *EditService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Window returns how long after a day ends it can still be edited
+func (s *EditService) Window() time.Duration {
+	return s.window
+}
+
+// editableDate returns the calendar date of challengeDay in the user's zone. Days
+// outside the user's challenge or after today return a *DayError, and days that
+// ended longer ago than the edit window return ErrEditWindowClosed.
+func (s *EditService) editableDate(userID string, challengeDay int) (time.Time, error) {
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start := progress.StartDate
+	date := time.Date(start.Year(), start.Month(), start.Day()+challengeDay-1, 0, 0, 0, 0, progress.Date.Location())
+	if _, err := ValidateChallengeDay(progress, date); err != nil {
+		return date, err
+	}
+	if time.Now().After(date.AddDate(0, 0, 1).Add(s.window)) {
+		return date, ErrEditWindowClosed
+	}
+	return date, nil
+}
+
+// GetDay returns what's logged for a day the user can still edit
+func (s *EditService) GetDay(userID string, challengeDay int) (DayValues, error) {
+	if s.db == nil {
+		return DayValues{}, fmt.Errorf("database not available")
+	}
+	if _, err := s.editableDate(userID, challengeDay); err != nil {
+		return DayValues{}, err
+	}
+	return loadDayValues(s.db, userID, challengeDay)
+}
+
+// loadDayValues reads the feats /edit can correct for a day
+func loadDayValues(q rowQuerier, userID string, challengeDay int) (DayValues, error) {
+	var values DayValues
+	var minutes sql.NullInt64
+	err := q.QueryRow(
+		`SELECT workout_duration_minutes FROM exercise_completions WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay,
+	).Scan(&minutes)
+	if err != nil && err != sql.ErrNoRows {
+		return DayValues{}, fmt.Errorf("failed to load exercise: %w", err)
+	}
+	values.WorkoutMinutes = int(minutes.Int64)
+
+	var ounces sql.NullFloat64
+	err = q.QueryRow(
+		`SELECT amount_ounces FROM water_completions WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay,
+	).Scan(&ounces)
+	if err != nil && err != sql.ErrNoRows {
+		return DayValues{}, fmt.Errorf("failed to load water: %w", err)
+	}
+	values.WaterOunces = ounces.Float64
+
+	// The table only accepts compliant days, so a row means the diet was followed
+	err = q.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM diet_completions WHERE user_id = $1 AND challenge_day = $2)`,
+		userID, challengeDay,
+	).Scan(&values.DietFollowed)
+	if err != nil {
+		return DayValues{}, fmt.Errorf("failed to load diet: %w", err)
+	}
+	return values, nil
+}
+
+// EditDay replaces what's logged for a day the user can still edit and returns what
+// it was before and after. A workout of 0 minutes or 0 oz of water removes that log;
+// water is rounded to the hundredth of an ounce stored and capped at the daily goal.
+// Each edit is written to the audit log, and the progress rollup is refreshed so the
+// leaderboard reflects it.
+func (s *EditService) EditDay(userID string, challengeDay int, values DayValues) (DayValues, DayValues, error) {
+	if s.db == nil {
+		return DayValues{}, DayValues{}, fmt.Errorf("database not available")
+	}
+	if values.WorkoutMinutes != 0 && values.WorkoutMinutes < MinWorkoutMinutes {
+		return DayValues{}, DayValues{}, fmt.Errorf("workout must be 0 or at least %d minutes", MinWorkoutMinutes)
+	}
+	if values.WaterOunces < 0 {
+		return DayValues{}, DayValues{}, fmt.Errorf("water can't be negative")
+	}
+	values.WaterOunces = math.Min(math.Round(values.WaterOunces*100)/100, WaterGoalOunces)
+
+	date, err := s.editableDate(userID, challengeDay)
+	if err != nil {
+		return DayValues{}, DayValues{}, err
+	}
+	completionDate := date.Format("2006-01-02")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return DayValues{}, DayValues{}, fmt.Errorf("failed to start edit: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := loadDayValues(tx, userID, challengeDay)
+	if err != nil {
+		return DayValues{}, DayValues{}, err
+	}
+	if before == values {
+		return before, values, nil
+	}
+
+	logger.DB("Editing day: user_id=%s, challenge_day=%d, before=%+v, after=%+v", userID, challengeDay, before, values)
+	if values.WorkoutMinutes != before.WorkoutMinutes {
+		if values.WorkoutMinutes == 0 {
+			_, err = tx.Exec(`DELETE FROM exercise_completions WHERE user_id = $1 AND challenge_day = $2`, userID, challengeDay)
+		} else {
+			_, err = tx.Exec(
+				`INSERT INTO exercise_completions (user_id, challenge_day, completion_date, workout_duration_minutes, metadata, autopopulated)
+				 VALUES ($1, $2, $3, $4, jsonb_build_object('source', $5::text), false)
+				 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+					workout_duration_minutes = EXCLUDED.workout_duration_minutes,
+					autopopulated = false,
+					completed_at = NOW()`,
+				userID, challengeDay, completionDate, values.WorkoutMinutes, SourceEdit,
+			)
+		}
+		if err != nil {
+			return DayValues{}, DayValues{}, fmt.Errorf("failed to edit exercise: %w", err)
+		}
+	}
+
+	if values.WaterOunces != before.WaterOunces {
+		// The change is logged as an entry, so the day's entries still add up to the total
+		if _, err := reconcileWater(tx, userID, challengeDay, SourceRollup); err != nil {
+			return DayValues{}, DayValues{}, err
+		}
+		if err := insertWaterEntry(tx, userID, challengeDay, values.WaterOunces-before.WaterOunces, SourceEdit); err != nil {
+			return DayValues{}, DayValues{}, err
+		}
+		if values.WaterOunces == 0 {
+			_, err = tx.Exec(`DELETE FROM water_completions WHERE user_id = $1 AND challenge_day = $2`, userID, challengeDay)
+		} else {
+			_, err = tx.Exec(
+				`INSERT INTO water_completions (user_id, challenge_day, completion_date, amount_ounces, is_plain_water, metadata, autopopulated)
+				 VALUES ($1, $2, $3, $4, true, jsonb_build_object('source', $5::text), false)
+				 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+					amount_ounces = EXCLUDED.amount_ounces,
+					autopopulated = false,
+					completed_at = NOW()`,
+				userID, challengeDay, completionDate, values.WaterOunces, SourceEdit,
+			)
+		}
+		if err != nil {
+			return DayValues{}, DayValues{}, fmt.Errorf("failed to edit water: %w", err)
+		}
+	}
+
+	if values.DietFollowed != before.DietFollowed {
+		if values.DietFollowed {
+			_, err = tx.Exec(
+				`INSERT INTO diet_completions (user_id, challenge_day, completion_date, metadata, autopopulated)
+				 VALUES ($1, $2, $3, jsonb_build_object('source', $4::text), false)
+				 ON CONFLICT (user_id, challenge_day) DO NOTHING`,
+				userID, challengeDay, completionDate, SourceEdit,
+			)
+		} else {
+			_, err = tx.Exec(`DELETE FROM diet_completions WHERE user_id = $1 AND challenge_day = $2`, userID, challengeDay)
+		}
+		if err != nil {
+			return DayValues{}, DayValues{}, fmt.Errorf("failed to edit diet: %w", err)
+		}
+	}
+
+	_, err = writeAudit(tx, AuditEntry{
+		UserID:       userID,
+		ActorID:      userID,
+		Action:       AuditEditDay,
+		ChallengeDay: challengeDay,
+		Details: map[string]interface{}{
+			"date":   completionDate,
+			"before": dayValuesDetails(before),
+			"after":  dayValuesDetails(values),
+		},
+	})
+	if err != nil {
+		return DayValues{}, DayValues{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return DayValues{}, DayValues{}, fmt.Errorf("failed to commit edit: %w", err)
+	}
+
+	// The edit may have changed whether the day counts, so recompute the rollup now
+	// rather than waiting for the next scheduled refresh
+	if s.leaderboard != nil {
+		if err := s.leaderboard.Refresh(); err != nil {
+			logger.Error("Failed to refresh progress after editing day %d for user_id=%s: %v", challengeDay, userID, err)
+		}
+	}
+	return before, values, nil
+}
+
+// dayValuesDetails describes day values for the audit log
+func dayValuesDetails(values DayValues) map[string]interface{} {
+	return map[string]interface{}{
+		"workout_minutes": values.WorkoutMinutes,
+		"water_ounces":    values.WaterOunces,
+		"diet_followed":   values.DietFollowed,
+	}
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/email"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+var (
+	// ErrEmailNotFound is returned when the user hasn't added an email address
+	ErrEmailNotFound = errors.New("no email address added")
+	// ErrEmailNotVerified is returned when reporting to an address that hasn't been verified
+	ErrEmailNotVerified = errors.New("email address not verified")
+)
+
+const (
+	// emailReportDays is how many days the completion table covers
+	emailReportDays = 7
+	// emailReportWeighIns is how many of the latest weigh-ins the weight chart shows
+	emailReportWeighIns = 30
+	// emailReportInterval keeps a restarted bot from sending the same week's report twice
+	emailReportInterval = 6 * 24 * time.Hour
+)
+
+// EmailReportWeighIn is one bar of a report's weight chart
+type EmailReportWeighIn struct {
+	WeighIn
+	Width int // Bar width in percent of the chart
+}
+
+// EmailReport is the data behind one member's weekly email
+type EmailReport struct {
+	Progress      Progress
+	Days          []DayCompletion // Newest first
+	CurrentStreak int             // Consecutive check-ins up to today, or yesterday when today isn't in yet
+	LongestStreak int
+	WeighIns      []EmailReportWeighIn // Oldest first
+	WeightUnit    string
+}
+
+// EmailReportService stores members' email addresses and sends them a weekly report
+// alongside the forum recaps. Like SMS numbers, an address only gets reports once
+// its owner enters the code emailed to it.
+type EmailReportService struct {
+	db          *sql.DB
+	sender      email.Sender
+	userService *UserService
+	preferences *PreferencesService
+	summaries   *SummaryService
+}
+
+// NewEmailReportService creates a new email report service. Reports are built from
+// the same summary queries as the web dashboard.
+func NewEmailReportService(sender email.Sender, userService *UserService, preferences *PreferencesService, summaries *SummaryService) *EmailReportService {
+	return &EmailReportService{
+		sender:      sender,
+		userService: userService,
+		preferences: preferences,
+		summaries:   summaries,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *EmailReportService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *EmailReportService) Name() string {
+	return "EmailReportService"
+}
+
+// Health checks the service health
+func (s *EmailReportService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Add stores the user's email address, replacing any earlier one, and emails it a
+// verification code. Reports are written in locale. Returns the bare address. The
+// user must already exist.
+func (s *EmailReportService) Add(userID, address, locale string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	address, err := email.NormalizeAddress(address)
+	if err != nil {
+		return "", err
+	}
+
+	var lastSent sql.NullTime
+	err = s.db.QueryRow(`SELECT code_sent_at FROM email_reports WHERE user_id = $1`, userID).Scan(&lastSent)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to load email address: %w", err)
+	}
+	if lastSent.Valid && time.Since(lastSent.Time) < verificationResendInterval {
+		return "", ErrVerificationTooSoon
+	}
+
+	code, err := newVerificationCode()
+	if err != nil {
+		return "", err
+	}
+
+	logger.DB("Adding email address for user_id=%s", userID)
+	_, err = s.db.Exec(`
+		INSERT INTO email_reports (user_id, address, locale, code_hash, code_sent_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			address = EXCLUDED.address,
+			locale = EXCLUDED.locale,
+			code_hash = EXCLUDED.code_hash,
+			code_sent_at = EXCLUDED.code_sent_at,
+			attempts = 0,
+			verified_at = NULL,
+			last_sent_at = NULL,
+			created_at = NOW()
+	`, userID, address, locale, hashVerificationCode(code))
+	if err != nil {
+		return "", fmt.Errorf("failed to save email address: %w", err)
+	}
+
+	body := i18n.T(locale, "email.code_body", code, int(VerificationCodeExpiry.Minutes()))
+	err = s.sender.Send(email.Message{
+		To:      address,
+		Subject: i18n.T(locale, "email.code_subject"),
+		HTML:    "<p>" + template.HTMLEscapeString(body) + "</p>",
+		Text:    body,
+	})
+	if err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// Verify checks the code emailed by Add. A correct code starts the weekly reports.
+func (s *EmailReportService) Verify(userID, code string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var codeHash sql.NullString
+	var sentAt sql.NullTime
+	var attempts int
+	err := s.db.QueryRow(
+		`SELECT code_hash, code_sent_at, attempts FROM email_reports WHERE user_id = $1`,
+		userID,
+	).Scan(&codeHash, &sentAt, &attempts)
+	if err == sql.ErrNoRows {
+		return ErrEmailNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load email address: %w", err)
+	}
+	err = checkVerificationCode(code, codeHash.String, sentAt.Time, attempts)
+	if errors.Is(err, ErrVerificationCodeInvalid) {
+		if _, err := s.db.Exec(`UPDATE email_reports SET attempts = attempts + 1 WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to record verification attempt: %w", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.DB("Verifying email address for user_id=%s", userID)
+	if _, err := s.db.Exec(
+		`UPDATE email_reports SET verified_at = NOW(), code_hash = NULL, attempts = 0 WHERE user_id = $1`,
+		userID,
+	); err != nil {
+		return fmt.Errorf("failed to verify email address: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the user's email address and reports whether they had one
+func (s *EmailReportService) Remove(userID string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Removing email address for user_id=%s", userID)
+	result, err := s.db.Exec(`DELETE FROM email_reports WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove email address: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Send emails the user's report to their verified address now, or returns
+// ErrEmailNotVerified. It doesn't count as the week's report.
+func (s *EmailReportService) Send(userID string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var address, locale string
+	err := s.db.QueryRow(
+		`SELECT address, locale FROM email_reports WHERE user_id = $1 AND verified_at IS NOT NULL`,
+		userID,
+	).Scan(&address, &locale)
+	if err == sql.ErrNoRows {
+		return ErrEmailNotVerified
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load email address: %w", err)
+	}
+	return s.send(userID, address, locale)
+}
+
+// SendWeekly emails every verified address its report, skipping any sent one in the
+// last few days so a restart or retry doesn't repeat the week. Returns how many
+// were sent; one member's failure doesn't stop the rest.
+func (s *EmailReportService) SendWeekly() (int, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT user_id, address, locale
+		FROM email_reports
+		WHERE verified_at IS NOT NULL AND (last_sent_at IS NULL OR last_sent_at < $1)
+	`, time.Now().Add(-emailReportInterval))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query email addresses: %w", err)
+	}
+	type recipient struct{ userID, address, locale string }
+	var recipients []recipient
+	for rows.Next() {
+		var r recipient
+		if err := rows.Scan(&r.userID, &r.address, &r.locale); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan email address: %w", err)
+		}
+		recipients = append(recipients, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read email addresses: %w", err)
+	}
+
+	sent := 0
+	for _, r := range recipients {
+		if err := s.send(r.userID, r.address, r.locale); err != nil {
+			logger.Error("Failed to email weekly report to user_id=%s: %v", r.userID, err)
+			continue
+		}
+		if _, err := s.db.Exec(`UPDATE email_reports SET last_sent_at = NOW() WHERE user_id = $1`, r.userID); err != nil {
+			return sent, fmt.Errorf("failed to record email report: %w", err)
+		}
+		sent++
+	}
+	logger.Info("✅ Emailed %d/%d weekly reports", sent, len(recipients))
+	return sent, nil
+}
+
+// send builds, renders, and emails one user's report
+func (s *EmailReportService) send(userID, address, locale string) error {
+	report, err := s.Report(userID)
+	if err != nil {
+		return err
+	}
+	message, err := RenderEmailReport(locale, report)
+	if err != nil {
+		return err
+	}
+	message.To = address
+	return s.sender.Send(message)
+}
+
+// Report gathers the user's last week of completions, their check-in streaks, and
+// their latest weigh-ins
+func (s *EmailReportService) Report(userID string) (EmailReport, error) {
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return EmailReport{}, err
+	}
+	prefs, err := s.preferences.Get(userID)
+	if err != nil {
+		return EmailReport{}, err
+	}
+	report := EmailReport{Progress: progress, WeightUnit: prefs.Units.Weight}
+
+	// The table covers the last week of the challenge, ending today or on its last day
+	lastDay := progress.ChallengeDay
+	if lastDay > progress.TotalDays {
+		lastDay = progress.TotalDays
+	}
+	firstDay := lastDay - emailReportDays + 1
+	if firstDay < 1 {
+		firstDay = 1
+	}
+	days, err := s.summaries.GetCompletions(userID, progress.StartDate, firstDay, lastDay)
+	if err != nil {
+		return report, err
+	}
+	for i := len(days) - 1; i >= 0; i-- {
+		report.Days = append(report.Days, days[i])
+	}
+
+	report.CurrentStreak, report.LongestStreak, err = s.summaries.GetStreaks(userID, progress.ChallengeDay)
+	if err != nil {
+		return report, err
+	}
+
+	weighIns, err := s.summaries.GetWeighIns(userID, emailReportWeighIns, prefs)
+	if err != nil {
+		return report, err
+	}
+	report.WeighIns = weighInBars(weighIns)
+	return report, nil
+}
+
+// weighInBars scales weigh-ins into chart bars
+func weighInBars(weighIns []WeighIn) []EmailReportWeighIn {
+	if len(weighIns) == 0 {
+		return nil
+	}
+	// Bars start a little below the lightest weigh-in so small changes stay visible
+	min, max := weighIns[0].Weight, weighIns[0].Weight
+	for _, w := range weighIns {
+		if w.Weight < min {
+			min = w.Weight
+		}
+		if w.Weight > max {
+			max = w.Weight
+		}
+	}
+	floor := min - (max-min)/4 - 1
+	bars := make([]EmailReportWeighIn, len(weighIns))
+	for i, w := range weighIns {
+		bars[i] = EmailReportWeighIn{WeighIn: w, Width: int(100 * (w.Weight - floor) / (max - floor))}
+	}
+	return bars
+}
+
+// emailReportHTML is the HTML body of a weekly report. Styles are inline because
+// most email clients drop style blocks.
+var emailReportHTML = template.Must(template.New("report").Funcs(template.FuncMap{
+	"T":        func(string, ...interface{}) string { return "" },
+	"monthDay": func(time.Time) string { return "" },
+}).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #222; max-width: 600px; margin: 0 auto;">
+<h1 style="font-size: 22px;">{{T "email.heading" .Progress.Username}}</h1>
+<p>{{T "email.progress" .Progress.ChallengeDay .Progress.TotalDays .Progress.DaysCompleted}}</p>
+
+<h2 style="font-size: 18px;">{{T "email.week_title"}}</h2>
+<table style="border-collapse: collapse; width: 100%;">
+<tr>
+<th style="text-align: left; padding: 4px; border-bottom: 1px solid #ccc;">{{T "email.column_day"}}</th>
+{{- range .Columns}}
+<th style="padding: 4px; border-bottom: 1px solid #ccc;">{{.}}</th>
+{{- end}}
+</tr>
+{{- range .Report.Days}}
+<tr>
+<td style="padding: 4px; border-bottom: 1px solid #eee;">{{T "email.day" .Day (monthDay .Date)}}</td>
+{{- range .Done}}
+<td style="text-align: center; padding: 4px; border-bottom: 1px solid #eee;">{{if .}}✅{{else}}—{{end}}</td>
+{{- end}}
+</tr>
+{{- end}}
+</table>
+
+<h2 style="font-size: 18px;">{{T "email.streaks_title"}}</h2>
+<p>{{T "email.streaks" .Report.CurrentStreak .Report.LongestStreak}}</p>
+
+<h2 style="font-size: 18px;">{{T "email.weight_title" .Report.WeightUnit}}</h2>
+{{- if .Report.WeighIns}}
+<table style="border-collapse: collapse; width: 100%;">
+{{- range .Report.WeighIns}}
+<tr>
+<td style="padding: 2px 8px 2px 0; white-space: nowrap; font-size: 12px;">{{monthDay .Date}}</td>
+<td style="width: 100%; padding: 2px 0;"><div style="background: #4a90d9; height: 12px; width: {{.Width}}%;"></div></td>
+<td style="padding: 2px 0 2px 8px; white-space: nowrap; font-size: 12px;">{{printf "%.1f" .Weight}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>{{T "email.no_weigh_ins"}}</p>
+{{- end}}
+
+<p style="font-size: 12px; color: #888; margin-top: 24px;">{{T "email.footer"}}</p>
+</body>
+</html>
+`))
+
+// RenderEmailReport renders report as an email in locale, without a recipient
+func RenderEmailReport(locale string, report EmailReport) (email.Message, error) {
+	columns := make([]string, len(CompletionFeats))
+	for i, feat := range CompletionFeats {
+		columns[i] = i18n.T(locale, "email.column_"+feat)
+	}
+
+	tmpl, err := emailReportHTML.Clone()
+	if err != nil {
+		return email.Message{}, fmt.Errorf("failed to prepare report template: %w", err)
+	}
+	tmpl.Funcs(template.FuncMap{
+		"T":        func(key string, args ...interface{}) string { return i18n.T(locale, key, args...) },
+		"monthDay": func(t time.Time) string { return i18n.FormatMonthDay(locale, t) },
+	})
+	var html bytes.Buffer
+	err = tmpl.Execute(&html, struct {
+		Progress Progress
+		Columns  []string
+		Report   EmailReport
+	}{report.Progress, columns, report})
+	if err != nil {
+		return email.Message{}, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	// The plain-text alternative carries the same numbers without the chart
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "email.heading", report.Progress.Username) + "\n\n")
+	text.WriteString(i18n.T(locale, "email.progress", report.Progress.ChallengeDay, report.Progress.TotalDays, report.Progress.DaysCompleted) + "\n\n")
+	text.WriteString(i18n.T(locale, "email.week_title") + "\n")
+	for _, day := range report.Days {
+		var done []string
+		for i, ok := range day.Done {
+			if ok {
+				done = append(done, columns[i])
+			}
+		}
+		if len(done) == 0 {
+			done = append(done, "—")
+		}
+		text.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(locale, "email.day", day.Day, i18n.FormatMonthDay(locale, day.Date)), strings.Join(done, ", ")))
+	}
+	text.WriteString("\n" + i18n.T(locale, "email.streaks", report.CurrentStreak, report.LongestStreak) + "\n")
+	if n := len(report.WeighIns); n > 0 {
+		latest := report.WeighIns[n-1]
+		text.WriteString(i18n.T(locale, "email.latest_weight", latest.Weight, report.WeightUnit, i18n.FormatMonthDay(locale, latest.Date)) + "\n")
+	}
+	text.WriteString("\n" + i18n.T(locale, "email.footer") + "\n")
+
+	return email.Message{
+		Subject: i18n.T(locale, "email.subject", report.Progress.ChallengeDay, report.Progress.TotalDays),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/75-hard-discord-bot/internal/logger"
 )
@@ -56,20 +57,25 @@ func (s *ExerciseService) LogExerciseDetailed(userID, username string, workoutDu
 		return fmt.Errorf("failed to ensure user exists: %w", err)
 	}
 
-	// Get current challenge day
-	challengeDay, err := s.userService.GetCurrentChallengeDay(userID)
+	// Get current challenge date and day
+	completionDate, challengeDay, err := s.userService.GetWritableDay(userID)
 	if err != nil {
 		return fmt.Errorf("failed to get challenge day: %w", err)
 	}
+	journal, err := snapshotDay(s.db, userID, ActionExercise, challengeDay)
+	if err != nil {
+		return err
+	}
 
 	// Insert or update exercise completion (mark as manual entry)
 	logger.DB("Logging exercise: user_id=%s, challenge_day=%d, workout=%dmin, core=%dmin", userID, challengeDay, workoutDuration, coreDuration)
 	_, err = s.db.Exec(
 		`INSERT INTO exercise_completions 
-		 (user_id, challenge_day, workout_duration_minutes, workout_type, workout_location, core_mobility_duration_minutes, core_mobility_type, autopopulated)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, false)
+		 (user_id, challenge_day, completion_date, workout_duration_minutes, workout_type, workout_location, core_mobility_duration_minutes, core_mobility_type, autopopulated)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, false)
 		 ON CONFLICT (user_id, challenge_day) 
 		 DO UPDATE SET 
+			completion_date = EXCLUDED.completion_date,
 			workout_duration_minutes = EXCLUDED.workout_duration_minutes,
 			workout_type = EXCLUDED.workout_type,
 			workout_location = EXCLUDED.workout_location,
@@ -77,12 +83,58 @@ func (s *ExerciseService) LogExerciseDetailed(userID, username string, workoutDu
 			core_mobility_type = EXCLUDED.core_mobility_type,
 			autopopulated = false,
 			completed_at = NOW()`,
-		userID, challengeDay, workoutDuration, workoutType, workoutLocation, coreDuration, coreType,
+		userID, challengeDay, completionDate.Format("2006-01-02"), workoutDuration, workoutType, workoutLocation, coreDuration, coreType,
 	)
 	if err != nil {
 		logger.Error("Failed to log exercise: %v", err)
 	} else {
 		logger.DB("Successfully logged exercise for user_id=%s, challenge_day=%d", userID, challengeDay)
+		recordAction(s.db, journal)
 	}
 	return err
 }
+
+// ImportWorkout records a workout synced from a linked account (source, e.g. "strava")
+// on the challenge day of date, the day it happened in the user's zone. It never
+// replaces a manual entry, and replaces an earlier import only with a longer workout.
+// Returns the challenge day and whether the workout was recorded; workouts outside
+// the user's challenge or dated after today are skipped.
+func (s *ExerciseService) ImportWorkout(userID string, date time.Time, minutes int, workoutType, workoutLocation, source, externalID string) (int, bool, error) {
+	if s.db == nil {
+		return 0, false, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return 0, false, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, date)
+	if err != nil {
+		return challengeDay, false, nil
+	}
+
+	logger.DB("Importing exercise: user_id=%s, challenge_day=%d, workout=%dmin, source=%s", userID, challengeDay, minutes, source)
+	result, err := s.db.Exec(
+		`INSERT INTO exercise_completions
+		 (user_id, challenge_day, completion_date, workout_duration_minutes, workout_type, workout_location, metadata, autopopulated)
+		 VALUES ($1, $2, $3, $4, $5, $6, jsonb_build_object('source', $7::text, 'external_id', $8::text), false)
+		 ON CONFLICT (user_id, challenge_day)
+		 DO UPDATE SET
+			completion_date = EXCLUDED.completion_date,
+			workout_duration_minutes = EXCLUDED.workout_duration_minutes,
+			workout_type = EXCLUDED.workout_type,
+			workout_location = EXCLUDED.workout_location,
+			metadata = EXCLUDED.metadata,
+			autopopulated = false,
+			completed_at = NOW()
+		 WHERE exercise_completions.autopopulated
+			OR (exercise_completions.metadata->>'source' = $7
+				AND exercise_completions.workout_duration_minutes < EXCLUDED.workout_duration_minutes)`,
+		userID, challengeDay, date.Format("2006-01-02"), minutes, workoutType, workoutLocation, source, externalID,
+	)
+	if err != nil {
+		return challengeDay, false, fmt.Errorf("failed to import exercise: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return challengeDay, rows > 0, nil
+}
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/storage"
+)
+
+// exportTables lists every table holding per-user data, in export order
+var exportTables = []string{
+	"users",
+	"accountability_checkins",
+	"exercise_completions",
+	"diet_completions",
+	"meals",
+	"water_completions",
+	"water_entries",
+	"self_improvement_completions",
+	"self_improvement_sessions",
+	"book_pages",
+	"finances_completions",
+	"purchases",
+	"books",
+	"workout_timers",
+	"weigh_ins",
+	"progress_photos",
+	"challenge_failures",
+	"council_exceptions",
+	"user_forum_threads",
+	"user_preferences",
+	"daily_steps",
+	"daily_nutrition",
+	"sms_numbers",
+	"email_reports",
+	"coach_usage",
+	"action_journal",
+	"audit_log",
+}
+
+// completionTables lists the per-day tables an admin completion export covers, in
+// export order. Each has challenge_day and completion_date columns.
+var completionTables = []string{
+	"accountability_checkins",
+	"exercise_completions",
+	"diet_completions",
+	"meals",
+	"water_completions",
+	"water_entries",
+	"self_improvement_completions",
+	"self_improvement_sessions",
+	"book_pages",
+	"finances_completions",
+	"purchases",
+	"weigh_ins",
+	"daily_steps",
+	"daily_nutrition",
+}
+
+// exportLinkExpiry is how long a download link for an uploaded export works
+const exportLinkExpiry = 24 * time.Hour
+
+// UserDataExport holds everything the bot stores about a single user
+type UserDataExport struct {
+	UserID      string                              `json:"user_id"`
+	GeneratedAt time.Time                           `json:"generated_at"`
+	Tables      map[string][]map[string]interface{} `json:"tables"`
+}
+
+// CompletionTable is one table of a completion export, with columns in table order
+type CompletionTable struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// CompletionExport holds every completion in a date range, for one user or everyone
+type CompletionExport struct {
+	From        time.Time
+	To          time.Time
+	UserID      string // Empty for everyone
+	GeneratedAt time.Time
+	Tables      []CompletionTable
+}
+
+// ExportService assembles personal data exports and admin completion exports
+type ExportService struct {
+	db            *sql.DB
+	storage       *storage.S3Client
+	storagePrefix string
+}
+
+// NewExportService creates a new export service
+func NewExportService() *ExportService {
+	return &ExportService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *ExportService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *ExportService) Name() string {
+	return "ExportService"
+}
+
+// Health checks the service health
+func (s *ExportService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// SetStorage lets exports too large to attach in Discord be uploaded under prefix
+// and shared as a temporary link
+func (s *ExportService) SetStorage(client *storage.S3Client, prefix string) {
+	s.storage = client
+	s.storagePrefix = prefix
+}
+
+// CanUpload reports whether exports can be uploaded to object storage
+func (s *ExportService) CanUpload() bool {
+	return s.storage != nil
+}
+
+// Upload stores an export in object storage and returns a link that works for a day
+func (s *ExportService) Upload(filename string, data []byte, contentType string) (string, error) {
+	if s.storage == nil {
+		return "", fmt.Errorf("object storage not configured")
+	}
+
+	key := s.storagePrefix + "/exports/" + filename
+	if err := s.storage.PutObject(key, data, contentType); err != nil {
+		return "", err
+	}
+	logger.Info("📦 Export uploaded: %s (%d bytes)", key, len(data))
+	return s.storage.PresignGetObject(key, exportLinkExpiry)
+}
+
+// ExportCompletions collects every completion dated from through to (inclusive), for
+// userID or, when it's empty, for everyone. Hidden members are included, since this
+// is for admins, who can already read the database through backups.
+func (s *ExportService) ExportCompletions(from, to time.Time, userID string) (*CompletionExport, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	export := &CompletionExport{
+		From:        from,
+		To:          to,
+		UserID:      userID,
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, table := range completionTables {
+		// Rows from before completion dates were recorded fall back to the day's date
+		query := fmt.Sprintf(`
+			SELECT u.username, t.*
+			FROM %s t
+			JOIN users u ON u.user_id = t.user_id
+			WHERE COALESCE(t.completion_date, u.challenge_start_date + (t.challenge_day - 1)) BETWEEN $1 AND $2
+			  AND ($3 = '' OR t.user_id = $3)
+			ORDER BY u.username, t.challenge_day
+		`, table)
+
+		logger.DB("Exporting completions: table=%s, from=%s, to=%s, user_id=%s", table, from.Format("2006-01-02"), to.Format("2006-01-02"), userID)
+		columns, rows, err := s.queryTable(query, from.Format("2006-01-02"), to.Format("2006-01-02"), userID)
+		if err != nil {
+			logger.Error("Failed to export %s: %v", table, err)
+			return nil, fmt.Errorf("failed to export %s: %w", table, err)
+		}
+		export.Tables = append(export.Tables, CompletionTable{Name: table, Columns: columns, Rows: rows})
+	}
+
+	return export, nil
+}
+
+// ExportUserData collects all rows stored for a user across every table
+func (s *ExportService) ExportUserData(userID string) (*UserDataExport, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	export := &UserDataExport{
+		UserID:      userID,
+		GeneratedAt: time.Now().UTC(),
+		Tables:      make(map[string][]map[string]interface{}),
+	}
+
+	for _, table := range exportTables {
+		logger.DB("Exporting user data: table=%s, user_id=%s", table, userID)
+		rows, err := s.queryRows(fmt.Sprintf(`SELECT * FROM %s WHERE user_id = $1`, table), userID)
+		if err != nil {
+			logger.Error("Failed to export %s: %v", table, err)
+			return nil, fmt.Errorf("failed to export %s: %w", table, err)
+		}
+		export.Tables[table] = rows
+	}
+
+	return export, nil
+}
+
+// queryRows runs a query and returns each row as a column name to value map
+func (s *ExportService) queryRows(query string, args ...interface{}) ([]map[string]interface{}, error) {
+	columns, values, err := s.queryTable(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = value[i]
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// queryTable runs a query and returns its column names and each row's values in order
+func (s *ExportService) queryTable(query string, args ...interface{}) ([]string, [][]interface{}, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([][]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// The driver returns NUMERIC, JSONB, and arrays as raw bytes
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+
+	return columns, result, rows.Err()
+}
+
+// ToJSON renders the export as indented JSON
+func (e *UserDataExport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// ToCSV renders the export as a single long-format CSV (table,row,column,value)
+func (e *UserDataExport) ToCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"table", "row", "column", "value"}); err != nil {
+		return nil, err
+	}
+
+	for _, table := range exportTables {
+		for rowIndex, row := range e.Tables[table] {
+			columns := make([]string, 0, len(row))
+			for column := range row {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+
+			for _, column := range columns {
+				record := []string{table, strconv.Itoa(rowIndex + 1), column, formatExportValue(row[column])}
+				if err := w.Write(record); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RowCount returns the number of rows across every table
+func (e *CompletionExport) RowCount() int {
+	count := 0
+	for _, table := range e.Tables {
+		count += len(table.Rows)
+	}
+	return count
+}
+
+// ToZip renders the export as a zip holding one CSV per table
+func (e *CompletionExport) ToZip() ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for _, table := range e.Tables {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     table.Name + ".csv",
+			Method:   zip.Deflate,
+			Modified: e.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w := csv.NewWriter(file)
+		if err := w.Write(table.Columns); err != nil {
+			return nil, err
+		}
+		for _, row := range table.Rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = formatExportValue(value)
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatExportValue converts a scanned column value to its CSV representation
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Feature names that can be toggled per guild
+const (
+	FeaturePenalties    = "penalties"
+	FeatureLeaderboards = "leaderboards"
+	FeaturePhotos       = "photos"
+	FeatureReminders    = "reminders"
+	FeatureForum        = "forum"
+	FeatureEmailReports = "email_reports"
+	FeatureCoach        = "coach"
+	// FeatureNaturalLogging only takes effect when NATURAL_LOGGING is on
+	FeatureNaturalLogging = "natural_logging"
+)
+
+// FeatureDefaults lists every toggleable feature and whether it is on when a guild hasn't chosen
+var FeatureDefaults = map[string]bool{
+	FeaturePenalties:      true,
+	FeatureLeaderboards:   true,
+	FeaturePhotos:         true,
+	FeatureReminders:      true,
+	FeatureForum:          true,
+	FeatureEmailReports:   true,
+	FeatureCoach:          true,
+	FeatureNaturalLogging: true,
+}
+
+// DisableFeaturesByDefault turns features off for guilds that haven't chosen (from config).
+// Call before services start; guilds can still turn them on with /features.
+func DisableFeaturesByDefault(features []string) error {
+	for _, feature := range features {
+		if _, ok := FeatureDefaults[feature]; !ok {
+			return fmt.Errorf("unknown feature %q", feature)
+		}
+	}
+	for _, feature := range features {
+		FeatureDefaults[feature] = false
+	}
+	return nil
+}
+
+// FeatureFlagService stores per-guild feature toggles. Flags are read on every
+// gated interaction, so they are cached in memory and refreshed on change.
+type FeatureFlagService struct {
+	db    *sql.DB
+	mu    sync.RWMutex
+	cache map[string]map[string]bool // guild ID -> feature -> enabled (explicit rows only)
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService() *FeatureFlagService {
+	return &FeatureFlagService{
+		cache: make(map[string]map[string]bool),
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *FeatureFlagService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *FeatureFlagService) Name() string {
+	return "FeatureFlagService"
+}
+
+// Health checks the service health
+func (s *FeatureFlagService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// IsEnabled reports whether feature is on for the guild. Without a database, or on
+// a lookup error, the feature's default applies so a DB blip doesn't disable things.
+func (s *FeatureFlagService) IsEnabled(guildID, feature string) bool {
+	flags, err := s.guildFlags(guildID)
+	if err != nil {
+		logger.Error("Failed to load feature flags for guild_id=%s: %v", guildID, err)
+	}
+	if enabled, ok := flags[feature]; ok {
+		return enabled
+	}
+	return FeatureDefaults[feature]
+}
+
+// Flags returns the effective state of every feature for the guild
+func (s *FeatureFlagService) Flags(guildID string) (map[string]bool, error) {
+	explicit, err := s.guildFlags(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]bool, len(FeatureDefaults))
+	for feature, enabled := range FeatureDefaults {
+		flags[feature] = enabled
+	}
+	for feature, enabled := range explicit {
+		flags[feature] = enabled
+	}
+	return flags, nil
+}
+
+// SetEnabled turns a feature on or off for the guild
+func (s *FeatureFlagService) SetEnabled(guildID, feature string, enabled bool, updatedBy string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if _, ok := FeatureDefaults[feature]; !ok {
+		return fmt.Errorf("unknown feature %q", feature)
+	}
+
+	logger.DB("Setting feature flag: guild_id=%s, feature=%s, enabled=%t", guildID, feature, enabled)
+	_, err := s.db.Exec(`
+		INSERT INTO guild_feature_flags (guild_id, feature, enabled, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (guild_id, feature) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, guildID, feature, enabled, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.cache, guildID)
+	s.mu.Unlock()
+	return nil
+}
+
+// guildFlags returns the guild's explicit flags, loading them on first use
+func (s *FeatureFlagService) guildFlags(guildID string) (map[string]bool, error) {
+	s.mu.RLock()
+	flags, ok := s.cache[guildID]
+	s.mu.RUnlock()
+	if ok {
+		return flags, nil
+	}
+	if s.db == nil {
+		return nil, nil
+	}
+
+	rows, err := s.db.Query(`SELECT feature, enabled FROM guild_feature_flags WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags = make(map[string]bool)
+	for rows.Next() {
+		var feature string
+		var enabled bool
+		if err := rows.Scan(&feature, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		flags[feature] = enabled
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[guildID] = flags
+	s.mu.Unlock()
+	return flags, nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// PurchaseCategories are the categories a purchase can be logged under
+var PurchaseCategories = []string{
+	"groceries",
+	"bills",
+	"transport",
+	"health",
+	"household",
+	"dining",
+	"entertainment",
+	"shopping",
+	"other",
+}
+
+// MaxPurchaseAmount is the largest purchase that can be logged
+const MaxPurchaseAmount = 1000000.0
+
+// Finances compliance statuses
+const (
+	FinancesCompliant    = "compliant"
+	FinancesNonCompliant = "non_compliant"
+)
+
+// Purchase is a purchase a member logged for the finances feat
+type Purchase struct {
+	ChallengeDay int
+	Date         time.Time
+	Amount       float64
+	Category     string
+	Necessary    bool
+}
+
+// spendWeekDays is how many days, up to today, a weekly spending summary covers
+const spendWeekDays = 7
+
+// SpendSummary totals a member's purchases for the days From through To
+type SpendSummary struct {
+	From        time.Time
+	To          time.Time
+	Total       float64
+	Necessary   float64            // Spent on necessities
+	Unnecessary float64            // Spent on non-necessities
+	ByCategory  map[string]float64 // Total per category
+	Purchases   int
+	FlaggedDays int // Days made non-compliant by a purchase
+}
+
+// FinanceService logs members' purchases for the finances feat, which only allows
+// spending on necessities
+type FinanceService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewFinanceService creates a new finance service
+func NewFinanceService(userService *UserService) *FinanceService {
+	return &FinanceService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *FinanceService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *FinanceService) Name() string {
+	return "FinanceService"
+}
+
+// Health checks the service health
+func (s *FinanceService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// LogPurchase records a purchase on the user's writable day. A purchase that wasn't
+// necessary marks the day's finances non-compliant and adds to its non-necessity
+// spending, even if a check-in already filled the feat in.
+func (s *FinanceService) LogPurchase(userID, username string, amount float64, category string, necessary bool) (Purchase, error) {
+	if s.db == nil {
+		return Purchase{}, fmt.Errorf("database not available")
+	}
+	amount = math.Round(amount*100) / 100
+	if amount <= 0 || amount > MaxPurchaseAmount {
+		return Purchase{}, fmt.Errorf("amount must be more than 0 and at most %.0f", MaxPurchaseAmount)
+	}
+	if !validPurchaseCategory(category) {
+		return Purchase{}, fmt.Errorf("unknown category %q", category)
+	}
+
+	if err := s.userService.EnsureUserExists(userID, username); err != nil {
+		return Purchase{}, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+	date, challengeDay, err := s.userService.GetWritableDay(userID)
+	if err != nil {
+		return Purchase{}, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+	completionDate := date.Format("2006-01-02")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Purchase{}, fmt.Errorf("failed to start purchase: %w", err)
+	}
+	defer tx.Rollback()
+
+	logger.DB("Logging purchase: user_id=%s, challenge_day=%d, amount=%.2f, category=%s, necessary=%t", userID, challengeDay, amount, category, necessary)
+	_, err = tx.Exec(
+		`INSERT INTO purchases (user_id, challenge_day, completion_date, amount, category, necessary)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		userID, challengeDay, completionDate, amount, category, necessary,
+	)
+	if err != nil {
+		return Purchase{}, fmt.Errorf("failed to log purchase: %w", err)
+	}
+
+	// Marked by hand, so a check-in later that day leaves the row alone
+	if !necessary {
+		_, err = tx.Exec(
+			`INSERT INTO finances_completions
+				(user_id, challenge_day, completion_date, compliance_status, non_necessity_spending, spending_category, autopopulated)
+			 VALUES ($1, $2, $3, $4, $5, $6, false)
+			 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+				compliance_status = EXCLUDED.compliance_status,
+				non_necessity_spending = COALESCE(finances_completions.non_necessity_spending, 0) + EXCLUDED.non_necessity_spending,
+				spending_category = EXCLUDED.spending_category,
+				autopopulated = false,
+				completed_at = NOW()`,
+			userID, challengeDay, completionDate, FinancesNonCompliant, amount, category,
+		)
+		if err != nil {
+			return Purchase{}, fmt.Errorf("failed to mark finances non-compliant: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return Purchase{}, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+
+	return Purchase{ChallengeDay: challengeDay, Date: date, Amount: amount, Category: category, Necessary: necessary}, nil
+}
+
+// WeeklySpend totals the purchases the user logged for the seven days ending today
+// in their zone
+func (s *FinanceService) WeeklySpend(userID string) (SpendSummary, error) {
+	if s.db == nil {
+		return SpendSummary{}, fmt.Errorf("database not available")
+	}
+	progress, err := s.userService.challengeDates(userID)
+	if err != nil {
+		return SpendSummary{}, err
+	}
+
+	summary := SpendSummary{
+		From:       progress.Date.AddDate(0, 0, -(spendWeekDays - 1)),
+		To:         progress.Date,
+		ByCategory: make(map[string]float64),
+	}
+	rows, err := s.db.Query(
+		`SELECT category, necessary, SUM(amount), COUNT(*)
+		 FROM purchases
+		 WHERE user_id = $1 AND completion_date BETWEEN $2 AND $3
+		 GROUP BY category, necessary`,
+		userID, summary.From.Format("2006-01-02"), summary.To.Format("2006-01-02"),
+	)
+	if err != nil {
+		return SpendSummary{}, fmt.Errorf("failed to load purchases: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var necessary bool
+		var amount float64
+		var purchases int
+		if err := rows.Scan(&category, &necessary, &amount, &purchases); err != nil {
+			return SpendSummary{}, fmt.Errorf("failed to read purchases: %w", err)
+		}
+		summary.Total += amount
+		summary.ByCategory[category] += amount
+		summary.Purchases += purchases
+		if necessary {
+			summary.Necessary += amount
+		} else {
+			summary.Unnecessary += amount
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return SpendSummary{}, fmt.Errorf("failed to load purchases: %w", err)
+	}
+
+	// Days are counted apart from the totals, since one day can have several categories
+	err = s.db.QueryRow(
+		`SELECT COUNT(DISTINCT challenge_day) FROM purchases
+		 WHERE user_id = $1 AND NOT necessary AND completion_date BETWEEN $2 AND $3`,
+		userID, summary.From.Format("2006-01-02"), summary.To.Format("2006-01-02"),
+	).Scan(&summary.FlaggedDays)
+	if err != nil {
+		return SpendSummary{}, fmt.Errorf("failed to count non-compliant days: %w", err)
+	}
+	return summary, nil
+}
+
+// FormatWeeklySpend describes spend in locale: the total, necessities against
+// non-necessities, and each category. Returns "" when nothing was spent.
+func FormatWeeklySpend(locale string, spend SpendSummary) string {
+	if spend.Purchases == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "finances.week_title", i18n.FormatDate(locale, spend.From),
+		i18n.FormatDate(locale, spend.To), spend.Total, spend.Purchases))
+	text.WriteString(i18n.T(locale, "finances.week_necessary", spend.Necessary))
+	text.WriteString(i18n.T(locale, "finances.week_unnecessary", spend.Unnecessary))
+	if spend.FlaggedDays > 0 {
+		text.WriteString(i18n.T(locale, "finances.week_flagged", spend.FlaggedDays))
+	}
+	text.WriteString(i18n.T(locale, "finances.week_categories"))
+	for _, category := range PurchaseCategories {
+		if amount, ok := spend.ByCategory[category]; ok {
+			text.WriteString(i18n.T(locale, "finances.week_category", category, amount))
+		}
+	}
+	return text.String()
+}
+
+// validPurchaseCategory reports whether category is one of PurchaseCategories
+func validPurchaseCategory(category string) bool {
+	for _, c := range PurchaseCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/fitbit"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// FitbitCallbackPath is where the HTTP server finishes Fitbit sign-ins, under PUBLIC_URL
+const FitbitCallbackPath = "/oauth/fitbit/callback"
+
+// FitbitService links Fitbit accounts and syncs their daily water, calories, and steps
+// on a schedule
+type FitbitService struct {
+	db          *sql.DB
+	client      *fitbit.Client
+	publicURL   string
+	interval    time.Duration
+	connections *ConnectionService
+	water       *WaterService
+	steps       *StepService
+	nutrition   *NutritionService
+	stop        chan struct{}
+	done        chan struct{}
+	jobStatus
+}
+
+// NewFitbitService creates a new Fitbit service that syncs on the given interval.
+// publicURL is where the HTTP server is reachable from the internet.
+func NewFitbitService(clientID, clientSecret, publicURL string, interval time.Duration, connections *ConnectionService, water *WaterService, steps *StepService, nutrition *NutritionService) *FitbitService {
+	return &FitbitService{
+		client:      fitbit.NewClient(clientID, clientSecret),
+		publicURL:   publicURL,
+		interval:    interval,
+		connections: connections,
+		water:       water,
+		steps:       steps,
+		nutrition:   nutrition,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *FitbitService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *FitbitService) Name() string {
+	return "FitbitService"
+}
+
+// Health checks the service health
+func (s *FitbitService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Provider returns the provider name
+func (s *FitbitService) Provider() string {
+	return ProviderFitbit
+}
+
+// AuthorizeURL starts linking the user's Fitbit account
+func (s *FitbitService) AuthorizeURL(userID string) (string, error) {
+	state, err := s.connections.NewState(userID, ProviderFitbit)
+	if err != nil {
+		return "", err
+	}
+	return s.client.AuthorizeURL(s.publicURL+FitbitCallbackPath, state), nil
+}
+
+// Complete finishes linking with the code from the OAuth callback and returns the
+// user. Their data is synced right away so they see it without waiting a full interval.
+func (s *FitbitService) Complete(state, code string) (string, error) {
+	userID, err := s.connections.ConsumeState(state, ProviderFitbit)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := s.client.Exchange(code, s.publicURL+FitbitCallbackPath)
+	if err != nil {
+		return "", err
+	}
+	conn := Connection{
+		UserID:       userID,
+		Provider:     ProviderFitbit,
+		ExternalID:   token.UserID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt,
+	}
+	if err := s.connections.Save(conn); err != nil {
+		return "", err
+	}
+	logger.Info("Fitbit user %s linked to user_id=%s (scope %q)", token.UserID, userID, token.Scope)
+
+	go func() {
+		if err := s.syncUser(conn); err != nil {
+			logger.Warn("Initial Fitbit sync failed for user_id=%s: %v", userID, err)
+		}
+	}()
+	return userID, nil
+}
+
+// Disconnect unlinks the user's Fitbit account, revoking the bot's access at Fitbit
+// too, and reports whether one was linked
+func (s *FitbitService) Disconnect(userID string) (bool, error) {
+	conn, err := s.connections.Get(userID, ProviderFitbit)
+	if errors.Is(err, ErrConnectionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Unlinking locally matters more than telling Fitbit, so a failure here only warns
+	if token, err := s.accessToken(&conn); err != nil {
+		logger.Warn("Failed to refresh Fitbit token to revoke user_id=%s: %v", userID, err)
+	} else if err := s.client.Revoke(token); err != nil {
+		logger.Warn("Failed to revoke Fitbit access for user_id=%s: %v", userID, err)
+	}
+	return s.connections.Delete(userID, ProviderFitbit)
+}
+
+// Start syncs every linked account immediately and then on every interval
+func (s *FitbitService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			err := s.Sync()
+			if err != nil {
+				logger.Error("Fitbit sync failed: %v", err)
+			}
+			s.recordRun(err)
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the sync job
+func (s *FitbitService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done // Let a run in progress finish
+		s.stop = nil
+	}
+}
+
+// Sync pulls water, calories, and steps for every linked account. One account failing (e.g.
+// revoked access) doesn't stop the others; the error reports how many failed.
+func (s *FitbitService) Sync() error {
+	connections, err := s.connections.List(ProviderFitbit)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, conn := range connections {
+		if err := s.syncUser(conn); err != nil {
+			logger.Warn("Fitbit sync failed for user_id=%s: %v", conn.UserID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d Fitbit accounts failed to sync", failed, len(connections))
+	}
+	return nil
+}
+
+// syncUser pulls today and yesterday, in the user's zone, since Fitbit devices can
+// upload a day's last entries after midnight
+func (s *FitbitService) syncUser(conn Connection) error {
+	token, err := s.accessToken(&conn)
+	if err != nil {
+		return err
+	}
+	progress, err := s.water.userService.GetProgress(conn.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, date := range []time.Time{progress.Date.AddDate(0, 0, -1), progress.Date} {
+		ounces, err := s.client.WaterOunces(token, date)
+		if err != nil {
+			return err
+		}
+		if ounces > 0 {
+			if _, _, err := s.water.SyncWater(conn.UserID, date, ounces, ProviderFitbit); err != nil {
+				return err
+			}
+		}
+
+		// Days without any food logged are skipped, so they aren't shown as 0 calories
+		calories, logged, err := s.client.Calories(token, date)
+		if err != nil {
+			return err
+		}
+		if logged {
+			if _, err := s.nutrition.SyncCalories(conn.UserID, date, calories, ProviderFitbit); err != nil {
+				return err
+			}
+		}
+
+		steps, err := s.client.Steps(token, date)
+		if err != nil {
+			return err
+		}
+		if _, err := s.steps.SyncSteps(conn.UserID, date, steps, ProviderFitbit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accessToken returns a usable access token, refreshing (and storing) it when it's
+// about to expire
+func (s *FitbitService) accessToken(conn *Connection) (string, error) {
+	if time.Until(conn.ExpiresAt) > time.Minute {
+		return conn.AccessToken, nil
+	}
+
+	token, err := s.client.Refresh(conn.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	conn.AccessToken, conn.RefreshToken, conn.ExpiresAt = token.AccessToken, token.RefreshToken, token.ExpiresAt
+	if err := s.connections.UpdateTokens(*conn); err != nil {
+		return "", err
+	}
+	return conn.AccessToken, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// ForumThread is a participant's personal progress post in the forum channel
+type ForumThread struct {
+	UserID   string
+	Username string
+	ThreadID string
+}
+
+// ForumService tracks each participant's progress post for forum-channel mode
+type ForumService struct {
+	db *sql.DB
+}
+
+// NewForumService creates a new forum service
+func NewForumService() *ForumService {
+	return &ForumService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *ForumService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *ForumService) Name() string {
+	return "ForumService"
+}
+
+// Health checks the service health
+func (s *ForumService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// GetThreadID returns the user's progress post in channelID, or "" if they don't have one yet
+func (s *ForumService) GetThreadID(userID, channelID string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	var threadID string
+	err := s.db.QueryRow(`
+		SELECT thread_id FROM user_forum_threads
+		WHERE user_id = $1 AND channel_id = $2
+	`, userID, channelID).Scan(&threadID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get forum thread: %w", err)
+	}
+	return threadID, nil
+}
+
+// SetThreadID records (or replaces) the user's progress post
+func (s *ForumService) SetThreadID(userID, channelID, threadID string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Saving forum thread: user_id=%s, thread_id=%s", userID, threadID)
+	_, err := s.db.Exec(`
+		INSERT INTO user_forum_threads (user_id, channel_id, thread_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			channel_id = EXCLUDED.channel_id,
+			thread_id = EXCLUDED.thread_id,
+			created_at = NOW()
+	`, userID, channelID, threadID)
+	if err != nil {
+		return fmt.Errorf("failed to save forum thread: %w", err)
+	}
+	return nil
+}
+
+// ListThreads returns every participant's progress post in channelID
+func (s *ForumService) ListThreads(channelID string) ([]ForumThread, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT t.user_id, u.username, t.thread_id
+		FROM user_forum_threads t
+		JOIN users u ON u.user_id = t.user_id
+		WHERE t.channel_id = $1
+		ORDER BY u.username
+	`, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list forum threads: %w", err)
+	}
+	defer rows.Close()
+
+	var threads []ForumThread
+	for rows.Next() {
+		var thread ForumThread
+		if err := rows.Scan(&thread.UserID, &thread.Username, &thread.ThreadID); err != nil {
+			return nil, fmt.Errorf("failed to scan forum thread: %w", err)
+		}
+		threads = append(threads, thread)
+	}
+	return threads, rows.Err()
+}
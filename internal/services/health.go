@@ -0,0 +1,193 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// HealthStatus is the most recent health check result for one component
+type HealthStatus struct {
+	Name      string
+	Healthy   bool
+	Error     string
+	CheckedAt time.Time
+	Since     time.Time // When the component entered its current state
+}
+
+// HealthMonitor periodically calls Health() on every registered service and pings the database.
+// It is registered like any other service so handlers can look it up for /botstats and HTTP probes.
+type HealthMonitor struct {
+	db       *sql.DB
+	registry *ServiceRegistry
+	interval time.Duration
+	mu       sync.RWMutex
+	statuses map[string]HealthStatus
+	alert    Alerter
+	stop     chan struct{}
+	done     chan struct{}
+	jobStatus
+}
+
+// NewHealthMonitor creates a new health monitor for the services in the registry
+func NewHealthMonitor(registry *ServiceRegistry, interval time.Duration) *HealthMonitor {
+	return &HealthMonitor{
+		registry: registry,
+		interval: interval,
+		statuses: make(map[string]HealthStatus),
+	}
+}
+
+// Initialize initializes the service with database connection
+func (m *HealthMonitor) Initialize(db *sql.DB) error {
+	m.db = db
+	return nil
+}
+
+// Name returns the service name
+func (m *HealthMonitor) Name() string {
+	return "HealthMonitor"
+}
+
+// Health reports whether the monitor itself is running
+func (m *HealthMonitor) Health() error {
+	if m.stop == nil {
+		return fmt.Errorf("health monitor not running")
+	}
+	return nil
+}
+
+// Start runs a check immediately and then on every interval
+func (m *HealthMonitor) Start() {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		m.CheckAll()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.CheckAll()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts periodic checks
+func (m *HealthMonitor) Stop() {
+	if m.stop != nil {
+		close(m.stop)
+		<-m.done // Let a run in progress finish
+		m.stop = nil
+	}
+}
+
+// SetAlerter sends health transitions to admins in addition to the log
+func (m *HealthMonitor) SetAlerter(alert Alerter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.alert = alert
+}
+
+// CheckAll checks the database and every registered service, logging any state changes
+func (m *HealthMonitor) CheckAll() {
+	if m.db != nil {
+		m.record("Database", m.db.Ping())
+	} else {
+		m.record("Database", fmt.Errorf("database not configured"))
+	}
+
+	for _, service := range m.registry.GetServices() {
+		if service == Service(m) {
+			continue
+		}
+		m.record(service.Name(), service.Health())
+	}
+	m.recordRun(nil)
+}
+
+// DBStats returns the connection pool stats of the primary database, if there is one
+func (m *HealthMonitor) DBStats() (sql.DBStats, bool) {
+	if m.db == nil {
+		return sql.DBStats{}, false
+	}
+	return m.db.Stats(), true
+}
+
+// record stores a check result and logs transitions between healthy and unhealthy
+func (m *HealthMonitor) record(name string, err error) {
+	now := time.Now()
+	status := HealthStatus{
+		Name:      name,
+		Healthy:   err == nil,
+		CheckedAt: now,
+		Since:     now,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	m.mu.Lock()
+	previous, seen := m.statuses[name]
+	if seen && previous.Healthy == status.Healthy {
+		status.Since = previous.Since
+	}
+	m.statuses[name] = status
+	alert := m.alert
+	m.mu.Unlock()
+
+	var message string
+	switch {
+	case !seen && !status.Healthy:
+		message = fmt.Sprintf("⚠️  %s is unhealthy: %s", name, status.Error)
+		logger.Error("%s", message)
+	case seen && previous.Healthy && !status.Healthy:
+		message = fmt.Sprintf("⚠️  %s became unhealthy: %s", name, status.Error)
+		logger.Error("%s", message)
+	case seen && !previous.Healthy && status.Healthy:
+		message = fmt.Sprintf("✅ %s recovered after %s", name, now.Sub(previous.Since).Round(time.Second))
+		logger.Info("%s", message)
+	}
+
+	if message != "" && alert != nil {
+		alert(message)
+	}
+}
+
+// Statuses returns the latest status of every checked component, sorted by name
+func (m *HealthMonitor) Statuses() []HealthStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]HealthStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Healthy reports whether every checked component passed its last check
+func (m *HealthMonitor) Healthy() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, status := range m.statuses {
+		if !status.Healthy {
+			return false
+		}
+	}
+	return len(m.statuses) > 0
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/applehealth"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// SourceAppleHealth marks rows backfilled from an Apple Health export
+const SourceAppleHealth = "apple_health"
+
+// HealthImportSummary counts what an Apple Health import did
+type HealthImportSummary struct {
+	WorkoutDays int // Days a workout was recorded
+	WaterDays   int // Days water was synced
+	WeighIns    int // Weigh-ins added
+	Kept        int // Days whose existing entry was kept (e.g. a longer or manual workout)
+	Short       int // Days whose longest workout was under MinWorkoutMinutes
+	Outside     int // Samples before the challenge started or after today
+	Skipped     int // Samples in units that can't be converted
+}
+
+// HealthImportService backfills a user's challenge from an Apple Health export
+type HealthImportService struct {
+	db          *sql.DB
+	userService *UserService
+	exercise    *ExerciseService
+	water       *WaterService
+	weighIns    *WeighInService
+}
+
+// NewHealthImportService creates a new health import service
+func NewHealthImportService(userService *UserService, exercise *ExerciseService, water *WaterService, weighIns *WeighInService) *HealthImportService {
+	return &HealthImportService{
+		userService: userService,
+		exercise:    exercise,
+		water:       water,
+		weighIns:    weighIns,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *HealthImportService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *HealthImportService) Name() string {
+	return "HealthImportService"
+}
+
+// Health checks the service health
+func (s *HealthImportService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Import backfills workouts, water, and weigh-ins from export for the days of the
+// user's challenge so far. Each day gets its longest workout, its total water
+// (merged with manual logs like a Fitbit sync), and its last weigh-in. Importing the
+// same export again changes nothing.
+func (s *HealthImportService) Import(userID string, export *applehealth.Export) (HealthImportSummary, error) {
+	if s.db == nil {
+		return HealthImportSummary{}, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return HealthImportSummary{}, err
+	}
+	summary := HealthImportSummary{Skipped: export.Skipped}
+
+	// Only the challenge so far: a sample's own date (in the offset it was recorded
+	// in) is the day it counts for
+	today := progress.Date.Format("2006-01-02")
+	inChallenge := func(at time.Time) bool {
+		day := ChallengeDayForDate(progress.StartDate, at)
+		if day < 1 || day > progress.TotalDays || at.Format("2006-01-02") > today {
+			summary.Outside++
+			return false
+		}
+		return true
+	}
+
+	longest := map[string]applehealth.Workout{}
+	for _, workout := range export.Workouts {
+		if !inChallenge(workout.Start) {
+			continue
+		}
+		key := workout.Start.Format("2006-01-02")
+		if current, ok := longest[key]; !ok || workout.Minutes > current.Minutes {
+			longest[key] = workout
+		}
+	}
+	for _, workout := range longest {
+		if workout.Minutes < MinWorkoutMinutes {
+			summary.Short++
+			continue
+		}
+		location := "indoor"
+		if workout.Outdoor {
+			location = "outdoor"
+		}
+		_, recorded, err := s.exercise.ImportWorkout(userID, workout.Start, workout.Minutes, workout.Type, location,
+			SourceAppleHealth, workout.Start.Format(time.RFC3339))
+		if err != nil {
+			return summary, err
+		}
+		if recorded {
+			summary.WorkoutDays++
+		} else {
+			summary.Kept++
+		}
+	}
+
+	water := map[string]applehealth.Sample{}
+	for _, sample := range export.Water {
+		if !inChallenge(sample.Time) {
+			continue
+		}
+		key := sample.Time.Format("2006-01-02")
+		day := water[key]
+		day.Time, day.Value = sample.Time, day.Value+sample.Value
+		water[key] = day
+	}
+	for _, day := range water {
+		if _, synced, err := s.water.SyncWater(userID, day.Time, day.Value, SourceAppleHealth); err != nil {
+			return summary, err
+		} else if synced {
+			summary.WaterDays++
+		}
+	}
+
+	latest := map[string]applehealth.Sample{}
+	for _, sample := range export.Weight {
+		// weigh_ins only accepts weights under 1000 lbs
+		if sample.Value <= 0 || sample.Value >= 1000 {
+			summary.Skipped++
+			continue
+		}
+		if !inChallenge(sample.Time) {
+			continue
+		}
+		key := sample.Time.Format("2006-01-02")
+		if current, ok := latest[key]; !ok || sample.Time.After(current.Time) {
+			latest[key] = sample
+		}
+	}
+	for _, sample := range latest {
+		recorded, err := s.weighIns.ImportWeighIn(userID, sample.Time, sample.Value, SourceAppleHealth)
+		if err != nil {
+			return summary, err
+		}
+		if recorded {
+			summary.WeighIns++
+		}
+	}
+
+	logger.Info("Apple Health import for user_id=%s: %d workout days, %d water days, %d weigh-ins, %d kept, %d outside the challenge",
+		userID, summary.WorkoutDays, summary.WaterDays, summary.WeighIns, summary.Kept, summary.Outside)
+	return summary, nil
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// SourceCSV marks rows backfilled from a /import csv file
+const SourceCSV = "csv"
+
+// historyFeat is a feat a history CSV can mark done, with the headers it's recognized by
+type historyFeat struct {
+	table   string
+	headers []string
+}
+
+// historyFeats lists the feats of a history CSV, in check-in order. A day with every
+// feat done also gets its check-in.
+var historyFeats = []historyFeat{
+	{table: "exercise_completions", headers: []string{"exercise", "workout", "workouts"}},
+	{table: "diet_completions", headers: []string{"diet"}},
+	{table: "water_completions", headers: []string{"water"}},
+	{table: "self_improvement_completions", headers: []string{"reading", "self-improvement", "self_improvement", "self improvement"}},
+	{table: "finances_completions", headers: []string{"finances", "finance"}},
+}
+
+// maxImportProblems caps how many problems an invalid file reports
+const maxImportProblems = 10
+
+// ImportProblems lists what's wrong with an import file, by line. Nothing is imported
+// from a file with problems.
+type ImportProblems []string
+
+func (p ImportProblems) Error() string {
+	return strings.Join(p, "; ")
+}
+
+// add records a problem, reporting whether there's room for more
+func (p *ImportProblems) add(format string, args ...interface{}) bool {
+	if len(*p) < maxImportProblems {
+		*p = append(*p, fmt.Sprintf(format, args...))
+	}
+	return len(*p) < maxImportProblems
+}
+
+// HistoryDay is one row of a history CSV: a day, by date or challenge day number, and
+// which feats were done on it
+type HistoryDay struct {
+	Line int
+	Date time.Time // Zero when the file numbers days instead
+	Day  int       // Zero when the file dates days instead
+	Done map[string]bool
+}
+
+// ParseHistoryCSV reads a history CSV. The header row names a date column (YYYY-MM-DD)
+// or a day column (challenge day numbers), then any of the feat columns: exercise,
+// diet, water, reading, and finances. Cells such as yes, x, or ✅ mark a feat done;
+// blank, no, or 0 mark it not done. Returns ImportProblems when the file is invalid.
+func ParseHistoryCSV(r io.Reader) ([]HistoryDay, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ImportProblems{"the file is empty"}
+	}
+	if err != nil {
+		return nil, ImportProblems{err.Error()}
+	}
+
+	var problems ImportProblems
+	dateColumn, dayColumn := -1, -1
+	featColumns := map[int]string{} // Column index to table
+	var columns []int
+	for column, name := range header {
+		// Spreadsheet apps often start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "date":
+			dateColumn = column
+			continue
+		case "day":
+			dayColumn = column
+			continue
+		}
+		table := ""
+		for _, feat := range historyFeats {
+			for _, alias := range feat.headers {
+				if name == alias {
+					table = feat.table
+				}
+			}
+		}
+		if table == "" {
+			problems.add("line 1: unknown column %q; use date or day, then exercise, diet, water, reading, finances", name)
+			continue
+		}
+		featColumns[column] = table
+		columns = append(columns, column)
+	}
+	if dateColumn < 0 && dayColumn < 0 {
+		problems.add("line 1: needs a date or day column")
+	}
+	if dateColumn >= 0 && dayColumn >= 0 {
+		problems.add("line 1: use either a date or a day column, not both")
+	}
+	if len(featColumns) == 0 {
+		problems.add("line 1: needs at least one feat column")
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	var days []HistoryDay
+	seen := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) || !problems.add("line %d: %v", parseErr.Line, parseErr.Err) {
+				break
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		day := HistoryDay{Line: line, Done: map[string]bool{}}
+		var key string
+		if dateColumn >= 0 {
+			key = strings.TrimSpace(record[dateColumn])
+			day.Date, err = time.Parse("2006-01-02", key)
+			if err != nil {
+				if !problems.add("line %d: %q is not a date like 2026-01-31", line, key) {
+					break
+				}
+				continue
+			}
+		} else {
+			key = strings.TrimSpace(record[dayColumn])
+			day.Day, err = strconv.Atoi(key)
+			if err != nil || day.Day < 1 {
+				if !problems.add("line %d: %q is not a challenge day number", line, key) {
+					break
+				}
+				continue
+			}
+		}
+		if first, ok := seen[key]; ok {
+			if !problems.add("line %d: %s is already on line %d", line, key, first) {
+				break
+			}
+			continue
+		}
+		seen[key] = line
+
+		for _, column := range columns {
+			table := featColumns[column]
+			done, ok := parseHistoryCell(record[column])
+			if !ok {
+				problems.add("line %d: %q in the %s column isn't yes or no", line, record[column], header[column])
+				continue
+			}
+			day.Done[table] = done
+		}
+		if len(problems) >= maxImportProblems {
+			break
+		}
+		days = append(days, day)
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	if len(days) == 0 {
+		return nil, ImportProblems{"the file has no days after the header"}
+	}
+	return days, nil
+}
+
+// parseHistoryCell reads a yes/no cell, reporting whether it was understood
+func parseHistoryCell(cell string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(cell)) {
+	case "yes", "y", "x", "1", "true", "done", "✓", "✔", "✅":
+		return true, true
+	case "", "no", "n", "0", "false", "-", "✗", "❌":
+		return false, true
+	}
+	return false, false
+}
+
+// HistoryImportSummary counts what a history import did, or would do on a dry run
+type HistoryImportSummary struct {
+	Days     int       // Days in the file
+	First    time.Time // Earliest date in the file
+	Last     time.Time // Latest date in the file
+	Feats    int       // Feat entries added
+	Kept     int       // Feats already recorded, which were left as they were
+	CheckIns int       // Days checked in because every feat was done
+}
+
+// HistoryImportService backfills a user's challenge from a history CSV, such as a
+// paper tracker typed into a spreadsheet
+type HistoryImportService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewHistoryImportService creates a new history import service
+func NewHistoryImportService(userService *UserService) *HistoryImportService {
+	return &HistoryImportService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *HistoryImportService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *HistoryImportService) Name() string {
+	return "HistoryImportService"
+}
+
+// Health checks the service health
+func (s *HistoryImportService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Import records the feats done on each day, which must fall within the user's
+// challenge so far. Entries already recorded (by hand or by a sync) are kept as they
+// are, so importing the same file again adds nothing. Days with every feat done are
+// checked in, without announcements. The import is all or nothing: days outside the
+// challenge are returned as ImportProblems. A dry run does the same work and rolls
+// it back, so the summary previews exactly what a real import would do.
+func (s *HistoryImportService) Import(userID string, days []HistoryDay, dryRun bool) (HistoryImportSummary, error) {
+	if s.db == nil {
+		return HistoryImportSummary{}, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return HistoryImportSummary{}, err
+	}
+
+	// Resolve every day before writing anything
+	var problems ImportProblems
+	lastDay := ChallengeDayForDate(progress.StartDate, progress.Date)
+	if lastDay > progress.TotalDays {
+		lastDay = progress.TotalDays
+	}
+	for i := range days {
+		day := &days[i]
+		if day.Day == 0 {
+			day.Day = ChallengeDayForDate(progress.StartDate, day.Date)
+		} else {
+			day.Date = progress.StartDate.AddDate(0, 0, day.Day-1)
+		}
+		date := day.Date.Format("2006-01-02")
+		switch {
+		case day.Day < 1:
+			problems.add("line %d: %s is before your challenge started on %s", day.Line, date, progress.StartDate.Format("2006-01-02"))
+		case day.Day > lastDay:
+			problems.add("line %d: %s (day %d) hasn't happened yet in your challenge", day.Line, date, day.Day)
+		}
+	}
+	if len(problems) > 0 {
+		return HistoryImportSummary{}, problems
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return HistoryImportSummary{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	summary := HistoryImportSummary{Days: len(days)}
+	for _, day := range days {
+		if summary.First.IsZero() || day.Date.Before(summary.First) {
+			summary.First = day.Date
+		}
+		if day.Date.After(summary.Last) {
+			summary.Last = day.Date
+		}
+		date := day.Date.Format("2006-01-02")
+
+		complete := true
+		for _, feat := range historyFeats {
+			if !day.Done[feat.table] {
+				complete = false
+				continue
+			}
+			// Columns left at their defaults, which meet each feat's minimums
+			result, err := tx.Exec(fmt.Sprintf(
+				`INSERT INTO %s (user_id, challenge_day, completion_date, metadata, autopopulated)
+				 VALUES ($1, $2, $3, jsonb_build_object('source', $4::text), false)
+				 ON CONFLICT (user_id, challenge_day) DO NOTHING`, feat.table),
+				userID, day.Day, date, SourceCSV,
+			)
+			if err != nil {
+				return summary, fmt.Errorf("failed to import into %s: %w", feat.table, err)
+			}
+			if rows, _ := result.RowsAffected(); rows > 0 {
+				summary.Feats++
+			} else {
+				summary.Kept++
+			}
+		}
+
+		// A check-in fills in any missing feats, so only complete days get one
+		if !complete {
+			continue
+		}
+		result, err := tx.Exec(
+			`INSERT INTO accountability_checkins (user_id, challenge_day, completion_date, check_in_method, metadata)
+			 VALUES ($1, $2, $3, 'import', jsonb_build_object('source', $4::text))
+			 ON CONFLICT (user_id, challenge_day) DO NOTHING`,
+			userID, day.Day, date, SourceCSV,
+		)
+		if err != nil {
+			return summary, fmt.Errorf("failed to import check-in: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			summary.CheckIns++
+		}
+	}
+
+	if dryRun {
+		return summary, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return summary, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	logger.Info("History import for user_id=%s: %d days, %d feats added, %d kept, %d check-ins",
+		userID, summary.Days, summary.Feats, summary.Kept, summary.CheckIns)
+	return summary, nil
+}
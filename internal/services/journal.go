@@ -0,0 +1,292 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/lib/pq"
+)
+
+// UndoWindow is how long after a log /undo can still reverse it
+const UndoWindow = 15 * time.Minute
+
+// Actions recorded in the journal
+const (
+	ActionWaterAdd      = "water_add"
+	ActionWaterSubtract = "water_subtract"
+	ActionExercise      = "exercise"
+	ActionCheckIn       = "check_in"
+)
+
+// journalTables lists the per-day tables each action changes, in the order they're
+// restored. A check-in's trigger fills the feat tables, so restoring it comes first
+// and the feats it created are then put back as they were.
+var journalTables = map[string][]string{
+	ActionWaterAdd:      {"water_completions"},
+	ActionWaterSubtract: {"water_completions"},
+	ActionExercise:      {"exercise_completions"},
+	ActionCheckIn: {
+		"accountability_checkins",
+		"exercise_completions",
+		"diet_completions",
+		"water_completions",
+		"self_improvement_completions",
+		"finances_completions",
+	},
+}
+
+// ErrNothingToUndo is returned when the user has no log recent enough to undo
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// journalEntry is a snapshot of a day's rows taken before an action changes them
+type journalEntry struct {
+	userID       string
+	action       string
+	challengeDay int
+	rows         map[string]json.RawMessage // Table to row as JSON; null when there was no row
+}
+
+// snapshotDay saves the rows action is about to change, so recordAction can journal
+// them once the write succeeds
+func snapshotDay(db *sql.DB, userID, action string, challengeDay int) (*journalEntry, error) {
+	entry := &journalEntry{userID: userID, action: action, challengeDay: challengeDay, rows: make(map[string]json.RawMessage)}
+	for _, table := range journalTables[action] {
+		var row []byte
+		err := db.QueryRow(
+			fmt.Sprintf(`SELECT to_jsonb(t) FROM %s t WHERE user_id = $1 AND challenge_day = $2`, table),
+			userID, challengeDay,
+		).Scan(&row)
+		if err == sql.ErrNoRows {
+			row = []byte("null")
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+		entry.rows[table] = row
+	}
+	return entry, nil
+}
+
+// userDay is one user's challenge day
+type userDay struct {
+	userID       string
+	challengeDay int
+}
+
+// snapshotDays is snapshotDay for several users' days at once, with one query per
+// table however many days there are
+func snapshotDays(db *sql.DB, action string, days []userDay) (map[userDay]*journalEntry, error) {
+	entries := make(map[userDay]*journalEntry, len(days))
+	userIDs := make([]string, len(days))
+	challengeDays := make([]int64, len(days))
+	for i, day := range days {
+		entry := &journalEntry{userID: day.userID, action: action, challengeDay: day.challengeDay, rows: make(map[string]json.RawMessage)}
+		for _, table := range journalTables[action] {
+			entry.rows[table] = json.RawMessage("null")
+		}
+		entries[day] = entry
+		userIDs[i] = day.userID
+		challengeDays[i] = int64(day.challengeDay)
+	}
+
+	for _, table := range journalTables[action] {
+		rows, err := db.Query(fmt.Sprintf(
+			`SELECT t.user_id, t.challenge_day, to_jsonb(t)
+			 FROM %s t
+			 JOIN unnest($1::text[], $2::int[]) AS d(user_id, challenge_day)
+			   ON t.user_id = d.user_id AND t.challenge_day = d.challenge_day`, table),
+			pq.Array(userIDs), pq.Array(challengeDays),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+		for rows.Next() {
+			var day userDay
+			var row []byte
+			if err := rows.Scan(&day.userID, &day.challengeDay, &row); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+			}
+			if entry, ok := entries[day]; ok {
+				entry.rows[table] = row
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+	}
+	return entries, nil
+}
+
+// recordActions is recordAction for several entries at once
+func recordActions(db *sql.DB, entries []*journalEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	userIDs := make([]string, 0, len(entries))
+	actions := make([]string, 0, len(entries))
+	challengeDays := make([]int64, 0, len(entries))
+	snapshots := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		snapshot, err := json.Marshal(entry.rows)
+		if err != nil {
+			logger.Error("Failed to encode %s journal entry: %v", entry.action, err)
+			continue
+		}
+		userIDs = append(userIDs, entry.userID)
+		actions = append(actions, entry.action)
+		challengeDays = append(challengeDays, int64(entry.challengeDay))
+		snapshots = append(snapshots, string(snapshot))
+	}
+
+	_, err := db.Exec(
+		`INSERT INTO action_journal (user_id, action, challenge_day, snapshot)
+		 SELECT * FROM unnest($1::text[], $2::text[], $3::int[], $4::jsonb[])`,
+		pq.Array(userIDs), pq.Array(actions), pq.Array(challengeDays), pq.Array(snapshots),
+	)
+	if err != nil {
+		logger.Error("Failed to journal %d actions: %v", len(userIDs), err)
+		return
+	}
+	_, err = db.Exec(
+		`DELETE FROM action_journal WHERE user_id = ANY($1) AND created_at < NOW() - INTERVAL '1 day'`,
+		pq.Array(userIDs),
+	)
+	if err != nil {
+		logger.Error("Failed to prune action journal: %v", err)
+	}
+}
+
+// recordAction journals an action whose write succeeded. Failing to journal doesn't
+// undo the write, so errors are only logged; entries past a day are pruned.
+func recordAction(db *sql.DB, entry *journalEntry) {
+	snapshot, err := json.Marshal(entry.rows)
+	if err != nil {
+		logger.Error("Failed to encode %s journal entry: %v", entry.action, err)
+		return
+	}
+	_, err = db.Exec(
+		`INSERT INTO action_journal (user_id, action, challenge_day, snapshot) VALUES ($1, $2, $3, $4)`,
+		entry.userID, entry.action, entry.challengeDay, string(snapshot),
+	)
+	if err != nil {
+		logger.Error("Failed to journal %s for user_id=%s: %v", entry.action, entry.userID, err)
+		return
+	}
+	_, err = db.Exec(
+		`DELETE FROM action_journal WHERE user_id = $1 AND created_at < NOW() - INTERVAL '1 day'`,
+		entry.userID,
+	)
+	if err != nil {
+		logger.Error("Failed to prune action journal for user_id=%s: %v", entry.userID, err)
+	}
+}
+
+// JournalService reverses members' recent logs
+type JournalService struct {
+	db *sql.DB
+}
+
+// NewJournalService creates a new journal service
+func NewJournalService() *JournalService {
+	return &JournalService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *JournalService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *JournalService) Name() string {
+	return "JournalService"
+}
+
+// Health checks the service health
+func (s *JournalService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// UndoneAction is a log that /undo reversed
+type UndoneAction struct {
+	Action       string
+	ChallengeDay int
+	LoggedAt     time.Time
+}
+
+// UndoLast puts back the rows the user's latest log within UndoWindow replaced, or
+// returns ErrNothingToUndo. Undoing again reverses the log before it.
+func (s *JournalService) UndoLast(userID string) (UndoneAction, error) {
+	if s.db == nil {
+		return UndoneAction{}, fmt.Errorf("database not available")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return UndoneAction{}, fmt.Errorf("failed to start undo: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	var undone UndoneAction
+	var snapshot []byte
+	err = tx.QueryRow(
+		`SELECT id, action, challenge_day, snapshot, created_at
+		 FROM action_journal
+		 WHERE user_id = $1 AND undone_at IS NULL AND created_at > NOW() - $2 * INTERVAL '1 second'
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT 1
+		 FOR UPDATE`,
+		userID, UndoWindow.Seconds(),
+	).Scan(&id, &undone.Action, &undone.ChallengeDay, &snapshot, &undone.LoggedAt)
+	if err == sql.ErrNoRows {
+		return UndoneAction{}, ErrNothingToUndo
+	}
+	if err != nil {
+		return UndoneAction{}, fmt.Errorf("failed to find the last log: %w", err)
+	}
+
+	var rows map[string]json.RawMessage
+	if err := json.Unmarshal(snapshot, &rows); err != nil {
+		return UndoneAction{}, fmt.Errorf("failed to read journal entry: %w", err)
+	}
+	for _, table := range journalTables[undone.Action] {
+		row, ok := rows[table]
+		if !ok {
+			continue
+		}
+		logger.DB("Undoing %s: restoring %s for user_id=%s, challenge_day=%d", undone.Action, table, userID, undone.ChallengeDay)
+		_, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1 AND challenge_day = $2`, table), userID, undone.ChallengeDay)
+		if err != nil {
+			return UndoneAction{}, fmt.Errorf("failed to undo %s: %w", table, err)
+		}
+		if string(row) != "null" {
+			_, err = tx.Exec(fmt.Sprintf(`INSERT INTO %[1]s SELECT * FROM jsonb_populate_record(NULL::%[1]s, $1)`, table), string(row))
+			if err != nil {
+				return UndoneAction{}, fmt.Errorf("failed to restore %s: %w", table, err)
+			}
+		}
+		// Entries are history, so the water put back or taken off is logged as one more
+		if table == "water_completions" {
+			if _, err := reconcileWater(tx, userID, undone.ChallengeDay, SourceUndo); err != nil {
+				return UndoneAction{}, err
+			}
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE action_journal SET undone_at = NOW() WHERE id = $1`, id); err != nil {
+		return UndoneAction{}, fmt.Errorf("failed to mark log undone: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return UndoneAction{}, fmt.Errorf("failed to commit undo: %w", err)
+	}
+	return undone, nil
+}
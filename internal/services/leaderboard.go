@@ -0,0 +1,224 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// LeaderboardEntry represents one user's precomputed progress
+type LeaderboardEntry struct {
+	UserID          string
+	Username        string
+	Privacy         string // How the user appears in public posts; hidden users are never returned
+	DaysCompleted   int
+	ExerciseDays    int
+	WaterGoalDays   int
+	WeighInCount    int
+	LastCheckInDate sql.NullTime
+}
+
+// LeaderboardService maintains the user_progress_rollup table on a schedule
+// and serves leaderboard reads from it
+type LeaderboardService struct {
+	db          *sql.DB
+	readDB      *sql.DB
+	interval    time.Duration
+	userService *UserService
+	stop        chan struct{}
+	done        chan struct{}
+	jobStatus
+}
+
+// NewLeaderboardService creates a new leaderboard service that refreshes on the given
+// interval; userService gives the zone days are counted in for users who haven't chosen one
+func NewLeaderboardService(interval time.Duration, userService *UserService) *LeaderboardService {
+	return &LeaderboardService{
+		interval:    interval,
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *LeaderboardService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// SetReadDB sets the connection used for leaderboard queries
+func (s *LeaderboardService) SetReadDB(db *sql.DB) {
+	s.readDB = db
+}
+
+// reader returns the read replica if configured, otherwise the primary
+func (s *LeaderboardService) reader() *sql.DB {
+	if s.readDB != nil {
+		return s.readDB
+	}
+	return s.db
+}
+
+// Name returns the service name
+func (s *LeaderboardService) Name() string {
+	return "LeaderboardService"
+}
+
+// Health checks the service health
+func (s *LeaderboardService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Start refreshes the rollup immediately and then on every interval
+func (s *LeaderboardService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		err := s.Refresh()
+		if err != nil {
+			logger.Error("Initial leaderboard refresh failed: %v", err)
+		}
+		s.recordRun(err)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := s.Refresh()
+				if err != nil {
+					logger.Error("Leaderboard refresh failed: %v", err)
+				}
+				s.recordRun(err)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the refresh job
+func (s *LeaderboardService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done // Let a run in progress finish
+		s.stop = nil
+	}
+}
+
+// Refresh recomputes the rollup row for every user
+func (s *LeaderboardService) Refresh() error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	// Days count up to today in each user's own zone
+	started := time.Now()
+	result, err := s.db.Exec(`
+		INSERT INTO user_progress_rollup
+			(user_id, days_completed, exercise_days, water_goal_days, weigh_in_count, last_check_in_date, refreshed_at)
+		SELECT
+			u.user_id,
+			(SELECT COUNT(DISTINCT a.challenge_day) FROM accountability_checkins a
+			  WHERE a.user_id = u.user_id
+			    AND a.challenge_day >= 1
+			    AND a.challenge_day <= GREATEST(1, ((NOW() AT TIME ZONE COALESCE(p.timezone, $1))::date - u.challenge_start_date::date) + 1)),
+			(SELECT COUNT(*) FROM exercise_completions e
+			  WHERE e.user_id = u.user_id AND e.autopopulated = false),
+			(SELECT COUNT(*) FROM water_completions w
+			  WHERE w.user_id = u.user_id AND w.amount_ounces >= 128),
+			(SELECT COUNT(*) FROM weigh_ins wi WHERE wi.user_id = u.user_id),
+			(SELECT MAX(a.completion_date) FROM accountability_checkins a WHERE a.user_id = u.user_id),
+			NOW()
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		ON CONFLICT (user_id) DO UPDATE SET
+			days_completed = EXCLUDED.days_completed,
+			exercise_days = EXCLUDED.exercise_days,
+			water_goal_days = EXCLUDED.water_goal_days,
+			weigh_in_count = EXCLUDED.weigh_in_count,
+			last_check_in_date = EXCLUDED.last_check_in_date,
+			refreshed_at = EXCLUDED.refreshed_at`,
+		s.userService.DefaultTimezone())
+	if err != nil {
+		logger.Error("Failed to refresh progress rollup: %v", err)
+		return fmt.Errorf("failed to refresh progress rollup: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	logger.DB("Refreshed progress rollup for %d users in %s", rowsAffected, time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// GetLeaderboard returns the top users by days completed, leaving out users who chose to be hidden
+func (s *LeaderboardService) GetLeaderboard(limit int) ([]LeaderboardEntry, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default to 10
+	}
+
+	logger.DB("Querying leaderboard (limit=%d)", limit)
+	rows, err := s.reader().Query(
+		`SELECT u.user_id, u.username, COALESCE(p.privacy, 'public'), r.days_completed, r.exercise_days,
+			r.water_goal_days, r.weigh_in_count, r.last_check_in_date
+		 FROM user_progress_rollup r
+		 JOIN users u ON u.user_id = r.user_id
+		 LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		 WHERE COALESCE(p.privacy, 'public') <> 'hidden'
+		 ORDER BY r.days_completed DESC, r.exercise_days DESC, u.username
+		 LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		logger.Error("Failed to query leaderboard: %v", err)
+		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []LeaderboardEntry
+	for rows.Next() {
+		var entry LeaderboardEntry
+		err := rows.Scan(&entry.UserID, &entry.Username, &entry.Privacy, &entry.DaysCompleted, &entry.ExerciseDays,
+			&entry.WaterGoalDays, &entry.WeighInCount, &entry.LastCheckInDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// FormatLeaderboard renders leaderboard entries as a Discord message in locale
+func FormatLeaderboard(locale string, entries []LeaderboardEntry) string {
+	var message strings.Builder
+	message.WriteString(i18n.T(locale, "leaderboard.title"))
+
+	if len(entries) == 0 {
+		message.WriteString(i18n.T(locale, "leaderboard.empty"))
+		return message.String()
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	for rank, entry := range entries {
+		prefix := fmt.Sprintf("%d.", rank+1)
+		if rank < len(medals) {
+			prefix = medals[rank]
+		}
+		message.WriteString(i18n.T(locale, "leaderboard.entry",
+			prefix, PublicName(locale, entry.Privacy, entry.UserID, entry.Username), entry.DaysCompleted, entry.ExerciseDays, entry.WaterGoalDays))
+	}
+
+	return message.String()
+}
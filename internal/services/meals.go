@@ -0,0 +1,210 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/storage"
+)
+
+// MaxMealDescriptionLength caps a meal's description
+const MaxMealDescriptionLength = 500
+
+// mealPhotoLinkExpiry is how long a link to a meal photo works; links in a weekly
+// recap last until the next one, which is as long as a presigned link can
+const mealPhotoLinkExpiry = 7 * 24 * time.Hour
+
+// ErrPhotosUnavailable is returned for a meal photo when there's no object storage
+// to keep it in
+var ErrPhotosUnavailable = errors.New("meal photos need object storage")
+
+// Meal is a meal a member logged for the diet feat
+type Meal struct {
+	ID           int64
+	ChallengeDay int
+	Date         time.Time
+	Description  string
+	PhotoKey     string // Object storage key; empty without a photo
+	LoggedAt     time.Time
+}
+
+// MealPhoto is a photo to keep with a meal
+type MealPhoto struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// MealService logs what members eat, so recaps can show it and cheat-meal disputes
+// can be settled with a photo
+type MealService struct {
+	db            *sql.DB
+	userService   *UserService
+	storage       *storage.S3Client
+	storagePrefix string
+}
+
+// NewMealService creates a new meal service
+func NewMealService(userService *UserService) *MealService {
+	return &MealService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *MealService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *MealService) Name() string {
+	return "MealService"
+}
+
+// Health checks the service health
+func (s *MealService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// SetStorage lets meal photos be kept in object storage under prefix
+func (s *MealService) SetStorage(client *storage.S3Client, prefix string) {
+	s.storage = client
+	s.storagePrefix = prefix
+}
+
+// CanStorePhotos reports whether meals can be logged with a photo
+func (s *MealService) CanStorePhotos() bool {
+	return s.storage != nil
+}
+
+// LogMeal records a meal on the user's writable day, uploading photo first when
+// there is one. Photos without object storage return ErrPhotosUnavailable.
+func (s *MealService) LogMeal(userID, username, description string, photo *MealPhoto) (Meal, error) {
+	if s.db == nil {
+		return Meal{}, fmt.Errorf("database not available")
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return Meal{}, fmt.Errorf("describe the meal")
+	}
+	if len(description) > MaxMealDescriptionLength {
+		return Meal{}, fmt.Errorf("description must be at most %d characters", MaxMealDescriptionLength)
+	}
+	if photo != nil && s.storage == nil {
+		return Meal{}, ErrPhotosUnavailable
+	}
+
+	if err := s.userService.EnsureUserExists(userID, username); err != nil {
+		return Meal{}, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+	date, challengeDay, err := s.userService.GetWritableDay(userID)
+	if err != nil {
+		return Meal{}, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+
+	meal := Meal{ChallengeDay: challengeDay, Date: date, Description: description}
+	if photo != nil {
+		meal.PhotoKey = fmt.Sprintf("%s/meals/%s/%d-%d%s", s.storagePrefix, userID, challengeDay,
+			time.Now().UnixNano(), strings.ToLower(path.Ext(photo.Filename)))
+		if err := s.storage.PutObject(meal.PhotoKey, photo.Data, photo.ContentType); err != nil {
+			return Meal{}, fmt.Errorf("failed to upload photo: %w", err)
+		}
+		logger.Info("📦 Meal photo uploaded: %s (%d bytes)", meal.PhotoKey, len(photo.Data))
+	}
+
+	logger.DB("Logging meal: user_id=%s, challenge_day=%d, photo=%t", userID, challengeDay, photo != nil)
+	err = s.db.QueryRow(
+		`INSERT INTO meals (user_id, challenge_day, completion_date, description, photo_key)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		 RETURNING id, logged_at`,
+		userID, challengeDay, date.Format("2006-01-02"), description, meal.PhotoKey,
+	).Scan(&meal.ID, &meal.LoggedAt)
+	if err != nil {
+		return Meal{}, fmt.Errorf("failed to log meal: %w", err)
+	}
+	return meal, nil
+}
+
+// RecentMeals returns the meals the user logged within the last window, oldest first
+func (s *MealService) RecentMeals(userID string, window time.Duration) ([]Meal, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(
+		`SELECT id, challenge_day, completion_date, description, COALESCE(photo_key, ''), logged_at
+		 FROM meals
+		 WHERE user_id = $1 AND logged_at > NOW() - $2 * INTERVAL '1 second'
+		 ORDER BY logged_at, id`,
+		userID, window.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load meals: %w", err)
+	}
+	defer rows.Close()
+
+	var meals []Meal
+	for rows.Next() {
+		var meal Meal
+		if err := rows.Scan(&meal.ID, &meal.ChallengeDay, &meal.Date, &meal.Description, &meal.PhotoKey, &meal.LoggedAt); err != nil {
+			return nil, fmt.Errorf("failed to read meal: %w", err)
+		}
+		meals = append(meals, meal)
+	}
+	return meals, rows.Err()
+}
+
+// PhotoURL returns a link to the meal's photo that works for a week, or "" when the
+// meal has no photo or storage has since been turned off
+func (s *MealService) PhotoURL(meal Meal) (string, error) {
+	if meal.PhotoKey == "" || s.storage == nil {
+		return "", nil
+	}
+	return s.storage.PresignGetObject(meal.PhotoKey, mealPhotoLinkExpiry)
+}
+
+// DeletePhotos removes every meal photo the user uploaded from object storage, ahead
+// of their rows being erased
+func (s *MealService) DeletePhotos(userID string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`SELECT photo_key FROM meals WHERE user_id = $1 AND photo_key IS NOT NULL`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load meal photos: %w", err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read meal photo: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load meal photos: %w", err)
+	}
+	if len(keys) > 0 && s.storage == nil {
+		return fmt.Errorf("%d meal photos are in object storage, which isn't configured", len(keys))
+	}
+
+	for _, key := range keys {
+		if err := s.storage.DeleteObject(key); err != nil {
+			return fmt.Errorf("failed to delete meal photo: %w", err)
+		}
+	}
+	logger.Info("Deleted %d meal photos for user_id=%s", len(keys), userID)
+	return nil
+}
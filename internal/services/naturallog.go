@@ -0,0 +1,114 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// naturalVolume finds a drink size such as "32oz", "500 ml", or "half a gallon"
+	naturalVolume = regexp.MustCompile(`(?:^|[^\pL\d.])(\d+(?:\.\d+)?|half an?|an?|one|medio|una?)\s*-?\s*(oz|ounces?|ml|l|liters?|litres?|litros?|cups?|vasos?|gal|gallons?)\b`)
+	// naturalDuration finds a length of time such as "40 min", "1.5 hours", or "an hour"
+	naturalDuration = regexp.MustCompile(`(?:^|[^\pL\d.])(\d+(?:\.\d+)?|half an?|an?|one|media|una?)\s*-?\s*(minutes?|mins?|minutos?|hours?|hrs?|h|horas?)\b`)
+)
+
+// naturalDrinkWords mark a message as being about something the author drank
+var naturalDrinkWords = map[string]bool{
+	"water": true, "drank": true, "drink": true, "had": true, "finished": true, "chugged": true, "downed": true,
+	"agua": true, "tomé": true, "tome": true, "bebí": true, "bebi": true,
+}
+
+// naturalActivities maps words that mark a message as a workout to the workout type
+// logged; empty is a general workout
+var naturalActivities = map[string]string{
+	"ran": "run", "run": "run", "jogged": "run", "jog": "run", "corrí": "run", "corri": "run",
+	"walked": "walk", "walk": "walk", "caminé": "walk", "camine": "walk",
+	"hiked": "hike", "hike": "hike",
+	"biked": "bike", "bike": "bike", "cycled": "bike", "rode": "bike", "ride": "bike", "spin": "bike", "bicicleta": "bike",
+	"swam": "swim", "swim": "swim", "nadé": "swim", "nade": "swim",
+	"lifted": "weights", "lift": "weights", "weights": "weights", "gym": "weights", "pesas": "weights", "gimnasio": "weights",
+	"yoga": "yoga", "rowed": "row", "row": "row",
+	"workout": "", "worked": "", "exercise": "", "exercised": "", "trained": "", "entrené": "", "entrene": "", "ejercicio": "",
+}
+
+// naturalNotYet are words that mean the activity didn't happen (yet), as in "didn't
+// drink 32oz" or "going to run for an hour"
+var naturalNotYet = map[string]bool{
+	"not": true, "didn": true, "haven": true, "won": true, "gonna": true, "going": true, "need": true,
+	"will": true, "should": true, "plan": true, "planning": true, "no": true, "voy": true, "vamos": true,
+}
+
+// ParseNaturalLog looks for something worth logging in a casual chat message, in
+// English or Spanish: water such as "drank 32oz" or "had half a gallon", or a
+// workout such as "ran 5k in 40 min" or "lifted for an hour". Workouts shorter than
+// the challenge counts are ignored. When a message mentions both, the workout wins.
+func ParseNaturalLog(text string) (QuickLog, bool) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	words := strings.FieldsFunc(normalized, func(r rune) bool { return !unicode.IsLetter(r) })
+
+	var drank, worked bool
+	var workoutType string
+	for _, word := range words {
+		if naturalNotYet[word] {
+			return QuickLog{}, false
+		}
+		drank = drank || naturalDrinkWords[word]
+		if activity, ok := naturalActivities[word]; ok && !worked {
+			worked, workoutType = true, activity
+		}
+	}
+
+	if worked {
+		if minutes := naturalMinutes(normalized); minutes >= MinWorkoutMinutes {
+			return QuickLog{Kind: QuickLogWorkout, Minutes: minutes, Type: workoutType}, true
+		}
+	}
+
+	if drank {
+		if match := naturalVolume.FindStringSubmatch(normalized); match != nil {
+			amount := naturalAmount(match[1])
+			entry := QuickLog{Kind: QuickLogWater, Amount: amount, Unit: VolumeUnitOunces}
+			switch unit := match[2]; {
+			case unit == "ml":
+				entry.Amount, entry.Unit = amount/1000, VolumeUnitLiters
+			case strings.HasPrefix(unit, "l"):
+				entry.Unit = VolumeUnitLiters
+			case strings.HasPrefix(unit, "cup"), strings.HasPrefix(unit, "vaso"):
+				entry.Amount = amount * 8
+			case strings.HasPrefix(unit, "gal"):
+				entry.Amount = amount * WaterGoalOunces
+			}
+			if entry.Amount > 0 {
+				return entry, true
+			}
+		}
+	}
+	return QuickLog{}, false
+}
+
+// naturalMinutes adds up the durations in text, so "1h 15min" is 75
+func naturalMinutes(text string) int {
+	var minutes float64
+	for _, match := range naturalDuration.FindAllStringSubmatch(text, -1) {
+		amount := naturalAmount(match[1])
+		if strings.HasPrefix(match[2], "h") {
+			amount *= 60
+		}
+		minutes += amount
+	}
+	return int(minutes)
+}
+
+// naturalAmount reads a number or a word standing for one, such as "a" or "half a"
+func naturalAmount(s string) float64 {
+	switch {
+	case strings.HasPrefix(s, "half"), s == "medio", s == "media":
+		return 0.5
+	case s == "a", s == "an", s == "one", s == "un", s == "una":
+		return 1
+	}
+	amount, _ := strconv.ParseFloat(s, 64)
+	return amount
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// NutritionService stores daily calorie totals synced from food logs in linked accounts.
+// They're informational: a calorie total can't show whether a day had a cheat meal or
+// alcohol, so diet compliance is still up to the member.
+type NutritionService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewNutritionService creates a new nutrition service
+func NewNutritionService(userService *UserService) *NutritionService {
+	return &NutritionService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *NutritionService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *NutritionService) Name() string {
+	return "NutritionService"
+}
+
+// Health checks the service health
+func (s *NutritionService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// SyncCalories stores the calorie total a provider reports for date, replacing the
+// last sync since members can still edit the day's food log. Days outside the user's
+// challenge or after today are skipped; returns whether the total was stored.
+func (s *NutritionService) SyncCalories(userID string, date time.Time, calories int, source string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return false, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, date)
+	if err != nil {
+		return false, nil
+	}
+
+	logger.DB("Syncing calories: user_id=%s, challenge_day=%d, calories=%d, source=%s", userID, challengeDay, calories, source)
+	_, err = s.db.Exec(`
+		INSERT INTO daily_nutrition (user_id, challenge_day, completion_date, calories, source)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			completion_date = EXCLUDED.completion_date,
+			calories = EXCLUDED.calories,
+			source = EXCLUDED.source,
+			synced_at = NOW()
+	`, userID, challengeDay, date.Format("2006-01-02"), calories, source)
+	if err != nil {
+		return false, fmt.Errorf("failed to sync calories: %w", err)
+	}
+	return true, nil
+}
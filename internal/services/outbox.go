@@ -0,0 +1,162 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// OutboxMaxAttempts is how many deliveries are tried before an announcement is abandoned
+const OutboxMaxAttempts = 10
+
+// Announcement is a pending delivery in the outbox: a public message to ChannelID, or
+// a JSON payload POSTed to an outbound webhook when WebhookID is set
+type Announcement struct {
+	ID        int64
+	ChannelID string
+	Content   string
+	Attempts  int
+
+	WebhookID     int64
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// OutboxService stores announcements until the dispatcher confirms delivery
+type OutboxService struct {
+	db *sql.DB
+}
+
+// NewOutboxService creates a new outbox service
+func NewOutboxService() *OutboxService {
+	return &OutboxService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *OutboxService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *OutboxService) Name() string {
+	return "OutboxService"
+}
+
+// Health checks the service health
+func (s *OutboxService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Enqueue queues an announcement for delivery. dedupeKey identifies the announcement;
+// enqueueing the same key again is a no-op, so retried handlers don't double-post.
+func (s *OutboxService) Enqueue(dedupeKey, channelID, content string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Queueing announcement: key=%s, channel_id=%s", dedupeKey, channelID)
+	_, err := s.db.Exec(`
+		INSERT INTO announcement_outbox (dedupe_key, channel_id, content)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (dedupe_key) DO NOTHING
+	`, dedupeKey, channelID, content)
+	if err != nil {
+		return fmt.Errorf("failed to queue announcement: %w", err)
+	}
+	return nil
+}
+
+// EnqueueWebhook queues a JSON payload for delivery to an outbound webhook, with the
+// same dedupe and retry behavior as announcements
+func (s *OutboxService) EnqueueWebhook(dedupeKey string, webhookID int64, payload string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Queueing webhook delivery: key=%s, webhook_id=%d", dedupeKey, webhookID)
+	_, err := s.db.Exec(`
+		INSERT INTO announcement_outbox (dedupe_key, webhook_id, content)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (dedupe_key) DO NOTHING
+	`, dedupeKey, webhookID, payload)
+	if err != nil {
+		return fmt.Errorf("failed to queue webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// Pending returns announcements due for delivery, oldest first
+func (s *OutboxService) Pending(limit int) ([]Announcement, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT o.announcement_id, COALESCE(o.channel_id, ''), o.content, o.attempts,
+			COALESCE(w.webhook_id, 0), COALESCE(w.url, ''), COALESCE(w.secret, '')
+		FROM announcement_outbox o
+		LEFT JOIN guild_webhooks w ON w.webhook_id = o.webhook_id
+		WHERE o.sent_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= NOW()
+		ORDER BY o.announcement_id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []Announcement
+	for rows.Next() {
+		var a Announcement
+		if err := rows.Scan(&a.ID, &a.ChannelID, &a.Content, &a.Attempts,
+			&a.WebhookID, &a.WebhookURL, &a.WebhookSecret); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		pending = append(pending, a)
+	}
+	return pending, rows.Err()
+}
+
+// MarkSent records a successful delivery. messageID is empty for webhook deliveries.
+func (s *OutboxService) MarkSent(id int64, messageID string) error {
+	_, err := s.db.Exec(`
+		UPDATE announcement_outbox
+		SET sent_at = NOW(), message_id = NULLIF($2, ''), attempts = attempts + 1, last_error = NULL
+		WHERE announcement_id = $1
+	`, id, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to mark announcement sent: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt with exponential
+// backoff; after OutboxMaxAttempts the announcement is abandoned. Returns true if abandoned.
+func (s *OutboxService) MarkFailed(a Announcement, deliveryErr error) (bool, error) {
+	attempts := a.Attempts + 1
+	abandoned := attempts >= OutboxMaxAttempts
+
+	backoff := time.Duration(1<<uint(attempts)) * 30 * time.Second
+	if backoff > time.Hour {
+		backoff = time.Hour
+	}
+
+	_, err := s.db.Exec(`
+		UPDATE announcement_outbox
+		SET attempts = $2,
+			last_error = $3,
+			next_attempt_at = NOW() + $4 * INTERVAL '1 second',
+			failed_at = CASE WHEN $5 THEN NOW() ELSE NULL END
+		WHERE announcement_id = $1
+	`, a.ID, attempts, deliveryErr.Error(), int(backoff.Seconds()), abandoned)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark announcement failed: %w", err)
+	}
+	return abandoned, nil
+}
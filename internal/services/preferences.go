@@ -0,0 +1,435 @@
+package services
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Units users can choose for weigh-ins and water
+const (
+	WeightUnitPounds    = "lbs"
+	WeightUnitKilograms = "kg"
+	VolumeUnitOunces    = "oz"
+	VolumeUnitLiters    = "l"
+)
+
+// Conversion factors from the stored units (pounds, US fluid ounces)
+const (
+	poundsPerKilogram = 2.20462262
+	ouncesPerLiter    = 33.8140227
+)
+
+// Units are the units a user enters and reads weights and water amounts in.
+// Amounts are always stored in pounds and ounces and converted at the service boundary.
+type Units struct {
+	Weight string
+	Volume string
+}
+
+// DefaultUnits apply to users who haven't chosen
+var DefaultUnits = Units{Weight: WeightUnitPounds, Volume: VolumeUnitOunces}
+
+// ToPounds converts a weight entered in u's weight unit to pounds
+func (u Units) ToPounds(weight float64) float64 {
+	if u.Weight == WeightUnitKilograms {
+		return weight * poundsPerKilogram
+	}
+	return weight
+}
+
+// FromPounds converts a stored weight in pounds to u's weight unit
+func (u Units) FromPounds(pounds float64) float64 {
+	if u.Weight == WeightUnitKilograms {
+		return pounds / poundsPerKilogram
+	}
+	return pounds
+}
+
+// ToOunces converts a water amount entered in u's volume unit to ounces
+func (u Units) ToOunces(amount float64) float64 {
+	if u.Volume == VolumeUnitLiters {
+		return amount * ouncesPerLiter
+	}
+	return amount
+}
+
+// FromOunces converts a stored water amount in ounces to u's volume unit
+func (u Units) FromOunces(ounces float64) float64 {
+	if u.Volume == VolumeUnitLiters {
+		return ounces / ouncesPerLiter
+	}
+	return ounces
+}
+
+// ValidateUnits checks that both units are supported
+func ValidateUnits(units Units) error {
+	if units.Weight != WeightUnitPounds && units.Weight != WeightUnitKilograms {
+		return fmt.Errorf("weight unit must be %s or %s, got %q", WeightUnitPounds, WeightUnitKilograms, units.Weight)
+	}
+	if units.Volume != VolumeUnitOunces && units.Volume != VolumeUnitLiters {
+		return fmt.Errorf("volume unit must be %s or %s, got %q", VolumeUnitOunces, VolumeUnitLiters, units.Volume)
+	}
+	return nil
+}
+
+// Privacy choices for how a user appears in public posts
+const (
+	PrivacyPublic    = "public"
+	PrivacyAnonymous = "anonymous"
+	PrivacyHidden    = "hidden"
+)
+
+// PublicName returns how a user appears in public posts in locale: their username, or a
+// stable pseudonym if they chose anonymous. Callers leave hidden users out entirely.
+func PublicName(locale, privacy, userID, username string) string {
+	if privacy == PrivacyAnonymous {
+		sum := sha256.Sum256([]byte(userID))
+		return i18n.T(locale, "privacy.pseudonym", strings.ToUpper(hex.EncodeToString(sum[:2])))
+	}
+	return username
+}
+
+// Delivery choices for the bot's nudges
+const (
+	DeliveryChannel = "channel"
+	DeliveryDM      = "dm"
+	DeliverySMS     = "sms" // Reminders only, to the number verified with /sms
+)
+
+// Preferences that can be changed with /preferences set
+const (
+	PreferenceTimezone     = "timezone"
+	PreferenceWeightUnit   = "weight_unit"
+	PreferenceVolumeUnit   = "volume_unit"
+	PreferenceReminders    = "reminders"
+	PreferenceReminderTime = "reminder_time"
+	PreferenceQuietHours   = "quiet_hours"
+	PreferencePrivacy      = "privacy"
+	PreferenceDelivery     = "delivery"
+)
+
+// PreferenceDescriptions lists every preference with a short description
+var PreferenceDescriptions = map[string]string{
+	PreferenceTimezone:     "IANA time zone for your challenge days and dates",
+	PreferenceWeightUnit:   "Weigh-in unit: lbs or kg",
+	PreferenceVolumeUnit:   "Water unit: oz or l",
+	PreferenceReminders:    "Daily reminders: on or off",
+	PreferenceReminderTime: "When to remind you, as HH:MM in your time zone",
+	PreferenceQuietHours:   "When the bot won't ping you, as HH:MM-HH:MM in your time zone, or off",
+	PreferencePrivacy:      "How you appear in public posts: public, anonymous, or hidden",
+	PreferenceDelivery:     "Where nudges reach you: channel, dm, or sms",
+}
+
+// preferenceColumns maps each preference to its user_preferences column
+var preferenceColumns = map[string]string{
+	PreferenceTimezone:     "timezone",
+	PreferenceWeightUnit:   "weight_unit",
+	PreferenceVolumeUnit:   "volume_unit",
+	PreferenceReminders:    "reminders_enabled",
+	PreferenceReminderTime: "reminder_time",
+	PreferenceQuietHours:   "quiet_hours",
+	PreferencePrivacy:      "privacy",
+	PreferenceDelivery:     "delivery",
+}
+
+// PreferenceNames returns every preference name, sorted
+func PreferenceNames() []string {
+	names := make([]string, 0, len(PreferenceDescriptions))
+	for name := range PreferenceDescriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preferences are one user's personal settings. Other services read them through
+// PreferencesService (or join user_preferences) rather than keeping their own columns.
+type Preferences struct {
+	Timezone         string // IANA zone; empty means the bot's default zone
+	Units            Units
+	RemindersEnabled bool
+	ReminderTime     string // HH:MM in the user's zone
+	QuietHours       string // HH:MM-HH:MM in the user's zone; empty means none
+	Privacy          string
+	Delivery         string
+}
+
+// DefaultPreferences apply to users who haven't chosen
+var DefaultPreferences = Preferences{
+	Units:            DefaultUnits,
+	RemindersEnabled: true,
+	ReminderTime:     "20:00",
+	Privacy:          PrivacyPublic,
+	Delivery:         DeliveryChannel,
+}
+
+// Location returns the user's time zone, or fallback when they haven't chosen one
+func (p Preferences) Location(fallback *time.Location) *time.Location {
+	if p.Timezone == "" {
+		return fallback
+	}
+	return LoadTimezone(p.Timezone)
+}
+
+// InQuietHours reports whether t, given in the user's zone, falls in their quiet hours.
+// Ranges that end earlier than they start, like 22:00-07:00, run past midnight.
+func (p Preferences) InQuietHours(t time.Time) bool {
+	if p.QuietHours == "" {
+		return false
+	}
+	start, end, err := parseQuietHours(p.QuietHours)
+	if err != nil {
+		return false
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return now >= from && now < to
+	}
+	return now >= from || now < to
+}
+
+// parseQuietHours splits an HH:MM-HH:MM range
+func parseQuietHours(value string) (time.Time, time.Time, error) {
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("missing '-' in %q", value)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// Value returns a preference formatted as /preferences set accepts it
+func (p Preferences) Value(name string) string {
+	switch name {
+	case PreferenceTimezone:
+		return p.Timezone
+	case PreferenceWeightUnit:
+		return p.Units.Weight
+	case PreferenceVolumeUnit:
+		return p.Units.Volume
+	case PreferenceReminders:
+		if p.RemindersEnabled {
+			return "on"
+		}
+		return "off"
+	case PreferenceReminderTime:
+		return p.ReminderTime
+	case PreferenceQuietHours:
+		if p.QuietHours == "" {
+			return "off"
+		}
+		return p.QuietHours
+	case PreferencePrivacy:
+		return p.Privacy
+	case PreferenceDelivery:
+		return p.Delivery
+	}
+	return ""
+}
+
+// ValidatePreference checks value for preference and returns it as stored
+func ValidatePreference(preference, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch preference {
+	case PreferenceTimezone:
+		if _, err := time.LoadLocation(value); err != nil || value == "" {
+			return nil, fmt.Errorf("%s must be an IANA time zone such as America/Denver, got %q", preference, value)
+		}
+		return value, nil
+	case PreferenceWeightUnit:
+		return value, ValidateUnits(Units{Weight: value, Volume: VolumeUnitOunces})
+	case PreferenceVolumeUnit:
+		return value, ValidateUnits(Units{Weight: WeightUnitPounds, Volume: value})
+	case PreferenceReminders:
+		switch strings.ToLower(value) {
+		case "on", "true", "yes":
+			return true, nil
+		case "off", "false", "no":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%s must be on or off, got %q", preference, value)
+	case PreferenceReminderTime:
+		parsed, err := time.Parse("15:04", value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a time like 20:00, got %q", preference, value)
+		}
+		return parsed.Format("15:04"), nil
+	case PreferenceQuietHours:
+		switch strings.ToLower(value) {
+		case "off", "none", "":
+			return nil, nil // Stored as NULL
+		}
+		start, end, err := parseQuietHours(value)
+		if err != nil || start == end {
+			return nil, fmt.Errorf("%s must be a range like 22:00-07:00, or off, got %q", preference, value)
+		}
+		return start.Format("15:04") + "-" + end.Format("15:04"), nil
+	case PreferencePrivacy:
+		if value != PrivacyPublic && value != PrivacyAnonymous && value != PrivacyHidden {
+			return nil, fmt.Errorf("%s must be %s, %s, or %s, got %q", preference, PrivacyPublic, PrivacyAnonymous, PrivacyHidden, value)
+		}
+		return value, nil
+	case PreferenceDelivery:
+		if value != DeliveryChannel && value != DeliveryDM && value != DeliverySMS {
+			return nil, fmt.Errorf("%s must be %s, %s, or %s, got %q", preference, DeliveryChannel, DeliveryDM, DeliverySMS, value)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("unknown preference %q", preference)
+}
+
+// PreferencesService stores per-user preferences
+type PreferencesService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewPreferencesService creates a new preferences service; userService's cached
+// record is dropped when a user changes their time zone
+func NewPreferencesService(userService *UserService) *PreferencesService {
+	return &PreferencesService{userService: userService}
+}
+
+// Initialize initializes the service with database connection
+func (s *PreferencesService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *PreferencesService) Name() string {
+	return "PreferencesService"
+}
+
+// Health checks the service health
+func (s *PreferencesService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Get returns the user's preferences, or DefaultPreferences if they haven't chosen any
+func (s *PreferencesService) Get(userID string) (Preferences, error) {
+	if s.db == nil {
+		return DefaultPreferences, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Querying preferences for user_id=%s", userID)
+	var prefs Preferences
+	var timezone, quietHours sql.NullString
+	err := s.db.QueryRow(
+		`SELECT timezone, weight_unit, volume_unit, reminders_enabled, reminder_time, quiet_hours, privacy, delivery
+		 FROM user_preferences WHERE user_id = $1`,
+		userID,
+	).Scan(&timezone, &prefs.Units.Weight, &prefs.Units.Volume, &prefs.RemindersEnabled,
+		&prefs.ReminderTime, &quietHours, &prefs.Privacy, &prefs.Delivery)
+	if err == sql.ErrNoRows {
+		return DefaultPreferences, nil
+	}
+	if err != nil {
+		return DefaultPreferences, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	prefs.Timezone = timezone.String
+	prefs.QuietHours = quietHours.String
+	return prefs, nil
+}
+
+// Set validates and stores one preference for the user
+func (s *PreferencesService) Set(userID, preference, value string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	stored, err := ValidatePreference(preference, value)
+	if err != nil {
+		return err
+	}
+
+	// The column name comes from preferenceColumns, never from user input
+	column := preferenceColumns[preference]
+	logger.DB("Setting preference: user_id=%s, %s=%v", userID, preference, stored)
+	_, err = s.db.Exec(fmt.Sprintf(`
+		INSERT INTO user_preferences (user_id, %[1]s)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			%[1]s = EXCLUDED.%[1]s,
+			updated_at = NOW()
+	`, column), userID, stored)
+	if err != nil {
+		return fmt.Errorf("failed to save preference: %w", err)
+	}
+	if preference == PreferenceTimezone {
+		s.userService.ForgetUser(userID)
+	}
+	return nil
+}
+
+// Reset puts one preference back to its default
+func (s *PreferencesService) Reset(userID, preference string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	column, ok := preferenceColumns[preference]
+	if !ok {
+		return fmt.Errorf("unknown preference %q", preference)
+	}
+
+	logger.DB("Resetting preference: user_id=%s, preference=%s", userID, preference)
+	if _, err := s.db.Exec(fmt.Sprintf(`
+		UPDATE user_preferences SET %[1]s = DEFAULT, updated_at = NOW() WHERE user_id = $1
+	`, column), userID); err != nil {
+		return fmt.Errorf("failed to reset preference: %w", err)
+	}
+	if preference == PreferenceTimezone {
+		s.userService.ForgetUser(userID)
+	}
+	return nil
+}
+
+// GetUnits returns the user's units, or DefaultUnits if they haven't chosen
+func (s *PreferencesService) GetUnits(userID string) (Units, error) {
+	prefs, err := s.Get(userID)
+	return prefs.Units, err
+}
+
+// SetUnits stores the user's units
+func (s *PreferencesService) SetUnits(userID string, units Units) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := ValidateUnits(units); err != nil {
+		return err
+	}
+
+	logger.DB("Setting units: user_id=%s, weight=%s, volume=%s", userID, units.Weight, units.Volume)
+	_, err := s.db.Exec(`
+		INSERT INTO user_preferences (user_id, weight_unit, volume_unit)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			weight_unit = EXCLUDED.weight_unit,
+			volume_unit = EXCLUDED.volume_unit,
+			updated_at = NOW()
+	`, userID, units.Weight, units.Volume)
+	if err != nil {
+		return fmt.Errorf("failed to save units: %w", err)
+	}
+	return nil
+}
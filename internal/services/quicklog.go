@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of quick log entries
+const (
+	QuickLogWater   = "water"
+	QuickLogWorkout = "workout"
+)
+
+// MinWorkoutMinutes is the shortest workout the challenge counts (and the database accepts)
+const MinWorkoutMinutes = 30
+
+// QuickLog is one short text entry such as "water +16oz" or "workout 45min run",
+// as sent by phone automations
+type QuickLog struct {
+	Kind    string
+	Amount  float64 // Water amount in Unit; negative removes water
+	Unit    string  // Volume unit for water; empty means the user's preference
+	Minutes int     // Workout length
+	Type    string  // Workout type, e.g. "run"; empty means general
+}
+
+var (
+	quickLogWater   = regexp.MustCompile(`^water\s+([+-]?\d+(?:\.\d+)?)\s*(oz|ounces?|l|liters?|litres?|ml)?$`)
+	quickLogWorkout = regexp.MustCompile(`^(?:workout|exercise)\s+(\d+)\s*(?:m|mins?|minutes?)?(?:\s+([\w -]+))?$`)
+)
+
+// ParseQuickLog parses a quick log entry. Matching ignores case and extra spaces.
+func ParseQuickLog(text string) (QuickLog, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	if match := quickLogWater.FindStringSubmatch(normalized); match != nil {
+		amount, _ := strconv.ParseFloat(match[1], 64)
+		if amount == 0 {
+			return QuickLog{}, fmt.Errorf("water amount can't be zero")
+		}
+
+		entry := QuickLog{Kind: QuickLogWater, Amount: amount}
+		switch {
+		case match[2] == "":
+		case match[2] == "ml":
+			entry.Amount, entry.Unit = amount/1000, VolumeUnitLiters
+		case strings.HasPrefix(match[2], "l"):
+			entry.Unit = VolumeUnitLiters
+		default:
+			entry.Unit = VolumeUnitOunces
+		}
+		return entry, nil
+	}
+
+	if match := quickLogWorkout.FindStringSubmatch(normalized); match != nil {
+		minutes, err := strconv.Atoi(match[1])
+		if err != nil || minutes < MinWorkoutMinutes {
+			return QuickLog{}, fmt.Errorf("workouts must be at least %d minutes", MinWorkoutMinutes)
+		}
+		return QuickLog{Kind: QuickLogWorkout, Minutes: minutes, Type: strings.TrimSpace(match[2])}, nil
+	}
+
+	return QuickLog{}, fmt.Errorf(`couldn't understand %q; try "water +16oz" or "workout 45min"`, text)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Reminder is a nudge owed to a participant who hasn't checked in today
+type Reminder struct {
+	UserID       string
+	Username     string
+	ChallengeDay int
+	Date         time.Time // The user's calendar date the reminder is for
+	Delivery     string    // DeliveryChannel, DeliveryDM, or DeliverySMS
+}
+
+// ReminderService finds participants due a check-in reminder, honouring each
+// one's reminder time, quiet hours, and time zone from user_preferences
+type ReminderService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewReminderService creates a new reminder service; reminder times are read in
+// userService's default zone for users who haven't chosen one
+func NewReminderService(userService *UserService) *ReminderService {
+	return &ReminderService{userService: userService}
+}
+
+// Initialize initializes the service with database connection
+func (s *ReminderService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *ReminderService) Name() string {
+	return "ReminderService"
+}
+
+// Health checks the service health
+func (s *ReminderService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Due returns the reminders owed at now: the participant's reminder time has passed
+// today in their zone, they aren't in quiet hours, haven't checked in for today's
+// challenge day, and haven't already been reminded today. A reminder that falls in
+// quiet hours waits until they end, as long as it's still the same day.
+func (s *ReminderService) Due(now time.Time) ([]Reminder, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	fallback := LoadTimezone(s.userService.DefaultTimezone())
+	// Zones differ per user, so widen the window by a day and narrow it per user below
+	rows, err := s.db.Query(`
+		SELECT u.user_id, u.username, u.challenge_start_date, u.current_challenge_end_date,
+			p.timezone, COALESCE(p.reminder_time, $2), p.quiet_hours,
+			COALESCE(p.delivery, $3), p.last_reminded_on
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		WHERE COALESCE(p.reminders_enabled, TRUE)
+		  AND u.challenge_start_date::date <= $1::date + 1
+		  AND u.current_challenge_end_date::date >= $1::date - 1
+	`, now, DefaultPreferences.ReminderTime, DefaultPreferences.Delivery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminder candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []Reminder
+	for rows.Next() {
+		var reminder Reminder
+		var startDate, endDate time.Time
+		var timezone, quietHours sql.NullString
+		var lastReminded sql.NullTime
+		prefs := DefaultPreferences
+		if err := rows.Scan(&reminder.UserID, &reminder.Username, &startDate, &endDate,
+			&timezone, &prefs.ReminderTime, &quietHours, &reminder.Delivery, &lastReminded); err != nil {
+			logger.Error("Failed to scan reminder candidate row: %v", err)
+			continue
+		}
+		prefs.Timezone = timezone.String
+		prefs.QuietHours = quietHours.String
+
+		local := now.In(prefs.Location(fallback))
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+		if lastReminded.Valid && lastReminded.Time.Format("2006-01-02") == today.Format("2006-01-02") {
+			continue
+		}
+		reminderTime, err := time.Parse("15:04", prefs.ReminderTime)
+		if err != nil || local.Hour()*60+local.Minute() < reminderTime.Hour()*60+reminderTime.Minute() {
+			continue
+		}
+		if prefs.InQuietHours(local) {
+			continue
+		}
+
+		reminder.ChallengeDay = ChallengeDayForDate(startDate, today)
+		if reminder.ChallengeDay < 1 || reminder.ChallengeDay > ChallengeDayForDate(startDate, endDate) {
+			continue
+		}
+		reminder.Date = today
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read reminder candidates: %w", err)
+	}
+
+	// Drop anyone who has already checked in for the day they'd be reminded about
+	due := reminders[:0]
+	for _, reminder := range reminders {
+		var checkedIn bool
+		err := s.db.QueryRow(
+			`SELECT EXISTS (SELECT 1 FROM accountability_checkins WHERE user_id = $1 AND challenge_day = $2)`,
+			reminder.UserID, reminder.ChallengeDay,
+		).Scan(&checkedIn)
+		if err != nil {
+			logger.Error("Failed to check today's check-in for user_id=%s: %v", reminder.UserID, err)
+			continue
+		}
+		if !checkedIn {
+			due = append(due, reminder)
+		}
+	}
+
+	logger.DB("Found %d due reminders", len(due))
+	return due, nil
+}
+
+// MarkSent records that the user was reminded on date, so they aren't reminded again that day
+func (s *ReminderService) MarkSent(userID string, date time.Time) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Marking reminder sent: user_id=%s, date=%s", userID, date.Format("2006-01-02"))
+	_, err := s.db.Exec(`
+		INSERT INTO user_preferences (user_id, last_reminded_on)
+		VALUES ($1, $2::date)
+		ON CONFLICT (user_id) DO UPDATE SET last_reminded_on = EXCLUDED.last_reminded_on
+	`, userID, date.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+	return nil
+}
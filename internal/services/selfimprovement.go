@@ -0,0 +1,254 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// SelfImprovementMinutes is how long a day's self-improvement has to add up to for
+// the feat
+const SelfImprovementMinutes = 30
+
+// MaxSessionDuration is the longest a timed session can count for; anything longer
+// was a timer left running, and isn't logged
+const MaxSessionDuration = 4 * time.Hour
+
+// MaxSessionActivityLength caps a session's activity
+const MaxSessionActivityLength = 100
+
+// Session statuses
+const (
+	SessionRunning = "running"
+	SessionLogged  = "logged"
+	SessionExpired = "expired" // Counted for longer than MaxSessionDuration
+)
+
+var (
+	// ErrSessionRunning is returned when a timer is started while another is running
+	ErrSessionRunning = errors.New("a session is already running")
+	// ErrNoSession is returned when a timer is stopped without one running
+	ErrNoSession = errors.New("no session running")
+)
+
+// SelfImprovementSession is a timed self-improvement session
+type SelfImprovementSession struct {
+	ID           int64
+	ChallengeDay int // The day it started on, which its minutes count toward
+	Date         time.Time
+	ActivityType string
+	StartedAt    time.Time
+	EndedAt      time.Time // Zero while running
+	Minutes      int
+	Status       string
+	CutOff       bool // It was still running when its day ended, so stopped counting at midnight
+	DayMinutes   int  // Minutes logged for its day, this session included
+}
+
+// SelfImprovementService times self-improvement sessions server-side, so members
+// don't have to estimate how long they read after the fact
+type SelfImprovementService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewSelfImprovementService creates a new self-improvement service
+func NewSelfImprovementService(userService *UserService) *SelfImprovementService {
+	return &SelfImprovementService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *SelfImprovementService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *SelfImprovementService) Name() string {
+	return "SelfImprovementService"
+}
+
+// Health checks the service health
+func (s *SelfImprovementService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// StartSession starts timing a session on the user's writable day. A session still
+// running from a day that has since ended is closed first and returned; one running
+// from today returns ErrSessionRunning along with it.
+func (s *SelfImprovementService) StartSession(userID, username, activity string) (SelfImprovementSession, *SelfImprovementSession, error) {
+	if s.db == nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("database not available")
+	}
+	activity = strings.TrimSpace(activity)
+	if activity == "" {
+		activity = "general"
+	}
+	if len(activity) > MaxSessionActivityLength {
+		return SelfImprovementSession{}, nil, fmt.Errorf("activity must be at most %d characters", MaxSessionActivityLength)
+	}
+
+	if err := s.userService.EnsureUserExists(userID, username); err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+	progress, err := s.userService.challengeDates(userID)
+	if err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+	challengeDay, err := ValidateChallengeDay(progress, progress.Date)
+	if err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	defer tx.Rollback()
+
+	var closed *SelfImprovementSession
+	running, err := runningSession(tx, userID)
+	if err != nil && err != ErrNoSession {
+		return SelfImprovementSession{}, nil, err
+	}
+	if err == nil {
+		if running.ChallengeDay == challengeDay {
+			return running, nil, ErrSessionRunning
+		}
+		// Orphaned at the day's rollover
+		if err := closeSession(tx, userID, &running, time.Now(), progress.Date.Location()); err != nil {
+			return SelfImprovementSession{}, nil, err
+		}
+		closed = &running
+	}
+
+	logger.DB("Starting self-improvement session: user_id=%s, challenge_day=%d", userID, challengeDay)
+	session := SelfImprovementSession{ChallengeDay: challengeDay, Date: progress.Date, ActivityType: activity, Status: SessionRunning}
+	err = tx.QueryRow(
+		`INSERT INTO self_improvement_sessions (user_id, challenge_day, completion_date, activity_type)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, started_at`,
+		userID, challengeDay, progress.Date.Format("2006-01-02"), activity,
+	).Scan(&session.ID, &session.StartedAt)
+	if err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return SelfImprovementSession{}, nil, fmt.Errorf("failed to commit session: %w", err)
+	}
+	return session, closed, nil
+}
+
+// StopSession stops the user's running session and logs its minutes toward the day it
+// started on, returning ErrNoSession when there isn't one. Once that day's sessions
+// add up to SelfImprovementMinutes, they fill in the day's self-improvement feat.
+func (s *SelfImprovementService) StopSession(userID string) (SelfImprovementSession, error) {
+	if s.db == nil {
+		return SelfImprovementSession{}, fmt.Errorf("database not available")
+	}
+	progress, err := s.userService.challengeDates(userID)
+	if err != nil {
+		return SelfImprovementSession{}, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return SelfImprovementSession{}, fmt.Errorf("failed to stop session: %w", err)
+	}
+	defer tx.Rollback()
+
+	session, err := runningSession(tx, userID)
+	if err != nil {
+		return SelfImprovementSession{}, err
+	}
+	if err := closeSession(tx, userID, &session, time.Now(), progress.Date.Location()); err != nil {
+		return SelfImprovementSession{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return SelfImprovementSession{}, fmt.Errorf("failed to commit session: %w", err)
+	}
+	return session, nil
+}
+
+// runningSession locks and returns the user's running session, or ErrNoSession
+func runningSession(tx *sql.Tx, userID string) (SelfImprovementSession, error) {
+	var session SelfImprovementSession
+	err := tx.QueryRow(
+		`SELECT id, challenge_day, completion_date, activity_type, started_at, status
+		 FROM self_improvement_sessions
+		 WHERE user_id = $1 AND status = $2
+		 FOR UPDATE`,
+		userID, SessionRunning,
+	).Scan(&session.ID, &session.ChallengeDay, &session.Date, &session.ActivityType, &session.StartedAt, &session.Status)
+	if err == sql.ErrNoRows {
+		return SelfImprovementSession{}, ErrNoSession
+	}
+	if err != nil {
+		return SelfImprovementSession{}, fmt.Errorf("failed to load running session: %w", err)
+	}
+	return session, nil
+}
+
+// closeSession ends session at now, counting it no further than midnight after its day
+// in loc, and logs its minutes. Counting more than MaxSessionDuration expires it instead.
+func closeSession(tx *sql.Tx, userID string, session *SelfImprovementSession, now time.Time, loc *time.Location) error {
+	session.EndedAt = now
+	dayEnd := time.Date(session.Date.Year(), session.Date.Month(), session.Date.Day()+1, 0, 0, 0, 0, loc)
+	counted := session.EndedAt
+	if counted.After(dayEnd) {
+		counted = dayEnd
+		session.CutOff = true
+	}
+	elapsed := counted.Sub(session.StartedAt)
+	session.Status = SessionLogged
+	session.Minutes = int(elapsed.Minutes())
+	if elapsed > MaxSessionDuration {
+		session.Status = SessionExpired
+		session.Minutes = 0
+	}
+
+	logger.DB("Closing self-improvement session: user_id=%s, session_id=%d, minutes=%d, status=%s", userID, session.ID, session.Minutes, session.Status)
+	_, err := tx.Exec(
+		`UPDATE self_improvement_sessions SET ended_at = $2, minutes = $3, status = $4 WHERE id = $1`,
+		session.ID, session.EndedAt, session.Minutes, session.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+
+	err = tx.QueryRow(
+		`SELECT COALESCE(SUM(minutes), 0) FROM self_improvement_sessions
+		 WHERE user_id = $1 AND challenge_day = $2 AND status = $3`,
+		userID, session.ChallengeDay, SessionLogged,
+	).Scan(&session.DayMinutes)
+	if err != nil {
+		return fmt.Errorf("failed to total session minutes: %w", err)
+	}
+	if session.Status != SessionLogged || session.DayMinutes < SelfImprovementMinutes {
+		return nil
+	}
+
+	// A check-in may already have filled the feat in with the minimum
+	_, err = tx.Exec(
+		`INSERT INTO self_improvement_completions (user_id, challenge_day, completion_date, duration_minutes, activity_type, autopopulated)
+		 VALUES ($1, $2, $3, $4, $5, false)
+		 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			duration_minutes = GREATEST(self_improvement_completions.duration_minutes, EXCLUDED.duration_minutes),
+			activity_type = EXCLUDED.activity_type,
+			autopopulated = false`,
+		userID, session.ChallengeDay, session.Date.Format("2006-01-02"), session.DayMinutes, session.ActivityType,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log self-improvement: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"sync"
+	"time"
 )
 
 // Service defines the interface that all services must implement
@@ -17,6 +19,44 @@ type Service interface {
 	Health() error
 }
 
+// ReadRouted is implemented by services that send heavy read queries to a read replica
+type ReadRouted interface {
+	// SetReadDB sets the connection used for read-only queries
+	SetReadDB(db *sql.DB)
+}
+
+// Scheduled is implemented by services that run a background job, so /botstats can show when it last ran
+type Scheduled interface {
+	Service
+	// LastRun returns when the job last finished and the error it returned; zero if it hasn't run
+	LastRun() (time.Time, error)
+}
+
+// jobStatus records a background job's last run; embed it to implement Scheduled
+type jobStatus struct {
+	runMu   sync.Mutex
+	lastRun time.Time
+	lastErr error
+}
+
+// recordRun stores the result of a finished run
+func (j *jobStatus) recordRun(err error) {
+	j.runMu.Lock()
+	defer j.runMu.Unlock()
+	j.lastRun = time.Now()
+	j.lastErr = err
+}
+
+// LastRun returns when the job last finished and the error it returned
+func (j *jobStatus) LastRun() (time.Time, error) {
+	j.runMu.Lock()
+	defer j.runMu.Unlock()
+	return j.lastRun, j.lastErr
+}
+
+// Alerter delivers an operational alert to challenge admins (e.g. the admin channel)
+type Alerter func(message string)
+
 // ServiceRegistry manages all services
 type ServiceRegistry struct {
 	services []Service
@@ -44,6 +84,15 @@ func (sr *ServiceRegistry) InitializeAll(db *sql.DB) error {
 	return nil
 }
 
+// SetReadDB hands the read connection to every service that routes reads separately
+func (sr *ServiceRegistry) SetReadDB(db *sql.DB) {
+	for _, service := range sr.services {
+		if routed, ok := service.(ReadRouted); ok {
+			routed.SetReadDB(db)
+		}
+	}
+}
+
 // GetServices returns all registered services (for handlers to access)
 func (sr *ServiceRegistry) GetServices() []Service {
 	return sr.services
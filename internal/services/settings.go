@@ -0,0 +1,260 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Settings that can be changed per guild without a restart
+const (
+	SettingCheckInChannel = "checkin_channel_id"
+	SettingSummaryChannel = "summary_channel_id"
+	SettingPhotosChannel  = "photos_channel_id"
+	SettingAdminChannel   = "admin_channel_id"
+	SettingForumChannel   = "forum_channel_id"
+	SettingLocale         = "locale"
+	SettingTimezone       = "timezone"
+)
+
+// SettingDescriptions lists every live setting with a short description
+var SettingDescriptions = map[string]string{
+	SettingCheckInChannel: "Channel for the daily check-in message",
+	SettingSummaryChannel: "Channel for active-user rosters and summaries",
+	SettingPhotosChannel:  "Channel for progress photo posts",
+	SettingAdminChannel:   "Channel for health and backup alerts",
+	SettingForumChannel:   "Forum channel for per-user progress posts",
+	SettingLocale:         "Language for bot messages",
+	SettingTimezone:       "Time zone for dates in channel posts and for members who haven't chosen one",
+}
+
+// SettingsService stores per-guild settings and notices when they change, including
+// edits made directly in the database, so they can be applied without a restart
+type SettingsService struct {
+	db           *sql.DB
+	events       *events.Bus
+	pollInterval time.Duration
+	mu           sync.Mutex
+	lastChange   time.Time
+	cache        map[string]map[string]string // guild ID -> settings, for Get
+	stop         chan struct{}
+	done         chan struct{}
+	jobStatus
+}
+
+// NewSettingsService creates a settings service; changes are published on bus
+func NewSettingsService(bus *events.Bus, pollInterval time.Duration) *SettingsService {
+	return &SettingsService{
+		events:       bus,
+		pollInterval: pollInterval,
+		cache:        make(map[string]map[string]string),
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *SettingsService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *SettingsService) Name() string {
+	return "SettingsService"
+}
+
+// Health checks the service health
+func (s *SettingsService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// ValidateSetting checks that value is acceptable for setting
+func ValidateSetting(setting, value string) error {
+	if _, ok := SettingDescriptions[setting]; !ok {
+		return fmt.Errorf("unknown setting %q", setting)
+	}
+	if setting == SettingLocale {
+		if !i18n.IsSupported(value) {
+			return fmt.Errorf("%s must be one of %s, got %q", setting, strings.Join(i18n.Supported(), ", "), value)
+		}
+		return nil
+	}
+	if setting == SettingTimezone {
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("%s must be an IANA time zone such as America/Denver, got %q", setting, value)
+		}
+		return nil
+	}
+	// Every other setting is a channel ID (a Discord snowflake)
+	if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+		return fmt.Errorf("%s must be a channel ID, got %q", setting, value)
+	}
+	return nil
+}
+
+// All returns the guild's explicitly set values
+func (s *SettingsService) All(guildID string) (map[string]string, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`SELECT setting, value FROM guild_settings WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var setting, value string
+		if err := rows.Scan(&setting, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		settings[setting] = value
+	}
+	return settings, rows.Err()
+}
+
+// Get returns one of the guild's explicitly set values. Settings are cached per guild
+// until they change, since this is looked up for every interaction.
+func (s *SettingsService) Get(guildID, setting string) (string, bool, error) {
+	s.mu.Lock()
+	settings, ok := s.cache[guildID]
+	s.mu.Unlock()
+	if !ok {
+		if s.db == nil {
+			return "", false, nil
+		}
+		var err error
+		if settings, err = s.All(guildID); err != nil {
+			return "", false, err
+		}
+		s.mu.Lock()
+		s.cache[guildID] = settings
+		s.mu.Unlock()
+	}
+
+	value, ok := settings[setting]
+	return value, ok, nil
+}
+
+// invalidate drops cached settings so the next Get reloads them
+func (s *SettingsService) invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[string]map[string]string)
+	s.mu.Unlock()
+}
+
+// Set stores a setting for the guild and applies it immediately
+func (s *SettingsService) Set(guildID, setting, value, updatedBy string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := ValidateSetting(setting, value); err != nil {
+		return err
+	}
+
+	logger.DB("Setting guild setting: guild_id=%s, setting=%s, value=%s", guildID, setting, value)
+	_, err := s.db.Exec(`
+		INSERT INTO guild_settings (guild_id, setting, value, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (guild_id, setting) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, guildID, setting, value, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save setting: %w", err)
+	}
+
+	s.invalidate()
+	s.events.Publish(events.SettingsChanged{GuildID: guildID})
+	return nil
+}
+
+// Reset removes a guild override so the environment config applies again
+func (s *SettingsService) Reset(guildID, setting string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Resetting guild setting: guild_id=%s, setting=%s", guildID, setting)
+	if _, err := s.db.Exec(`DELETE FROM guild_settings WHERE guild_id = $1 AND setting = $2`, guildID, setting); err != nil {
+		return fmt.Errorf("failed to reset setting: %w", err)
+	}
+
+	s.invalidate()
+	s.events.Publish(events.SettingsChanged{GuildID: guildID})
+	return nil
+}
+
+// Start polls for settings changed outside the bot (e.g. edited in the database)
+func (s *SettingsService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		s.recordRun(s.checkForChanges())
+		for {
+			select {
+			case <-ticker.C:
+				s.recordRun(s.checkForChanges())
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts change polling
+func (s *SettingsService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done // Let a check in progress finish
+		s.stop = nil
+	}
+}
+
+// checkForChanges publishes SettingsChanged when the newest update is later than last seen.
+// Resets (deletes) are published directly by Reset; deletes made outside the bot are
+// picked up on the next insert/update or restart.
+func (s *SettingsService) checkForChanges() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	var latest sql.NullTime
+	if err := s.db.QueryRow(`SELECT MAX(updated_at) FROM guild_settings`).Scan(&latest); err != nil {
+		logger.Error("Failed to poll guild settings: %v", err)
+		return fmt.Errorf("failed to poll guild settings: %w", err)
+	}
+	if !latest.Valid {
+		return nil
+	}
+
+	s.mu.Lock()
+	changed := !s.lastChange.IsZero() && latest.Time.After(s.lastChange)
+	if latest.Time.After(s.lastChange) {
+		s.lastChange = latest.Time
+	}
+	s.mu.Unlock()
+
+	if changed {
+		s.invalidate()
+		logger.Info("🔄 Guild settings changed - reloading")
+		s.events.Publish(events.SettingsChanged{})
+	}
+	return nil
+}
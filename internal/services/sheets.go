@@ -0,0 +1,417 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/sheets"
+)
+
+// ErrSheetNotFound is returned when a guild hasn't set up a Google Sheet
+var ErrSheetNotFound = errors.New("no google sheet set up")
+
+// spreadsheetIDPattern matches a spreadsheet ID on its own or inside a sheet's URL
+var spreadsheetIDPattern = regexp.MustCompile(`(?:/spreadsheets/d/)?([A-Za-z0-9_-]{20,})`)
+
+// ParseSpreadsheetID accepts a Google Sheets URL or a bare spreadsheet ID
+func ParseSpreadsheetID(raw string) (string, error) {
+	match := spreadsheetIDPattern.FindStringSubmatch(strings.TrimSpace(raw))
+	if match == nil {
+		return "", fmt.Errorf("%q is not a Google Sheets URL or spreadsheet ID", raw)
+	}
+	return match[1], nil
+}
+
+// GuildSheet is the spreadsheet a guild mirrors its challenge data into
+type GuildSheet struct {
+	GuildID       string
+	SpreadsheetID string
+	CreatedBy     string
+	SyncedAt      sql.NullTime
+	LastError     string
+}
+
+// URL links to the spreadsheet
+func (g GuildSheet) URL() string {
+	return "https://docs.google.com/spreadsheets/d/" + g.SpreadsheetID
+}
+
+// SheetService mirrors check-ins, feats, and weigh-ins into each guild's Google Sheet
+// on a schedule, rewriting the bot's tabs in full so edits and deletions carry over
+type SheetService struct {
+	db            *sql.DB
+	client        *sheets.Client
+	interval      time.Duration
+	settings      *SettingsService
+	defaultLocale string
+	stop          chan struct{}
+	done          chan struct{}
+	jobStatus
+}
+
+// NewSheetService creates a new sheet service that syncs on the given interval.
+// defaultLocale names pseudonyms in guilds that haven't chosen a language.
+func NewSheetService(account *sheets.ServiceAccount, interval time.Duration, settings *SettingsService, defaultLocale string) *SheetService {
+	return &SheetService{
+		client:        sheets.NewClient(account),
+		interval:      interval,
+		settings:      settings,
+		defaultLocale: defaultLocale,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *SheetService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *SheetService) Name() string {
+	return "SheetService"
+}
+
+// Health checks the service health
+func (s *SheetService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// ServiceAccountEmail returns the address admins share their spreadsheet with
+func (s *SheetService) ServiceAccountEmail() string {
+	return s.client.Email()
+}
+
+// Set points the guild's mirror at a spreadsheet
+func (s *SheetService) Set(guildID, spreadsheetID, createdBy string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Setting google sheet: guild_id=%s, spreadsheet_id=%s", guildID, spreadsheetID)
+	_, err := s.db.Exec(`
+		INSERT INTO guild_sheets (guild_id, spreadsheet_id, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			spreadsheet_id = EXCLUDED.spreadsheet_id,
+			created_by = EXCLUDED.created_by,
+			created_at = NOW(),
+			synced_at = NULL,
+			last_error = NULL
+	`, guildID, spreadsheetID, createdBy)
+	if err != nil {
+		return fmt.Errorf("failed to save google sheet: %w", err)
+	}
+	return nil
+}
+
+// Clear stops the guild's mirror and reports whether one was set up. The spreadsheet
+// itself keeps the data already written.
+func (s *SheetService) Clear(guildID string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Clearing google sheet: guild_id=%s", guildID)
+	result, err := s.db.Exec(`DELETE FROM guild_sheets WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return false, fmt.Errorf("failed to clear google sheet: %w", err)
+	}
+	cleared, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to clear google sheet: %w", err)
+	}
+	return cleared > 0, nil
+}
+
+// Get returns the guild's sheet, or ErrSheetNotFound
+func (s *SheetService) Get(guildID string) (GuildSheet, error) {
+	if s.db == nil {
+		return GuildSheet{}, fmt.Errorf("database not available")
+	}
+
+	sheet := GuildSheet{GuildID: guildID}
+	var lastError sql.NullString
+	err := s.db.QueryRow(
+		`SELECT spreadsheet_id, created_by, synced_at, last_error FROM guild_sheets WHERE guild_id = $1`,
+		guildID,
+	).Scan(&sheet.SpreadsheetID, &sheet.CreatedBy, &sheet.SyncedAt, &lastError)
+	if err == sql.ErrNoRows {
+		return GuildSheet{}, ErrSheetNotFound
+	}
+	if err != nil {
+		return GuildSheet{}, fmt.Errorf("failed to query google sheet: %w", err)
+	}
+	sheet.LastError = lastError.String
+	return sheet, nil
+}
+
+// Start syncs every guild's sheet immediately and then on every interval
+func (s *SheetService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			err := s.Sync()
+			if err != nil {
+				logger.Error("Google Sheets sync failed: %v", err)
+			}
+			s.recordRun(err)
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the sync job
+func (s *SheetService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done // Let a run in progress finish
+		s.stop = nil
+	}
+}
+
+// Sync rewrites every guild's sheet. One guild failing (e.g. the sheet was unshared)
+// doesn't stop the others; the error reports how many failed.
+func (s *SheetService) Sync() error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`SELECT guild_id FROM guild_sheets`)
+	if err != nil {
+		return fmt.Errorf("failed to query google sheets: %w", err)
+	}
+	var guildIDs []string
+	for rows.Next() {
+		var guildID string
+		if err := rows.Scan(&guildID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan google sheet: %w", err)
+		}
+		guildIDs = append(guildIDs, guildID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query google sheets: %w", err)
+	}
+
+	failed := 0
+	for _, guildID := range guildIDs {
+		if err := s.SyncGuild(guildID); err != nil {
+			logger.Warn("Google Sheets sync failed for guild_id=%s: %v", guildID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d Google Sheets failed to sync", failed, len(guildIDs))
+	}
+	return nil
+}
+
+// SyncGuild rewrites the guild's sheet now, recording the outcome for /config sheet status
+func (s *SheetService) SyncGuild(guildID string) error {
+	sheet, err := s.Get(guildID)
+	if err != nil {
+		return err
+	}
+
+	tabs, err := s.tabs(s.locale(guildID))
+	if err == nil {
+		err = s.client.Replace(sheet.SpreadsheetID, tabs)
+	}
+
+	if err != nil {
+		if _, dbErr := s.db.Exec(`UPDATE guild_sheets SET last_error = $2 WHERE guild_id = $1`, guildID, err.Error()); dbErr != nil {
+			logger.Error("Failed to record google sheet error for guild_id=%s: %v", guildID, dbErr)
+		}
+		return err
+	}
+	if _, err := s.db.Exec(`UPDATE guild_sheets SET synced_at = NOW(), last_error = NULL WHERE guild_id = $1`, guildID); err != nil {
+		return fmt.Errorf("failed to record google sheet sync: %w", err)
+	}
+	return nil
+}
+
+// locale returns the guild's language, which names anonymous members' pseudonyms
+func (s *SheetService) locale(guildID string) string {
+	if s.settings != nil {
+		if locale, ok, err := s.settings.Get(guildID, SettingLocale); err == nil && ok {
+			return locale
+		}
+	}
+	return s.defaultLocale
+}
+
+// tabs builds the Check-ins, Feats, and Weigh-ins tabs. A sheet is shared beyond the
+// bot, so hidden members are left out and anonymous ones appear by pseudonym.
+func (s *SheetService) tabs(locale string) ([]sheets.Tab, error) {
+	checkIns := sheets.Tab{
+		Title: "Check-ins",
+		Rows:  [][]interface{}{{"Member", "Day", "Date", "Checked In At (UTC)"}},
+	}
+	rows, err := s.db.Query(`
+		SELECT u.user_id, u.username, COALESCE(p.privacy, 'public'), a.challenge_day,
+			u.challenge_start_date + (a.challenge_day - 1), a.completed_at
+		FROM accountability_checkins a
+		JOIN users u ON u.user_id = a.user_id
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		WHERE COALESCE(p.privacy, 'public') != $1
+		ORDER BY u.username, a.challenge_day
+	`, PrivacyHidden)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check-ins: %w", err)
+	}
+	for rows.Next() {
+		var userID, username, privacy string
+		var day int
+		var date, completedAt time.Time
+		if err := rows.Scan(&userID, &username, &privacy, &day, &date, &completedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan check-in: %w", err)
+		}
+		checkIns.Rows = append(checkIns.Rows, []interface{}{
+			PublicName(locale, privacy, userID, username), day, date.Format("2006-01-02"),
+			completedAt.UTC().Format("2006-01-02 15:04:05"),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query check-ins: %w", err)
+	}
+
+	feats := sheets.Tab{
+		Title: "Feats",
+		Rows: [][]interface{}{{"Member", "Day", "Date", "Checked In", "Workout (min)", "Core/Mobility (min)",
+			"Diet", "Water (oz)", "Self-Improvement (min)", "Finances", "Steps", "Calories"}},
+	}
+	rows, err = s.db.Query(`
+		WITH days AS (
+			SELECT user_id, challenge_day FROM accountability_checkins
+			UNION SELECT user_id, challenge_day FROM exercise_completions
+			UNION SELECT user_id, challenge_day FROM diet_completions
+			UNION SELECT user_id, challenge_day FROM water_completions
+			UNION SELECT user_id, challenge_day FROM self_improvement_completions
+			UNION SELECT user_id, challenge_day FROM finances_completions
+		)
+		SELECT u.user_id, u.username, COALESCE(p.privacy, 'public'), d.challenge_day,
+			u.challenge_start_date + (d.challenge_day - 1),
+			a.user_id IS NOT NULL, e.workout_duration_minutes, e.core_mobility_duration_minutes,
+			dc.user_id IS NOT NULL, w.amount_ounces, si.duration_minutes, f.compliance_status,
+			st.steps, n.calories
+		FROM days d
+		JOIN users u ON u.user_id = d.user_id
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		LEFT JOIN accountability_checkins a ON a.user_id = d.user_id AND a.challenge_day = d.challenge_day
+		LEFT JOIN exercise_completions e ON e.user_id = d.user_id AND e.challenge_day = d.challenge_day
+		LEFT JOIN diet_completions dc ON dc.user_id = d.user_id AND dc.challenge_day = d.challenge_day
+		LEFT JOIN water_completions w ON w.user_id = d.user_id AND w.challenge_day = d.challenge_day
+		LEFT JOIN self_improvement_completions si ON si.user_id = d.user_id AND si.challenge_day = d.challenge_day
+		LEFT JOIN finances_completions f ON f.user_id = d.user_id AND f.challenge_day = d.challenge_day
+		LEFT JOIN daily_steps st ON st.user_id = d.user_id AND st.challenge_day = d.challenge_day
+		LEFT JOIN daily_nutrition n ON n.user_id = d.user_id AND n.challenge_day = d.challenge_day
+		WHERE COALESCE(p.privacy, 'public') != $1
+		ORDER BY u.username, d.challenge_day
+	`, PrivacyHidden)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feats: %w", err)
+	}
+	for rows.Next() {
+		var userID, username, privacy string
+		var day int
+		var date time.Time
+		var checkedIn, diet bool
+		var workout, core, selfImprovement, steps, calories sql.NullInt64
+		var water sql.NullFloat64
+		var finances sql.NullString
+		if err := rows.Scan(&userID, &username, &privacy, &day, &date, &checkedIn, &workout, &core,
+			&diet, &water, &selfImprovement, &finances, &steps, &calories); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan feats: %w", err)
+		}
+		feats.Rows = append(feats.Rows, []interface{}{
+			PublicName(locale, privacy, userID, username), day, date.Format("2006-01-02"),
+			yesNo(checkedIn), nullInt(workout), nullInt(core), yesNo(diet), nullFloat(water),
+			nullInt(selfImprovement), finances.String, nullInt(steps), nullInt(calories),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query feats: %w", err)
+	}
+
+	weighIns := sheets.Tab{
+		Title: "Weigh-ins",
+		Rows:  [][]interface{}{{"Member", "Day", "Weighed At (UTC)", "Weight (lb)"}},
+	}
+	rows, err = s.db.Query(`
+		SELECT u.user_id, u.username, COALESCE(p.privacy, 'public'), wi.challenge_day, wi.weighed_at, wi.weight_lbs
+		FROM weigh_ins wi
+		JOIN users u ON u.user_id = wi.user_id
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		WHERE COALESCE(p.privacy, 'public') != $1
+		ORDER BY u.username, wi.weighed_at
+	`, PrivacyHidden)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weigh-ins: %w", err)
+	}
+	for rows.Next() {
+		var userID, username, privacy string
+		var day int
+		var weighedAt time.Time
+		var pounds float64
+		if err := rows.Scan(&userID, &username, &privacy, &day, &weighedAt, &pounds); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan weigh-in: %w", err)
+		}
+		weighIns.Rows = append(weighIns.Rows, []interface{}{
+			PublicName(locale, privacy, userID, username), day, weighedAt.UTC().Format("2006-01-02 15:04:05"), pounds,
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query weigh-ins: %w", err)
+	}
+
+	return []sheets.Tab{checkIns, feats, weighIns}, nil
+}
+
+// yesNo renders a completed flag for a sheet cell
+func yesNo(done bool) string {
+	if done {
+		return "Yes"
+	}
+	return "No"
+}
+
+// nullInt renders a nullable number for a sheet cell, blank when missing
+func nullInt(value sql.NullInt64) interface{} {
+	if !value.Valid {
+		return ""
+	}
+	return value.Int64
+}
+
+// nullFloat renders a nullable number for a sheet cell, blank when missing
+func nullFloat(value sql.NullFloat64) interface{} {
+	if !value.Valid {
+		return ""
+	}
+	return value.Float64
+}
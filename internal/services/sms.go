@@ -0,0 +1,206 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/i18n"
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/twilio"
+)
+
+var (
+	// ErrSMSNotFound is returned when the user hasn't added a phone number
+	ErrSMSNotFound = errors.New("no phone number added")
+	// ErrSMSNotVerified is returned when texting a number that hasn't been verified
+	ErrSMSNotVerified = errors.New("phone number not verified")
+)
+
+// SMSService stores members' phone numbers and texts them check-in reminders. A
+// number only receives reminders once its owner enters the code texted to it, so
+// nobody can sign up someone else's phone.
+type SMSService struct {
+	db     *sql.DB
+	client *twilio.Client
+}
+
+// NewSMSService creates a new SMS service
+func NewSMSService(client *twilio.Client) *SMSService {
+	return &SMSService{
+		client: client,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *SMSService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *SMSService) Name() string {
+	return "SMSService"
+}
+
+// Health checks the service health
+func (s *SMSService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Add stores the user's phone number, replacing any earlier one, and texts it a
+// verification code in locale. Returns the number in E.164 form. The user must
+// already exist.
+func (s *SMSService) Add(userID, number, locale string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	number, err := twilio.NormalizeNumber(number)
+	if err != nil {
+		return "", err
+	}
+
+	var lastSent sql.NullTime
+	err = s.db.QueryRow(`SELECT code_sent_at FROM sms_numbers WHERE user_id = $1`, userID).Scan(&lastSent)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to load phone number: %w", err)
+	}
+	if lastSent.Valid && time.Since(lastSent.Time) < verificationResendInterval {
+		return "", ErrVerificationTooSoon
+	}
+
+	code, err := newVerificationCode()
+	if err != nil {
+		return "", err
+	}
+
+	logger.DB("Adding phone number for user_id=%s", userID)
+	_, err = s.db.Exec(`
+		INSERT INTO sms_numbers (user_id, phone_number, code_hash, code_sent_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			phone_number = EXCLUDED.phone_number,
+			code_hash = EXCLUDED.code_hash,
+			code_sent_at = EXCLUDED.code_sent_at,
+			attempts = 0,
+			verified_at = NULL,
+			created_at = NOW()
+	`, userID, number, hashVerificationCode(code))
+	if err != nil {
+		return "", fmt.Errorf("failed to save phone number: %w", err)
+	}
+
+	if err := s.client.Send(number, i18n.T(locale, "sms.code", code, int(VerificationCodeExpiry.Minutes()))); err != nil {
+		return "", err
+	}
+	return number, nil
+}
+
+// Verify checks the code texted by Add. A correct code verifies the number and
+// switches the user's reminder delivery to SMS.
+func (s *SMSService) Verify(userID, code string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var codeHash sql.NullString
+	var sentAt sql.NullTime
+	var attempts int
+	err := s.db.QueryRow(
+		`SELECT code_hash, code_sent_at, attempts FROM sms_numbers WHERE user_id = $1`,
+		userID,
+	).Scan(&codeHash, &sentAt, &attempts)
+	if err == sql.ErrNoRows {
+		return ErrSMSNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load phone number: %w", err)
+	}
+	err = checkVerificationCode(code, codeHash.String, sentAt.Time, attempts)
+	if errors.Is(err, ErrVerificationCodeInvalid) {
+		if _, err := s.db.Exec(`UPDATE sms_numbers SET attempts = attempts + 1 WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to record verification attempt: %w", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	logger.DB("Verifying phone number for user_id=%s", userID)
+	if _, err := tx.Exec(
+		`UPDATE sms_numbers SET verified_at = NOW(), code_hash = NULL, attempts = 0 WHERE user_id = $1`,
+		userID,
+	); err != nil {
+		return fmt.Errorf("failed to verify phone number: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO user_preferences (user_id, delivery)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET delivery = EXCLUDED.delivery
+	`, userID, DeliverySMS); err != nil {
+		return fmt.Errorf("failed to switch reminders to SMS: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Remove deletes the user's phone number and reports whether they had one. Reminders
+// that went by SMS go back to the check-in channel.
+func (s *SMSService) Remove(userID string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	logger.DB("Removing phone number for user_id=%s", userID)
+	result, err := tx.Exec(`DELETE FROM sms_numbers WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove phone number: %w", err)
+	}
+	if _, err := tx.Exec(
+		`UPDATE user_preferences SET delivery = $2 WHERE user_id = $1 AND delivery = $3`,
+		userID, DeliveryChannel, DeliverySMS,
+	); err != nil {
+		return false, fmt.Errorf("failed to switch reminders off SMS: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit removal: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Send texts body to the user's verified number, or returns ErrSMSNotVerified
+func (s *SMSService) Send(userID, body string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var number string
+	err := s.db.QueryRow(
+		`SELECT phone_number FROM sms_numbers WHERE user_id = $1 AND verified_at IS NOT NULL`,
+		userID,
+	).Scan(&number)
+	if err == sql.ErrNoRows {
+		return ErrSMSNotVerified
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load phone number: %w", err)
+	}
+	return s.client.Send(number, body)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// StepService stores daily step counts synced from linked accounts
+type StepService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewStepService creates a new step service
+func NewStepService(userService *UserService) *StepService {
+	return &StepService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *StepService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *StepService) Name() string {
+	return "StepService"
+}
+
+// Health checks the service health
+func (s *StepService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// SyncSteps stores the step count a provider reports for date, replacing the last
+// sync since the provider's count for a day only grows. Days outside the user's
+// challenge or after today are skipped; returns whether the count was stored.
+func (s *StepService) SyncSteps(userID string, date time.Time, steps int, source string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return false, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, date)
+	if err != nil {
+		return false, nil
+	}
+
+	logger.DB("Syncing steps: user_id=%s, challenge_day=%d, steps=%d, source=%s", userID, challengeDay, steps, source)
+	_, err = s.db.Exec(`
+		INSERT INTO daily_steps (user_id, challenge_day, completion_date, steps, source)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			completion_date = EXCLUDED.completion_date,
+			steps = EXCLUDED.steps,
+			source = EXCLUDED.source,
+			synced_at = NOW()
+	`, userID, challengeDay, date.Format("2006-01-02"), steps, source)
+	if err != nil {
+		return false, fmt.Errorf("failed to sync steps: %w", err)
+	}
+	return true, nil
+}
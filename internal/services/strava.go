@@ -0,0 +1,203 @@
+package services
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/strava"
+)
+
+// Paths the HTTP server serves for Strava, under PUBLIC_URL
+const (
+	StravaCallbackPath = "/oauth/strava/callback"
+	StravaEventsPath   = "/hooks/strava"
+)
+
+// StravaService links Strava accounts and imports their activities as workouts
+type StravaService struct {
+	db          *sql.DB
+	client      *strava.Client
+	publicURL   string
+	verifyToken string
+	connections *ConnectionService
+	exercise    *ExerciseService
+}
+
+// NewStravaService creates a new Strava service. publicURL is where the HTTP server
+// is reachable from the internet.
+func NewStravaService(clientID, clientSecret, publicURL string, connections *ConnectionService, exercise *ExerciseService) *StravaService {
+	// Strava echoes the token back only while the subscription is being created,
+	// which happens in this process, so a fresh one per run is enough
+	raw := make([]byte, 16)
+	rand.Read(raw)
+
+	return &StravaService{
+		client:      strava.NewClient(clientID, clientSecret),
+		publicURL:   publicURL,
+		verifyToken: hex.EncodeToString(raw),
+		connections: connections,
+		exercise:    exercise,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *StravaService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *StravaService) Name() string {
+	return "StravaService"
+}
+
+// Health checks the service health
+func (s *StravaService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Provider returns the provider name
+func (s *StravaService) Provider() string {
+	return ProviderStrava
+}
+
+// VerifyToken is the token Strava sends when confirming the push subscription
+func (s *StravaService) VerifyToken() string {
+	return s.verifyToken
+}
+
+// EnsureSubscription registers the bot's event endpoint with Strava. Call it once the
+// HTTP server is listening, since Strava calls the endpoint to confirm it.
+func (s *StravaService) EnsureSubscription() error {
+	return s.client.EnsureSubscription(s.publicURL+StravaEventsPath, s.verifyToken)
+}
+
+// AuthorizeURL starts linking the user's Strava account
+func (s *StravaService) AuthorizeURL(userID string) (string, error) {
+	state, err := s.connections.NewState(userID, ProviderStrava)
+	if err != nil {
+		return "", err
+	}
+	return s.client.AuthorizeURL(s.publicURL+StravaCallbackPath, state), nil
+}
+
+// Complete finishes linking with the code from the OAuth callback and returns the user
+func (s *StravaService) Complete(state, code string) (string, error) {
+	userID, err := s.connections.ConsumeState(state, ProviderStrava)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := s.client.Exchange(code)
+	if err != nil {
+		return "", err
+	}
+	err = s.connections.Save(Connection{
+		UserID:       userID,
+		Provider:     ProviderStrava,
+		ExternalID:   token.AthleteID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	logger.Info("Strava athlete %s linked to user_id=%s", token.AthleteID, userID)
+	return userID, nil
+}
+
+// Disconnect unlinks the user's Strava account, revoking the bot's access at Strava
+// too, and reports whether one was linked
+func (s *StravaService) Disconnect(userID string) (bool, error) {
+	conn, err := s.connections.Get(userID, ProviderStrava)
+	if errors.Is(err, ErrConnectionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Unlinking locally matters more than telling Strava, so a failure here only warns
+	if token, err := s.accessToken(conn); err != nil {
+		logger.Warn("Failed to refresh Strava token to deauthorize user_id=%s: %v", userID, err)
+	} else if err := s.client.Deauthorize(token); err != nil {
+		logger.Warn("Failed to deauthorize Strava for user_id=%s: %v", userID, err)
+	}
+	return s.connections.Delete(userID, ProviderStrava)
+}
+
+// HandleEvent processes one push subscription event: new activities are imported as
+// workouts, and revoked access unlinks the account
+func (s *StravaService) HandleEvent(event strava.Event) error {
+	conn, err := s.connections.ByExternalID(ProviderStrava, strconv.FormatInt(event.OwnerID, 10))
+	if errors.Is(err, ErrConnectionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if event.Deauthorized() {
+		logger.Info("Strava access revoked by user_id=%s, unlinking", conn.UserID)
+		_, err := s.connections.Delete(conn.UserID, ProviderStrava)
+		return err
+	}
+	if event.ObjectType != "activity" || event.AspectType != "create" {
+		return nil
+	}
+
+	token, err := s.accessToken(conn)
+	if err != nil {
+		return err
+	}
+	activity, err := s.client.Activity(token, event.ObjectID)
+	if err != nil {
+		return err
+	}
+	if activity.Minutes() < MinWorkoutMinutes {
+		logger.Info("Skipping %d min Strava activity %d for user_id=%s", activity.Minutes(), activity.ID, conn.UserID)
+		return nil
+	}
+
+	location := "indoor"
+	if activity.Outdoor() {
+		location = "outdoor"
+	}
+	challengeDay, recorded, err := s.exercise.ImportWorkout(conn.UserID, activity.Date(), activity.Minutes(),
+		activity.WorkoutType(), location, ProviderStrava, strconv.FormatInt(activity.ID, 10))
+	if err != nil {
+		return err
+	}
+	if recorded {
+		logger.Info("Imported %d min %s from Strava activity %d for user_id=%s, challenge_day=%d",
+			activity.Minutes(), activity.WorkoutType(), activity.ID, conn.UserID, challengeDay)
+	}
+	return nil
+}
+
+// accessToken returns a usable access token, refreshing it when it's about to expire
+func (s *StravaService) accessToken(conn Connection) (string, error) {
+	if time.Until(conn.ExpiresAt) > time.Minute {
+		return conn.AccessToken, nil
+	}
+
+	token, err := s.client.Refresh(conn.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	conn.AccessToken, conn.RefreshToken, conn.ExpiresAt = token.AccessToken, token.RefreshToken, token.ExpiresAt
+	if err := s.connections.UpdateTokens(conn); err != nil {
+		return "", err
+	}
+	return conn.AccessToken, nil
+}
@@ -1,22 +1,29 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/75-hard-discord-bot/internal/discord/ui"
+	"github.com/75-hard-discord-bot/internal/i18n"
 	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/75-hard-discord-bot/internal/tracing"
 )
 
 // SummaryService handles summary-related operations
 type SummaryService struct {
-	db *sql.DB
+	db          *sql.DB
+	readDB      *sql.DB
+	userService *UserService
 }
 
-// NewSummaryService creates a new summary service
-func NewSummaryService() *SummaryService {
-	return &SummaryService{}
+// NewSummaryService creates a new summary service; userService gives the zone of
+// users who haven't chosen one
+func NewSummaryService(userService *UserService) *SummaryService {
+	return &SummaryService{userService: userService}
 }
 
 // Initialize initializes the service with database connection
@@ -25,6 +32,19 @@ func (s *SummaryService) Initialize(db *sql.DB) error {
 	return nil
 }
 
+// SetReadDB sets the connection used for summary queries
+func (s *SummaryService) SetReadDB(db *sql.DB) {
+	s.readDB = db
+}
+
+// reader returns the read replica if configured, otherwise the primary
+func (s *SummaryService) reader() *sql.DB {
+	if s.readDB != nil {
+		return s.readDB
+	}
+	return s.db
+}
+
 // Name returns the service name
 func (s *SummaryService) Name() string {
 	return "SummaryService"
@@ -38,80 +58,99 @@ func (s *SummaryService) Health() error {
 	return s.db.Ping()
 }
 
-// GetProgressSummary returns a formatted progress summary
-func (s *SummaryService) GetProgressSummary(targetUsername string) (string, error) {
+// GetProgressSummary returns a progress summary formatted in locale for viewerID to read.
+// Queries are traced under the span in ctx.
+func (s *SummaryService) GetProgressSummary(ctx context.Context, locale, targetUsername, viewerID string) (string, error) {
+	ctx, span := tracing.StartChild(ctx, "SummaryService.GetProgressSummary", tracing.KindInternal,
+		"summary.all_users", targetUsername == "")
+	defer span.End()
+
+	var summary string
+	var err error
 	if targetUsername == "" {
-		return s.GetAllUsersSummary()
+		summary, err = s.GetAllUsersSummary(ctx, locale)
+	} else {
+		summary, err = s.GetUserSummary(ctx, locale, targetUsername, viewerID)
 	}
-	return s.GetUserSummary(targetUsername)
+	span.RecordError(err)
+	return summary, err
 }
 
-// GetAllUsersSummary returns summary for all users
-func (s *SummaryService) GetAllUsersSummary() (string, error) {
+// GetAllUsersSummary returns summary for all users, pseudonymizing anonymous users and
+// leaving out hidden ones
+func (s *SummaryService) GetAllUsersSummary(ctx context.Context, locale string) (string, error) {
 	if s.db == nil {
 		return "", fmt.Errorf("database not available")
 	}
 
-	// Count distinct challenge days completed (using check-ins as the source of truth)
+	// Days completed come from the rollup maintained by LeaderboardService
 	query := `
 		SELECT 
 			u.user_id,
 			u.username,
+			COALESCE(p.privacy, 'public'),
 			u.challenge_start_date,
 			u.current_challenge_end_date,
 			u.days_added,
-			COUNT(DISTINCT CASE WHEN a.challenge_day >= 1 AND a.challenge_day <= GREATEST(1, (CURRENT_DATE::date - u.challenge_start_date::date) + 1) THEN a.challenge_day END) as days_completed
+			COALESCE(p.timezone, $1),
+			COALESCE(r.days_completed, 0) as days_completed
 		FROM users u
-		LEFT JOIN accountability_checkins a ON a.user_id = u.user_id
-		GROUP BY u.user_id, u.username, u.challenge_start_date, u.current_challenge_end_date, u.days_added
+		LEFT JOIN user_progress_rollup r ON r.user_id = u.user_id
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		WHERE COALESCE(p.privacy, 'public') <> 'hidden'
 		ORDER BY days_completed DESC, u.username
 	`
 
 	logger.DB("Querying summary for all users")
-	rows, err := s.db.Query(query)
+	rows, err := s.reader().QueryContext(ctx, query, s.userService.DefaultTimezone())
 	if err != nil {
 		logger.Error("Failed to query users: %v", err)
 		return "", fmt.Errorf("failed to query users: %w", err)
 	}
 	defer rows.Close()
 
+	title := i18n.T(locale, "summary.all_title")
 	var summary strings.Builder
-	summary.WriteString("📊 **Challenge Progress Summary (All Users)**\n\n")
+	summary.WriteString(title)
 
 	for rows.Next() {
-		var userID, username string
+		var userID, username, privacy, timezone string
 		var startDate, endDate time.Time
 		var daysAdded int
 		var daysCompleted sql.NullInt64
 
-		err := rows.Scan(&userID, &username, &startDate, &endDate, &daysAdded, &daysCompleted)
+		err := rows.Scan(&userID, &username, &privacy, &startDate, &endDate, &daysAdded, &timezone, &daysCompleted)
 		if err != nil {
 			return "", fmt.Errorf("failed to scan user row: %w", err)
 		}
 
-		totalDays := int(endDate.Sub(startDate).Hours() / 24)
-		currentDay := int(time.Since(startDate).Hours()/24) + 1
+		totalDays := CalendarDaysBetween(startDate, endDate)
+		currentDay := CurrentChallengeDay(startDate, LoadTimezone(timezone))
+		if currentDay < 1 {
+			currentDay = 1
+		}
 		if currentDay > totalDays {
 			currentDay = totalDays
 		}
 
-		summary.WriteString(fmt.Sprintf("**%s** (Day %d/%d", username, currentDay, totalDays))
+		summary.WriteString(i18n.T(locale, "summary.all_user", PublicName(locale, privacy, userID, username), currentDay, totalDays))
 		if daysAdded > 0 {
 			summary.WriteString(fmt.Sprintf(" +%d", daysAdded))
 		}
 		summary.WriteString(")\n")
-		summary.WriteString(fmt.Sprintf("  ✅ Days Completed: %d\n\n", daysCompleted.Int64))
+		summary.WriteString(i18n.T(locale, "summary.all_days_completed", daysCompleted.Int64))
 	}
 
-	if summary.Len() == len("📊 **Challenge Progress Summary (All Users)**\n\n") {
-		summary.WriteString("No users found.")
+	if summary.Len() == len(title) {
+		summary.WriteString(i18n.T(locale, "summary.no_users"))
 	}
 
 	return summary.String(), nil
 }
 
-// GetUserSummary returns summary for a specific user
-func (s *SummaryService) GetUserSummary(username string) (string, error) {
+// GetUserSummary returns summary for a specific user. Users who aren't public are
+// only summarized for themselves; anyone else is told they weren't found.
+func (s *SummaryService) GetUserSummary(ctx context.Context, locale, username, viewerID string) (string, error) {
 	if s.db == nil {
 		return "", fmt.Errorf("database not available")
 	}
@@ -123,48 +162,279 @@ func (s *SummaryService) GetUserSummary(username string) (string, error) {
 			u.challenge_start_date,
 			u.current_challenge_end_date,
 			u.days_added,
-			COUNT(DISTINCT CASE WHEN a.challenge_day >= 1 AND a.challenge_day <= GREATEST(1, (CURRENT_DATE::date - u.challenge_start_date::date) + 1) THEN a.challenge_day END) as days_completed
+			COALESCE(p.timezone, $2),
+			COALESCE(p.privacy, 'public'),
+			COUNT(DISTINCT CASE WHEN a.challenge_day >= 1 AND a.challenge_day <= GREATEST(1, ((NOW() AT TIME ZONE COALESCE(p.timezone, $2))::date - u.challenge_start_date::date) + 1) THEN a.challenge_day END) as days_completed
 		FROM users u
 		LEFT JOIN accountability_checkins a ON a.user_id = u.user_id
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
 		WHERE LOWER(u.username) = LOWER($1)
-		GROUP BY u.user_id, u.username, u.challenge_start_date, u.current_challenge_end_date, u.days_added
+		GROUP BY u.user_id, u.username, u.challenge_start_date, u.current_challenge_end_date, u.days_added, p.timezone, p.privacy
 	`
 
 	logger.DB("Querying summary for user: %s", username)
 	var userID, dbUsername string
 	var startDate, endDate time.Time
 	var daysAdded int
+	var timezone, privacy string
 	var daysCompleted sql.NullInt64
 
-	err := s.db.QueryRow(query, username).Scan(&userID, &dbUsername, &startDate, &endDate, &daysAdded, &daysCompleted)
-	if err == sql.ErrNoRows {
+	err := s.reader().QueryRowContext(ctx, query, username, s.userService.DefaultTimezone()).Scan(&userID, &dbUsername, &startDate, &endDate, &daysAdded, &timezone, &privacy, &daysCompleted)
+	if err == sql.ErrNoRows || (err == nil && privacy != PrivacyPublic && userID != viewerID) {
 		logger.DB("User not found: %s", username)
-		return fmt.Sprintf("❌ User '%s' not found.", username), nil
+		return i18n.T(locale, "summary.user_not_found", username), nil
 	}
 	if err != nil {
 		logger.Error("Failed to query user: %v", err)
 		return "", fmt.Errorf("failed to query user: %w", err)
 	}
 
-	totalDays := int(endDate.Sub(startDate).Hours() / 24)
-	currentDay := int(time.Since(startDate).Hours()/24) + 1
+	totalDays := CalendarDaysBetween(startDate, endDate)
+	currentDay := CurrentChallengeDay(startDate, LoadTimezone(timezone))
+	if currentDay < 1 {
+		currentDay = 1
+	}
 	if currentDay > totalDays {
 		currentDay = totalDays
 	}
 
 	var summary strings.Builder
-	summary.WriteString(fmt.Sprintf("📊 **Challenge Progress Summary: %s**\n\n", dbUsername))
-	summary.WriteString(fmt.Sprintf("**Challenge:** Day %d/%d", currentDay, totalDays))
+	summary.WriteString(i18n.T(locale, "summary.user_title", dbUsername))
+	summary.WriteString(i18n.T(locale, "summary.user_challenge_day", currentDay, totalDays))
 	if daysAdded > 0 {
-		summary.WriteString(fmt.Sprintf(" (+%d days added)", daysAdded))
+		summary.WriteString(i18n.T(locale, "summary.user_days_added", daysAdded))
+	}
+	// A day on the user's own calendar, so labelled with their zone
+	summary.WriteString(i18n.T(locale, "summary.user_started", i18n.FormatDayIn(locale, startDate, LoadTimezone(timezone))))
+
+	summary.WriteString(i18n.T(locale, "summary.user_days_completed", daysCompleted.Int64))
+
+	// Synced calories appear in members' own summaries and weekly recaps, not when
+	// others look them up
+	if userID == viewerID {
+		var calories int
+		var source string
+		err := s.reader().QueryRowContext(ctx,
+			`SELECT calories, source FROM daily_nutrition WHERE user_id = $1 AND challenge_day = $2`,
+			userID, currentDay,
+		).Scan(&calories, &source)
+		if err == nil {
+			summary.WriteString(i18n.T(locale, "summary.user_calories", calories, i18n.T(locale, "service."+source)))
+		} else if err != sql.ErrNoRows {
+			logger.Warn("Failed to query calories for user_id=%s: %v", userID, err)
+		}
 	}
-	summary.WriteString(fmt.Sprintf("\n**Started:** %s\n\n", startDate.Format("January 2, 2006")))
 
-	summary.WriteString(fmt.Sprintf("**Days Completed:** %d\n", daysCompleted.Int64))
+	summary.WriteString(i18n.T(locale, "summary.user_progress", ui.ProgressBar(int(daysCompleted.Int64), totalDays, ui.DefaultBarWidth)))
 
-	// Calculate completion percentage
-	completionRate := float64(daysCompleted.Int64) / float64(totalDays) * 100
-	summary.WriteString(fmt.Sprintf("\n**Progress:** %.1f%% (%d/%d days)", completionRate, daysCompleted.Int64, totalDays))
+	books, err := s.finishedBooks(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to query finished books for user_id=%s: %v", userID, err)
+	}
+	if len(books) > 0 {
+		summary.WriteString(i18n.T(locale, "summary.user_books", len(books)))
+		for _, book := range books {
+			summary.WriteString(i18n.T(locale, "summary.user_book", book.Title, book.TotalPages))
+		}
+	}
 
 	return summary.String(), nil
 }
+
+// finishedBooks returns the books the user has finished, in the order they finished them
+func (s *SummaryService) finishedBooks(ctx context.Context, userID string) ([]Book, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT title, total_pages FROM books
+		 WHERE user_id = $1 AND status = $2
+		 ORDER BY finished_at, id`,
+		userID, BookFinished,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []Book
+	for rows.Next() {
+		book := Book{Status: BookFinished}
+		if err := rows.Scan(&book.Title, &book.TotalPages); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	return books, rows.Err()
+}
+
+// CompletionFeats names the feats in DayCompletion.Done, in check-in order
+var CompletionFeats = []string{"checkin", "exercise", "diet", "water", "reading", "finances"}
+
+// featTables are the tables recording each of CompletionFeats
+var featTables = []string{
+	"accountability_checkins",
+	"exercise_completions",
+	"diet_completions",
+	"water_completions",
+	"self_improvement_completions",
+	"finances_completions",
+}
+
+// featDone adds the condition a row in one of featTables must meet to count as done;
+// a finances row stays after a purchase that wasn't necessary, marked non-compliant
+var featDone = map[string]string{
+	"finances_completions": " AND compliance_status = '" + FinancesCompliant + "'",
+}
+
+// DayCompletion is which feats a member completed on one challenge day
+type DayCompletion struct {
+	Date time.Time
+	Day  int
+	Done []bool // One per CompletionFeats entry
+}
+
+// WeighIn is one weigh-in in the member's units, dated in their zone
+type WeighIn struct {
+	Date   time.Time
+	Weight float64
+}
+
+// GetCompletions returns the user's completions for challenge days firstDay through
+// lastDay, in day order. startDate dates the days, as in Progress.
+func (s *SummaryService) GetCompletions(userID string, startDate time.Time, firstDay, lastDay int) ([]DayCompletion, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var days []DayCompletion
+	for day := firstDay; day <= lastDay; day++ {
+		days = append(days, DayCompletion{
+			Date: startDate.AddDate(0, 0, day-1),
+			Day:  day,
+			Done: make([]bool, len(CompletionFeats)),
+		})
+	}
+	for feat, table := range featTables {
+		rows, err := s.reader().Query(fmt.Sprintf(
+			`SELECT challenge_day FROM %s WHERE user_id = $1 AND challenge_day BETWEEN $2 AND $3%s`, table, featDone[table]),
+			userID, firstDay, lastDay,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", table, err)
+		}
+		for rows.Next() {
+			var day int
+			if err := rows.Scan(&day); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+			}
+			days[day-firstDay].Done[feat] = true
+		}
+		rows.Close()
+	}
+	return days, nil
+}
+
+// GetStreaks returns the user's current and longest runs of consecutive check-ins as
+// of challenge day today
+func (s *SummaryService) GetStreaks(userID string, today int) (int, int, error) {
+	if s.db == nil {
+		return 0, 0, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.reader().Query(
+		`SELECT challenge_day FROM accountability_checkins
+		 WHERE user_id = $1 AND challenge_day BETWEEN 1 AND $2
+		 ORDER BY challenge_day`,
+		userID, today,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query check-ins: %w", err)
+	}
+	defer rows.Close()
+
+	current, longest, previous := 0, 0, 0
+	for rows.Next() {
+		var day int
+		if err := rows.Scan(&day); err != nil {
+			return 0, 0, fmt.Errorf("failed to scan check-in: %w", err)
+		}
+		if day == previous+1 {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+		previous = day
+	}
+	// Today's check-in may still be coming, so a streak through yesterday is still going
+	if previous < today-1 {
+		current = 0
+	}
+	return current, longest, rows.Err()
+}
+
+// GetWeighIns returns the user's latest weigh-ins, at most limit, oldest first
+func (s *SummaryService) GetWeighIns(userID string, limit int, prefs Preferences) ([]WeighIn, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.reader().Query(
+		`SELECT weighed_at, weight_lbs FROM weigh_ins
+		 WHERE user_id = $1
+		 ORDER BY weighed_at DESC
+		 LIMIT $2`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weigh-ins: %w", err)
+	}
+	defer rows.Close()
+
+	var weighIns []WeighIn
+	for rows.Next() {
+		var weighedAt time.Time
+		var pounds float64
+		if err := rows.Scan(&weighedAt, &pounds); err != nil {
+			return nil, fmt.Errorf("failed to scan weigh-in: %w", err)
+		}
+		weighIns = append([]WeighIn{{
+			Date:   weighedAt.In(prefs.Location(LoadTimezone(s.userService.DefaultTimezone()))),
+			Weight: prefs.Units.FromPounds(pounds),
+		}}, weighIns...)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read weigh-ins: %w", err)
+	}
+	return weighIns, nil
+}
+
+// GetWaterOunces returns the user's water total for each of challenge days firstDay
+// through lastDay that has any, keyed by day
+func (s *SummaryService) GetWaterOunces(userID string, firstDay, lastDay int) (map[int]float64, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.reader().Query(
+		`SELECT challenge_day, COALESCE(amount_ounces, 0) FROM water_completions
+		 WHERE user_id = $1 AND challenge_day BETWEEN $2 AND $3`,
+		userID, firstDay, lastDay,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query water: %w", err)
+	}
+	defer rows.Close()
+
+	ounces := make(map[int]float64)
+	for rows.Next() {
+		var day int
+		var amount float64
+		if err := rows.Scan(&day, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan water: %w", err)
+		}
+		ounces[day] = amount
+	}
+	return ounces, rows.Err()
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// Messages admins can override per guild with /config template
+const (
+	TemplateCheckIn           = "checkin"
+	TemplateDayComplete       = "day_complete"
+	TemplateChallengeComplete = "challenge_complete"
+)
+
+// TemplateVariables lists every template and the {variable} placeholders it can use
+var TemplateVariables = map[string][]string{
+	TemplateCheckIn:           {"date"},
+	TemplateDayComplete:       {"user", "day", "date"},
+	TemplateChallengeComplete: {"user", "day", "date"},
+}
+
+// MaxTemplateLength leaves room under Discord's 2000 character limit for the
+// check-in header and rendered variables
+const MaxTemplateLength = 1500
+
+// templateVariable matches a {variable} placeholder
+var templateVariable = regexp.MustCompile(`\{(\w+)\}`)
+
+// ValidateTemplate checks that body is a usable override for template
+func ValidateTemplate(template, body string) error {
+	variables, ok := TemplateVariables[template]
+	if !ok {
+		return fmt.Errorf("unknown template %q", template)
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("template text is empty")
+	}
+	if len(body) > MaxTemplateLength {
+		return fmt.Errorf("template text is %d characters, the limit is %d", len(body), MaxTemplateLength)
+	}
+
+	allowed := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		allowed[variable] = true
+	}
+	for _, match := range templateVariable.FindAllStringSubmatch(body, -1) {
+		if !allowed[match[1]] {
+			return fmt.Errorf("unknown variable {%s}; %s can use %s", match[1], template, FormatTemplateVariables(template))
+		}
+	}
+	return nil
+}
+
+// FormatTemplateVariables lists a template's placeholders, e.g. "{user}, {day}, {date}"
+func FormatTemplateVariables(template string) string {
+	variables := TemplateVariables[template]
+	formatted := make([]string, len(variables))
+	for idx, variable := range variables {
+		formatted[idx] = "{" + variable + "}"
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// RenderTemplate fills body's placeholders from vars; unknown placeholders are left as written
+func RenderTemplate(body string, vars map[string]string) string {
+	return templateVariable.ReplaceAllStringFunc(body, func(placeholder string) string {
+		if value, ok := vars[placeholder[1:len(placeholder)-1]]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// TemplateNames returns every template name, sorted
+func TemplateNames() []string {
+	names := make([]string, 0, len(TemplateVariables))
+	for name := range TemplateVariables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplateService stores per-guild message template overrides
+type TemplateService struct {
+	db *sql.DB
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService() *TemplateService {
+	return &TemplateService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *TemplateService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *TemplateService) Name() string {
+	return "TemplateService"
+}
+
+// Health checks the service health
+func (s *TemplateService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// All returns the guild's overridden templates
+func (s *TemplateService) All(guildID string) (map[string]string, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`SELECT template, body FROM guild_message_templates WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make(map[string]string)
+	for rows.Next() {
+		var template, body string
+		if err := rows.Scan(&template, &body); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates[template] = body
+	}
+	return templates, rows.Err()
+}
+
+// Get returns the guild's override for template, if it has one
+func (s *TemplateService) Get(guildID, template string) (string, bool, error) {
+	if s.db == nil {
+		return "", false, nil
+	}
+
+	var body string
+	err := s.db.QueryRow(`
+		SELECT body FROM guild_message_templates WHERE guild_id = $1 AND template = $2
+	`, guildID, template).Scan(&body)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get template: %w", err)
+	}
+	return body, true, nil
+}
+
+// Set stores an override for template in the guild
+func (s *TemplateService) Set(guildID, template, body, updatedBy string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := ValidateTemplate(template, body); err != nil {
+		return err
+	}
+
+	logger.DB("Setting message template: guild_id=%s, template=%s", guildID, template)
+	_, err := s.db.Exec(`
+		INSERT INTO guild_message_templates (guild_id, template, body, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (guild_id, template) DO UPDATE SET
+			body = EXCLUDED.body,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, guildID, template, body, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+	return nil
+}
+
+// Reset removes the guild's override so the built-in message applies again
+func (s *TemplateService) Reset(guildID, template string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	logger.DB("Resetting message template: guild_id=%s, template=%s", guildID, template)
+	if _, err := s.db.Exec(`
+		DELETE FROM guild_message_templates WHERE guild_id = $1 AND template = $2
+	`, guildID, template); err != nil {
+		return fmt.Errorf("failed to reset template: %w", err)
+	}
+	return nil
+}
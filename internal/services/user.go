@@ -2,20 +2,61 @@ package services
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/75-hard-discord-bot/internal/expiring"
 	"github.com/75-hard-discord-bot/internal/logger"
 )
 
 // UserService handles user-related operations
 type UserService struct {
-	db *sql.DB
+	db       *sql.DB
+	cacheTTL time.Duration
+	cache    *expiring.Map[string, userRecord] // user ID -> record
+	mu       sync.Mutex
+	timezone string // Zone for users who haven't chosen one
 }
 
-// NewUserService creates a new user service
-func NewUserService() *UserService {
-	return &UserService{}
+// userRecord is what every write reads about a user: their name, challenge dates,
+// and time zone
+type userRecord struct {
+	username  string
+	startDate time.Time
+	endDate   time.Time
+	timezone  string
+}
+
+// NewUserService creates a new user service. User records are cached for cacheTTL,
+// since each log looks them up; 0 turns the cache off. Users who haven't chosen a time
+// zone are in timezone (BOT_TIMEZONE).
+func NewUserService(cacheTTL time.Duration, timezone string) *UserService {
+	return &UserService{
+		cacheTTL: cacheTTL,
+		cache:    expiring.New[string, userRecord](),
+		timezone: timezone,
+	}
+}
+
+// DefaultTimezone returns the zone for users who haven't chosen one
+func (s *UserService) DefaultTimezone() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timezone
+}
+
+// SetDefaultTimezone changes the zone for users who haven't chosen one, e.g. when the
+// guild's timezone setting changes. Cached records carry the old zone, so they're dropped.
+func (s *UserService) SetDefaultTimezone(timezone string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if timezone == s.timezone {
+		return
+	}
+	s.timezone = timezone
+	s.cache.Clear()
 }
 
 // Initialize initializes the service with database connection
@@ -37,18 +78,66 @@ func (s *UserService) Health() error {
 	return s.db.Ping()
 }
 
+// loadUser returns the user's record, from the cache while it's fresh, or
+// ErrUserNotFound
+func (s *UserService) loadUser(userID string) (userRecord, error) {
+	record, ok := s.cache.Get(userID)
+	if ok {
+		return record, nil
+	}
+
+	logger.DB("Querying challenge dates and timezone for user_id=%s", userID)
+	err := s.db.QueryRow(
+		`SELECT u.username, u.challenge_start_date, u.current_challenge_end_date, COALESCE(p.timezone, $2)
+		 FROM users u
+		 LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		 WHERE u.user_id = $1`,
+		userID, s.DefaultTimezone(),
+	).Scan(&record.username, &record.startDate, &record.endDate, &record.timezone)
+	if err == sql.ErrNoRows {
+		return userRecord{}, ErrUserNotFound
+	}
+	if err != nil {
+		return userRecord{}, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if s.cacheTTL > 0 {
+		s.cache.Set(userID, record, s.cacheTTL)
+	}
+	return record, nil
+}
+
+// ForgetUser drops the user's cached record. Call it after changing their name,
+// challenge dates, or time zone outside UserService, so the change applies at once.
+func (s *UserService) ForgetUser(userID string) {
+	s.cache.Delete(userID)
+}
+
 // EnsureUserExists creates a user record if it doesn't exist
 func (s *UserService) EnsureUserExists(userID, username string) error {
 	if s.db == nil {
 		return fmt.Errorf("database not available")
 	}
 
-	now := time.Now()
+	// A cached record under the same name means there's nothing to write
+	record, ok := s.cache.Get(userID)
+	if ok && record.username == username {
+		return nil
+	}
+
+	// New users start today in their own zone, so the write that enrolls them isn't
+	// rejected as coming before their start date
+	timezone := s.DefaultTimezone()
+	err := s.db.QueryRow(`SELECT timezone FROM user_preferences WHERE user_id = $1 AND timezone IS NOT NULL`, userID).Scan(&timezone)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get timezone: %w", err)
+	}
+	now := time.Now().In(LoadTimezone(timezone))
 	startDate := now.Format("2006-01-02")
 	endDate := now.AddDate(0, 0, 75).Format("2006-01-02")
 
 	logger.DB("Executing INSERT/UPDATE on users table: user_id=%s, username=%s, start_date=%s", userID, username, startDate)
-	_, err := s.db.Exec(
+	_, err = s.db.Exec(
 		`INSERT INTO users (user_id, username, challenge_start_date, original_challenge_end_date, current_challenge_end_date)
 		 VALUES ($1, $2, $3, $4, $5)
 		 ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username`,
@@ -57,6 +146,7 @@ func (s *UserService) EnsureUserExists(userID, username string) error {
 	if err != nil {
 		logger.Error("Failed to ensure user exists: %v", err)
 	}
+	s.ForgetUser(userID)
 	return err
 }
 
@@ -82,6 +172,7 @@ func (s *UserService) StartChallenge(userID, username string, startDate time.Tim
 			days_added = 0`,
 		userID, username, startDateStr, endDateStr, endDateStr,
 	)
+	s.ForgetUser(userID)
 	if err != nil {
 		logger.Error("Failed to start challenge: %v", err)
 		return time.Time{}, time.Time{}, fmt.Errorf("failed to start challenge: %w", err)
@@ -91,36 +182,62 @@ func (s *UserService) StartChallenge(userID, username string, startDate time.Tim
 	return startDate, endDate, nil
 }
 
-// GetCurrentChallengeDay calculates the current challenge day for a user
-func (s *UserService) GetCurrentChallengeDay(userID string) (int, error) {
+// LoadTimezone loads a timezone by name, falling back to MST (UTC-7)
+func LoadTimezone(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone("MST", -7*3600)
+	}
+	return loc
+}
+
+// CalendarDaysBetween counts the calendar days from from's date to to's date, each read
+// in its own location. Only the dates are compared, so DST transitions and time of day
+// never shift the result, unlike dividing the elapsed hours by 24.
+func CalendarDaysBetween(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// ChallengeDayForDate returns the 1-based challenge day that a calendar date falls on.
+// startDate is a DATE column, so it's read as the calendar date it holds.
+func ChallengeDayForDate(startDate, date time.Time) int {
+	return CalendarDaysBetween(startDate, date) + 1
+}
+
+// CurrentChallengeDay returns the 1-based challenge day it is now in loc, the user's
+// zone; it's below 1 before the challenge starts
+func CurrentChallengeDay(startDate time.Time, loc *time.Location) int {
+	return ChallengeDayForDate(startDate, time.Now().In(loc))
+}
+
+// GetChallengeDate returns today's calendar date in the user's timezone and the challenge day it maps to
+func (s *UserService) GetChallengeDate(userID string) (time.Time, int, error) {
 	if s.db == nil {
-		return 0, fmt.Errorf("database not available")
+		return time.Time{}, 0, fmt.Errorf("database not available")
 	}
 
-	logger.DB("Querying challenge_start_date for user_id=%s", userID)
-	var startDate time.Time
-	err := s.db.QueryRow(
-		`SELECT challenge_start_date FROM users WHERE user_id = $1`,
-		userID,
-	).Scan(&startDate)
+	progress, err := s.challengeDates(userID)
 	if err != nil {
 		logger.Error("Failed to get challenge start date: %v", err)
-		return 0, err
+		return time.Time{}, 0, err
 	}
+	logger.DB("Calculated challenge_day=%d (date=%s) for user_id=%s", progress.ChallengeDay, progress.Date.Format("2006-01-02"), userID)
+	return progress.Date, progress.ChallengeDay, nil
+}
 
-	daysSinceStart := int(time.Since(startDate).Hours() / 24)
-	if daysSinceStart < 0 {
-		daysSinceStart = 0
-	}
-	challengeDay := daysSinceStart + 1
-	logger.DB("Calculated challenge_day=%d for user_id=%s", challengeDay, userID)
-	return challengeDay, nil
+// GetCurrentChallengeDay calculates the current challenge day for a user
+func (s *UserService) GetCurrentChallengeDay(userID string) (int, error) {
+	_, challengeDay, err := s.GetChallengeDate(userID)
+	return challengeDay, err
 }
 
 // ActiveUser represents a user currently participating in the challenge
 type ActiveUser struct {
 	UserID      string
 	Username    string
+	Privacy     string // How the user appears in public posts
 	StartDate   time.Time
 	EndDate     time.Time
 	CurrentDay  int
@@ -134,31 +251,24 @@ func (s *UserService) GetActiveUsers() ([]ActiveUser, error) {
 		return nil, fmt.Errorf("database not available")
 	}
 
-	// Load MST location for consistent date handling
-	mst, err := time.LoadLocation("America/Denver")
-	if err != nil {
-		mst = time.FixedZone("MST", -7*3600)
-	}
-
-	// Get today's date in MST (normalized to midnight)
-	nowMST := time.Now().In(mst)
-	todayMST := time.Date(nowMST.Year(), nowMST.Month(), nowMST.Day(), 0, 0, 0, 0, mst)
-	
-	// Use date-only comparison (cast to date in SQL)
+	// Each user is active by the date it is in their own zone (cast to date in SQL)
 	query := `
 		SELECT 
-			user_id,
-			username,
-			challenge_start_date,
-			current_challenge_end_date,
-			days_added
-		FROM users
-		WHERE challenge_start_date::date <= $1::date
-		  AND current_challenge_end_date::date >= $1::date
-		ORDER BY challenge_start_date ASC, username ASC
+			u.user_id,
+			u.username,
+			COALESCE(p.privacy, 'public'),
+			u.challenge_start_date,
+			u.current_challenge_end_date,
+			u.days_added,
+			COALESCE(p.timezone, $1)
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.user_id
+		WHERE u.challenge_start_date::date <= (NOW() AT TIME ZONE COALESCE(p.timezone, $1))::date
+		  AND u.current_challenge_end_date::date >= (NOW() AT TIME ZONE COALESCE(p.timezone, $1))::date
+		ORDER BY u.challenge_start_date ASC, u.username ASC
 	`
 
-	rows, err := s.db.Query(query, todayMST)
+	rows, err := s.db.Query(query, s.DefaultTimezone())
 	if err != nil {
 		logger.Error("Failed to query active users: %v", err)
 		return nil, fmt.Errorf("failed to query active users: %w", err)
@@ -167,27 +277,22 @@ func (s *UserService) GetActiveUsers() ([]ActiveUser, error) {
 
 	var activeUsers []ActiveUser
 	for rows.Next() {
-		var userID, username string
+		var userID, username, privacy, timezone string
 		var startDate, endDate time.Time
 		var daysAdded int
 
-		err := rows.Scan(&userID, &username, &startDate, &endDate, &daysAdded)
+		err := rows.Scan(&userID, &username, &privacy, &startDate, &endDate, &daysAdded, &timezone)
 		if err != nil {
 			logger.Error("Failed to scan active user row: %v", err)
 			continue
 		}
 
-		// Normalize dates to MST midnight for accurate day calculations
-		startDateMST := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, mst)
-		endDateMST := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, mst)
-
-		// Calculate days since start using MST dates
-		daysSinceStart := int(todayMST.Sub(startDateMST).Hours() / 24)
-		if daysSinceStart < 0 {
-			daysSinceStart = 0
+		// Each user is on the day it is in their own zone
+		currentDay := CurrentChallengeDay(startDate, LoadTimezone(timezone))
+		if currentDay < 1 {
+			currentDay = 1
 		}
-		currentDay := daysSinceStart + 1
-		totalDays := int(endDateMST.Sub(startDateMST).Hours() / 24)
+		totalDays := CalendarDaysBetween(startDate, endDate)
 		if currentDay > totalDays {
 			currentDay = totalDays
 		}
@@ -195,8 +300,9 @@ func (s *UserService) GetActiveUsers() ([]ActiveUser, error) {
 		activeUsers = append(activeUsers, ActiveUser{
 			UserID:     userID,
 			Username:   username,
-			StartDate:  startDateMST,
-			EndDate:    endDateMST,
+			Privacy:    privacy,
+			StartDate:  startDate,
+			EndDate:    endDate,
 			CurrentDay: currentDay,
 			TotalDays:  totalDays,
 			DaysAdded:  daysAdded,
@@ -205,3 +311,135 @@ func (s *UserService) GetActiveUsers() ([]ActiveUser, error) {
 
 	return activeUsers, nil
 }
+
+// ErrUserNotFound is returned for users who have never used the bot
+var ErrUserNotFound = errors.New("user not found")
+
+// Progress is a participant's standing in their challenge as of today in their zone
+type Progress struct {
+	UserID        string
+	Username      string
+	StartDate     time.Time
+	EndDate       time.Time
+	Date          time.Time // Today in the user's zone
+	ChallengeDay  int
+	TotalDays     int
+	DaysCompleted int
+}
+
+// GetProgress returns the user's challenge progress, or ErrUserNotFound
+func (s *UserService) GetProgress(userID string) (Progress, error) {
+	progress, err := s.challengeDates(userID)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	logger.DB("Counting completed days for user_id=%s", userID)
+	err = s.db.QueryRow(
+		`SELECT COUNT(*) FROM accountability_checkins WHERE user_id = $1 AND challenge_day <= $2`,
+		userID, progress.ChallengeDay,
+	).Scan(&progress.DaysCompleted)
+	if err != nil {
+		return Progress{}, fmt.Errorf("failed to count completed days: %w", err)
+	}
+	return progress, nil
+}
+
+// challengeDates returns the user's progress without DaysCompleted, which changes
+// with every check-in and isn't cached, or ErrUserNotFound
+func (s *UserService) challengeDates(userID string) (Progress, error) {
+	if s.db == nil {
+		return Progress{}, fmt.Errorf("database not available")
+	}
+	record, err := s.loadUser(userID)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	progress := Progress{
+		UserID:    userID,
+		Username:  record.username,
+		StartDate: record.startDate,
+		EndDate:   record.endDate,
+	}
+	now := time.Now().In(LoadTimezone(record.timezone))
+	progress.Date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	progress.TotalDays = CalendarDaysBetween(progress.StartDate, progress.EndDate)
+	progress.ChallengeDay = ChallengeDayForDate(progress.StartDate, progress.Date)
+	if progress.ChallengeDay < 1 {
+		progress.ChallengeDay = 1
+	}
+	return progress, nil
+}
+
+// DeleteUserData permanently removes every row stored for a user.
+// Feat, weigh-in, photo, and failure tables cascade from users, but each table is
+// cleared explicitly so the erasure does not depend on foreign key configuration.
+func (s *UserService) DeleteUserData(userID string) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Child tables first, users last
+	tables := []string{
+		"council_exceptions",
+		"challenge_failures",
+		"weigh_ins",
+		"progress_photos",
+		"accountability_checkins",
+		"exercise_completions",
+		"diet_completions",
+		"meals",
+		"water_completions",
+		"water_entries",
+		"self_improvement_completions",
+		"self_improvement_sessions",
+		"book_pages",
+		"books",
+		"workout_timers",
+		"finances_completions",
+		"purchases",
+		"user_progress_rollup",
+		"user_forum_threads",
+		"user_preferences",
+		"webhook_keys",
+		"oauth_connections",
+		"oauth_states",
+		"daily_steps",
+		"daily_nutrition",
+		"calendar_feeds",
+		"sms_numbers",
+		"email_reports",
+		"dashboard_sessions",
+		"coach_usage",
+		"action_journal",
+		"audit_log",
+		"users",
+	}
+
+	var totalDeleted int64
+	for _, table := range tables {
+		logger.DB("Deleting user data: table=%s, user_id=%s", table, userID)
+		result, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table), userID)
+		if err != nil {
+			logger.Error("Failed to delete from %s: %v", table, err)
+			return 0, fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		totalDeleted += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit deletion: %w", err)
+	}
+	s.ForgetUser(userID)
+
+	logger.DB("Deleted %d rows for user_id=%s", totalDeleted, userID)
+	return totalDeleted, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Writes to a challenge day are checked against the user's challenge dates, so clock
+// skew or an admin mistake can't create rows for days that haven't happened yet or
+// that fall outside the challenge. Rejected writes return a *DayError wrapping one of
+// these.
+var (
+	// ErrChallengeNotStarted is returned for writes to a day before the user's start date
+	ErrChallengeNotStarted = errors.New("challenge has not started")
+	// ErrChallengeEnded is returned for writes to a day after the user's last day
+	ErrChallengeEnded = errors.New("challenge has ended")
+	// ErrFutureDay is returned for writes to a day after today in the user's zone
+	ErrFutureDay = errors.New("day is in the future")
+)
+
+// DayError is a write rejected because of the challenge day it was for
+type DayError struct {
+	Err       error
+	Date      time.Time // The calendar date the write was for
+	Today     time.Time // Today in the user's zone
+	StartDate time.Time
+	LastDate  time.Time // The challenge's final day
+}
+
+func (e *DayError) Error() string {
+	return fmt.Sprintf("%v: %s is outside %s to %s (today is %s)", e.Err, e.Date.Format("2006-01-02"),
+		e.StartDate.Format("2006-01-02"), e.LastDate.Format("2006-01-02"), e.Today.Format("2006-01-02"))
+}
+
+// Unwrap lets errors.Is match the reason
+func (e *DayError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateChallengeDay returns the challenge day date falls on, with a *DayError when
+// it's before the user's start, after their last day, or after today in their zone.
+// The day is returned either way so callers can say which day was skipped.
+func ValidateChallengeDay(progress Progress, date time.Time) (int, error) {
+	day := ChallengeDayForDate(progress.StartDate, date)
+	var reason error
+	switch {
+	case day < 1:
+		reason = ErrChallengeNotStarted
+	case day > progress.TotalDays:
+		reason = ErrChallengeEnded
+	case CalendarDaysBetween(progress.Date, date) > 0:
+		reason = ErrFutureDay
+	default:
+		return day, nil
+	}
+	return day, &DayError{
+		Err:       reason,
+		Date:      date,
+		Today:     progress.Date,
+		StartDate: progress.StartDate,
+		LastDate:  progress.EndDate.AddDate(0, 0, -1),
+	}
+}
+
+// GetWritableDay returns today's date in the user's zone and its challenge day for a
+// write, with a *DayError when today is outside the user's challenge. It reads only
+// the user's cached record, since it's on every write's path.
+func (s *UserService) GetWritableDay(userID string) (time.Time, int, error) {
+	progress, err := s.challengeDates(userID)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	day, err := ValidateChallengeDay(progress, progress.Date)
+	return progress.Date, day, err
+}
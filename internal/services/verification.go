@@ -0,0 +1,58 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Codes that confirm a member owns a phone number or email address
+const (
+	// VerificationCodeExpiry is how long a verification code can be entered
+	VerificationCodeExpiry = 10 * time.Minute
+	// verificationResendInterval is how long a member waits before another code is sent
+	verificationResendInterval = time.Minute
+	// verificationMaxAttempts is how many wrong guesses a code allows
+	verificationMaxAttempts = 5
+)
+
+var (
+	// ErrVerificationTooSoon is returned when a new code is asked for right after the last one
+	ErrVerificationTooSoon = errors.New("a code was just sent; wait a minute before asking for another")
+	// ErrVerificationCodeInvalid is returned for a wrong verification code
+	ErrVerificationCodeInvalid = errors.New("wrong verification code")
+	// ErrVerificationCodeExpired is returned when the code expired or was guessed wrong too often
+	ErrVerificationCodeExpired = errors.New("verification code expired")
+)
+
+// newVerificationCode returns a random 6-digit code
+func newVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashVerificationCode hashes a code so pending codes aren't stored as typed
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkVerificationCode checks a guess against a pending code sent at sentAt, after
+// attempts wrong guesses
+func checkVerificationCode(code, codeHash string, sentAt time.Time, attempts int) error {
+	if codeHash == "" || time.Since(sentAt) > VerificationCodeExpiry || attempts >= verificationMaxAttempts {
+		return ErrVerificationCodeExpired
+	}
+	if subtle.ConstantTimeCompare([]byte(hashVerificationCode(code)), []byte(codeHash)) != 1 {
+		return ErrVerificationCodeInvalid
+	}
+	return nil
+}
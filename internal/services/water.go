@@ -3,20 +3,40 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"math"
+	"time"
 
+	"github.com/75-hard-discord-bot/internal/events"
 	"github.com/75-hard-discord-bot/internal/logger"
 )
 
+// WaterGoalOunces is the daily water goal: one gallon
+const WaterGoalOunces = 128.0
+
+// Sources of water entries besides providers and corrections
+const (
+	// SourceManual marks water a member logged themself, in Discord or through the API
+	SourceManual = "manual"
+	// SourceRollup marks a change to a day's total made outside the entry log, such as
+	// a check-in filling in the gallon or a CSV import
+	SourceRollup = "rollup"
+	// SourceUndo marks water /undo put back or took off
+	SourceUndo = "undo"
+)
+
 // WaterService handles water intake tracking operations
 type WaterService struct {
 	db          *sql.DB
 	userService *UserService
+	events      *events.Bus
 }
 
-// NewWaterService creates a new water service
-func NewWaterService(userService *UserService) *WaterService {
+// NewWaterService creates a new water service. Changes to today's total are
+// published on bus.
+func NewWaterService(userService *UserService, bus *events.Bus) *WaterService {
 	return &WaterService{
 		userService: userService,
+		events:      bus,
 	}
 }
 
@@ -39,14 +59,38 @@ func (s *WaterService) Health() error {
 	return s.db.Ping()
 }
 
-// AddWater adds water intake for the user
-func (s *WaterService) AddWater(userID, username string, ounces float64) (float64, float64, error) {
-	if s.db == nil {
-		return 0, 0, fmt.Errorf("database not available")
+// AddWater adds water intake for the user. amount, and the amount added and new
+// total it returns, are in the user's units.
+func (s *WaterService) AddWater(userID, username string, amount float64, units Units) (float64, float64, error) {
+	if amount <= 0 {
+		return 0, 0, fmt.Errorf("amount must be greater than 0")
+	}
+	ounces, total, err := s.changeWater(userID, username, ActionWaterAdd, units.ToOunces(amount))
+	if err != nil {
+		return 0, 0, err
 	}
+	return units.FromOunces(ounces), units.FromOunces(total), nil
+}
 
-	if ounces <= 0 {
-		return 0, 0, fmt.Errorf("ounces must be greater than 0")
+// SubtractWater subtracts water intake for the user. amount, and the amount subtracted
+// and new total it returns, are in the user's units.
+func (s *WaterService) SubtractWater(userID, username string, amount float64, units Units) (float64, float64, error) {
+	if amount <= 0 {
+		return 0, 0, fmt.Errorf("amount must be greater than 0")
+	}
+	ounces, total, err := s.changeWater(userID, username, ActionWaterSubtract, -units.ToOunces(amount))
+	if err != nil {
+		return 0, 0, err
+	}
+	return units.FromOunces(-ounces), units.FromOunces(total), nil
+}
+
+// changeWater logs ounces (negative to take water off) on the user's writable day as
+// action. The total stays between 0 and the daily goal, so only what fits is logged;
+// returns the ounces logged and the day's new total.
+func (s *WaterService) changeWater(userID, username, action string, ounces float64) (float64, float64, error) {
+	if s.db == nil {
+		return 0, 0, fmt.Errorf("database not available")
 	}
 
 	// Ensure user exists
@@ -55,126 +99,216 @@ func (s *WaterService) AddWater(userID, username string, ounces float64) (float6
 		return 0, 0, fmt.Errorf("failed to ensure user exists: %w", err)
 	}
 
-	// Get current challenge day
-	challengeDay, err := s.userService.GetCurrentChallengeDay(userID)
+	// Get current challenge date and day
+	completionDate, challengeDay, err := s.userService.GetWritableDay(userID)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get challenge day: %w", err)
 	}
+	// Settled before the snapshot, so /undo puts back what was logged rather than the claim
+	if err := s.settleClaim(userID, challengeDay); err != nil {
+		return 0, 0, err
+	}
+	journal, err := snapshotDay(s.db, userID, action, challengeDay)
+	if err != nil {
+		return 0, 0, err
+	}
 
-	// Get current water amount for today
-	var currentAmount sql.NullFloat64
-	err = s.db.QueryRow(
-		`SELECT amount_ounces FROM water_completions WHERE user_id = $1 AND challenge_day = $2`,
-		userID, challengeDay,
-	).Scan(&currentAmount)
-
-	currentTotal := 0.0
-	if err == nil && currentAmount.Valid {
-		currentTotal = currentAmount.Float64
-	} else if err != sql.ErrNoRows {
-		logger.Error("Failed to query current water amount: %v", err)
-		return 0, 0, fmt.Errorf("failed to query current water amount: %w", err)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to start water log: %w", err)
 	}
+	defer tx.Rollback()
 
-	// Calculate new total (cap at 128oz)
-	newTotal := currentTotal + ounces
-	if newTotal > 128.0 {
-		newTotal = 128.0
-		ounces = 128.0 - currentTotal // Only add what fits
+	logger.DB("Logging water: user_id=%s, challenge_day=%d, change=%.2f oz", userID, challengeDay, ounces)
+	logged, total, err := LogWater(tx, userID, challengeDay, completionDate, ounces, SourceManual)
+	if err != nil {
+		logger.Error("Failed to log water: %v", err)
+		return 0, 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit water log: %w", err)
 	}
 
-	// Insert or update water completion
-	logger.DB("Adding water: user_id=%s, challenge_day=%d, adding=%.2f oz, new_total=%.2f oz", userID, challengeDay, ounces, newTotal)
-	if currentTotal == 0 {
-		// Insert new record
-		_, err = s.db.Exec(
-			`INSERT INTO water_completions (user_id, challenge_day, amount_ounces, is_plain_water, completed_at)
-			 VALUES ($1, $2, $3, true, NOW())`,
-			userID, challengeDay, newTotal,
-		)
-	} else {
-		// Update existing record
-		_, err = s.db.Exec(
-			`UPDATE water_completions 
-			 SET amount_ounces = LEAST(amount_ounces + $3, 128.0),
-			     completed_at = NOW()
-			 WHERE user_id = $1 AND challenge_day = $2`,
-			userID, challengeDay, ounces,
-		)
+	logger.DB("Successfully logged water for user_id=%s, challenge_day=%d, total=%.2f oz", userID, challengeDay, total)
+	if logged != 0 {
+		recordAction(s.db, journal)
+		s.events.Publish(events.WaterLogged{
+			UserID:       userID,
+			Username:     username,
+			ChallengeDay: challengeDay,
+			TotalOunces:  total,
+			GoalReached:  logged > 0 && total >= WaterGoalOunces,
+		})
 	}
+	return logged, total, nil
+}
+
+// LogWater records ounces (negative to take water off) in water_entries for the
+// user's challengeDay and rolls the day's entries up into its water_completions row.
+// The total stays between 0 and the daily goal, so only what fits is recorded; returns
+// the ounces recorded and the day's new total. Nothing is written when nothing fits.
+// It's exported for writers outside the services, like the dev seeder, that build
+// days in their own transaction.
+func LogWater(tx *sql.Tx, userID string, challengeDay int, completionDate time.Time, ounces float64, source string) (float64, float64, error) {
+	current, err := reconcileWater(tx, userID, challengeDay, SourceRollup)
 	if err != nil {
-		logger.Error("Failed to add water: %v", err)
-		return 0, 0, fmt.Errorf("failed to add water: %w", err)
+		return 0, 0, err
+	}
+	total := math.Min(math.Max(roundOunces(current+ounces), 0), WaterGoalOunces)
+	ounces = roundOunces(total - current)
+	if ounces == 0 {
+		return 0, current, nil
 	}
 
-	logger.DB("Successfully added water for user_id=%s, challenge_day=%d, total=%.2f oz", userID, challengeDay, newTotal)
-	return ounces, newTotal, nil
+	if err := insertWaterEntry(tx, userID, challengeDay, ounces, source); err != nil {
+		return 0, 0, err
+	}
+	err = tx.QueryRow(
+		`INSERT INTO water_completions (user_id, challenge_day, completion_date, amount_ounces, is_plain_water, completed_at)
+		 SELECT $1, $2, $3, LEAST(GREATEST(COALESCE(SUM(amount_ounces), 0), 0), $4), true, NOW()
+		 FROM water_entries
+		 WHERE user_id = $1 AND challenge_day = $2
+		 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			amount_ounces = EXCLUDED.amount_ounces,
+			completed_at = NOW()
+		 RETURNING amount_ounces`,
+		userID, challengeDay, completionDate.Format("2006-01-02"), WaterGoalOunces,
+	).Scan(&total)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to update water total: %w", err)
+	}
+	return ounces, total, nil
 }
 
-// SubtractWater subtracts water intake for the user
-func (s *WaterService) SubtractWater(userID, username string, ounces float64) (float64, float64, error) {
-	if s.db == nil {
-		return 0, 0, fmt.Errorf("database not available")
+// settleClaim takes back the water a check-in claimed on the user's challengeDay; see
+// settleClaimedWater
+func (s *WaterService) settleClaim(userID string, challengeDay int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start water check: %w", err)
 	}
+	defer tx.Rollback()
 
-	if ounces <= 0 {
-		return 0, 0, fmt.Errorf("ounces must be greater than 0")
+	if _, _, _, err := settleClaimedWater(tx, userID, challengeDay); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit water check: %w", err)
 	}
+	return nil
+}
 
-	// Ensure user exists
-	err := s.userService.EnsureUserExists(userID, username)
+// settleClaimedWater brings the user's water row for challengeDay down to what was
+// logged while the row is autopopulated. A check-in fills in a gallon for a day with
+// no water row, and reconcileWater books that gallon as a rollup entry, so until a
+// member, provider, edit, or import sets the day only its other entries count: the
+// rollups are reversed and the row is lowered to the rest. Rows set by an edit or
+// import are left alone. Returns the row's total before settling, the logged total,
+// and whether the row is autopopulated; all zero when there's no row. Locks the row.
+func settleClaimedWater(tx *sql.Tx, userID string, challengeDay int) (float64, float64, bool, error) {
+	var claimed float64
+	var autopopulated bool
+	err := tx.QueryRow(
+		`SELECT COALESCE(amount_ounces, 0), COALESCE(autopopulated, true)
+		 FROM water_completions WHERE user_id = $1 AND challenge_day = $2 FOR UPDATE`,
+		userID, challengeDay,
+	).Scan(&claimed, &autopopulated)
+	if err == sql.ErrNoRows {
+		return 0, 0, false, nil
+	}
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to ensure user exists: %w", err)
+		return 0, 0, false, fmt.Errorf("failed to query current water amount: %w", err)
+	}
+	if !autopopulated {
+		return claimed, claimed, false, nil
 	}
 
-	// Get current challenge day
-	challengeDay, err := s.userService.GetCurrentChallengeDay(userID)
+	var logged, rollups float64
+	err = tx.QueryRow(
+		`SELECT COALESCE(SUM(amount_ounces) FILTER (WHERE source <> $3), 0),
+			COALESCE(SUM(amount_ounces) FILTER (WHERE source = $3), 0)
+		 FROM water_entries WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay, SourceRollup,
+	).Scan(&logged, &rollups)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get challenge day: %w", err)
+		return 0, 0, false, fmt.Errorf("failed to query water entries: %w", err)
+	}
+	logged = math.Min(math.Max(roundOunces(logged), 0), WaterGoalOunces)
+	if roundOunces(rollups) == 0 && logged == claimed {
+		return claimed, logged, true, nil
 	}
 
-	// Get current water amount for today
-	var currentAmount sql.NullFloat64
-	err = s.db.QueryRow(
-		`SELECT amount_ounces FROM water_completions WHERE user_id = $1 AND challenge_day = $2`,
+	logger.DB("Settling claimed water: user_id=%s, challenge_day=%d, claimed=%.2f oz, logged=%.2f oz", userID, challengeDay, claimed, logged)
+	// Taking the rollups back off keeps the day's entries adding up to its total
+	if difference := roundOunces(rollups); difference != 0 {
+		if err := insertWaterEntry(tx, userID, challengeDay, -difference, SourceRollup); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	_, err = tx.Exec(
+		`UPDATE water_completions SET amount_ounces = $3 WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay, logged,
+	)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("failed to update water total: %w", err)
+	}
+	return claimed, logged, true, nil
+}
+
+// reconcileWater returns the user's water total for challengeDay from its
+// water_completions row, locking the row. When the total changed outside
+// water_entries since the last entry, such as a check-in filling in the gallon or
+// /undo putting an old total back, the difference is first recorded as source so the
+// day's entries keep adding up to its total.
+func reconcileWater(tx *sql.Tx, userID string, challengeDay int, source string) (float64, error) {
+	var total sql.NullFloat64
+	err := tx.QueryRow(
+		`SELECT amount_ounces FROM water_completions WHERE user_id = $1 AND challenge_day = $2 FOR UPDATE`,
 		userID, challengeDay,
-	).Scan(&currentAmount)
-
-	currentTotal := 0.0
-	if err == nil && currentAmount.Valid {
-		currentTotal = currentAmount.Float64
-	} else if err != sql.ErrNoRows {
-		logger.Error("Failed to query current water amount: %v", err)
-		return 0, 0, fmt.Errorf("failed to query current water amount: %w", err)
-	}
-
-	// Calculate new total (can't go below 0)
-	newTotal := currentTotal - ounces
-	if newTotal < 0 {
-		newTotal = 0
-		ounces = currentTotal // Only subtract what exists
-	}
-
-	// Update water completion
-	logger.DB("Subtracting water: user_id=%s, challenge_day=%d, subtracting=%.2f oz, new_total=%.2f oz", userID, challengeDay, ounces, newTotal)
-	_, err = s.db.Exec(
-		`UPDATE water_completions 
-		 SET amount_ounces = GREATEST(amount_ounces - $3, 0),
-		     completed_at = NOW()
-		 WHERE user_id = $1 AND challenge_day = $2`,
-		userID, challengeDay, ounces,
+	).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to query current water amount: %w", err)
+	}
+
+	var logged float64
+	err = tx.QueryRow(
+		`SELECT COALESCE(SUM(amount_ounces), 0) FROM water_entries WHERE user_id = $1 AND challenge_day = $2`,
+		userID, challengeDay,
+	).Scan(&logged)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query water entries: %w", err)
+	}
+
+	if difference := roundOunces(total.Float64 - logged); difference != 0 {
+		logger.DB("Reconciling water entries: user_id=%s, challenge_day=%d, total=%.2f oz, logged=%.2f oz", userID, challengeDay, total.Float64, logged)
+		if err := insertWaterEntry(tx, userID, challengeDay, difference, source); err != nil {
+			return 0, err
+		}
+	}
+	return total.Float64, nil
+}
+
+// insertWaterEntry records ounces (negative for water taken off) for the user's
+// challengeDay, dated by their start date
+func insertWaterEntry(tx *sql.Tx, userID string, challengeDay int, ounces float64, source string) error {
+	_, err := tx.Exec(
+		`INSERT INTO water_entries (user_id, challenge_day, completion_date, amount_ounces, source)
+		 SELECT user_id, $2, challenge_start_date + ($2::integer - 1), $3, $4 FROM users WHERE user_id = $1`,
+		userID, challengeDay, ounces, source,
 	)
 	if err != nil {
-		logger.Error("Failed to subtract water: %v", err)
-		return 0, 0, fmt.Errorf("failed to subtract water: %w", err)
+		return fmt.Errorf("failed to record water entry: %w", err)
 	}
+	return nil
+}
 
-	logger.DB("Successfully subtracted water for user_id=%s, challenge_day=%d, total=%.2f oz", userID, challengeDay, newTotal)
-	return ounces, newTotal, nil
+// roundOunces rounds ounces to the hundredth stored
+func roundOunces(ounces float64) float64 {
+	return math.Round(ounces*100) / 100
 }
 
-// GetWaterIntake gets the current water intake for the user today
-func (s *WaterService) GetWaterIntake(userID string) (float64, error) {
+// GetWaterIntake gets the current water intake for the user today, in the user's units
+func (s *WaterService) GetWaterIntake(userID string, units Units) (float64, error) {
 	if s.db == nil {
 		return 0, fmt.Errorf("database not available")
 	}
@@ -200,7 +334,84 @@ func (s *WaterService) GetWaterIntake(userID string) (float64, error) {
 	}
 
 	if amount.Valid {
-		return amount.Float64, nil
+		return units.FromOunces(amount.Float64), nil
 	}
 	return 0, nil
 }
+
+// SyncWater applies the water total a provider (e.g. "fitbit") reports for date.
+// Manual logs and the provider often record the same glass, so the day's total
+// becomes the higher of the two rather than their sum; syncing never lowers a total.
+// Returns the day's total in ounces and whether the day was synced; days outside
+// the user's challenge or after today are skipped.
+func (s *WaterService) SyncWater(userID string, date time.Time, ounces float64, source string) (float64, bool, error) {
+	if s.db == nil {
+		return 0, false, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return 0, false, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, date)
+	if err != nil {
+		return 0, false, nil
+	}
+	if ounces > WaterGoalOunces {
+		ounces = WaterGoalOunces
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to start water sync: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A check-in's claimed gallon would otherwise win over the synced total
+	if _, _, _, err := settleClaimedWater(tx, userID, challengeDay); err != nil {
+		return 0, false, err
+	}
+	// The previous total decides whether the sync changed anything worth publishing
+	previous, err := reconcileWater(tx, userID, challengeDay, SourceRollup)
+	if err != nil {
+		return 0, false, err
+	}
+
+	logger.DB("Syncing water: user_id=%s, challenge_day=%d, synced=%.2f oz, source=%s", userID, challengeDay, ounces, source)
+	var total float64
+	err = tx.QueryRow(
+		`INSERT INTO water_completions (user_id, challenge_day, completion_date, amount_ounces, is_plain_water, completed_at, metadata)
+		 VALUES ($1, $2, $3, $4, true, NOW(), jsonb_build_object($5::text || '_ounces', $4::numeric))
+		 ON CONFLICT (user_id, challenge_day) DO UPDATE SET
+			amount_ounces = GREATEST(water_completions.amount_ounces, EXCLUDED.amount_ounces),
+			metadata = COALESCE(water_completions.metadata, '{}'::jsonb) || EXCLUDED.metadata,
+			completed_at = CASE WHEN EXCLUDED.amount_ounces > water_completions.amount_ounces
+				THEN NOW() ELSE water_completions.completed_at END
+		 RETURNING amount_ounces`,
+		userID, challengeDay, date.Format("2006-01-02"), ounces, source,
+	).Scan(&total)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to sync water: %w", err)
+	}
+	// Only what the provider added beyond the previous total is logged as its entry
+	if added := roundOunces(total - previous); added > 0 {
+		if err := insertWaterEntry(tx, userID, challengeDay, added, source); err != nil {
+			return 0, false, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit water sync: %w", err)
+	}
+	// Only today's total is published, as for manual logs; late uploads for
+	// yesterday don't light anything up
+	if total > previous && challengeDay == ChallengeDayForDate(progress.StartDate, progress.Date) {
+		s.events.Publish(events.WaterLogged{
+			UserID:       userID,
+			Username:     progress.Username,
+			ChallengeDay: challengeDay,
+			TotalOunces:  total,
+			GoalReached:  total >= WaterGoalOunces && previous < WaterGoalOunces,
+		})
+	}
+	return total, true, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// WebhookSignatureWindow is how far a signed webhook request's timestamp may be from the
+// server's clock. Older requests are rejected, so a captured one can't be replayed later.
+const WebhookSignatureWindow = 5 * time.Minute
+
+// WebhookKeyService stores each user's key for the inbound logging webhook
+type WebhookKeyService struct {
+	db *sql.DB
+}
+
+// NewWebhookKeyService creates a new webhook key service
+func NewWebhookKeyService() *WebhookKeyService {
+	return &WebhookKeyService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *WebhookKeyService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *WebhookKeyService) Name() string {
+	return "WebhookKeyService"
+}
+
+// Health checks the service health
+func (s *WebhookKeyService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Rotate creates a new key for the user, replacing any old one, and returns it.
+// The user must already exist.
+func (s *WebhookKeyService) Rotate(userID string) (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	secret := hex.EncodeToString(raw)
+
+	logger.DB("Rotating webhook key for user_id=%s", userID)
+	_, err := s.db.Exec(`
+		INSERT INTO webhook_keys (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			created_at = NOW(),
+			last_used_at = NULL
+	`, userID, secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to save webhook key: %w", err)
+	}
+	return secret, nil
+}
+
+// Revoke deletes the user's key and reports whether they had one
+func (s *WebhookKeyService) Revoke(userID string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Revoking webhook key for user_id=%s", userID)
+	result, err := s.db.Exec(`DELETE FROM webhook_keys WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke webhook key: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Verify checks a request for the user's webhook. It accepts either the key itself as
+// bearer (for clients like iOS Shortcuts that can't compute HMACs) or signature, a
+// "sha256=<hex>" HMAC-SHA256 keyed with the user's key of "<timestamp>.<body>", where
+// timestamp is the request's Unix time in seconds and within WebhookSignatureWindow.
+func (s *WebhookKeyService) Verify(userID, bearer, signature, timestamp string, body []byte) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	var secret string
+	err := s.db.QueryRow(`SELECT secret FROM webhook_keys WHERE user_id = $1`, userID).Scan(&secret)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load webhook key: %w", err)
+	}
+
+	if !verifyWebhookRequest(secret, bearer, signature, timestamp, body, time.Now()) {
+		return false, nil
+	}
+
+	if _, err := s.db.Exec(`UPDATE webhook_keys SET last_used_at = NOW() WHERE user_id = $1`, userID); err != nil {
+		logger.Error("Failed to record webhook key use for user_id=%s: %v", userID, err)
+	}
+	return true, nil
+}
+
+// verifyWebhookRequest checks a request's bearer key or timestamped signature against
+// secret, as described on Verify
+func verifyWebhookRequest(secret, bearer, signature, timestamp string, body []byte, now time.Time) bool {
+	if bearer != "" {
+		return subtle.ConstantTimeCompare([]byte(bearer), []byte(secret)) == 1
+	}
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > WebhookSignatureWindow || age < -WebhookSignatureWindow {
+		return false
+	}
+	return hmac.Equal([]byte(strings.ToLower(hexSum)), []byte(SignWebhookRequest(secret, timestamp, body)))
+}
+
+// SignWebhookRequest returns the hex HMAC-SHA256 a client sends (after "sha256=") for
+// body at timestamp, a Unix time in seconds
+func SignWebhookRequest(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookRequest(t *testing.T) {
+	const secret = "0123456789abcdef"
+	body := []byte("water +64oz")
+	now := time.Unix(1760000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	valid := "sha256=" + SignWebhookRequest(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		bearer    string
+		signature string
+		timestamp string
+		body      []byte
+		want      bool
+	}{
+		{name: "signed", signature: valid, timestamp: timestamp, body: body, want: true},
+		{name: "signature is case-insensitive", signature: "sha256=" + strings.ToUpper(SignWebhookRequest(secret, timestamp, body)), timestamp: timestamp, body: body, want: true},
+		{name: "signed within the window", signature: "sha256=" + SignWebhookRequest(secret, "1759999760", body), timestamp: "1759999760", body: body, want: true},
+		{name: "bearer", bearer: secret, body: body, want: true},
+		{name: "wrong bearer", bearer: "not-the-key", body: body},
+		{name: "bearer wins over a valid signature", bearer: "not-the-key", signature: valid, timestamp: timestamp, body: body},
+		{name: "no credentials", body: body},
+		{name: "signature without prefix", signature: SignWebhookRequest(secret, timestamp, body), timestamp: timestamp, body: body},
+		{name: "wrong key", signature: "sha256=" + SignWebhookRequest("other", timestamp, body), timestamp: timestamp, body: body},
+		{name: "tampered body", signature: valid, timestamp: timestamp, body: []byte("water +640oz")},
+		{name: "body signed without timestamp", signature: "sha256=" + signBodyOnly(secret, body), timestamp: timestamp, body: body},
+		{name: "missing timestamp", signature: valid, body: body},
+		{name: "malformed timestamp", signature: valid, timestamp: "yesterday", body: body},
+		{name: "timestamp changed after signing", signature: valid, timestamp: "1760000001", body: body},
+		{name: "stale", signature: "sha256=" + SignWebhookRequest(secret, "1759999699", body), timestamp: "1759999699", body: body},
+		{name: "from the future", signature: "sha256=" + SignWebhookRequest(secret, "1760000301", body), timestamp: "1760000301", body: body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyWebhookRequest(secret, tt.bearer, tt.signature, tt.timestamp, tt.body, now)
+			if got != tt.want {
+				t.Errorf("verifyWebhookRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// signBodyOnly signs the body alone, the way requests were signed before timestamps,
+// which must no longer verify
+func signBodyOnly(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/events"
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// WebhookEvents lists the events outbound webhooks can subscribe to
+var WebhookEvents = []string{
+	events.CheckInRecordedEvent,
+	events.PenaltyAppliedEvent,
+	events.ChallengeCompletedEvent,
+}
+
+// WebhookAllEvents subscribes a webhook to every event in WebhookEvents
+const WebhookAllEvents = "*"
+
+// Webhook payload formats. Both are stable: fields may be added, but none are
+// renamed or removed without a new schema version.
+const (
+	// WebhookFormatStandard is {"event", "guild_id", "occurred_at", "data": {...}}
+	WebhookFormatStandard = "standard"
+	// WebhookFormatFlat puts every field at the top level, next to an id that is the
+	// same on every retry, which is what Zapier and Make map and deduplicate by
+	WebhookFormatFlat = "flat"
+)
+
+// WebhookFormats lists the payload formats a webhook can use
+var WebhookFormats = []string{WebhookFormatStandard, WebhookFormatFlat}
+
+// WebhookSchemaVersion is sent as schema_version in flat payloads
+const WebhookSchemaVersion = 1
+
+// webhookTimeout bounds one delivery so a slow receiver can't stall the outbox
+const webhookTimeout = 10 * time.Second
+
+// webhookClient POSTs outbound webhook payloads
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// Webhook is an admin-registered URL that receives event payloads
+type Webhook struct {
+	ID        int64
+	GuildID   string
+	URL       string
+	Secret    string // HMAC-SHA256 key for the X-Signature-256 header
+	Events    []string
+	Format    string // WebhookFormatStandard or WebhookFormatFlat
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// WebhookEvent is one occurrence of an event, rendered for each webhook by Payload
+type WebhookEvent struct {
+	Name       string
+	GuildID    string
+	OccurredAt time.Time
+	// Key identifies the occurrence (event, user, and day), so a re-published event
+	// gets the same flat-format id
+	Key  string
+	Data map[string]interface{}
+	Test bool // Sent by /config webhook test rather than by a member
+}
+
+// standardPayload is the JSON body of WebhookFormatStandard
+type standardPayload struct {
+	Event      string                 `json:"event"`
+	GuildID    string                 `json:"guild_id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Test       bool                   `json:"test,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// Payload renders e as the JSON body w receives. Flat payloads always have user_id
+// and username, null when privacy leaves them out, so automations can map them.
+func (w Webhook) Payload(e WebhookEvent) ([]byte, error) {
+	if w.Format != WebhookFormatFlat {
+		return json.Marshal(standardPayload{Event: e.Name, GuildID: e.GuildID, OccurredAt: e.OccurredAt, Test: e.Test, Data: e.Data})
+	}
+
+	// Keyed with the webhook's secret, so the id doesn't reveal who it's about
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(e.Key))
+
+	flat := map[string]interface{}{"user_id": nil, "username": nil}
+	for key, value := range e.Data {
+		flat[key] = value
+	}
+	flat["id"] = hex.EncodeToString(mac.Sum(nil))[:32]
+	flat["event"] = e.Name
+	flat["schema_version"] = WebhookSchemaVersion
+	flat["guild_id"] = e.GuildID
+	flat["occurred_at"] = e.OccurredAt
+	flat["test"] = e.Test
+	return json.Marshal(flat)
+}
+
+// webhookSamples is made-up data for each event, as a public member would send it
+var webhookSamples = map[string]map[string]interface{}{
+	events.CheckInRecordedEvent: {
+		"user_id":       "123456789012345678",
+		"username":      "sample_member",
+		"challenge_day": 12,
+	},
+	events.PenaltyAppliedEvent: {
+		"user_id":       "123456789012345678",
+		"username":      "sample_member",
+		"challenge_day": 12,
+		"days_added":    1,
+		"reason":        "missed check-in",
+	},
+	events.ChallengeCompletedEvent: {
+		"user_id":    "123456789012345678",
+		"username":   "sample_member",
+		"total_days": 75,
+	},
+}
+
+// WebhookSample returns an example of event for /config webhook test, so automation
+// tools can learn the fields before a real event happens
+func WebhookSample(guildID, event string) WebhookEvent {
+	now := time.Now().UTC()
+	data := map[string]interface{}{}
+	for key, value := range webhookSamples[event] {
+		data[key] = value
+	}
+	switch event {
+	case events.CheckInRecordedEvent:
+		data["date"] = now.Format("2006-01-02")
+	case events.PenaltyAppliedEvent:
+		data["last_day"] = now.AddDate(0, 0, 64).Format("2006-01-02")
+	}
+
+	// Every test gets a fresh id so tools that deduplicate still show it
+	nonce := make([]byte, 8)
+	rand.Read(nonce)
+	return WebhookEvent{Name: event, GuildID: guildID, OccurredAt: now, Key: "test:" + hex.EncodeToString(nonce), Data: data, Test: true}
+}
+
+// DeliverWebhook POSTs a payload, signed with the webhook's secret in
+// X-Signature-256 ("sha256=<hex HMAC-SHA256 of the body>"). Any non-2xx response is
+// a failure.
+func DeliverWebhook(webhookURL, secret string, body []byte) error {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "75-hard-discord-bot")
+	req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to POST webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Wants reports whether the webhook is subscribed to event
+func (w Webhook) Wants(event string) bool {
+	for _, name := range w.Events {
+		if name == WebhookAllEvents || name == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookURL checks that raw is an absolute http(s) URL
+func ValidateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return fmt.Errorf("%q is not an http(s) URL", raw)
+	}
+	return nil
+}
+
+// WebhookService stores each guild's outbound webhooks
+type WebhookService struct {
+	db *sql.DB
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService() *WebhookService {
+	return &WebhookService{}
+}
+
+// Initialize initializes the service with database connection
+func (s *WebhookService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *WebhookService) Name() string {
+	return "WebhookService"
+}
+
+// Health checks the service health
+func (s *WebhookService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Add registers a webhook for event (an entry of WebhookEvents, or WebhookAllEvents)
+// with a payload format from WebhookFormats, and returns it with its newly generated
+// signing secret
+func (s *WebhookService) Add(guildID, rawURL, event, format, createdBy string) (Webhook, error) {
+	if s.db == nil {
+		return Webhook{}, fmt.Errorf("database not available")
+	}
+	if err := ValidateWebhookURL(rawURL); err != nil {
+		return Webhook{}, err
+	}
+	known := event == WebhookAllEvents
+	for _, name := range WebhookEvents {
+		known = known || name == event
+	}
+	if !known {
+		return Webhook{}, fmt.Errorf("unknown event %q", event)
+	}
+	if format != WebhookFormatStandard && format != WebhookFormatFlat {
+		return Webhook{}, fmt.Errorf("unknown format %q", format)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return Webhook{}, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	webhook := Webhook{GuildID: guildID, URL: rawURL, Secret: hex.EncodeToString(raw), Events: []string{event}, Format: format, CreatedBy: createdBy}
+
+	logger.DB("Adding webhook: guild_id=%s, event=%s, format=%s, created_by=%s", guildID, event, format, createdBy)
+	err := s.db.QueryRow(`
+		INSERT INTO guild_webhooks (guild_id, url, secret, events, format, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING webhook_id, created_at
+	`, guildID, rawURL, webhook.Secret, event, format, createdBy).Scan(&webhook.ID, &webhook.CreatedAt)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to add webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// Remove deletes a guild's webhook and reports whether it existed. Pending deliveries
+// to it are dropped with it.
+func (s *WebhookService) Remove(guildID string, webhookID int64) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	logger.DB("Removing webhook: guild_id=%s, webhook_id=%d", guildID, webhookID)
+	result, err := s.db.Exec(`DELETE FROM guild_webhooks WHERE guild_id = $1 AND webhook_id = $2`, guildID, webhookID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove webhook: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// List returns the guild's webhooks, oldest first
+func (s *WebhookService) List(guildID string) ([]Webhook, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT webhook_id, guild_id, url, secret, events, format, created_by, created_at
+		FROM guild_webhooks
+		WHERE guild_id = $1
+		ORDER BY webhook_id
+	`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var webhook Webhook
+		var eventList string
+		if err := rows.Scan(&webhook.ID, &webhook.GuildID, &webhook.URL, &webhook.Secret, &eventList, &webhook.Format,
+			&webhook.CreatedBy, &webhook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhook.Events = strings.Split(eventList, ",")
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Get returns one of the guild's webhooks, or false when it has none with that ID
+func (s *WebhookService) Get(guildID string, webhookID int64) (Webhook, bool, error) {
+	webhooks, err := s.List(guildID)
+	if err != nil {
+		return Webhook{}, false, err
+	}
+	for _, webhook := range webhooks {
+		if webhook.ID == webhookID {
+			return webhook, true, nil
+		}
+	}
+	return Webhook{}, false, nil
+}
+
+// Subscribed returns the guild's webhooks that want event
+func (s *WebhookService) Subscribed(guildID, event string) ([]Webhook, error) {
+	webhooks, err := s.List(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed := webhooks[:0]
+	for _, webhook := range webhooks {
+		if webhook.Wants(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	return subscribed, nil
+}
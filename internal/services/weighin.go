@@ -3,7 +3,9 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/75-hard-discord-bot/internal/events"
 	"github.com/75-hard-discord-bot/internal/logger"
 )
 
@@ -11,12 +13,15 @@ import (
 type WeighInService struct {
 	db          *sql.DB
 	userService *UserService
+	events      *events.Bus
 }
 
-// NewWeighInService creates a new weigh-in service
-func NewWeighInService(userService *UserService) *WeighInService {
+// NewWeighInService creates a new weigh-in service. Recorded weigh-ins are
+// published on bus.
+func NewWeighInService(userService *UserService, bus *events.Bus) *WeighInService {
 	return &WeighInService{
 		userService: userService,
+		events:      bus,
 	}
 }
 
@@ -39,11 +44,12 @@ func (s *WeighInService) Health() error {
 	return s.db.Ping()
 }
 
-// RecordWeighIn records a weigh-in for the user
-func (s *WeighInService) RecordWeighIn(userID, username string, weightLbs float64, notes string) error {
+// RecordWeighIn records a weigh-in for the user; weight is in the user's units
+func (s *WeighInService) RecordWeighIn(userID, username string, weight float64, units Units, notes string) error {
 	if s.db == nil {
 		return fmt.Errorf("database not available")
 	}
+	weightLbs := units.ToPounds(weight)
 
 	// Ensure user exists
 	err := s.userService.EnsureUserExists(userID, username)
@@ -51,8 +57,8 @@ func (s *WeighInService) RecordWeighIn(userID, username string, weightLbs float6
 		return fmt.Errorf("failed to ensure user exists: %w", err)
 	}
 
-	// Get current challenge day
-	challengeDay, err := s.userService.GetCurrentChallengeDay(userID)
+	// Get current challenge date and day
+	completionDate, challengeDay, err := s.userService.GetWritableDay(userID)
 	if err != nil {
 		return fmt.Errorf("failed to get challenge day: %w", err)
 	}
@@ -60,9 +66,9 @@ func (s *WeighInService) RecordWeighIn(userID, username string, weightLbs float6
 	// Insert weigh-in (allows multiple per day)
 	logger.DB("Recording weigh-in: user_id=%s, challenge_day=%d, weight=%.2f lbs", userID, challengeDay, weightLbs)
 	_, err = s.db.Exec(
-		`INSERT INTO weigh_ins (user_id, challenge_day, weight_lbs, notes)
-		 VALUES ($1, $2, $3, $4)`,
-		userID, challengeDay, weightLbs, notes,
+		`INSERT INTO weigh_ins (user_id, challenge_day, completion_date, weight_lbs, notes)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		userID, challengeDay, completionDate.Format("2006-01-02"), weightLbs, notes,
 	)
 	if err != nil {
 		logger.Error("Failed to record weigh-in: %v", err)
@@ -70,11 +76,17 @@ func (s *WeighInService) RecordWeighIn(userID, username string, weightLbs float6
 	}
 
 	logger.DB("Successfully recorded weigh-in for user_id=%s, challenge_day=%d, weight=%.2f lbs", userID, challengeDay, weightLbs)
+	s.events.Publish(events.WeighInRecorded{
+		UserID:       userID,
+		Username:     username,
+		ChallengeDay: challengeDay,
+		Pounds:       weightLbs,
+	})
 	return nil
 }
 
-// GetLatestWeighIn gets the most recent weigh-in for a user
-func (s *WeighInService) GetLatestWeighIn(userID string) (float64, int, error) {
+// GetLatestWeighIn gets the most recent weigh-in for a user, in the user's units
+func (s *WeighInService) GetLatestWeighIn(userID string, units Units) (float64, int, error) {
 	if s.db == nil {
 		return 0, 0, fmt.Errorf("database not available")
 	}
@@ -98,11 +110,11 @@ func (s *WeighInService) GetLatestWeighIn(userID string) (float64, int, error) {
 		return 0, 0, fmt.Errorf("failed to get latest weigh-in: %w", err)
 	}
 
-	return weight, challengeDay, nil
+	return units.FromPounds(weight), challengeDay, nil
 }
 
-// GetWeighInHistory gets weigh-in history for a user (optional limit)
-func (s *WeighInService) GetWeighInHistory(userID string, limit int) ([]map[string]interface{}, error) {
+// GetWeighInHistory gets weigh-in history for a user (optional limit); "weight" is in the user's units
+func (s *WeighInService) GetWeighInHistory(userID string, limit int, units Units) ([]map[string]interface{}, error) {
 	if s.db == nil {
 		return nil, fmt.Errorf("database not available")
 	}
@@ -139,6 +151,7 @@ func (s *WeighInService) GetWeighInHistory(userID string, limit int) ([]map[stri
 
 		entry := map[string]interface{}{
 			"challenge_day": challengeDay,
+			"weight":        units.FromPounds(weight),
 			"weight_lbs":    weight,
 			"weighed_at":    weighedAt.Time,
 			"notes":         notes.String,
@@ -148,3 +161,38 @@ func (s *WeighInService) GetWeighInHistory(userID string, limit int) ([]map[stri
 
 	return history, nil
 }
+
+// ImportWeighIn records a weigh-in taken at weighedAt from an import (source, e.g.
+// "apple_health"), on the challenge day of its date. A day that already has a
+// weigh-in from the same source is left alone, so importing again adds nothing.
+// Days outside the user's challenge or after today are skipped; returns whether it was recorded.
+func (s *WeighInService) ImportWeighIn(userID string, weighedAt time.Time, pounds float64, source string) (bool, error) {
+	if s.db == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	progress, err := s.userService.GetProgress(userID)
+	if err != nil {
+		return false, err
+	}
+	challengeDay, err := ValidateChallengeDay(progress, weighedAt)
+	if err != nil {
+		return false, nil
+	}
+
+	logger.DB("Importing weigh-in: user_id=%s, challenge_day=%d, weight=%.2f lbs, source=%s", userID, challengeDay, pounds, source)
+	result, err := s.db.Exec(
+		`INSERT INTO weigh_ins (user_id, challenge_day, completion_date, weight_lbs, weighed_at, metadata)
+		 SELECT $1, $2, $3, $4, $5, jsonb_build_object('source', $6::text)
+		 WHERE NOT EXISTS (
+			SELECT 1 FROM weigh_ins
+			WHERE user_id = $1 AND challenge_day = $2 AND metadata->>'source' = $6
+		 )`,
+		userID, challengeDay, weighedAt.Format("2006-01-02"), pounds, weighedAt, source,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to import weigh-in: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+	"github.com/lib/pq"
+)
+
+// MaxWorkoutTimerDuration is how long a workout timer runs before it's taken as
+// forgotten and expires without logging anything
+const MaxWorkoutTimerDuration = 4 * time.Hour
+
+// Workout timer statuses
+const (
+	TimerRunning   = "running"
+	TimerStopped   = "stopped" // Measured, waiting for the workout's type and location
+	TimerLogged    = "logged"
+	TimerCancelled = "cancelled"
+	TimerExpired   = "expired"
+)
+
+var (
+	// ErrTimerRunning is returned when a workout timer is started while another is open
+	ErrTimerRunning = errors.New("a workout timer is already open")
+	// ErrTimerNotFound is returned for a timer that doesn't exist or isn't the user's
+	ErrTimerNotFound = errors.New("workout timer not found")
+	// ErrTimerClosed is returned for a timer that was already logged, cancelled, or expired
+	ErrTimerClosed = errors.New("workout timer is closed")
+	// ErrWorkoutTooShort is returned when a timer is stopped before MinWorkoutMinutes
+	ErrWorkoutTooShort = errors.New("workout is too short")
+)
+
+// WorkoutTimer is a workout being timed with /exercise start
+type WorkoutTimer struct {
+	ID        int64
+	UserID    string
+	ChannelID string
+	MessageID string // The message showing the elapsed time; empty until posted
+	StartedAt time.Time
+	EndedAt   time.Time // Zero while running
+	Minutes   int       // Whole minutes measured, set once stopped
+	Status    string
+}
+
+// Elapsed returns how long the timer has run, up to now or when it was stopped
+func (t WorkoutTimer) Elapsed(now time.Time) time.Duration {
+	if !t.EndedAt.IsZero() {
+		return t.EndedAt.Sub(t.StartedAt)
+	}
+	return now.Sub(t.StartedAt)
+}
+
+// WorkoutTimerService times workouts server-side, so members can log the measured
+// duration instead of an estimate
+type WorkoutTimerService struct {
+	db          *sql.DB
+	userService *UserService
+}
+
+// NewWorkoutTimerService creates a new workout timer service
+func NewWorkoutTimerService(userService *UserService) *WorkoutTimerService {
+	return &WorkoutTimerService{
+		userService: userService,
+	}
+}
+
+// Initialize initializes the service with database connection
+func (s *WorkoutTimerService) Initialize(db *sql.DB) error {
+	s.db = db
+	return nil
+}
+
+// Name returns the service name
+func (s *WorkoutTimerService) Name() string {
+	return "WorkoutTimerService"
+}
+
+// Health checks the service health
+func (s *WorkoutTimerService) Health() error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.Ping()
+}
+
+// Start starts a workout timer whose message will be posted in channelID. A timer
+// the user already has open is returned with ErrTimerRunning.
+func (s *WorkoutTimerService) Start(userID, username, channelID string) (WorkoutTimer, error) {
+	if s.db == nil {
+		return WorkoutTimer{}, fmt.Errorf("database not available")
+	}
+	if err := s.userService.EnsureUserExists(userID, username); err != nil {
+		return WorkoutTimer{}, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+	// Checked now so nobody times a workout that can't be logged
+	if _, _, err := s.userService.GetWritableDay(userID); err != nil {
+		return WorkoutTimer{}, fmt.Errorf("failed to get challenge day: %w", err)
+	}
+
+	open, err := scanWorkoutTimer(s.db.QueryRow(
+		`SELECT `+workoutTimerColumns+` FROM workout_timers WHERE user_id = $1 AND status IN ($2, $3)`,
+		userID, TimerRunning, TimerStopped,
+	))
+	if err == nil {
+		return open, ErrTimerRunning
+	}
+	if err != sql.ErrNoRows {
+		return WorkoutTimer{}, fmt.Errorf("failed to check for an open timer: %w", err)
+	}
+
+	logger.DB("Starting workout timer: user_id=%s, channel_id=%s", userID, channelID)
+	timer := WorkoutTimer{UserID: userID, ChannelID: channelID, Status: TimerRunning}
+	err = s.db.QueryRow(
+		`INSERT INTO workout_timers (user_id, channel_id) VALUES ($1, $2) RETURNING id, started_at`,
+		userID, channelID,
+	).Scan(&timer.ID, &timer.StartedAt)
+	if err != nil {
+		return WorkoutTimer{}, fmt.Errorf("failed to start workout timer: %w", err)
+	}
+	return timer, nil
+}
+
+// SetMessage records the message showing the timer's elapsed time
+func (s *WorkoutTimerService) SetMessage(timerID int64, messageID string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	_, err := s.db.Exec(`UPDATE workout_timers SET message_id = $2 WHERE id = $1`, timerID, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to save timer message: %w", err)
+	}
+	return nil
+}
+
+// Stop stops the user's running timer and measures the workout. Stopping before
+// MinWorkoutMinutes returns the timer, still running, with ErrWorkoutTooShort, and a
+// timer that's already stopped is returned as it is.
+func (s *WorkoutTimerService) Stop(userID string, timerID int64) (WorkoutTimer, error) {
+	timer, err := s.load(userID, timerID)
+	if err != nil {
+		return WorkoutTimer{}, err
+	}
+	switch timer.Status {
+	case TimerStopped:
+		return timer, nil
+	case TimerRunning:
+	default:
+		return timer, ErrTimerClosed
+	}
+
+	now := time.Now()
+	elapsed := timer.Elapsed(now)
+	if elapsed > MaxWorkoutTimerDuration {
+		if err := s.Expire(timerID); err != nil {
+			return WorkoutTimer{}, err
+		}
+		timer.Status = TimerExpired
+		return timer, ErrTimerClosed
+	}
+	timer.Minutes = int(elapsed.Minutes())
+	if timer.Minutes < MinWorkoutMinutes {
+		return timer, ErrWorkoutTooShort
+	}
+
+	logger.DB("Stopping workout timer: user_id=%s, timer_id=%d, minutes=%d", userID, timerID, timer.Minutes)
+	result, err := s.db.Exec(
+		`UPDATE workout_timers SET status = $3, ended_at = $4, minutes = $5
+		 WHERE id = $1 AND user_id = $2 AND status = $6`,
+		timerID, userID, TimerStopped, now, timer.Minutes, TimerRunning,
+	)
+	if err != nil {
+		return WorkoutTimer{}, fmt.Errorf("failed to stop workout timer: %w", err)
+	}
+	// A second press can race the first; either way the timer is now stopped
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return s.load(userID, timerID)
+	}
+	timer.Status = TimerStopped
+	timer.EndedAt = now
+	return timer, nil
+}
+
+// MarkLogged closes a stopped timer once its workout has been logged
+func (s *WorkoutTimerService) MarkLogged(userID string, timerID int64) error {
+	return s.close(userID, timerID, TimerLogged, TimerStopped)
+}
+
+// Cancel discards the user's running or stopped timer without logging anything
+func (s *WorkoutTimerService) Cancel(userID string, timerID int64) error {
+	return s.close(userID, timerID, TimerCancelled, TimerRunning, TimerStopped)
+}
+
+// Expire closes a running timer that was left running past MaxWorkoutTimerDuration
+func (s *WorkoutTimerService) Expire(timerID int64) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	_, err := s.db.Exec(
+		`UPDATE workout_timers SET status = $2, ended_at = NOW() WHERE id = $1 AND status = $3`,
+		timerID, TimerExpired, TimerRunning,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to expire workout timer: %w", err)
+	}
+	return nil
+}
+
+// Running returns every running timer whose message has been posted, oldest first
+func (s *WorkoutTimerService) Running() ([]WorkoutTimer, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	rows, err := s.db.Query(
+		`SELECT `+workoutTimerColumns+` FROM workout_timers
+		 WHERE status = $1 AND message_id IS NOT NULL
+		 ORDER BY started_at`,
+		TimerRunning,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load running timers: %w", err)
+	}
+	defer rows.Close()
+
+	var timers []WorkoutTimer
+	for rows.Next() {
+		timer, err := scanWorkoutTimer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read running timer: %w", err)
+		}
+		timers = append(timers, timer)
+	}
+	return timers, rows.Err()
+}
+
+// load returns one of the user's timers, or ErrTimerNotFound
+func (s *WorkoutTimerService) load(userID string, timerID int64) (WorkoutTimer, error) {
+	if s.db == nil {
+		return WorkoutTimer{}, fmt.Errorf("database not available")
+	}
+	timer, err := scanWorkoutTimer(s.db.QueryRow(
+		`SELECT `+workoutTimerColumns+` FROM workout_timers WHERE id = $1 AND user_id = $2`,
+		timerID, userID,
+	))
+	if err == sql.ErrNoRows {
+		return WorkoutTimer{}, ErrTimerNotFound
+	}
+	if err != nil {
+		return WorkoutTimer{}, fmt.Errorf("failed to load workout timer: %w", err)
+	}
+	return timer, nil
+}
+
+// close moves one of the user's timers to status from any of the statuses in from,
+// returning ErrTimerClosed when it had already moved on
+func (s *WorkoutTimerService) close(userID string, timerID int64, status string, from ...string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	result, err := s.db.Exec(
+		`UPDATE workout_timers SET status = $3, ended_at = COALESCE(ended_at, NOW())
+		 WHERE id = $1 AND user_id = $2 AND status = ANY($4)`,
+		timerID, userID, status, pq.Array(from),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close workout timer: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		if _, err := s.load(userID, timerID); err != nil {
+			return err
+		}
+		return ErrTimerClosed
+	}
+	return nil
+}
+
+// workoutTimerColumns are the columns scanWorkoutTimer reads, in order
+const workoutTimerColumns = `id, user_id, channel_id, COALESCE(message_id, ''), started_at, ended_at, COALESCE(minutes, 0), status`
+
+// scanWorkoutTimer reads a row of workoutTimerColumns
+func scanWorkoutTimer(row interface{ Scan(...interface{}) error }) (WorkoutTimer, error) {
+	var timer WorkoutTimer
+	var endedAt sql.NullTime
+	err := row.Scan(&timer.ID, &timer.UserID, &timer.ChannelID, &timer.MessageID, &timer.StartedAt, &endedAt, &timer.Minutes, &timer.Status)
+	timer.EndedAt = endedAt.Time
+	return timer, err
+}
@@ -0,0 +1,266 @@
+// Package sheets is a small client for the parts of the Google Sheets API the bot uses,
+// authenticating as a service account.
+package sheets
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiURL          = "https://sheets.googleapis.com/v4/spreadsheets/"
+	defaultTokenURL = "https://oauth2.googleapis.com/token"
+
+	// Scope lets the service account edit spreadsheets shared with it
+	Scope = "https://www.googleapis.com/auth/spreadsheets"
+)
+
+// ServiceAccount is a Google Cloud service account key, as downloaded in JSON
+type ServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+	key         *rsa.PrivateKey
+}
+
+// ParseServiceAccount reads a service account's JSON key
+func ParseServiceAccount(data []byte) (*ServiceAccount, error) {
+	var account ServiceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("not a service account JSON key: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("service account key is missing client_email or private_key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = defaultTokenURL
+	}
+
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("service account private_key is not PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account private_key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service account private_key is not an RSA key")
+	}
+	account.key = key
+	return &account, nil
+}
+
+// Tab is one sheet of a spreadsheet and the rows to fill it with
+type Tab struct {
+	Title string
+	Rows  [][]interface{} // Strings and numbers; the first row is usually a header
+}
+
+// Client calls the Sheets API as a service account. Spreadsheets must be shared with
+// the account's email address before it can edit them.
+type Client struct {
+	account    *ServiceAccount
+	httpClient *http.Client
+	mu         sync.Mutex
+	token      string
+	expiresAt  time.Time
+}
+
+// NewClient creates a new Sheets client
+func NewClient(account *ServiceAccount) *Client {
+	return &Client{
+		account:    account,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Email returns the address spreadsheets have to be shared with
+func (c *Client) Email() string {
+	return c.account.ClientEmail
+}
+
+// Replace overwrites each tab's contents with its rows, adding tabs the spreadsheet
+// doesn't have yet. Other tabs (e.g. the members' own pivot tables) are left alone.
+func (c *Client) Replace(spreadsheetID string, tabs []Tab) error {
+	base := apiURL + url.PathEscape(spreadsheetID)
+
+	var spreadsheet struct {
+		Sheets []struct {
+			Properties struct {
+				Title string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}
+	if err := c.do(http.MethodGet, base+"?fields=sheets.properties.title", nil, &spreadsheet); err != nil {
+		return fmt.Errorf("failed to open spreadsheet: %w", err)
+	}
+	existing := make(map[string]bool)
+	for _, sheet := range spreadsheet.Sheets {
+		existing[sheet.Properties.Title] = true
+	}
+
+	var addSheets []interface{}
+	ranges := make([]string, 0, len(tabs))
+	data := make([]interface{}, 0, len(tabs))
+	for _, tab := range tabs {
+		if !existing[tab.Title] {
+			addSheets = append(addSheets, map[string]interface{}{
+				"addSheet": map[string]interface{}{"properties": map[string]string{"title": tab.Title}},
+			})
+		}
+		ranges = append(ranges, quoteTitle(tab.Title))
+		data = append(data, map[string]interface{}{"range": quoteTitle(tab.Title) + "!A1", "values": tab.Rows})
+	}
+
+	if len(addSheets) > 0 {
+		if err := c.do(http.MethodPost, base+":batchUpdate", map[string]interface{}{"requests": addSheets}, nil); err != nil {
+			return fmt.Errorf("failed to add sheets: %w", err)
+		}
+	}
+	// Clearing first drops rows left over from a longer previous sync
+	if err := c.do(http.MethodPost, base+"/values:batchClear", map[string]interface{}{"ranges": ranges}, nil); err != nil {
+		return fmt.Errorf("failed to clear sheets: %w", err)
+	}
+	// RAW stores values as given, so a username like "=IMPORTXML(...)" isn't run as a formula
+	update := map[string]interface{}{"valueInputOption": "RAW", "data": data}
+	if err := c.do(http.MethodPost, base+"/values:batchUpdate", update, nil); err != nil {
+		return fmt.Errorf("failed to write sheets: %w", err)
+	}
+	return nil
+}
+
+// quoteTitle quotes a sheet title for A1 notation
+func quoteTitle(title string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
+
+// accessToken returns a cached access token, signing in again shortly before it expires
+func (c *Client) accessToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Until(c.expiresAt) > time.Minute {
+		return c.token, nil
+	}
+
+	assertion, err := c.assertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	resp, err := c.httpClient.PostForm(c.account.TokenURI, form)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("google token endpoint responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"` // Seconds
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode access token: %w", err)
+	}
+	c.token = token.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return c.token, nil
+}
+
+// assertion builds the signed JWT a service account trades for an access token
+func (c *Client) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   c.account.ClientEmail,
+		"scope": Scope,
+		"aud":   c.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.account.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token request: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// do sends a JSON request and decodes a JSON reply into out
+func (c *Client) do(method, target string, in, out interface{}) error {
+	token, err := c.accessToken()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(message, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("google sheets responded %d: %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return fmt.Errorf("google sheets responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package shutdown
+
+import (
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+// hook is a named cleanup step run during shutdown
+type hook struct {
+	name string
+	fn   func()
+}
+
+// Coordinator tracks in-flight work and runs cleanup steps in order on shutdown.
+// Once shutdown starts no new work is accepted; outstanding work gets a grace
+// period to finish before jobs, the Discord session, and the database are closed.
+type Coordinator struct {
+	mu       sync.RWMutex
+	closing  bool
+	inFlight sync.WaitGroup
+	hooks    []hook
+}
+
+// New creates a new shutdown coordinator
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// Acquire registers a unit of in-flight work. It returns false once shutdown has
+// started, in which case the work must not run. Every successful Acquire must be
+// paired with Release.
+func (c *Coordinator) Acquire() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closing {
+		return false
+	}
+	c.inFlight.Add(1)
+	return true
+}
+
+// Release marks a unit of in-flight work as finished
+func (c *Coordinator) Release() {
+	c.inFlight.Done()
+}
+
+// ShuttingDown reports whether shutdown has started
+func (c *Coordinator) ShuttingDown() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.closing
+}
+
+// OnShutdown registers a cleanup step; steps run in registration order after draining
+func (c *Coordinator) OnShutdown(name string, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook{name: name, fn: fn})
+}
+
+// Shutdown stops accepting work, waits up to timeout for in-flight work, then runs cleanup steps
+func (c *Coordinator) Shutdown(timeout time.Duration) {
+	c.mu.Lock()
+	c.closing = true
+	hooks := c.hooks
+	c.mu.Unlock()
+
+	logger.Info("Draining in-flight handlers (timeout %s)...", timeout)
+	drained := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		logger.Info("✅ All in-flight handlers finished")
+	case <-time.After(timeout):
+		logger.Warn("⚠️  Timed out after %s waiting for in-flight handlers; continuing shutdown", timeout)
+	}
+
+	for _, h := range hooks {
+		logger.Info("Shutting down %s...", h.name)
+		h.fn()
+	}
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config holds connection details for an S3-compatible object store
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Client uploads objects to S3-compatible storage using path-style URLs
+// and AWS Signature Version 4, so it works with AWS, MinIO, R2, B2, etc.
+type S3Client struct {
+	config     S3Config
+	httpClient *http.Client
+}
+
+// NewS3Client creates a new S3 client
+func NewS3Client(config S3Config) *S3Client {
+	return &S3Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// PutObject uploads data under the given key
+func (c *S3Client) PutObject(key string, data []byte, contentType string) error {
+	objectURL, err := c.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", contentType)
+
+	c.sign(req, data, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// DeleteObject removes the object under key; deleting a key that doesn't exist succeeds
+func (c *S3Client) DeleteObject(key string) error {
+	objectURL, err := c.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, objectURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build delete request: %w", err)
+	}
+
+	c.sign(req, nil, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("delete failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// PresignGetObject returns a URL that downloads the object under key without
+// credentials until expires passes (at most 7 days)
+func (c *S3Client) PresignGetObject(key string, expires time.Duration) (string, error) {
+	objectURL, err := c.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build download request: %w", err)
+	}
+
+	PresignV4(req, "s3", AWSCredentials{
+		Region:          c.config.Region,
+		AccessKeyID:     c.config.AccessKeyID,
+		SecretAccessKey: c.config.SecretAccessKey,
+	}, time.Now().UTC(), expires)
+	return req.URL.String(), nil
+}
+
+// objectURL builds the path-style URL for a key
+func (c *S3Client) objectURL(key string) (*url.URL, error) {
+	base, err := url.Parse(strings.TrimRight(c.config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	segments := strings.Split(strings.TrimLeft(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	base.RawPath = base.Path + "/" + url.PathEscape(c.config.Bucket) + "/" + strings.Join(segments, "/")
+	base.Path, _ = url.PathUnescape(base.RawPath)
+	return base, nil
+}
+
+// sign adds AWS Signature Version 4 headers to the request
+func (c *S3Client) sign(req *http.Request, payload []byte, now time.Time) {
+	SignV4(req, payload, "s3", AWSCredentials{
+		Region:          c.config.Region,
+		AccessKeyID:     c.config.AccessKeyID,
+		SecretAccessKey: c.config.SecretAccessKey,
+	}, now)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// hmacSHA256 returns HMAC-SHA256(key, data)
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
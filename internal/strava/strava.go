@@ -0,0 +1,243 @@
+// Package strava is a small client for the parts of the Strava API the bot uses:
+// OAuth, push subscriptions, and reading activities.
+package strava
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	authorizeURL = "https://www.strava.com/oauth/authorize"
+	tokenURL     = "https://www.strava.com/oauth/token"
+	deauthURL    = "https://www.strava.com/oauth/deauthorize"
+	apiURL       = "https://www.strava.com/api/v3"
+
+	// Scope lets the bot read private activities too, since most members don't share publicly
+	Scope = "activity:read_all"
+)
+
+// Token is an OAuth token for one athlete
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	AthleteID    string // Set when the token comes from a code exchange
+}
+
+// Activity is the subset of a Strava activity the bot imports
+type Activity struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	SportType      string    `json:"sport_type"`
+	MovingTime     int       `json:"moving_time"` // Seconds
+	StartDateLocal time.Time `json:"start_date_local"`
+	Trainer        bool      `json:"trainer"`
+	Manual         bool      `json:"manual"`
+	StartLatLng    []float64 `json:"start_latlng"`
+}
+
+// Minutes returns the activity's moving time in whole minutes
+func (a Activity) Minutes() int {
+	return a.MovingTime / 60
+}
+
+// Outdoor reports whether the activity was recorded outside: GPS was used and it
+// wasn't on a trainer or in a virtual world
+func (a Activity) Outdoor() bool {
+	return !a.Trainer && !a.Manual && len(a.StartLatLng) == 2 && !strings.HasPrefix(a.SportType, "Virtual")
+}
+
+// WorkoutType returns the activity's sport as a workout type, e.g. "trail run"
+func (a Activity) WorkoutType() string {
+	sport := a.SportType
+	if sport == "" {
+		sport = a.Type
+	}
+	var words strings.Builder
+	for i, r := range sport {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			words.WriteByte(' ')
+		}
+		words.WriteRune(r)
+	}
+	return strings.ToLower(words.String())
+}
+
+// Date returns the calendar day the activity started on, in the athlete's zone
+func (a Activity) Date() time.Time {
+	// start_date_local is the local wall time labeled as UTC
+	return time.Date(a.StartDateLocal.Year(), a.StartDateLocal.Month(), a.StartDateLocal.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Event is a push subscription event
+type Event struct {
+	ObjectType string            `json:"object_type"` // "activity" or "athlete"
+	ObjectID   int64             `json:"object_id"`
+	AspectType string            `json:"aspect_type"` // "create", "update", or "delete"
+	OwnerID    int64             `json:"owner_id"`
+	Updates    map[string]string `json:"updates"`
+}
+
+// Deauthorized reports whether the athlete revoked the bot's access
+func (e Event) Deauthorized() bool {
+	return e.ObjectType == "athlete" && e.Updates["authorized"] == "false"
+}
+
+// Client calls the Strava API with the app's client credentials
+type Client struct {
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+}
+
+// NewClient creates a new Strava client
+func NewClient(clientID, clientSecret string) *Client {
+	return &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// AuthorizeURL returns the page where an athlete approves the bot. Strava redirects
+// back to redirectURL with state and a code for Exchange.
+func (c *Client) AuthorizeURL(redirectURL, state string) string {
+	query := url.Values{
+		"client_id":       {c.clientID},
+		"response_type":   {"code"},
+		"redirect_uri":    {redirectURL},
+		"approval_prompt": {"auto"},
+		"scope":           {Scope},
+		"state":           {state},
+	}
+	return authorizeURL + "?" + query.Encode()
+}
+
+// tokenResponse is the body of a token exchange or refresh
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    int64  `json:"expires_at"`
+	Athlete      struct {
+		ID int64 `json:"id"`
+	} `json:"athlete"`
+}
+
+// Exchange trades an authorization code for a token
+func (c *Client) Exchange(code string) (Token, error) {
+	return c.token(url.Values{"code": {code}, "grant_type": {"authorization_code"}})
+}
+
+// Refresh gets a new access token; Strava may rotate the refresh token too
+func (c *Client) Refresh(refreshToken string) (Token, error) {
+	return c.token(url.Values{"refresh_token": {refreshToken}, "grant_type": {"refresh_token"}})
+}
+
+// token posts a grant to the token endpoint
+func (c *Client) token(form url.Values) (Token, error) {
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+
+	var body tokenResponse
+	if err := c.do(http.MethodPost, tokenURL, "", form, &body); err != nil {
+		return Token{}, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	token := Token{AccessToken: body.AccessToken, RefreshToken: body.RefreshToken, ExpiresAt: time.Unix(body.ExpiresAt, 0)}
+	if body.Athlete.ID != 0 {
+		token.AthleteID = strconv.FormatInt(body.Athlete.ID, 10)
+	}
+	return token, nil
+}
+
+// Deauthorize revokes the bot's access to an athlete's account
+func (c *Client) Deauthorize(accessToken string) error {
+	if err := c.do(http.MethodPost, deauthURL, "", url.Values{"access_token": {accessToken}}, nil); err != nil {
+		return fmt.Errorf("failed to deauthorize: %w", err)
+	}
+	return nil
+}
+
+// Activity fetches one of the athlete's activities
+func (c *Client) Activity(accessToken string, id int64) (Activity, error) {
+	var activity Activity
+	if err := c.do(http.MethodGet, fmt.Sprintf("%s/activities/%d", apiURL, id), accessToken, nil, &activity); err != nil {
+		return Activity{}, fmt.Errorf("failed to get activity %d: %w", id, err)
+	}
+	return activity, nil
+}
+
+// EnsureSubscription creates the app's push subscription to callbackURL unless one
+// exists. Strava allows one per app and confirms it by calling callbackURL with
+// verifyToken, so the server must already be reachable there.
+func (c *Client) EnsureSubscription(callbackURL, verifyToken string) error {
+	credentials := url.Values{"client_id": {c.clientID}, "client_secret": {c.clientSecret}}
+
+	var existing []struct {
+		ID          int64  `json:"id"`
+		CallbackURL string `json:"callback_url"`
+	}
+	if err := c.do(http.MethodGet, apiURL+"/push_subscriptions?"+credentials.Encode(), "", nil, &existing); err != nil {
+		return fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	for _, subscription := range existing {
+		if subscription.CallbackURL == callbackURL {
+			return nil
+		}
+		// Only one is allowed, so an old callback URL has to go first
+		if err := c.do(http.MethodDelete, fmt.Sprintf("%s/push_subscriptions/%d?%s", apiURL, subscription.ID, credentials.Encode()), "", nil, nil); err != nil {
+			return fmt.Errorf("failed to delete push subscription %d: %w", subscription.ID, err)
+		}
+	}
+
+	credentials.Set("callback_url", callbackURL)
+	credentials.Set("verify_token", verifyToken)
+	if err := c.do(http.MethodPost, apiURL+"/push_subscriptions", "", credentials, nil); err != nil {
+		return fmt.Errorf("failed to create push subscription: %w", err)
+	}
+	return nil
+}
+
+// do sends a request, form-encoding form when set, and decodes a JSON reply into out
+func (c *Client) do(method, target, accessToken string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("strava responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/75-hard-discord-bot/internal/logger"
+)
+
+const (
+	// exportInterval is how often queued spans are sent
+	exportInterval = 5 * time.Second
+	// maxQueuedSpans drops spans beyond this many waiting for export, so a down collector can't grow memory
+	maxQueuedSpans = 2048
+)
+
+// Exporter batches finished spans and posts them to an OTLP/HTTP collector
+type Exporter struct {
+	endpoint    string
+	serviceName string
+	headers     map[string]string
+	httpClient  *http.Client
+
+	mu      sync.Mutex
+	queue   []*Span
+	dropped int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewExporter creates an exporter for a collector base URL (e.g. http://otel-collector:4318);
+// spans are posted to {endpoint}/v1/traces
+func NewExporter(endpoint, serviceName string, headers map[string]string) *Exporter {
+	return &Exporter{
+		endpoint:    strings.TrimRight(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		headers:     headers,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start begins sending queued spans in the background
+func (e *Exporter) Start() {
+	if e.stop != nil {
+		return
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+
+	go func(stop <-chan struct{}, done chan<- struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(exportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				e.flush()
+			case <-stop:
+				e.flush()
+				return
+			}
+		}
+	}(e.stop, e.done)
+}
+
+// Stop sends any remaining spans and stops the background sender
+func (e *Exporter) Stop() {
+	if e.stop == nil {
+		return
+	}
+	close(e.stop)
+	<-e.done // Let the final export finish
+	e.stop = nil
+}
+
+// enqueue adds a finished span to the next batch
+func (e *Exporter) enqueue(span *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.queue) >= maxQueuedSpans {
+		e.dropped++
+		return
+	}
+	e.queue = append(e.queue, span)
+}
+
+// flush exports everything queued so far
+func (e *Exporter) flush() {
+	e.mu.Lock()
+	batch, dropped := e.queue, e.dropped
+	e.queue, e.dropped = nil, 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		logger.Warn("⚠️  Dropped %d trace spans: export queue full", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	if err := e.export(batch); err != nil {
+		logger.Warn("⚠️  Failed to export %d trace spans: %v", len(batch), err)
+	}
+}
+
+// export posts one batch as an OTLP ExportTraceServiceRequest
+func (e *Exporter) export(batch []*Span) error {
+	spans := make([]map[string]interface{}, 0, len(batch))
+	for _, span := range batch {
+		spans = append(spans, span.otlp())
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(map[string]interface{}{"service.name": e.serviceName}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "github.com/75-hard-discord-bot"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send spans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// otlp converts a finished span to its OTLP JSON form
+func (s *Span) otlp() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	span := map[string]interface{}{
+		"traceId":           s.traceID,
+		"spanId":            s.spanID,
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        otlpAttributes(s.attrs),
+	}
+	if s.parentID != "" {
+		span["parentSpanId"] = s.parentID
+	}
+	if s.err != nil {
+		span["status"] = map[string]interface{}{"code": 2, "message": s.err.Error()}
+	}
+	return span
+}
+
+// otlpAttributes converts attributes to OTLP key/value pairs; 64-bit ints are strings in OTLP JSON
+func otlpAttributes(attrs map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(attrs))
+	for key, value := range attrs {
+		var otlpValue map[string]interface{}
+		switch v := value.(type) {
+		case bool:
+			otlpValue = map[string]interface{}{"boolValue": v}
+		case int:
+			otlpValue = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int64:
+			otlpValue = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			otlpValue = map[string]interface{}{"doubleValue": v}
+		default:
+			otlpValue = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		result = append(result, map[string]interface{}{"key": key, "value": otlpValue})
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"net/http"
+)
+
+// Transport wraps an http.RoundTripper so requests made under a traced context get a
+// client span, e.g. Discord REST calls passed discordgo.WithContext(ctx)
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip records the request method, path, and response status on a child span
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, span := StartChild(req.Context(), "HTTP "+req.Method, KindClient,
+		"http.request.method", req.Method,
+		"server.address", req.URL.Host,
+		"url.path", req.URL.Path,
+	)
+	defer span.End()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes("http.response.status_code", resp.StatusCode)
+	return resp, nil
+}
@@ -0,0 +1,149 @@
+// Package tracing records spans for interactions, service calls, SQL queries, and Discord
+// REST calls, and exports them to an OpenTelemetry collector over OTLP/HTTP (JSON encoding).
+//
+// Spans travel in a context.Context. Child spans are only recorded under an existing span,
+// so background queries don't produce traces of their own; with no exporter set, every
+// function here is a no-op.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Span kinds, as numbered by OTLP
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Span is one timed operation in a trace
+type Span struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+
+	mu    sync.Mutex
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+type spanKey struct{}
+
+var (
+	exporterMu sync.RWMutex
+	exporter   *Exporter
+)
+
+// SetExporter turns tracing on by sending finished spans to e; nil turns it off
+func SetExporter(e *Exporter) {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+	exporter = e
+}
+
+// currentExporter returns the exporter, or nil when tracing is off
+func currentExporter() *Exporter {
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+	return exporter
+}
+
+// Start begins a span under the span in ctx, or a new trace if there is none.
+// Attributes are key/value pairs, e.g. Start(ctx, "interaction summary", "discord.guild_id", id).
+func Start(ctx context.Context, name string, kind int, attrs ...interface{}) (context.Context, *Span) {
+	if currentExporter() == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		spanID: randomHex(8),
+		name:   name,
+		kind:   kind,
+		start:  time.Now(),
+		attrs:  make(map[string]interface{}),
+	}
+	if parent := FromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		span.traceID = randomHex(16)
+	}
+	span.SetAttributes(attrs...)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// StartChild begins a span only when ctx already holds one, so untraced work stays untraced
+func StartChild(ctx context.Context, name string, kind int, attrs ...interface{}) (context.Context, *Span) {
+	if FromContext(ctx) == nil {
+		return ctx, nil
+	}
+	return Start(ctx, name, kind, attrs...)
+}
+
+// FromContext returns the span in ctx, if any
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SetAttributes adds key/value pairs to the span; safe to call on a nil span
+func (s *Span) SetAttributes(attrs ...interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i+1 < len(attrs); i += 2 {
+		s.attrs[fmt.Sprint(attrs[i])] = attrs[i+1]
+	}
+}
+
+// RecordError marks the span as failed; safe to call on a nil span or nil error
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// End finishes the span and queues it for export; safe to call on a nil span
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if e := currentExporter(); e != nil {
+		e.enqueue(s)
+	}
+}
+
+// randomHex returns n random bytes hex-encoded, as OTLP JSON expects for trace and span IDs
+func randomHex(n int) string {
+	id := make([]byte, n)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
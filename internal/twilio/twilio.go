@@ -0,0 +1,93 @@
+// Package twilio is a small client for sending SMS through the Twilio Messages API.
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const apiURL = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// e164 matches a phone number in E.164 form, e.g. +15551234567
+var e164 = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+
+// Client sends SMS from one Twilio number with the account's credentials
+type Client struct {
+	accountSID string
+	authToken  string
+	from       string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Twilio client. from is a Twilio phone number in E.164 form
+// or a messaging service SID (MG...).
+func NewClient(accountSID, authToken, from string) *Client {
+	return &Client{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// NormalizeNumber returns a phone number in E.164 form, dropping the spaces, dashes,
+// dots, and parentheses people type. Numbers must include the country code.
+func NormalizeNumber(number string) (string, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(number))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+	if !e164.MatchString(normalized) {
+		return "", fmt.Errorf("%q is not a phone number with a country code, like +15551234567", number)
+	}
+	return normalized, nil
+}
+
+// Send texts body to a number in E.164 form
+func (c *Client) Send(to, body string) error {
+	form := url.Values{"To": {to}, "Body": {body}}
+	if strings.HasPrefix(c.from, "MG") {
+		form.Set("MessagingServiceSid", c.from)
+	} else {
+		form.Set("From", c.from)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, apiURL+c.accountSID+"/Messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.accountSID, c.authToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Errors carry Twilio's code, e.g. 21211 for an invalid number
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(raw, &body) == nil && body.Message != "" {
+			return fmt.Errorf("twilio responded %d: %s (code %d)", resp.StatusCode, body.Message, body.Code)
+		}
+		return fmt.Errorf("twilio responded %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package workoutfile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// fitEpoch is when FIT timestamps start counting seconds
+var fitEpoch = time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Global message numbers read from FIT files
+const (
+	fitSession = 18
+	fitRecord  = 20
+)
+
+// Invalid values that mark a FIT field as unset
+const (
+	fitInvalidUint32 = 0xFFFFFFFF
+	fitInvalidSint32 = 0x7FFFFFFF
+	fitInvalidEnum   = 0xFF
+)
+
+// fitSports names the FIT profile's sport values
+var fitSports = map[byte]string{
+	0: defaultType, 1: "running", 2: "cycling", 4: "fitness equipment", 5: "swimming",
+	6: "basketball", 7: "soccer", 8: "tennis", 10: "training", 11: "walking",
+	12: "cross country skiing", 13: "alpine skiing", 14: "snowboarding", 15: "rowing",
+	16: "mountaineering", 17: "hiking", 18: "multisport", 19: "paddling",
+}
+
+// fitSubSports names the FIT profile's sub-sport values that say more than the sport
+var fitSubSports = map[byte]string{
+	1: "treadmill", 3: "trail running", 5: "spin", 6: "indoor cycling", 8: "mountain biking",
+	14: "indoor rowing", 15: "elliptical", 16: "stair climbing", 17: "lap swimming",
+	18: "open water swimming", 19: "flexibility training", 20: "strength training",
+	26: "cardio training", 27: "indoor walking", 45: "indoor running", 58: "virtual activity",
+}
+
+// fitIndoorSubSports are sub-sports done in place, even when the watch had GPS on
+var fitIndoorSubSports = map[byte]bool{
+	1: true, 5: true, 6: true, 14: true, 15: true, 16: true, 17: true, 19: true, 20: true,
+	26: true, 27: true, 45: true, 58: true,
+}
+
+// errFITTruncated is returned for a FIT file that ends before its header says it does
+var errFITTruncated = errors.New("FIT file is truncated")
+
+// fitCRCTable is the FIT protocol's CRC-16 table, applied a nibble at a time
+var fitCRCTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// fitCRC returns the FIT CRC-16 of b. Over a block followed by its little-endian CRC
+// it's 0.
+func fitCRC(b []byte) uint16 {
+	var crc uint16
+	for _, c := range b {
+		tmp := fitCRCTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ fitCRCTable[c&0xF]
+		tmp = fitCRCTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ fitCRCTable[(c>>4)&0xF]
+	}
+	return crc
+}
+
+// fitField is one field of a definition message
+type fitField struct {
+	num, size byte
+}
+
+// fitDefinition describes the layout of a local message type's data messages
+type fitDefinition struct {
+	global    uint16
+	order     binary.ByteOrder
+	fields    []fitField
+	devBytes  int // Developer fields, which are skipped
+	totalSize int
+}
+
+// isFIT reports whether data starts with a FIT file header
+func isFIT(data []byte) bool {
+	return len(data) >= 12 && (data[0] == 12 || data[0] == 14) && string(data[8:12]) == ".FIT"
+}
+
+// fitSummary collects what's read from session messages, or from the records when an
+// activity file has no session
+type fitSummary struct {
+	start              time.Time
+	timerMs, elapsedMs uint64
+	centimeters        uint64
+	sport, subSport    byte
+	sessions           int
+	gps                bool
+
+	firstRecord, lastRecord time.Time
+	recordCentimeters       uint64
+}
+
+// parseFIT reads an activity file's sessions. Only the fields the bot needs are
+// decoded, so no FIT SDK is required. Files whose CRCs don't match are rejected
+// before anything is decoded.
+func parseFIT(data []byte) (*Workout, error) {
+	headerSize := int(data[0])
+	dataSize := int(binary.LittleEndian.Uint32(data[4:8]))
+	// The records are followed by the file's CRC
+	if len(data) < headerSize+dataSize+2 {
+		return nil, errFITTruncated
+	}
+	// A 14-byte header carries its own CRC, which encoders may leave as 0
+	if headerSize == 14 {
+		if crc := binary.LittleEndian.Uint16(data[12:14]); crc != 0 && crc != fitCRC(data[:12]) {
+			return nil, fmt.Errorf("FIT file header is corrupt")
+		}
+	}
+	if fitCRC(data[:headerSize+dataSize+2]) != 0 {
+		return nil, fmt.Errorf("FIT file is corrupt")
+	}
+	return decodeFIT(data[headerSize : headerSize+dataSize])
+}
+
+// decodeFIT reads the records of a FIT file: definition messages and the data
+// messages laid out by them
+func decodeFIT(records []byte) (*Workout, error) {
+	summary := fitSummary{sport: fitInvalidEnum, subSport: fitInvalidEnum}
+	definitions := make(map[byte]*fitDefinition)
+	for pos := 0; pos < len(records); {
+		header := records[pos]
+		pos++
+
+		// Compressed timestamp headers start data messages of local types 0-3
+		local := header & 0x0F
+		if header&0x80 != 0 {
+			local = (header >> 5) & 0x03
+		} else if header&0x40 != 0 {
+			definition, size, err := readFITDefinition(records[pos:], header&0x20 != 0)
+			if err != nil {
+				return nil, err
+			}
+			definitions[local] = definition
+			pos += size
+			continue
+		}
+
+		definition, ok := definitions[local]
+		if !ok {
+			return nil, fmt.Errorf("FIT data message uses undefined local type %d", local)
+		}
+		if pos+definition.totalSize > len(records) {
+			return nil, errFITTruncated
+		}
+		summary.add(definition, records[pos:pos+definition.totalSize])
+		pos += definition.totalSize
+	}
+
+	return summary.workout()
+}
+
+// readFITDefinition reads a definition message, returning it and its length
+func readFITDefinition(b []byte, developer bool) (*fitDefinition, int, error) {
+	if len(b) < 5 {
+		return nil, 0, errFITTruncated
+	}
+	definition := &fitDefinition{order: binary.LittleEndian}
+	if b[1] == 1 {
+		definition.order = binary.BigEndian
+	}
+	definition.global = definition.order.Uint16(b[2:4])
+	count := int(b[4])
+	pos := 5
+	if len(b) < pos+3*count {
+		return nil, 0, errFITTruncated
+	}
+	for i := 0; i < count; i++ {
+		field := fitField{num: b[pos], size: b[pos+1]}
+		definition.fields = append(definition.fields, field)
+		definition.totalSize += int(field.size)
+		pos += 3
+	}
+
+	if developer {
+		if len(b) < pos+1 {
+			return nil, 0, errFITTruncated
+		}
+		count := int(b[pos])
+		pos++
+		if len(b) < pos+3*count {
+			return nil, 0, errFITTruncated
+		}
+		for i := 0; i < count; i++ {
+			definition.devBytes += int(b[pos+1])
+			pos += 3
+		}
+		definition.totalSize += definition.devBytes
+	}
+	return definition, pos, nil
+}
+
+// add reads the fields the bot uses from one session or record message
+func (s *fitSummary) add(definition *fitDefinition, b []byte) {
+	if definition.global != fitSession && definition.global != fitRecord {
+		return
+	}
+	values := make(map[byte]uint32)
+	enums := make(map[byte]byte)
+	pos := 0
+	for _, field := range definition.fields {
+		switch field.size {
+		case 4:
+			values[field.num] = definition.order.Uint32(b[pos : pos+4])
+		case 1:
+			enums[field.num] = b[pos]
+		}
+		pos += int(field.size)
+	}
+	value := func(num byte) (uint32, bool) {
+		v, ok := values[num]
+		return v, ok && v != fitInvalidUint32
+	}
+
+	if definition.global == fitRecord {
+		if lat, ok := values[0]; ok && lat != fitInvalidSint32 {
+			s.gps = true
+		}
+		if distance, ok := value(5); ok {
+			s.recordCentimeters = uint64(distance)
+		}
+		if timestamp, ok := value(253); ok {
+			at := fitEpoch.Add(time.Duration(timestamp) * time.Second)
+			if s.firstRecord.IsZero() {
+				s.firstRecord = at
+			}
+			s.lastRecord = at
+		}
+		return
+	}
+
+	// Multisport activities have a session per leg
+	s.sessions++
+	if start, ok := value(2); ok && (s.start.IsZero() || fitEpoch.Add(time.Duration(start)*time.Second).Before(s.start)) {
+		s.start = fitEpoch.Add(time.Duration(start) * time.Second)
+	}
+	if lat, ok := values[3]; ok && lat != fitInvalidSint32 {
+		s.gps = true
+	}
+	if elapsed, ok := value(7); ok {
+		s.elapsedMs += uint64(elapsed)
+	}
+	if timer, ok := value(8); ok {
+		s.timerMs += uint64(timer)
+	}
+	if distance, ok := value(9); ok {
+		s.centimeters += uint64(distance)
+	}
+	if s.sessions == 1 {
+		if sport, ok := enums[5]; ok {
+			s.sport = sport
+		}
+		if subSport, ok := enums[6]; ok {
+			s.subSport = subSport
+		}
+	} else if sport, ok := enums[5]; ok && sport != s.sport {
+		s.sport, s.subSport = 18, fitInvalidEnum
+	}
+}
+
+// workout turns the summary into a workout, falling back to the records' timestamps
+// and distance when there was no session
+func (s *fitSummary) workout() (*Workout, error) {
+	workout := &Workout{Type: defaultType, Start: s.start}
+	milliseconds := s.timerMs
+	if milliseconds == 0 {
+		milliseconds = s.elapsedMs
+	}
+	workout.Meters = float64(s.centimeters) / 100
+
+	if s.sessions == 0 || workout.Start.IsZero() {
+		if s.firstRecord.IsZero() {
+			return nil, fmt.Errorf("FIT file has no recorded activity")
+		}
+		workout.Start = s.firstRecord
+		milliseconds = uint64(s.lastRecord.Sub(s.firstRecord).Milliseconds())
+	}
+	if workout.Meters == 0 {
+		workout.Meters = float64(s.recordCentimeters) / 100
+	}
+	workout.Minutes = int(milliseconds / 60000)
+
+	if name, ok := fitSubSports[s.subSport]; ok {
+		workout.Type = name
+	} else if name, ok := fitSports[s.sport]; ok {
+		workout.Type = name
+	}
+	workout.Outdoor = s.gps && !fitIndoorSubSports[s.subSport] && !indoorType(workout.Type)
+	return workout, nil
+}
@@ -0,0 +1,261 @@
+package workoutfile
+
+import (
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fitStart is when the test files' activities begin
+var fitStart = time.Date(2026, time.October, 18, 13, 0, 0, 0, time.UTC)
+
+// fitTime returns t as a FIT timestamp
+func fitTime(t time.Time) uint32 {
+	return uint32(t.Sub(fitEpoch) / time.Second)
+}
+
+// fitBuilder writes the records of a FIT file for tests
+type fitBuilder struct {
+	records []byte
+}
+
+// define writes a definition message. Fields are {number, size} pairs.
+func (b *fitBuilder) define(local byte, global uint16, bigEndian bool, fields ...[2]byte) {
+	var order binary.AppendByteOrder = binary.LittleEndian
+	architecture := byte(0)
+	if bigEndian {
+		order, architecture = binary.BigEndian, 1
+	}
+	b.records = append(b.records, 0x40|local, 0, architecture)
+	b.records = order.AppendUint16(b.records, global)
+	b.records = append(b.records, byte(len(fields)))
+	for _, field := range fields {
+		b.records = append(b.records, field[0], field[1], 0)
+	}
+}
+
+// data writes a data message of values already laid out by its definition
+func (b *fitBuilder) data(header byte, values ...[]byte) {
+	b.records = append(b.records, header)
+	for _, value := range values {
+		b.records = append(b.records, value...)
+	}
+}
+
+// file wraps the records in a 14-byte header and appends the file CRC
+func (b *fitBuilder) file() []byte {
+	header := []byte{14, 0x20, 0x54, 0x08}
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(b.records)))
+	header = append(header, ".FIT"...)
+	header = binary.LittleEndian.AppendUint16(header, fitCRC(header))
+	file := append(header, b.records...)
+	return binary.LittleEndian.AppendUint16(file, fitCRC(file))
+}
+
+func le32(v uint32) []byte { return binary.LittleEndian.AppendUint32(nil, v) }
+func be32(v uint32) []byte { return binary.BigEndian.AppendUint32(nil, v) }
+
+// sessionFields are the session fields the test files write
+var sessionFields = [][2]byte{{2, 4}, {3, 4}, {7, 4}, {8, 4}, {9, 4}, {5, 1}, {6, 1}}
+
+// session returns a session message's values: start, start latitude, elapsed and
+// timer time, distance, sport, and sub-sport
+func session(start time.Time, lat uint32, elapsed, timer time.Duration, centimeters uint32, sport, subSport byte) [][]byte {
+	return [][]byte{
+		le32(fitTime(start)), le32(lat), le32(uint32(elapsed.Milliseconds())),
+		le32(uint32(timer.Milliseconds())), le32(centimeters), {sport}, {subSport},
+	}
+}
+
+func readTestdata(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseFITFixture(t *testing.T) {
+	workout, err := Parse(readTestdata(t, "run.fit"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := Workout{Start: fitStart, Minutes: 30, Meters: 5000, Type: "running", Outdoor: true}
+	if *workout != want {
+		t.Errorf("Parse() = %+v, want %+v", *workout, want)
+	}
+}
+
+func TestParseFIT(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b *fitBuilder)
+		want  Workout
+	}{
+		{
+			name: "records only",
+			build: func(b *fitBuilder) {
+				b.define(0, fitRecord, false, [2]byte{253, 4}, [2]byte{0, 4}, [2]byte{5, 4})
+				for i := 0; i <= 20; i++ {
+					at := fitStart.Add(time.Duration(i) * time.Minute)
+					b.data(0, le32(fitTime(at)), le32(474000000), le32(uint32(i*10000)))
+				}
+			},
+			want: Workout{Start: fitStart, Minutes: 20, Meters: 2000, Type: defaultType, Outdoor: true},
+		},
+		{
+			name: "treadmill run with GPS on",
+			build: func(b *fitBuilder) {
+				b.define(0, fitSession, false, sessionFields...)
+				b.data(0, session(fitStart, 474000000, 46*time.Minute, 45*time.Minute, 800000, 1, 1)...)
+			},
+			want: Workout{Start: fitStart, Minutes: 45, Meters: 8000, Type: "treadmill"},
+		},
+		{
+			name: "big-endian session",
+			build: func(b *fitBuilder) {
+				b.define(0, fitSession, true, [2]byte{2, 4}, [2]byte{8, 4}, [2]byte{5, 1})
+				b.data(0, be32(fitTime(fitStart)), be32(50*60000), []byte{2})
+			},
+			want: Workout{Start: fitStart, Minutes: 50, Type: "cycling"},
+		},
+		{
+			name: "multisport sessions add up",
+			build: func(b *fitBuilder) {
+				b.define(0, fitSession, false, sessionFields...)
+				b.data(0, session(fitStart.Add(40*time.Minute), fitInvalidSint32, 0, 30*time.Minute, 1000000, 2, 0xFF)...)
+				b.data(0, session(fitStart, 474000000, 0, 40*time.Minute, 500000, 1, 0xFF)...)
+			},
+			want: Workout{Start: fitStart, Minutes: 70, Meters: 15000, Type: "multisport", Outdoor: true},
+		},
+		{
+			name: "elapsed time when there's no timer time",
+			build: func(b *fitBuilder) {
+				b.define(0, fitSession, false, sessionFields...)
+				b.data(0, session(fitStart, fitInvalidSint32, 35*time.Minute, 0, fitInvalidUint32, 10, 20)...)
+			},
+			want: Workout{Start: fitStart, Minutes: 35, Type: "strength training"},
+		},
+		{
+			name: "compressed timestamp headers",
+			build: func(b *fitBuilder) {
+				b.define(1, fitRecord, false, [2]byte{253, 4}, [2]byte{5, 4})
+				b.data(0x01, le32(fitTime(fitStart)), le32(0))
+				b.data(0x01, le32(fitTime(fitStart.Add(25*time.Minute))), le32(300000))
+				// Local type 1 with a time offset, which the reader doesn't need
+				b.data(0x80|1<<5|0x05, le32(fitInvalidUint32), le32(310000))
+			},
+			want: Workout{Start: fitStart, Minutes: 25, Meters: 3100, Type: defaultType},
+		},
+		{
+			name: "developer fields and other messages are skipped",
+			build: func(b *fitBuilder) {
+				b.define(0, 0, false, [2]byte{0, 1}, [2]byte{4, 4})
+				b.data(0, []byte{4}, le32(fitTime(fitStart)))
+				b.records = append(b.records, 0x61, 0, 0, fitSession, 0, 2, 2, 4, 0, 8, 4, 0, 1, 0, 3, 0)
+				b.data(1, le32(fitTime(fitStart)), le32(15*60000), []byte{0xAA, 0xBB, 0xCC})
+			},
+			want: Workout{Start: fitStart, Minutes: 15, Type: defaultType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b fitBuilder
+			tt.build(&b)
+			workout, err := Parse(b.file())
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if *workout != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *workout, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFITErrors(t *testing.T) {
+	valid := readTestdata(t, "run.fit")
+	corrupt := func(at int) []byte {
+		data := append([]byte(nil), valid...)
+		data[at] ^= 0x10
+		return data
+	}
+	withCRC := func(records []byte) []byte {
+		return (&fitBuilder{records: records}).file()
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "header only", data: valid[:14], want: "truncated"},
+		{name: "missing file CRC", data: valid[:len(valid)-2], want: "truncated"},
+		{name: "corrupt header", data: corrupt(2), want: "header is corrupt"},
+		{name: "corrupt record", data: corrupt(100), want: "corrupt"},
+		{name: "corrupt file CRC", data: corrupt(len(valid) - 1), want: "corrupt"},
+		{name: "no records", data: withCRC(nil), want: "no recorded activity"},
+		{name: "undefined local type", data: withCRC([]byte{0x03, 1, 2, 3}), want: "undefined local type 3"},
+		{name: "truncated definition", data: withCRC([]byte{0x40, 0, 0, 20, 0, 2, 253, 4}), want: "truncated"},
+		{name: "truncated developer fields", data: withCRC([]byte{0x60, 0, 0, 20, 0, 0, 2, 0, 1}), want: "truncated"},
+		{name: "truncated data message", data: withCRC([]byte{0x40, 0, 0, 20, 0, 1, 253, 4, 0x86, 0x00, 1, 2}), want: "truncated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFITTruncated(t *testing.T) {
+	valid := readTestdata(t, "run.fit")
+	for size := 0; size < len(valid); size++ {
+		if _, err := Parse(valid[:size]); err == nil {
+			t.Errorf("Parse() of the first %d bytes succeeded, want an error", size)
+		}
+	}
+}
+
+// TestDecodeFITCorrupt decodes corrupted and truncated records directly, since most
+// wouldn't get past the CRC check, to show a bad upload fails without a panic
+func TestDecodeFITCorrupt(t *testing.T) {
+	valid := readTestdata(t, "run.fit")
+	records := valid[14 : len(valid)-2]
+	decode := func(t *testing.T, b []byte) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("decodeFIT(% x) panicked: %v", b, r)
+			}
+		}()
+		decodeFIT(b)
+	}
+
+	for size := 0; size < len(records); size++ {
+		decode(t, records[:size])
+	}
+	for at := range records {
+		for _, value := range []byte{0x00, 0x40, 0x60, 0x80, 0xFF, records[at] ^ 0x01} {
+			b := append([]byte(nil), records...)
+			b[at] = value
+			decode(t, b)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(readTestdata(f, "run.fit"))
+	f.Add(readTestdata(f, "ride.gpx"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		workout, err := Parse(data)
+		if err == nil && workout == nil {
+			t.Error("Parse() returned neither a workout nor an error")
+		}
+	})
+}
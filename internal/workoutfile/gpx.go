@@ -0,0 +1,85 @@
+package workoutfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// earthRadiusMeters is the mean radius used for distances between track points
+const earthRadiusMeters = 6371008.8
+
+// gpxFile is the part of a GPX document that describes recorded tracks
+type gpxFile struct {
+	Tracks []struct {
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// gpxPoint is one <trkpt>
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time"`
+}
+
+// parseGPX reads the tracks of a GPX file as one workout: from the first timed point
+// to the last, with the distance along every segment
+func parseGPX(data []byte) (*Workout, error) {
+	var file gpxFile
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse GPX: %w", err)
+	}
+
+	workout := &Workout{Type: defaultType}
+	var first, last time.Time
+	var points int
+	for _, track := range file.Tracks {
+		if workoutType := strings.TrimSpace(track.Type); workoutType != "" && workout.Type == defaultType {
+			workout.Type = strings.ToLower(strings.ReplaceAll(workoutType, "_", " "))
+		}
+		for _, segment := range track.Segments {
+			for i, point := range segment.Points {
+				points++
+				if i > 0 {
+					workout.Meters += haversine(segment.Points[i-1], point)
+				}
+				at, err := time.Parse(time.RFC3339, strings.TrimSpace(point.Time))
+				if err != nil {
+					continue
+				}
+				if first.IsZero() || at.Before(first) {
+					first = at
+				}
+				if at.After(last) {
+					last = at
+				}
+			}
+		}
+	}
+	if first.IsZero() {
+		return nil, fmt.Errorf("GPX file has no timed track points")
+	}
+
+	workout.Start = first.UTC()
+	workout.Minutes = int(last.Sub(first).Minutes())
+	workout.Outdoor = points > 1 && workout.Meters > 0 && !indoorType(workout.Type)
+	return workout, nil
+}
+
+// haversine returns the great-circle distance between two points in meters
+func haversine(a, b gpxPoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
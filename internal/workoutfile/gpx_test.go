@@ -0,0 +1,108 @@
+package workoutfile
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGPXFixture(t *testing.T) {
+	workout, err := Parse(readTestdata(t, "ride.gpx"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	wantStart := time.Date(2026, time.October, 17, 15, 2, 11, 0, time.UTC)
+	if !workout.Start.Equal(wantStart) || workout.Minutes != 45 || workout.Type != "cycling" || !workout.Outdoor {
+		t.Errorf("Parse() = %+v, want a 45-minute outdoor ride starting %v", *workout, wantStart)
+	}
+	// Three steps of 0.009° of latitude north, about 1 km each
+	if math.Abs(workout.Meters-3002.3) > 1 {
+		t.Errorf("Meters = %.1f, want about 3002.3", workout.Meters)
+	}
+}
+
+func TestParseGPX(t *testing.T) {
+	tests := []struct {
+		name string
+		gpx  string
+		want Workout
+	}{
+		{
+			name: "no type, points out of order, zone offsets",
+			gpx: `<gpx><trk><trkseg>
+				<trkpt lat="0" lon="0"><time>2026-10-18T07:30:00-06:00</time></trkpt>
+				<trkpt lat="0" lon="0.01"><time>2026-10-18T13:00:00Z</time></trkpt>
+			</trkseg></trk></gpx>`,
+			want: Workout{Start: time.Date(2026, time.October, 18, 13, 0, 0, 0, time.UTC), Minutes: 30, Type: defaultType, Outdoor: true},
+		},
+		{
+			name: "indoor type with no movement",
+			gpx: `<gpx><trk><type>Virtual_Ride</type><trkseg>
+				<trkpt lat="0" lon="0"><time>2026-10-18T13:00:00Z</time></trkpt>
+				<trkpt lat="0" lon="0"><time>2026-10-18T14:00:00Z</time></trkpt>
+			</trkseg></trk></gpx>`,
+			want: Workout{Start: time.Date(2026, time.October, 18, 13, 0, 0, 0, time.UTC), Minutes: 60, Type: "virtual ride"},
+		},
+		{
+			name: "untimed points are skipped",
+			gpx: `<gpx><trk><trkseg>
+				<trkpt lat="0" lon="0"><time>2026-10-18T13:00:00Z</time></trkpt>
+				<trkpt lat="0" lon="0"><time>soon</time></trkpt>
+				<trkpt lat="0" lon="0"><time>2026-10-18T13:20:00Z</time></trkpt>
+			</trkseg></trk></gpx>`,
+			want: Workout{Start: time.Date(2026, time.October, 18, 13, 0, 0, 0, time.UTC), Minutes: 20, Type: defaultType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workout, err := Parse([]byte(tt.gpx))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			// Distance is covered by the fixture test
+			workout.Meters = 0
+			if *workout != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *workout, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGPXErrors(t *testing.T) {
+	fixture := string(readTestdata(t, "ride.gpx"))
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "empty", data: "", want: "not a GPX or FIT file"},
+		{name: "not a workout file", data: "%PDF-1.7", want: "not a GPX or FIT file"},
+		{name: "not XML", data: "<<<>>>", want: "failed to parse GPX"},
+		{name: "no tracks", data: `<gpx><wpt lat="0" lon="0"/></gpx>`, want: "no timed track points"},
+		{name: "no times", data: `<gpx><trk><trkseg><trkpt lat="0" lon="0"/></trkseg></trk></gpx>`, want: "no timed track points"},
+		{name: "truncated before the first time", data: fixture[:strings.Index(fixture, "<trkpt")], want: "GPX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGPXTruncated(t *testing.T) {
+	fixture := readTestdata(t, "ride.gpx")
+	for size := 0; size < len(fixture); size++ {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("Parse() of the first %d bytes panicked: %v", size, r)
+				}
+			}()
+			Parse(fixture[:size])
+		}()
+	}
+}
@@ -0,0 +1,51 @@
+// Package workoutfile reads one recorded workout from a file exported by a watch or
+// fitness app: GPX tracks, or Garmin's binary FIT activity files.
+package workoutfile
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Workout is the activity recorded in a file
+type Workout struct {
+	Start   time.Time // UTC
+	Minutes int       // Moving time when the file has it, otherwise elapsed time
+	Meters  float64   // Distance covered; 0 when the file has none
+	Type    string    // e.g. "running" or "indoor cycling"; "workout" when the file doesn't say
+	Outdoor bool      // Recorded with GPS and not on a treadmill, trainer, or in a virtual world
+}
+
+// defaultType is the workout type used when a file doesn't name the sport
+const defaultType = "workout"
+
+// Parse reads a .gpx or .fit file, detecting the format from its content
+func Parse(data []byte) (*Workout, error) {
+	if isFIT(data) {
+		return parseFIT(data)
+	}
+	if trimmed := bytes.TrimLeft(data, " \t\r\n\ufeff"); bytes.HasPrefix(trimmed, []byte("<")) {
+		return parseGPX(data)
+	}
+	return nil, fmt.Errorf("not a GPX or FIT file")
+}
+
+// Miles converts the distance to miles
+func (w *Workout) Miles() float64 {
+	return w.Meters / 1609.344
+}
+
+// indoorWords mark a named sport as done inside even when the file has GPS points
+var indoorWords = []string{"indoor", "treadmill", "trainer", "virtual", "elliptical", "pool"}
+
+// indoorType reports whether the workout type names an indoor activity
+func indoorType(workoutType string) bool {
+	for _, word := range indoorWords {
+		if strings.Contains(workoutType, word) {
+			return true
+		}
+	}
+	return false
+}
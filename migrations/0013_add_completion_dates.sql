@@ -0,0 +1,42 @@
+-- Migration: 0013_add_completion_dates
+-- Description: Stores the calendar date each feat was completed for, alongside challenge_day,
+-- and a per-user timezone used to derive the challenge day from that date
+
+BEGIN;
+
+-- Timezone used to decide which calendar day "today" is for a user
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'America/Denver';
+
+-- Completion date columns
+ALTER TABLE accountability_checkins ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE exercise_completions ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE diet_completions ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE water_completions ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE self_improvement_completions ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE finances_completions ADD COLUMN IF NOT EXISTS completion_date DATE;
+ALTER TABLE weigh_ins ADD COLUMN IF NOT EXISTS completion_date DATE;
+
+-- Backfill existing rows from each user's start date (day 1 = start date)
+UPDATE accountability_checkins t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE exercise_completions t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE diet_completions t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE water_completions t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE self_improvement_completions t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE finances_completions t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+UPDATE weigh_ins t SET completion_date = u.challenge_start_date + (t.challenge_day - 1)
+FROM users u WHERE u.user_id = t.user_id AND t.completion_date IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_accountability_checkins_user_date
+    ON accountability_checkins(user_id, completion_date);
+
+CREATE INDEX IF NOT EXISTS idx_weigh_ins_user_completion_date
+    ON weigh_ins(user_id, completion_date);
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Migration: 0014_add_progress_rollup
+-- Description: Per-user progress rollup maintained by the background leaderboard job,
+-- so /leaderboard and /summary read precomputed counts instead of aggregating every feat table
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_progress_rollup (
+    user_id VARCHAR(20) PRIMARY KEY,
+    days_completed INTEGER NOT NULL DEFAULT 0,     -- Distinct check-in days within the current challenge window
+    exercise_days INTEGER NOT NULL DEFAULT 0,      -- Days with a manually logged workout
+    water_goal_days INTEGER NOT NULL DEFAULT 0,    -- Days with 128oz+ of water
+    weigh_in_count INTEGER NOT NULL DEFAULT 0,
+    last_check_in_date DATE,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_progress_rollup_days_completed
+    ON user_progress_rollup(days_completed DESC);
+
+COMMIT;
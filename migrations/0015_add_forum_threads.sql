@@ -0,0 +1,14 @@
+-- Migration: 0015_add_forum_threads
+-- Description: Maps each participant to their personal progress post when the bot runs in forum-channel mode
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_forum_threads (
+    user_id VARCHAR(20) PRIMARY KEY,
+    channel_id VARCHAR(20) NOT NULL,   -- Forum channel the post lives in
+    thread_id VARCHAR(20) NOT NULL,    -- The participant's progress post (a thread)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+COMMIT;
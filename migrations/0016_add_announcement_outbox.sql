@@ -0,0 +1,25 @@
+-- Migration: 0016_add_announcement_outbox
+-- Description: Outbox for public announcements so they survive Discord errors and restarts;
+-- a dispatcher job delivers pending rows with retries
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS announcement_outbox (
+    announcement_id SERIAL PRIMARY KEY,
+    dedupe_key VARCHAR(200) NOT NULL UNIQUE,   -- e.g. 'challenge_started:<user_id>:<date>'; repeats are ignored
+    channel_id VARCHAR(20) NOT NULL,
+    content TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    message_id VARCHAR(20),
+    failed_at TIMESTAMP WITH TIME ZONE          -- Set when delivery is abandoned after max attempts
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcement_outbox_pending
+    ON announcement_outbox(next_attempt_at)
+    WHERE sent_at IS NULL AND failed_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Migration: 0017_add_feature_flags
+-- Description: Per-guild feature flags so admins can switch subsystems on and off without a deploy.
+-- A missing row means the feature's default applies.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_feature_flags (
+    guild_id VARCHAR(20) NOT NULL,
+    feature VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_by VARCHAR(20),                     -- User ID of the admin who last changed it
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, feature)
+);
+
+COMMIT;
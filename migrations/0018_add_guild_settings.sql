@@ -0,0 +1,19 @@
+-- Migration: 0018_add_guild_settings
+-- Description: Per-guild settings that override environment config and are applied live
+-- (no restart) when changed with /settings or directly in the database
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_settings (
+    guild_id VARCHAR(20) NOT NULL,
+    setting VARCHAR(50) NOT NULL,
+    value TEXT NOT NULL,
+    updated_by VARCHAR(20),                     -- User ID of the admin who last changed it
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, setting)
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_settings_updated_at
+    ON guild_settings(updated_at);
+
+COMMIT;
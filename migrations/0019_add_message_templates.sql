@@ -0,0 +1,16 @@
+-- Migration: 0019_add_message_templates
+-- Description: Per-guild overrides for key bot messages (check-in text, announcements),
+-- edited with /config template. A missing row means the built-in message applies.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_message_templates (
+    guild_id VARCHAR(20) NOT NULL,
+    template VARCHAR(50) NOT NULL,
+    body TEXT NOT NULL,                         -- Message text with {variable} placeholders
+    updated_by VARCHAR(20),                     -- User ID of the admin who last changed it
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, template)
+);
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration: 0020_add_user_preferences
+-- Description: Per-user preferences, starting with display units for weigh-ins and water.
+-- Amounts are always stored in pounds and ounces; a missing row means the defaults apply.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(20) PRIMARY KEY,
+    weight_unit VARCHAR(10) NOT NULL DEFAULT 'lbs',     -- lbs or kg
+    volume_unit VARCHAR(10) NOT NULL DEFAULT 'oz',      -- oz or l
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Migration: 0021_centralize_user_preferences
+-- Description: Move every per-user setting into user_preferences: time zone (from users),
+-- reminder opt-in and time, privacy in public posts, and how notifications are delivered.
+-- NULL timezone means the bot's default zone (BOT_TIMEZONE) applies.
+
+BEGIN;
+
+ALTER TABLE user_preferences
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64),
+ADD COLUMN IF NOT EXISTS reminders_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN IF NOT EXISTS reminder_time VARCHAR(5) NOT NULL DEFAULT '20:00',   -- HH:MM in the user's zone
+ADD COLUMN IF NOT EXISTS privacy VARCHAR(20) NOT NULL DEFAULT 'public',        -- public, anonymous, or hidden
+ADD COLUMN IF NOT EXISTS delivery VARCHAR(20) NOT NULL DEFAULT 'channel';      -- channel or dm
+
+-- Keep zones that differ from the old column default; everyone else follows BOT_TIMEZONE
+INSERT INTO user_preferences (user_id, timezone)
+SELECT user_id, timezone FROM users WHERE timezone <> 'America/Denver'
+ON CONFLICT (user_id) DO UPDATE SET timezone = EXCLUDED.timezone;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Migration: 0022_add_quiet_hours_and_reminders
+-- Description: Quiet hours during which the bot won't ping a member, and the last day
+-- each member was sent a check-in reminder so restarts don't send it twice.
+
+BEGIN;
+
+ALTER TABLE user_preferences
+ADD COLUMN IF NOT EXISTS quiet_hours VARCHAR(11),       -- HH:MM-HH:MM in the user's zone; NULL means none
+ADD COLUMN IF NOT EXISTS last_reminded_on DATE;         -- the user's calendar date of the last reminder
+
+COMMIT;
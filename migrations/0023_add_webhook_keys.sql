@@ -0,0 +1,14 @@
+-- Migration: 0023_add_webhook_keys
+-- Description: Per-user keys for the inbound logging webhook (iOS Shortcuts, Tasker).
+-- The key is kept so HMAC signatures can be verified; /shortcut key rotates it.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhook_keys (
+    user_id VARCHAR(20) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMIT;
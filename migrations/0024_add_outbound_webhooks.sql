@@ -0,0 +1,24 @@
+-- Migration: 0024_add_outbound_webhooks
+-- Description: Admin-registered webhook URLs that receive JSON payloads for challenge
+-- events. Deliveries go through announcement_outbox, so they get the same retries.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_webhooks (
+    webhook_id SERIAL PRIMARY KEY,
+    guild_id VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,             -- Signs each payload (X-Signature-256)
+    events TEXT NOT NULL DEFAULT '*',        -- Comma-separated event names, or * for all
+    created_by VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_webhooks_guild ON guild_webhooks(guild_id);
+
+-- An outbox row is either a Discord message (channel_id) or a webhook delivery (webhook_id)
+ALTER TABLE announcement_outbox ALTER COLUMN channel_id DROP NOT NULL;
+ALTER TABLE announcement_outbox
+ADD COLUMN IF NOT EXISTS webhook_id INTEGER REFERENCES guild_webhooks(webhook_id) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- Migration: 0025_add_oauth_connections
+-- Description: Linked fitness accounts (e.g. Strava) and pending OAuth sign-ins
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS oauth_connections (
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,           -- e.g. 'strava'
+    external_id VARCHAR(64) NOT NULL,        -- The user's account ID at the provider
+    access_token TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, provider),
+    UNIQUE (provider, external_id)
+);
+
+-- One row per started /connect, consumed by the OAuth callback
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Migration: 0026_add_daily_steps
+-- Description: Daily step counts synced from linked fitness accounts (e.g. Fitbit)
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS daily_steps (
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    steps INTEGER NOT NULL,
+    source VARCHAR(20) NOT NULL,             -- Provider the count came from, e.g. 'fitbit'
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, challenge_day),
+    CHECK (challenge_day >= 1),
+    CHECK (steps >= 0)
+);
+
+COMMIT;
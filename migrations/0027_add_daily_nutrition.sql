@@ -0,0 +1,18 @@
+-- Migration: 0027_add_daily_nutrition
+-- Description: Daily calorie totals from food logs in linked accounts (e.g. Fitbit, which MyFitnessPal can sync to)
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS daily_nutrition (
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    calories INTEGER NOT NULL,
+    source VARCHAR(20) NOT NULL,             -- Provider the total came from, e.g. 'fitbit'
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, challenge_day),
+    CHECK (challenge_day >= 1),
+    CHECK (calories >= 0)
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Migration: 0028_add_guild_sheets
+-- Description: Google Sheets each guild mirrors check-ins, feats, and weigh-ins into
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_sheets (
+    guild_id VARCHAR(20) PRIMARY KEY,
+    spreadsheet_id VARCHAR(100) NOT NULL,
+    created_by VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    synced_at TIMESTAMP WITH TIME ZONE,      -- Last successful sync
+    last_error TEXT                          -- Why the last sync failed, cleared on success
+);
+
+COMMIT;
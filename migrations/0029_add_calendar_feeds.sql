@@ -0,0 +1,14 @@
+-- Migration: 0029_add_calendar_feeds
+-- Description: Per-user secret tokens for the challenge calendar (iCal) feed
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id VARCHAR(20) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,       -- Secret part of the feed URL
+    locale VARCHAR(10) NOT NULL,             -- Language the event titles are written in
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_fetched_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMIT;
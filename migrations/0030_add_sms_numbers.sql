@@ -0,0 +1,16 @@
+-- Migration: 0030_add_sms_numbers
+-- Description: Phone numbers members verified for SMS check-in reminders
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sms_numbers (
+    user_id VARCHAR(20) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    phone_number VARCHAR(16) NOT NULL,         -- E.164, e.g. +15551234567
+    code_hash VARCHAR(64),                     -- SHA-256 of the pending verification code
+    code_sent_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,       -- Wrong guesses at the pending code
+    verified_at TIMESTAMP WITH TIME ZONE,      -- NULL until the member enters the code
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Migration: 0031_add_email_reports
+-- Description: Email addresses members verified for weekly report emails
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS email_reports (
+    user_id VARCHAR(20) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    address VARCHAR(254) NOT NULL,
+    locale VARCHAR(10) NOT NULL,               -- Language the reports are written in
+    code_hash VARCHAR(64),                     -- SHA-256 of the pending verification code
+    code_sent_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,       -- Wrong guesses at the pending code
+    verified_at TIMESTAMP WITH TIME ZONE,      -- NULL until the member enters the code
+    last_sent_at TIMESTAMP WITH TIME ZONE,     -- Last weekly report, so restarts don't send twice
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Migration: 0032_add_dashboard_sessions
+-- Description: Web dashboard sign-ins, one row per browser signed in with Discord
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS dashboard_sessions (
+    token_hash VARCHAR(64) PRIMARY KEY,        -- SHA-256 of the session cookie
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dashboard_sessions_user_id ON dashboard_sessions(user_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Migration: 0033_add_webhook_format
+-- Description: Payload format of each outbound webhook: the original nested JSON, or
+-- flat JSON with a stable id for no-code automation tools such as Zapier and Make
+
+BEGIN;
+
+ALTER TABLE guild_webhooks
+ADD COLUMN IF NOT EXISTS format VARCHAR(16) NOT NULL DEFAULT 'standard';  -- standard or flat
+
+COMMIT;
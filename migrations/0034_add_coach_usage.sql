@@ -0,0 +1,13 @@
+-- Migration: 0034_add_coach_usage
+-- Description: How many /coach replies each member got per day, for the daily cap
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS coach_usage (
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,                -- The member's calendar date
+    requests INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, usage_date)
+);
+
+COMMIT;
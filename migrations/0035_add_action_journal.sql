@@ -0,0 +1,19 @@
+-- Migration: 0035_add_action_journal
+-- Description: Recent logs each member made, with what they replaced, so /undo can reverse the latest
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS action_journal (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    action VARCHAR(32) NOT NULL,             -- e.g. 'water_add', 'exercise', 'check_in'
+    challenge_day INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,                 -- Each changed table's row for the day before the action; null where there was none
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    undone_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_journal_user_created
+    ON action_journal(user_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Migration: 0036_add_audit_log
+-- Description: Corrections made to members' challenge records after the fact, with who made them and what changed
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    actor_id VARCHAR(20) NOT NULL,           -- Who made the change; the member themself for /edit
+    action VARCHAR(32) NOT NULL,             -- e.g. 'edit_day'
+    challenge_day INTEGER,                   -- NULL when the change isn't for one day
+    details JSONB NOT NULL,                  -- Values before and after the change
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_created
+    ON audit_log(user_id, created_at DESC);
+
+COMMIT;
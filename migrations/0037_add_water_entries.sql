@@ -0,0 +1,28 @@
+-- Migration: 0037_add_water_entries
+-- Description: Each water log as its own entry, with water_completions kept as the day's total
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS water_entries (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    amount_ounces DECIMAL(6, 2) NOT NULL,    -- Negative for water taken off
+    source VARCHAR(32) NOT NULL,             -- e.g. 'manual', 'fitbit', 'edit', 'undo'
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (challenge_day >= 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_water_entries_user_day
+    ON water_entries(user_id, challenge_day);
+
+-- Totals logged before entries existed become one entry each
+INSERT INTO water_entries (user_id, challenge_day, completion_date, amount_ounces, source, logged_at)
+SELECT w.user_id, w.challenge_day, COALESCE(w.completion_date, u.challenge_start_date + (w.challenge_day - 1)),
+       w.amount_ounces, 'rollup', w.completed_at
+FROM water_completions w
+JOIN users u ON u.user_id = w.user_id
+WHERE w.amount_ounces > 0;
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Migration: 0038_add_meals
+-- Description: Meals members log for the diet feat, with optional photos kept in object storage
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS meals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    description TEXT NOT NULL,
+    photo_key TEXT,                          -- Object storage key; NULL without a photo
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (challenge_day >= 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_meals_user_day
+    ON meals(user_id, challenge_day);
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Migration: 0039_add_purchases
+-- Description: Purchases members log for the finances feat; non-necessities make the day non-compliant
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS purchases (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    category VARCHAR(32) NOT NULL,           -- e.g. 'groceries', 'dining', 'entertainment'
+    necessary BOOLEAN NOT NULL,
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (challenge_day >= 1),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchases_user_day
+    ON purchases(user_id, challenge_day);
+
+COMMIT;
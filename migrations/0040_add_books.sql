@@ -0,0 +1,43 @@
+-- Migration: 0040_add_books
+-- Description: Books members are reading for the reading feat, and the pages they log toward them
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS books (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    total_pages INTEGER NOT NULL,
+    pages_read INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'reading',  -- 'reading', 'finished', or 'shelved' when another book was started
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    finished_day INTEGER,                            -- Challenge day the last page was logged on
+    CHECK (total_pages > 0),
+    CHECK (pages_read >= 0),
+    CHECK (status IN ('reading', 'finished', 'shelved'))
+);
+
+-- One book in progress at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_user_reading
+    ON books(user_id) WHERE status = 'reading';
+
+CREATE INDEX IF NOT EXISTS idx_books_user_status
+    ON books(user_id, status);
+
+CREATE TABLE IF NOT EXISTS book_pages (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    book_id BIGINT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,
+    completion_date DATE NOT NULL,
+    pages INTEGER NOT NULL,
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (challenge_day >= 1),
+    CHECK (pages > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_pages_user_day
+    ON book_pages(user_id, challenge_day);
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Migration: 0041_add_self_improvement_sessions
+-- Description: Timed self-improvement sessions from /selfimprovement start and stop
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS self_improvement_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    challenge_day INTEGER NOT NULL,                  -- The day the session started, which its minutes count toward
+    completion_date DATE NOT NULL,
+    activity_type VARCHAR(100) NOT NULL DEFAULT 'general',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMP WITH TIME ZONE,
+    minutes INTEGER,                                 -- Set once the session is closed
+    status VARCHAR(16) NOT NULL DEFAULT 'running',   -- 'running', 'logged', or 'expired' when it ran too long to be real
+    CHECK (challenge_day >= 1),
+    CHECK (minutes IS NULL OR minutes >= 0),
+    CHECK (status IN ('running', 'logged', 'expired'))
+);
+
+-- One timer at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_self_improvement_sessions_user_running
+    ON self_improvement_sessions(user_id) WHERE status = 'running';
+
+CREATE INDEX IF NOT EXISTS idx_self_improvement_sessions_user_day
+    ON self_improvement_sessions(user_id, challenge_day);
+
+COMMIT;
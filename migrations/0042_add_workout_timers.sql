@@ -0,0 +1,26 @@
+-- Migration: 0042_add_workout_timers
+-- Description: Workout timers from /exercise start, with the channel message that shows their elapsed time
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS workout_timers (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(20) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    channel_id VARCHAR(20) NOT NULL,
+    message_id VARCHAR(20),                          -- Set once the timer message is posted
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMP WITH TIME ZONE,
+    minutes INTEGER,                                 -- Measured when stopped
+    status VARCHAR(16) NOT NULL DEFAULT 'running',   -- 'running', 'stopped', 'logged', 'cancelled', or 'expired'
+    CHECK (minutes IS NULL OR minutes >= 0),
+    CHECK (status IN ('running', 'stopped', 'logged', 'cancelled', 'expired'))
+);
+
+-- One timer at a time; a stopped one is waiting for its details
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_timers_user_open
+    ON workout_timers(user_id) WHERE status IN ('running', 'stopped');
+
+CREATE INDEX IF NOT EXISTS idx_workout_timers_running
+    ON workout_timers(status) WHERE status = 'running';
+
+COMMIT;